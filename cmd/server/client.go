@@ -0,0 +1,1129 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	grpcMD "google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+	backupService "github.com/go-tangra/go-tangra-backup/internal/service"
+)
+
+// clientCommand is one "client <group> <action>" operation. It registers its
+// flags on fs and returns the function that runs it once they are parsed.
+type clientCommand struct {
+	usage string
+	setup func(fs *flag.FlagSet) func(ctx context.Context, c backupV1.BackupOrchestratorServiceClient, format string) error
+}
+
+var clientCommands = map[string]clientCommand{
+	"backup create":       {"--target <module=endpoint> [--tenant N] [--description <text>] [--password <password>] [--include-secrets] [--compression-level N]", clientBackupCreate},
+	"backup list":         {"[--module <id>] [--tenant N]", clientBackupList},
+	"backup get":          {"--id <id>", clientBackupGet},
+	"backup download":     {"--id <id> [--password <password>] [--output <path>]", clientBackupDownload},
+	"backup restore":      {"--id <id> --target <module=endpoint> [--mode skip|overwrite] [--password <password>]", clientBackupRestore},
+	"backup delete":       {"--id <id>", clientBackupDelete},
+	"backup pin":          {"--id <id> [--reason <text>]", clientPin(false)},
+	"backup unpin":        {"--id <id>", clientUnpin(false)},
+	"backup usage":        {"--id <id>", clientBackupUsage},
+	"backup verify":       {"--id <id> [--password <password>]", clientVerify(false)},
+	"backup test-restore": {"--id <id> --target <module=endpoint> [--password <password>]", clientTestRestore(false)},
+	"backup repair":       {"--id <id> --module <id> [--tenant N] [--password <password>]", clientBackupRepair},
+	"backup replicate":    {"--id <id>", clientReplicate(false)},
+	"backup delete-many":  {"(--id <id>... | [--module <id>] [--tenant N] [--older-than <duration>] [--status <status>]) [--dry-run]", clientBackupDeleteMany},
+	"full create":         {"[--target <module=endpoint>]... [--selector <selector>] [--tenant N] [--description <text>] [--password <password>] [--include-secrets] [--compression-level N] [--idempotency-key <key>]", clientFullCreate},
+	"full list":           {"[--tenant N]", clientFullList},
+	"full get":            {"--id <id>", clientFullGet},
+	"full download":       {"--id <id> [--password <password>] [--output <path>]", clientFullDownload},
+	"full restore":        {"--id <id> --target <module=endpoint>... [--mode skip|overwrite] [--password <password>]", clientFullRestore},
+	"full bootstrap":      {"--id <id> [--profile <name>] [--target <module=endpoint>]... [--selector <selector>] [--depends <module=dep+dep>]... [--skip <module>]... [--allow-older] [--mode skip|overwrite] [--password <password>] [--dry-run]", clientFullBootstrap},
+	"full update":         {"--id <id> [--description <text>] [--label <key=value>]...", clientFullUpdate},
+	"full delete":         {"--id <id>", clientFullDelete},
+	"full pin":            {"--id <id> [--reason <text>]", clientPin(true)},
+	"full unpin":          {"--id <id>", clientUnpin(true)},
+	"full verify":         {"--id <id> [--password <password>]", clientVerify(true)},
+	"full test-restore":   {"--id <id> --target <module=endpoint> [--password <password>]", clientTestRestore(true)},
+	"full replicate":      {"--id <id>", clientReplicate(true)},
+	"quota usage":         {"[--tenant N] [--module <id>]", clientQuotaUsage},
+	"mirror status":       {"", clientMirrorStatus},
+	"federation status":   {"", clientFederationStatus},
+	"catalog export":      {"[--tenant N] [--as csv|json] [--output <path>]", clientCatalogExport},
+	"catalog import":      {"[--source <path>] [--catalog <file>] [--dry-run]", clientCatalogImport},
+	"repository export":   {"--output <path> --password <password> [--module <id>] [--tenant N] [--since <time>] [--until <time>]", clientRepositoryExport},
+	"repository import":   {"--source <path> --password <password> [--dry-run]", clientRepositoryImport},
+}
+
+func clientUsage() {
+	fmt.Fprintf(os.Stderr, "Usage: %s client <group> <action> [flags]\n\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "Call a running orchestrator over gRPC. Every command also takes the connection\nflags --addr, --ca-cert, --cert, --key, --server-name, --token and --timeout,\nand --format to choose between text and json output.\n\nCommands:\n")
+	names := make([]string, 0, len(clientCommands))
+	for name := range clientCommands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %s %s\n", name, clientCommands[name].usage)
+	}
+}
+
+func runClient() error {
+	if len(os.Args) < 4 {
+		clientUsage()
+		return fmt.Errorf("missing command")
+	}
+	name := os.Args[2] + " " + os.Args[3]
+	cmd, ok := clientCommands[name]
+	if !ok {
+		clientUsage()
+		return fmt.Errorf("unknown command %q", name)
+	}
+
+	fs := flag.NewFlagSet("client "+name, flag.ExitOnError)
+	addr := fs.String("addr", "localhost:10100", "orchestrator gRPC address")
+	caCert := fs.String("ca-cert", os.Getenv("BACKUP_CA_CERT_PATH"), "CA certificate (PEM) to verify the orchestrator; enables TLS")
+	certFile := fs.String("cert", os.Getenv("BACKUP_CLIENT_CERT_PATH"), "client certificate (PEM) for mTLS")
+	keyFile := fs.String("key", os.Getenv("BACKUP_CLIENT_KEY_PATH"), "client key (PEM) for mTLS")
+	serverName := fs.String("server-name", "", "expected server certificate name, if not the dialed host")
+	token := fs.String("token", os.Getenv("BACKUP_CLIENT_TOKEN"), "bearer token sent as the authorization header")
+	timeout := fs.Duration("timeout", 30*time.Minute, "deadline for the call")
+	format := formatFlag(fs)
+	run := cmd.setup(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s client %s %s\n\n", os.Args[0], name, cmd.usage)
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(os.Args[4:]); err != nil {
+		return err
+	}
+	if err := checkFormat(*format); err != nil {
+		return err
+	}
+
+	creds, err := clientCredentials(*caCert, *certFile, *keyFile, *serverName)
+	if err != nil {
+		return err
+	}
+	conn, err := grpc.NewClient(*addr,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(math.MaxInt32), grpc.MaxCallSendMsgSize(math.MaxInt32)),
+	)
+	if err != nil {
+		return fmt.Errorf("connect to %s: %w", *addr, err)
+	}
+	defer conn.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+	if *token != "" {
+		ctx = grpcMD.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*token)
+	}
+
+	return run(ctx, backupV1.NewBackupOrchestratorServiceClient(conn), *format)
+}
+
+// clientCredentials picks mTLS when a client certificate is given, TLS when
+// only a CA is, and plaintext otherwise.
+func clientCredentials(caCert, certFile, keyFile, serverName string) (credentials.TransportCredentials, error) {
+	if caCert == "" && certFile == "" {
+		return insecure.NewCredentials(), nil
+	}
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12, ServerName: serverName}
+	if caCert != "" {
+		pem, err := os.ReadFile(caCert)
+		if err != nil {
+			return nil, fmt.Errorf("read CA cert: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("parse CA certificate %s", caCert)
+		}
+		cfg.RootCAs = pool
+	}
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load client cert/key: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return credentials.NewTLS(cfg), nil
+}
+
+// targetList collects repeated --target module=endpoint flags.
+type targetList []*backupV1.ModuleTarget
+
+func (t *targetList) String() string {
+	parts := make([]string, len(*t))
+	for i, target := range *t {
+		parts[i] = target.ModuleId + "=" + target.GrpcEndpoint
+	}
+	return strings.Join(parts, ",")
+}
+
+func (t *targetList) Set(v string) error {
+	id, endpoint, ok := strings.Cut(v, "=")
+	if !ok || id == "" || endpoint == "" {
+		return fmt.Errorf("want module=endpoint, got %q", v)
+	}
+	*t = append(*t, &backupV1.ModuleTarget{ModuleId: id, GrpcEndpoint: endpoint})
+	return nil
+}
+
+// stringList collects a repeated string flag.
+type stringList []string
+
+func (l *stringList) String() string { return strings.Join(*l, ",") }
+
+func (l *stringList) Set(v string) error {
+	*l = append(*l, v)
+	return nil
+}
+
+// listFilter holds the server-side filters and ordering shared by the list
+// commands.
+type listFilter struct {
+	after, before     *timestamppb.Timestamp
+	status, createdBy string
+	encrypted         *bool
+	search            string
+	sortBy, sortOrder string
+}
+
+// listFilterFlags registers the filter and sort flags of the list commands.
+// parseTime reads the value of a time flag; empty is unset.
+func parseTime(name, v string) (*timestamppb.Timestamp, error) {
+	if v == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, v); err == nil {
+			return timestamppb.New(t), nil
+		}
+	}
+	return nil, fmt.Errorf("--%s: want RFC 3339 or YYYY-MM-DD, got %q", name, v)
+}
+
+func listFilterFlags(fs *flag.FlagSet) func() (listFilter, error) {
+	since := fs.String("since", "", "only backups created at or after this time (RFC 3339 or YYYY-MM-DD)")
+	until := fs.String("until", "", "only backups created before this time (RFC 3339 or YYYY-MM-DD)")
+	status := fs.String("status", "", "only backups with this status")
+	createdBy := fs.String("created-by", "", "only backups created by this user")
+	encrypted := fs.String("encrypted", "", "only encrypted (true) or unencrypted (false) backups")
+	search := fs.String("search", "", "case-insensitive match on ID, module and description")
+	sortBy := fs.String("sort", "", "sort by created_at (default), size, module_id or status")
+	sortOrder := fs.String("order", "", "sort order: asc or desc (default desc for created_at and size)")
+	return func() (listFilter, error) {
+		f := listFilter{status: *status, createdBy: *createdBy, search: *search, sortBy: *sortBy, sortOrder: *sortOrder}
+		var err error
+		if f.after, err = parseTime("since", *since); err != nil {
+			return f, err
+		}
+		if f.before, err = parseTime("until", *until); err != nil {
+			return f, err
+		}
+		switch *encrypted {
+		case "":
+		case "true", "false":
+			e := *encrypted == "true"
+			f.encrypted = &e
+		default:
+			return f, fmt.Errorf("--encrypted must be true or false")
+		}
+		return f, nil
+	}
+}
+
+// tenantFlag registers --tenant; the result is nil unless it was given.
+func tenantFlag(fs *flag.FlagSet) func() *uint32 {
+	tenant := fs.Int("tenant", -1, "tenant ID (default: all tenants)")
+	return func() *uint32 {
+		if *tenant < 0 {
+			return nil
+		}
+		t := uint32(*tenant)
+		return &t
+	}
+}
+
+func compressionLevelFlag(fs *flag.FlagSet) func() *int32 {
+	level := fs.Int("compression-level", -1, "compression level, 1-9 for gzip or 1-22 for zstd; 0 = algorithm default (default: server default)")
+	return func() *int32 {
+		if *level < 0 {
+			return nil
+		}
+		l := int32(*level)
+		return &l
+	}
+}
+
+func restoreModeFlag(fs *flag.FlagSet) func() (backupV1.RestoreMode, error) {
+	mode := fs.String("mode", "skip", "restore mode: skip or overwrite")
+	return func() (backupV1.RestoreMode, error) {
+		v, ok := backupV1.RestoreMode_value["RESTORE_MODE_"+strings.ToUpper(*mode)]
+		if !ok {
+			return 0, fmt.Errorf("--mode must be skip or overwrite")
+		}
+		return backupV1.RestoreMode(v), nil
+	}
+}
+
+func writeDownload(data []byte, filename, output, format string) error {
+	if output == "" {
+		output = filepath.Base(filename)
+	}
+	if err := os.WriteFile(output, data, 0o644); err != nil {
+		return fmt.Errorf("write output: %w", err)
+	}
+	if format == "json" {
+		return printJSON(map[string]any{"output": output, "bytes": len(data)})
+	}
+	fmt.Printf("Downloaded %s (%s)\n", output, backupService.FormatBytes(int64(len(data))))
+	return nil
+}
+
+func clientBackupCreate(fs *flag.FlagSet) func(context.Context, backupV1.BackupOrchestratorServiceClient, string) error {
+	var targets targetList
+	fs.Var(&targets, "target", "module to back up, as module=endpoint")
+	tenant := tenantFlag(fs)
+	description := fs.String("description", "", "backup description")
+	password := fs.String("password", "", "encrypt the backup with this password")
+	secrets := fs.Bool("include-secrets", false, "include secrets in the export")
+	level := compressionLevelFlag(fs)
+	return func(ctx context.Context, c backupV1.BackupOrchestratorServiceClient, format string) error {
+		if len(targets) != 1 {
+			return fmt.Errorf("exactly one --target is required")
+		}
+		resp, err := c.CreateModuleBackup(ctx, &backupV1.CreateModuleBackupRequest{
+			Target: targets[0], TenantId: tenant(), Description: *description, Password: *password, IncludeSecrets: *secrets,
+			CompressionLevel: level(),
+		})
+		if err != nil {
+			return err
+		}
+		return printMessage(resp.Backup)
+	}
+}
+
+func clientBackupList(fs *flag.FlagSet) func(context.Context, backupV1.BackupOrchestratorServiceClient, string) error {
+	moduleID := fs.String("module", "", "only list backups of this module")
+	tenant := tenantFlag(fs)
+	filter := listFilterFlags(fs)
+	return func(ctx context.Context, c backupV1.BackupOrchestratorServiceClient, format string) error {
+		f, err := filter()
+		if err != nil {
+			return err
+		}
+		// The server caps pages at 100 backups.
+		resp := &backupV1.ListBackupsResponse{}
+		for token := ""; ; {
+			r, err := c.ListBackups(ctx, &backupV1.ListBackupsRequest{
+				ModuleId: *moduleID, TenantId: tenant(), PageToken: token, PageSize: 100,
+				CreatedAfter: f.after, CreatedBefore: f.before, Status: f.status, CreatedBy: f.createdBy, Encrypted: f.encrypted, Search: f.search,
+				SortBy: f.sortBy, SortOrder: f.sortOrder,
+			})
+			if err != nil {
+				return err
+			}
+			resp.Backups, resp.Total = append(resp.Backups, r.Backups...), r.Total
+			if token = r.NextPageToken; token == "" {
+				break
+			}
+		}
+		if format == "json" {
+			return printMessage(resp)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "ID\tMODULE\tTENANT\tCREATED\tSTATUS\tSIZE\tENCRYPTED\n")
+		for _, b := range resp.Backups {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\t%t\n", b.Id, b.ModuleId, b.TenantId,
+				b.CreatedAt.AsTime().UTC().Format(time.RFC3339), b.Status, backupService.FormatBytes(b.SizeBytes), b.Encrypted)
+		}
+		return w.Flush()
+	}
+}
+
+func clientBackupGet(fs *flag.FlagSet) func(context.Context, backupV1.BackupOrchestratorServiceClient, string) error {
+	id := fs.String("id", "", "backup ID")
+	return func(ctx context.Context, c backupV1.BackupOrchestratorServiceClient, format string) error {
+		resp, err := c.GetBackup(ctx, &backupV1.GetBackupRequest{Id: *id})
+		if err != nil {
+			return err
+		}
+		return printMessage(resp.Backup)
+	}
+}
+
+func clientBackupDownload(fs *flag.FlagSet) func(context.Context, backupV1.BackupOrchestratorServiceClient, string) error {
+	id := fs.String("id", "", "backup ID")
+	password := fs.String("password", "", "password if the backup is encrypted")
+	output := fs.String("output", "", "output file path (default: the server's file name)")
+	return func(ctx context.Context, c backupV1.BackupOrchestratorServiceClient, format string) error {
+		resp, err := c.DownloadBackup(ctx, &backupV1.DownloadBackupRequest{Id: *id, Password: *password})
+		if err != nil {
+			return err
+		}
+		return writeDownload(resp.Data, resp.Filename, *output, format)
+	}
+}
+
+func clientBackupRestore(fs *flag.FlagSet) func(context.Context, backupV1.BackupOrchestratorServiceClient, string) error {
+	id := fs.String("id", "", "backup ID")
+	var targets targetList
+	fs.Var(&targets, "target", "module to restore into, as module=endpoint")
+	mode := restoreModeFlag(fs)
+	password := fs.String("password", "", "password if the backup is encrypted")
+	return func(ctx context.Context, c backupV1.BackupOrchestratorServiceClient, format string) error {
+		if len(targets) != 1 {
+			return fmt.Errorf("exactly one --target is required")
+		}
+		m, err := mode()
+		if err != nil {
+			return err
+		}
+		resp, err := c.RestoreModuleBackup(ctx, &backupV1.RestoreModuleBackupRequest{BackupId: *id, Target: targets[0], Mode: m, Password: *password})
+		if err != nil {
+			return err
+		}
+		if err := printMessage(resp); err != nil {
+			return err
+		}
+		if !resp.Success {
+			return fmt.Errorf("restore was not successful")
+		}
+		return nil
+	}
+}
+
+func clientBackupDelete(fs *flag.FlagSet) func(context.Context, backupV1.BackupOrchestratorServiceClient, string) error {
+	id := fs.String("id", "", "backup ID")
+	return func(ctx context.Context, c backupV1.BackupOrchestratorServiceClient, format string) error {
+		if _, err := c.DeleteBackup(ctx, &backupV1.DeleteBackupRequest{Id: *id}); err != nil {
+			return err
+		}
+		if format == "json" {
+			return printJSON(map[string]any{"deleted": *id})
+		}
+		fmt.Printf("Deleted backup %s\n", *id)
+		return nil
+	}
+}
+
+func clientBackupDeleteMany(fs *flag.FlagSet) func(context.Context, backupV1.BackupOrchestratorServiceClient, string) error {
+	var ids stringList
+	fs.Var(&ids, "id", "backup ID to delete (repeatable)")
+	moduleID := fs.String("module", "", "only delete backups of this module")
+	tenant := tenantFlag(fs)
+	olderThan := fs.Duration("older-than", 0, "only delete backups older than this, e.g. 720h")
+	status := fs.String("status", "", "only delete backups with this status, e.g. failed")
+	dryRun := fs.Bool("dry-run", false, "list what would be deleted without deleting it")
+	return func(ctx context.Context, c backupV1.BackupOrchestratorServiceClient, format string) error {
+		req := &backupV1.DeleteBackupsRequest{Ids: ids, ModuleId: *moduleID, TenantId: tenant(), Status: *status, DryRun: *dryRun}
+		if *olderThan > 0 {
+			req.CreatedBefore = timestamppb.New(time.Now().Add(-*olderThan))
+		}
+		resp, err := c.DeleteBackups(ctx, req)
+		if err != nil {
+			return err
+		}
+		if format == "json" {
+			if err := printMessage(resp); err != nil {
+				return err
+			}
+		} else {
+			for _, r := range resp.Results {
+				switch {
+				case r.Error != "":
+					fmt.Printf("FAIL    %s: %s\n", r.Id, r.Error)
+				case r.Deleted:
+					fmt.Printf("DELETED %s\n", r.Id)
+				default:
+					fmt.Printf("WOULD   %s\n", r.Id)
+				}
+			}
+			if *dryRun {
+				fmt.Printf("\n%d backups would be deleted, %d failed\n", len(resp.Results)-int(resp.Failed), resp.Failed)
+			} else {
+				fmt.Printf("\nDeleted %d backups, %d failed\n", resp.Deleted, resp.Failed)
+			}
+		}
+		if resp.Failed > 0 {
+			return fmt.Errorf("%d backups could not be deleted", resp.Failed)
+		}
+		return nil
+	}
+}
+
+func clientQuotaUsage(fs *flag.FlagSet) func(context.Context, backupV1.BackupOrchestratorServiceClient, string) error {
+	tenant := tenantFlag(fs)
+	moduleID := fs.String("module", "", "only this module")
+	return func(ctx context.Context, c backupV1.BackupOrchestratorServiceClient, format string) error {
+		resp, err := c.GetQuotaUsage(ctx, &backupV1.GetQuotaUsageRequest{TenantId: tenant(), ModuleId: *moduleID})
+		if err != nil {
+			return err
+		}
+		if format == "json" {
+			return printMessage(resp)
+		}
+		limit := func(n int64, s string) string {
+			if n <= 0 {
+				return "-"
+			}
+			return s
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "SCOPE\tID\tUSED\tMAX\tBACKUPS\tMAX BACKUPS\tEXCEEDED\n")
+		for _, u := range resp.Usage {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\t%t\n", u.Scope, u.Id, backupService.FormatBytes(u.UsedBytes),
+				limit(u.MaxBytes, backupService.FormatBytes(u.MaxBytes)), u.Backups, limit(int64(u.MaxBackups), fmt.Sprint(u.MaxBackups)), u.Exceeded)
+		}
+		return w.Flush()
+	}
+}
+
+func clientMirrorStatus(*flag.FlagSet) func(context.Context, backupV1.BackupOrchestratorServiceClient, string) error {
+	return func(ctx context.Context, c backupV1.BackupOrchestratorServiceClient, format string) error {
+		resp, err := c.GetMirrorStatus(ctx, &backupV1.GetMirrorStatusRequest{})
+		if err != nil {
+			return err
+		}
+		if format == "json" {
+			return printMessage(resp)
+		}
+		fmt.Printf("Mirror:   %s\n", resp.Target)
+		fmt.Printf("Backups:  %d mirrored, %d pending, %d failed\n", resp.Mirrored, resp.Pending, resp.Failed)
+		fmt.Printf("Lag:      %s\n", time.Duration(resp.LagSeconds)*time.Second)
+		if resp.LastMirroredAt != nil {
+			fmt.Printf("Last:     %s\n", resp.LastMirroredAt.AsTime().Local().Format(time.RFC3339))
+		}
+		for _, id := range resp.FailedBackupIds {
+			fmt.Printf("Failed:   %s\n", id)
+		}
+		return nil
+	}
+}
+
+func clientFederationStatus(*flag.FlagSet) func(context.Context, backupV1.BackupOrchestratorServiceClient, string) error {
+	return func(ctx context.Context, c backupV1.BackupOrchestratorServiceClient, format string) error {
+		resp, err := c.GetFederationStatus(ctx, &backupV1.GetFederationStatusRequest{})
+		if err != nil {
+			return err
+		}
+		if format == "json" {
+			return printMessage(resp)
+		}
+		when := func(ts *timestamppb.Timestamp) string {
+			if ts == nil {
+				return "never"
+			}
+			return ts.AsTime().Local().Format(time.RFC3339)
+		}
+		fmt.Printf("Peer:          %s\n", resp.Peer)
+		fmt.Printf("Last sync:     %s\n", when(resp.LastSyncAt))
+		fmt.Printf("Last success:  %s\n", when(resp.LastSuccessAt))
+		if resp.LastError != "" {
+			fmt.Printf("Error:         %s\n", resp.LastError)
+		}
+		fmt.Printf("Backups:       %d on the peer, %d pulled by the last sync\n", resp.PeerBackups, resp.Pulled)
+		for _, id := range resp.FailedBackupIds {
+			fmt.Printf("Failed:        %s\n", id)
+		}
+		return nil
+	}
+}
+
+func clientCatalogExport(fs *flag.FlagSet) func(context.Context, backupV1.BackupOrchestratorServiceClient, string) error {
+	tenant := tenantFlag(fs)
+	as := fs.String("as", "csv", "catalog format: csv or json")
+	output := fs.String("output", "", "write the catalog to this file (default: stdout)")
+	return func(ctx context.Context, c backupV1.BackupOrchestratorServiceClient, format string) error {
+		resp, err := c.ExportCatalog(ctx, &backupV1.ExportCatalogRequest{Format: *as, TenantId: tenant()})
+		if err != nil {
+			return err
+		}
+		if *output == "" {
+			_, err := os.Stdout.WriteString(resp.Document)
+			return err
+		}
+		if err := os.WriteFile(*output, []byte(resp.Document), 0o644); err != nil {
+			return fmt.Errorf("write output: %w", err)
+		}
+		if format == "json" {
+			return printJSON(map[string]any{"output": *output, "entries": len(resp.Entries)})
+		}
+		fmt.Printf("Wrote %d catalog entries to %s\n", len(resp.Entries), *output)
+		return nil
+	}
+}
+
+func clientCatalogImport(fs *flag.FlagSet) func(context.Context, backupV1.BackupOrchestratorServiceClient, string) error {
+	source := fs.String("source", "", "storage tree to import, as the orchestrator sees it (default: check its own storage in place)")
+	catalog := fs.String("catalog", "", "catalog export (CSV or JSON) listing the backups to import")
+	dryRun := fs.Bool("dry-run", false, "list what would be imported without importing it")
+	return func(ctx context.Context, c backupV1.BackupOrchestratorServiceClient, format string) error {
+		req := &backupV1.ImportCatalogRequest{SourcePath: *source, DryRun: *dryRun}
+		if *catalog != "" {
+			doc, err := os.ReadFile(*catalog)
+			if err != nil {
+				return fmt.Errorf("read catalog: %w", err)
+			}
+			req.Catalog = string(doc)
+		}
+		resp, err := c.ImportCatalog(ctx, req)
+		if err != nil {
+			return err
+		}
+		if format == "json" {
+			if err := printMessage(resp); err != nil {
+				return err
+			}
+		} else {
+			for _, r := range resp.Results {
+				line := fmt.Sprintf("%-12s %-6s %s", strings.ToUpper(r.Outcome), r.Kind, r.BackupId)
+				if r.Reason != "" {
+					line += ": " + r.Reason
+				}
+				fmt.Println(line)
+			}
+			fmt.Printf("\n%d imported, %d registered, %d already present, %d invalid, %d missing\n",
+				resp.Imported, resp.Registered, resp.Present, resp.Invalid, resp.Missing)
+		}
+		if resp.Invalid > 0 || resp.Missing > 0 {
+			return fmt.Errorf("%d backups could not be imported", resp.Invalid+resp.Missing)
+		}
+		return nil
+	}
+}
+
+func clientRepositoryExport(fs *flag.FlagSet) func(context.Context, backupV1.BackupOrchestratorServiceClient, string) error {
+	output := fs.String("output", "", "bundle file to write, as the orchestrator sees it")
+	password := fs.String("password", "", "password to encrypt the bundle with")
+	moduleID := fs.String("module", "", "only backups of this module")
+	tenant := tenantFlag(fs)
+	since := fs.String("since", "", "only backups created at or after this time (RFC 3339 or YYYY-MM-DD)")
+	until := fs.String("until", "", "only backups created before this time (RFC 3339 or YYYY-MM-DD)")
+	return func(ctx context.Context, c backupV1.BackupOrchestratorServiceClient, format string) error {
+		req := &backupV1.ExportRepositoryRequest{DestinationPath: *output, Password: *password, ModuleId: *moduleID, TenantId: tenant()}
+		var err error
+		if req.CreatedAfter, err = parseTime("since", *since); err != nil {
+			return err
+		}
+		if req.CreatedBefore, err = parseTime("until", *until); err != nil {
+			return err
+		}
+		resp, err := c.ExportRepository(ctx, req)
+		if err != nil {
+			return err
+		}
+		if format == "json" {
+			return printMessage(resp)
+		}
+		for _, w := range resp.Warnings {
+			fmt.Printf("WARN %s\n", w)
+		}
+		fmt.Printf("Wrote %s: %d module and %d full backups, %d bytes\n", resp.Path, resp.ModuleBackups, resp.FullBackups, resp.SizeBytes)
+		fmt.Printf("SHA-256:  %s\nSigned by %s\n", resp.Sha256, resp.Signer)
+		return nil
+	}
+}
+
+func clientRepositoryImport(fs *flag.FlagSet) func(context.Context, backupV1.BackupOrchestratorServiceClient, string) error {
+	source := fs.String("source", "", "bundle file to import, as the orchestrator sees it")
+	password := fs.String("password", "", "password the bundle was encrypted with")
+	dryRun := fs.Bool("dry-run", false, "list what would be imported without importing it")
+	return func(ctx context.Context, c backupV1.BackupOrchestratorServiceClient, format string) error {
+		resp, err := c.ImportRepository(ctx, &backupV1.ImportRepositoryRequest{SourcePath: *source, Password: *password, DryRun: *dryRun})
+		if err != nil {
+			return err
+		}
+		result := resp.GetCatalog()
+		if format == "json" {
+			if err := printMessage(resp); err != nil {
+				return err
+			}
+		} else {
+			trust := "not checked: BACKUP_BUNDLE_TRUSTED_KEYS is unset"
+			if resp.SignerTrusted {
+				trust = "trusted"
+			}
+			fmt.Printf("Bundle exported %s by %q, signed by %s (%s)\n\n",
+				resp.ExportedAt.AsTime().Local().Format(time.RFC3339), resp.ExportedBy, resp.Signer, trust)
+			for _, r := range result.GetResults() {
+				line := fmt.Sprintf("%-12s %-6s %s", strings.ToUpper(r.Outcome), r.Kind, r.BackupId)
+				if r.Reason != "" {
+					line += ": " + r.Reason
+				}
+				fmt.Println(line)
+			}
+			fmt.Printf("\n%d imported, %d already present, %d invalid, %d missing\n",
+				result.GetImported(), result.GetPresent(), result.GetInvalid(), result.GetMissing())
+		}
+		if n := result.GetInvalid() + result.GetMissing(); n > 0 {
+			return fmt.Errorf("%d backups could not be imported", n)
+		}
+		return nil
+	}
+}
+
+// clientPin and clientUnpin serve both the backup and full groups.
+func clientPin(full bool) func(*flag.FlagSet) func(context.Context, backupV1.BackupOrchestratorServiceClient, string) error {
+	return func(fs *flag.FlagSet) func(context.Context, backupV1.BackupOrchestratorServiceClient, string) error {
+		id := fs.String("id", "", "backup ID")
+		reason := fs.String("reason", "", "why the backup must be kept")
+		return func(ctx context.Context, c backupV1.BackupOrchestratorServiceClient, format string) error {
+			resp, err := c.PinBackup(ctx, &backupV1.PinBackupRequest{BackupId: *id, Full: full, Reason: *reason})
+			if err != nil {
+				return err
+			}
+			if format == "json" {
+				return printMessage(resp)
+			}
+			fmt.Printf("Pinned backup %s\n", *id)
+			return nil
+		}
+	}
+}
+
+func clientUnpin(full bool) func(*flag.FlagSet) func(context.Context, backupV1.BackupOrchestratorServiceClient, string) error {
+	return func(fs *flag.FlagSet) func(context.Context, backupV1.BackupOrchestratorServiceClient, string) error {
+		id := fs.String("id", "", "backup ID")
+		return func(ctx context.Context, c backupV1.BackupOrchestratorServiceClient, format string) error {
+			if _, err := c.UnpinBackup(ctx, &backupV1.UnpinBackupRequest{BackupId: *id, Full: full}); err != nil {
+				return err
+			}
+			if format == "json" {
+				return printJSON(map[string]any{"unpinned": *id})
+			}
+			fmt.Printf("Unpinned backup %s\n", *id)
+			return nil
+		}
+	}
+}
+
+func clientReplicate(full bool) func(*flag.FlagSet) func(context.Context, backupV1.BackupOrchestratorServiceClient, string) error {
+	return func(fs *flag.FlagSet) func(context.Context, backupV1.BackupOrchestratorServiceClient, string) error {
+		id := fs.String("id", "", "backup ID")
+		return func(ctx context.Context, c backupV1.BackupOrchestratorServiceClient, format string) error {
+			resp, err := c.ReplicateBackup(ctx, &backupV1.ReplicateBackupRequest{BackupId: *id, Full: full})
+			if err != nil {
+				return err
+			}
+			if format == "json" {
+				return printMessage(resp)
+			}
+			fmt.Printf("Queued backup %s for replication to %s\n", *id, resp.Replication.GetTarget())
+			return nil
+		}
+	}
+}
+
+// clientVerify serves both the backup and full groups. It fails when the
+// backup does, so scripts can check the exit status.
+func clientVerify(full bool) func(*flag.FlagSet) func(context.Context, backupV1.BackupOrchestratorServiceClient, string) error {
+	return func(fs *flag.FlagSet) func(context.Context, backupV1.BackupOrchestratorServiceClient, string) error {
+		id := fs.String("id", "", "backup ID")
+		password := fs.String("password", "", "password for encrypted backups")
+		return func(ctx context.Context, c backupV1.BackupOrchestratorServiceClient, format string) error {
+			resp, err := c.VerifyBackup(ctx, &backupV1.VerifyBackupRequest{BackupId: *id, Full: full, Password: *password})
+			if err != nil {
+				return err
+			}
+			if format == "json" {
+				if err := printMessage(resp); err != nil {
+					return err
+				}
+			} else {
+				for _, m := range resp.Modules {
+					fmt.Println(m.ModuleId)
+					for _, c := range m.Checks {
+						line := fmt.Sprintf("  [%s] %s", c.Status, c.Name)
+						if c.Detail != "" {
+							line += ": " + c.Detail
+						}
+						fmt.Println(line)
+					}
+				}
+				if resp.Verification.Ok {
+					fmt.Printf("\nResult: PASS (%d modules)\n", len(resp.Modules))
+				} else {
+					fmt.Printf("\nResult: FAIL (%s)\n", resp.Verification.Error)
+				}
+			}
+			if !resp.Verification.Ok {
+				return fmt.Errorf("verification failed")
+			}
+			return nil
+		}
+	}
+}
+
+// clientTestRestore serves both the backup and full groups. Like
+// clientVerify it fails when the test does, and also when the validation
+// tenant could not be cleaned up.
+func clientTestRestore(full bool) func(*flag.FlagSet) func(context.Context, backupV1.BackupOrchestratorServiceClient, string) error {
+	return func(fs *flag.FlagSet) func(context.Context, backupV1.BackupOrchestratorServiceClient, string) error {
+		id := fs.String("id", "", "backup ID")
+		var targets targetList
+		fs.Var(&targets, "target", "module to test the restore on, as module=endpoint")
+		password := fs.String("password", "", "password if the backup is encrypted")
+		return func(ctx context.Context, c backupV1.BackupOrchestratorServiceClient, format string) error {
+			if len(targets) != 1 {
+				return fmt.Errorf("exactly one --target is required")
+			}
+			resp, err := c.TestRestore(ctx, &backupV1.TestRestoreRequest{BackupId: *id, Full: full, Target: targets[0], Password: *password})
+			if err != nil {
+				return err
+			}
+			if err := printMessage(resp); err != nil {
+				return err
+			}
+			switch {
+			case !resp.Success:
+				return fmt.Errorf("test restore failed: %s", resp.Error)
+			case !resp.CleanedUp:
+				return fmt.Errorf("test restore succeeded, but validation tenant %d was not cleaned up: %s", resp.TenantId, resp.Error)
+			}
+			return nil
+		}
+	}
+}
+
+func clientBackupRepair(fs *flag.FlagSet) func(context.Context, backupV1.BackupOrchestratorServiceClient, string) error {
+	id := fs.String("id", "", "backup ID")
+	moduleID := fs.String("module", "", "module the data was exported from")
+	tenant := fs.Uint("tenant", 0, "tenant the data was exported for")
+	password := fs.String("password", "", "password if the backup is encrypted")
+	return func(ctx context.Context, c backupV1.BackupOrchestratorServiceClient, format string) error {
+		resp, err := c.RepairBackup(ctx, &backupV1.RepairBackupRequest{BackupId: *id, ModuleId: *moduleID, TenantId: uint32(*tenant), Password: *password})
+		if err != nil {
+			return err
+		}
+		return printMessage(resp.Backup)
+	}
+}
+
+func clientBackupUsage(fs *flag.FlagSet) func(context.Context, backupV1.BackupOrchestratorServiceClient, string) error {
+	id := fs.String("id", "", "module or full backup ID")
+	return func(ctx context.Context, c backupV1.BackupOrchestratorServiceClient, format string) error {
+		resp, err := c.GetBackupUsage(ctx, &backupV1.GetBackupUsageRequest{BackupId: *id})
+		if err != nil {
+			return err
+		}
+		if format == "json" {
+			return printMessage(resp)
+		}
+		if len(resp.Restores) == 0 {
+			fmt.Printf("Backup %s was never restored\n", *id)
+			return nil
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "STARTED\tMODULE\tTARGET\tMODE\tBY\tSOURCE\tSUCCESS\tERROR\n")
+		for _, r := range resp.Restores {
+			source := "local"
+			if r.Source != nil {
+				source = r.Source.Source
+				if r.Source.Source != "local" {
+					source += " " + r.Source.Target
+				}
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%t\t%s\n", r.StartedAt.AsTime().UTC().Format(time.RFC3339), r.ModuleId,
+				r.GrpcEndpoint, r.Mode, r.RestoredBy, source, r.Success, r.Error)
+		}
+		return w.Flush()
+	}
+}
+
+func clientFullCreate(fs *flag.FlagSet) func(context.Context, backupV1.BackupOrchestratorServiceClient, string) error {
+	var targets targetList
+	fs.Var(&targets, "target", "module to back up, as module=endpoint (repeatable)")
+	selector := fs.String("selector", "", "add registered modules matching this selector, e.g. tier=core")
+	tenant := tenantFlag(fs)
+	description := fs.String("description", "", "backup description")
+	password := fs.String("password", "", "encrypt the backup with this password")
+	secrets := fs.Bool("include-secrets", false, "include secrets in the export")
+	level := compressionLevelFlag(fs)
+	key := fs.String("idempotency-key", "", "resume an unfinished backup created with this key, or return the saved one")
+	return func(ctx context.Context, c backupV1.BackupOrchestratorServiceClient, format string) error {
+		if len(targets) == 0 && *selector == "" {
+			return fmt.Errorf("--target or --selector is required")
+		}
+		resp, err := c.CreateFullBackup(ctx, &backupV1.CreateFullBackupRequest{
+			Targets: targets, TargetSelector: *selector, TenantId: tenant(),
+			Description: *description, Password: *password, IncludeSecrets: *secrets,
+			CompressionLevel: level(), IdempotencyKey: *key,
+		})
+		if err != nil {
+			return err
+		}
+		return printMessage(resp.Backup)
+	}
+}
+
+func clientFullList(fs *flag.FlagSet) func(context.Context, backupV1.BackupOrchestratorServiceClient, string) error {
+	tenant := tenantFlag(fs)
+	filter := listFilterFlags(fs)
+	return func(ctx context.Context, c backupV1.BackupOrchestratorServiceClient, format string) error {
+		f, err := filter()
+		if err != nil {
+			return err
+		}
+		resp := &backupV1.ListFullBackupsResponse{}
+		for token := ""; ; {
+			r, err := c.ListFullBackups(ctx, &backupV1.ListFullBackupsRequest{
+				TenantId: tenant(), PageToken: token, PageSize: 100,
+				CreatedAfter: f.after, CreatedBefore: f.before, Status: f.status, CreatedBy: f.createdBy, Encrypted: f.encrypted, Search: f.search,
+				SortBy: f.sortBy, SortOrder: f.sortOrder,
+			})
+			if err != nil {
+				return err
+			}
+			resp.Backups, resp.Total = append(resp.Backups, r.Backups...), r.Total
+			if token = r.NextPageToken; token == "" {
+				break
+			}
+		}
+		if format == "json" {
+			return printMessage(resp)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "ID\tMODULES\tTENANT\tCREATED\tSTATUS\tVALIDATION\tSIZE\tENCRYPTED\n")
+		for _, b := range resp.Backups {
+			fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\t%s\t%s\t%t\n", b.Id, len(b.ModuleBackups), b.TenantId,
+				b.CreatedAt.AsTime().UTC().Format(time.RFC3339), b.Status, b.ValidationStatus, backupService.FormatBytes(b.TotalSizeBytes), b.Encrypted)
+		}
+		return w.Flush()
+	}
+}
+
+func clientFullGet(fs *flag.FlagSet) func(context.Context, backupV1.BackupOrchestratorServiceClient, string) error {
+	id := fs.String("id", "", "full backup ID")
+	return func(ctx context.Context, c backupV1.BackupOrchestratorServiceClient, format string) error {
+		resp, err := c.GetFullBackup(ctx, &backupV1.GetFullBackupRequest{Id: *id})
+		if err != nil {
+			return err
+		}
+		return printMessage(resp.Backup)
+	}
+}
+
+func clientFullDownload(fs *flag.FlagSet) func(context.Context, backupV1.BackupOrchestratorServiceClient, string) error {
+	id := fs.String("id", "", "full backup ID")
+	password := fs.String("password", "", "password if the backup is encrypted")
+	output := fs.String("output", "", "output file path (default: the server's file name)")
+	return func(ctx context.Context, c backupV1.BackupOrchestratorServiceClient, format string) error {
+		resp, err := c.DownloadFullBackup(ctx, &backupV1.DownloadFullBackupRequest{Id: *id, Password: *password})
+		if err != nil {
+			return err
+		}
+		return writeDownload(resp.Data, resp.Filename, *output, format)
+	}
+}
+
+func clientFullRestore(fs *flag.FlagSet) func(context.Context, backupV1.BackupOrchestratorServiceClient, string) error {
+	id := fs.String("id", "", "full backup ID")
+	var targets targetList
+	fs.Var(&targets, "target", "module to restore into, as module=endpoint (repeatable)")
+	mode := restoreModeFlag(fs)
+	password := fs.String("password", "", "password if the backup is encrypted")
+	return func(ctx context.Context, c backupV1.BackupOrchestratorServiceClient, format string) error {
+		if len(targets) == 0 {
+			return fmt.Errorf("at least one --target is required")
+		}
+		m, err := mode()
+		if err != nil {
+			return err
+		}
+		resp, err := c.RestoreFullBackup(ctx, &backupV1.RestoreFullBackupRequest{BackupId: *id, Targets: targets, Mode: m, Password: *password})
+		if err != nil {
+			return err
+		}
+		if err := printMessage(resp); err != nil {
+			return err
+		}
+		if !resp.Success {
+			return fmt.Errorf("restore was not successful")
+		}
+		return nil
+	}
+}
+
+func clientFullBootstrap(fs *flag.FlagSet) func(context.Context, backupV1.BackupOrchestratorServiceClient, string) error {
+	id := fs.String("id", "", "full backup ID")
+	profileName := fs.String("profile", "", "environment profile on the orchestrator (BACKUP_BOOTSTRAP_PROFILE_DIR)")
+	var targets targetList
+	fs.Var(&targets, "target", "module to restore into, as module=endpoint (repeatable)")
+	selector := fs.String("selector", "", "also restore into the registered modules matching this label selector")
+	var depends, skip stringList
+	fs.Var(&depends, "depends", "restore order, as module=dep+dep (repeatable)")
+	fs.Var(&skip, "skip", "module of the backup to leave out (repeatable)")
+	allowOlder := fs.Bool("allow-older", false, "restore into modules older than the ones backed up")
+	mode := restoreModeFlag(fs)
+	password := fs.String("password", "", "password if the backup is encrypted")
+	dryRun := fs.Bool("dry-run", false, "only plan and run the pre-flight checks")
+	return func(ctx context.Context, c backupV1.BackupOrchestratorServiceClient, format string) error {
+		m, err := mode()
+		if err != nil {
+			return err
+		}
+		profile := &backupV1.BootstrapProfile{Targets: targets, TargetSelector: *selector, SkipModules: skip, AllowOlderModules: *allowOlder}
+		for _, d := range depends {
+			module, deps, ok := strings.Cut(d, "=")
+			if !ok || module == "" || deps == "" {
+				return fmt.Errorf("invalid --depends %q: expected module=dep+dep", d)
+			}
+			profile.Dependencies = append(profile.Dependencies, &backupV1.ModuleDependency{ModuleId: module, DependsOn: strings.Split(deps, "+")})
+		}
+		stream, err := c.BootstrapRestore(ctx, &backupV1.BootstrapRestoreRequest{
+			BackupId: *id, Password: *password, Mode: m, ProfileName: *profileName, Profile: profile, DryRun: *dryRun,
+		})
+		if err != nil {
+			return err
+		}
+
+		// JSON output is one document: the events, printed once the stream ends.
+		var events []json.RawMessage
+		var result *backupV1.BootstrapResult
+		for {
+			ev, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				if len(events) > 0 {
+					printJSON(map[string]any{"events": events})
+				}
+				return err
+			}
+			if r := ev.GetResult(); r != nil {
+				result = r
+			}
+			if format != "json" {
+				printBootstrapEvent(ev)
+				continue
+			}
+			raw, err := protoJSON(ev)
+			if err != nil {
+				return err
+			}
+			events = append(events, raw)
+		}
+		if format == "json" {
+			if err := printJSON(map[string]any{"events": events}); err != nil {
+				return err
+			}
+		}
+		if result == nil || !result.Success {
+			return fmt.Errorf("bootstrap was not successful")
+		}
+		return nil
+	}
+}
+
+func printBootstrapEvent(ev *backupV1.BootstrapRestoreEvent) {
+	fmt.Printf("%s %-9s %s\n", ev.Time.AsTime().Local().Format("15:04:05"), ev.Phase, ev.Message)
+	if m := ev.GetModule(); m != nil {
+		for _, p := range m.Problems {
+			fmt.Printf("%18s %s\n", "", p)
+		}
+		for _, w := range m.GetResult().GetWarnings() {
+			fmt.Printf("%18s warning: %s\n", "", w)
+		}
+	}
+	plan := ev.GetPlan()
+	if plan == nil {
+		return
+	}
+	dash := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+		return s
+	}
+	fmt.Println()
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "WAVE\tMODULE\tSTATE\tENDPOINT\tBACKED UP\tRUNNING\tNOTES")
+	for _, m := range plan.Modules {
+		wave := strconv.Itoa(int(m.Wave) + 1)
+		if m.State == "skipped" {
+			wave = "-"
+		}
+		notes := append(slices.Clone(m.Problems), m.Warnings...)
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", wave, m.ModuleId, strings.ToUpper(m.State), dash(m.GrpcEndpoint),
+			dash(m.BackupVersion), dash(m.TargetVersion), strings.Join(notes, "; "))
+	}
+	tw.Flush()
+	for _, p := range plan.Problems {
+		fmt.Printf("problem: %s\n", p)
+	}
+	for _, w := range plan.Warnings {
+		fmt.Printf("warning: %s\n", w)
+	}
+	fmt.Println()
+}
+
+func clientFullUpdate(fs *flag.FlagSet) func(context.Context, backupV1.BackupOrchestratorServiceClient, string) error {
+	id := fs.String("id", "", "full backup ID")
+	description := fs.String("description", "", "new description")
+	var labels stringList
+	fs.Var(&labels, "label", "label to set as key=value; an empty value removes it (repeatable)")
+	return func(ctx context.Context, c backupV1.BackupOrchestratorServiceClient, format string) error {
+		req := &backupV1.UpdateFullBackupRequest{Id: *id}
+		fs.Visit(func(f *flag.Flag) {
+			if f.Name == "description" {
+				req.Description = description
+			}
+		})
+		if len(labels) > 0 {
+			req.Labels = make(map[string]string, len(labels))
+			for _, l := range labels {
+				k, v, ok := strings.Cut(l, "=")
+				if !ok {
+					return fmt.Errorf("invalid --label %q: expected key=value", l)
+				}
+				req.Labels[k] = v
+			}
+		}
+		resp, err := c.UpdateFullBackup(ctx, req)
+		if err != nil {
+			return err
+		}
+		return printMessage(resp.Backup)
+	}
+}
+
+func clientFullDelete(fs *flag.FlagSet) func(context.Context, backupV1.BackupOrchestratorServiceClient, string) error {
+	id := fs.String("id", "", "full backup ID")
+	return func(ctx context.Context, c backupV1.BackupOrchestratorServiceClient, format string) error {
+		if _, err := c.DeleteFullBackup(ctx, &backupV1.DeleteFullBackupRequest{Id: *id}); err != nil {
+			return err
+		}
+		if format == "json" {
+			return printJSON(map[string]any{"deleted": *id})
+		}
+		fmt.Printf("Deleted full backup %s\n", *id)
+		return nil
+	}
+}
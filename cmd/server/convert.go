@@ -0,0 +1,118 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	backupService "github.com/go-tangra/go-tangra-backup/internal/service"
+)
+
+func runConvert() error {
+	fs := flag.NewFlagSet("convert", flag.ExitOnError)
+	path := fs.String("path", "", "backup directory, or a directory to search for backups (e.g. the storage path)")
+	password := fs.String("password", "", "password for encrypted backups; they are re-encrypted with it")
+	compression := fs.String("compression", backupService.CompressionGzip, "target compression: gzip or zstd")
+	level := fs.Int("level", 0, "compression level, 1-9 for gzip or 1-22 for zstd (default: smallest output)")
+	payload := fs.String("payload-format", "json", "target payload format (only json is supported so far)")
+	format := formatFlag(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s convert --path <dir> [--compression gzip|zstd] [--level N] [--password <password>] [--format text|json]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Recompress stored backups in place and update their metadata. Stop the\norchestrator or make sure it is not writing to the same backups while this runs.\n\n")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		return err
+	}
+	if err := checkFormat(*format); err != nil {
+		return err
+	}
+	if *path == "" {
+		fs.Usage()
+		return fmt.Errorf("--path is required")
+	}
+	// The service reads back gzip and zstd; converting to anything else
+	// would produce backups it cannot restore.
+	switch *compression {
+	case backupService.CompressionGzip, backupService.CompressionZstd:
+	default:
+		return fmt.Errorf("compression %q is not supported: use gzip or zstd", *compression)
+	}
+	minLevel, maxLevel := backupService.CompressionLevelRange(*compression)
+	if *payload != "json" {
+		return fmt.Errorf("payload format %q is not supported: modules export JSON", *payload)
+	}
+	if *level == 0 {
+		*level = maxLevel
+	}
+	if *level < minLevel || *level > maxLevel {
+		return fmt.Errorf("--level must be between %d and %d for %s", minLevel, maxLevel, *compression)
+	}
+
+	dirs, err := findBackupDirs(*path)
+	if err != nil {
+		return err
+	}
+
+	var total int64
+	for _, dir := range dirs {
+		total += backupDataSize(dir)
+	}
+	p := newProgress("Converting", total, *format)
+
+	var results []backupResult
+	var totalBefore, totalAfter int64
+	converted, failed := 0, 0
+	for _, dir := range dirs {
+		r := backupResult{Path: dir, Status: "OK"}
+		before, after, err := backupService.RecompressBackup(dir, *password, *compression, *level)
+		if err != nil {
+			r.Status, r.Detail = "FAIL", err.Error()
+			failed++
+			p.Add(backupDataSize(dir))
+		} else {
+			r.Detail = fmt.Sprintf("%s -> %s", backupService.FormatBytes(before), backupService.FormatBytes(after))
+			r.BytesBefore, r.BytesAfter = before, after
+			totalBefore += before
+			totalAfter += after
+			converted++
+			p.Add(before)
+		}
+		results = append(results, r)
+	}
+	p.Done()
+
+	if *format == "json" {
+		if err := printJSON(map[string]any{
+			"converted": converted, "failed": failed, "bytesBefore": totalBefore, "bytesAfter": totalAfter, "backups": results,
+		}); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range results {
+			r.print()
+		}
+		fmt.Printf("\nConverted %d of %d backups: %s -> %s\n", converted, len(dirs),
+			backupService.FormatBytes(totalBefore), backupService.FormatBytes(totalAfter))
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d backups could not be converted", failed)
+	}
+	return nil
+}
+
+// backupDataSize is the stored size of a backup's data files, 0 if unknown.
+func backupDataSize(dir string) int64 {
+	module, full, err := backupService.ReadBackupMetadata(dir)
+	if err != nil {
+		return 0
+	}
+	var size int64
+	for _, f := range backupService.BackupDataFiles(dir, module, full) {
+		if st, err := os.Stat(f.Path); err == nil {
+			size += st.Size()
+		}
+	}
+	return size
+}
@@ -0,0 +1,299 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+	backupService "github.com/go-tangra/go-tangra-backup/internal/service"
+)
+
+// doctorFinding is one environment check. Hint says what to do about a
+// WARN or FAIL.
+type doctorFinding struct {
+	Area   string `json:"area"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	Hint   string `json:"hint,omitempty"`
+}
+
+type doctor struct {
+	findings []doctorFinding
+}
+
+func (d *doctor) pass(area, name, detail string) {
+	d.findings = append(d.findings, doctorFinding{area, name, "PASS", detail, ""})
+}
+
+func (d *doctor) warn(area, name, detail, hint string) {
+	d.findings = append(d.findings, doctorFinding{area, name, "WARN", detail, hint})
+}
+
+func (d *doctor) fail(area, name, detail, hint string) {
+	d.findings = append(d.findings, doctorFinding{area, name, "FAIL", detail, hint})
+}
+
+func (d *doctor) skip(area, name, detail string) {
+	d.findings = append(d.findings, doctorFinding{area, name, "SKIP", detail, ""})
+}
+
+func runDoctor() error {
+	fs := flag.NewFlagSet("doctor", flag.ExitOnError)
+	path := fs.String("path", backupService.StoragePath(), "backup storage path")
+	minFree := fs.Int64("min-free-gb", 10, "warn when the storage path has less free space than this, in GiB")
+	certDays := fs.Int("cert-warn-days", 14, "warn when a certificate expires within this many days")
+	timeout := fs.Duration("timeout", 5*time.Second, "timeout for each connectivity check")
+	var targets targetList
+	fs.Var(&targets, "target", "module endpoint to probe, as module=endpoint (repeatable)")
+	format := formatFlag(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s doctor [--path <dir>] [--target module=endpoint ...] [--format text|json]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Check storage, certificates, dependent services and module endpoints using\nthe same environment as the service, and say what to fix.\n\n")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		return err
+	}
+	if err := checkFormat(*format); err != nil {
+		return err
+	}
+
+	d := &doctor{}
+	d.checkStorage(*path, *minFree<<30)
+	d.checkCerts(time.Duration(*certDays) * 24 * time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout*time.Duration(len(targets)+4))
+	defer cancel()
+	client := backupService.NewOfflineModuleClient()
+	d.checkServices(ctx, client, *timeout)
+	d.checkTargets(ctx, client, targets, *timeout)
+
+	failed, warned := 0, 0
+	for _, f := range d.findings {
+		switch f.Status {
+		case "FAIL":
+			failed++
+		case "WARN":
+			warned++
+		}
+	}
+
+	if *format == "json" {
+		if err := printJSON(map[string]any{"failed": failed, "warnings": warned, "findings": d.findings}); err != nil {
+			return err
+		}
+	} else {
+		area := ""
+		for _, f := range d.findings {
+			if f.Area != area {
+				if area != "" {
+					fmt.Println()
+				}
+				area = f.Area
+				fmt.Printf("%s\n", area)
+			}
+			fmt.Printf("  [%s] %s", f.Status, f.Name)
+			if f.Detail != "" {
+				fmt.Printf(": %s", f.Detail)
+			}
+			fmt.Println()
+			if f.Hint != "" {
+				fmt.Printf("         -> %s\n", f.Hint)
+			}
+		}
+		fmt.Printf("\nResult: %d failed, %d warnings\n", failed, warned)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d checks failed", failed)
+	}
+	return nil
+}
+
+func (d *doctor) checkStorage(path string, minFree int64) {
+	const area = "Storage"
+	st, err := os.Stat(path)
+	if err != nil {
+		d.fail(area, "path", err.Error(), "create the directory or point BACKUP_STORAGE_PATH (or --path) at the backup volume")
+		return
+	}
+	if !st.IsDir() {
+		d.fail(area, "path", path+" is not a directory", "point BACKUP_STORAGE_PATH at the backup volume")
+		return
+	}
+	d.pass(area, "path", path)
+
+	probe := filepath.Join(path, fmt.Sprintf(".doctor-%d", os.Getpid()))
+	if err := os.WriteFile(probe, []byte("ok"), 0o600); err != nil {
+		d.fail(area, "writable", err.Error(), "check the mount is read-write and owned by the service user")
+	} else {
+		os.Remove(probe)
+		d.pass(area, "writable", "")
+	}
+
+	free, total, err := diskSpace(path)
+	switch {
+	case err != nil:
+		d.skip(area, "free space", err.Error())
+	case free < minFree:
+		d.warn(area, "free space", fmt.Sprintf("%s free of %s", backupService.FormatBytes(free), backupService.FormatBytes(total)),
+			"prune old backups (see the prune subcommand) or grow the volume")
+	default:
+		d.pass(area, "free space", fmt.Sprintf("%s free of %s", backupService.FormatBytes(free), backupService.FormatBytes(total)))
+	}
+}
+
+// checkCerts looks at the mTLS client certificate used to dial modules and
+// the server certificate issued by LCM, at the paths the service uses.
+func (d *doctor) checkCerts(warnWithin time.Duration) {
+	const area = "Certificates"
+	certsDir := os.Getenv("CERTS_DIR")
+	if certsDir == "" {
+		certsDir = "/app/certs"
+	}
+	envOr := func(key, def string) string {
+		if v := os.Getenv(key); v != "" {
+			return v
+		}
+		return def
+	}
+
+	caPath := envOr("BACKUP_CA_CERT_PATH", filepath.Join(certsDir, "ca", "ca.crt"))
+	ca, ok := d.checkCertFile(area, "CA", caPath, warnWithin)
+	var roots *x509.CertPool
+	if ok {
+		roots = x509.NewCertPool()
+		roots.AddCert(ca)
+	}
+
+	pairs := []struct {
+		name, cert, key string
+		usage           x509.ExtKeyUsage
+	}{
+		{"client", envOr("BACKUP_CLIENT_CERT_PATH", filepath.Join(certsDir, "backup", "backup.crt")),
+			envOr("BACKUP_CLIENT_KEY_PATH", filepath.Join(certsDir, "backup", "backup.key")), x509.ExtKeyUsageClientAuth},
+		{"server", filepath.Join(certsDir, "server", "server.crt"), filepath.Join(certsDir, "server", "server.key"), x509.ExtKeyUsageServerAuth},
+	}
+	for _, p := range pairs {
+		cert, ok := d.checkCertFile(area, p.name, p.cert, warnWithin)
+		if !ok {
+			continue
+		}
+		if _, err := tls.LoadX509KeyPair(p.cert, p.key); err != nil {
+			d.fail(area, p.name+" key", err.Error(), "the key must be readable and match "+p.cert)
+		} else {
+			d.pass(area, p.name+" key", p.key)
+		}
+		if roots == nil {
+			continue
+		}
+		if _, err := cert.Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{p.usage}}); err != nil {
+			d.fail(area, p.name+" chain", err.Error(), "the certificate was not issued by "+caPath+"; re-bootstrap certificates from LCM")
+		} else {
+			d.pass(area, p.name+" chain", "")
+		}
+	}
+}
+
+func (d *doctor) checkCertFile(area, name, path string, warnWithin time.Duration) (*x509.Certificate, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		d.fail(area, name, err.Error(), "mount the certificate or set CERTS_DIR / the BACKUP_*_PATH variables")
+		return nil, false
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		d.fail(area, name, path+" is not PEM", "replace it with a PEM-encoded certificate")
+		return nil, false
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		d.fail(area, name, fmt.Sprintf("%s: %v", path, err), "replace it with a valid certificate")
+		return nil, false
+	}
+
+	now := time.Now()
+	detail := fmt.Sprintf("%s, expires %s", path, cert.NotAfter.UTC().Format(time.RFC3339))
+	switch {
+	case now.After(cert.NotAfter):
+		d.fail(area, name, path+" expired "+cert.NotAfter.UTC().Format(time.RFC3339), "restart the service to renew certificates from LCM")
+		return cert, false
+	case now.Before(cert.NotBefore):
+		d.fail(area, name, path+" not valid before "+cert.NotBefore.UTC().Format(time.RFC3339), "check the system clock")
+		return cert, false
+	case cert.NotAfter.Sub(now) < warnWithin:
+		d.warn(area, name, detail, "renew soon: restart the service to request fresh certificates from LCM")
+	default:
+		d.pass(area, name, detail)
+	}
+	return cert, true
+}
+
+// checkServices probes the services the orchestrator depends on. Admin is the
+// module registry used for label selectors; LCM issues certificates. There is
+// no KMS: backup encryption keys come from the request password.
+func (d *doctor) checkServices(ctx context.Context, client *backupService.ModuleClient, timeout time.Duration) {
+	const area = "Services"
+	services := []struct {
+		name, env, hint string
+		grpc            bool
+	}{
+		{"admin (module registry)", "ADMIN_GRPC_ENDPOINT", "label-selector backups and module registration need it", true},
+		{"scheduler", "SCHEDULER_GRPC_ENDPOINT", "scheduled backups are not registered without it", true},
+		{"LCM", "LCM_BOOTSTRAP_ENDPOINT", "certificates cannot be renewed without it", false},
+	}
+	for _, s := range services {
+		endpoint := os.Getenv(s.env)
+		if endpoint == "" {
+			d.skip(area, s.name, s.env+" is not set")
+			continue
+		}
+		if s.grpc {
+			d.probe(ctx, area, client, &backupV1.ModuleTarget{ModuleId: s.name, GrpcEndpoint: endpoint}, timeout, s.hint)
+			continue
+		}
+		conn, err := net.DialTimeout("tcp", endpoint, timeout)
+		if err != nil {
+			d.fail(area, s.name, err.Error(), fmt.Sprintf("check %s=%s and network policy; %s", s.env, endpoint, s.hint))
+			continue
+		}
+		conn.Close()
+		d.pass(area, s.name, endpoint+" reachable")
+	}
+}
+
+func (d *doctor) checkTargets(ctx context.Context, client *backupService.ModuleClient, targets targetList, timeout time.Duration) {
+	const area = "Modules"
+	if len(targets) == 0 {
+		d.skip(area, "targets", "none given; pass --target module=endpoint to probe modules")
+		return
+	}
+	for _, t := range targets {
+		d.probe(ctx, area, client, t, timeout, "backups and restores of "+t.ModuleId+" will fail")
+	}
+}
+
+func (d *doctor) probe(ctx context.Context, area string, client *backupService.ModuleClient, target *backupV1.ModuleTarget, timeout time.Duration, hint string) {
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	r := client.Preflight(callCtx, target)
+	switch {
+	case !r.Reachable:
+		d.fail(area, target.ModuleId, fmt.Sprintf("%s: %s", target.GrpcEndpoint, r.Error),
+			"check the endpoint, DNS, network policy and mTLS certificates; "+hint)
+	case r.Health == "NOT_SERVING":
+		d.fail(area, target.ModuleId, fmt.Sprintf("%s reports NOT_SERVING", target.GrpcEndpoint), "check the service's own logs; "+hint)
+	default:
+		d.pass(area, target.ModuleId, fmt.Sprintf("%s health %s (%dms)", target.GrpcEndpoint, r.Health, r.LatencyMs))
+	}
+}
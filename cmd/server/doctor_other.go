@@ -0,0 +1,9 @@
+//go:build !linux
+
+package main
+
+import "errors"
+
+func diskSpace(string) (free, total int64, err error) {
+	return 0, 0, errors.New("free space check is only supported on Linux")
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	backupService "github.com/go-tangra/go-tangra-backup/internal/service"
+)
+
+func runExtract() error {
+	fs := flag.NewFlagSet("extract", flag.ExitOnError)
+	src := fs.String("path", "", "full backup directory, or a .tar/.tar.gz/.tgz archive of one")
+	moduleID := fs.String("module", "", "module to extract")
+	password := fs.String("password", "", "password for encrypted backups")
+	output := fs.String("output", "", "output file path (default: <module>.json)")
+	format := formatFlag(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s extract --path <dir|archive> --module <id> [--password <password>] [--output <path>] [--format text|json]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Write one module's decrypted, decompressed JSON export out of a full backup.\n\n")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		return err
+	}
+	if err := checkFormat(*format); err != nil {
+		return err
+	}
+	if *src == "" || *moduleID == "" {
+		fs.Usage()
+		return fmt.Errorf("both --path and --module are required")
+	}
+
+	st, err := os.Stat(*src)
+	if err != nil {
+		return err
+	}
+
+	var raw []byte
+	var name string
+	if st.IsDir() {
+		module, full, err := backupService.ReadBackupMetadata(*src)
+		if err != nil {
+			return err
+		}
+		if full == nil {
+			return fmt.Errorf("%s is a module backup of %s, not a full backup", *src, module.ModuleId)
+		}
+		for _, f := range backupService.BackupDataFiles(*src, nil, full) {
+			if f.Info.ModuleId == *moduleID && f.Path != "" {
+				name = f.Path
+			}
+		}
+		if name == "" {
+			return fmt.Errorf("module %s not found in full backup %s", *moduleID, full.Id)
+		}
+		if raw, err = os.ReadFile(name); err != nil {
+			return fmt.Errorf("read module data: %w", err)
+		}
+	} else {
+		if raw, name, err = readFromArchive(*src, *moduleID); err != nil {
+			return err
+		}
+	}
+
+	data, err := backupService.DecodeBackupData(raw, strings.HasSuffix(name, ".enc"), *password)
+	if err != nil {
+		return err
+	}
+
+	outPath := *output
+	if outPath == "" {
+		outPath = *moduleID + ".json"
+	}
+	if err := os.WriteFile(outPath, data, 0o644); err != nil {
+		return fmt.Errorf("write output: %w", err)
+	}
+
+	if *format == "json" {
+		return printJSON(map[string]any{"module": *moduleID, "source": *src, "output": outPath, "bytes": len(data)})
+	}
+	fmt.Printf("Extracted %s from %s -> %s (%d bytes)\n", *moduleID, *src, outPath, len(data))
+	return nil
+}
+
+// readFromArchive finds {module}.json.{gz,zst}[.enc] in a tar archive of a full
+// backup directory, at any depth, and returns its contents and name.
+func readFromArchive(archive, moduleID string) ([]byte, string, error) {
+	f, err := os.Open(archive)
+	if err != nil {
+		return nil, "", err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if ext := filepath.Ext(archive); ext == ".gz" || ext == ".tgz" {
+		gr, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, "", fmt.Errorf("gzip reader: %w", err)
+		}
+		defer gr.Close()
+		r = gr
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("read archive: %w", err)
+		}
+		base := path.Base(hdr.Name)
+		if hdr.Typeflag != tar.TypeReg || !slices.Contains(backupService.DataFileNames(moduleID), base) {
+			continue
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, "", fmt.Errorf("read %s: %w", hdr.Name, err)
+		}
+		return data, hdr.Name, nil
+	}
+	return nil, "", fmt.Errorf("module %s not found in %s", moduleID, archive)
+}
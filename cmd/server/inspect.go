@@ -0,0 +1,153 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+	backupService "github.com/go-tangra/go-tangra-backup/internal/service"
+)
+
+func runInspect() error {
+	fs := flag.NewFlagSet("inspect", flag.ExitOnError)
+	path := fs.String("path", "", "backup directory containing metadata.json")
+	format := formatFlag(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s inspect --path <dir> [--format text|json]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Show what a module or full backup contains, read straight from its metadata.\n\n")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		return err
+	}
+	if err := checkFormat(*format); err != nil {
+		return err
+	}
+	if *path == "" {
+		fs.Usage()
+		return fmt.Errorf("--path is required")
+	}
+
+	module, full, err := backupService.ReadBackupMetadata(*path)
+	if err != nil {
+		return err
+	}
+
+	if *format == "json" {
+		var msg proto.Message = module
+		if full != nil {
+			msg = full
+		}
+		out, err := protojson.MarshalOptions{Indent: "  ", EmitUnpopulated: true}.Marshal(msg)
+		if err != nil {
+			return fmt.Errorf("marshal metadata: %w", err)
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if full != nil {
+		inspectFull(w, full)
+	} else {
+		inspectModule(w, module)
+	}
+	return w.Flush()
+}
+
+func inspectModule(w *tabwriter.Writer, b *backupV1.BackupInfo) {
+	fmt.Fprintf(w, "Backup:\t%s\n", b.Id)
+	fmt.Fprintf(w, "Type:\tmodule\n")
+	fmt.Fprintf(w, "Module:\t%s\n", b.ModuleId)
+	inspectCommon(w, b.Description, b.TenantId, b.Status, b.Encrypted, b.CreatedBy, b.CreatedAt)
+	fmt.Fprintf(w, "Version:\t%s (schema %d)\n", b.Version, b.SchemaVersion)
+	fmt.Fprintf(w, "Size:\t%s\n", inspectSize(b.SizeBytes, b.CompressedSizeBytes, b.CompressionRatio))
+	if b.DurationMs > 0 {
+		fmt.Fprintf(w, "Duration:\t%s\n", time.Duration(b.DurationMs)*time.Millisecond)
+	}
+	if b.Sha256 != "" {
+		fmt.Fprintf(w, "SHA-256:\t%s\n", b.Sha256)
+	}
+	if b.StoredSha256 != "" {
+		fmt.Fprintf(w, "Stored SHA-256:\t%s\n", b.StoredSha256)
+	}
+	for _, warning := range b.Warnings {
+		fmt.Fprintf(w, "Warning:\t%s\n", warning)
+	}
+
+	fmt.Fprintf(w, "\nENTITY\tCOUNT\n")
+	for _, name := range sortedKeys(b.EntityCounts) {
+		fmt.Fprintf(w, "%s\t%d\n", name, b.EntityCounts[name])
+	}
+}
+
+func inspectFull(w *tabwriter.Writer, b *backupV1.FullBackupInfo) {
+	fmt.Fprintf(w, "Backup:\t%s\n", b.Id)
+	fmt.Fprintf(w, "Type:\tfull (%d modules)\n", len(b.ModuleBackups))
+	inspectCommon(w, b.Description, b.TenantId, b.Status, b.Encrypted, b.CreatedBy, b.CreatedAt)
+	if b.ValidationStatus != "" {
+		fmt.Fprintf(w, "Validation:\t%s\n", b.ValidationStatus)
+	}
+	for _, reason := range b.ValidationReasons {
+		fmt.Fprintf(w, "  Reason:\t%s\n", reason)
+	}
+	fmt.Fprintf(w, "Size:\t%s\n", inspectSize(b.TotalSizeBytes, b.TotalCompressedSizeBytes, b.CompressionRatio))
+	if b.DurationMs > 0 {
+		fmt.Fprintf(w, "Duration:\t%s\n", time.Duration(b.DurationMs)*time.Millisecond)
+	}
+	for _, e := range b.Errors {
+		fmt.Fprintf(w, "Error:\t%s\n", e)
+	}
+
+	fmt.Fprintf(w, "\nMODULE\tSTATUS\tVERSION\tSIZE\tENTITIES\n")
+	for _, mb := range b.ModuleBackups {
+		var parts []string
+		for _, name := range sortedKeys(mb.EntityCounts) {
+			parts = append(parts, fmt.Sprintf("%s=%d", name, mb.EntityCounts[name]))
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			mb.ModuleId, mb.Status, mb.Version, backupService.FormatBytes(mb.SizeBytes), strings.Join(parts, ", "))
+	}
+}
+
+func inspectCommon(w *tabwriter.Writer, description string, tenantID uint32, status string, encrypted bool, createdBy string, createdAt *timestamppb.Timestamp) {
+	if description != "" {
+		fmt.Fprintf(w, "Description:\t%s\n", description)
+	}
+	fmt.Fprintf(w, "Tenant:\t%d\n", tenantID)
+	fmt.Fprintf(w, "Status:\t%s\n", status)
+	fmt.Fprintf(w, "Encrypted:\t%t\n", encrypted)
+	if createdBy != "" {
+		fmt.Fprintf(w, "Created by:\t%s\n", createdBy)
+	}
+	if createdAt != nil {
+		fmt.Fprintf(w, "Created at:\t%s\n", createdAt.AsTime().UTC().Format(time.RFC3339))
+	}
+}
+
+func inspectSize(size, compressed int64, ratio float64) string {
+	s := backupService.FormatBytes(size)
+	if compressed > 0 {
+		s += fmt.Sprintf(" (%s compressed, ratio %.2f)", backupService.FormatBytes(compressed), ratio)
+	}
+	return s
+}
+
+func sortedKeys(m map[string]int64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
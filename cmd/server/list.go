@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+	backupService "github.com/go-tangra/go-tangra-backup/internal/service"
+)
+
+// listRow is one line of the list table, module and full backups alike.
+type listRow struct {
+	kind      string
+	id        string
+	modules   string
+	createdAt *timestamppb.Timestamp
+	status    string
+	size      int64
+	encrypted bool
+}
+
+func runList() error {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	path := fs.String("path", backupService.StoragePath(), "storage directory (defaults to BACKUP_STORAGE_PATH)")
+	moduleID := fs.String("module", "", "only list module backups of this module")
+	tenant := fs.Int("tenant", -1, "only list backups of this tenant")
+	kind := fs.String("type", "all", "which backups to list: all, module or full")
+	format := formatFlag(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s list [--path <dir>] [--module <id>] [--tenant <id>] [--type all|module|full] [--format text|json]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "List the backups in a storage directory without the orchestrator running.\n\n")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		return err
+	}
+	if err := checkFormat(*format); err != nil {
+		return err
+	}
+	if *kind != "all" && *kind != "module" && *kind != "full" {
+		fs.Usage()
+		return fmt.Errorf("--type must be all, module or full")
+	}
+
+	storage, err := backupService.OpenBackupStorage(*path)
+	if err != nil {
+		return err
+	}
+
+	var tenantID *uint32
+	if *tenant >= 0 {
+		t := uint32(*tenant)
+		tenantID = &t
+	}
+
+	var modules []*backupV1.BackupInfo
+	var fulls []*backupV1.FullBackupInfo
+	if *kind != "full" {
+		if modules, err = storage.ListModuleBackups(*moduleID, tenantID); err != nil {
+			return err
+		}
+	}
+	if *kind != "module" && *moduleID == "" {
+		if fulls, err = storage.ListFullBackups(tenantID); err != nil {
+			return err
+		}
+	}
+
+	if *format == "json" {
+		modulesJSON, err := protoJSON(&backupV1.ListBackupsResponse{Backups: modules, Total: int32(len(modules))})
+		if err != nil {
+			return fmt.Errorf("marshal module backups: %w", err)
+		}
+		fullJSON, err := protoJSON(&backupV1.ListFullBackupsResponse{Backups: fulls, Total: int32(len(fulls))})
+		if err != nil {
+			return fmt.Errorf("marshal full backups: %w", err)
+		}
+		return printJSON(map[string]json.RawMessage{"modules": modulesJSON, "full": fullJSON})
+	}
+
+	rows := make([]listRow, 0, len(modules)+len(fulls))
+	for _, b := range modules {
+		rows = append(rows, listRow{"module", b.Id, b.ModuleId, b.CreatedAt, b.Status, b.SizeBytes, b.Encrypted})
+	}
+	for _, b := range fulls {
+		rows = append(rows, listRow{"full", b.Id, fmt.Sprintf("%d modules", len(b.ModuleBackups)), b.CreatedAt, b.Status, b.TotalSizeBytes, b.Encrypted})
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].createdAt.AsTime().After(rows[j].createdAt.AsTime())
+	})
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "TYPE\tID\tMODULE\tCREATED\tSTATUS\tSIZE\tENCRYPTED\n")
+	for _, r := range rows {
+		created := "-"
+		if r.createdAt != nil {
+			created = r.createdAt.AsTime().UTC().Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%t\n",
+			r.kind, r.id, r.modules, created, r.status, backupService.FormatBytes(r.size), r.encrypted)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("\n%d module backups, %d full backups in %s\n", len(modules), len(fulls), *path)
+	return nil
+}
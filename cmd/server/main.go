@@ -39,6 +39,7 @@ func newApp(
 	ctx *bootstrap.Context,
 	gs *grpc.Server,
 	hs *kratosHttp.Server,
+	jobs *backupService.JobTracker,
 ) *kratos.App {
 	globalRegHelper = registration.StartRegistration(ctx, ctx.GetLogger(), &registration.Config{
 		ModuleID:          moduleID,
@@ -60,7 +61,9 @@ func newApp(
 	// Register backup task types with the scheduler (background, with retries)
 	backupService.RegisterTasksWithScheduler(ctx.GetLogger())
 
-	return bootstrap.NewApp(ctx, gs, hs)
+	// The job tracker stops alongside the servers, draining the backups and
+	// restores in flight.
+	return bootstrap.NewApp(ctx, gs, hs, jobs)
 }
 
 func runApp() error {
@@ -83,8 +86,9 @@ func runDecrypt() error {
 	fileName := fs.String("file", "", "path to encrypted backup file (.enc)")
 	password := fs.String("password", "", "decryption password")
 	output := fs.String("output", "", "output file path (default: input without .enc suffix)")
+	format := formatFlag(fs)
 	fs.Usage = func() {
-		fmt.Fprintf(os.Stderr, "Usage: %s decrypt --file <path> --password <password> [--output <path>]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Usage: %s decrypt --file <path> --password <password> [--output <path>] [--format text|json]\n\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "Decrypt an AES-256-GCM encrypted backup file.\n\n")
 		fs.PrintDefaults()
 	}
@@ -93,29 +97,38 @@ func runDecrypt() error {
 		return err
 	}
 
+	if err := checkFormat(*format); err != nil {
+		return err
+	}
 	if *fileName == "" || *password == "" {
 		fs.Usage()
 		return fmt.Errorf("both --file and --password are required")
 	}
 
-	encrypted, err := os.ReadFile(*fileName)
+	encrypted, err := readFileProgress(*fileName, *format)
 	if err != nil {
 		return fmt.Errorf("read file: %w", err)
 	}
 
-	compressed, err := backupService.DecryptData(encrypted, *password)
+	_, sealed, err := backupService.ParseFormatHeader(encrypted)
+	if err != nil {
+		return err
+	}
+	compressed, err := backupService.DecryptData(sealed, *password)
 	if err != nil {
 		return fmt.Errorf("decrypt: %w", err)
 	}
 
-	// Decompress gzip
-	gr, err := gzip.NewReader(bytes.NewReader(compressed))
+	// Decompress gzip or zstd
+	p := newProgress("Decompressing", int64(len(compressed)), *format)
+	dr, err := backupService.NewDecompressReader(p.Reader(bytes.NewReader(compressed)))
 	if err != nil {
-		return fmt.Errorf("gzip reader: %w", err)
+		return fmt.Errorf("decompress: %w", err)
 	}
-	defer gr.Close()
+	defer dr.Close()
 
-	plaintext, err := io.ReadAll(gr)
+	plaintext, err := io.ReadAll(dr)
+	p.Done()
 	if err != nil {
 		return fmt.Errorf("decompress: %w", err)
 	}
@@ -124,25 +137,134 @@ func runDecrypt() error {
 	outPath := *output
 	if outPath == "" {
 		outPath = strings.TrimSuffix(*fileName, ".enc")
-		// If the file was .json.gz.enc, strip to .json
-		outPath = strings.TrimSuffix(outPath, ".gz")
+		// If the file was .json.gz.enc or .json.zst.enc, strip to .json
+		outPath = strings.TrimSuffix(strings.TrimSuffix(outPath, ".gz"), ".zst")
 	}
 
 	if err := os.WriteFile(outPath, plaintext, 0o644); err != nil {
 		return fmt.Errorf("write output: %w", err)
 	}
 
+	if *format == "json" {
+		return printJSON(map[string]any{"input": *fileName, "output": outPath, "bytes": len(plaintext)})
+	}
 	fmt.Printf("Decrypted %s -> %s (%d bytes)\n", *fileName, outPath, len(plaintext))
 	return nil
 }
 
+func runEncrypt() error {
+	fs := flag.NewFlagSet("encrypt", flag.ExitOnError)
+	fileName := fs.String("file", "", "path to backup file (.json, .json.gz or .json.zst)")
+	password := fs.String("password", "", "encryption password")
+	output := fs.String("output", "", "output file path (default: input with .gz.enc suffix)")
+	compress := fs.Bool("gzip", true, "gzip the input before encrypting (skipped if it is already gzip or zstd)")
+	format := formatFlag(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s encrypt --file <path> --password <password> [--output <path>] [--gzip=false] [--format text|json]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Encrypt a backup file with AES-256-GCM in the format the backup service and decrypt read.\n\n")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		return err
+	}
+
+	if err := checkFormat(*format); err != nil {
+		return err
+	}
+	if *fileName == "" || *password == "" {
+		fs.Usage()
+		return fmt.Errorf("both --file and --password are required")
+	}
+
+	data, err := readFileProgress(*fileName, *format)
+	if err != nil {
+		return fmt.Errorf("read file: %w", err)
+	}
+
+	// BackupStorage stores gzip(JSON) or zstd(JSON), so compress unless the
+	// input already is one of them.
+	algo := backupService.DetectCompression(data)
+	if *compress && algo == "" {
+		var buf bytes.Buffer
+		gw := gzip.NewWriter(&buf)
+		if _, err := gw.Write(data); err != nil {
+			return fmt.Errorf("compress: %w", err)
+		}
+		if err := gw.Close(); err != nil {
+			return fmt.Errorf("compress: %w", err)
+		}
+		data = buf.Bytes()
+		algo = backupService.CompressionGzip
+	}
+
+	encrypted, err := backupService.EncryptData(data, *password)
+	if err != nil {
+		return fmt.Errorf("encrypt: %w", err)
+	}
+	encrypted = backupService.FrameStoredData(algo, true, encrypted)
+
+	// Determine output path
+	outPath := *output
+	if outPath == "" {
+		outPath = *fileName
+		if ext := backupService.CompressionExt(algo); algo != "" && !strings.HasSuffix(outPath, ext) {
+			outPath += ext
+		}
+		outPath += ".enc"
+	}
+
+	if err := os.WriteFile(outPath, encrypted, 0o644); err != nil {
+		return fmt.Errorf("write output: %w", err)
+	}
+
+	if *format == "json" {
+		return printJSON(map[string]any{"input": *fileName, "output": outPath, "bytes": len(encrypted)})
+	}
+	fmt.Printf("Encrypted %s -> %s (%d bytes)\n", *fileName, outPath, len(encrypted))
+	return nil
+}
+
 func main() {
-	if len(os.Args) > 1 && os.Args[1] == "decrypt" {
-		if err := runDecrypt(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+	if len(os.Args) > 1 {
+		var cmd func() error
+		switch os.Args[1] {
+		case "decrypt":
+			cmd = runDecrypt
+		case "encrypt":
+			cmd = runEncrypt
+		case "verify":
+			cmd = runVerify
+		case "inspect":
+			cmd = runInspect
+		case "list":
+			cmd = runList
+		case "extract":
+			cmd = runExtract
+		case "prune":
+			cmd = runPrune
+		case "rekey":
+			cmd = runRekey
+		case "migrate-format":
+			cmd = runMigrateFormat
+		case "migrate-metadata":
+			cmd = runMigrateMetadata
+		case "restore":
+			cmd = runRestore
+		case "convert":
+			cmd = runConvert
+		case "client":
+			cmd = runClient
+		case "doctor":
+			cmd = runDoctor
+		}
+		if cmd != nil {
+			if err := cmd(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
 		}
-		return
 	}
 
 	if err := runApp(); err != nil {
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	backupService "github.com/go-tangra/go-tangra-backup/internal/service"
+)
+
+func runMigrateFormat() error {
+	fs := flag.NewFlagSet("migrate-format", flag.ExitOnError)
+	path := fs.String("path", backupService.StoragePath(), "storage directory (defaults to BACKUP_STORAGE_PATH)")
+	dryRun := fs.Bool("dry-run", false, "list the backups that would be migrated without changing them")
+	format := formatFlag(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s migrate-format [--path <dir>] [--dry-run] [--format text|json]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Upgrade stored backups to data format %d in place by adding the format header\nto files written before it existed. Payloads are not decrypted, so no password\nis needed. Files that fail their stored checksum are left alone.\n\n", backupService.FormatVersion)
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		return err
+	}
+	if err := checkFormat(*format); err != nil {
+		return err
+	}
+
+	dirs, err := findBackupDirs(*path)
+	if err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if !*dryRun {
+		storage, err := backupService.OpenBackupStorage(*path)
+		if err != nil {
+			return err
+		}
+		// Waits for a running service replica to finish rewriting backups.
+		var unlock func()
+		if ctx, unlock, err = storage.LockOperations(ctx, "migrate-format"); err != nil {
+			return err
+		}
+		defer unlock()
+	}
+
+	var results []backupResult
+	migrated, failed := 0, 0
+	for _, dir := range dirs {
+		if ctx.Err() != nil {
+			break
+		}
+		r := backupResult{Path: dir, Status: "OK"}
+		n, err := backupService.MigrateBackupFormat(dir, *dryRun)
+		switch {
+		case err != nil:
+			r.Status, r.Detail = "FAIL", err.Error()
+			failed++
+		case n == 0:
+			r.Status, r.Detail = "SKIP", fmt.Sprintf("already format %d", backupService.FormatVersion)
+		case *dryRun:
+			r.Detail = fmt.Sprintf("%d files would be migrated", n)
+			migrated++
+		default:
+			r.Detail = fmt.Sprintf("%d files migrated", n)
+			migrated++
+		}
+		results = append(results, r)
+		if *format == "text" {
+			r.print()
+		}
+	}
+
+	if *format == "json" {
+		if err := printJSON(map[string]any{"migrated": migrated, "failed": failed, "dryRun": *dryRun, "backups": results}); err != nil {
+			return err
+		}
+	} else if *dryRun {
+		fmt.Printf("\nWould migrate %d of %d backups\n", migrated, len(dirs))
+	} else {
+		fmt.Printf("\nMigrated %d of %d backups\n", migrated, len(dirs))
+	}
+	if err := context.Cause(ctx); err != nil {
+		return fmt.Errorf("interrupted: %w", err)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d backups could not be migrated", failed)
+	}
+	return nil
+}
+
+func runMigrateMetadata() error {
+	fs := flag.NewFlagSet("migrate-metadata", flag.ExitOnError)
+	path := fs.String("path", backupService.StoragePath(), "storage directory (defaults to BACKUP_STORAGE_PATH)")
+	dryRun := fs.Bool("dry-run", false, "count the legacy metadata files without rewriting them")
+	format := formatFlag(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s migrate-metadata [--path <dir>] [--dry-run] [--format text|json]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Rewrite metadata written in the legacy encoding/json layout into protojson. The\nservice does this once at startup; run it again over backups copied in since.\n\n")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		return err
+	}
+	if err := checkFormat(*format); err != nil {
+		return err
+	}
+
+	storage, err := backupService.OpenBackupStorage(*path)
+	if err != nil {
+		return err
+	}
+	if !*dryRun {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		_, unlock, err := storage.LockOperations(ctx, "metadata migration")
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+
+	m, err := storage.MigrateMetadata(*dryRun)
+	if err != nil {
+		return err
+	}
+	if *format == "json" {
+		if err := printJSON(map[string]any{"dryRun": *dryRun, "result": m}); err != nil {
+			return err
+		}
+	} else {
+		for _, p := range m.Unreadable {
+			fmt.Printf("%-6s %s: readable in neither format\n", "[SKIP]", p)
+		}
+		for _, f := range m.Failed {
+			fmt.Printf("%-6s %s\n", "[FAIL]", f)
+		}
+		verb := "Migrated"
+		if *dryRun {
+			verb = "Would migrate"
+		}
+		fmt.Printf("\n%s %d of %d metadata files\n", verb, m.Migrated, m.Scanned)
+	}
+	if len(m.Failed) > 0 {
+		return fmt.Errorf("%d metadata files could not be migrated", len(m.Failed))
+	}
+	return nil
+}
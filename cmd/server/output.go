@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	backupService "github.com/go-tangra/go-tangra-backup/internal/service"
+)
+
+// formatFlag registers the --format flag every subcommand takes. Text is for
+// people; json prints a single document on stdout for scripts. (--output is
+// already the output file of decrypt, encrypt and extract.)
+func formatFlag(fs *flag.FlagSet) *string {
+	return fs.String("format", "text", "output format: text or json")
+}
+
+func checkFormat(format string) error {
+	if format != "text" && format != "json" {
+		return fmt.Errorf("--format must be text or json")
+	}
+	return nil
+}
+
+func printJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// protoJSON renders a proto message for embedding in a printJSON document.
+func protoJSON(m proto.Message) (json.RawMessage, error) {
+	return protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(m)
+}
+
+func printMessage(m proto.Message) error {
+	out, err := protojson.MarshalOptions{Indent: "  "}.Marshal(m)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+// progress reports bytes processed, throughput and an ETA on stderr while a
+// long operation runs. It stays silent unless stderr is a terminal and the
+// output is text, so it never ends up in logs or JSON.
+type progress struct {
+	label   string
+	total   int64
+	done    int64
+	start   time.Time
+	last    time.Time
+	enabled bool
+}
+
+func newProgress(label string, total int64, format string) *progress {
+	enabled := false
+	if st, err := os.Stderr.Stat(); err == nil && format == "text" {
+		enabled = st.Mode()&os.ModeCharDevice != 0
+	}
+	return &progress{label: label, total: total, start: time.Now(), enabled: enabled}
+}
+
+func (p *progress) Add(n int64) {
+	p.done += n
+	if !p.enabled || time.Since(p.last) < 200*time.Millisecond {
+		return
+	}
+	p.last = time.Now()
+	p.print()
+}
+
+// Done prints the final state and ends the line.
+func (p *progress) Done() {
+	if !p.enabled {
+		return
+	}
+	p.print()
+	fmt.Fprintln(os.Stderr)
+}
+
+func (p *progress) print() {
+	elapsed := time.Since(p.start)
+	line := fmt.Sprintf("%s %s", p.label, backupService.FormatBytes(p.done))
+	if p.total > 0 {
+		line += fmt.Sprintf(" / %s (%d%%)", backupService.FormatBytes(p.total), p.done*100/p.total)
+	}
+	if secs := elapsed.Seconds(); secs > 0 {
+		rate := float64(p.done) / secs
+		line += fmt.Sprintf(", %s/s", backupService.FormatBytes(int64(rate)))
+		if p.total > p.done && rate > 0 {
+			eta := time.Duration(float64(p.total-p.done) / rate * float64(time.Second))
+			line += fmt.Sprintf(", ETA %s", eta.Round(time.Second))
+		}
+	}
+	fmt.Fprintf(os.Stderr, "\r\033[K%s", line)
+}
+
+// Reader counts everything read through r.
+func (p *progress) Reader(r io.Reader) io.Reader {
+	return &progressReader{r: r, p: p}
+}
+
+type progressReader struct {
+	r io.Reader
+	p *progress
+}
+
+func (pr *progressReader) Read(b []byte) (int, error) {
+	n, err := pr.r.Read(b)
+	pr.p.Add(int64(n))
+	return n, err
+}
+
+// readFileProgress reads a whole file, reporting progress as it goes.
+func readFileProgress(path, format string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	st, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	p := newProgress("Reading", st.Size(), format)
+	defer p.Done()
+
+	data := make([]byte, 0, st.Size())
+	buf := make([]byte, 1<<20)
+	r := p.Reader(f)
+	for {
+		n, err := r.Read(buf)
+		data = append(data, buf[:n]...)
+		if err == io.EOF {
+			return data, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	backupService "github.com/go-tangra/go-tangra-backup/internal/service"
+)
+
+// pruneAction is what prune did, or would do, with one backup.
+type pruneAction struct {
+	Series    string    `json:"series"`
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+	Action    string    `json:"action"`          // keep, delete, would delete, delete failed
+	Rules     []string  `json:"rules,omitempty"` // the rules that keep it
+	Error     string    `json:"error,omitempty"`
+}
+
+// pruneSeries is the set of backups a retention policy is applied to as a
+// whole, with the function that deletes one of them.
+type pruneSeries struct {
+	name   string
+	items  []backupService.RetentionItem
+	delete func(id string) error
+}
+
+func runPrune() error {
+	fs := flag.NewFlagSet("prune", flag.ExitOnError)
+	path := fs.String("path", backupService.StoragePath(), "storage directory (defaults to BACKUP_STORAGE_PATH)")
+	var policy backupService.RetentionPolicy
+	fs.IntVar(&policy.KeepLast, "keep-last", 0, "keep the newest N backups")
+	fs.IntVar(&policy.KeepDaily, "keep-daily", 0, "keep the newest backup of each of the last N days")
+	fs.IntVar(&policy.KeepWeekly, "keep-weekly", 0, "keep the newest backup of each of the last N weeks")
+	fs.IntVar(&policy.KeepMonthly, "keep-monthly", 0, "keep the newest backup of each of the last N months")
+	moduleID := fs.String("module", "", "only prune module backups of this module")
+	kind := fs.String("type", "all", "which backups to prune: all, module or full")
+	dryRun := fs.Bool("dry-run", false, "list what would be deleted without deleting it")
+	format := formatFlag(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s prune [--path <dir>] [--keep-last N] [--keep-daily N] [--keep-weekly N] [--keep-monthly N] [--module <id>] [--type all|module|full] [--dry-run] [--format text|json]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Delete backups not kept by any of the retention rules. Rules apply per module\nand tenant for module backups and per tenant for full backups. Backups that did\nnot complete are deleted and pinned backups are always kept.\n\n")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		return err
+	}
+	if err := checkFormat(*format); err != nil {
+		return err
+	}
+	if policy.Empty() {
+		fs.Usage()
+		return fmt.Errorf("at least one --keep-* rule is required")
+	}
+	if *kind != "all" && *kind != "module" && *kind != "full" {
+		fs.Usage()
+		return fmt.Errorf("--type must be all, module or full")
+	}
+
+	storage, err := backupService.OpenBackupStorage(*path)
+	if err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if !*dryRun {
+		// Waits for a running service replica to finish its own deletes.
+		var unlock func()
+		if ctx, unlock, err = storage.LockOperations(ctx, "prune"); err != nil {
+			return err
+		}
+		defer unlock()
+	}
+
+	series := map[string]*pruneSeries{}
+	add := func(name, id string, createdAt time.Time, completed, pinned bool, del func(string) error) {
+		s, ok := series[name]
+		if !ok {
+			s = &pruneSeries{name: name, delete: del}
+			series[name] = s
+		}
+		s.items = append(s.items, backupService.RetentionItem{ID: id, CreatedAt: createdAt, Completed: completed, Pinned: pinned})
+	}
+
+	if *kind != "full" {
+		modules, err := storage.ListModuleBackups(*moduleID, nil)
+		if err != nil {
+			return err
+		}
+		for _, b := range modules {
+			if b.Status == "corrupt" {
+				continue // left for repair or an explicit delete
+			}
+			add(fmt.Sprintf("module %s (tenant %d)", b.ModuleId, b.TenantId), b.Id, b.CreatedAt.AsTime(), b.Status == "completed", b.Pin != nil, storage.DeleteModuleBackup)
+		}
+	}
+	if *kind != "module" && *moduleID == "" {
+		fulls, err := storage.ListFullBackups(nil)
+		if err != nil {
+			return err
+		}
+		for _, b := range fulls {
+			add(fmt.Sprintf("full (tenant %d)", b.TenantId), b.Id, b.CreatedAt.AsTime(), b.Status == "completed", b.Pin != nil, storage.DeleteFullBackup)
+		}
+	}
+
+	names := make([]string, 0, len(series))
+	for name := range series {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var actions []pruneAction
+	kept, deleted, failed := 0, 0, 0
+	for _, name := range names {
+		s := series[name]
+		keep, remove := policy.Apply(s.items)
+		for _, it := range s.items {
+			if rules, ok := keep[it.ID]; ok {
+				kept++
+				actions = append(actions, pruneAction{Series: name, ID: it.ID, CreatedAt: it.CreatedAt, Action: "keep", Rules: rules})
+			}
+		}
+		for _, it := range remove {
+			a := pruneAction{Series: name, ID: it.ID, CreatedAt: it.CreatedAt, Action: "delete"}
+			if *dryRun {
+				a.Action = "would delete"
+				deleted++
+			} else if ctx.Err() != nil {
+				a.Action, a.Error = "delete failed", context.Cause(ctx).Error()
+				failed++
+			} else if err := s.delete(it.ID); err != nil {
+				a.Action, a.Error = "delete failed", err.Error()
+				failed++
+			} else {
+				deleted++
+			}
+			actions = append(actions, a)
+		}
+	}
+
+	if *format == "json" {
+		if err := printJSON(map[string]any{"dryRun": *dryRun, "kept": kept, "deleted": deleted, "failed": failed, "backups": actions}); err != nil {
+			return err
+		}
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "SERIES\tID\tCREATED\tACTION\n")
+		for _, a := range actions {
+			action := a.Action
+			if len(a.Rules) > 0 {
+				action += " (" + strings.Join(a.Rules, ", ") + ")"
+			}
+			if a.Error != "" {
+				action += ": " + a.Error
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", a.Series, a.ID, a.CreatedAt.UTC().Format(time.RFC3339), action)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		if *dryRun {
+			fmt.Printf("\n[DRY RUN] Would delete %d backups, keeping %d\n", deleted, kept)
+		} else {
+			fmt.Printf("\nDeleted %d backups, kept %d\n", deleted, kept)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d backups could not be deleted", failed)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	backupService "github.com/go-tangra/go-tangra-backup/internal/service"
+)
+
+func runRekey() error {
+	fs := flag.NewFlagSet("rekey", flag.ExitOnError)
+	path := fs.String("path", "", "backup directory, or a directory to search for backups (e.g. the storage path)")
+	oldPassword := fs.String("old-password", "", "current password of the encrypted backups")
+	newPassword := fs.String("new-password", "", "password to re-encrypt with")
+	encryptPlain := fs.Bool("encrypt-unencrypted", false, "also encrypt backups that are not encrypted yet")
+	format := formatFlag(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s rekey --path <dir> --old-password <password> --new-password <password> [--encrypt-unencrypted] [--format text|json]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Re-encrypt backups with a new password in place. Stop the orchestrator or make\nsure it is not writing to the same backups while this runs.\n\n")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		return err
+	}
+	if err := checkFormat(*format); err != nil {
+		return err
+	}
+	if *path == "" || *newPassword == "" {
+		fs.Usage()
+		return fmt.Errorf("both --path and --new-password are required")
+	}
+
+	dirs, err := findBackupDirs(*path)
+	if err != nil {
+		return err
+	}
+
+	var results []backupResult
+	rekeyed, failed := 0, 0
+	for _, dir := range dirs {
+		r := backupResult{Path: dir, Status: "OK"}
+		n, err := backupService.RekeyBackup(dir, *oldPassword, *newPassword, *encryptPlain)
+		switch {
+		case err != nil:
+			r.Status, r.Detail = "FAIL", err.Error()
+			failed++
+		case n == 0:
+			r.Status, r.Detail = "SKIP", "not encrypted"
+		default:
+			r.Detail = fmt.Sprintf("%d files re-encrypted", n)
+			rekeyed++
+		}
+		results = append(results, r)
+		if *format == "text" {
+			r.print()
+		}
+	}
+
+	if *format == "json" {
+		if err := printJSON(map[string]any{"rekeyed": rekeyed, "failed": failed, "backups": results}); err != nil {
+			return err
+		}
+	} else {
+		fmt.Printf("\nRe-encrypted %d of %d backups\n", rekeyed, len(dirs))
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d backups could not be re-encrypted", failed)
+	}
+	return nil
+}
+
+// backupResult is the outcome of a bulk command on one backup directory.
+type backupResult struct {
+	Path   string `json:"path"`
+	Status string `json:"status"` // OK, SKIP or FAIL
+	Detail string `json:"detail,omitempty"`
+	// Sizes of the data files, for commands that rewrite them.
+	BytesBefore int64 `json:"bytesBefore,omitempty"`
+	BytesAfter  int64 `json:"bytesAfter,omitempty"`
+}
+
+func (r backupResult) print() {
+	fmt.Printf("%-6s %s: %s\n", "["+r.Status+"]", r.Path, r.Detail)
+}
+
+// findBackupDirs returns path itself if it is a backup directory, otherwise
+// every backup directory below it.
+func findBackupDirs(path string) ([]string, error) {
+	var dirs []string
+	err := filepath.WalkDir(path, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if _, err := os.Stat(filepath.Join(p, "metadata.json")); err == nil {
+			dirs = append(dirs, p)
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("search backups: %w", err)
+	}
+	if len(dirs) == 0 {
+		return nil, fmt.Errorf("no backups found under %s", path)
+	}
+	return dirs, nil
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+	backupService "github.com/go-tangra/go-tangra-backup/internal/service"
+)
+
+func runRestore() error {
+	fs := flag.NewFlagSet("restore", flag.ExitOnError)
+	src := fs.String("path", "", "backup directory, or a single .json.{gz,zst}[.enc] data file")
+	moduleID := fs.String("module", "", "module to restore (default: the module of a module backup)")
+	endpoint := fs.String("endpoint", "", "module gRPC endpoint, e.g. ipam-service:9400")
+	mode := fs.String("mode", "skip", "restore mode: skip or overwrite")
+	password := fs.String("password", "", "password for encrypted backups")
+	backupSvc := fs.String("backup-service", "", "legacy BackupService full name, if not <module>.service.v1.BackupService")
+	insecure := fs.Bool("insecure", false, "connect without TLS")
+	caCert := fs.String("ca-cert", "", "trust this CA (PEM) instead of the configured one")
+	serverName := fs.String("server-name", "", "expected server certificate name, if not the dialed host")
+	format := formatFlag(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s restore --path <dir|file> --endpoint <host:port> [--module <id>] [--mode skip|overwrite] [--password <password>] [--format text|json]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Import a backup straight into a module, without the orchestrator. mTLS client\ncertificates are taken from the same environment variables as the service.\n\n")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		return err
+	}
+	if err := checkFormat(*format); err != nil {
+		return err
+	}
+	if *src == "" || *endpoint == "" {
+		fs.Usage()
+		return fmt.Errorf("both --path and --endpoint are required")
+	}
+	restoreMode, ok := backupV1.RestoreMode_value["RESTORE_MODE_"+strings.ToUpper(*mode)]
+	if !ok {
+		fs.Usage()
+		return fmt.Errorf("--mode must be skip or overwrite")
+	}
+
+	file, info, err := restoreSource(*src, *moduleID)
+	if err != nil {
+		return err
+	}
+	if *moduleID == "" {
+		*moduleID = info.GetModuleId()
+	}
+	if *moduleID == "" {
+		return fmt.Errorf("--module is required when restoring a single file")
+	}
+
+	raw, err := readFileProgress(file, *format)
+	if err != nil {
+		return fmt.Errorf("read file: %w", err)
+	}
+	if info.GetStoredSha256() != "" {
+		sum := sha256.Sum256(raw)
+		if got := hex.EncodeToString(sum[:]); got != info.StoredSha256 {
+			return fmt.Errorf("stored file is corrupt: sha256 %s, metadata says %s", got, info.StoredSha256)
+		}
+	}
+	data, err := backupService.DecodeBackupData(raw, strings.HasSuffix(file, ".enc"), *password)
+	if err != nil {
+		return err
+	}
+	if info.GetSha256() != "" {
+		sum := sha256.Sum256(data)
+		if got := hex.EncodeToString(sum[:]); got != info.Sha256 {
+			return fmt.Errorf("checksum mismatch: sha256 %s, metadata says %s", got, info.Sha256)
+		}
+	}
+
+	target := &backupV1.ModuleTarget{ModuleId: *moduleID, GrpcEndpoint: *endpoint, BackupService: *backupSvc}
+	if *insecure || *caCert != "" || *serverName != "" {
+		target.Tls = &backupV1.TargetTLS{Insecure: *insecure, CaCertPath: *caCert, ServerName: *serverName}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if *format == "text" {
+		fmt.Printf("Restoring %s (%d bytes) to %s at %s (mode=%s)\n", file, len(data), *moduleID, *endpoint, *mode)
+	}
+	resp, err := backupService.NewOfflineModuleClient().ImportBackup(ctx, target, data, backupV1.RestoreMode(restoreMode))
+	if err != nil {
+		return fmt.Errorf("import backup to %s: %w", *moduleID, err)
+	}
+
+	if *format == "json" {
+		if err := printMessage(resp); err != nil {
+			return err
+		}
+		if !resp.Success {
+			return fmt.Errorf("module %s reported the restore as unsuccessful", *moduleID)
+		}
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "\nENTITY\tTOTAL\tCREATED\tUPDATED\tSKIPPED\tFAILED\n")
+	for _, r := range resp.Results {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\n", r.EntityType, r.Total, r.Created, r.Updated, r.Skipped, r.Failed)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	for _, warning := range resp.Warnings {
+		fmt.Printf("Warning: %s\n", warning)
+	}
+	if resp.MigrationsApplied > 0 {
+		fmt.Printf("Migrated from schema %d to %d (%d migrations)\n", resp.SourceVersion, resp.TargetVersion, resp.MigrationsApplied)
+	}
+
+	if !resp.Success {
+		return fmt.Errorf("module %s reported the restore as unsuccessful", *moduleID)
+	}
+	fmt.Println("\nRestore completed")
+	return nil
+}
+
+// restoreSource resolves --path to the data file to restore and, for backup
+// directories, the metadata describing it.
+func restoreSource(src, moduleID string) (string, *backupV1.BackupInfo, error) {
+	st, err := os.Stat(src)
+	if err != nil {
+		return "", nil, err
+	}
+	if !st.IsDir() {
+		return src, nil, nil
+	}
+
+	module, full, err := backupService.ReadBackupMetadata(src)
+	if err != nil {
+		return "", nil, err
+	}
+	if full != nil && moduleID == "" {
+		return "", nil, fmt.Errorf("%s is a full backup: --module is required", src)
+	}
+	if module != nil && moduleID != "" && module.ModuleId != moduleID {
+		return "", nil, fmt.Errorf("%s is a backup of %s, not %s", src, module.ModuleId, moduleID)
+	}
+	if err := backupService.SelfContained(module); err != nil {
+		return "", nil, err
+	}
+
+	for _, f := range backupService.BackupDataFiles(src, module, full) {
+		if moduleID != "" && f.Info.ModuleId != moduleID {
+			continue
+		}
+		if f.Path == "" {
+			return "", nil, fmt.Errorf("%s data file missing", f.Info.ModuleId)
+		}
+		return f.Path, f.Info, nil
+	}
+	return "", nil, fmt.Errorf("module %s not found in %s", moduleID, src)
+}
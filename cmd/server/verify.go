@@ -0,0 +1,237 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+	backupService "github.com/go-tangra/go-tangra-backup/internal/service"
+)
+
+// minEncryptedSize is salt(32) + nonce(12) + GCM tag(16).
+const minEncryptedSize = 32 + 12 + 16
+
+// verifyCheck is the outcome of one check on one file.
+type verifyCheck struct {
+	Name   string `json:"name"`
+	Status string `json:"status"` // PASS, FAIL or SKIP
+	Detail string `json:"detail,omitempty"`
+}
+
+// verifyResult is the checks run on one data file.
+type verifyResult struct {
+	File   string        `json:"file"`
+	Checks []verifyCheck `json:"checks"`
+}
+
+func runVerify() error {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	path := fs.String("path", "", "backup directory (containing metadata.json) or a single .json.{gz,zst}[.enc] file")
+	password := fs.String("password", "", "password for encrypted backups")
+	format := formatFlag(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s verify --path <dir|file> [--password <password>] [--format text|json]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Check that a backup can be decrypted, decompressed and parsed, and that it\nmatches the checksums and size recorded in its metadata.\n\n")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		return err
+	}
+	if err := checkFormat(*format); err != nil {
+		return err
+	}
+	if *path == "" {
+		fs.Usage()
+		return fmt.Errorf("--path is required")
+	}
+
+	st, err := os.Stat(*path)
+	if err != nil {
+		return err
+	}
+
+	var results []verifyResult
+	var files []backupService.BackupDataFile
+	if st.IsDir() {
+		module, full, err := backupService.ReadBackupMetadata(*path)
+		if err == nil {
+			err = backupService.SelfContained(module)
+		}
+		if err != nil {
+			results = append(results, verifyResult{File: *path, Checks: []verifyCheck{{"metadata", "FAIL", err.Error()}}})
+		} else {
+			files = backupService.BackupDataFiles(*path, module, full)
+			if *format == "text" && full != nil {
+				fmt.Printf("Full backup %s (%d module files)\n\n", full.Id, len(files))
+			} else if *format == "text" {
+				fmt.Printf("Module backup %s (%s)\n\n", module.Id, module.ModuleId)
+			}
+		}
+	} else {
+		files = []backupService.BackupDataFile{{Path: *path}}
+	}
+
+	for _, f := range files {
+		name := f.Path
+		if name == "" {
+			name = fmt.Sprintf("%s data file", f.Info.GetModuleId())
+		}
+		results = append(results, verifyResult{File: name, Checks: verifyFile(f.Path, f.Info, *password, *format)})
+	}
+
+	failed := 0
+	for _, r := range results {
+		for _, c := range r.Checks {
+			if c.Status == "FAIL" {
+				failed++
+			}
+		}
+	}
+
+	if *format == "json" {
+		result := "PASS"
+		if failed > 0 {
+			result = "FAIL"
+		}
+		if err := printJSON(map[string]any{"files": results, "result": result}); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range results {
+			fmt.Println(r.File)
+			for _, c := range r.Checks {
+				line := fmt.Sprintf("  [%s] %s", c.Status, c.Name)
+				if c.Detail != "" {
+					line += ": " + c.Detail
+				}
+				fmt.Println(line)
+			}
+		}
+		if failed > 0 {
+			fmt.Printf("\nResult: FAIL (%d failed checks)\n", failed)
+		} else {
+			fmt.Printf("\nResult: PASS (%d files)\n", len(results))
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("verification failed")
+	}
+	return nil
+}
+
+// verifyFile runs the checks in order and stops at the first failure, since
+// later checks need the output of earlier ones.
+func verifyFile(path string, info *backupV1.BackupInfo, password, format string) []verifyCheck {
+	var checks []verifyCheck
+	pass := func(name, detail string) { checks = append(checks, verifyCheck{name, "PASS", detail}) }
+	skip := func(name, detail string) { checks = append(checks, verifyCheck{name, "SKIP", detail}) }
+	fail := func(name string, err error) []verifyCheck {
+		return append(checks, verifyCheck{name, "FAIL", err.Error()})
+	}
+
+	if path == "" {
+		return fail("exists", fmt.Errorf("data file missing"))
+	}
+	raw, err := readFileProgress(path, format)
+	if err != nil {
+		return fail("read", err)
+	}
+
+	// The stored checksum catches bit rot before anything is decrypted.
+	switch {
+	case info == nil:
+		skip("stored", "no metadata")
+	case info.StoredSha256 == "":
+		skip("stored", "not recorded in metadata")
+	default:
+		sum := sha256.Sum256(raw)
+		if got := hex.EncodeToString(sum[:]); got != info.StoredSha256 {
+			return fail("stored", fmt.Errorf("sha256 %s, metadata says %s", got, info.StoredSha256))
+		}
+		pass("stored", "sha256 of the stored file matches")
+	}
+
+	encrypted := strings.HasSuffix(path, ".enc")
+	h, compressed, err := backupService.ParseFormatHeader(raw)
+	if err != nil {
+		return fail("format", err)
+	}
+	version := fmt.Sprintf("format %d", h.Version)
+	if h.Version == 0 {
+		version = "no format header, run migrate-format"
+	}
+	if encrypted {
+		if len(compressed) < minEncryptedSize {
+			return fail("format", fmt.Errorf("%d bytes is shorter than the %d-byte salt, nonce and tag", len(compressed), minEncryptedSize))
+		}
+		pass("format", fmt.Sprintf("AES-256-GCM, %d bytes, %s", len(compressed), version))
+		if password == "" {
+			return fail("decrypt", fmt.Errorf("file is encrypted: --password required"))
+		}
+		if compressed, err = backupService.DecryptData(compressed, password); err != nil {
+			return fail("decrypt", err)
+		}
+		pass("decrypt", "")
+	} else {
+		algo := backupService.DetectCompression(compressed)
+		if algo == "" {
+			return fail("format", fmt.Errorf("not a gzip or zstd file"))
+		}
+		pass("format", fmt.Sprintf("%s, %d bytes, %s", algo, len(compressed), version))
+		skip("decrypt", "not encrypted")
+	}
+
+	// Reading to EOF verifies the gzip trailer CRC and length, or the zstd
+	// frame checksums.
+	algo := backupService.DetectCompression(compressed)
+	if algo == "" {
+		return fail("decompress", fmt.Errorf("not gzip or zstd data"))
+	}
+	dr, err := backupService.NewDecompressReader(bytes.NewReader(compressed))
+	if err != nil {
+		return fail(algo, err)
+	}
+	defer dr.Close()
+	data, err := io.ReadAll(dr)
+	if err != nil {
+		return fail(algo, err)
+	}
+	pass(algo, fmt.Sprintf("%d bytes uncompressed", len(data)))
+
+	if !json.Valid(data) {
+		return fail("json", fmt.Errorf("payload is not valid JSON"))
+	}
+	pass("json", "")
+
+	switch {
+	case info == nil:
+		skip("checksum", "no metadata")
+	case info.Sha256 == "":
+		skip("checksum", "not recorded in metadata")
+	default:
+		sum := sha256.Sum256(data)
+		if got := hex.EncodeToString(sum[:]); got != info.Sha256 {
+			return fail("checksum", fmt.Errorf("sha256 %s, metadata says %s", got, info.Sha256))
+		}
+		pass("checksum", "sha256 matches")
+	}
+
+	switch {
+	case info == nil || info.SizeBytes == 0:
+		skip("size", "not recorded in metadata")
+	case int64(len(data)) != info.SizeBytes:
+		return fail("size", fmt.Errorf("%d bytes, metadata says %d", len(data), info.SizeBytes))
+	default:
+		pass("size", "")
+	}
+	return checks
+}
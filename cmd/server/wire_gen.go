@@ -22,13 +22,62 @@ func initApp(context *bootstrap.Context) (*kratos.App, func(), error) {
 	if err != nil {
 		return nil, nil, err
 	}
-	moduleClient := service.NewModuleClient(context)
+	runtimeConfig, cleanup, err := service.NewRuntimeConfig(context)
+	if err != nil {
+		return nil, nil, err
+	}
+	throttle := service.NewThrottle(context, runtimeConfig)
+	moduleClient, err := service.NewModuleClient(context, runtimeConfig, throttle)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
 	backupStorage := service.NewBackupStorage(context)
-	orchestratorService := service.NewOrchestratorService(context, moduleClient, backupStorage)
-	taskExecutor := service.NewTaskExecutor(context, orchestratorService, backupStorage)
+	eventPublisher, cleanup2, err := service.NewEventPublisher(context, runtimeConfig)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	jobTracker := service.NewJobTracker(context)
+	replicator, cleanup3, err := service.NewReplicator(context, backupStorage, eventPublisher, throttle)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	mirror, cleanup4, err := service.NewMirror(context, backupStorage, eventPublisher)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	federation, cleanup5, err := service.NewFederation(context, backupStorage, throttle)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	orchestratorService := service.NewOrchestratorService(context, moduleClient, backupStorage, eventPublisher, runtimeConfig, jobTracker, replicator, mirror, federation, throttle)
+	taskExecutor := service.NewTaskExecutor(context, orchestratorService, backupStorage, eventPublisher)
 	grpcServer := server.NewGRPCServer(context, certManager, orchestratorService, taskExecutor)
-	httpServer := server.NewHTTPServer(context)
-	app := newApp(context, grpcServer, httpServer)
+	httpServer, err := server.NewHTTPServer(context, orchestratorService)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	app := newApp(context, grpcServer, httpServer, jobTracker)
 	return app, func() {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
 	}, nil
 }
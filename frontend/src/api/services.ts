@@ -19,9 +19,18 @@ export interface Module {
 
 // ==================== Entity Types ====================
 
+export interface TargetTLS {
+  insecure?: boolean;
+  caCertPath?: string;
+  skipVerify?: boolean;
+  serverName?: string;
+}
+
 export interface ModuleTarget {
   moduleId: string;
   grpcEndpoint: string;
+  tls?: TargetTLS;
+  backupService?: string;
 }
 
 export interface BackupInfo {
@@ -30,6 +39,7 @@ export interface BackupInfo {
   description: string;
   tenantId: number;
   fullBackup: boolean;
+  /** "completed", "failed", or "corrupt" when the metadata is unreadable. */
   status: string;
   sizeBytes: string | number;
   entityCounts: Record<string, string | number>;
@@ -38,6 +48,127 @@ export interface BackupInfo {
   version: string;
   warnings: string[];
   encrypted: boolean;
+  durationMs?: string | number;
+  timings?: PhaseTimings;
+  compressedSizeBytes?: string | number;
+  compressionRatio?: number;
+  throughputBytesPerSec?: string | number;
+  sha256?: string;
+  pin?: BackupPin;
+  compression?: string;
+  compressionLevel?: number;
+  deltaBaseId?: string;
+  deltaDepth?: number;
+  storedSha256?: string;
+  lastVerification?: BackupVerification;
+  /** Checks on the export when it was taken: "usable" or "suspect". */
+  validationStatus?: string;
+  validationReasons?: string[];
+  replication?: ReplicationStatus;
+  /** Copy in object storage; unset when mirroring is off. */
+  mirror?: ReplicationStatus;
+  /** Site or region of the orchestrator that took the backup. */
+  site?: string;
+  /** Copy on the primary a federated backup was pulled from. */
+  origin?: ReplicationStatus;
+  /** Copies restores may read, local first; filled in by get only. */
+  copies?: BackupCopy[];
+}
+
+/** Set while a backup is protected from deletion. */
+export interface BackupPin {
+  pinnedBy: string;
+  pinnedAt: string;
+  reason: string;
+}
+
+/** Where a backup has been copied off-site, by replication or the mirror. */
+export interface ReplicationStatus {
+  state: 'pending' | 'replicated' | 'failed';
+  /** Orchestrator or mirror URL the copy goes to. */
+  target: string;
+  attempts?: number;
+  /** Set while failed. */
+  lastError?: string;
+  updatedAt?: string;
+  replicatedAt?: string;
+  /** Site or region of the target. */
+  site?: string;
+}
+
+/** One copy of a backup a restore may read. */
+export interface BackupCopy {
+  source: 'local' | 'mirror' | 'peer';
+  site?: string;
+  /** Storage path, mirror URL or orchestrator address. */
+  target: string;
+  /** Backup status for the local copy, copy state otherwise. */
+  state: string;
+  available: boolean;
+}
+
+export interface ReplicateBackupResponse {
+  replication: ReplicationStatus;
+}
+
+/** Outcome of the last VerifyBackup call. */
+export interface BackupVerification {
+  verifiedAt: string;
+  verifiedBy: string;
+  ok: boolean;
+  /** The first failed check, when not ok. */
+  error?: string;
+}
+
+export interface VerificationCheck {
+  name: string;
+  status: 'PASS' | 'FAIL' | 'SKIP';
+  detail?: string;
+}
+
+export interface ModuleVerification {
+  moduleId: string;
+  ok: boolean;
+  /** In order, up to the first failure. */
+  checks: VerificationCheck[];
+}
+
+export interface VerifyBackupResponse {
+  verification: BackupVerification;
+  modules: ModuleVerification[];
+}
+
+export interface TestRestoreRequest {
+  /** Restore the target's module from a full backup. */
+  full?: boolean;
+  target: ModuleTarget;
+  password?: string;
+}
+
+export interface TestRestoreResponse {
+  success: boolean;
+  results: EntityImportResult[];
+  warnings: string[];
+  /** Validation tenant the backup was imported into. */
+  tenantId: number;
+  /** The validation tenant was returned to its state before the test. */
+  cleanedUp: boolean;
+  error: string;
+}
+
+export interface RepairBackupRequest {
+  /** Module the data was exported from. */
+  moduleId: string;
+  /** Tenant the data was exported for. */
+  tenantId?: number;
+  password?: string;
+}
+
+export interface PhaseTimings {
+  exportMs?: string | number;
+  compressMs?: string | number;
+  encryptMs?: string | number;
+  writeMs?: string | number;
 }
 
 export interface FullBackupInfo {
@@ -52,6 +183,26 @@ export interface FullBackupInfo {
   createdBy: string;
   errors: string[];
   encrypted: boolean;
+  validationStatus?: 'usable' | 'unusable';
+  validationReasons?: string[];
+  durationMs?: string | number;
+  totalCompressedSizeBytes?: string | number;
+  compressionRatio?: number;
+  pin?: BackupPin;
+  labels?: Record<string, string>;
+  updatedAt?: string;
+  updatedBy?: string;
+  lastVerification?: BackupVerification;
+  idempotencyKey?: string;
+  replication?: ReplicationStatus;
+  /** Copy in object storage; unset when mirroring is off. */
+  mirror?: ReplicationStatus;
+  /** Site or region of the orchestrator that took the backup. */
+  site?: string;
+  /** Copy on the primary a federated backup was pulled from. */
+  origin?: ReplicationStatus;
+  /** Copies restores may read, local first; filled in by get only. */
+  copies?: BackupCopy[];
 }
 
 export interface EntityImportResult {
@@ -69,6 +220,8 @@ export interface ModuleRestoreResult {
   results: EntityImportResult[];
   warnings: string[];
   error: string;
+  /** Copy the data was read from. */
+  source?: BackupCopy;
 }
 
 // ==================== Request/Response Types ====================
@@ -79,6 +232,7 @@ export interface CreateModuleBackupRequest {
   description: string;
   includeSecrets?: boolean;
   password?: string;
+  compressionLevel?: number;
 }
 
 export interface CreateModuleBackupResponse {
@@ -95,11 +249,15 @@ export interface RestoreModuleBackupResponse {
   success: boolean;
   results: EntityImportResult[];
   warnings: string[];
+  /** Copy the data was read from; warnings say why when it is not the local one. */
+  source?: BackupCopy;
 }
 
 export interface ListBackupsResponse {
   backups: BackupInfo[];
   total: number;
+  /** Empty on the last page. */
+  nextPageToken?: string;
 }
 
 export interface GetBackupResponse {
@@ -115,18 +273,90 @@ export interface DownloadBackupResponse {
   filename: string;
 }
 
+export interface GeneratePresignedDownloadURLRequest {
+  moduleId?: string;
+  expiresInSeconds?: number;
+}
+
+export interface GeneratePresignedDownloadURLResponse {
+  url: string;
+  expiresAt: string;
+}
+
+/** Either ids or at least one filter field, not both. */
+export interface DeleteBackupsRequest {
+  ids?: string[];
+  moduleId?: string;
+  tenantId?: number;
+  createdBefore?: string;
+  status?: string;
+  dryRun?: boolean;
+}
+
+export interface DeleteBackupResult {
+  id: string;
+  deleted: boolean;
+  error: string;
+}
+
+export interface DeleteBackupsResponse {
+  results: DeleteBackupResult[];
+  deleted: number;
+  failed: number;
+}
+
+export interface PinBackupResponse {
+  pin: BackupPin;
+}
+
+export interface RestoreRecord {
+  backupId: string;
+  kind: 'module' | 'full' | 'test';
+  moduleId: string;
+  grpcEndpoint: string;
+  mode: 'RESTORE_MODE_OVERWRITE' | 'RESTORE_MODE_SKIP';
+  restoredBy: string;
+  requestId: string;
+  startedAt: string;
+  finishedAt: string;
+  success: boolean;
+  error?: string;
+  source?: BackupCopy;
+}
+
+export interface GetBackupUsageResponse {
+  /** Oldest first. */
+  restores: RestoreRecord[];
+}
+
 export interface CreateFullBackupRequest {
   targets: ModuleTarget[];
   tenantId?: number;
   description: string;
   includeSecrets?: boolean;
   password?: string;
+  minSuccessPercent?: number;
+  requiredModules?: string[];
+  targetSelector?: string;
+  compressionLevel?: number;
+  /** A retry with the same key resumes the unfinished backup or returns the saved one. */
+  idempotencyKey?: string;
 }
 
 export interface CreateFullBackupResponse {
   backup: FullBackupInfo;
 }
 
+export interface UpdateFullBackupRequest {
+  description?: string;
+  /** Merged into the existing labels; an empty value removes the label. */
+  labels?: Record<string, string>;
+}
+
+export interface UpdateFullBackupResponse {
+  backup: FullBackupInfo;
+}
+
 export interface RestoreFullBackupRequest {
   targets: ModuleTarget[];
   mode: 'RESTORE_MODE_OVERWRITE' | 'RESTORE_MODE_SKIP';
@@ -141,6 +371,8 @@ export interface RestoreFullBackupResponse {
 export interface ListFullBackupsResponse {
   backups: FullBackupInfo[];
   total: number;
+  /** Empty on the last page. */
+  nextPageToken?: string;
 }
 
 export interface GetFullBackupResponse {
@@ -156,6 +388,239 @@ export interface DownloadFullBackupResponse {
   filename: string;
 }
 
+export interface PreflightCheckRequest {
+  targets: ModuleTarget[];
+}
+
+export interface TargetPreflightResult {
+  moduleId: string;
+  reachable: boolean;
+  health: string;
+  error: string;
+  latencyMs: string | number;
+}
+
+export interface PreflightCheckResponse {
+  results: TargetPreflightResult[];
+  ready: boolean;
+}
+
+export interface BackupStatisticsBucket {
+  start: string;
+  backupCount: number;
+  failedCount: number;
+  totalBytes: string | number;
+  avgDurationMs: string | number;
+  failureRate: number;
+  totalCompressedBytes: string | number;
+}
+
+export interface ModuleBackupTrend {
+  moduleId: string;
+  tenantId: number;
+  backupCount: number;
+  firstSizeBytes: string | number;
+  lastSizeBytes: string | number;
+  growthBytes: string | number;
+  growthPercent: number;
+  avgCompressionRatio: number;
+  lastCompressionRatio: number;
+  lastGrowthPercent: number;
+  ballooned: boolean;
+}
+
+export interface GetBackupStatisticsResponse {
+  buckets: BackupStatisticsBucket[];
+  totalBackups: number;
+  failedBackups: number;
+  totalBytes: string | number;
+  avgDurationMs: string | number;
+  failureRate: number;
+  moduleTrends: ModuleBackupTrend[];
+}
+
+export interface ModuleFreshness {
+  moduleId: string;
+  tenantId: number;
+  lastSuccessAt?: string;
+  ageSeconds: string | number;
+  sloSeconds: string | number;
+  violated: boolean;
+}
+
+export interface GetBackupFreshnessResponse {
+  modules: ModuleFreshness[];
+  violations: number;
+}
+
+export interface GetMirrorStatusResponse {
+  target: string;
+  mirrored?: number;
+  pending?: number;
+  failed?: number;
+  /** Age of the oldest backup not mirrored yet; 0 when caught up. */
+  lagSeconds?: string | number;
+  lastMirroredAt?: string;
+  failedBackupIds?: string[];
+}
+
+/** Outcome of the last sync of a DR orchestrator with its primary. */
+export interface GetFederationStatusResponse {
+  peer: string;
+  lastSyncAt?: string;
+  /** Last sync that left nothing behind. */
+  lastSuccessAt?: string;
+  /** Set when the last sync could not read the catalog. */
+  lastError?: string;
+  peerBackups?: number;
+  pulled?: number;
+  failedBackupIds?: string[];
+}
+
+export interface QuotaUsage {
+  scope: 'tenant' | 'module';
+  id: string;
+  usedBytes: string | number;
+  maxBytes: string | number;
+  backups: number;
+  maxBackups: number;
+  exceeded: boolean;
+}
+
+export interface GetQuotaUsageResponse {
+  usage: QuotaUsage[];
+}
+
+export interface CatalogEntry {
+  backupId: string;
+  kind: 'module' | 'full' | 'full_module';
+  moduleId: string;
+  tenantId: number;
+  status: string;
+  createdAt: string;
+  createdBy: string;
+  sizeBytes: string | number;
+  storedSizeBytes: string | number;
+  encrypted: boolean;
+  sha256: string;
+  version: string;
+  schemaVersion: number;
+  pinned: boolean;
+  description: string;
+}
+
+export interface ExportCatalogResponse {
+  entries: CatalogEntry[];
+  document: string;
+  contentType: string;
+  filename: string;
+}
+
+export interface ImportCatalogRequest {
+  sourcePath?: string;
+  catalog?: string;
+  dryRun?: boolean;
+}
+
+export interface CatalogImportResult {
+  backupId: string;
+  kind: 'module' | 'full';
+  outcome: 'imported' | 'registered' | 'present' | 'invalid' | 'missing' | 'would_import';
+  reason?: string;
+}
+
+export interface ImportCatalogResponse {
+  results: CatalogImportResult[];
+  imported: number;
+  registered: number;
+  present: number;
+  invalid: number;
+  missing: number;
+}
+
+export interface ExportRepositoryRequest {
+  /** Bundle file on the orchestrator host; absolute, outside its storage, and not there yet. */
+  destinationPath: string;
+  /** Encrypts the bundle. */
+  password: string;
+  moduleId?: string;
+  tenantId?: number;
+  createdAfter?: string;
+  createdBefore?: string;
+}
+
+export interface ExportRepositoryResponse {
+  path: string;
+  sizeBytes: string | number;
+  sha256: string;
+  /** Fingerprint of the signing key. */
+  signer: string;
+  /** Including delta bases the filters leave out. */
+  moduleBackups: number;
+  fullBackups: number;
+  /** Backups left out. */
+  warnings?: string[];
+}
+
+export interface ImportRepositoryRequest {
+  sourcePath: string;
+  password: string;
+  dryRun?: boolean;
+}
+
+export interface ImportRepositoryResponse {
+  signer: string;
+  /** The key is one of BACKUP_BUNDLE_TRUSTED_KEYS. */
+  signerTrusted: boolean;
+  exportedAt: string;
+  exportedBy: string;
+  site?: string;
+  catalog: ImportCatalogResponse;
+}
+
+export interface ReportFailure {
+  backupId: string;
+  moduleId?: string;
+  createdAt: string;
+  errors: string[];
+}
+
+export interface ReportModuleSummary {
+  moduleId: string;
+  backups: number;
+  failed: number;
+  totalBytes: string | number;
+}
+
+export interface RetentionCandidate {
+  backupId: string;
+  moduleId: string;
+  createdAt: string;
+  deletesAt: string;
+}
+
+export interface BackupReport {
+  period: 'daily' | 'weekly';
+  startTime: string;
+  endTime: string;
+  generatedAt: string;
+  totalBackups: number;
+  failedBackups: number;
+  totalBytes: string | number;
+  storageUsedBytes: string | number;
+  failures: ReportFailure[];
+  modules: ReportModuleSummary[];
+  upcomingDeletions: RetentionCandidate[];
+  /** IDs of module backups with unreadable metadata. */
+  corruptBackups?: string[];
+}
+
+export interface GenerateBackupReportResponse {
+  report: BackupReport;
+  document: string;
+  contentType: string;
+}
+
 // ==================== Helper ====================
 
 function buildQuery(params: Record<string, unknown>): string {
@@ -169,6 +634,20 @@ function buildQuery(params: Record<string, unknown>): string {
   return query ? `?${query}` : '';
 }
 
+/** Server-side filters and ordering shared by the module and full backup lists. */
+export interface BackupListFilters {
+  created_after?: string;
+  created_before?: string;
+  status?: string;
+  created_by?: string;
+  encrypted?: boolean;
+  search?: string;
+  sort_by?: string;
+  sort_order?: 'asc' | 'desc';
+  /** nextPageToken of the previous call; replaces page. */
+  page_token?: string;
+}
+
 // ==================== Module Backup Service ====================
 
 export const ModuleBackupService = {
@@ -192,7 +671,7 @@ export const ModuleBackupService = {
       tenant_id?: number;
       page?: number;
       page_size?: number;
-    },
+    } & BackupListFilters,
     options?: RequestOptions,
   ) => {
     const qs = buildQuery({
@@ -200,6 +679,15 @@ export const ModuleBackupService = {
       tenant_id: params?.tenant_id,
       page: params?.page,
       page_size: params?.page_size,
+      created_after: params?.created_after,
+      created_before: params?.created_before,
+      status: params?.status,
+      created_by: params?.created_by,
+      encrypted: params?.encrypted,
+      search: params?.search,
+      sort_by: params?.sort_by,
+      sort_order: params?.sort_order,
+      page_token: params?.page_token,
     });
     return backupApi.get<ListBackupsResponse>(`/backups${qs}`, options);
   },
@@ -210,8 +698,41 @@ export const ModuleBackupService = {
   delete: (id: string, options?: RequestOptions) =>
     backupApi.delete<void>(`/backups/${id}`, options),
 
+  deleteMany: (data: DeleteBackupsRequest, options?: RequestOptions) =>
+    backupApi.post<DeleteBackupsResponse>('/backups/delete', data, options),
+
   download: (id: string, data?: DownloadBackupRequest, options?: RequestOptions) =>
     backupApi.post<DownloadBackupResponse>(`/backups/${id}/download`, data ?? {}, options),
+
+  presign: (id: string, data?: GeneratePresignedDownloadURLRequest, options?: RequestOptions) =>
+    backupApi.post<GeneratePresignedDownloadURLResponse>(`/backups/${id}/presign`, data ?? {}, options),
+
+  pin: (id: string, reason?: string, options?: RequestOptions) =>
+    backupApi.post<PinBackupResponse>(`/backups/${id}/pin`, { reason }, options),
+
+  /** Requires a platform admin. */
+  unpin: (id: string, options?: RequestOptions) =>
+    backupApi.post<void>(`/backups/${id}/unpin`, {}, options),
+
+  /** Reads the backup end to end and records the result on it. */
+  verify: (id: string, password?: string, options?: RequestOptions) =>
+    backupApi.post<VerifyBackupResponse>(`/backups/${id}/verify`, { password }, options),
+
+  /** Queues the backup for replication to the secondary now. */
+  replicate: (id: string, options?: RequestOptions) =>
+    backupApi.post<ReplicateBackupResponse>(`/backups/${id}/replicate`, {}, options),
+
+  /** Imports into the validation tenant and cleans it up again; requires a platform admin. */
+  testRestore: (id: string, data: TestRestoreRequest, options?: RequestOptions) =>
+    backupApi.post<TestRestoreResponse>(`/backups/${id}/test-restore`, data, options),
+
+  /** Rebuilds the metadata of a corrupt backup from its data file; requires a platform admin. */
+  repair: (id: string, data: RepairBackupRequest, options?: RequestOptions) =>
+    backupApi.post<GetBackupResponse>(`/backups/${id}/repair`, data, options),
+
+  /** Restore history; for full restores pass the full backup's ID. */
+  usage: (id: string, options?: RequestOptions) =>
+    backupApi.get<GetBackupUsageResponse>(`/backups/${id}/usage`, options),
 };
 
 // ==================== Full Backup Service ====================
@@ -236,13 +757,22 @@ export const FullBackupService = {
       tenant_id?: number;
       page?: number;
       page_size?: number;
-    },
+    } & BackupListFilters,
     options?: RequestOptions,
   ) => {
     const qs = buildQuery({
       tenant_id: params?.tenant_id,
       page: params?.page,
       page_size: params?.page_size,
+      created_after: params?.created_after,
+      created_before: params?.created_before,
+      status: params?.status,
+      created_by: params?.created_by,
+      encrypted: params?.encrypted,
+      search: params?.search,
+      sort_by: params?.sort_by,
+      sort_order: params?.sort_order,
+      page_token: params?.page_token,
     });
     return backupApi.get<ListFullBackupsResponse>(`/backups/full${qs}`, options);
   },
@@ -250,9 +780,131 @@ export const FullBackupService = {
   get: (id: string, options?: RequestOptions) =>
     backupApi.get<GetFullBackupResponse>(`/backups/full/${id}`, options),
 
+  update: (id: string, data: UpdateFullBackupRequest, options?: RequestOptions) =>
+    backupApi.patch<UpdateFullBackupResponse>(`/backups/full/${id}`, data, options),
+
   download: (id: string, data?: DownloadFullBackupRequest, options?: RequestOptions) =>
     backupApi.post<DownloadFullBackupResponse>(`/backups/full/${id}/download`, data ?? {}, options),
 
   delete: (id: string, options?: RequestOptions) =>
     backupApi.delete<void>(`/backups/full/${id}`, options),
+
+  pin: (id: string, reason?: string, options?: RequestOptions) =>
+    backupApi.post<PinBackupResponse>(`/backups/${id}/pin`, { full: true, reason }, options),
+
+  /** Requires a platform admin. */
+  unpin: (id: string, options?: RequestOptions) =>
+    backupApi.post<void>(`/backups/${id}/unpin`, { full: true }, options),
+
+  verify: (id: string, password?: string, options?: RequestOptions) =>
+    backupApi.post<VerifyBackupResponse>(`/backups/${id}/verify`, { full: true, password }, options),
+
+  replicate: (id: string, options?: RequestOptions) =>
+    backupApi.post<ReplicateBackupResponse>(`/backups/${id}/replicate`, { full: true }, options),
+};
+
+// ==================== Target Service ====================
+
+export const TargetService = {
+  preflight: (data: PreflightCheckRequest, options?: RequestOptions) =>
+    backupApi.post<PreflightCheckResponse>('/backups/preflight', data, options),
+};
+
+// ==================== Statistics Service ====================
+
+export const StatisticsService = {
+  get: (
+    params?: {
+      tenant_id?: number;
+      module_id?: string;
+      start_time?: string;
+      end_time?: string;
+      bucket?: 'hour' | 'day' | 'week';
+    },
+    options?: RequestOptions,
+  ) => {
+    const qs = buildQuery({
+      tenant_id: params?.tenant_id,
+      module_id: params?.module_id,
+      start_time: params?.start_time,
+      end_time: params?.end_time,
+      bucket: params?.bucket,
+    });
+    return backupApi.get<GetBackupStatisticsResponse>(`/backups/statistics${qs}`, options);
+  },
+
+  freshness: (
+    params?: {
+      tenant_id?: number;
+      module_id?: string;
+    },
+    options?: RequestOptions,
+  ) => {
+    const qs = buildQuery({
+      tenant_id: params?.tenant_id,
+      module_id: params?.module_id,
+    });
+    return backupApi.get<GetBackupFreshnessResponse>(`/backups/freshness${qs}`, options);
+  },
+
+  mirror: (options?: RequestOptions) =>
+    backupApi.get<GetMirrorStatusResponse>(`/backups/mirror`, options),
+
+  federation: (options?: RequestOptions) =>
+    backupApi.get<GetFederationStatusResponse>(`/backups/federation`, options),
+
+  quota: (
+    params?: {
+      tenant_id?: number;
+      module_id?: string;
+    },
+    options?: RequestOptions,
+  ) => {
+    const qs = buildQuery({
+      tenant_id: params?.tenant_id,
+      module_id: params?.module_id,
+    });
+    return backupApi.get<GetQuotaUsageResponse>(`/backups/quota${qs}`, options);
+  },
+
+  catalog: (
+    params?: {
+      format?: 'csv' | 'json';
+      tenant_id?: number;
+    },
+    options?: RequestOptions,
+  ) => {
+    const qs = buildQuery({
+      format: params?.format,
+      tenant_id: params?.tenant_id,
+    });
+    return backupApi.get<ExportCatalogResponse>(`/backups/catalog${qs}`, options);
+  },
+
+  importCatalog: (data: ImportCatalogRequest, options?: RequestOptions) =>
+    backupApi.post<ImportCatalogResponse>(`/backups/catalog/import`, data, options),
+
+  exportRepository: (data: ExportRepositoryRequest, options?: RequestOptions) =>
+    backupApi.post<ExportRepositoryResponse>(`/backups/repository/export`, data, options),
+
+  importRepository: (data: ImportRepositoryRequest, options?: RequestOptions) =>
+    backupApi.post<ImportRepositoryResponse>(`/backups/repository/import`, data, options),
+
+  report: (
+    params?: {
+      period?: 'daily' | 'weekly';
+      end_time?: string;
+      tenant_id?: number;
+      format?: 'json' | 'html';
+    },
+    options?: RequestOptions,
+  ) => {
+    const qs = buildQuery({
+      period: params?.period,
+      end_time: params?.end_time,
+      tenant_id: params?.tenant_id,
+      format: params?.format,
+    });
+    return backupApi.get<GenerateBackupReportResponse>(`/backups/report${qs}`, options);
+  },
 };
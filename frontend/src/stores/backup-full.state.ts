@@ -2,6 +2,7 @@ import { defineStore } from 'pinia';
 
 import {
   FullBackupService,
+  type BackupListFilters,
   type CreateFullBackupRequest,
   type CreateFullBackupResponse,
   type DownloadFullBackupResponse,
@@ -10,13 +11,16 @@ import {
   type ListFullBackupsResponse,
   type RestoreFullBackupRequest,
   type RestoreFullBackupResponse,
+  type UpdateFullBackupRequest,
 } from '../api/services';
 
 export const useBackupFullStore = defineStore('backup-full', () => {
   async function listFullBackups(
     paging?: { page?: number; pageSize?: number },
+    filters?: BackupListFilters | null,
   ): Promise<ListFullBackupsResponse> {
     return await FullBackupService.list({
+      ...filters,
       page: paging?.page,
       page_size: paging?.pageSize,
     });
@@ -40,6 +44,22 @@ export const useBackupFullStore = defineStore('backup-full', () => {
     return await FullBackupService.restore(backupId, data);
   }
 
+  async function updateFullBackup(
+    id: string,
+    data: UpdateFullBackupRequest,
+  ): Promise<FullBackupInfo> {
+    const resp = await FullBackupService.update(id, data);
+    return resp.backup;
+  }
+
+  async function pinFullBackup(id: string, reason?: string): Promise<void> {
+    await FullBackupService.pin(id, reason);
+  }
+
+  async function unpinFullBackup(id: string): Promise<void> {
+    await FullBackupService.unpin(id);
+  }
+
   async function deleteFullBackup(id: string): Promise<void> {
     return await FullBackupService.delete(id);
   }
@@ -77,8 +97,11 @@ export const useBackupFullStore = defineStore('backup-full', () => {
     listFullBackups,
     getFullBackup,
     createFullBackup,
+    updateFullBackup,
     restoreFullBackup,
     deleteFullBackup,
+    pinFullBackup,
+    unpinFullBackup,
     downloadFullBackup,
   };
 });
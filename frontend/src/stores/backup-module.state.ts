@@ -3,6 +3,7 @@ import { defineStore } from 'pinia';
 import {
   ModuleBackupService,
   type BackupInfo,
+  type BackupListFilters,
   type CreateModuleBackupRequest,
   type CreateModuleBackupResponse,
   type DownloadBackupResponse,
@@ -15,12 +16,12 @@ import {
 export const useBackupModuleStore = defineStore('backup-module', () => {
   async function listBackups(
     paging?: { page?: number; pageSize?: number },
-    filters?: { module_id?: string } | null,
+    filters?: ({ module_id?: string } & BackupListFilters) | null,
   ): Promise<ListBackupsResponse> {
     return await ModuleBackupService.list({
+      ...filters,
       page: paging?.page,
       page_size: paging?.pageSize,
-      module_id: filters?.module_id,
     });
   }
 
@@ -42,6 +43,14 @@ export const useBackupModuleStore = defineStore('backup-module', () => {
     return await ModuleBackupService.restore(backupId, data);
   }
 
+  async function pinBackup(id: string, reason?: string): Promise<void> {
+    await ModuleBackupService.pin(id, reason);
+  }
+
+  async function unpinBackup(id: string): Promise<void> {
+    await ModuleBackupService.unpin(id);
+  }
+
   async function deleteBackup(id: string): Promise<void> {
     return await ModuleBackupService.delete(id);
   }
@@ -79,6 +88,8 @@ export const useBackupModuleStore = defineStore('backup-module', () => {
     createBackup,
     restoreBackup,
     deleteBackup,
+    pinBackup,
+    unpinBackup,
     downloadBackup,
   };
 });
@@ -25,8 +25,10 @@ const (
 
 type ModuleTarget struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ModuleId      string                 `protobuf:"bytes,1,opt,name=module_id,json=moduleId,proto3" json:"module_id,omitempty"`             // e.g., "ipam"
-	GrpcEndpoint  string                 `protobuf:"bytes,2,opt,name=grpc_endpoint,json=grpcEndpoint,proto3" json:"grpc_endpoint,omitempty"` // e.g., "ipam-service:9400"
+	ModuleId      string                 `protobuf:"bytes,1,opt,name=module_id,json=moduleId,proto3" json:"module_id,omitempty"`                // e.g., "ipam"
+	GrpcEndpoint  string                 `protobuf:"bytes,2,opt,name=grpc_endpoint,json=grpcEndpoint,proto3" json:"grpc_endpoint,omitempty"`    // e.g., "ipam-service:9400" or "discovery:///ipam-service"
+	Tls           *TargetTLS             `protobuf:"bytes,3,opt,name=tls,proto3" json:"tls,omitempty"`                                          // optional override of the global mTLS settings
+	BackupService string                 `protobuf:"bytes,4,opt,name=backup_service,json=backupService,proto3" json:"backup_service,omitempty"` // legacy BackupService full name if not "{module_id}.service.v1.BackupService"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -75,21 +77,105 @@ func (x *ModuleTarget) GetGrpcEndpoint() string {
 	return ""
 }
 
+func (x *ModuleTarget) GetTls() *TargetTLS {
+	if x != nil {
+		return x.Tls
+	}
+	return nil
+}
+
+func (x *ModuleTarget) GetBackupService() string {
+	if x != nil {
+		return x.BackupService
+	}
+	return ""
+}
+
+// TargetTLS overrides how the orchestrator secures the connection to one module.
+type TargetTLS struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Insecure      bool                   `protobuf:"varint,1,opt,name=insecure,proto3" json:"insecure,omitempty"`                        // plaintext, no TLS at all; only honored for modules in BACKUP_TLS_SKIP_VERIFY_ALLOWLIST
+	CaCertPath    string                 `protobuf:"bytes,2,opt,name=ca_cert_path,json=caCertPath,proto3" json:"ca_cert_path,omitempty"` // trust this CA (PEM) instead of the global one; resolved inside BACKUP_TLS_CA_DIR
+	SkipVerify    bool                   `protobuf:"varint,3,opt,name=skip_verify,json=skipVerify,proto3" json:"skip_verify,omitempty"`  // only honored for modules in BACKUP_TLS_SKIP_VERIFY_ALLOWLIST
+	ServerName    string                 `protobuf:"bytes,4,opt,name=server_name,json=serverName,proto3" json:"server_name,omitempty"`   // expected server certificate name, if not the dialed host
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TargetTLS) Reset() {
+	*x = TargetTLS{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TargetTLS) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TargetTLS) ProtoMessage() {}
+
+func (x *TargetTLS) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TargetTLS.ProtoReflect.Descriptor instead.
+func (*TargetTLS) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TargetTLS) GetInsecure() bool {
+	if x != nil {
+		return x.Insecure
+	}
+	return false
+}
+
+func (x *TargetTLS) GetCaCertPath() string {
+	if x != nil {
+		return x.CaCertPath
+	}
+	return ""
+}
+
+func (x *TargetTLS) GetSkipVerify() bool {
+	if x != nil {
+		return x.SkipVerify
+	}
+	return false
+}
+
+func (x *TargetTLS) GetServerName() string {
+	if x != nil {
+		return x.ServerName
+	}
+	return ""
+}
+
 // Single module backup
 type CreateModuleBackupRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Target         *ModuleTarget          `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
-	TenantId       *uint32                `protobuf:"varint,2,opt,name=tenant_id,json=tenantId,proto3,oneof" json:"tenant_id,omitempty"` // 0 = full cross-tenant (platform admin only)
-	Description    string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	IncludeSecrets bool                   `protobuf:"varint,4,opt,name=include_secrets,json=includeSecrets,proto3" json:"include_secrets,omitempty"` // include Vault passwords in export
-	Password       string                 `protobuf:"bytes,5,opt,name=password,proto3" json:"password,omitempty"`                                    // if set, backup is AES-256-GCM encrypted
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Target           *ModuleTarget          `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	TenantId         *uint32                `protobuf:"varint,2,opt,name=tenant_id,json=tenantId,proto3,oneof" json:"tenant_id,omitempty"` // 0 = full cross-tenant (platform admin only)
+	Description      string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	IncludeSecrets   bool                   `protobuf:"varint,4,opt,name=include_secrets,json=includeSecrets,proto3" json:"include_secrets,omitempty"`             // include Vault passwords in export
+	Password         string                 `protobuf:"bytes,5,opt,name=password,proto3" json:"password,omitempty"`                                                // if set, backup is AES-256-GCM encrypted
+	CompressionLevel *int32                 `protobuf:"varint,6,opt,name=compression_level,json=compressionLevel,proto3,oneof" json:"compression_level,omitempty"` // 1-9 for gzip, 1-22 for zstd; 0 = algorithm default; unset = server default
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *CreateModuleBackupRequest) Reset() {
 	*x = CreateModuleBackupRequest{}
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[1]
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -101,7 +187,7 @@ func (x *CreateModuleBackupRequest) String() string {
 func (*CreateModuleBackupRequest) ProtoMessage() {}
 
 func (x *CreateModuleBackupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[1]
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -114,7 +200,7 @@ func (x *CreateModuleBackupRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateModuleBackupRequest.ProtoReflect.Descriptor instead.
 func (*CreateModuleBackupRequest) Descriptor() ([]byte, []int) {
-	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{1}
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *CreateModuleBackupRequest) GetTarget() *ModuleTarget {
@@ -152,29 +238,56 @@ func (x *CreateModuleBackupRequest) GetPassword() string {
 	return ""
 }
 
+func (x *CreateModuleBackupRequest) GetCompressionLevel() int32 {
+	if x != nil && x.CompressionLevel != nil {
+		return *x.CompressionLevel
+	}
+	return 0
+}
+
 type BackupInfo struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	ModuleId      string                 `protobuf:"bytes,2,opt,name=module_id,json=moduleId,proto3" json:"module_id,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	TenantId      uint32                 `protobuf:"varint,4,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
-	FullBackup    bool                   `protobuf:"varint,5,opt,name=full_backup,json=fullBackup,proto3" json:"full_backup,omitempty"`
-	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"` // "completed", "failed"
-	SizeBytes     int64                  `protobuf:"varint,7,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
-	EntityCounts  map[string]int64       `protobuf:"bytes,8,rep,name=entity_counts,json=entityCounts,proto3" json:"entity_counts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	CreatedBy     string                 `protobuf:"bytes,10,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
-	Version       string                 `protobuf:"bytes,11,opt,name=version,proto3" json:"version,omitempty"`
-	Warnings      []string               `protobuf:"bytes,12,rep,name=warnings,proto3" json:"warnings,omitempty"`
-	Encrypted     bool                   `protobuf:"varint,13,opt,name=encrypted,proto3" json:"encrypted,omitempty"`
-	SchemaVersion int32                  `protobuf:"varint,14,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Id                    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ModuleId              string                 `protobuf:"bytes,2,opt,name=module_id,json=moduleId,proto3" json:"module_id,omitempty"`
+	Description           string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	TenantId              uint32                 `protobuf:"varint,4,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	FullBackup            bool                   `protobuf:"varint,5,opt,name=full_backup,json=fullBackup,proto3" json:"full_backup,omitempty"`
+	Status                string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"` // "completed", "failed", "corrupt" (metadata unreadable)
+	SizeBytes             int64                  `protobuf:"varint,7,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	EntityCounts          map[string]int64       `protobuf:"bytes,8,rep,name=entity_counts,json=entityCounts,proto3" json:"entity_counts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	CreatedAt             *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CreatedBy             string                 `protobuf:"bytes,10,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	Version               string                 `protobuf:"bytes,11,opt,name=version,proto3" json:"version,omitempty"`
+	Warnings              []string               `protobuf:"bytes,12,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	Encrypted             bool                   `protobuf:"varint,13,opt,name=encrypted,proto3" json:"encrypted,omitempty"`
+	SchemaVersion         int32                  `protobuf:"varint,14,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	DurationMs            int64                  `protobuf:"varint,15,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`                                      // time taken to export and store the backup
+	Timings               *PhaseTimings          `protobuf:"bytes,16,opt,name=timings,proto3" json:"timings,omitempty"`                                                               // where duration_ms went
+	CompressedSizeBytes   int64                  `protobuf:"varint,17,opt,name=compressed_size_bytes,json=compressedSizeBytes,proto3" json:"compressed_size_bytes,omitempty"`         // stored size after compression (before encryption)
+	CompressionRatio      float64                `protobuf:"fixed64,18,opt,name=compression_ratio,json=compressionRatio,proto3" json:"compression_ratio,omitempty"`                   // size_bytes / compressed_size_bytes
+	ThroughputBytesPerSec int64                  `protobuf:"varint,19,opt,name=throughput_bytes_per_sec,json=throughputBytesPerSec,proto3" json:"throughput_bytes_per_sec,omitempty"` // size_bytes over duration_ms
+	Sha256                string                 `protobuf:"bytes,20,opt,name=sha256,proto3" json:"sha256,omitempty"`                                                                 // hex SHA-256 of the uncompressed export
+	Pin                   *BackupPin             `protobuf:"bytes,21,opt,name=pin,proto3" json:"pin,omitempty"`                                                                       // set while the backup is protected from deletion
+	Compression           string                 `protobuf:"bytes,22,opt,name=compression,proto3" json:"compression,omitempty"`                                                       // "gzip" or "zstd"; empty means gzip
+	CompressionLevel      int32                  `protobuf:"varint,23,opt,name=compression_level,json=compressionLevel,proto3" json:"compression_level,omitempty"`                    // level the data was compressed with; 0 = unknown
+	DeltaBaseId           string                 `protobuf:"bytes,24,opt,name=delta_base_id,json=deltaBaseId,proto3" json:"delta_base_id,omitempty"`                                  // set when the data is stored as a delta against this earlier backup
+	DeltaDepth            int32                  `protobuf:"varint,25,opt,name=delta_depth,json=deltaDepth,proto3" json:"delta_depth,omitempty"`                                      // deltas between this backup and the nearest fully stored one
+	StoredSha256          string                 `protobuf:"bytes,26,opt,name=stored_sha256,json=storedSha256,proto3" json:"stored_sha256,omitempty"`                                 // hex SHA-256 of the data file as stored (compressed, then encrypted); checked on every load
+	LastVerification      *BackupVerification    `protobuf:"bytes,27,opt,name=last_verification,json=lastVerification,proto3" json:"last_verification,omitempty"`                     // outcome of the last VerifyBackup
+	ValidationStatus      string                 `protobuf:"bytes,28,opt,name=validation_status,json=validationStatus,proto3" json:"validation_status,omitempty"`                     // checks on the export when it was taken: "usable", "suspect"; empty for older backups
+	ValidationReasons     []string               `protobuf:"bytes,29,rep,name=validation_reasons,json=validationReasons,proto3" json:"validation_reasons,omitempty"`                  // why the export is suspect
+	Replication           *ReplicationStatus     `protobuf:"bytes,30,opt,name=replication,proto3" json:"replication,omitempty"`                                                       // off-site copy; unset when replication is off
+	Mirror                *ReplicationStatus     `protobuf:"bytes,31,opt,name=mirror,proto3" json:"mirror,omitempty"`                                                                 // copy in object storage; unset when mirroring is off
+	Site                  string                 `protobuf:"bytes,32,opt,name=site,proto3" json:"site,omitempty"`                                                                     // site or region the backup was taken at (BACKUP_SITE)
+	Origin                *ReplicationStatus     `protobuf:"bytes,33,opt,name=origin,proto3" json:"origin,omitempty"`                                                                 // copy on the primary the backup was pulled from; unset otherwise
+	Copies                []*BackupCopy          `protobuf:"bytes,34,rep,name=copies,proto3" json:"copies,omitempty"`                                                                 // every known copy; filled in by GetBackup, not stored
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
 func (x *BackupInfo) Reset() {
 	*x = BackupInfo{}
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[2]
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -186,7 +299,7 @@ func (x *BackupInfo) String() string {
 func (*BackupInfo) ProtoMessage() {}
 
 func (x *BackupInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[2]
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -199,7 +312,7 @@ func (x *BackupInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BackupInfo.ProtoReflect.Descriptor instead.
 func (*BackupInfo) Descriptor() ([]byte, []int) {
-	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{2}
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *BackupInfo) GetId() string {
@@ -300,75 +413,171 @@ func (x *BackupInfo) GetSchemaVersion() int32 {
 	return 0
 }
 
-type CreateModuleBackupResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Backup        *BackupInfo            `protobuf:"bytes,1,opt,name=backup,proto3" json:"backup,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *BackupInfo) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
 }
 
-func (x *CreateModuleBackupResponse) Reset() {
-	*x = CreateModuleBackupResponse{}
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[3]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *BackupInfo) GetTimings() *PhaseTimings {
+	if x != nil {
+		return x.Timings
+	}
+	return nil
 }
 
-func (x *CreateModuleBackupResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *BackupInfo) GetCompressedSizeBytes() int64 {
+	if x != nil {
+		return x.CompressedSizeBytes
+	}
+	return 0
 }
 
-func (*CreateModuleBackupResponse) ProtoMessage() {}
+func (x *BackupInfo) GetCompressionRatio() float64 {
+	if x != nil {
+		return x.CompressionRatio
+	}
+	return 0
+}
 
-func (x *CreateModuleBackupResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[3]
+func (x *BackupInfo) GetThroughputBytesPerSec() int64 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.ThroughputBytesPerSec
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use CreateModuleBackupResponse.ProtoReflect.Descriptor instead.
-func (*CreateModuleBackupResponse) Descriptor() ([]byte, []int) {
-	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{3}
+func (x *BackupInfo) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
 }
 
-func (x *CreateModuleBackupResponse) GetBackup() *BackupInfo {
+func (x *BackupInfo) GetPin() *BackupPin {
 	if x != nil {
-		return x.Backup
+		return x.Pin
 	}
 	return nil
 }
 
-// Restore
-type RestoreModuleBackupRequest struct {
+func (x *BackupInfo) GetCompression() string {
+	if x != nil {
+		return x.Compression
+	}
+	return ""
+}
+
+func (x *BackupInfo) GetCompressionLevel() int32 {
+	if x != nil {
+		return x.CompressionLevel
+	}
+	return 0
+}
+
+func (x *BackupInfo) GetDeltaBaseId() string {
+	if x != nil {
+		return x.DeltaBaseId
+	}
+	return ""
+}
+
+func (x *BackupInfo) GetDeltaDepth() int32 {
+	if x != nil {
+		return x.DeltaDepth
+	}
+	return 0
+}
+
+func (x *BackupInfo) GetStoredSha256() string {
+	if x != nil {
+		return x.StoredSha256
+	}
+	return ""
+}
+
+func (x *BackupInfo) GetLastVerification() *BackupVerification {
+	if x != nil {
+		return x.LastVerification
+	}
+	return nil
+}
+
+func (x *BackupInfo) GetValidationStatus() string {
+	if x != nil {
+		return x.ValidationStatus
+	}
+	return ""
+}
+
+func (x *BackupInfo) GetValidationReasons() []string {
+	if x != nil {
+		return x.ValidationReasons
+	}
+	return nil
+}
+
+func (x *BackupInfo) GetReplication() *ReplicationStatus {
+	if x != nil {
+		return x.Replication
+	}
+	return nil
+}
+
+func (x *BackupInfo) GetMirror() *ReplicationStatus {
+	if x != nil {
+		return x.Mirror
+	}
+	return nil
+}
+
+func (x *BackupInfo) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+func (x *BackupInfo) GetOrigin() *ReplicationStatus {
+	if x != nil {
+		return x.Origin
+	}
+	return nil
+}
+
+func (x *BackupInfo) GetCopies() []*BackupCopy {
+	if x != nil {
+		return x.Copies
+	}
+	return nil
+}
+
+// A pinned backup cannot be deleted, by hand or by retention, until a
+// platform admin unpins it
+type BackupPin struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	BackupId      string                 `protobuf:"bytes,1,opt,name=backup_id,json=backupId,proto3" json:"backup_id,omitempty"`
-	Target        *ModuleTarget          `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
-	Mode          RestoreMode            `protobuf:"varint,3,opt,name=mode,proto3,enum=backup.service.v1.RestoreMode" json:"mode,omitempty"`
-	Password      string                 `protobuf:"bytes,4,opt,name=password,proto3" json:"password,omitempty"` // required if backup is encrypted
+	PinnedBy      string                 `protobuf:"bytes,1,opt,name=pinned_by,json=pinnedBy,proto3" json:"pinned_by,omitempty"`
+	PinnedAt      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=pinned_at,json=pinnedAt,proto3" json:"pinned_at,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RestoreModuleBackupRequest) Reset() {
-	*x = RestoreModuleBackupRequest{}
+func (x *BackupPin) Reset() {
+	*x = BackupPin{}
 	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RestoreModuleBackupRequest) String() string {
+func (x *BackupPin) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RestoreModuleBackupRequest) ProtoMessage() {}
+func (*BackupPin) ProtoMessage() {}
 
-func (x *RestoreModuleBackupRequest) ProtoReflect() protoreflect.Message {
+func (x *BackupPin) ProtoReflect() protoreflect.Message {
 	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -380,65 +589,60 @@ func (x *RestoreModuleBackupRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RestoreModuleBackupRequest.ProtoReflect.Descriptor instead.
-func (*RestoreModuleBackupRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BackupPin.ProtoReflect.Descriptor instead.
+func (*BackupPin) Descriptor() ([]byte, []int) {
 	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *RestoreModuleBackupRequest) GetBackupId() string {
+func (x *BackupPin) GetPinnedBy() string {
 	if x != nil {
-		return x.BackupId
+		return x.PinnedBy
 	}
 	return ""
 }
 
-func (x *RestoreModuleBackupRequest) GetTarget() *ModuleTarget {
+func (x *BackupPin) GetPinnedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Target
+		return x.PinnedAt
 	}
 	return nil
 }
 
-func (x *RestoreModuleBackupRequest) GetMode() RestoreMode {
-	if x != nil {
-		return x.Mode
-	}
-	return RestoreMode_RESTORE_MODE_SKIP
-}
-
-func (x *RestoreModuleBackupRequest) GetPassword() string {
+func (x *BackupPin) GetReason() string {
 	if x != nil {
-		return x.Password
+		return x.Reason
 	}
 	return ""
 }
 
-type RestoreModuleBackupResponse struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	Success           bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Results           []*EntityImportResult  `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
-	Warnings          []string               `protobuf:"bytes,3,rep,name=warnings,proto3" json:"warnings,omitempty"`
-	SourceVersion     int32                  `protobuf:"varint,4,opt,name=source_version,json=sourceVersion,proto3" json:"source_version,omitempty"`
-	TargetVersion     int32                  `protobuf:"varint,5,opt,name=target_version,json=targetVersion,proto3" json:"target_version,omitempty"`
-	MigrationsApplied int32                  `protobuf:"varint,6,opt,name=migrations_applied,json=migrationsApplied,proto3" json:"migrations_applied,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+// Where a backup has been copied off-site, by replication or the mirror
+type ReplicationStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         string                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`   // "pending", "replicated" or "failed"
+	Target        string                 `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"` // orchestrator or mirror URL the copy goes to
+	Attempts      int32                  `protobuf:"varint,3,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	LastError     string                 `protobuf:"bytes,4,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"` // set while failed
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ReplicatedAt  *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=replicated_at,json=replicatedAt,proto3" json:"replicated_at,omitempty"`
+	Site          string                 `protobuf:"bytes,7,opt,name=site,proto3" json:"site,omitempty"` // site or region the copy lives in
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RestoreModuleBackupResponse) Reset() {
-	*x = RestoreModuleBackupResponse{}
+func (x *ReplicationStatus) Reset() {
+	*x = ReplicationStatus{}
 	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RestoreModuleBackupResponse) String() string {
+func (x *ReplicationStatus) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RestoreModuleBackupResponse) ProtoMessage() {}
+func (*ReplicationStatus) ProtoMessage() {}
 
-func (x *RestoreModuleBackupResponse) ProtoReflect() protoreflect.Message {
+func (x *ReplicationStatus) ProtoReflect() protoreflect.Message {
 	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -450,78 +654,86 @@ func (x *RestoreModuleBackupResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RestoreModuleBackupResponse.ProtoReflect.Descriptor instead.
-func (*RestoreModuleBackupResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReplicationStatus.ProtoReflect.Descriptor instead.
+func (*ReplicationStatus) Descriptor() ([]byte, []int) {
 	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *RestoreModuleBackupResponse) GetSuccess() bool {
+func (x *ReplicationStatus) GetState() string {
 	if x != nil {
-		return x.Success
+		return x.State
 	}
-	return false
+	return ""
 }
 
-func (x *RestoreModuleBackupResponse) GetResults() []*EntityImportResult {
+func (x *ReplicationStatus) GetTarget() string {
 	if x != nil {
-		return x.Results
+		return x.Target
 	}
-	return nil
+	return ""
 }
 
-func (x *RestoreModuleBackupResponse) GetWarnings() []string {
+func (x *ReplicationStatus) GetAttempts() int32 {
 	if x != nil {
-		return x.Warnings
+		return x.Attempts
 	}
-	return nil
+	return 0
 }
 
-func (x *RestoreModuleBackupResponse) GetSourceVersion() int32 {
+func (x *ReplicationStatus) GetLastError() string {
 	if x != nil {
-		return x.SourceVersion
+		return x.LastError
 	}
-	return 0
+	return ""
 }
 
-func (x *RestoreModuleBackupResponse) GetTargetVersion() int32 {
+func (x *ReplicationStatus) GetUpdatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.TargetVersion
+		return x.UpdatedAt
 	}
-	return 0
+	return nil
 }
 
-func (x *RestoreModuleBackupResponse) GetMigrationsApplied() int32 {
+func (x *ReplicationStatus) GetReplicatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.MigrationsApplied
+		return x.ReplicatedAt
 	}
-	return 0
+	return nil
 }
 
-// List
-type ListBackupsRequest struct {
+func (x *ReplicationStatus) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+// One copy of a backup a restore can read
+type BackupCopy struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ModuleId      string                 `protobuf:"bytes,1,opt,name=module_id,json=moduleId,proto3" json:"module_id,omitempty"` // filter by module (optional)
-	TenantId      *uint32                `protobuf:"varint,2,opt,name=tenant_id,json=tenantId,proto3,oneof" json:"tenant_id,omitempty"`
-	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`        // "local", "mirror" or "peer"
+	Site          string                 `protobuf:"bytes,2,opt,name=site,proto3" json:"site,omitempty"`            // site or region it lives in
+	Target        string                 `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`        // storage path, mirror URL or peer address
+	State         string                 `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`          // the backup's status for the local copy, the copy status otherwise
+	Available     bool                   `protobuf:"varint,5,opt,name=available,proto3" json:"available,omitempty"` // holds the backup's data, as far as this orchestrator knows
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListBackupsRequest) Reset() {
-	*x = ListBackupsRequest{}
+func (x *BackupCopy) Reset() {
+	*x = BackupCopy{}
 	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListBackupsRequest) String() string {
+func (x *BackupCopy) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListBackupsRequest) ProtoMessage() {}
+func (*BackupCopy) ProtoMessage() {}
 
-func (x *ListBackupsRequest) ProtoReflect() protoreflect.Message {
+func (x *BackupCopy) ProtoReflect() protoreflect.Message {
 	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -533,62 +745,5842 @@ func (x *ListBackupsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListBackupsRequest.ProtoReflect.Descriptor instead.
-func (*ListBackupsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BackupCopy.ProtoReflect.Descriptor instead.
+func (*BackupCopy) Descriptor() ([]byte, []int) {
 	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *ListBackupsRequest) GetModuleId() string {
+func (x *BackupCopy) GetSource() string {
 	if x != nil {
-		return x.ModuleId
+		return x.Source
 	}
 	return ""
 }
 
-func (x *ListBackupsRequest) GetTenantId() uint32 {
-	if x != nil && x.TenantId != nil {
-		return *x.TenantId
+func (x *BackupCopy) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+func (x *BackupCopy) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *BackupCopy) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *BackupCopy) GetAvailable() bool {
+	if x != nil {
+		return x.Available
+	}
+	return false
+}
+
+// Per-phase durations of one module backup
+type PhaseTimings struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExportMs      int64                  `protobuf:"varint,1,opt,name=export_ms,json=exportMs,proto3" json:"export_ms,omitempty"` // module ExportBackup call, including retries
+	CompressMs    int64                  `protobuf:"varint,2,opt,name=compress_ms,json=compressMs,proto3" json:"compress_ms,omitempty"`
+	EncryptMs     int64                  `protobuf:"varint,3,opt,name=encrypt_ms,json=encryptMs,proto3" json:"encrypt_ms,omitempty"` // 0 when unencrypted
+	WriteMs       int64                  `protobuf:"varint,4,opt,name=write_ms,json=writeMs,proto3" json:"write_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PhaseTimings) Reset() {
+	*x = PhaseTimings{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PhaseTimings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhaseTimings) ProtoMessage() {}
+
+func (x *PhaseTimings) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhaseTimings.ProtoReflect.Descriptor instead.
+func (*PhaseTimings) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PhaseTimings) GetExportMs() int64 {
+	if x != nil {
+		return x.ExportMs
 	}
 	return 0
 }
 
-func (x *ListBackupsRequest) GetPage() int32 {
+func (x *PhaseTimings) GetCompressMs() int64 {
 	if x != nil {
-		return x.Page
+		return x.CompressMs
 	}
 	return 0
 }
 
-func (x *ListBackupsRequest) GetPageSize() int32 {
+func (x *PhaseTimings) GetEncryptMs() int64 {
 	if x != nil {
-		return x.PageSize
+		return x.EncryptMs
+	}
+	return 0
+}
+
+func (x *PhaseTimings) GetWriteMs() int64 {
+	if x != nil {
+		return x.WriteMs
 	}
 	return 0
 }
 
-type ListBackupsResponse struct {
+type CreateModuleBackupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Backup        *BackupInfo            `protobuf:"bytes,1,opt,name=backup,proto3" json:"backup,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateModuleBackupResponse) Reset() {
+	*x = CreateModuleBackupResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateModuleBackupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateModuleBackupResponse) ProtoMessage() {}
+
+func (x *CreateModuleBackupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateModuleBackupResponse.ProtoReflect.Descriptor instead.
+func (*CreateModuleBackupResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateModuleBackupResponse) GetBackup() *BackupInfo {
+	if x != nil {
+		return x.Backup
+	}
+	return nil
+}
+
+// Restore
+type RestoreModuleBackupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BackupId      string                 `protobuf:"bytes,1,opt,name=backup_id,json=backupId,proto3" json:"backup_id,omitempty"`
+	Target        *ModuleTarget          `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	Mode          RestoreMode            `protobuf:"varint,3,opt,name=mode,proto3,enum=backup.service.v1.RestoreMode" json:"mode,omitempty"`
+	Password      string                 `protobuf:"bytes,4,opt,name=password,proto3" json:"password,omitempty"` // required if backup is encrypted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreModuleBackupRequest) Reset() {
+	*x = RestoreModuleBackupRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreModuleBackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreModuleBackupRequest) ProtoMessage() {}
+
+func (x *RestoreModuleBackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreModuleBackupRequest.ProtoReflect.Descriptor instead.
+func (*RestoreModuleBackupRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RestoreModuleBackupRequest) GetBackupId() string {
+	if x != nil {
+		return x.BackupId
+	}
+	return ""
+}
+
+func (x *RestoreModuleBackupRequest) GetTarget() *ModuleTarget {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *RestoreModuleBackupRequest) GetMode() RestoreMode {
+	if x != nil {
+		return x.Mode
+	}
+	return RestoreMode_RESTORE_MODE_SKIP
+}
+
+func (x *RestoreModuleBackupRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type RestoreModuleBackupResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Success           bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Results           []*EntityImportResult  `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	Warnings          []string               `protobuf:"bytes,3,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	SourceVersion     int32                  `protobuf:"varint,4,opt,name=source_version,json=sourceVersion,proto3" json:"source_version,omitempty"`
+	TargetVersion     int32                  `protobuf:"varint,5,opt,name=target_version,json=targetVersion,proto3" json:"target_version,omitempty"`
+	MigrationsApplied int32                  `protobuf:"varint,6,opt,name=migrations_applied,json=migrationsApplied,proto3" json:"migrations_applied,omitempty"`
+	Source            *BackupCopy            `protobuf:"bytes,7,opt,name=source,proto3" json:"source,omitempty"` // copy the data was read from
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RestoreModuleBackupResponse) Reset() {
+	*x = RestoreModuleBackupResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreModuleBackupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreModuleBackupResponse) ProtoMessage() {}
+
+func (x *RestoreModuleBackupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreModuleBackupResponse.ProtoReflect.Descriptor instead.
+func (*RestoreModuleBackupResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RestoreModuleBackupResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RestoreModuleBackupResponse) GetResults() []*EntityImportResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *RestoreModuleBackupResponse) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+func (x *RestoreModuleBackupResponse) GetSourceVersion() int32 {
+	if x != nil {
+		return x.SourceVersion
+	}
+	return 0
+}
+
+func (x *RestoreModuleBackupResponse) GetTargetVersion() int32 {
+	if x != nil {
+		return x.TargetVersion
+	}
+	return 0
+}
+
+func (x *RestoreModuleBackupResponse) GetMigrationsApplied() int32 {
+	if x != nil {
+		return x.MigrationsApplied
+	}
+	return 0
+}
+
+func (x *RestoreModuleBackupResponse) GetSource() *BackupCopy {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+// List
+type ListBackupsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ModuleId      string                 `protobuf:"bytes,1,opt,name=module_id,json=moduleId,proto3" json:"module_id,omitempty"` // filter by module (optional)
+	TenantId      *uint32                `protobuf:"varint,2,opt,name=tenant_id,json=tenantId,proto3,oneof" json:"tenant_id,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	CreatedAfter  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`    // inclusive
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"` // exclusive
+	Status        string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`                                    // e.g. "completed", "failed"
+	CreatedBy     string                 `protobuf:"bytes,8,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	Encrypted     *bool                  `protobuf:"varint,9,opt,name=encrypted,proto3,oneof" json:"encrypted,omitempty"`
+	Search        string                 `protobuf:"bytes,10,opt,name=search,proto3" json:"search,omitempty"`                        // case-insensitive match on ID, module and description
+	SortBy        string                 `protobuf:"bytes,11,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`          // created_at (default), size, module_id or status
+	SortOrder     string                 `protobuf:"bytes,12,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"` // asc or desc; default desc for created_at and size, asc otherwise
+	PageToken     string                 `protobuf:"bytes,13,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token of the previous call; replaces page. Keep filters and sort unchanged.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBackupsRequest) Reset() {
+	*x = ListBackupsRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBackupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBackupsRequest) ProtoMessage() {}
+
+func (x *ListBackupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBackupsRequest.ProtoReflect.Descriptor instead.
+func (*ListBackupsRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListBackupsRequest) GetModuleId() string {
+	if x != nil {
+		return x.ModuleId
+	}
+	return ""
+}
+
+func (x *ListBackupsRequest) GetTenantId() uint32 {
+	if x != nil && x.TenantId != nil {
+		return *x.TenantId
+	}
+	return 0
+}
+
+func (x *ListBackupsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListBackupsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListBackupsRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *ListBackupsRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *ListBackupsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListBackupsRequest) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *ListBackupsRequest) GetEncrypted() bool {
+	if x != nil && x.Encrypted != nil {
+		return *x.Encrypted
+	}
+	return false
+}
+
+func (x *ListBackupsRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ListBackupsRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *ListBackupsRequest) GetSortOrder() string {
+	if x != nil {
+		return x.SortOrder
+	}
+	return ""
+}
+
+func (x *ListBackupsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListBackupsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Backups       []*BackupInfo          `protobuf:"bytes,1,rep,name=backups,proto3" json:"backups,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBackupsResponse) Reset() {
+	*x = ListBackupsResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBackupsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBackupsResponse) ProtoMessage() {}
+
+func (x *ListBackupsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBackupsResponse.ProtoReflect.Descriptor instead.
+func (*ListBackupsResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListBackupsResponse) GetBackups() []*BackupInfo {
+	if x != nil {
+		return x.Backups
+	}
+	return nil
+}
+
+func (x *ListBackupsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListBackupsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Get
+type GetBackupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBackupRequest) Reset() {
+	*x = GetBackupRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBackupRequest) ProtoMessage() {}
+
+func (x *GetBackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBackupRequest.ProtoReflect.Descriptor instead.
+func (*GetBackupRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetBackupRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetBackupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Backup        *BackupInfo            `protobuf:"bytes,1,opt,name=backup,proto3" json:"backup,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBackupResponse) Reset() {
+	*x = GetBackupResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBackupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBackupResponse) ProtoMessage() {}
+
+func (x *GetBackupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBackupResponse.ProtoReflect.Descriptor instead.
+func (*GetBackupResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetBackupResponse) GetBackup() *BackupInfo {
+	if x != nil {
+		return x.Backup
+	}
+	return nil
+}
+
+// Delete
+type DeleteBackupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBackupRequest) Reset() {
+	*x = DeleteBackupRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBackupRequest) ProtoMessage() {}
+
+func (x *DeleteBackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBackupRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBackupRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DeleteBackupRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteBackupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBackupResponse) Reset() {
+	*x = DeleteBackupResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBackupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBackupResponse) ProtoMessage() {}
+
+func (x *DeleteBackupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBackupResponse.ProtoReflect.Descriptor instead.
+func (*DeleteBackupResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DeleteBackupResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// Bulk delete of module backups, either by ID or by filter
+type DeleteBackupsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"` // delete exactly these; no filter fields may be set
+	ModuleId      string                 `protobuf:"bytes,2,opt,name=module_id,json=moduleId,proto3" json:"module_id,omitempty"`
+	TenantId      *uint32                `protobuf:"varint,3,opt,name=tenant_id,json=tenantId,proto3,oneof" json:"tenant_id,omitempty"`
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"` // older than, exclusive
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`                                    // e.g. "failed"
+	DryRun        bool                   `protobuf:"varint,6,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`                     // report what would be deleted without deleting it
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBackupsRequest) Reset() {
+	*x = DeleteBackupsRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBackupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBackupsRequest) ProtoMessage() {}
+
+func (x *DeleteBackupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBackupsRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBackupsRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DeleteBackupsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *DeleteBackupsRequest) GetModuleId() string {
+	if x != nil {
+		return x.ModuleId
+	}
+	return ""
+}
+
+func (x *DeleteBackupsRequest) GetTenantId() uint32 {
+	if x != nil && x.TenantId != nil {
+		return *x.TenantId
+	}
+	return 0
+}
+
+func (x *DeleteBackupsRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *DeleteBackupsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *DeleteBackupsRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type DeleteBackupResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Deleted       bool                   `protobuf:"varint,2,opt,name=deleted,proto3" json:"deleted,omitempty"` // false on dry runs and errors
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBackupResult) Reset() {
+	*x = DeleteBackupResult{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBackupResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBackupResult) ProtoMessage() {}
+
+func (x *DeleteBackupResult) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBackupResult.ProtoReflect.Descriptor instead.
+func (*DeleteBackupResult) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DeleteBackupResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteBackupResult) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+func (x *DeleteBackupResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type DeleteBackupsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*DeleteBackupResult  `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Deleted       int32                  `protobuf:"varint,2,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	Failed        int32                  `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBackupsResponse) Reset() {
+	*x = DeleteBackupsResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBackupsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBackupsResponse) ProtoMessage() {}
+
+func (x *DeleteBackupsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBackupsResponse.ProtoReflect.Descriptor instead.
+func (*DeleteBackupsResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DeleteBackupsResponse) GetResults() []*DeleteBackupResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *DeleteBackupsResponse) GetDeleted() int32 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+func (x *DeleteBackupsResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+// Download raw backup data
+type DownloadBackupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"` // required if backup is encrypted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadBackupRequest) Reset() {
+	*x = DownloadBackupRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadBackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadBackupRequest) ProtoMessage() {}
+
+func (x *DownloadBackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadBackupRequest.ProtoReflect.Descriptor instead.
+func (*DownloadBackupRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DownloadBackupRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DownloadBackupRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type DownloadBackupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadBackupResponse) Reset() {
+	*x = DownloadBackupResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadBackupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadBackupResponse) ProtoMessage() {}
+
+func (x *DownloadBackupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadBackupResponse.ProtoReflect.Descriptor instead.
+func (*DownloadBackupResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DownloadBackupResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *DownloadBackupResponse) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+// Full platform backup (all modules)
+type CreateFullBackupRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Targets           []*ModuleTarget        `protobuf:"bytes,1,rep,name=targets,proto3" json:"targets,omitempty"` // portal sends all registered modules
+	TenantId          *uint32                `protobuf:"varint,2,opt,name=tenant_id,json=tenantId,proto3,oneof" json:"tenant_id,omitempty"`
+	Description       string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	IncludeSecrets    bool                   `protobuf:"varint,4,opt,name=include_secrets,json=includeSecrets,proto3" json:"include_secrets,omitempty"`                  // include Vault passwords in export
+	Password          string                 `protobuf:"bytes,5,opt,name=password,proto3" json:"password,omitempty"`                                                     // if set, backup is AES-256-GCM encrypted
+	MinSuccessPercent *int32                 `protobuf:"varint,6,opt,name=min_success_percent,json=minSuccessPercent,proto3,oneof" json:"min_success_percent,omitempty"` // share of targets that must export (0-100); unset = server default
+	RequiredModules   []string               `protobuf:"bytes,7,rep,name=required_modules,json=requiredModules,proto3" json:"required_modules,omitempty"`                // modules that must export for the backup to be usable
+	TargetSelector    string                 `protobuf:"bytes,8,opt,name=target_selector,json=targetSelector,proto3" json:"target_selector,omitempty"`                   // e.g., "tier=core,backup!=false"; adds matching registered modules to targets
+	CompressionLevel  *int32                 `protobuf:"varint,9,opt,name=compression_level,json=compressionLevel,proto3,oneof" json:"compression_level,omitempty"`      // 1-9 for gzip, 1-22 for zstd; 0 = algorithm default; unset = server default
+	IdempotencyKey    string                 `protobuf:"bytes,10,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`                  // a retry with the same key resumes the unfinished backup or returns the saved one
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CreateFullBackupRequest) Reset() {
+	*x = CreateFullBackupRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateFullBackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFullBackupRequest) ProtoMessage() {}
+
+func (x *CreateFullBackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFullBackupRequest.ProtoReflect.Descriptor instead.
+func (*CreateFullBackupRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CreateFullBackupRequest) GetTargets() []*ModuleTarget {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *CreateFullBackupRequest) GetTenantId() uint32 {
+	if x != nil && x.TenantId != nil {
+		return *x.TenantId
+	}
+	return 0
+}
+
+func (x *CreateFullBackupRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateFullBackupRequest) GetIncludeSecrets() bool {
+	if x != nil {
+		return x.IncludeSecrets
+	}
+	return false
+}
+
+func (x *CreateFullBackupRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *CreateFullBackupRequest) GetMinSuccessPercent() int32 {
+	if x != nil && x.MinSuccessPercent != nil {
+		return *x.MinSuccessPercent
+	}
+	return 0
+}
+
+func (x *CreateFullBackupRequest) GetRequiredModules() []string {
+	if x != nil {
+		return x.RequiredModules
+	}
+	return nil
+}
+
+func (x *CreateFullBackupRequest) GetTargetSelector() string {
+	if x != nil {
+		return x.TargetSelector
+	}
+	return ""
+}
+
+func (x *CreateFullBackupRequest) GetCompressionLevel() int32 {
+	if x != nil && x.CompressionLevel != nil {
+		return *x.CompressionLevel
+	}
+	return 0
+}
+
+func (x *CreateFullBackupRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type FullBackupInfo struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	Id                       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Description              string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	TenantId                 uint32                 `protobuf:"varint,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	FullBackup               bool                   `protobuf:"varint,4,opt,name=full_backup,json=fullBackup,proto3" json:"full_backup,omitempty"`
+	Status                   string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	TotalSizeBytes           int64                  `protobuf:"varint,6,opt,name=total_size_bytes,json=totalSizeBytes,proto3" json:"total_size_bytes,omitempty"`
+	ModuleBackups            []*BackupInfo          `protobuf:"bytes,7,rep,name=module_backups,json=moduleBackups,proto3" json:"module_backups,omitempty"`
+	CreatedAt                *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CreatedBy                string                 `protobuf:"bytes,9,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	Errors                   []string               `protobuf:"bytes,10,rep,name=errors,proto3" json:"errors,omitempty"`
+	Encrypted                bool                   `protobuf:"varint,11,opt,name=encrypted,proto3" json:"encrypted,omitempty"`
+	ValidationStatus         string                 `protobuf:"bytes,12,opt,name=validation_status,json=validationStatus,proto3" json:"validation_status,omitempty"`    // "usable", "unusable"
+	ValidationReasons        []string               `protobuf:"bytes,13,rep,name=validation_reasons,json=validationReasons,proto3" json:"validation_reasons,omitempty"` // why the backup was flagged unusable
+	DurationMs               int64                  `protobuf:"varint,14,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`                     // time taken to export and store the backup
+	TotalCompressedSizeBytes int64                  `protobuf:"varint,15,opt,name=total_compressed_size_bytes,json=totalCompressedSizeBytes,proto3" json:"total_compressed_size_bytes,omitempty"`
+	CompressionRatio         float64                `protobuf:"fixed64,16,opt,name=compression_ratio,json=compressionRatio,proto3" json:"compression_ratio,omitempty"`                             // total_size_bytes / total_compressed_size_bytes
+	Pin                      *BackupPin             `protobuf:"bytes,17,opt,name=pin,proto3" json:"pin,omitempty"`                                                                                 // set while the backup is protected from deletion
+	Labels                   map[string]string      `protobuf:"bytes,18,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // operator annotations, e.g. ticket=OPS-123
+	UpdatedAt                *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`                                                    // last UpdateFullBackup
+	UpdatedBy                string                 `protobuf:"bytes,20,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	LastVerification         *BackupVerification    `protobuf:"bytes,21,opt,name=last_verification,json=lastVerification,proto3" json:"last_verification,omitempty"` // outcome of the last VerifyBackup
+	IdempotencyKey           string                 `protobuf:"bytes,22,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`       // set when created with one
+	Replication              *ReplicationStatus     `protobuf:"bytes,23,opt,name=replication,proto3" json:"replication,omitempty"`                                   // off-site copy; unset when replication is off
+	Mirror                   *ReplicationStatus     `protobuf:"bytes,24,opt,name=mirror,proto3" json:"mirror,omitempty"`                                             // copy in object storage; unset when mirroring is off
+	Site                     string                 `protobuf:"bytes,25,opt,name=site,proto3" json:"site,omitempty"`                                                 // site or region the backup was taken at (BACKUP_SITE)
+	Origin                   *ReplicationStatus     `protobuf:"bytes,26,opt,name=origin,proto3" json:"origin,omitempty"`                                             // copy on the primary the backup was pulled from; unset otherwise
+	Copies                   []*BackupCopy          `protobuf:"bytes,27,rep,name=copies,proto3" json:"copies,omitempty"`                                             // every known copy; filled in by GetFullBackup, not stored
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *FullBackupInfo) Reset() {
+	*x = FullBackupInfo{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FullBackupInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FullBackupInfo) ProtoMessage() {}
+
+func (x *FullBackupInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FullBackupInfo.ProtoReflect.Descriptor instead.
+func (*FullBackupInfo) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *FullBackupInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FullBackupInfo) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *FullBackupInfo) GetTenantId() uint32 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+func (x *FullBackupInfo) GetFullBackup() bool {
+	if x != nil {
+		return x.FullBackup
+	}
+	return false
+}
+
+func (x *FullBackupInfo) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *FullBackupInfo) GetTotalSizeBytes() int64 {
+	if x != nil {
+		return x.TotalSizeBytes
+	}
+	return 0
+}
+
+func (x *FullBackupInfo) GetModuleBackups() []*BackupInfo {
+	if x != nil {
+		return x.ModuleBackups
+	}
+	return nil
+}
+
+func (x *FullBackupInfo) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *FullBackupInfo) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *FullBackupInfo) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *FullBackupInfo) GetEncrypted() bool {
+	if x != nil {
+		return x.Encrypted
+	}
+	return false
+}
+
+func (x *FullBackupInfo) GetValidationStatus() string {
+	if x != nil {
+		return x.ValidationStatus
+	}
+	return ""
+}
+
+func (x *FullBackupInfo) GetValidationReasons() []string {
+	if x != nil {
+		return x.ValidationReasons
+	}
+	return nil
+}
+
+func (x *FullBackupInfo) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *FullBackupInfo) GetTotalCompressedSizeBytes() int64 {
+	if x != nil {
+		return x.TotalCompressedSizeBytes
+	}
+	return 0
+}
+
+func (x *FullBackupInfo) GetCompressionRatio() float64 {
+	if x != nil {
+		return x.CompressionRatio
+	}
+	return 0
+}
+
+func (x *FullBackupInfo) GetPin() *BackupPin {
+	if x != nil {
+		return x.Pin
+	}
+	return nil
+}
+
+func (x *FullBackupInfo) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *FullBackupInfo) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *FullBackupInfo) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *FullBackupInfo) GetLastVerification() *BackupVerification {
+	if x != nil {
+		return x.LastVerification
+	}
+	return nil
+}
+
+func (x *FullBackupInfo) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *FullBackupInfo) GetReplication() *ReplicationStatus {
+	if x != nil {
+		return x.Replication
+	}
+	return nil
+}
+
+func (x *FullBackupInfo) GetMirror() *ReplicationStatus {
+	if x != nil {
+		return x.Mirror
+	}
+	return nil
+}
+
+func (x *FullBackupInfo) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+func (x *FullBackupInfo) GetOrigin() *ReplicationStatus {
+	if x != nil {
+		return x.Origin
+	}
+	return nil
+}
+
+func (x *FullBackupInfo) GetCopies() []*BackupCopy {
+	if x != nil {
+		return x.Copies
+	}
+	return nil
+}
+
+type CreateFullBackupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Backup        *FullBackupInfo        `protobuf:"bytes,1,opt,name=backup,proto3" json:"backup,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateFullBackupResponse) Reset() {
+	*x = CreateFullBackupResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateFullBackupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFullBackupResponse) ProtoMessage() {}
+
+func (x *CreateFullBackupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFullBackupResponse.ProtoReflect.Descriptor instead.
+func (*CreateFullBackupResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CreateFullBackupResponse) GetBackup() *FullBackupInfo {
+	if x != nil {
+		return x.Backup
+	}
+	return nil
+}
+
+// Restore full backup
+type RestoreFullBackupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BackupId      string                 `protobuf:"bytes,1,opt,name=backup_id,json=backupId,proto3" json:"backup_id,omitempty"`
+	Targets       []*ModuleTarget        `protobuf:"bytes,2,rep,name=targets,proto3" json:"targets,omitempty"` // portal sends endpoints for each module
+	Mode          RestoreMode            `protobuf:"varint,3,opt,name=mode,proto3,enum=backup.service.v1.RestoreMode" json:"mode,omitempty"`
+	Password      string                 `protobuf:"bytes,4,opt,name=password,proto3" json:"password,omitempty"` // required if backup is encrypted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreFullBackupRequest) Reset() {
+	*x = RestoreFullBackupRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreFullBackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreFullBackupRequest) ProtoMessage() {}
+
+func (x *RestoreFullBackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreFullBackupRequest.ProtoReflect.Descriptor instead.
+func (*RestoreFullBackupRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RestoreFullBackupRequest) GetBackupId() string {
+	if x != nil {
+		return x.BackupId
+	}
+	return ""
+}
+
+func (x *RestoreFullBackupRequest) GetTargets() []*ModuleTarget {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *RestoreFullBackupRequest) GetMode() RestoreMode {
+	if x != nil {
+		return x.Mode
+	}
+	return RestoreMode_RESTORE_MODE_SKIP
+}
+
+func (x *RestoreFullBackupRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type RestoreFullBackupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	ModuleResults []*ModuleRestoreResult `protobuf:"bytes,2,rep,name=module_results,json=moduleResults,proto3" json:"module_results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreFullBackupResponse) Reset() {
+	*x = RestoreFullBackupResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreFullBackupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreFullBackupResponse) ProtoMessage() {}
+
+func (x *RestoreFullBackupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreFullBackupResponse.ProtoReflect.Descriptor instead.
+func (*RestoreFullBackupResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RestoreFullBackupResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RestoreFullBackupResponse) GetModuleResults() []*ModuleRestoreResult {
+	if x != nil {
+		return x.ModuleResults
+	}
+	return nil
+}
+
+type ModuleRestoreResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ModuleId      string                 `protobuf:"bytes,1,opt,name=module_id,json=moduleId,proto3" json:"module_id,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Results       []*EntityImportResult  `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
+	Warnings      []string               `protobuf:"bytes,4,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	Source        *BackupCopy            `protobuf:"bytes,6,opt,name=source,proto3" json:"source,omitempty"` // copy the data was read from
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModuleRestoreResult) Reset() {
+	*x = ModuleRestoreResult{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModuleRestoreResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModuleRestoreResult) ProtoMessage() {}
+
+func (x *ModuleRestoreResult) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModuleRestoreResult.ProtoReflect.Descriptor instead.
+func (*ModuleRestoreResult) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ModuleRestoreResult) GetModuleId() string {
+	if x != nil {
+		return x.ModuleId
+	}
+	return ""
+}
+
+func (x *ModuleRestoreResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ModuleRestoreResult) GetResults() []*EntityImportResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *ModuleRestoreResult) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+func (x *ModuleRestoreResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ModuleRestoreResult) GetSource() *BackupCopy {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+// List full backups
+type ListFullBackupsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      *uint32                `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3,oneof" json:"tenant_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	CreatedAfter  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`    // inclusive
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"` // exclusive
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`                                    // e.g. "completed", "partial", "failed", "canceled"
+	CreatedBy     string                 `protobuf:"bytes,7,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	Encrypted     *bool                  `protobuf:"varint,8,opt,name=encrypted,proto3,oneof" json:"encrypted,omitempty"`
+	Search        string                 `protobuf:"bytes,9,opt,name=search,proto3" json:"search,omitempty"`                         // case-insensitive match on ID, description, module IDs and labels (key=value)
+	SortBy        string                 `protobuf:"bytes,10,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`          // created_at (default), size or status
+	SortOrder     string                 `protobuf:"bytes,11,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"` // asc or desc; default desc for created_at and size, asc otherwise
+	PageToken     string                 `protobuf:"bytes,12,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token of the previous call; replaces page. Keep filters and sort unchanged.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFullBackupsRequest) Reset() {
+	*x = ListFullBackupsRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFullBackupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFullBackupsRequest) ProtoMessage() {}
+
+func (x *ListFullBackupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFullBackupsRequest.ProtoReflect.Descriptor instead.
+func (*ListFullBackupsRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListFullBackupsRequest) GetTenantId() uint32 {
+	if x != nil && x.TenantId != nil {
+		return *x.TenantId
+	}
+	return 0
+}
+
+func (x *ListFullBackupsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListFullBackupsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListFullBackupsRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *ListFullBackupsRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *ListFullBackupsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListFullBackupsRequest) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *ListFullBackupsRequest) GetEncrypted() bool {
+	if x != nil && x.Encrypted != nil {
+		return *x.Encrypted
+	}
+	return false
+}
+
+func (x *ListFullBackupsRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ListFullBackupsRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *ListFullBackupsRequest) GetSortOrder() string {
+	if x != nil {
+		return x.SortOrder
+	}
+	return ""
+}
+
+func (x *ListFullBackupsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListFullBackupsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Backups       []*FullBackupInfo      `protobuf:"bytes,1,rep,name=backups,proto3" json:"backups,omitempty"` // module_backups hold only module_id, status and sizes; see GetFullBackup
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFullBackupsResponse) Reset() {
+	*x = ListFullBackupsResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFullBackupsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFullBackupsResponse) ProtoMessage() {}
+
+func (x *ListFullBackupsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFullBackupsResponse.ProtoReflect.Descriptor instead.
+func (*ListFullBackupsResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListFullBackupsResponse) GetBackups() []*FullBackupInfo {
+	if x != nil {
+		return x.Backups
+	}
+	return nil
+}
+
+func (x *ListFullBackupsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListFullBackupsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Get full backup
+type GetFullBackupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFullBackupRequest) Reset() {
+	*x = GetFullBackupRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFullBackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFullBackupRequest) ProtoMessage() {}
+
+func (x *GetFullBackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFullBackupRequest.ProtoReflect.Descriptor instead.
+func (*GetFullBackupRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetFullBackupRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetFullBackupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Backup        *FullBackupInfo        `protobuf:"bytes,1,opt,name=backup,proto3" json:"backup,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFullBackupResponse) Reset() {
+	*x = GetFullBackupResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFullBackupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFullBackupResponse) ProtoMessage() {}
+
+func (x *GetFullBackupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFullBackupResponse.ProtoReflect.Descriptor instead.
+func (*GetFullBackupResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetFullBackupResponse) GetBackup() *FullBackupInfo {
+	if x != nil {
+		return x.Backup
+	}
+	return nil
+}
+
+// Update the annotations of a full backup after creation
+type UpdateFullBackupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Description   *string                `protobuf:"bytes,2,opt,name=description,proto3,oneof" json:"description,omitempty"`                                                           // replaces the description when set
+	Labels        map[string]string      `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // merged into the labels; an empty value removes the label
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateFullBackupRequest) Reset() {
+	*x = UpdateFullBackupRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateFullBackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateFullBackupRequest) ProtoMessage() {}
+
+func (x *UpdateFullBackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateFullBackupRequest.ProtoReflect.Descriptor instead.
+func (*UpdateFullBackupRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *UpdateFullBackupRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateFullBackupRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateFullBackupRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type UpdateFullBackupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Backup        *FullBackupInfo        `protobuf:"bytes,1,opt,name=backup,proto3" json:"backup,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateFullBackupResponse) Reset() {
+	*x = UpdateFullBackupResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateFullBackupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateFullBackupResponse) ProtoMessage() {}
+
+func (x *UpdateFullBackupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateFullBackupResponse.ProtoReflect.Descriptor instead.
+func (*UpdateFullBackupResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *UpdateFullBackupResponse) GetBackup() *FullBackupInfo {
+	if x != nil {
+		return x.Backup
+	}
+	return nil
+}
+
+// Download full backup (all modules combined)
+type DownloadFullBackupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"` // required if backup is encrypted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadFullBackupRequest) Reset() {
+	*x = DownloadFullBackupRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadFullBackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadFullBackupRequest) ProtoMessage() {}
+
+func (x *DownloadFullBackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadFullBackupRequest.ProtoReflect.Descriptor instead.
+func (*DownloadFullBackupRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *DownloadFullBackupRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DownloadFullBackupRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type DownloadFullBackupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadFullBackupResponse) Reset() {
+	*x = DownloadFullBackupResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadFullBackupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadFullBackupResponse) ProtoMessage() {}
+
+func (x *DownloadFullBackupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadFullBackupResponse.ProtoReflect.Descriptor instead.
+func (*DownloadFullBackupResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DownloadFullBackupResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *DownloadFullBackupResponse) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+// Time-limited download link that needs no credentials, so large payloads
+// skip the gRPC API
+type GeneratePresignedDownloadURLRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	BackupId         string                 `protobuf:"bytes,1,opt,name=backup_id,json=backupId,proto3" json:"backup_id,omitempty"`
+	ModuleId         string                 `protobuf:"bytes,2,opt,name=module_id,json=moduleId,proto3" json:"module_id,omitempty"`                            // set to download one module of a full backup
+	ExpiresInSeconds uint32                 `protobuf:"varint,3,opt,name=expires_in_seconds,json=expiresInSeconds,proto3" json:"expires_in_seconds,omitempty"` // default 900, at most 86400
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GeneratePresignedDownloadURLRequest) Reset() {
+	*x = GeneratePresignedDownloadURLRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeneratePresignedDownloadURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeneratePresignedDownloadURLRequest) ProtoMessage() {}
+
+func (x *GeneratePresignedDownloadURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeneratePresignedDownloadURLRequest.ProtoReflect.Descriptor instead.
+func (*GeneratePresignedDownloadURLRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GeneratePresignedDownloadURLRequest) GetBackupId() string {
+	if x != nil {
+		return x.BackupId
+	}
+	return ""
+}
+
+func (x *GeneratePresignedDownloadURLRequest) GetModuleId() string {
+	if x != nil {
+		return x.ModuleId
+	}
+	return ""
+}
+
+func (x *GeneratePresignedDownloadURLRequest) GetExpiresInSeconds() uint32 {
+	if x != nil {
+		return x.ExpiresInSeconds
+	}
+	return 0
+}
+
+type GeneratePresignedDownloadURLResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"` // presigned S3 mirror link if the backup is mirrored there, else this service's raw download; still encrypted if the backup is
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeneratePresignedDownloadURLResponse) Reset() {
+	*x = GeneratePresignedDownloadURLResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeneratePresignedDownloadURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeneratePresignedDownloadURLResponse) ProtoMessage() {}
+
+func (x *GeneratePresignedDownloadURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeneratePresignedDownloadURLResponse.ProtoReflect.Descriptor instead.
+func (*GeneratePresignedDownloadURLResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GeneratePresignedDownloadURLResponse) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *GeneratePresignedDownloadURLResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// Delete full backup
+type DeleteFullBackupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFullBackupRequest) Reset() {
+	*x = DeleteFullBackupRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFullBackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFullBackupRequest) ProtoMessage() {}
+
+func (x *DeleteFullBackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFullBackupRequest.ProtoReflect.Descriptor instead.
+func (*DeleteFullBackupRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *DeleteFullBackupRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteFullBackupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFullBackupResponse) Reset() {
+	*x = DeleteFullBackupResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFullBackupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFullBackupResponse) ProtoMessage() {}
+
+func (x *DeleteFullBackupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFullBackupResponse.ProtoReflect.Descriptor instead.
+func (*DeleteFullBackupResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *DeleteFullBackupResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// Pin and unpin a module or full backup
+type PinBackupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BackupId      string                 `protobuf:"bytes,1,opt,name=backup_id,json=backupId,proto3" json:"backup_id,omitempty"`
+	Full          bool                   `protobuf:"varint,2,opt,name=full,proto3" json:"full,omitempty"`    // backup_id names a full backup
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // e.g. "known-good before the v3 migration"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinBackupRequest) Reset() {
+	*x = PinBackupRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinBackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinBackupRequest) ProtoMessage() {}
+
+func (x *PinBackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinBackupRequest.ProtoReflect.Descriptor instead.
+func (*PinBackupRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *PinBackupRequest) GetBackupId() string {
+	if x != nil {
+		return x.BackupId
+	}
+	return ""
+}
+
+func (x *PinBackupRequest) GetFull() bool {
+	if x != nil {
+		return x.Full
+	}
+	return false
+}
+
+func (x *PinBackupRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type PinBackupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pin           *BackupPin             `protobuf:"bytes,1,opt,name=pin,proto3" json:"pin,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinBackupResponse) Reset() {
+	*x = PinBackupResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinBackupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinBackupResponse) ProtoMessage() {}
+
+func (x *PinBackupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinBackupResponse.ProtoReflect.Descriptor instead.
+func (*PinBackupResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *PinBackupResponse) GetPin() *BackupPin {
+	if x != nil {
+		return x.Pin
+	}
+	return nil
+}
+
+type UnpinBackupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BackupId      string                 `protobuf:"bytes,1,opt,name=backup_id,json=backupId,proto3" json:"backup_id,omitempty"`
+	Full          bool                   `protobuf:"varint,2,opt,name=full,proto3" json:"full,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnpinBackupRequest) Reset() {
+	*x = UnpinBackupRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnpinBackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnpinBackupRequest) ProtoMessage() {}
+
+func (x *UnpinBackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnpinBackupRequest.ProtoReflect.Descriptor instead.
+func (*UnpinBackupRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *UnpinBackupRequest) GetBackupId() string {
+	if x != nil {
+		return x.BackupId
+	}
+	return ""
+}
+
+func (x *UnpinBackupRequest) GetFull() bool {
+	if x != nil {
+		return x.Full
+	}
+	return false
+}
+
+type UnpinBackupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnpinBackupResponse) Reset() {
+	*x = UnpinBackupResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnpinBackupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnpinBackupResponse) ProtoMessage() {}
+
+func (x *UnpinBackupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnpinBackupResponse.ProtoReflect.Descriptor instead.
+func (*UnpinBackupResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{43}
+}
+
+// On-demand integrity check of a stored backup; the outcome is recorded in
+// the backup's metadata
+type VerifyBackupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BackupId      string                 `protobuf:"bytes,1,opt,name=backup_id,json=backupId,proto3" json:"backup_id,omitempty"`
+	Full          bool                   `protobuf:"varint,2,opt,name=full,proto3" json:"full,omitempty"`        // backup_id names a full backup
+	Password      string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"` // required if the backup is encrypted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyBackupRequest) Reset() {
+	*x = VerifyBackupRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyBackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyBackupRequest) ProtoMessage() {}
+
+func (x *VerifyBackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyBackupRequest.ProtoReflect.Descriptor instead.
+func (*VerifyBackupRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *VerifyBackupRequest) GetBackupId() string {
+	if x != nil {
+		return x.BackupId
+	}
+	return ""
+}
+
+func (x *VerifyBackupRequest) GetFull() bool {
+	if x != nil {
+		return x.Full
+	}
+	return false
+}
+
+func (x *VerifyBackupRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type VerificationCheck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`     // read, stored, format, decrypt, decompress, delta, json, checksum, size, entity_counts
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // "PASS", "FAIL" or "SKIP"
+	Detail        string                 `protobuf:"bytes,3,opt,name=detail,proto3" json:"detail,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerificationCheck) Reset() {
+	*x = VerificationCheck{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerificationCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerificationCheck) ProtoMessage() {}
+
+func (x *VerificationCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerificationCheck.ProtoReflect.Descriptor instead.
+func (*VerificationCheck) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *VerificationCheck) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VerificationCheck) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *VerificationCheck) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+type ModuleVerification struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ModuleId      string                 `protobuf:"bytes,1,opt,name=module_id,json=moduleId,proto3" json:"module_id,omitempty"`
+	Ok            bool                   `protobuf:"varint,2,opt,name=ok,proto3" json:"ok,omitempty"`
+	Checks        []*VerificationCheck   `protobuf:"bytes,3,rep,name=checks,proto3" json:"checks,omitempty"` // in order, up to the first failure
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModuleVerification) Reset() {
+	*x = ModuleVerification{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModuleVerification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModuleVerification) ProtoMessage() {}
+
+func (x *ModuleVerification) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModuleVerification.ProtoReflect.Descriptor instead.
+func (*ModuleVerification) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ModuleVerification) GetModuleId() string {
+	if x != nil {
+		return x.ModuleId
+	}
+	return ""
+}
+
+func (x *ModuleVerification) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *ModuleVerification) GetChecks() []*VerificationCheck {
+	if x != nil {
+		return x.Checks
+	}
+	return nil
+}
+
+type BackupVerification struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VerifiedAt    *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=verified_at,json=verifiedAt,proto3" json:"verified_at,omitempty"`
+	VerifiedBy    string                 `protobuf:"bytes,2,opt,name=verified_by,json=verifiedBy,proto3" json:"verified_by,omitempty"`
+	Ok            bool                   `protobuf:"varint,3,opt,name=ok,proto3" json:"ok,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"` // the first failed check, when not ok
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackupVerification) Reset() {
+	*x = BackupVerification{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackupVerification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupVerification) ProtoMessage() {}
+
+func (x *BackupVerification) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupVerification.ProtoReflect.Descriptor instead.
+func (*BackupVerification) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *BackupVerification) GetVerifiedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.VerifiedAt
+	}
+	return nil
+}
+
+func (x *BackupVerification) GetVerifiedBy() string {
+	if x != nil {
+		return x.VerifiedBy
+	}
+	return ""
+}
+
+func (x *BackupVerification) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *BackupVerification) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type VerifyBackupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Verification  *BackupVerification    `protobuf:"bytes,1,opt,name=verification,proto3" json:"verification,omitempty"`
+	Modules       []*ModuleVerification  `protobuf:"bytes,2,rep,name=modules,proto3" json:"modules,omitempty"` // one per completed module backup
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyBackupResponse) Reset() {
+	*x = VerifyBackupResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyBackupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyBackupResponse) ProtoMessage() {}
+
+func (x *VerifyBackupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyBackupResponse.ProtoReflect.Descriptor instead.
+func (*VerifyBackupResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *VerifyBackupResponse) GetVerification() *BackupVerification {
+	if x != nil {
+		return x.Verification
+	}
+	return nil
+}
+
+func (x *VerifyBackupResponse) GetModules() []*ModuleVerification {
+	if x != nil {
+		return x.Modules
+	}
+	return nil
+}
+
+// Restore test: the backup is imported into the validation tenant configured
+// with BACKUP_TEST_RESTORE_TENANT_ID, which is then returned to the state it
+// had before the test. Recorded in the backup's restore history as kind "test"
+type TestRestoreRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BackupId      string                 `protobuf:"bytes,1,opt,name=backup_id,json=backupId,proto3" json:"backup_id,omitempty"`
+	Full          bool                   `protobuf:"varint,2,opt,name=full,proto3" json:"full,omitempty"`        // backup_id names a full backup; the target's module is restored from it
+	Target        *ModuleTarget          `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`     // module to restore into; must implement common.service.v1.BackupService
+	Password      string                 `protobuf:"bytes,4,opt,name=password,proto3" json:"password,omitempty"` // required if the backup is encrypted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestRestoreRequest) Reset() {
+	*x = TestRestoreRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestRestoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestRestoreRequest) ProtoMessage() {}
+
+func (x *TestRestoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestRestoreRequest.ProtoReflect.Descriptor instead.
+func (*TestRestoreRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *TestRestoreRequest) GetBackupId() string {
+	if x != nil {
+		return x.BackupId
+	}
+	return ""
+}
+
+func (x *TestRestoreRequest) GetFull() bool {
+	if x != nil {
+		return x.Full
+	}
+	return false
+}
+
+func (x *TestRestoreRequest) GetTarget() *ModuleTarget {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *TestRestoreRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type TestRestoreResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // the module imported the backup
+	Results       []*EntityImportResult  `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	Warnings      []string               `protobuf:"bytes,3,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	TenantId      uint32                 `protobuf:"varint,4,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`    // validation tenant the backup was imported into
+	CleanedUp     bool                   `protobuf:"varint,5,opt,name=cleaned_up,json=cleanedUp,proto3" json:"cleaned_up,omitempty"` // the validation tenant was returned to its state before the test
+	Error         string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`                           // why the import or the cleanup failed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestRestoreResponse) Reset() {
+	*x = TestRestoreResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestRestoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestRestoreResponse) ProtoMessage() {}
+
+func (x *TestRestoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestRestoreResponse.ProtoReflect.Descriptor instead.
+func (*TestRestoreResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *TestRestoreResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *TestRestoreResponse) GetResults() []*EntityImportResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *TestRestoreResponse) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+func (x *TestRestoreResponse) GetTenantId() uint32 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+func (x *TestRestoreResponse) GetCleanedUp() bool {
+	if x != nil {
+		return x.CleanedUp
+	}
+	return false
+}
+
+func (x *TestRestoreResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// Repair of a corrupt module backup: its metadata is rebuilt from the data
+// file. The module and tenant were only recorded in the lost metadata
+type RepairBackupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BackupId      string                 `protobuf:"bytes,1,opt,name=backup_id,json=backupId,proto3" json:"backup_id,omitempty"`
+	ModuleId      string                 `protobuf:"bytes,2,opt,name=module_id,json=moduleId,proto3" json:"module_id,omitempty"`  // module the data was exported from
+	TenantId      uint32                 `protobuf:"varint,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"` // tenant the data was exported for
+	Password      string                 `protobuf:"bytes,4,opt,name=password,proto3" json:"password,omitempty"`                  // required if the backup is encrypted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RepairBackupRequest) Reset() {
+	*x = RepairBackupRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RepairBackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepairBackupRequest) ProtoMessage() {}
+
+func (x *RepairBackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepairBackupRequest.ProtoReflect.Descriptor instead.
+func (*RepairBackupRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *RepairBackupRequest) GetBackupId() string {
+	if x != nil {
+		return x.BackupId
+	}
+	return ""
+}
+
+func (x *RepairBackupRequest) GetModuleId() string {
+	if x != nil {
+		return x.ModuleId
+	}
+	return ""
+}
+
+func (x *RepairBackupRequest) GetTenantId() uint32 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+func (x *RepairBackupRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type RepairBackupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Backup        *BackupInfo            `protobuf:"bytes,1,opt,name=backup,proto3" json:"backup,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RepairBackupResponse) Reset() {
+	*x = RepairBackupResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RepairBackupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepairBackupResponse) ProtoMessage() {}
+
+func (x *RepairBackupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepairBackupResponse.ProtoReflect.Descriptor instead.
+func (*RepairBackupResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *RepairBackupResponse) GetBackup() *BackupInfo {
+	if x != nil {
+		return x.Backup
+	}
+	return nil
+}
+
+// Replication: a primary pushes each new backup to a secondary orchestrator
+type ReplicateBackupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BackupId      string                 `protobuf:"bytes,1,opt,name=backup_id,json=backupId,proto3" json:"backup_id,omitempty"`
+	Full          bool                   `protobuf:"varint,2,opt,name=full,proto3" json:"full,omitempty"` // backup_id names a full backup
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplicateBackupRequest) Reset() {
+	*x = ReplicateBackupRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplicateBackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicateBackupRequest) ProtoMessage() {}
+
+func (x *ReplicateBackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicateBackupRequest.ProtoReflect.Descriptor instead.
+func (*ReplicateBackupRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ReplicateBackupRequest) GetBackupId() string {
+	if x != nil {
+		return x.BackupId
+	}
+	return ""
+}
+
+func (x *ReplicateBackupRequest) GetFull() bool {
+	if x != nil {
+		return x.Full
+	}
+	return false
+}
+
+type ReplicateBackupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Replication   *ReplicationStatus     `protobuf:"bytes,1,opt,name=replication,proto3" json:"replication,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplicateBackupResponse) Reset() {
+	*x = ReplicateBackupResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplicateBackupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicateBackupResponse) ProtoMessage() {}
+
+func (x *ReplicateBackupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicateBackupResponse.ProtoReflect.Descriptor instead.
+func (*ReplicateBackupResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ReplicateBackupResponse) GetReplication() *ReplicationStatus {
+	if x != nil {
+		return x.Replication
+	}
+	return nil
+}
+
+// ReplicaChunk is one message of a ReceiveReplica stream: the manifest
+// first, then the content of every file it lists, in order.
+type ReplicaChunk struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*ReplicaChunk_Manifest
+	//	*ReplicaChunk_File
+	Payload       isReplicaChunk_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplicaChunk) Reset() {
+	*x = ReplicaChunk{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplicaChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicaChunk) ProtoMessage() {}
+
+func (x *ReplicaChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicaChunk.ProtoReflect.Descriptor instead.
+func (*ReplicaChunk) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ReplicaChunk) GetPayload() isReplicaChunk_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ReplicaChunk) GetManifest() *ReplicaManifest {
+	if x != nil {
+		if x, ok := x.Payload.(*ReplicaChunk_Manifest); ok {
+			return x.Manifest
+		}
+	}
+	return nil
+}
+
+func (x *ReplicaChunk) GetFile() *ReplicaFileChunk {
+	if x != nil {
+		if x, ok := x.Payload.(*ReplicaChunk_File); ok {
+			return x.File
+		}
+	}
+	return nil
+}
+
+type isReplicaChunk_Payload interface {
+	isReplicaChunk_Payload()
+}
+
+type ReplicaChunk_Manifest struct {
+	Manifest *ReplicaManifest `protobuf:"bytes,1,opt,name=manifest,proto3,oneof"`
+}
+
+type ReplicaChunk_File struct {
+	File *ReplicaFileChunk `protobuf:"bytes,2,opt,name=file,proto3,oneof"`
+}
+
+func (*ReplicaChunk_Manifest) isReplicaChunk_Payload() {}
+
+func (*ReplicaChunk_File) isReplicaChunk_Payload() {}
+
+type ReplicaManifest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BackupId      string                 `protobuf:"bytes,1,opt,name=backup_id,json=backupId,proto3" json:"backup_id,omitempty"`
+	Full          bool                   `protobuf:"varint,2,opt,name=full,proto3" json:"full,omitempty"`
+	Metadata      []byte                 `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"` // the backup's metadata.json
+	Files         []*ReplicaFile         `protobuf:"bytes,4,rep,name=files,proto3" json:"files,omitempty"`       // data files, without metadata.json and summary.json
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplicaManifest) Reset() {
+	*x = ReplicaManifest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplicaManifest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicaManifest) ProtoMessage() {}
+
+func (x *ReplicaManifest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicaManifest.ProtoReflect.Descriptor instead.
+func (*ReplicaManifest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ReplicaManifest) GetBackupId() string {
+	if x != nil {
+		return x.BackupId
+	}
+	return ""
+}
+
+func (x *ReplicaManifest) GetFull() bool {
+	if x != nil {
+		return x.Full
+	}
+	return false
+}
+
+func (x *ReplicaManifest) GetMetadata() []byte {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ReplicaManifest) GetFiles() []*ReplicaFile {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+type ReplicaFile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Sha256        string                 `protobuf:"bytes,3,opt,name=sha256,proto3" json:"sha256,omitempty"` // hex, of the file as stored
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplicaFile) Reset() {
+	*x = ReplicaFile{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplicaFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicaFile) ProtoMessage() {}
+
+func (x *ReplicaFile) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicaFile.ProtoReflect.Descriptor instead.
+func (*ReplicaFile) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ReplicaFile) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ReplicaFile) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ReplicaFile) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+type ReplicaFileChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplicaFileChunk) Reset() {
+	*x = ReplicaFileChunk{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplicaFileChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicaFileChunk) ProtoMessage() {}
+
+func (x *ReplicaFileChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicaFileChunk.ProtoReflect.Descriptor instead.
+func (*ReplicaFileChunk) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ReplicaFileChunk) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ReplicaFileChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ReceiveReplicaResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	BackupId       string                 `protobuf:"bytes,1,opt,name=backup_id,json=backupId,proto3" json:"backup_id,omitempty"`
+	BytesReceived  int64                  `protobuf:"varint,2,opt,name=bytes_received,json=bytesReceived,proto3" json:"bytes_received,omitempty"`
+	AlreadyPresent bool                   `protobuf:"varint,3,opt,name=already_present,json=alreadyPresent,proto3" json:"already_present,omitempty"` // the secondary had the backup already
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReceiveReplicaResponse) Reset() {
+	*x = ReceiveReplicaResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceiveReplicaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiveReplicaResponse) ProtoMessage() {}
+
+func (x *ReceiveReplicaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiveReplicaResponse.ProtoReflect.Descriptor instead.
+func (*ReceiveReplicaResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *ReceiveReplicaResponse) GetBackupId() string {
+	if x != nil {
+		return x.BackupId
+	}
+	return ""
+}
+
+func (x *ReceiveReplicaResponse) GetBytesReceived() int64 {
+	if x != nil {
+		return x.BytesReceived
+	}
+	return 0
+}
+
+func (x *ReceiveReplicaResponse) GetAlreadyPresent() bool {
+	if x != nil {
+		return x.AlreadyPresent
+	}
+	return false
+}
+
+// Federation: a DR orchestrator pulls the catalog and missing backups from
+// its primary peer
+type ListReplicasRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReplicasRequest) Reset() {
+	*x = ListReplicasRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReplicasRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReplicasRequest) ProtoMessage() {}
+
+func (x *ListReplicasRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReplicasRequest.ProtoReflect.Descriptor instead.
+func (*ListReplicasRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{60}
+}
+
+type ReplicaEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BackupId      string                 `protobuf:"bytes,1,opt,name=backup_id,json=backupId,proto3" json:"backup_id,omitempty"`
+	Full          bool                   `protobuf:"varint,2,opt,name=full,proto3" json:"full,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	DeltaBaseId   string                 `protobuf:"bytes,4,opt,name=delta_base_id,json=deltaBaseId,proto3" json:"delta_base_id,omitempty"` // pull this backup first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplicaEntry) Reset() {
+	*x = ReplicaEntry{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplicaEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicaEntry) ProtoMessage() {}
+
+func (x *ReplicaEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicaEntry.ProtoReflect.Descriptor instead.
+func (*ReplicaEntry) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ReplicaEntry) GetBackupId() string {
+	if x != nil {
+		return x.BackupId
+	}
+	return ""
+}
+
+func (x *ReplicaEntry) GetFull() bool {
+	if x != nil {
+		return x.Full
+	}
+	return false
+}
+
+func (x *ReplicaEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ReplicaEntry) GetDeltaBaseId() string {
+	if x != nil {
+		return x.DeltaBaseId
+	}
+	return ""
+}
+
+type ListReplicasResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Backups       []*ReplicaEntry        `protobuf:"bytes,1,rep,name=backups,proto3" json:"backups,omitempty"` // oldest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReplicasResponse) Reset() {
+	*x = ListReplicasResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReplicasResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReplicasResponse) ProtoMessage() {}
+
+func (x *ListReplicasResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReplicasResponse.ProtoReflect.Descriptor instead.
+func (*ListReplicasResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ListReplicasResponse) GetBackups() []*ReplicaEntry {
+	if x != nil {
+		return x.Backups
+	}
+	return nil
+}
+
+type FetchReplicaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BackupId      string                 `protobuf:"bytes,1,opt,name=backup_id,json=backupId,proto3" json:"backup_id,omitempty"`
+	Full          bool                   `protobuf:"varint,2,opt,name=full,proto3" json:"full,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchReplicaRequest) Reset() {
+	*x = FetchReplicaRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchReplicaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchReplicaRequest) ProtoMessage() {}
+
+func (x *FetchReplicaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchReplicaRequest.ProtoReflect.Descriptor instead.
+func (*FetchReplicaRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *FetchReplicaRequest) GetBackupId() string {
+	if x != nil {
+		return x.BackupId
+	}
+	return ""
+}
+
+func (x *FetchReplicaRequest) GetFull() bool {
+	if x != nil {
+		return x.Full
+	}
+	return false
+}
+
+type GetFederationStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFederationStatusRequest) Reset() {
+	*x = GetFederationStatusRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFederationStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFederationStatusRequest) ProtoMessage() {}
+
+func (x *GetFederationStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFederationStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetFederationStatusRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{64}
+}
+
+type GetFederationStatusResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Peer            string                 `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"` // primary this orchestrator pulls from
+	LastSyncAt      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=last_sync_at,json=lastSyncAt,proto3" json:"last_sync_at,omitempty"`
+	LastSuccessAt   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_success_at,json=lastSuccessAt,proto3" json:"last_success_at,omitempty"`       // last sync that left nothing behind
+	LastError       string                 `protobuf:"bytes,4,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`                     // set when the last sync could not read the catalog
+	PeerBackups     int32                  `protobuf:"varint,5,opt,name=peer_backups,json=peerBackups,proto3" json:"peer_backups,omitempty"`              // in the primary's catalog at the last sync
+	Pulled          int32                  `protobuf:"varint,6,opt,name=pulled,proto3" json:"pulled,omitempty"`                                           // by the last sync
+	FailedBackupIds []string               `protobuf:"bytes,7,rep,name=failed_backup_ids,json=failedBackupIds,proto3" json:"failed_backup_ids,omitempty"` // the last sync could not pull these
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetFederationStatusResponse) Reset() {
+	*x = GetFederationStatusResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFederationStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFederationStatusResponse) ProtoMessage() {}
+
+func (x *GetFederationStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFederationStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetFederationStatusResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *GetFederationStatusResponse) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+func (x *GetFederationStatusResponse) GetLastSyncAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSyncAt
+	}
+	return nil
+}
+
+func (x *GetFederationStatusResponse) GetLastSuccessAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSuccessAt
+	}
+	return nil
+}
+
+func (x *GetFederationStatusResponse) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *GetFederationStatusResponse) GetPeerBackups() int32 {
+	if x != nil {
+		return x.PeerBackups
+	}
+	return 0
+}
+
+func (x *GetFederationStatusResponse) GetPulled() int32 {
+	if x != nil {
+		return x.Pulled
+	}
+	return 0
+}
+
+func (x *GetFederationStatusResponse) GetFailedBackupIds() []string {
+	if x != nil {
+		return x.FailedBackupIds
+	}
+	return nil
+}
+
+// Mirroring: new backups are copied to object storage in the background
+type GetMirrorStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMirrorStatusRequest) Reset() {
+	*x = GetMirrorStatusRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMirrorStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMirrorStatusRequest) ProtoMessage() {}
+
+func (x *GetMirrorStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMirrorStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetMirrorStatusRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{66}
+}
+
+type GetMirrorStatusResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Target          string                 `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"` // mirror URL, without credentials
+	Mirrored        int32                  `protobuf:"varint,2,opt,name=mirrored,proto3" json:"mirrored,omitempty"`
+	Pending         int32                  `protobuf:"varint,3,opt,name=pending,proto3" json:"pending,omitempty"`
+	Failed          int32                  `protobuf:"varint,4,opt,name=failed,proto3" json:"failed,omitempty"`
+	LagSeconds      int64                  `protobuf:"varint,5,opt,name=lag_seconds,json=lagSeconds,proto3" json:"lag_seconds,omitempty"` // age of the oldest backup not mirrored yet; 0 when caught up
+	LastMirroredAt  *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_mirrored_at,json=lastMirroredAt,proto3" json:"last_mirrored_at,omitempty"`
+	FailedBackupIds []string               `protobuf:"bytes,7,rep,name=failed_backup_ids,json=failedBackupIds,proto3" json:"failed_backup_ids,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetMirrorStatusResponse) Reset() {
+	*x = GetMirrorStatusResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMirrorStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMirrorStatusResponse) ProtoMessage() {}
+
+func (x *GetMirrorStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMirrorStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetMirrorStatusResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *GetMirrorStatusResponse) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *GetMirrorStatusResponse) GetMirrored() int32 {
+	if x != nil {
+		return x.Mirrored
+	}
+	return 0
+}
+
+func (x *GetMirrorStatusResponse) GetPending() int32 {
+	if x != nil {
+		return x.Pending
+	}
+	return 0
+}
+
+func (x *GetMirrorStatusResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *GetMirrorStatusResponse) GetLagSeconds() int64 {
+	if x != nil {
+		return x.LagSeconds
+	}
+	return 0
+}
+
+func (x *GetMirrorStatusResponse) GetLastMirroredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastMirroredAt
+	}
+	return nil
+}
+
+func (x *GetMirrorStatusResponse) GetFailedBackupIds() []string {
+	if x != nil {
+		return x.FailedBackupIds
+	}
+	return nil
+}
+
+// Where and when a backup has been restored
+type GetBackupUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BackupId      string                 `protobuf:"bytes,1,opt,name=backup_id,json=backupId,proto3" json:"backup_id,omitempty"` // module or full backup ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBackupUsageRequest) Reset() {
+	*x = GetBackupUsageRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBackupUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBackupUsageRequest) ProtoMessage() {}
+
+func (x *GetBackupUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBackupUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetBackupUsageRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *GetBackupUsageRequest) GetBackupId() string {
+	if x != nil {
+		return x.BackupId
+	}
+	return ""
+}
+
+type RestoreRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BackupId      string                 `protobuf:"bytes,1,opt,name=backup_id,json=backupId,proto3" json:"backup_id,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"` // "module", "full" or "test"
+	ModuleId      string                 `protobuf:"bytes,3,opt,name=module_id,json=moduleId,proto3" json:"module_id,omitempty"`
+	GrpcEndpoint  string                 `protobuf:"bytes,4,opt,name=grpc_endpoint,json=grpcEndpoint,proto3" json:"grpc_endpoint,omitempty"` // target the data was imported into
+	Mode          RestoreMode            `protobuf:"varint,5,opt,name=mode,proto3,enum=backup.service.v1.RestoreMode" json:"mode,omitempty"`
+	RestoredBy    string                 `protobuf:"bytes,6,opt,name=restored_by,json=restoredBy,proto3" json:"restored_by,omitempty"`
+	RequestId     string                 `protobuf:"bytes,7,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	Success       bool                   `protobuf:"varint,10,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,11,opt,name=error,proto3" json:"error,omitempty"`
+	Source        *BackupCopy            `protobuf:"bytes,12,opt,name=source,proto3" json:"source,omitempty"` // copy the data was read from
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreRecord) Reset() {
+	*x = RestoreRecord{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreRecord) ProtoMessage() {}
+
+func (x *RestoreRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreRecord.ProtoReflect.Descriptor instead.
+func (*RestoreRecord) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *RestoreRecord) GetBackupId() string {
+	if x != nil {
+		return x.BackupId
+	}
+	return ""
+}
+
+func (x *RestoreRecord) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *RestoreRecord) GetModuleId() string {
+	if x != nil {
+		return x.ModuleId
+	}
+	return ""
+}
+
+func (x *RestoreRecord) GetGrpcEndpoint() string {
+	if x != nil {
+		return x.GrpcEndpoint
+	}
+	return ""
+}
+
+func (x *RestoreRecord) GetMode() RestoreMode {
+	if x != nil {
+		return x.Mode
+	}
+	return RestoreMode_RESTORE_MODE_SKIP
+}
+
+func (x *RestoreRecord) GetRestoredBy() string {
+	if x != nil {
+		return x.RestoredBy
+	}
+	return ""
+}
+
+func (x *RestoreRecord) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *RestoreRecord) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *RestoreRecord) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+func (x *RestoreRecord) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RestoreRecord) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *RestoreRecord) GetSource() *BackupCopy {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+type GetBackupUsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Restores      []*RestoreRecord       `protobuf:"bytes,1,rep,name=restores,proto3" json:"restores,omitempty"` // oldest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBackupUsageResponse) Reset() {
+	*x = GetBackupUsageResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBackupUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBackupUsageResponse) ProtoMessage() {}
+
+func (x *GetBackupUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBackupUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetBackupUsageResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *GetBackupUsageResponse) GetRestores() []*RestoreRecord {
+	if x != nil {
+		return x.Restores
+	}
+	return nil
+}
+
+// Storage used against the configured quotas
+type GetQuotaUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      *uint32                `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3,oneof" json:"tenant_id,omitempty"` // only this tenant's usage
+	ModuleId      string                 `protobuf:"bytes,2,opt,name=module_id,json=moduleId,proto3" json:"module_id,omitempty"`        // only this module's usage; with neither set, all of them
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuotaUsageRequest) Reset() {
+	*x = GetQuotaUsageRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuotaUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuotaUsageRequest) ProtoMessage() {}
+
+func (x *GetQuotaUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuotaUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetQuotaUsageRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *GetQuotaUsageRequest) GetTenantId() uint32 {
+	if x != nil && x.TenantId != nil {
+		return *x.TenantId
+	}
+	return 0
+}
+
+func (x *GetQuotaUsageRequest) GetModuleId() string {
+	if x != nil {
+		return x.ModuleId
+	}
+	return ""
+}
+
+type QuotaUsage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Scope         string                 `protobuf:"bytes,1,opt,name=scope,proto3" json:"scope,omitempty"`                           // "tenant" or "module"
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`                                 // tenant ID or module ID
+	UsedBytes     int64                  `protobuf:"varint,3,opt,name=used_bytes,json=usedBytes,proto3" json:"used_bytes,omitempty"` // stored (compressed) size of its backups; module usage spans tenants
+	MaxBytes      int64                  `protobuf:"varint,4,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`    // 0 = unlimited
+	Backups       int32                  `protobuf:"varint,5,opt,name=backups,proto3" json:"backups,omitempty"`
+	MaxBackups    int32                  `protobuf:"varint,6,opt,name=max_backups,json=maxBackups,proto3" json:"max_backups,omitempty"` // 0 = unlimited
+	Exceeded      bool                   `protobuf:"varint,7,opt,name=exceeded,proto3" json:"exceeded,omitempty"`                       // new backups are refused
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuotaUsage) Reset() {
+	*x = QuotaUsage{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuotaUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuotaUsage) ProtoMessage() {}
+
+func (x *QuotaUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuotaUsage.ProtoReflect.Descriptor instead.
+func (*QuotaUsage) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *QuotaUsage) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *QuotaUsage) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *QuotaUsage) GetUsedBytes() int64 {
+	if x != nil {
+		return x.UsedBytes
+	}
+	return 0
+}
+
+func (x *QuotaUsage) GetMaxBytes() int64 {
+	if x != nil {
+		return x.MaxBytes
+	}
+	return 0
+}
+
+func (x *QuotaUsage) GetBackups() int32 {
+	if x != nil {
+		return x.Backups
+	}
+	return 0
+}
+
+func (x *QuotaUsage) GetMaxBackups() int32 {
+	if x != nil {
+		return x.MaxBackups
+	}
+	return 0
+}
+
+func (x *QuotaUsage) GetExceeded() bool {
+	if x != nil {
+		return x.Exceeded
+	}
+	return false
+}
+
+type GetQuotaUsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Usage         []*QuotaUsage          `protobuf:"bytes,1,rep,name=usage,proto3" json:"usage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuotaUsageResponse) Reset() {
+	*x = GetQuotaUsageResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuotaUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuotaUsageResponse) ProtoMessage() {}
+
+func (x *GetQuotaUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuotaUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetQuotaUsageResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *GetQuotaUsageResponse) GetUsage() []*QuotaUsage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+// Flat listing of every stored backup, for audit and CMDB ingestion
+type ExportCatalogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Format        string                 `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"` // "csv" (default) or "json"
+	TenantId      *uint32                `protobuf:"varint,2,opt,name=tenant_id,json=tenantId,proto3,oneof" json:"tenant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportCatalogRequest) Reset() {
+	*x = ExportCatalogRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportCatalogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportCatalogRequest) ProtoMessage() {}
+
+func (x *ExportCatalogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportCatalogRequest.ProtoReflect.Descriptor instead.
+func (*ExportCatalogRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *ExportCatalogRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ExportCatalogRequest) GetTenantId() uint32 {
+	if x != nil && x.TenantId != nil {
+		return *x.TenantId
+	}
+	return 0
+}
+
+// One catalog row. A full backup has a row of its own plus one per module,
+// all sharing its backup_id.
+type CatalogEntry struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	BackupId        string                 `protobuf:"bytes,1,opt,name=backup_id,json=backupId,proto3" json:"backup_id,omitempty"`
+	Kind            string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"` // "module", "full" or "full_module"
+	ModuleId        string                 `protobuf:"bytes,3,opt,name=module_id,json=moduleId,proto3" json:"module_id,omitempty"`
+	TenantId        uint32                 `protobuf:"varint,4,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Status          string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CreatedBy       string                 `protobuf:"bytes,7,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	SizeBytes       int64                  `protobuf:"varint,8,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`                     // uncompressed export size
+	StoredSizeBytes int64                  `protobuf:"varint,9,opt,name=stored_size_bytes,json=storedSizeBytes,proto3" json:"stored_size_bytes,omitempty"` // compressed size on disk
+	Encrypted       bool                   `protobuf:"varint,10,opt,name=encrypted,proto3" json:"encrypted,omitempty"`
+	Sha256          string                 `protobuf:"bytes,11,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	Version         string                 `protobuf:"bytes,12,opt,name=version,proto3" json:"version,omitempty"`
+	SchemaVersion   int32                  `protobuf:"varint,13,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	Pinned          bool                   `protobuf:"varint,14,opt,name=pinned,proto3" json:"pinned,omitempty"`
+	Description     string                 `protobuf:"bytes,15,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CatalogEntry) Reset() {
+	*x = CatalogEntry{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CatalogEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CatalogEntry) ProtoMessage() {}
+
+func (x *CatalogEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CatalogEntry.ProtoReflect.Descriptor instead.
+func (*CatalogEntry) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *CatalogEntry) GetBackupId() string {
+	if x != nil {
+		return x.BackupId
+	}
+	return ""
+}
+
+func (x *CatalogEntry) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *CatalogEntry) GetModuleId() string {
+	if x != nil {
+		return x.ModuleId
+	}
+	return ""
+}
+
+func (x *CatalogEntry) GetTenantId() uint32 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+func (x *CatalogEntry) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CatalogEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *CatalogEntry) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *CatalogEntry) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *CatalogEntry) GetStoredSizeBytes() int64 {
+	if x != nil {
+		return x.StoredSizeBytes
+	}
+	return 0
+}
+
+func (x *CatalogEntry) GetEncrypted() bool {
+	if x != nil {
+		return x.Encrypted
+	}
+	return false
+}
+
+func (x *CatalogEntry) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *CatalogEntry) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *CatalogEntry) GetSchemaVersion() int32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *CatalogEntry) GetPinned() bool {
+	if x != nil {
+		return x.Pinned
+	}
+	return false
+}
+
+func (x *CatalogEntry) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type ExportCatalogResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*CatalogEntry        `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	Document      string                 `protobuf:"bytes,2,opt,name=document,proto3" json:"document,omitempty"` // the entries rendered in the requested format
+	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Filename      string                 `protobuf:"bytes,4,opt,name=filename,proto3" json:"filename,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportCatalogResponse) Reset() {
+	*x = ExportCatalogResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportCatalogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportCatalogResponse) ProtoMessage() {}
+
+func (x *ExportCatalogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportCatalogResponse.ProtoReflect.Descriptor instead.
+func (*ExportCatalogResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *ExportCatalogResponse) GetEntries() []*CatalogEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *ExportCatalogResponse) GetDocument() string {
+	if x != nil {
+		return x.Document
+	}
+	return ""
+}
+
+func (x *ExportCatalogResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ExportCatalogResponse) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+// Registers the backups of an attached storage tree, e.g. a replicated or
+// restored volume, so they can be listed and restored here
+type ImportCatalogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SourcePath    string                 `protobuf:"bytes,1,opt,name=source_path,json=sourcePath,proto3" json:"source_path,omitempty"` // storage tree (with modules/ and full/) to copy backups from; empty checks this orchestrator's own storage in place
+	Catalog       string                 `protobuf:"bytes,2,opt,name=catalog,proto3" json:"catalog,omitempty"`                         // an ExportCatalog document, csv or json; when set only the backups it lists are imported, and checked against it
+	DryRun        bool                   `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`            // only report what would be imported
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportCatalogRequest) Reset() {
+	*x = ImportCatalogRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportCatalogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportCatalogRequest) ProtoMessage() {}
+
+func (x *ImportCatalogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportCatalogRequest.ProtoReflect.Descriptor instead.
+func (*ImportCatalogRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *ImportCatalogRequest) GetSourcePath() string {
+	if x != nil {
+		return x.SourcePath
+	}
+	return ""
+}
+
+func (x *ImportCatalogRequest) GetCatalog() string {
+	if x != nil {
+		return x.Catalog
+	}
+	return ""
+}
+
+func (x *ImportCatalogRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type CatalogImportResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BackupId      string                 `protobuf:"bytes,1,opt,name=backup_id,json=backupId,proto3" json:"backup_id,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`       // "module" or "full"
+	Outcome       string                 `protobuf:"bytes,3,opt,name=outcome,proto3" json:"outcome,omitempty"` // "imported", "registered", "present", "invalid" or "missing"; "would_import" on a dry run
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`   // why the backup is invalid or missing
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CatalogImportResult) Reset() {
+	*x = CatalogImportResult{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CatalogImportResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CatalogImportResult) ProtoMessage() {}
+
+func (x *CatalogImportResult) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CatalogImportResult.ProtoReflect.Descriptor instead.
+func (*CatalogImportResult) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *CatalogImportResult) GetBackupId() string {
+	if x != nil {
+		return x.BackupId
+	}
+	return ""
+}
+
+func (x *CatalogImportResult) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *CatalogImportResult) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *CatalogImportResult) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ImportCatalogResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*CatalogImportResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`        // oldest first
+	Imported      int32                  `protobuf:"varint,2,opt,name=imported,proto3" json:"imported,omitempty"`     // copied in, or that would be on a dry run
+	Registered    int32                  `protobuf:"varint,3,opt,name=registered,proto3" json:"registered,omitempty"` // found valid in place
+	Present       int32                  `protobuf:"varint,4,opt,name=present,proto3" json:"present,omitempty"`       // already stored here
+	Invalid       int32                  `protobuf:"varint,5,opt,name=invalid,proto3" json:"invalid,omitempty"`
+	Missing       int32                  `protobuf:"varint,6,opt,name=missing,proto3" json:"missing,omitempty"` // listed in the catalog but not in the tree
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportCatalogResponse) Reset() {
+	*x = ImportCatalogResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportCatalogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportCatalogResponse) ProtoMessage() {}
+
+func (x *ImportCatalogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportCatalogResponse.ProtoReflect.Descriptor instead.
+func (*ImportCatalogResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *ImportCatalogResponse) GetResults() []*CatalogImportResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *ImportCatalogResponse) GetImported() int32 {
+	if x != nil {
+		return x.Imported
+	}
+	return 0
+}
+
+func (x *ImportCatalogResponse) GetRegistered() int32 {
+	if x != nil {
+		return x.Registered
+	}
+	return 0
+}
+
+func (x *ImportCatalogResponse) GetPresent() int32 {
+	if x != nil {
+		return x.Present
+	}
+	return 0
+}
+
+func (x *ImportCatalogResponse) GetInvalid() int32 {
+	if x != nil {
+		return x.Invalid
+	}
+	return 0
+}
+
+func (x *ImportCatalogResponse) GetMissing() int32 {
+	if x != nil {
+		return x.Missing
+	}
+	return 0
+}
+
+// Writes backups and their catalog to one encrypted, signed bundle for cold
+// offline storage
+type ExportRepositoryRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	DestinationPath string                 `protobuf:"bytes,1,opt,name=destination_path,json=destinationPath,proto3" json:"destination_path,omitempty"` // bundle file on the orchestrator's host; absolute, outside its storage, and not there yet
+	Password        string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`                                      // encrypts the bundle
+	ModuleId        string                 `protobuf:"bytes,3,opt,name=module_id,json=moduleId,proto3" json:"module_id,omitempty"`                      // only backups of this module, and full backups including it
+	TenantId        *uint32                `protobuf:"varint,4,opt,name=tenant_id,json=tenantId,proto3,oneof" json:"tenant_id,omitempty"`
+	CreatedAfter    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ExportRepositoryRequest) Reset() {
+	*x = ExportRepositoryRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportRepositoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportRepositoryRequest) ProtoMessage() {}
+
+func (x *ExportRepositoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportRepositoryRequest.ProtoReflect.Descriptor instead.
+func (*ExportRepositoryRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *ExportRepositoryRequest) GetDestinationPath() string {
+	if x != nil {
+		return x.DestinationPath
+	}
+	return ""
+}
+
+func (x *ExportRepositoryRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *ExportRepositoryRequest) GetModuleId() string {
+	if x != nil {
+		return x.ModuleId
+	}
+	return ""
+}
+
+func (x *ExportRepositoryRequest) GetTenantId() uint32 {
+	if x != nil && x.TenantId != nil {
+		return *x.TenantId
+	}
+	return 0
+}
+
+func (x *ExportRepositoryRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *ExportRepositoryRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+type ExportRepositoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	SizeBytes     int64                  `protobuf:"varint,2,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	Sha256        string                 `protobuf:"bytes,3,opt,name=sha256,proto3" json:"sha256,omitempty"`                                     // of the bundle file
+	Signer        string                 `protobuf:"bytes,4,opt,name=signer,proto3" json:"signer,omitempty"`                                     // fingerprint of the key it is signed with
+	ModuleBackups int32                  `protobuf:"varint,5,opt,name=module_backups,json=moduleBackups,proto3" json:"module_backups,omitempty"` // including delta bases the filters leave out
+	FullBackups   int32                  `protobuf:"varint,6,opt,name=full_backups,json=fullBackups,proto3" json:"full_backups,omitempty"`
+	Warnings      []string               `protobuf:"bytes,7,rep,name=warnings,proto3" json:"warnings,omitempty"` // backups left out
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportRepositoryResponse) Reset() {
+	*x = ExportRepositoryResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportRepositoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportRepositoryResponse) ProtoMessage() {}
+
+func (x *ExportRepositoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportRepositoryResponse.ProtoReflect.Descriptor instead.
+func (*ExportRepositoryResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *ExportRepositoryResponse) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ExportRepositoryResponse) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *ExportRepositoryResponse) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *ExportRepositoryResponse) GetSigner() string {
+	if x != nil {
+		return x.Signer
+	}
+	return ""
+}
+
+func (x *ExportRepositoryResponse) GetModuleBackups() int32 {
+	if x != nil {
+		return x.ModuleBackups
+	}
+	return 0
+}
+
+func (x *ExportRepositoryResponse) GetFullBackups() int32 {
+	if x != nil {
+		return x.FullBackups
+	}
+	return 0
+}
+
+func (x *ExportRepositoryResponse) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+// Imports the backups of a bundle written by ExportRepository, e.g. onto a
+// fresh orchestrator
+type ImportRepositoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SourcePath    string                 `protobuf:"bytes,1,opt,name=source_path,json=sourcePath,proto3" json:"source_path,omitempty"` // bundle file on the orchestrator's host
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	DryRun        bool                   `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"` // only report what would be imported
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportRepositoryRequest) Reset() {
+	*x = ImportRepositoryRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRepositoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRepositoryRequest) ProtoMessage() {}
+
+func (x *ImportRepositoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRepositoryRequest.ProtoReflect.Descriptor instead.
+func (*ImportRepositoryRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *ImportRepositoryRequest) GetSourcePath() string {
+	if x != nil {
+		return x.SourcePath
+	}
+	return ""
+}
+
+func (x *ImportRepositoryRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *ImportRepositoryRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type ImportRepositoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Signer        string                 `protobuf:"bytes,1,opt,name=signer,proto3" json:"signer,omitempty"`                                     // fingerprint of the key the bundle is signed with
+	SignerTrusted bool                   `protobuf:"varint,2,opt,name=signer_trusted,json=signerTrusted,proto3" json:"signer_trusted,omitempty"` // the key is one of BACKUP_BUNDLE_TRUSTED_KEYS
+	ExportedAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=exported_at,json=exportedAt,proto3" json:"exported_at,omitempty"`
+	ExportedBy    string                 `protobuf:"bytes,4,opt,name=exported_by,json=exportedBy,proto3" json:"exported_by,omitempty"`
+	Site          string                 `protobuf:"bytes,5,opt,name=site,proto3" json:"site,omitempty"`       // of the orchestrator that wrote it
+	Catalog       *ImportCatalogResponse `protobuf:"bytes,6,opt,name=catalog,proto3" json:"catalog,omitempty"` // the backups, checked against the bundle's catalog
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportRepositoryResponse) Reset() {
+	*x = ImportRepositoryResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRepositoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRepositoryResponse) ProtoMessage() {}
+
+func (x *ImportRepositoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRepositoryResponse.ProtoReflect.Descriptor instead.
+func (*ImportRepositoryResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *ImportRepositoryResponse) GetSigner() string {
+	if x != nil {
+		return x.Signer
+	}
+	return ""
+}
+
+func (x *ImportRepositoryResponse) GetSignerTrusted() bool {
+	if x != nil {
+		return x.SignerTrusted
+	}
+	return false
+}
+
+func (x *ImportRepositoryResponse) GetExportedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExportedAt
+	}
+	return nil
+}
+
+func (x *ImportRepositoryResponse) GetExportedBy() string {
+	if x != nil {
+		return x.ExportedBy
+	}
+	return ""
+}
+
+func (x *ImportRepositoryResponse) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+func (x *ImportRepositoryResponse) GetCatalog() *ImportCatalogResponse {
+	if x != nil {
+		return x.Catalog
+	}
+	return nil
+}
+
+// Pre-flight check of targets before a backup or restore
+type PreflightCheckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Targets       []*ModuleTarget        `protobuf:"bytes,1,rep,name=targets,proto3" json:"targets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreflightCheckRequest) Reset() {
+	*x = PreflightCheckRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreflightCheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreflightCheckRequest) ProtoMessage() {}
+
+func (x *PreflightCheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreflightCheckRequest.ProtoReflect.Descriptor instead.
+func (*PreflightCheckRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *PreflightCheckRequest) GetTargets() []*ModuleTarget {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+type TargetPreflightResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ModuleId      string                 `protobuf:"bytes,1,opt,name=module_id,json=moduleId,proto3" json:"module_id,omitempty"`
+	Reachable     bool                   `protobuf:"varint,2,opt,name=reachable,proto3" json:"reachable,omitempty"`
+	Health        string                 `protobuf:"bytes,3,opt,name=health,proto3" json:"health,omitempty"` // grpc.health.v1 status: "SERVING", "NOT_SERVING", ..., or "UNIMPLEMENTED"
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	LatencyMs     int64                  `protobuf:"varint,5,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TargetPreflightResult) Reset() {
+	*x = TargetPreflightResult{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TargetPreflightResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TargetPreflightResult) ProtoMessage() {}
+
+func (x *TargetPreflightResult) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TargetPreflightResult.ProtoReflect.Descriptor instead.
+func (*TargetPreflightResult) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *TargetPreflightResult) GetModuleId() string {
+	if x != nil {
+		return x.ModuleId
+	}
+	return ""
+}
+
+func (x *TargetPreflightResult) GetReachable() bool {
+	if x != nil {
+		return x.Reachable
+	}
+	return false
+}
+
+func (x *TargetPreflightResult) GetHealth() string {
+	if x != nil {
+		return x.Health
+	}
+	return ""
+}
+
+func (x *TargetPreflightResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *TargetPreflightResult) GetLatencyMs() int64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+type PreflightCheckResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Results       []*TargetPreflightResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Ready         bool                     `protobuf:"varint,2,opt,name=ready,proto3" json:"ready,omitempty"` // every target is reachable and not reporting NOT_SERVING
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreflightCheckResponse) Reset() {
+	*x = PreflightCheckResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreflightCheckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreflightCheckResponse) ProtoMessage() {}
+
+func (x *PreflightCheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreflightCheckResponse.ProtoReflect.Descriptor instead.
+func (*PreflightCheckResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *PreflightCheckResponse) GetResults() []*TargetPreflightResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *PreflightCheckResponse) GetReady() bool {
+	if x != nil {
+		return x.Ready
+	}
+	return false
+}
+
+// Guided restore of a whole platform from one full backup, e.g. onto a freshly
+// provisioned environment: checks the modules are there and in a version the
+// data fits, orders them by dependency and restores them one by one,
+// streaming progress. Platform admin only.
+type BootstrapRestoreRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BackupId      string                 `protobuf:"bytes,1,opt,name=backup_id,json=backupId,proto3" json:"backup_id,omitempty"` // full backup; ImportRepository brings one in from a bundle
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`                 // required if the backup is encrypted
+	Mode          RestoreMode            `protobuf:"varint,3,opt,name=mode,proto3,enum=backup.service.v1.RestoreMode" json:"mode,omitempty"`
+	ProfileName   string                 `protobuf:"bytes,4,opt,name=profile_name,json=profileName,proto3" json:"profile_name,omitempty"` // environment profile <name>.json in BACKUP_BOOTSTRAP_PROFILE_DIR
+	Profile       *BootstrapProfile      `protobuf:"bytes,5,opt,name=profile,proto3" json:"profile,omitempty"`                            // inline profile; merged over the named one
+	DryRun        bool                   `protobuf:"varint,6,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`               // stop after the pre-flight checks
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BootstrapRestoreRequest) Reset() {
+	*x = BootstrapRestoreRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BootstrapRestoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BootstrapRestoreRequest) ProtoMessage() {}
+
+func (x *BootstrapRestoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BootstrapRestoreRequest.ProtoReflect.Descriptor instead.
+func (*BootstrapRestoreRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *BootstrapRestoreRequest) GetBackupId() string {
+	if x != nil {
+		return x.BackupId
+	}
+	return ""
+}
+
+func (x *BootstrapRestoreRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *BootstrapRestoreRequest) GetMode() RestoreMode {
+	if x != nil {
+		return x.Mode
+	}
+	return RestoreMode_RESTORE_MODE_SKIP
+}
+
+func (x *BootstrapRestoreRequest) GetProfileName() string {
+	if x != nil {
+		return x.ProfileName
+	}
+	return ""
+}
+
+func (x *BootstrapRestoreRequest) GetProfile() *BootstrapProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+func (x *BootstrapRestoreRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+// What differs between the environments a backup is restored into
+type BootstrapProfile struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Targets           []*ModuleTarget        `protobuf:"bytes,1,rep,name=targets,proto3" json:"targets,omitempty"`                                                 // where the modules run; empty with no selector = the default endpoints
+	TargetSelector    string                 `protobuf:"bytes,2,opt,name=target_selector,json=targetSelector,proto3" json:"target_selector,omitempty"`             // adds the matching registered modules to targets
+	Dependencies      []*ModuleDependency    `protobuf:"bytes,3,rep,name=dependencies,proto3" json:"dependencies,omitempty"`                                       // on top of BACKUP_MODULE_DEPENDENCIES
+	SkipModules       []string               `protobuf:"bytes,4,rep,name=skip_modules,json=skipModules,proto3" json:"skip_modules,omitempty"`                      // modules of the backup to leave out
+	AllowOlderModules bool                   `protobuf:"varint,5,opt,name=allow_older_modules,json=allowOlderModules,proto3" json:"allow_older_modules,omitempty"` // restore into modules older than the ones backed up
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *BootstrapProfile) Reset() {
+	*x = BootstrapProfile{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BootstrapProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BootstrapProfile) ProtoMessage() {}
+
+func (x *BootstrapProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BootstrapProfile.ProtoReflect.Descriptor instead.
+func (*BootstrapProfile) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *BootstrapProfile) GetTargets() []*ModuleTarget {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *BootstrapProfile) GetTargetSelector() string {
+	if x != nil {
+		return x.TargetSelector
+	}
+	return ""
+}
+
+func (x *BootstrapProfile) GetDependencies() []*ModuleDependency {
+	if x != nil {
+		return x.Dependencies
+	}
+	return nil
+}
+
+func (x *BootstrapProfile) GetSkipModules() []string {
+	if x != nil {
+		return x.SkipModules
+	}
+	return nil
+}
+
+func (x *BootstrapProfile) GetAllowOlderModules() bool {
+	if x != nil {
+		return x.AllowOlderModules
+	}
+	return false
+}
+
+type ModuleDependency struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ModuleId      string                 `protobuf:"bytes,1,opt,name=module_id,json=moduleId,proto3" json:"module_id,omitempty"`
+	DependsOn     []string               `protobuf:"bytes,2,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"` // restored before module_id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModuleDependency) Reset() {
+	*x = ModuleDependency{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModuleDependency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModuleDependency) ProtoMessage() {}
+
+func (x *ModuleDependency) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModuleDependency.ProtoReflect.Descriptor instead.
+func (*ModuleDependency) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *ModuleDependency) GetModuleId() string {
+	if x != nil {
+		return x.ModuleId
+	}
+	return ""
+}
+
+func (x *ModuleDependency) GetDependsOn() []string {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
+type BootstrapModule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ModuleId      string                 `protobuf:"bytes,1,opt,name=module_id,json=moduleId,proto3" json:"module_id,omitempty"`
+	GrpcEndpoint  string                 `protobuf:"bytes,2,opt,name=grpc_endpoint,json=grpcEndpoint,proto3" json:"grpc_endpoint,omitempty"` // empty when the profile has no target for it
+	Wave          int32                  `protobuf:"varint,3,opt,name=wave,proto3" json:"wave,omitempty"`                                    // dependency depth; modules restore in wave order
+	DependsOn     []string               `protobuf:"bytes,4,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
+	BackupVersion string                 `protobuf:"bytes,5,opt,name=backup_version,json=backupVersion,proto3" json:"backup_version,omitempty"` // module version the backup was taken from
+	SchemaVersion int32                  `protobuf:"varint,6,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	TargetVersion string                 `protobuf:"bytes,7,opt,name=target_version,json=targetVersion,proto3" json:"target_version,omitempty"` // version registered with the admin service; empty if unknown
+	SizeBytes     int64                  `protobuf:"varint,8,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	State         string                 `protobuf:"bytes,9,opt,name=state,proto3" json:"state,omitempty"`        // "ready", "blocked", "skipped", "restoring", "restored", "failed"
+	Problems      []string               `protobuf:"bytes,10,rep,name=problems,proto3" json:"problems,omitempty"` // why it is blocked, skipped or failed
+	Warnings      []string               `protobuf:"bytes,11,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	Preflight     *TargetPreflightResult `protobuf:"bytes,12,opt,name=preflight,proto3" json:"preflight,omitempty"`
+	Result        *ModuleRestoreResult   `protobuf:"bytes,13,opt,name=result,proto3" json:"result,omitempty"` // once restored or failed
+	DurationMs    int64                  `protobuf:"varint,14,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BootstrapModule) Reset() {
+	*x = BootstrapModule{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BootstrapModule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BootstrapModule) ProtoMessage() {}
+
+func (x *BootstrapModule) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BootstrapModule.ProtoReflect.Descriptor instead.
+func (*BootstrapModule) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *BootstrapModule) GetModuleId() string {
+	if x != nil {
+		return x.ModuleId
+	}
+	return ""
+}
+
+func (x *BootstrapModule) GetGrpcEndpoint() string {
+	if x != nil {
+		return x.GrpcEndpoint
+	}
+	return ""
+}
+
+func (x *BootstrapModule) GetWave() int32 {
+	if x != nil {
+		return x.Wave
+	}
+	return 0
+}
+
+func (x *BootstrapModule) GetDependsOn() []string {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
+func (x *BootstrapModule) GetBackupVersion() string {
+	if x != nil {
+		return x.BackupVersion
+	}
+	return ""
+}
+
+func (x *BootstrapModule) GetSchemaVersion() int32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *BootstrapModule) GetTargetVersion() string {
+	if x != nil {
+		return x.TargetVersion
+	}
+	return ""
+}
+
+func (x *BootstrapModule) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *BootstrapModule) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *BootstrapModule) GetProblems() []string {
+	if x != nil {
+		return x.Problems
+	}
+	return nil
+}
+
+func (x *BootstrapModule) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+func (x *BootstrapModule) GetPreflight() *TargetPreflightResult {
+	if x != nil {
+		return x.Preflight
+	}
+	return nil
+}
+
+func (x *BootstrapModule) GetResult() *ModuleRestoreResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *BootstrapModule) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+type BootstrapPlan struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	BackupId        string                 `protobuf:"bytes,1,opt,name=backup_id,json=backupId,proto3" json:"backup_id,omitempty"`
+	BackupCreatedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=backup_created_at,json=backupCreatedAt,proto3" json:"backup_created_at,omitempty"`
+	Modules         []*BootstrapModule     `protobuf:"bytes,3,rep,name=modules,proto3" json:"modules,omitempty"`   // in restore order
+	Problems        []string               `protobuf:"bytes,4,rep,name=problems,proto3" json:"problems,omitempty"` // plan-wide, e.g. a dependency cycle
+	Warnings        []string               `protobuf:"bytes,5,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	Ready           bool                   `protobuf:"varint,6,opt,name=ready,proto3" json:"ready,omitempty"` // nothing is blocked
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *BootstrapPlan) Reset() {
+	*x = BootstrapPlan{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BootstrapPlan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BootstrapPlan) ProtoMessage() {}
+
+func (x *BootstrapPlan) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BootstrapPlan.ProtoReflect.Descriptor instead.
+func (*BootstrapPlan) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *BootstrapPlan) GetBackupId() string {
+	if x != nil {
+		return x.BackupId
+	}
+	return ""
+}
+
+func (x *BootstrapPlan) GetBackupCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BackupCreatedAt
+	}
+	return nil
+}
+
+func (x *BootstrapPlan) GetModules() []*BootstrapModule {
+	if x != nil {
+		return x.Modules
+	}
+	return nil
+}
+
+func (x *BootstrapPlan) GetProblems() []string {
+	if x != nil {
+		return x.Problems
+	}
+	return nil
+}
+
+func (x *BootstrapPlan) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+func (x *BootstrapPlan) GetReady() bool {
+	if x != nil {
+		return x.Ready
+	}
+	return false
+}
+
+type BootstrapResult struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Backups       []*BackupInfo          `protobuf:"bytes,1,rep,name=backups,proto3" json:"backups,omitempty"`
-	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Restored      int32                  `protobuf:"varint,2,opt,name=restored,proto3" json:"restored,omitempty"`
+	Failed        int32                  `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	Skipped       int32                  `protobuf:"varint,4,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	DurationMs    int64                  `protobuf:"varint,5,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	RequestId     string                 `protobuf:"bytes,6,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListBackupsResponse) Reset() {
-	*x = ListBackupsResponse{}
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[7]
+func (x *BootstrapResult) Reset() {
+	*x = BootstrapResult{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[92]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListBackupsResponse) String() string {
+func (x *BootstrapResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListBackupsResponse) ProtoMessage() {}
+func (*BootstrapResult) ProtoMessage() {}
 
-func (x *ListBackupsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[7]
+func (x *BootstrapResult) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[92]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -599,92 +6591,83 @@ func (x *ListBackupsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListBackupsResponse.ProtoReflect.Descriptor instead.
-func (*ListBackupsResponse) Descriptor() ([]byte, []int) {
-	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use BootstrapResult.ProtoReflect.Descriptor instead.
+func (*BootstrapResult) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{92}
 }
 
-func (x *ListBackupsResponse) GetBackups() []*BackupInfo {
+func (x *BootstrapResult) GetSuccess() bool {
 	if x != nil {
-		return x.Backups
+		return x.Success
 	}
-	return nil
+	return false
 }
 
-func (x *ListBackupsResponse) GetTotal() int32 {
+func (x *BootstrapResult) GetRestored() int32 {
 	if x != nil {
-		return x.Total
+		return x.Restored
 	}
 	return 0
 }
 
-// Get
-type GetBackupRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetBackupRequest) Reset() {
-	*x = GetBackupRequest{}
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[8]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetBackupRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *BootstrapResult) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
 }
 
-func (*GetBackupRequest) ProtoMessage() {}
-
-func (x *GetBackupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[8]
+func (x *BootstrapResult) GetSkipped() int32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Skipped
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use GetBackupRequest.ProtoReflect.Descriptor instead.
-func (*GetBackupRequest) Descriptor() ([]byte, []int) {
-	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{8}
+func (x *BootstrapResult) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
 }
 
-func (x *GetBackupRequest) GetId() string {
+func (x *BootstrapResult) GetRequestId() string {
 	if x != nil {
-		return x.Id
+		return x.RequestId
 	}
 	return ""
 }
 
-type GetBackupResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Backup        *BackupInfo            `protobuf:"bytes,1,opt,name=backup,proto3" json:"backup,omitempty"`
+type BootstrapRestoreEvent struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Time    *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Phase   string                 `protobuf:"bytes,2,opt,name=phase,proto3" json:"phase,omitempty"` // "validate", "preflight", "restore" or "done"
+	Message string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*BootstrapRestoreEvent_Plan
+	//	*BootstrapRestoreEvent_Module
+	//	*BootstrapRestoreEvent_Result
+	Payload       isBootstrapRestoreEvent_Payload `protobuf_oneof:"payload"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetBackupResponse) Reset() {
-	*x = GetBackupResponse{}
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[9]
+func (x *BootstrapRestoreEvent) Reset() {
+	*x = BootstrapRestoreEvent{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[93]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetBackupResponse) String() string {
+func (x *BootstrapRestoreEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetBackupResponse) ProtoMessage() {}
+func (*BootstrapRestoreEvent) ProtoMessage() {}
 
-func (x *GetBackupResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[9]
+func (x *BootstrapRestoreEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[93]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -695,85 +6678,110 @@ func (x *GetBackupResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetBackupResponse.ProtoReflect.Descriptor instead.
-func (*GetBackupResponse) Descriptor() ([]byte, []int) {
-	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use BootstrapRestoreEvent.ProtoReflect.Descriptor instead.
+func (*BootstrapRestoreEvent) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{93}
 }
 
-func (x *GetBackupResponse) GetBackup() *BackupInfo {
+func (x *BootstrapRestoreEvent) GetTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Backup
+		return x.Time
 	}
 	return nil
 }
 
-// Delete
-type DeleteBackupRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *BootstrapRestoreEvent) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
 }
 
-func (x *DeleteBackupRequest) Reset() {
-	*x = DeleteBackupRequest{}
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[10]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *BootstrapRestoreEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
 }
 
-func (x *DeleteBackupRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *BootstrapRestoreEvent) GetPayload() isBootstrapRestoreEvent_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
 }
 
-func (*DeleteBackupRequest) ProtoMessage() {}
-
-func (x *DeleteBackupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[10]
+func (x *BootstrapRestoreEvent) GetPlan() *BootstrapPlan {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
+		if x, ok := x.Payload.(*BootstrapRestoreEvent_Plan); ok {
+			return x.Plan
 		}
-		return ms
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use DeleteBackupRequest.ProtoReflect.Descriptor instead.
-func (*DeleteBackupRequest) Descriptor() ([]byte, []int) {
-	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{10}
+func (x *BootstrapRestoreEvent) GetModule() *BootstrapModule {
+	if x != nil {
+		if x, ok := x.Payload.(*BootstrapRestoreEvent_Module); ok {
+			return x.Module
+		}
+	}
+	return nil
 }
 
-func (x *DeleteBackupRequest) GetId() string {
+func (x *BootstrapRestoreEvent) GetResult() *BootstrapResult {
 	if x != nil {
-		return x.Id
+		if x, ok := x.Payload.(*BootstrapRestoreEvent_Result); ok {
+			return x.Result
+		}
 	}
-	return ""
+	return nil
 }
 
-type DeleteBackupResponse struct {
+type isBootstrapRestoreEvent_Payload interface {
+	isBootstrapRestoreEvent_Payload()
+}
+
+type BootstrapRestoreEvent_Plan struct {
+	Plan *BootstrapPlan `protobuf:"bytes,4,opt,name=plan,proto3,oneof"` // after validation and again after the pre-flight checks
+}
+
+type BootstrapRestoreEvent_Module struct {
+	Module *BootstrapModule `protobuf:"bytes,5,opt,name=module,proto3,oneof"` // a module changed state
+}
+
+type BootstrapRestoreEvent_Result struct {
+	Result *BootstrapResult `protobuf:"bytes,6,opt,name=result,proto3,oneof"` // last event
+}
+
+func (*BootstrapRestoreEvent_Plan) isBootstrapRestoreEvent_Payload() {}
+
+func (*BootstrapRestoreEvent_Module) isBootstrapRestoreEvent_Payload() {}
+
+func (*BootstrapRestoreEvent_Result) isBootstrapRestoreEvent_Payload() {}
+
+// The descriptor set bundled with the service, for dynamic clients
+type GetDescriptorSetRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteBackupResponse) Reset() {
-	*x = DeleteBackupResponse{}
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[11]
+func (x *GetDescriptorSetRequest) Reset() {
+	*x = GetDescriptorSetRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[94]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteBackupResponse) String() string {
+func (x *GetDescriptorSetRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteBackupResponse) ProtoMessage() {}
+func (*GetDescriptorSetRequest) ProtoMessage() {}
 
-func (x *DeleteBackupResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[11]
+func (x *GetDescriptorSetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[94]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -784,42 +6792,34 @@ func (x *DeleteBackupResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteBackupResponse.ProtoReflect.Descriptor instead.
-func (*DeleteBackupResponse) Descriptor() ([]byte, []int) {
-	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{11}
-}
-
-func (x *DeleteBackupResponse) GetSuccess() bool {
-	if x != nil {
-		return x.Success
-	}
-	return false
+// Deprecated: Use GetDescriptorSetRequest.ProtoReflect.Descriptor instead.
+func (*GetDescriptorSetRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{94}
 }
 
-// Download raw backup data
-type DownloadBackupRequest struct {
+type GetDescriptorSetResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"` // required if backup is encrypted
+	DescriptorSet []byte                 `protobuf:"bytes,1,opt,name=descriptor_set,json=descriptorSet,proto3" json:"descriptor_set,omitempty"` // serialized google.protobuf.FileDescriptorSet, imports included
+	Services      []string               `protobuf:"bytes,2,rep,name=services,proto3" json:"services,omitempty"`                                // fully-qualified names of the services it describes
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DownloadBackupRequest) Reset() {
-	*x = DownloadBackupRequest{}
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[12]
+func (x *GetDescriptorSetResponse) Reset() {
+	*x = GetDescriptorSetResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[95]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DownloadBackupRequest) String() string {
+func (x *GetDescriptorSetResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DownloadBackupRequest) ProtoMessage() {}
+func (*GetDescriptorSetResponse) ProtoMessage() {}
 
-func (x *DownloadBackupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[12]
+func (x *GetDescriptorSetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[95]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -830,48 +6830,52 @@ func (x *DownloadBackupRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DownloadBackupRequest.ProtoReflect.Descriptor instead.
-func (*DownloadBackupRequest) Descriptor() ([]byte, []int) {
-	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use GetDescriptorSetResponse.ProtoReflect.Descriptor instead.
+func (*GetDescriptorSetResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{95}
 }
 
-func (x *DownloadBackupRequest) GetId() string {
+func (x *GetDescriptorSetResponse) GetDescriptorSet() []byte {
 	if x != nil {
-		return x.Id
+		return x.DescriptorSet
 	}
-	return ""
+	return nil
 }
 
-func (x *DownloadBackupRequest) GetPassword() string {
+func (x *GetDescriptorSetResponse) GetServices() []string {
 	if x != nil {
-		return x.Password
+		return x.Services
 	}
-	return ""
+	return nil
 }
 
-type DownloadBackupResponse struct {
+// Backup statistics computed from stored metadata
+type GetBackupStatisticsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
-	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	TenantId      *uint32                `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3,oneof" json:"tenant_id,omitempty"`
+	ModuleId      string                 `protobuf:"bytes,2,opt,name=module_id,json=moduleId,proto3" json:"module_id,omitempty"`    // limit to one module
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"` // default: 30 days before end_time
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`       // default: now
+	Bucket        string                 `protobuf:"bytes,5,opt,name=bucket,proto3" json:"bucket,omitempty"`                        // "hour", "day" (default), "week"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DownloadBackupResponse) Reset() {
-	*x = DownloadBackupResponse{}
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[13]
+func (x *GetBackupStatisticsRequest) Reset() {
+	*x = GetBackupStatisticsRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[96]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DownloadBackupResponse) String() string {
+func (x *GetBackupStatisticsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DownloadBackupResponse) ProtoMessage() {}
+func (*GetBackupStatisticsRequest) ProtoMessage() {}
 
-func (x *DownloadBackupResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[13]
+func (x *GetBackupStatisticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[96]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -882,52 +6886,74 @@ func (x *DownloadBackupResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DownloadBackupResponse.ProtoReflect.Descriptor instead.
-func (*DownloadBackupResponse) Descriptor() ([]byte, []int) {
-	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use GetBackupStatisticsRequest.ProtoReflect.Descriptor instead.
+func (*GetBackupStatisticsRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{96}
 }
 
-func (x *DownloadBackupResponse) GetData() []byte {
+func (x *GetBackupStatisticsRequest) GetTenantId() uint32 {
+	if x != nil && x.TenantId != nil {
+		return *x.TenantId
+	}
+	return 0
+}
+
+func (x *GetBackupStatisticsRequest) GetModuleId() string {
 	if x != nil {
-		return x.Data
+		return x.ModuleId
+	}
+	return ""
+}
+
+func (x *GetBackupStatisticsRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
 	}
 	return nil
 }
 
-func (x *DownloadBackupResponse) GetFilename() string {
+func (x *GetBackupStatisticsRequest) GetEndTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Filename
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *GetBackupStatisticsRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
 	}
 	return ""
 }
 
-// Full platform backup (all modules)
-type CreateFullBackupRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Targets        []*ModuleTarget        `protobuf:"bytes,1,rep,name=targets,proto3" json:"targets,omitempty"` // portal sends all registered modules
-	TenantId       *uint32                `protobuf:"varint,2,opt,name=tenant_id,json=tenantId,proto3,oneof" json:"tenant_id,omitempty"`
-	Description    string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	IncludeSecrets bool                   `protobuf:"varint,4,opt,name=include_secrets,json=includeSecrets,proto3" json:"include_secrets,omitempty"` // include Vault passwords in export
-	Password       string                 `protobuf:"bytes,5,opt,name=password,proto3" json:"password,omitempty"`                                    // if set, backup is AES-256-GCM encrypted
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type BackupStatisticsBucket struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Start                *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	BackupCount          int32                  `protobuf:"varint,2,opt,name=backup_count,json=backupCount,proto3" json:"backup_count,omitempty"`
+	FailedCount          int32                  `protobuf:"varint,3,opt,name=failed_count,json=failedCount,proto3" json:"failed_count,omitempty"`
+	TotalBytes           int64                  `protobuf:"varint,4,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
+	AvgDurationMs        int64                  `protobuf:"varint,5,opt,name=avg_duration_ms,json=avgDurationMs,proto3" json:"avg_duration_ms,omitempty"` // over backups that recorded a duration
+	FailureRate          float64                `protobuf:"fixed64,6,opt,name=failure_rate,json=failureRate,proto3" json:"failure_rate,omitempty"`        // failed_count / backup_count
+	TotalCompressedBytes int64                  `protobuf:"varint,7,opt,name=total_compressed_bytes,json=totalCompressedBytes,proto3" json:"total_compressed_bytes,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
-func (x *CreateFullBackupRequest) Reset() {
-	*x = CreateFullBackupRequest{}
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[14]
+func (x *BackupStatisticsBucket) Reset() {
+	*x = BackupStatisticsBucket{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[97]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateFullBackupRequest) String() string {
+func (x *BackupStatisticsBucket) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateFullBackupRequest) ProtoMessage() {}
+func (*BackupStatisticsBucket) ProtoMessage() {}
 
-func (x *CreateFullBackupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[14]
+func (x *BackupStatisticsBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[97]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -938,78 +6964,92 @@ func (x *CreateFullBackupRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateFullBackupRequest.ProtoReflect.Descriptor instead.
-func (*CreateFullBackupRequest) Descriptor() ([]byte, []int) {
-	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use BackupStatisticsBucket.ProtoReflect.Descriptor instead.
+func (*BackupStatisticsBucket) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{97}
 }
 
-func (x *CreateFullBackupRequest) GetTargets() []*ModuleTarget {
+func (x *BackupStatisticsBucket) GetStart() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Targets
+		return x.Start
 	}
 	return nil
 }
 
-func (x *CreateFullBackupRequest) GetTenantId() uint32 {
-	if x != nil && x.TenantId != nil {
-		return *x.TenantId
+func (x *BackupStatisticsBucket) GetBackupCount() int32 {
+	if x != nil {
+		return x.BackupCount
 	}
 	return 0
 }
 
-func (x *CreateFullBackupRequest) GetDescription() string {
+func (x *BackupStatisticsBucket) GetFailedCount() int32 {
 	if x != nil {
-		return x.Description
+		return x.FailedCount
 	}
-	return ""
+	return 0
 }
 
-func (x *CreateFullBackupRequest) GetIncludeSecrets() bool {
+func (x *BackupStatisticsBucket) GetTotalBytes() int64 {
 	if x != nil {
-		return x.IncludeSecrets
+		return x.TotalBytes
 	}
-	return false
+	return 0
 }
 
-func (x *CreateFullBackupRequest) GetPassword() string {
+func (x *BackupStatisticsBucket) GetAvgDurationMs() int64 {
 	if x != nil {
-		return x.Password
+		return x.AvgDurationMs
 	}
-	return ""
+	return 0
 }
 
-type FullBackupInfo struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Description    string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	TenantId       uint32                 `protobuf:"varint,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
-	FullBackup     bool                   `protobuf:"varint,4,opt,name=full_backup,json=fullBackup,proto3" json:"full_backup,omitempty"`
-	Status         string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
-	TotalSizeBytes int64                  `protobuf:"varint,6,opt,name=total_size_bytes,json=totalSizeBytes,proto3" json:"total_size_bytes,omitempty"`
-	ModuleBackups  []*BackupInfo          `protobuf:"bytes,7,rep,name=module_backups,json=moduleBackups,proto3" json:"module_backups,omitempty"`
-	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	CreatedBy      string                 `protobuf:"bytes,9,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
-	Errors         []string               `protobuf:"bytes,10,rep,name=errors,proto3" json:"errors,omitempty"`
-	Encrypted      bool                   `protobuf:"varint,11,opt,name=encrypted,proto3" json:"encrypted,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *BackupStatisticsBucket) GetFailureRate() float64 {
+	if x != nil {
+		return x.FailureRate
+	}
+	return 0
 }
 
-func (x *FullBackupInfo) Reset() {
-	*x = FullBackupInfo{}
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[15]
+func (x *BackupStatisticsBucket) GetTotalCompressedBytes() int64 {
+	if x != nil {
+		return x.TotalCompressedBytes
+	}
+	return 0
+}
+
+type ModuleBackupTrend struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	ModuleId             string                 `protobuf:"bytes,1,opt,name=module_id,json=moduleId,proto3" json:"module_id,omitempty"`
+	TenantId             uint32                 `protobuf:"varint,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	BackupCount          int32                  `protobuf:"varint,3,opt,name=backup_count,json=backupCount,proto3" json:"backup_count,omitempty"`
+	FirstSizeBytes       int64                  `protobuf:"varint,4,opt,name=first_size_bytes,json=firstSizeBytes,proto3" json:"first_size_bytes,omitempty"` // oldest successful backup in range
+	LastSizeBytes        int64                  `protobuf:"varint,5,opt,name=last_size_bytes,json=lastSizeBytes,proto3" json:"last_size_bytes,omitempty"`    // newest successful backup in range
+	GrowthBytes          int64                  `protobuf:"varint,6,opt,name=growth_bytes,json=growthBytes,proto3" json:"growth_bytes,omitempty"`
+	GrowthPercent        float64                `protobuf:"fixed64,7,opt,name=growth_percent,json=growthPercent,proto3" json:"growth_percent,omitempty"`
+	AvgCompressionRatio  float64                `protobuf:"fixed64,8,opt,name=avg_compression_ratio,json=avgCompressionRatio,proto3" json:"avg_compression_ratio,omitempty"`
+	LastCompressionRatio float64                `protobuf:"fixed64,9,opt,name=last_compression_ratio,json=lastCompressionRatio,proto3" json:"last_compression_ratio,omitempty"`
+	LastGrowthPercent    float64                `protobuf:"fixed64,10,opt,name=last_growth_percent,json=lastGrowthPercent,proto3" json:"last_growth_percent,omitempty"` // newest vs the backup before it
+	Ballooned            bool                   `protobuf:"varint,11,opt,name=ballooned,proto3" json:"ballooned,omitempty"`                                             // last_growth_percent above the alert threshold
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ModuleBackupTrend) Reset() {
+	*x = ModuleBackupTrend{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[98]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FullBackupInfo) String() string {
+func (x *ModuleBackupTrend) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FullBackupInfo) ProtoMessage() {}
+func (*ModuleBackupTrend) ProtoMessage() {}
 
-func (x *FullBackupInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[15]
+func (x *ModuleBackupTrend) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[98]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1020,110 +7060,116 @@ func (x *FullBackupInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FullBackupInfo.ProtoReflect.Descriptor instead.
-func (*FullBackupInfo) Descriptor() ([]byte, []int) {
-	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use ModuleBackupTrend.ProtoReflect.Descriptor instead.
+func (*ModuleBackupTrend) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{98}
 }
 
-func (x *FullBackupInfo) GetId() string {
+func (x *ModuleBackupTrend) GetModuleId() string {
 	if x != nil {
-		return x.Id
+		return x.ModuleId
 	}
 	return ""
 }
 
-func (x *FullBackupInfo) GetDescription() string {
+func (x *ModuleBackupTrend) GetTenantId() uint32 {
 	if x != nil {
-		return x.Description
+		return x.TenantId
 	}
-	return ""
+	return 0
 }
 
-func (x *FullBackupInfo) GetTenantId() uint32 {
+func (x *ModuleBackupTrend) GetBackupCount() int32 {
 	if x != nil {
-		return x.TenantId
+		return x.BackupCount
 	}
 	return 0
 }
 
-func (x *FullBackupInfo) GetFullBackup() bool {
+func (x *ModuleBackupTrend) GetFirstSizeBytes() int64 {
 	if x != nil {
-		return x.FullBackup
+		return x.FirstSizeBytes
 	}
-	return false
+	return 0
 }
 
-func (x *FullBackupInfo) GetStatus() string {
+func (x *ModuleBackupTrend) GetLastSizeBytes() int64 {
 	if x != nil {
-		return x.Status
+		return x.LastSizeBytes
 	}
-	return ""
+	return 0
 }
 
-func (x *FullBackupInfo) GetTotalSizeBytes() int64 {
+func (x *ModuleBackupTrend) GetGrowthBytes() int64 {
 	if x != nil {
-		return x.TotalSizeBytes
+		return x.GrowthBytes
 	}
 	return 0
 }
 
-func (x *FullBackupInfo) GetModuleBackups() []*BackupInfo {
+func (x *ModuleBackupTrend) GetGrowthPercent() float64 {
 	if x != nil {
-		return x.ModuleBackups
+		return x.GrowthPercent
 	}
-	return nil
+	return 0
 }
 
-func (x *FullBackupInfo) GetCreatedAt() *timestamppb.Timestamp {
+func (x *ModuleBackupTrend) GetAvgCompressionRatio() float64 {
 	if x != nil {
-		return x.CreatedAt
+		return x.AvgCompressionRatio
 	}
-	return nil
+	return 0
 }
 
-func (x *FullBackupInfo) GetCreatedBy() string {
+func (x *ModuleBackupTrend) GetLastCompressionRatio() float64 {
 	if x != nil {
-		return x.CreatedBy
+		return x.LastCompressionRatio
 	}
-	return ""
+	return 0
 }
 
-func (x *FullBackupInfo) GetErrors() []string {
+func (x *ModuleBackupTrend) GetLastGrowthPercent() float64 {
 	if x != nil {
-		return x.Errors
+		return x.LastGrowthPercent
 	}
-	return nil
+	return 0
 }
 
-func (x *FullBackupInfo) GetEncrypted() bool {
+func (x *ModuleBackupTrend) GetBallooned() bool {
 	if x != nil {
-		return x.Encrypted
+		return x.Ballooned
 	}
 	return false
 }
 
-type CreateFullBackupResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Backup        *FullBackupInfo        `protobuf:"bytes,1,opt,name=backup,proto3" json:"backup,omitempty"`
+type GetBackupStatisticsResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Buckets       []*BackupStatisticsBucket `protobuf:"bytes,1,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	TotalBackups  int32                     `protobuf:"varint,2,opt,name=total_backups,json=totalBackups,proto3" json:"total_backups,omitempty"`
+	FailedBackups int32                     `protobuf:"varint,3,opt,name=failed_backups,json=failedBackups,proto3" json:"failed_backups,omitempty"`
+	TotalBytes    int64                     `protobuf:"varint,4,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
+	AvgDurationMs int64                     `protobuf:"varint,5,opt,name=avg_duration_ms,json=avgDurationMs,proto3" json:"avg_duration_ms,omitempty"`
+	FailureRate   float64                   `protobuf:"fixed64,6,opt,name=failure_rate,json=failureRate,proto3" json:"failure_rate,omitempty"`
+	ModuleTrends  []*ModuleBackupTrend      `protobuf:"bytes,7,rep,name=module_trends,json=moduleTrends,proto3" json:"module_trends,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateFullBackupResponse) Reset() {
-	*x = CreateFullBackupResponse{}
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[16]
+func (x *GetBackupStatisticsResponse) Reset() {
+	*x = GetBackupStatisticsResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[99]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateFullBackupResponse) String() string {
+func (x *GetBackupStatisticsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateFullBackupResponse) ProtoMessage() {}
+func (*GetBackupStatisticsResponse) ProtoMessage() {}
 
-func (x *CreateFullBackupResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[16]
+func (x *GetBackupStatisticsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[99]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1134,110 +7180,84 @@ func (x *CreateFullBackupResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateFullBackupResponse.ProtoReflect.Descriptor instead.
-func (*CreateFullBackupResponse) Descriptor() ([]byte, []int) {
-	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use GetBackupStatisticsResponse.ProtoReflect.Descriptor instead.
+func (*GetBackupStatisticsResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{99}
 }
 
-func (x *CreateFullBackupResponse) GetBackup() *FullBackupInfo {
+func (x *GetBackupStatisticsResponse) GetBuckets() []*BackupStatisticsBucket {
 	if x != nil {
-		return x.Backup
+		return x.Buckets
 	}
 	return nil
 }
 
-// Restore full backup
-type RestoreFullBackupRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	BackupId      string                 `protobuf:"bytes,1,opt,name=backup_id,json=backupId,proto3" json:"backup_id,omitempty"`
-	Targets       []*ModuleTarget        `protobuf:"bytes,2,rep,name=targets,proto3" json:"targets,omitempty"` // portal sends endpoints for each module
-	Mode          RestoreMode            `protobuf:"varint,3,opt,name=mode,proto3,enum=backup.service.v1.RestoreMode" json:"mode,omitempty"`
-	Password      string                 `protobuf:"bytes,4,opt,name=password,proto3" json:"password,omitempty"` // required if backup is encrypted
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *RestoreFullBackupRequest) Reset() {
-	*x = RestoreFullBackupRequest{}
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[17]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *RestoreFullBackupRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*RestoreFullBackupRequest) ProtoMessage() {}
-
-func (x *RestoreFullBackupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[17]
+func (x *GetBackupStatisticsResponse) GetTotalBackups() int32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.TotalBackups
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use RestoreFullBackupRequest.ProtoReflect.Descriptor instead.
-func (*RestoreFullBackupRequest) Descriptor() ([]byte, []int) {
-	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{17}
+func (x *GetBackupStatisticsResponse) GetFailedBackups() int32 {
+	if x != nil {
+		return x.FailedBackups
+	}
+	return 0
 }
 
-func (x *RestoreFullBackupRequest) GetBackupId() string {
+func (x *GetBackupStatisticsResponse) GetTotalBytes() int64 {
 	if x != nil {
-		return x.BackupId
+		return x.TotalBytes
 	}
-	return ""
+	return 0
 }
 
-func (x *RestoreFullBackupRequest) GetTargets() []*ModuleTarget {
+func (x *GetBackupStatisticsResponse) GetAvgDurationMs() int64 {
 	if x != nil {
-		return x.Targets
+		return x.AvgDurationMs
 	}
-	return nil
+	return 0
 }
 
-func (x *RestoreFullBackupRequest) GetMode() RestoreMode {
+func (x *GetBackupStatisticsResponse) GetFailureRate() float64 {
 	if x != nil {
-		return x.Mode
+		return x.FailureRate
 	}
-	return RestoreMode_RESTORE_MODE_SKIP
+	return 0
 }
 
-func (x *RestoreFullBackupRequest) GetPassword() string {
+func (x *GetBackupStatisticsResponse) GetModuleTrends() []*ModuleBackupTrend {
 	if x != nil {
-		return x.Password
+		return x.ModuleTrends
 	}
-	return ""
+	return nil
 }
 
-type RestoreFullBackupResponse struct {
+// Age of the last successful backup per module and tenant
+type GetBackupFreshnessRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	ModuleResults []*ModuleRestoreResult `protobuf:"bytes,2,rep,name=module_results,json=moduleResults,proto3" json:"module_results,omitempty"`
+	TenantId      *uint32                `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3,oneof" json:"tenant_id,omitempty"`
+	ModuleId      string                 `protobuf:"bytes,2,opt,name=module_id,json=moduleId,proto3" json:"module_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RestoreFullBackupResponse) Reset() {
-	*x = RestoreFullBackupResponse{}
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[18]
+func (x *GetBackupFreshnessRequest) Reset() {
+	*x = GetBackupFreshnessRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[100]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RestoreFullBackupResponse) String() string {
+func (x *GetBackupFreshnessRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RestoreFullBackupResponse) ProtoMessage() {}
+func (*GetBackupFreshnessRequest) ProtoMessage() {}
 
-func (x *RestoreFullBackupResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[18]
+func (x *GetBackupFreshnessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[100]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1248,51 +7268,52 @@ func (x *RestoreFullBackupResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RestoreFullBackupResponse.ProtoReflect.Descriptor instead.
-func (*RestoreFullBackupResponse) Descriptor() ([]byte, []int) {
-	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use GetBackupFreshnessRequest.ProtoReflect.Descriptor instead.
+func (*GetBackupFreshnessRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{100}
 }
 
-func (x *RestoreFullBackupResponse) GetSuccess() bool {
-	if x != nil {
-		return x.Success
+func (x *GetBackupFreshnessRequest) GetTenantId() uint32 {
+	if x != nil && x.TenantId != nil {
+		return *x.TenantId
 	}
-	return false
+	return 0
 }
 
-func (x *RestoreFullBackupResponse) GetModuleResults() []*ModuleRestoreResult {
+func (x *GetBackupFreshnessRequest) GetModuleId() string {
 	if x != nil {
-		return x.ModuleResults
+		return x.ModuleId
 	}
-	return nil
+	return ""
 }
 
-type ModuleRestoreResult struct {
+type ModuleFreshness struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ModuleId      string                 `protobuf:"bytes,1,opt,name=module_id,json=moduleId,proto3" json:"module_id,omitempty"`
-	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
-	Results       []*EntityImportResult  `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
-	Warnings      []string               `protobuf:"bytes,4,rep,name=warnings,proto3" json:"warnings,omitempty"`
-	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	TenantId      uint32                 `protobuf:"varint,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	LastSuccessAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_success_at,json=lastSuccessAt,proto3" json:"last_success_at,omitempty"` // unset if no backup ever succeeded
+	AgeSeconds    int64                  `protobuf:"varint,4,opt,name=age_seconds,json=ageSeconds,proto3" json:"age_seconds,omitempty"`           // -1 if no backup ever succeeded
+	SloSeconds    int64                  `protobuf:"varint,5,opt,name=slo_seconds,json=sloSeconds,proto3" json:"slo_seconds,omitempty"`           // configured freshness SLO
+	Violated      bool                   `protobuf:"varint,6,opt,name=violated,proto3" json:"violated,omitempty"`                                 // age exceeds the SLO, or never succeeded
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ModuleRestoreResult) Reset() {
-	*x = ModuleRestoreResult{}
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[19]
+func (x *ModuleFreshness) Reset() {
+	*x = ModuleFreshness{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[101]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ModuleRestoreResult) String() string {
+func (x *ModuleFreshness) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ModuleRestoreResult) ProtoMessage() {}
+func (*ModuleFreshness) ProtoMessage() {}
 
-func (x *ModuleRestoreResult) ProtoReflect() protoreflect.Message {
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[19]
+func (x *ModuleFreshness) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[101]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1303,71 +7324,76 @@ func (x *ModuleRestoreResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ModuleRestoreResult.ProtoReflect.Descriptor instead.
-func (*ModuleRestoreResult) Descriptor() ([]byte, []int) {
-	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use ModuleFreshness.ProtoReflect.Descriptor instead.
+func (*ModuleFreshness) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{101}
 }
 
-func (x *ModuleRestoreResult) GetModuleId() string {
+func (x *ModuleFreshness) GetModuleId() string {
 	if x != nil {
 		return x.ModuleId
 	}
 	return ""
 }
 
-func (x *ModuleRestoreResult) GetSuccess() bool {
+func (x *ModuleFreshness) GetTenantId() uint32 {
 	if x != nil {
-		return x.Success
+		return x.TenantId
 	}
-	return false
+	return 0
 }
 
-func (x *ModuleRestoreResult) GetResults() []*EntityImportResult {
+func (x *ModuleFreshness) GetLastSuccessAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Results
+		return x.LastSuccessAt
 	}
 	return nil
 }
 
-func (x *ModuleRestoreResult) GetWarnings() []string {
+func (x *ModuleFreshness) GetAgeSeconds() int64 {
 	if x != nil {
-		return x.Warnings
+		return x.AgeSeconds
 	}
-	return nil
+	return 0
 }
 
-func (x *ModuleRestoreResult) GetError() string {
+func (x *ModuleFreshness) GetSloSeconds() int64 {
 	if x != nil {
-		return x.Error
+		return x.SloSeconds
 	}
-	return ""
+	return 0
 }
 
-// List full backups
-type ListFullBackupsRequest struct {
+func (x *ModuleFreshness) GetViolated() bool {
+	if x != nil {
+		return x.Violated
+	}
+	return false
+}
+
+type GetBackupFreshnessResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TenantId      *uint32                `protobuf:"varint,1,opt,name=tenant_id,json=tenantId,proto3,oneof" json:"tenant_id,omitempty"`
-	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Modules       []*ModuleFreshness     `protobuf:"bytes,1,rep,name=modules,proto3" json:"modules,omitempty"`
+	Violations    int32                  `protobuf:"varint,2,opt,name=violations,proto3" json:"violations,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListFullBackupsRequest) Reset() {
-	*x = ListFullBackupsRequest{}
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[20]
+func (x *GetBackupFreshnessResponse) Reset() {
+	*x = GetBackupFreshnessResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[102]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListFullBackupsRequest) String() string {
+func (x *GetBackupFreshnessResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListFullBackupsRequest) ProtoMessage() {}
+func (*GetBackupFreshnessResponse) ProtoMessage() {}
 
-func (x *ListFullBackupsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[20]
+func (x *GetBackupFreshnessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[102]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1378,55 +7404,51 @@ func (x *ListFullBackupsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListFullBackupsRequest.ProtoReflect.Descriptor instead.
-func (*ListFullBackupsRequest) Descriptor() ([]byte, []int) {
-	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{20}
-}
-
-func (x *ListFullBackupsRequest) GetTenantId() uint32 {
-	if x != nil && x.TenantId != nil {
-		return *x.TenantId
-	}
-	return 0
+// Deprecated: Use GetBackupFreshnessResponse.ProtoReflect.Descriptor instead.
+func (*GetBackupFreshnessResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{102}
 }
 
-func (x *ListFullBackupsRequest) GetPage() int32 {
+func (x *GetBackupFreshnessResponse) GetModules() []*ModuleFreshness {
 	if x != nil {
-		return x.Page
+		return x.Modules
 	}
-	return 0
+	return nil
 }
 
-func (x *ListFullBackupsRequest) GetPageSize() int32 {
+func (x *GetBackupFreshnessResponse) GetViolations() int32 {
 	if x != nil {
-		return x.PageSize
+		return x.Violations
 	}
 	return 0
 }
 
-type ListFullBackupsResponse struct {
+// Periodic summary of backup activity
+type GenerateBackupReportRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Backups       []*FullBackupInfo      `protobuf:"bytes,1,rep,name=backups,proto3" json:"backups,omitempty"`
-	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Period        string                 `protobuf:"bytes,1,opt,name=period,proto3" json:"period,omitempty"`                  // "daily" (default) or "weekly"
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"` // default: now
+	TenantId      *uint32                `protobuf:"varint,3,opt,name=tenant_id,json=tenantId,proto3,oneof" json:"tenant_id,omitempty"`
+	Format        string                 `protobuf:"bytes,4,opt,name=format,proto3" json:"format,omitempty"` // "json" (default) or "html"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListFullBackupsResponse) Reset() {
-	*x = ListFullBackupsResponse{}
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[21]
+func (x *GenerateBackupReportRequest) Reset() {
+	*x = GenerateBackupReportRequest{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[103]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListFullBackupsResponse) String() string {
+func (x *GenerateBackupReportRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListFullBackupsResponse) ProtoMessage() {}
+func (*GenerateBackupReportRequest) ProtoMessage() {}
 
-func (x *ListFullBackupsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[21]
+func (x *GenerateBackupReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[103]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1437,48 +7459,64 @@ func (x *ListFullBackupsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListFullBackupsResponse.ProtoReflect.Descriptor instead.
-func (*ListFullBackupsResponse) Descriptor() ([]byte, []int) {
-	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use GenerateBackupReportRequest.ProtoReflect.Descriptor instead.
+func (*GenerateBackupReportRequest) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{103}
 }
 
-func (x *ListFullBackupsResponse) GetBackups() []*FullBackupInfo {
+func (x *GenerateBackupReportRequest) GetPeriod() string {
 	if x != nil {
-		return x.Backups
+		return x.Period
 	}
-	return nil
+	return ""
 }
 
-func (x *ListFullBackupsResponse) GetTotal() int32 {
+func (x *GenerateBackupReportRequest) GetEndTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Total
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *GenerateBackupReportRequest) GetTenantId() uint32 {
+	if x != nil && x.TenantId != nil {
+		return *x.TenantId
 	}
 	return 0
 }
 
-// Get full backup
-type GetFullBackupRequest struct {
+func (x *GenerateBackupReportRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+type ReportFailure struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	BackupId      string                 `protobuf:"bytes,1,opt,name=backup_id,json=backupId,proto3" json:"backup_id,omitempty"`
+	ModuleId      string                 `protobuf:"bytes,2,opt,name=module_id,json=moduleId,proto3" json:"module_id,omitempty"` // empty for a full backup
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Errors        []string               `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetFullBackupRequest) Reset() {
-	*x = GetFullBackupRequest{}
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[22]
+func (x *ReportFailure) Reset() {
+	*x = ReportFailure{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[104]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetFullBackupRequest) String() string {
+func (x *ReportFailure) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetFullBackupRequest) ProtoMessage() {}
+func (*ReportFailure) ProtoMessage() {}
 
-func (x *GetFullBackupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[22]
+func (x *ReportFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[104]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1486,43 +7524,67 @@ func (x *GetFullBackupRequest) ProtoReflect() protoreflect.Message {
 		}
 		return ms
 	}
-	return mi.MessageOf(x)
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportFailure.ProtoReflect.Descriptor instead.
+func (*ReportFailure) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *ReportFailure) GetBackupId() string {
+	if x != nil {
+		return x.BackupId
+	}
+	return ""
+}
+
+func (x *ReportFailure) GetModuleId() string {
+	if x != nil {
+		return x.ModuleId
+	}
+	return ""
 }
 
-// Deprecated: Use GetFullBackupRequest.ProtoReflect.Descriptor instead.
-func (*GetFullBackupRequest) Descriptor() ([]byte, []int) {
-	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{22}
+func (x *ReportFailure) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
 }
 
-func (x *GetFullBackupRequest) GetId() string {
+func (x *ReportFailure) GetErrors() []string {
 	if x != nil {
-		return x.Id
+		return x.Errors
 	}
-	return ""
+	return nil
 }
 
-type GetFullBackupResponse struct {
+type ReportModuleSummary struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Backup        *FullBackupInfo        `protobuf:"bytes,1,opt,name=backup,proto3" json:"backup,omitempty"`
+	ModuleId      string                 `protobuf:"bytes,1,opt,name=module_id,json=moduleId,proto3" json:"module_id,omitempty"`
+	Backups       int32                  `protobuf:"varint,2,opt,name=backups,proto3" json:"backups,omitempty"`
+	Failed        int32                  `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	TotalBytes    int64                  `protobuf:"varint,4,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetFullBackupResponse) Reset() {
-	*x = GetFullBackupResponse{}
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[23]
+func (x *ReportModuleSummary) Reset() {
+	*x = ReportModuleSummary{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[105]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetFullBackupResponse) String() string {
+func (x *ReportModuleSummary) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetFullBackupResponse) ProtoMessage() {}
+func (*ReportModuleSummary) ProtoMessage() {}
 
-func (x *GetFullBackupResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[23]
+func (x *ReportModuleSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[105]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1533,42 +7595,64 @@ func (x *GetFullBackupResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetFullBackupResponse.ProtoReflect.Descriptor instead.
-func (*GetFullBackupResponse) Descriptor() ([]byte, []int) {
-	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use ReportModuleSummary.ProtoReflect.Descriptor instead.
+func (*ReportModuleSummary) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{105}
 }
 
-func (x *GetFullBackupResponse) GetBackup() *FullBackupInfo {
+func (x *ReportModuleSummary) GetModuleId() string {
 	if x != nil {
-		return x.Backup
+		return x.ModuleId
 	}
-	return nil
+	return ""
 }
 
-// Download full backup (all modules combined)
-type DownloadFullBackupRequest struct {
+func (x *ReportModuleSummary) GetBackups() int32 {
+	if x != nil {
+		return x.Backups
+	}
+	return 0
+}
+
+func (x *ReportModuleSummary) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *ReportModuleSummary) GetTotalBytes() int64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
+}
+
+type RetentionCandidate struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"` // required if backup is encrypted
+	BackupId      string                 `protobuf:"bytes,1,opt,name=backup_id,json=backupId,proto3" json:"backup_id,omitempty"`
+	ModuleId      string                 `protobuf:"bytes,2,opt,name=module_id,json=moduleId,proto3" json:"module_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	DeletesAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=deletes_at,json=deletesAt,proto3" json:"deletes_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DownloadFullBackupRequest) Reset() {
-	*x = DownloadFullBackupRequest{}
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[24]
+func (x *RetentionCandidate) Reset() {
+	*x = RetentionCandidate{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[106]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DownloadFullBackupRequest) String() string {
+func (x *RetentionCandidate) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DownloadFullBackupRequest) ProtoMessage() {}
+func (*RetentionCandidate) ProtoMessage() {}
 
-func (x *DownloadFullBackupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[24]
+func (x *RetentionCandidate) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[106]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1579,48 +7663,72 @@ func (x *DownloadFullBackupRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DownloadFullBackupRequest.ProtoReflect.Descriptor instead.
-func (*DownloadFullBackupRequest) Descriptor() ([]byte, []int) {
-	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use RetentionCandidate.ProtoReflect.Descriptor instead.
+func (*RetentionCandidate) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{106}
 }
 
-func (x *DownloadFullBackupRequest) GetId() string {
+func (x *RetentionCandidate) GetBackupId() string {
 	if x != nil {
-		return x.Id
+		return x.BackupId
 	}
 	return ""
 }
 
-func (x *DownloadFullBackupRequest) GetPassword() string {
+func (x *RetentionCandidate) GetModuleId() string {
 	if x != nil {
-		return x.Password
+		return x.ModuleId
 	}
 	return ""
 }
 
-type DownloadFullBackupResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
-	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *RetentionCandidate) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
 }
 
-func (x *DownloadFullBackupResponse) Reset() {
-	*x = DownloadFullBackupResponse{}
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[25]
+func (x *RetentionCandidate) GetDeletesAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletesAt
+	}
+	return nil
+}
+
+type BackupReport struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Period            string                 `protobuf:"bytes,1,opt,name=period,proto3" json:"period,omitempty"`
+	StartTime         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime           *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	GeneratedAt       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=generated_at,json=generatedAt,proto3" json:"generated_at,omitempty"`
+	TotalBackups      int32                  `protobuf:"varint,5,opt,name=total_backups,json=totalBackups,proto3" json:"total_backups,omitempty"`
+	FailedBackups     int32                  `protobuf:"varint,6,opt,name=failed_backups,json=failedBackups,proto3" json:"failed_backups,omitempty"`
+	TotalBytes        int64                  `protobuf:"varint,7,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`                     // created during the period
+	StorageUsedBytes  int64                  `protobuf:"varint,8,opt,name=storage_used_bytes,json=storageUsedBytes,proto3" json:"storage_used_bytes,omitempty"` // everything currently stored
+	Failures          []*ReportFailure       `protobuf:"bytes,9,rep,name=failures,proto3" json:"failures,omitempty"`
+	Modules           []*ReportModuleSummary `protobuf:"bytes,10,rep,name=modules,proto3" json:"modules,omitempty"`
+	UpcomingDeletions []*RetentionCandidate  `protobuf:"bytes,11,rep,name=upcoming_deletions,json=upcomingDeletions,proto3" json:"upcoming_deletions,omitempty"` // due within the next period
+	CorruptBackups    []string               `protobuf:"bytes,12,rep,name=corrupt_backups,json=corruptBackups,proto3" json:"corrupt_backups,omitempty"`          // IDs of module backups with unreadable metadata
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *BackupReport) Reset() {
+	*x = BackupReport{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[107]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DownloadFullBackupResponse) String() string {
+func (x *BackupReport) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DownloadFullBackupResponse) ProtoMessage() {}
+func (*BackupReport) ProtoMessage() {}
 
-func (x *DownloadFullBackupResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[25]
+func (x *BackupReport) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[107]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1631,92 +7739,119 @@ func (x *DownloadFullBackupResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DownloadFullBackupResponse.ProtoReflect.Descriptor instead.
-func (*DownloadFullBackupResponse) Descriptor() ([]byte, []int) {
-	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use BackupReport.ProtoReflect.Descriptor instead.
+func (*BackupReport) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{107}
 }
 
-func (x *DownloadFullBackupResponse) GetData() []byte {
+func (x *BackupReport) GetPeriod() string {
 	if x != nil {
-		return x.Data
+		return x.Period
+	}
+	return ""
+}
+
+func (x *BackupReport) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
 	}
 	return nil
 }
 
-func (x *DownloadFullBackupResponse) GetFilename() string {
+func (x *BackupReport) GetEndTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Filename
+		return x.EndTime
 	}
-	return ""
+	return nil
 }
 
-// Delete full backup
-type DeleteFullBackupRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *BackupReport) GetGeneratedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.GeneratedAt
+	}
+	return nil
 }
 
-func (x *DeleteFullBackupRequest) Reset() {
-	*x = DeleteFullBackupRequest{}
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[26]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *BackupReport) GetTotalBackups() int32 {
+	if x != nil {
+		return x.TotalBackups
+	}
+	return 0
 }
 
-func (x *DeleteFullBackupRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *BackupReport) GetFailedBackups() int32 {
+	if x != nil {
+		return x.FailedBackups
+	}
+	return 0
 }
 
-func (*DeleteFullBackupRequest) ProtoMessage() {}
+func (x *BackupReport) GetTotalBytes() int64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
+}
 
-func (x *DeleteFullBackupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[26]
+func (x *BackupReport) GetStorageUsedBytes() int64 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.StorageUsedBytes
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use DeleteFullBackupRequest.ProtoReflect.Descriptor instead.
-func (*DeleteFullBackupRequest) Descriptor() ([]byte, []int) {
-	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{26}
+func (x *BackupReport) GetFailures() []*ReportFailure {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
 }
 
-func (x *DeleteFullBackupRequest) GetId() string {
+func (x *BackupReport) GetModules() []*ReportModuleSummary {
 	if x != nil {
-		return x.Id
+		return x.Modules
 	}
-	return ""
+	return nil
 }
 
-type DeleteFullBackupResponse struct {
+func (x *BackupReport) GetUpcomingDeletions() []*RetentionCandidate {
+	if x != nil {
+		return x.UpcomingDeletions
+	}
+	return nil
+}
+
+func (x *BackupReport) GetCorruptBackups() []string {
+	if x != nil {
+		return x.CorruptBackups
+	}
+	return nil
+}
+
+type GenerateBackupReportResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Report        *BackupReport          `protobuf:"bytes,1,opt,name=report,proto3" json:"report,omitempty"`
+	Document      string                 `protobuf:"bytes,2,opt,name=document,proto3" json:"document,omitempty"` // the report rendered in the requested format
+	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteFullBackupResponse) Reset() {
-	*x = DeleteFullBackupResponse{}
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[27]
+func (x *GenerateBackupReportResponse) Reset() {
+	*x = GenerateBackupReportResponse{}
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[108]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteFullBackupResponse) String() string {
+func (x *GenerateBackupReportResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteFullBackupResponse) ProtoMessage() {}
+func (*GenerateBackupReportResponse) ProtoMessage() {}
 
-func (x *DeleteFullBackupResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[27]
+func (x *GenerateBackupReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_orchestrator_proto_msgTypes[108]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1727,34 +7862,60 @@ func (x *DeleteFullBackupResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteFullBackupResponse.ProtoReflect.Descriptor instead.
-func (*DeleteFullBackupResponse) Descriptor() ([]byte, []int) {
-	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use GenerateBackupReportResponse.ProtoReflect.Descriptor instead.
+func (*GenerateBackupReportResponse) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP(), []int{108}
 }
 
-func (x *DeleteFullBackupResponse) GetSuccess() bool {
+func (x *GenerateBackupReportResponse) GetReport() *BackupReport {
 	if x != nil {
-		return x.Success
+		return x.Report
 	}
-	return false
+	return nil
+}
+
+func (x *GenerateBackupReportResponse) GetDocument() string {
+	if x != nil {
+		return x.Document
+	}
+	return ""
+}
+
+func (x *GenerateBackupReportResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
 }
 
 var File_backup_service_v1_backup_orchestrator_proto protoreflect.FileDescriptor
 
 const file_backup_service_v1_backup_orchestrator_proto_rawDesc = "" +
 	"\n" +
-	"+backup/service/v1/backup_orchestrator.proto\x12\x11backup.service.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a&backup/service/v1/backup_service.proto\"P\n" +
+	"+backup/service/v1/backup_orchestrator.proto\x12\x11backup.service.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a&backup/service/v1/backup_service.proto\"\xa7\x01\n" +
 	"\fModuleTarget\x12\x1b\n" +
 	"\tmodule_id\x18\x01 \x01(\tR\bmoduleId\x12#\n" +
-	"\rgrpc_endpoint\x18\x02 \x01(\tR\fgrpcEndpoint\"\xeb\x01\n" +
+	"\rgrpc_endpoint\x18\x02 \x01(\tR\fgrpcEndpoint\x12.\n" +
+	"\x03tls\x18\x03 \x01(\v2\x1c.backup.service.v1.TargetTLSR\x03tls\x12%\n" +
+	"\x0ebackup_service\x18\x04 \x01(\tR\rbackupService\"\x8b\x01\n" +
+	"\tTargetTLS\x12\x1a\n" +
+	"\binsecure\x18\x01 \x01(\bR\binsecure\x12 \n" +
+	"\fca_cert_path\x18\x02 \x01(\tR\n" +
+	"caCertPath\x12\x1f\n" +
+	"\vskip_verify\x18\x03 \x01(\bR\n" +
+	"skipVerify\x12\x1f\n" +
+	"\vserver_name\x18\x04 \x01(\tR\n" +
+	"serverName\"\xb3\x02\n" +
 	"\x19CreateModuleBackupRequest\x127\n" +
 	"\x06target\x18\x01 \x01(\v2\x1f.backup.service.v1.ModuleTargetR\x06target\x12 \n" +
 	"\ttenant_id\x18\x02 \x01(\rH\x00R\btenantId\x88\x01\x01\x12 \n" +
 	"\vdescription\x18\x03 \x01(\tR\vdescription\x12'\n" +
 	"\x0finclude_secrets\x18\x04 \x01(\bR\x0eincludeSecrets\x12\x1a\n" +
-	"\bpassword\x18\x05 \x01(\tR\bpasswordB\f\n" +
+	"\bpassword\x18\x05 \x01(\tR\bpassword\x120\n" +
+	"\x11compression_level\x18\x06 \x01(\x05H\x01R\x10compressionLevel\x88\x01\x01B\f\n" +
 	"\n" +
-	"_tenant_id\"\xbc\x04\n" +
+	"_tenant_idB\x14\n" +
+	"\x12_compression_level\"\xf2\v\n" +
 	"\n" +
 	"BackupInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
@@ -1775,34 +7936,101 @@ const file_backup_service_v1_backup_orchestrator_proto_rawDesc = "" +
 	"\aversion\x18\v \x01(\tR\aversion\x12\x1a\n" +
 	"\bwarnings\x18\f \x03(\tR\bwarnings\x12\x1c\n" +
 	"\tencrypted\x18\r \x01(\bR\tencrypted\x12%\n" +
-	"\x0eschema_version\x18\x0e \x01(\x05R\rschemaVersion\x1a?\n" +
+	"\x0eschema_version\x18\x0e \x01(\x05R\rschemaVersion\x12\x1f\n" +
+	"\vduration_ms\x18\x0f \x01(\x03R\n" +
+	"durationMs\x129\n" +
+	"\atimings\x18\x10 \x01(\v2\x1f.backup.service.v1.PhaseTimingsR\atimings\x122\n" +
+	"\x15compressed_size_bytes\x18\x11 \x01(\x03R\x13compressedSizeBytes\x12+\n" +
+	"\x11compression_ratio\x18\x12 \x01(\x01R\x10compressionRatio\x127\n" +
+	"\x18throughput_bytes_per_sec\x18\x13 \x01(\x03R\x15throughputBytesPerSec\x12\x16\n" +
+	"\x06sha256\x18\x14 \x01(\tR\x06sha256\x12.\n" +
+	"\x03pin\x18\x15 \x01(\v2\x1c.backup.service.v1.BackupPinR\x03pin\x12 \n" +
+	"\vcompression\x18\x16 \x01(\tR\vcompression\x12+\n" +
+	"\x11compression_level\x18\x17 \x01(\x05R\x10compressionLevel\x12\"\n" +
+	"\rdelta_base_id\x18\x18 \x01(\tR\vdeltaBaseId\x12\x1f\n" +
+	"\vdelta_depth\x18\x19 \x01(\x05R\n" +
+	"deltaDepth\x12#\n" +
+	"\rstored_sha256\x18\x1a \x01(\tR\fstoredSha256\x12R\n" +
+	"\x11last_verification\x18\x1b \x01(\v2%.backup.service.v1.BackupVerificationR\x10lastVerification\x12+\n" +
+	"\x11validation_status\x18\x1c \x01(\tR\x10validationStatus\x12-\n" +
+	"\x12validation_reasons\x18\x1d \x03(\tR\x11validationReasons\x12F\n" +
+	"\vreplication\x18\x1e \x01(\v2$.backup.service.v1.ReplicationStatusR\vreplication\x12<\n" +
+	"\x06mirror\x18\x1f \x01(\v2$.backup.service.v1.ReplicationStatusR\x06mirror\x12\x12\n" +
+	"\x04site\x18  \x01(\tR\x04site\x12<\n" +
+	"\x06origin\x18! \x01(\v2$.backup.service.v1.ReplicationStatusR\x06origin\x125\n" +
+	"\x06copies\x18\" \x03(\v2\x1d.backup.service.v1.BackupCopyR\x06copies\x1a?\n" +
 	"\x11EntityCountsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"S\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"y\n" +
+	"\tBackupPin\x12\x1b\n" +
+	"\tpinned_by\x18\x01 \x01(\tR\bpinnedBy\x127\n" +
+	"\tpinned_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bpinnedAt\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\x8c\x02\n" +
+	"\x11ReplicationStatus\x12\x14\n" +
+	"\x05state\x18\x01 \x01(\tR\x05state\x12\x16\n" +
+	"\x06target\x18\x02 \x01(\tR\x06target\x12\x1a\n" +
+	"\battempts\x18\x03 \x01(\x05R\battempts\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\x04 \x01(\tR\tlastError\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12?\n" +
+	"\rreplicated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\freplicatedAt\x12\x12\n" +
+	"\x04site\x18\a \x01(\tR\x04site\"\x84\x01\n" +
+	"\n" +
+	"BackupCopy\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12\x12\n" +
+	"\x04site\x18\x02 \x01(\tR\x04site\x12\x16\n" +
+	"\x06target\x18\x03 \x01(\tR\x06target\x12\x14\n" +
+	"\x05state\x18\x04 \x01(\tR\x05state\x12\x1c\n" +
+	"\tavailable\x18\x05 \x01(\bR\tavailable\"\x86\x01\n" +
+	"\fPhaseTimings\x12\x1b\n" +
+	"\texport_ms\x18\x01 \x01(\x03R\bexportMs\x12\x1f\n" +
+	"\vcompress_ms\x18\x02 \x01(\x03R\n" +
+	"compressMs\x12\x1d\n" +
+	"\n" +
+	"encrypt_ms\x18\x03 \x01(\x03R\tencryptMs\x12\x19\n" +
+	"\bwrite_ms\x18\x04 \x01(\x03R\awriteMs\"S\n" +
 	"\x1aCreateModuleBackupResponse\x125\n" +
 	"\x06backup\x18\x01 \x01(\v2\x1d.backup.service.v1.BackupInfoR\x06backup\"\xc2\x01\n" +
 	"\x1aRestoreModuleBackupRequest\x12\x1b\n" +
 	"\tbackup_id\x18\x01 \x01(\tR\bbackupId\x127\n" +
 	"\x06target\x18\x02 \x01(\v2\x1f.backup.service.v1.ModuleTargetR\x06target\x122\n" +
 	"\x04mode\x18\x03 \x01(\x0e2\x1e.backup.service.v1.RestoreModeR\x04mode\x12\x1a\n" +
-	"\bpassword\x18\x04 \x01(\tR\bpassword\"\x91\x02\n" +
+	"\bpassword\x18\x04 \x01(\tR\bpassword\"\xc8\x02\n" +
 	"\x1bRestoreModuleBackupResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12?\n" +
 	"\aresults\x18\x02 \x03(\v2%.backup.service.v1.EntityImportResultR\aresults\x12\x1a\n" +
 	"\bwarnings\x18\x03 \x03(\tR\bwarnings\x12%\n" +
 	"\x0esource_version\x18\x04 \x01(\x05R\rsourceVersion\x12%\n" +
 	"\x0etarget_version\x18\x05 \x01(\x05R\rtargetVersion\x12-\n" +
-	"\x12migrations_applied\x18\x06 \x01(\x05R\x11migrationsApplied\"\x92\x01\n" +
+	"\x12migrations_applied\x18\x06 \x01(\x05R\x11migrationsApplied\x125\n" +
+	"\x06source\x18\a \x01(\v2\x1d.backup.service.v1.BackupCopyR\x06source\"\xed\x03\n" +
 	"\x12ListBackupsRequest\x12\x1b\n" +
 	"\tmodule_id\x18\x01 \x01(\tR\bmoduleId\x12 \n" +
 	"\ttenant_id\x18\x02 \x01(\rH\x00R\btenantId\x88\x01\x01\x12\x12\n" +
 	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x04 \x01(\x05R\bpageSizeB\f\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12?\n" +
+	"\rcreated_after\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12\x1d\n" +
 	"\n" +
-	"_tenant_id\"d\n" +
+	"created_by\x18\b \x01(\tR\tcreatedBy\x12!\n" +
+	"\tencrypted\x18\t \x01(\bH\x01R\tencrypted\x88\x01\x01\x12\x16\n" +
+	"\x06search\x18\n" +
+	" \x01(\tR\x06search\x12\x17\n" +
+	"\asort_by\x18\v \x01(\tR\x06sortBy\x12\x1d\n" +
+	"\n" +
+	"sort_order\x18\f \x01(\tR\tsortOrder\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\r \x01(\tR\tpageTokenB\f\n" +
+	"\n" +
+	"_tenant_idB\f\n" +
+	"\n" +
+	"_encrypted\"\x8c\x01\n" +
 	"\x13ListBackupsResponse\x127\n" +
 	"\abackups\x18\x01 \x03(\v2\x1d.backup.service.v1.BackupInfoR\abackups\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total\"\"\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\"\"\n" +
 	"\x10GetBackupRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"J\n" +
 	"\x11GetBackupResponse\x125\n" +
@@ -1810,21 +8038,47 @@ const file_backup_service_v1_backup_orchestrator_proto_rawDesc = "" +
 	"\x13DeleteBackupRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"0\n" +
 	"\x14DeleteBackupResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"C\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xe9\x01\n" +
+	"\x14DeleteBackupsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\x12\x1b\n" +
+	"\tmodule_id\x18\x02 \x01(\tR\bmoduleId\x12 \n" +
+	"\ttenant_id\x18\x03 \x01(\rH\x00R\btenantId\x88\x01\x01\x12A\n" +
+	"\x0ecreated_before\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x17\n" +
+	"\adry_run\x18\x06 \x01(\bR\x06dryRunB\f\n" +
+	"\n" +
+	"_tenant_id\"T\n" +
+	"\x12DeleteBackupResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\adeleted\x18\x02 \x01(\bR\adeleted\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"\x8a\x01\n" +
+	"\x15DeleteBackupsResponse\x12?\n" +
+	"\aresults\x18\x01 \x03(\v2%.backup.service.v1.DeleteBackupResultR\aresults\x12\x18\n" +
+	"\adeleted\x18\x02 \x01(\x05R\adeleted\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x05R\x06failed\"C\n" +
 	"\x15DownloadBackupRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
 	"\bpassword\x18\x02 \x01(\tR\bpassword\"H\n" +
 	"\x16DownloadBackupResponse\x12\x12\n" +
 	"\x04data\x18\x01 \x01(\fR\x04data\x12\x1a\n" +
-	"\bfilename\x18\x02 \x01(\tR\bfilename\"\xeb\x01\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\"\xfd\x03\n" +
 	"\x17CreateFullBackupRequest\x129\n" +
 	"\atargets\x18\x01 \x03(\v2\x1f.backup.service.v1.ModuleTargetR\atargets\x12 \n" +
 	"\ttenant_id\x18\x02 \x01(\rH\x00R\btenantId\x88\x01\x01\x12 \n" +
 	"\vdescription\x18\x03 \x01(\tR\vdescription\x12'\n" +
 	"\x0finclude_secrets\x18\x04 \x01(\bR\x0eincludeSecrets\x12\x1a\n" +
-	"\bpassword\x18\x05 \x01(\tR\bpasswordB\f\n" +
+	"\bpassword\x18\x05 \x01(\tR\bpassword\x123\n" +
+	"\x13min_success_percent\x18\x06 \x01(\x05H\x01R\x11minSuccessPercent\x88\x01\x01\x12)\n" +
+	"\x10required_modules\x18\a \x03(\tR\x0frequiredModules\x12'\n" +
+	"\x0ftarget_selector\x18\b \x01(\tR\x0etargetSelector\x120\n" +
+	"\x11compression_level\x18\t \x01(\x05H\x02R\x10compressionLevel\x88\x01\x01\x12'\n" +
+	"\x0fidempotency_key\x18\n" +
+	" \x01(\tR\x0eidempotencyKeyB\f\n" +
+	"\n" +
+	"_tenant_idB\x16\n" +
+	"\x14_min_success_percentB\x14\n" +
+	"\x12_compression_level\"\x99\n" +
 	"\n" +
-	"_tenant_id\"\x98\x03\n" +
 	"\x0eFullBackupInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1b\n" +
@@ -1840,7 +8094,29 @@ const file_backup_service_v1_backup_orchestrator_proto_rawDesc = "" +
 	"created_by\x18\t \x01(\tR\tcreatedBy\x12\x16\n" +
 	"\x06errors\x18\n" +
 	" \x03(\tR\x06errors\x12\x1c\n" +
-	"\tencrypted\x18\v \x01(\bR\tencrypted\"U\n" +
+	"\tencrypted\x18\v \x01(\bR\tencrypted\x12+\n" +
+	"\x11validation_status\x18\f \x01(\tR\x10validationStatus\x12-\n" +
+	"\x12validation_reasons\x18\r \x03(\tR\x11validationReasons\x12\x1f\n" +
+	"\vduration_ms\x18\x0e \x01(\x03R\n" +
+	"durationMs\x12=\n" +
+	"\x1btotal_compressed_size_bytes\x18\x0f \x01(\x03R\x18totalCompressedSizeBytes\x12+\n" +
+	"\x11compression_ratio\x18\x10 \x01(\x01R\x10compressionRatio\x12.\n" +
+	"\x03pin\x18\x11 \x01(\v2\x1c.backup.service.v1.BackupPinR\x03pin\x12E\n" +
+	"\x06labels\x18\x12 \x03(\v2-.backup.service.v1.FullBackupInfo.LabelsEntryR\x06labels\x129\n" +
+	"\n" +
+	"updated_at\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\x14 \x01(\tR\tupdatedBy\x12R\n" +
+	"\x11last_verification\x18\x15 \x01(\v2%.backup.service.v1.BackupVerificationR\x10lastVerification\x12'\n" +
+	"\x0fidempotency_key\x18\x16 \x01(\tR\x0eidempotencyKey\x12F\n" +
+	"\vreplication\x18\x17 \x01(\v2$.backup.service.v1.ReplicationStatusR\vreplication\x12<\n" +
+	"\x06mirror\x18\x18 \x01(\v2$.backup.service.v1.ReplicationStatusR\x06mirror\x12\x12\n" +
+	"\x04site\x18\x19 \x01(\tR\x04site\x12<\n" +
+	"\x06origin\x18\x1a \x01(\v2$.backup.service.v1.ReplicationStatusR\x06origin\x125\n" +
+	"\x06copies\x18\x1b \x03(\v2\x1d.backup.service.v1.BackupCopyR\x06copies\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"U\n" +
 	"\x18CreateFullBackupResponse\x129\n" +
 	"\x06backup\x18\x01 \x01(\v2!.backup.service.v1.FullBackupInfoR\x06backup\"\xc2\x01\n" +
 	"\x18RestoreFullBackupRequest\x12\x1b\n" +
@@ -1850,49 +8126,522 @@ const file_backup_service_v1_backup_orchestrator_proto_rawDesc = "" +
 	"\bpassword\x18\x04 \x01(\tR\bpassword\"\x84\x01\n" +
 	"\x19RestoreFullBackupResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12M\n" +
-	"\x0emodule_results\x18\x02 \x03(\v2&.backup.service.v1.ModuleRestoreResultR\rmoduleResults\"\xbf\x01\n" +
+	"\x0emodule_results\x18\x02 \x03(\v2&.backup.service.v1.ModuleRestoreResultR\rmoduleResults\"\xf6\x01\n" +
 	"\x13ModuleRestoreResult\x12\x1b\n" +
 	"\tmodule_id\x18\x01 \x01(\tR\bmoduleId\x12\x18\n" +
 	"\asuccess\x18\x02 \x01(\bR\asuccess\x12?\n" +
 	"\aresults\x18\x03 \x03(\v2%.backup.service.v1.EntityImportResultR\aresults\x12\x1a\n" +
 	"\bwarnings\x18\x04 \x03(\tR\bwarnings\x12\x14\n" +
-	"\x05error\x18\x05 \x01(\tR\x05error\"y\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x125\n" +
+	"\x06source\x18\x06 \x01(\v2\x1d.backup.service.v1.BackupCopyR\x06source\"\xd4\x03\n" +
 	"\x16ListFullBackupsRequest\x12 \n" +
 	"\ttenant_id\x18\x01 \x01(\rH\x00R\btenantId\x88\x01\x01\x12\x12\n" +
 	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x03 \x01(\x05R\bpageSizeB\f\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12?\n" +
+	"\rcreated_after\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\a \x01(\tR\tcreatedBy\x12!\n" +
+	"\tencrypted\x18\b \x01(\bH\x01R\tencrypted\x88\x01\x01\x12\x16\n" +
+	"\x06search\x18\t \x01(\tR\x06search\x12\x17\n" +
+	"\asort_by\x18\n" +
+	" \x01(\tR\x06sortBy\x12\x1d\n" +
 	"\n" +
-	"_tenant_id\"l\n" +
+	"sort_order\x18\v \x01(\tR\tsortOrder\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\f \x01(\tR\tpageTokenB\f\n" +
+	"\n" +
+	"_tenant_idB\f\n" +
+	"\n" +
+	"_encrypted\"\x94\x01\n" +
 	"\x17ListFullBackupsResponse\x12;\n" +
 	"\abackups\x18\x01 \x03(\v2!.backup.service.v1.FullBackupInfoR\abackups\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total\"&\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\"&\n" +
 	"\x14GetFullBackupRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"R\n" +
 	"\x15GetFullBackupResponse\x129\n" +
+	"\x06backup\x18\x01 \x01(\v2!.backup.service.v1.FullBackupInfoR\x06backup\"\xeb\x01\n" +
+	"\x17UpdateFullBackupRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12%\n" +
+	"\vdescription\x18\x02 \x01(\tH\x00R\vdescription\x88\x01\x01\x12N\n" +
+	"\x06labels\x18\x03 \x03(\v26.backup.service.v1.UpdateFullBackupRequest.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x0e\n" +
+	"\f_description\"U\n" +
+	"\x18UpdateFullBackupResponse\x129\n" +
 	"\x06backup\x18\x01 \x01(\v2!.backup.service.v1.FullBackupInfoR\x06backup\"G\n" +
 	"\x19DownloadFullBackupRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
 	"\bpassword\x18\x02 \x01(\tR\bpassword\"L\n" +
 	"\x1aDownloadFullBackupResponse\x12\x12\n" +
 	"\x04data\x18\x01 \x01(\fR\x04data\x12\x1a\n" +
-	"\bfilename\x18\x02 \x01(\tR\bfilename\")\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\"\x8d\x01\n" +
+	"#GeneratePresignedDownloadURLRequest\x12\x1b\n" +
+	"\tbackup_id\x18\x01 \x01(\tR\bbackupId\x12\x1b\n" +
+	"\tmodule_id\x18\x02 \x01(\tR\bmoduleId\x12,\n" +
+	"\x12expires_in_seconds\x18\x03 \x01(\rR\x10expiresInSeconds\"s\n" +
+	"$GeneratePresignedDownloadURLResponse\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\")\n" +
 	"\x17DeleteFullBackupRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"4\n" +
 	"\x18DeleteFullBackupResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess2\xa2\r\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"[\n" +
+	"\x10PinBackupRequest\x12\x1b\n" +
+	"\tbackup_id\x18\x01 \x01(\tR\bbackupId\x12\x12\n" +
+	"\x04full\x18\x02 \x01(\bR\x04full\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"C\n" +
+	"\x11PinBackupResponse\x12.\n" +
+	"\x03pin\x18\x01 \x01(\v2\x1c.backup.service.v1.BackupPinR\x03pin\"E\n" +
+	"\x12UnpinBackupRequest\x12\x1b\n" +
+	"\tbackup_id\x18\x01 \x01(\tR\bbackupId\x12\x12\n" +
+	"\x04full\x18\x02 \x01(\bR\x04full\"\x15\n" +
+	"\x13UnpinBackupResponse\"b\n" +
+	"\x13VerifyBackupRequest\x12\x1b\n" +
+	"\tbackup_id\x18\x01 \x01(\tR\bbackupId\x12\x12\n" +
+	"\x04full\x18\x02 \x01(\bR\x04full\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\"W\n" +
+	"\x11VerificationCheck\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x16\n" +
+	"\x06detail\x18\x03 \x01(\tR\x06detail\"\x7f\n" +
+	"\x12ModuleVerification\x12\x1b\n" +
+	"\tmodule_id\x18\x01 \x01(\tR\bmoduleId\x12\x0e\n" +
+	"\x02ok\x18\x02 \x01(\bR\x02ok\x12<\n" +
+	"\x06checks\x18\x03 \x03(\v2$.backup.service.v1.VerificationCheckR\x06checks\"\x98\x01\n" +
+	"\x12BackupVerification\x12;\n" +
+	"\vverified_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"verifiedAt\x12\x1f\n" +
+	"\vverified_by\x18\x02 \x01(\tR\n" +
+	"verifiedBy\x12\x0e\n" +
+	"\x02ok\x18\x03 \x01(\bR\x02ok\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"\xa2\x01\n" +
+	"\x14VerifyBackupResponse\x12I\n" +
+	"\fverification\x18\x01 \x01(\v2%.backup.service.v1.BackupVerificationR\fverification\x12?\n" +
+	"\amodules\x18\x02 \x03(\v2%.backup.service.v1.ModuleVerificationR\amodules\"\x9a\x01\n" +
+	"\x12TestRestoreRequest\x12\x1b\n" +
+	"\tbackup_id\x18\x01 \x01(\tR\bbackupId\x12\x12\n" +
+	"\x04full\x18\x02 \x01(\bR\x04full\x127\n" +
+	"\x06target\x18\x03 \x01(\v2\x1f.backup.service.v1.ModuleTargetR\x06target\x12\x1a\n" +
+	"\bpassword\x18\x04 \x01(\tR\bpassword\"\xde\x01\n" +
+	"\x13TestRestoreResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12?\n" +
+	"\aresults\x18\x02 \x03(\v2%.backup.service.v1.EntityImportResultR\aresults\x12\x1a\n" +
+	"\bwarnings\x18\x03 \x03(\tR\bwarnings\x12\x1b\n" +
+	"\ttenant_id\x18\x04 \x01(\rR\btenantId\x12\x1d\n" +
+	"\n" +
+	"cleaned_up\x18\x05 \x01(\bR\tcleanedUp\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\"\x88\x01\n" +
+	"\x13RepairBackupRequest\x12\x1b\n" +
+	"\tbackup_id\x18\x01 \x01(\tR\bbackupId\x12\x1b\n" +
+	"\tmodule_id\x18\x02 \x01(\tR\bmoduleId\x12\x1b\n" +
+	"\ttenant_id\x18\x03 \x01(\rR\btenantId\x12\x1a\n" +
+	"\bpassword\x18\x04 \x01(\tR\bpassword\"M\n" +
+	"\x14RepairBackupResponse\x125\n" +
+	"\x06backup\x18\x01 \x01(\v2\x1d.backup.service.v1.BackupInfoR\x06backup\"I\n" +
+	"\x16ReplicateBackupRequest\x12\x1b\n" +
+	"\tbackup_id\x18\x01 \x01(\tR\bbackupId\x12\x12\n" +
+	"\x04full\x18\x02 \x01(\bR\x04full\"a\n" +
+	"\x17ReplicateBackupResponse\x12F\n" +
+	"\vreplication\x18\x01 \x01(\v2$.backup.service.v1.ReplicationStatusR\vreplication\"\x96\x01\n" +
+	"\fReplicaChunk\x12@\n" +
+	"\bmanifest\x18\x01 \x01(\v2\".backup.service.v1.ReplicaManifestH\x00R\bmanifest\x129\n" +
+	"\x04file\x18\x02 \x01(\v2#.backup.service.v1.ReplicaFileChunkH\x00R\x04fileB\t\n" +
+	"\apayload\"\x94\x01\n" +
+	"\x0fReplicaManifest\x12\x1b\n" +
+	"\tbackup_id\x18\x01 \x01(\tR\bbackupId\x12\x12\n" +
+	"\x04full\x18\x02 \x01(\bR\x04full\x12\x1a\n" +
+	"\bmetadata\x18\x03 \x01(\fR\bmetadata\x124\n" +
+	"\x05files\x18\x04 \x03(\v2\x1e.backup.service.v1.ReplicaFileR\x05files\"M\n" +
+	"\vReplicaFile\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12\x16\n" +
+	"\x06sha256\x18\x03 \x01(\tR\x06sha256\":\n" +
+	"\x10ReplicaFileChunk\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"\x85\x01\n" +
+	"\x16ReceiveReplicaResponse\x12\x1b\n" +
+	"\tbackup_id\x18\x01 \x01(\tR\bbackupId\x12%\n" +
+	"\x0ebytes_received\x18\x02 \x01(\x03R\rbytesReceived\x12'\n" +
+	"\x0falready_present\x18\x03 \x01(\bR\x0ealreadyPresent\"\x15\n" +
+	"\x13ListReplicasRequest\"\x9e\x01\n" +
+	"\fReplicaEntry\x12\x1b\n" +
+	"\tbackup_id\x18\x01 \x01(\tR\bbackupId\x12\x12\n" +
+	"\x04full\x18\x02 \x01(\bR\x04full\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\"\n" +
+	"\rdelta_base_id\x18\x04 \x01(\tR\vdeltaBaseId\"Q\n" +
+	"\x14ListReplicasResponse\x129\n" +
+	"\abackups\x18\x01 \x03(\v2\x1f.backup.service.v1.ReplicaEntryR\abackups\"F\n" +
+	"\x13FetchReplicaRequest\x12\x1b\n" +
+	"\tbackup_id\x18\x01 \x01(\tR\bbackupId\x12\x12\n" +
+	"\x04full\x18\x02 \x01(\bR\x04full\"\x1c\n" +
+	"\x1aGetFederationStatusRequest\"\xb9\x02\n" +
+	"\x1bGetFederationStatusResponse\x12\x12\n" +
+	"\x04peer\x18\x01 \x01(\tR\x04peer\x12<\n" +
+	"\flast_sync_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastSyncAt\x12B\n" +
+	"\x0flast_success_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\rlastSuccessAt\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\x04 \x01(\tR\tlastError\x12!\n" +
+	"\fpeer_backups\x18\x05 \x01(\x05R\vpeerBackups\x12\x16\n" +
+	"\x06pulled\x18\x06 \x01(\x05R\x06pulled\x12*\n" +
+	"\x11failed_backup_ids\x18\a \x03(\tR\x0ffailedBackupIds\"\x18\n" +
+	"\x16GetMirrorStatusRequest\"\x92\x02\n" +
+	"\x17GetMirrorStatusResponse\x12\x16\n" +
+	"\x06target\x18\x01 \x01(\tR\x06target\x12\x1a\n" +
+	"\bmirrored\x18\x02 \x01(\x05R\bmirrored\x12\x18\n" +
+	"\apending\x18\x03 \x01(\x05R\apending\x12\x16\n" +
+	"\x06failed\x18\x04 \x01(\x05R\x06failed\x12\x1f\n" +
+	"\vlag_seconds\x18\x05 \x01(\x03R\n" +
+	"lagSeconds\x12D\n" +
+	"\x10last_mirrored_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x0elastMirroredAt\x12*\n" +
+	"\x11failed_backup_ids\x18\a \x03(\tR\x0ffailedBackupIds\"4\n" +
+	"\x15GetBackupUsageRequest\x12\x1b\n" +
+	"\tbackup_id\x18\x01 \x01(\tR\bbackupId\"\xd5\x03\n" +
+	"\rRestoreRecord\x12\x1b\n" +
+	"\tbackup_id\x18\x01 \x01(\tR\bbackupId\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x1b\n" +
+	"\tmodule_id\x18\x03 \x01(\tR\bmoduleId\x12#\n" +
+	"\rgrpc_endpoint\x18\x04 \x01(\tR\fgrpcEndpoint\x122\n" +
+	"\x04mode\x18\x05 \x01(\x0e2\x1e.backup.service.v1.RestoreModeR\x04mode\x12\x1f\n" +
+	"\vrestored_by\x18\x06 \x01(\tR\n" +
+	"restoredBy\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\a \x01(\tR\trequestId\x129\n" +
+	"\n" +
+	"started_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12;\n" +
+	"\vfinished_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\x12\x18\n" +
+	"\asuccess\x18\n" +
+	" \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\v \x01(\tR\x05error\x125\n" +
+	"\x06source\x18\f \x01(\v2\x1d.backup.service.v1.BackupCopyR\x06source\"V\n" +
+	"\x16GetBackupUsageResponse\x12<\n" +
+	"\brestores\x18\x01 \x03(\v2 .backup.service.v1.RestoreRecordR\brestores\"c\n" +
+	"\x14GetQuotaUsageRequest\x12 \n" +
+	"\ttenant_id\x18\x01 \x01(\rH\x00R\btenantId\x88\x01\x01\x12\x1b\n" +
+	"\tmodule_id\x18\x02 \x01(\tR\bmoduleIdB\f\n" +
+	"\n" +
+	"_tenant_id\"\xc5\x01\n" +
+	"\n" +
+	"QuotaUsage\x12\x14\n" +
+	"\x05scope\x18\x01 \x01(\tR\x05scope\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"used_bytes\x18\x03 \x01(\x03R\tusedBytes\x12\x1b\n" +
+	"\tmax_bytes\x18\x04 \x01(\x03R\bmaxBytes\x12\x18\n" +
+	"\abackups\x18\x05 \x01(\x05R\abackups\x12\x1f\n" +
+	"\vmax_backups\x18\x06 \x01(\x05R\n" +
+	"maxBackups\x12\x1a\n" +
+	"\bexceeded\x18\a \x01(\bR\bexceeded\"L\n" +
+	"\x15GetQuotaUsageResponse\x123\n" +
+	"\x05usage\x18\x01 \x03(\v2\x1d.backup.service.v1.QuotaUsageR\x05usage\"^\n" +
+	"\x14ExportCatalogRequest\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12 \n" +
+	"\ttenant_id\x18\x02 \x01(\rH\x00R\btenantId\x88\x01\x01B\f\n" +
+	"\n" +
+	"_tenant_id\"\xe7\x03\n" +
+	"\fCatalogEntry\x12\x1b\n" +
+	"\tbackup_id\x18\x01 \x01(\tR\bbackupId\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x1b\n" +
+	"\tmodule_id\x18\x03 \x01(\tR\bmoduleId\x12\x1b\n" +
+	"\ttenant_id\x18\x04 \x01(\rR\btenantId\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\a \x01(\tR\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\b \x01(\x03R\tsizeBytes\x12*\n" +
+	"\x11stored_size_bytes\x18\t \x01(\x03R\x0fstoredSizeBytes\x12\x1c\n" +
+	"\tencrypted\x18\n" +
+	" \x01(\bR\tencrypted\x12\x16\n" +
+	"\x06sha256\x18\v \x01(\tR\x06sha256\x12\x18\n" +
+	"\aversion\x18\f \x01(\tR\aversion\x12%\n" +
+	"\x0eschema_version\x18\r \x01(\x05R\rschemaVersion\x12\x16\n" +
+	"\x06pinned\x18\x0e \x01(\bR\x06pinned\x12 \n" +
+	"\vdescription\x18\x0f \x01(\tR\vdescription\"\xad\x01\n" +
+	"\x15ExportCatalogResponse\x129\n" +
+	"\aentries\x18\x01 \x03(\v2\x1f.backup.service.v1.CatalogEntryR\aentries\x12\x1a\n" +
+	"\bdocument\x18\x02 \x01(\tR\bdocument\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12\x1a\n" +
+	"\bfilename\x18\x04 \x01(\tR\bfilename\"j\n" +
+	"\x14ImportCatalogRequest\x12\x1f\n" +
+	"\vsource_path\x18\x01 \x01(\tR\n" +
+	"sourcePath\x12\x18\n" +
+	"\acatalog\x18\x02 \x01(\tR\acatalog\x12\x17\n" +
+	"\adry_run\x18\x03 \x01(\bR\x06dryRun\"x\n" +
+	"\x13CatalogImportResult\x12\x1b\n" +
+	"\tbackup_id\x18\x01 \x01(\tR\bbackupId\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x18\n" +
+	"\aoutcome\x18\x03 \x01(\tR\aoutcome\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"\xe3\x01\n" +
+	"\x15ImportCatalogResponse\x12@\n" +
+	"\aresults\x18\x01 \x03(\v2&.backup.service.v1.CatalogImportResultR\aresults\x12\x1a\n" +
+	"\bimported\x18\x02 \x01(\x05R\bimported\x12\x1e\n" +
+	"\n" +
+	"registered\x18\x03 \x01(\x05R\n" +
+	"registered\x12\x18\n" +
+	"\apresent\x18\x04 \x01(\x05R\apresent\x12\x18\n" +
+	"\ainvalid\x18\x05 \x01(\x05R\ainvalid\x12\x18\n" +
+	"\amissing\x18\x06 \x01(\x05R\amissing\"\xb1\x02\n" +
+	"\x17ExportRepositoryRequest\x12)\n" +
+	"\x10destination_path\x18\x01 \x01(\tR\x0fdestinationPath\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x1b\n" +
+	"\tmodule_id\x18\x03 \x01(\tR\bmoduleId\x12 \n" +
+	"\ttenant_id\x18\x04 \x01(\rH\x00R\btenantId\x88\x01\x01\x12?\n" +
+	"\rcreated_after\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBeforeB\f\n" +
+	"\n" +
+	"_tenant_id\"\xe3\x01\n" +
+	"\x18ExportRepositoryResponse\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x02 \x01(\x03R\tsizeBytes\x12\x16\n" +
+	"\x06sha256\x18\x03 \x01(\tR\x06sha256\x12\x16\n" +
+	"\x06signer\x18\x04 \x01(\tR\x06signer\x12%\n" +
+	"\x0emodule_backups\x18\x05 \x01(\x05R\rmoduleBackups\x12!\n" +
+	"\ffull_backups\x18\x06 \x01(\x05R\vfullBackups\x12\x1a\n" +
+	"\bwarnings\x18\a \x03(\tR\bwarnings\"o\n" +
+	"\x17ImportRepositoryRequest\x12\x1f\n" +
+	"\vsource_path\x18\x01 \x01(\tR\n" +
+	"sourcePath\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x17\n" +
+	"\adry_run\x18\x03 \x01(\bR\x06dryRun\"\x8f\x02\n" +
+	"\x18ImportRepositoryResponse\x12\x16\n" +
+	"\x06signer\x18\x01 \x01(\tR\x06signer\x12%\n" +
+	"\x0esigner_trusted\x18\x02 \x01(\bR\rsignerTrusted\x12;\n" +
+	"\vexported_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"exportedAt\x12\x1f\n" +
+	"\vexported_by\x18\x04 \x01(\tR\n" +
+	"exportedBy\x12\x12\n" +
+	"\x04site\x18\x05 \x01(\tR\x04site\x12B\n" +
+	"\acatalog\x18\x06 \x01(\v2(.backup.service.v1.ImportCatalogResponseR\acatalog\"R\n" +
+	"\x15PreflightCheckRequest\x129\n" +
+	"\atargets\x18\x01 \x03(\v2\x1f.backup.service.v1.ModuleTargetR\atargets\"\x9f\x01\n" +
+	"\x15TargetPreflightResult\x12\x1b\n" +
+	"\tmodule_id\x18\x01 \x01(\tR\bmoduleId\x12\x1c\n" +
+	"\treachable\x18\x02 \x01(\bR\treachable\x12\x16\n" +
+	"\x06health\x18\x03 \x01(\tR\x06health\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"latency_ms\x18\x05 \x01(\x03R\tlatencyMs\"r\n" +
+	"\x16PreflightCheckResponse\x12B\n" +
+	"\aresults\x18\x01 \x03(\v2(.backup.service.v1.TargetPreflightResultR\aresults\x12\x14\n" +
+	"\x05ready\x18\x02 \x01(\bR\x05ready\"\x81\x02\n" +
+	"\x17BootstrapRestoreRequest\x12\x1b\n" +
+	"\tbackup_id\x18\x01 \x01(\tR\bbackupId\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x122\n" +
+	"\x04mode\x18\x03 \x01(\x0e2\x1e.backup.service.v1.RestoreModeR\x04mode\x12!\n" +
+	"\fprofile_name\x18\x04 \x01(\tR\vprofileName\x12=\n" +
+	"\aprofile\x18\x05 \x01(\v2#.backup.service.v1.BootstrapProfileR\aprofile\x12\x17\n" +
+	"\adry_run\x18\x06 \x01(\bR\x06dryRun\"\x92\x02\n" +
+	"\x10BootstrapProfile\x129\n" +
+	"\atargets\x18\x01 \x03(\v2\x1f.backup.service.v1.ModuleTargetR\atargets\x12'\n" +
+	"\x0ftarget_selector\x18\x02 \x01(\tR\x0etargetSelector\x12G\n" +
+	"\fdependencies\x18\x03 \x03(\v2#.backup.service.v1.ModuleDependencyR\fdependencies\x12!\n" +
+	"\fskip_modules\x18\x04 \x03(\tR\vskipModules\x12.\n" +
+	"\x13allow_older_modules\x18\x05 \x01(\bR\x11allowOlderModules\"N\n" +
+	"\x10ModuleDependency\x12\x1b\n" +
+	"\tmodule_id\x18\x01 \x01(\tR\bmoduleId\x12\x1d\n" +
+	"\n" +
+	"depends_on\x18\x02 \x03(\tR\tdependsOn\"\x91\x04\n" +
+	"\x0fBootstrapModule\x12\x1b\n" +
+	"\tmodule_id\x18\x01 \x01(\tR\bmoduleId\x12#\n" +
+	"\rgrpc_endpoint\x18\x02 \x01(\tR\fgrpcEndpoint\x12\x12\n" +
+	"\x04wave\x18\x03 \x01(\x05R\x04wave\x12\x1d\n" +
+	"\n" +
+	"depends_on\x18\x04 \x03(\tR\tdependsOn\x12%\n" +
+	"\x0ebackup_version\x18\x05 \x01(\tR\rbackupVersion\x12%\n" +
+	"\x0eschema_version\x18\x06 \x01(\x05R\rschemaVersion\x12%\n" +
+	"\x0etarget_version\x18\a \x01(\tR\rtargetVersion\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\b \x01(\x03R\tsizeBytes\x12\x14\n" +
+	"\x05state\x18\t \x01(\tR\x05state\x12\x1a\n" +
+	"\bproblems\x18\n" +
+	" \x03(\tR\bproblems\x12\x1a\n" +
+	"\bwarnings\x18\v \x03(\tR\bwarnings\x12F\n" +
+	"\tpreflight\x18\f \x01(\v2(.backup.service.v1.TargetPreflightResultR\tpreflight\x12>\n" +
+	"\x06result\x18\r \x01(\v2&.backup.service.v1.ModuleRestoreResultR\x06result\x12\x1f\n" +
+	"\vduration_ms\x18\x0e \x01(\x03R\n" +
+	"durationMs\"\x80\x02\n" +
+	"\rBootstrapPlan\x12\x1b\n" +
+	"\tbackup_id\x18\x01 \x01(\tR\bbackupId\x12F\n" +
+	"\x11backup_created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x0fbackupCreatedAt\x12<\n" +
+	"\amodules\x18\x03 \x03(\v2\".backup.service.v1.BootstrapModuleR\amodules\x12\x1a\n" +
+	"\bproblems\x18\x04 \x03(\tR\bproblems\x12\x1a\n" +
+	"\bwarnings\x18\x05 \x03(\tR\bwarnings\x12\x14\n" +
+	"\x05ready\x18\x06 \x01(\bR\x05ready\"\xb9\x01\n" +
+	"\x0fBootstrapResult\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1a\n" +
+	"\brestored\x18\x02 \x01(\x05R\brestored\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x05R\x06failed\x12\x18\n" +
+	"\askipped\x18\x04 \x01(\x05R\askipped\x12\x1f\n" +
+	"\vduration_ms\x18\x05 \x01(\x03R\n" +
+	"durationMs\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x06 \x01(\tR\trequestId\"\xb6\x02\n" +
+	"\x15BootstrapRestoreEvent\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x14\n" +
+	"\x05phase\x18\x02 \x01(\tR\x05phase\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x126\n" +
+	"\x04plan\x18\x04 \x01(\v2 .backup.service.v1.BootstrapPlanH\x00R\x04plan\x12<\n" +
+	"\x06module\x18\x05 \x01(\v2\".backup.service.v1.BootstrapModuleH\x00R\x06module\x12<\n" +
+	"\x06result\x18\x06 \x01(\v2\".backup.service.v1.BootstrapResultH\x00R\x06resultB\t\n" +
+	"\apayload\"\x19\n" +
+	"\x17GetDescriptorSetRequest\"]\n" +
+	"\x18GetDescriptorSetResponse\x12%\n" +
+	"\x0edescriptor_set\x18\x01 \x01(\fR\rdescriptorSet\x12\x1a\n" +
+	"\bservices\x18\x02 \x03(\tR\bservices\"\xf3\x01\n" +
+	"\x1aGetBackupStatisticsRequest\x12 \n" +
+	"\ttenant_id\x18\x01 \x01(\rH\x00R\btenantId\x88\x01\x01\x12\x1b\n" +
+	"\tmodule_id\x18\x02 \x01(\tR\bmoduleId\x129\n" +
+	"\n" +
+	"start_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x16\n" +
+	"\x06bucket\x18\x05 \x01(\tR\x06bucketB\f\n" +
+	"\n" +
+	"_tenant_id\"\xb2\x02\n" +
+	"\x16BackupStatisticsBucket\x120\n" +
+	"\x05start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12!\n" +
+	"\fbackup_count\x18\x02 \x01(\x05R\vbackupCount\x12!\n" +
+	"\ffailed_count\x18\x03 \x01(\x05R\vfailedCount\x12\x1f\n" +
+	"\vtotal_bytes\x18\x04 \x01(\x03R\n" +
+	"totalBytes\x12&\n" +
+	"\x0favg_duration_ms\x18\x05 \x01(\x03R\ravgDurationMs\x12!\n" +
+	"\ffailure_rate\x18\x06 \x01(\x01R\vfailureRate\x124\n" +
+	"\x16total_compressed_bytes\x18\a \x01(\x03R\x14totalCompressedBytes\"\xc4\x03\n" +
+	"\x11ModuleBackupTrend\x12\x1b\n" +
+	"\tmodule_id\x18\x01 \x01(\tR\bmoduleId\x12\x1b\n" +
+	"\ttenant_id\x18\x02 \x01(\rR\btenantId\x12!\n" +
+	"\fbackup_count\x18\x03 \x01(\x05R\vbackupCount\x12(\n" +
+	"\x10first_size_bytes\x18\x04 \x01(\x03R\x0efirstSizeBytes\x12&\n" +
+	"\x0flast_size_bytes\x18\x05 \x01(\x03R\rlastSizeBytes\x12!\n" +
+	"\fgrowth_bytes\x18\x06 \x01(\x03R\vgrowthBytes\x12%\n" +
+	"\x0egrowth_percent\x18\a \x01(\x01R\rgrowthPercent\x122\n" +
+	"\x15avg_compression_ratio\x18\b \x01(\x01R\x13avgCompressionRatio\x124\n" +
+	"\x16last_compression_ratio\x18\t \x01(\x01R\x14lastCompressionRatio\x12.\n" +
+	"\x13last_growth_percent\x18\n" +
+	" \x01(\x01R\x11lastGrowthPercent\x12\x1c\n" +
+	"\tballooned\x18\v \x01(\bR\tballooned\"\xe5\x02\n" +
+	"\x1bGetBackupStatisticsResponse\x12C\n" +
+	"\abuckets\x18\x01 \x03(\v2).backup.service.v1.BackupStatisticsBucketR\abuckets\x12#\n" +
+	"\rtotal_backups\x18\x02 \x01(\x05R\ftotalBackups\x12%\n" +
+	"\x0efailed_backups\x18\x03 \x01(\x05R\rfailedBackups\x12\x1f\n" +
+	"\vtotal_bytes\x18\x04 \x01(\x03R\n" +
+	"totalBytes\x12&\n" +
+	"\x0favg_duration_ms\x18\x05 \x01(\x03R\ravgDurationMs\x12!\n" +
+	"\ffailure_rate\x18\x06 \x01(\x01R\vfailureRate\x12I\n" +
+	"\rmodule_trends\x18\a \x03(\v2$.backup.service.v1.ModuleBackupTrendR\fmoduleTrends\"h\n" +
+	"\x19GetBackupFreshnessRequest\x12 \n" +
+	"\ttenant_id\x18\x01 \x01(\rH\x00R\btenantId\x88\x01\x01\x12\x1b\n" +
+	"\tmodule_id\x18\x02 \x01(\tR\bmoduleIdB\f\n" +
+	"\n" +
+	"_tenant_id\"\xed\x01\n" +
+	"\x0fModuleFreshness\x12\x1b\n" +
+	"\tmodule_id\x18\x01 \x01(\tR\bmoduleId\x12\x1b\n" +
+	"\ttenant_id\x18\x02 \x01(\rR\btenantId\x12B\n" +
+	"\x0flast_success_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\rlastSuccessAt\x12\x1f\n" +
+	"\vage_seconds\x18\x04 \x01(\x03R\n" +
+	"ageSeconds\x12\x1f\n" +
+	"\vslo_seconds\x18\x05 \x01(\x03R\n" +
+	"sloSeconds\x12\x1a\n" +
+	"\bviolated\x18\x06 \x01(\bR\bviolated\"z\n" +
+	"\x1aGetBackupFreshnessResponse\x12<\n" +
+	"\amodules\x18\x01 \x03(\v2\".backup.service.v1.ModuleFreshnessR\amodules\x12\x1e\n" +
+	"\n" +
+	"violations\x18\x02 \x01(\x05R\n" +
+	"violations\"\xb4\x01\n" +
+	"\x1bGenerateBackupReportRequest\x12\x16\n" +
+	"\x06period\x18\x01 \x01(\tR\x06period\x125\n" +
+	"\bend_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12 \n" +
+	"\ttenant_id\x18\x03 \x01(\rH\x00R\btenantId\x88\x01\x01\x12\x16\n" +
+	"\x06format\x18\x04 \x01(\tR\x06formatB\f\n" +
+	"\n" +
+	"_tenant_id\"\x9c\x01\n" +
+	"\rReportFailure\x12\x1b\n" +
+	"\tbackup_id\x18\x01 \x01(\tR\bbackupId\x12\x1b\n" +
+	"\tmodule_id\x18\x02 \x01(\tR\bmoduleId\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x16\n" +
+	"\x06errors\x18\x04 \x03(\tR\x06errors\"\x85\x01\n" +
+	"\x13ReportModuleSummary\x12\x1b\n" +
+	"\tmodule_id\x18\x01 \x01(\tR\bmoduleId\x12\x18\n" +
+	"\abackups\x18\x02 \x01(\x05R\abackups\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x05R\x06failed\x12\x1f\n" +
+	"\vtotal_bytes\x18\x04 \x01(\x03R\n" +
+	"totalBytes\"\xc4\x01\n" +
+	"\x12RetentionCandidate\x12\x1b\n" +
+	"\tbackup_id\x18\x01 \x01(\tR\bbackupId\x12\x1b\n" +
+	"\tmodule_id\x18\x02 \x01(\tR\bmoduleId\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"deletes_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tdeletesAt\"\xf1\x04\n" +
+	"\fBackupReport\x12\x16\n" +
+	"\x06period\x18\x01 \x01(\tR\x06period\x129\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12=\n" +
+	"\fgenerated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vgeneratedAt\x12#\n" +
+	"\rtotal_backups\x18\x05 \x01(\x05R\ftotalBackups\x12%\n" +
+	"\x0efailed_backups\x18\x06 \x01(\x05R\rfailedBackups\x12\x1f\n" +
+	"\vtotal_bytes\x18\a \x01(\x03R\n" +
+	"totalBytes\x12,\n" +
+	"\x12storage_used_bytes\x18\b \x01(\x03R\x10storageUsedBytes\x12<\n" +
+	"\bfailures\x18\t \x03(\v2 .backup.service.v1.ReportFailureR\bfailures\x12@\n" +
+	"\amodules\x18\n" +
+	" \x03(\v2&.backup.service.v1.ReportModuleSummaryR\amodules\x12T\n" +
+	"\x12upcoming_deletions\x18\v \x03(\v2%.backup.service.v1.RetentionCandidateR\x11upcomingDeletions\x12'\n" +
+	"\x0fcorrupt_backups\x18\f \x03(\tR\x0ecorruptBackups\"\x96\x01\n" +
+	"\x1cGenerateBackupReportResponse\x127\n" +
+	"\x06report\x18\x01 \x01(\v2\x1f.backup.service.v1.BackupReportR\x06report\x12\x1a\n" +
+	"\bdocument\x18\x02 \x01(\tR\bdocument\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType2\x89)\n" +
 	"\x19BackupOrchestratorService\x12\x91\x01\n" +
 	"\x12CreateModuleBackup\x12,.backup.service.v1.CreateModuleBackupRequest\x1a-.backup.service.v1.CreateModuleBackupResponse\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/v1/backups/modules\x12\xa0\x01\n" +
 	"\x13RestoreModuleBackup\x12-.backup.service.v1.RestoreModuleBackupRequest\x1a..backup.service.v1.RestoreModuleBackupResponse\"*\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/v1/backups/{backup_id}/restore\x12q\n" +
-	"\vListBackups\x12%.backup.service.v1.ListBackupsRequest\x1a&.backup.service.v1.ListBackupsResponse\"\x13\x82\xd3\xe4\x93\x02\r\x12\v/v1/backups\x12p\n" +
-	"\tGetBackup\x12#.backup.service.v1.GetBackupRequest\x1a$.backup.service.v1.GetBackupResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/backups/{id}\x12y\n" +
-	"\fDeleteBackup\x12&.backup.service.v1.DeleteBackupRequest\x1a'.backup.service.v1.DeleteBackupResponse\"\x18\x82\xd3\xe4\x93\x02\x12*\x10/v1/backups/{id}\x12\x8b\x01\n" +
-	"\x0eDownloadBackup\x12(.backup.service.v1.DownloadBackupRequest\x1a).backup.service.v1.DownloadBackupResponse\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/v1/backups/{id}/download\x12\x88\x01\n" +
+	"\vListBackups\x12%.backup.service.v1.ListBackupsRequest\x1a&.backup.service.v1.ListBackupsResponse\"\x13\x82\xd3\xe4\x93\x02\r\x12\v/v1/backups\x12\x81\x01\n" +
+	"\rDeleteBackups\x12'.backup.service.v1.DeleteBackupsRequest\x1a(.backup.service.v1.DeleteBackupsResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/v1/backups/delete\x12\x88\x01\n" +
 	"\x10CreateFullBackup\x12*.backup.service.v1.CreateFullBackupRequest\x1a+.backup.service.v1.CreateFullBackupResponse\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/v1/backups/full\x12\x9f\x01\n" +
 	"\x11RestoreFullBackup\x12+.backup.service.v1.RestoreFullBackupRequest\x1a,.backup.service.v1.RestoreFullBackupResponse\"/\x82\xd3\xe4\x93\x02):\x01*\"$/v1/backups/full/{backup_id}/restore\x12\x82\x01\n" +
 	"\x0fListFullBackups\x12).backup.service.v1.ListFullBackupsRequest\x1a*.backup.service.v1.ListFullBackupsResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/backups/full\x12\x81\x01\n" +
-	"\rGetFullBackup\x12'.backup.service.v1.GetFullBackupRequest\x1a(.backup.service.v1.GetFullBackupResponse\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/v1/backups/full/{id}\x12\x9c\x01\n" +
+	"\rGetFullBackup\x12'.backup.service.v1.GetFullBackupRequest\x1a(.backup.service.v1.GetFullBackupResponse\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/v1/backups/full/{id}\x12\x8d\x01\n" +
+	"\x10UpdateFullBackup\x12*.backup.service.v1.UpdateFullBackupRequest\x1a+.backup.service.v1.UpdateFullBackupResponse\" \x82\xd3\xe4\x93\x02\x1a:\x01*2\x15/v1/backups/full/{id}\x12\x9c\x01\n" +
 	"\x12DownloadFullBackup\x12,.backup.service.v1.DownloadFullBackupRequest\x1a-.backup.service.v1.DownloadFullBackupResponse\")\x82\xd3\xe4\x93\x02#:\x01*\"\x1e/v1/backups/full/{id}/download\x12\x8a\x01\n" +
-	"\x10DeleteFullBackup\x12*.backup.service.v1.DeleteFullBackupRequest\x1a+.backup.service.v1.DeleteFullBackupResponse\"\x1d\x82\xd3\xe4\x93\x02\x17*\x15/v1/backups/full/{id}B\xdf\x01\n" +
+	"\x10DeleteFullBackup\x12*.backup.service.v1.DeleteFullBackupRequest\x1a+.backup.service.v1.DeleteFullBackupResponse\"\x1d\x82\xd3\xe4\x93\x02\x17*\x15/v1/backups/full/{id}\x12\xbb\x01\n" +
+	"\x1cGeneratePresignedDownloadURL\x126.backup.service.v1.GeneratePresignedDownloadURLRequest\x1a7.backup.service.v1.GeneratePresignedDownloadURLResponse\"*\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/v1/backups/{backup_id}/presign\x12~\n" +
+	"\tPinBackup\x12#.backup.service.v1.PinBackupRequest\x1a$.backup.service.v1.PinBackupResponse\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/v1/backups/{backup_id}/pin\x12\x86\x01\n" +
+	"\vUnpinBackup\x12%.backup.service.v1.UnpinBackupRequest\x1a&.backup.service.v1.UnpinBackupResponse\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/v1/backups/{backup_id}/unpin\x12\x8a\x01\n" +
+	"\fVerifyBackup\x12&.backup.service.v1.VerifyBackupRequest\x1a'.backup.service.v1.VerifyBackupResponse\")\x82\xd3\xe4\x93\x02#:\x01*\"\x1e/v1/backups/{backup_id}/verify\x12\x8d\x01\n" +
+	"\vTestRestore\x12%.backup.service.v1.TestRestoreRequest\x1a&.backup.service.v1.TestRestoreResponse\"/\x82\xd3\xe4\x93\x02):\x01*\"$/v1/backups/{backup_id}/test-restore\x12\x8a\x01\n" +
+	"\fRepairBackup\x12&.backup.service.v1.RepairBackupRequest\x1a'.backup.service.v1.RepairBackupResponse\")\x82\xd3\xe4\x93\x02#:\x01*\"\x1e/v1/backups/{backup_id}/repair\x12\x96\x01\n" +
+	"\x0fReplicateBackup\x12).backup.service.v1.ReplicateBackupRequest\x1a*.backup.service.v1.ReplicateBackupResponse\",\x82\xd3\xe4\x93\x02&:\x01*\"!/v1/backups/{backup_id}/replicate\x12^\n" +
+	"\x0eReceiveReplica\x12\x1f.backup.service.v1.ReplicaChunk\x1a).backup.service.v1.ReceiveReplicaResponse(\x01\x12_\n" +
+	"\fListReplicas\x12&.backup.service.v1.ListReplicasRequest\x1a'.backup.service.v1.ListReplicasResponse\x12Y\n" +
+	"\fFetchReplica\x12&.backup.service.v1.FetchReplicaRequest\x1a\x1f.backup.service.v1.ReplicaChunk0\x01\x12\x94\x01\n" +
+	"\x13GetFederationStatus\x12-.backup.service.v1.GetFederationStatusRequest\x1a..backup.service.v1.GetFederationStatusResponse\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/v1/backups/federation\x12\x84\x01\n" +
+	"\x0fGetMirrorStatus\x12).backup.service.v1.GetMirrorStatusRequest\x1a*.backup.service.v1.GetMirrorStatusResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/backups/mirror\x12\x8c\x01\n" +
+	"\x0eGetBackupUsage\x12(.backup.service.v1.GetBackupUsageRequest\x1a).backup.service.v1.GetBackupUsageResponse\"%\x82\xd3\xe4\x93\x02\x1f\x12\x1d/v1/backups/{backup_id}/usage\x12\x94\x01\n" +
+	"\x13GetBackupStatistics\x12-.backup.service.v1.GetBackupStatisticsRequest\x1a..backup.service.v1.GetBackupStatisticsResponse\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/v1/backups/statistics\x12\x90\x01\n" +
+	"\x12GetBackupFreshness\x12,.backup.service.v1.GetBackupFreshnessRequest\x1a-.backup.service.v1.GetBackupFreshnessResponse\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/v1/backups/freshness\x12\x93\x01\n" +
+	"\x14GenerateBackupReport\x12..backup.service.v1.GenerateBackupReportRequest\x1a/.backup.service.v1.GenerateBackupReportResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/backups/report\x12}\n" +
+	"\rGetQuotaUsage\x12'.backup.service.v1.GetQuotaUsageRequest\x1a(.backup.service.v1.GetQuotaUsageResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/backups/quota\x12\x7f\n" +
+	"\rExportCatalog\x12'.backup.service.v1.ExportCatalogRequest\x1a(.backup.service.v1.ExportCatalogResponse\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/backups/catalog\x12\x89\x01\n" +
+	"\rImportCatalog\x12'.backup.service.v1.ImportCatalogRequest\x1a(.backup.service.v1.ImportCatalogResponse\"%\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/v1/backups/catalog/import\x12\x95\x01\n" +
+	"\x10ExportRepository\x12*.backup.service.v1.ExportRepositoryRequest\x1a+.backup.service.v1.ExportRepositoryResponse\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/v1/backups/repository/export\x12\x95\x01\n" +
+	"\x10ImportRepository\x12*.backup.service.v1.ImportRepositoryRequest\x1a+.backup.service.v1.ImportRepositoryResponse\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/v1/backups/repository/import\x12\x87\x01\n" +
+	"\x0ePreflightCheck\x12(.backup.service.v1.PreflightCheckRequest\x1a).backup.service.v1.PreflightCheckResponse\" \x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/v1/backups/preflight\x12j\n" +
+	"\x10BootstrapRestore\x12*.backup.service.v1.BootstrapRestoreRequest\x1a(.backup.service.v1.BootstrapRestoreEvent0\x01\x12\x8b\x01\n" +
+	"\x10GetDescriptorSet\x12*.backup.service.v1.GetDescriptorSetRequest\x1a+.backup.service.v1.GetDescriptorSetResponse\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/v1/backups/descriptor\x12p\n" +
+	"\tGetBackup\x12#.backup.service.v1.GetBackupRequest\x1a$.backup.service.v1.GetBackupResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/backups/{id}\x12y\n" +
+	"\fDeleteBackup\x12&.backup.service.v1.DeleteBackupRequest\x1a'.backup.service.v1.DeleteBackupResponse\"\x18\x82\xd3\xe4\x93\x02\x12*\x10/v1/backups/{id}\x12\x8b\x01\n" +
+	"\x0eDownloadBackup\x12(.backup.service.v1.DownloadBackupRequest\x1a).backup.service.v1.DownloadBackupResponse\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/v1/backups/{id}/downloadB\xdf\x01\n" +
 	"\x15com.backup.service.v1B\x17BackupOrchestratorProtoP\x01ZGgithub.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1;backuppb\xa2\x02\x03BSX\xaa\x02\x11Backup.Service.V1\xca\x02\x11Backup\\Service\\V1\xe2\x02\x1dBackup\\Service\\V1\\GPBMetadata\xea\x02\x13Backup::Service::V1b\x06proto3"
 
 var (
@@ -1907,90 +8656,317 @@ func file_backup_service_v1_backup_orchestrator_proto_rawDescGZIP() []byte {
 	return file_backup_service_v1_backup_orchestrator_proto_rawDescData
 }
 
-var file_backup_service_v1_backup_orchestrator_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
+var file_backup_service_v1_backup_orchestrator_proto_msgTypes = make([]protoimpl.MessageInfo, 112)
 var file_backup_service_v1_backup_orchestrator_proto_goTypes = []any{
-	(*ModuleTarget)(nil),                // 0: backup.service.v1.ModuleTarget
-	(*CreateModuleBackupRequest)(nil),   // 1: backup.service.v1.CreateModuleBackupRequest
-	(*BackupInfo)(nil),                  // 2: backup.service.v1.BackupInfo
-	(*CreateModuleBackupResponse)(nil),  // 3: backup.service.v1.CreateModuleBackupResponse
-	(*RestoreModuleBackupRequest)(nil),  // 4: backup.service.v1.RestoreModuleBackupRequest
-	(*RestoreModuleBackupResponse)(nil), // 5: backup.service.v1.RestoreModuleBackupResponse
-	(*ListBackupsRequest)(nil),          // 6: backup.service.v1.ListBackupsRequest
-	(*ListBackupsResponse)(nil),         // 7: backup.service.v1.ListBackupsResponse
-	(*GetBackupRequest)(nil),            // 8: backup.service.v1.GetBackupRequest
-	(*GetBackupResponse)(nil),           // 9: backup.service.v1.GetBackupResponse
-	(*DeleteBackupRequest)(nil),         // 10: backup.service.v1.DeleteBackupRequest
-	(*DeleteBackupResponse)(nil),        // 11: backup.service.v1.DeleteBackupResponse
-	(*DownloadBackupRequest)(nil),       // 12: backup.service.v1.DownloadBackupRequest
-	(*DownloadBackupResponse)(nil),      // 13: backup.service.v1.DownloadBackupResponse
-	(*CreateFullBackupRequest)(nil),     // 14: backup.service.v1.CreateFullBackupRequest
-	(*FullBackupInfo)(nil),              // 15: backup.service.v1.FullBackupInfo
-	(*CreateFullBackupResponse)(nil),    // 16: backup.service.v1.CreateFullBackupResponse
-	(*RestoreFullBackupRequest)(nil),    // 17: backup.service.v1.RestoreFullBackupRequest
-	(*RestoreFullBackupResponse)(nil),   // 18: backup.service.v1.RestoreFullBackupResponse
-	(*ModuleRestoreResult)(nil),         // 19: backup.service.v1.ModuleRestoreResult
-	(*ListFullBackupsRequest)(nil),      // 20: backup.service.v1.ListFullBackupsRequest
-	(*ListFullBackupsResponse)(nil),     // 21: backup.service.v1.ListFullBackupsResponse
-	(*GetFullBackupRequest)(nil),        // 22: backup.service.v1.GetFullBackupRequest
-	(*GetFullBackupResponse)(nil),       // 23: backup.service.v1.GetFullBackupResponse
-	(*DownloadFullBackupRequest)(nil),   // 24: backup.service.v1.DownloadFullBackupRequest
-	(*DownloadFullBackupResponse)(nil),  // 25: backup.service.v1.DownloadFullBackupResponse
-	(*DeleteFullBackupRequest)(nil),     // 26: backup.service.v1.DeleteFullBackupRequest
-	(*DeleteFullBackupResponse)(nil),    // 27: backup.service.v1.DeleteFullBackupResponse
-	nil,                                 // 28: backup.service.v1.BackupInfo.EntityCountsEntry
-	(*timestamppb.Timestamp)(nil),       // 29: google.protobuf.Timestamp
-	(RestoreMode)(0),                    // 30: backup.service.v1.RestoreMode
-	(*EntityImportResult)(nil),          // 31: backup.service.v1.EntityImportResult
+	(*ModuleTarget)(nil),                         // 0: backup.service.v1.ModuleTarget
+	(*TargetTLS)(nil),                            // 1: backup.service.v1.TargetTLS
+	(*CreateModuleBackupRequest)(nil),            // 2: backup.service.v1.CreateModuleBackupRequest
+	(*BackupInfo)(nil),                           // 3: backup.service.v1.BackupInfo
+	(*BackupPin)(nil),                            // 4: backup.service.v1.BackupPin
+	(*ReplicationStatus)(nil),                    // 5: backup.service.v1.ReplicationStatus
+	(*BackupCopy)(nil),                           // 6: backup.service.v1.BackupCopy
+	(*PhaseTimings)(nil),                         // 7: backup.service.v1.PhaseTimings
+	(*CreateModuleBackupResponse)(nil),           // 8: backup.service.v1.CreateModuleBackupResponse
+	(*RestoreModuleBackupRequest)(nil),           // 9: backup.service.v1.RestoreModuleBackupRequest
+	(*RestoreModuleBackupResponse)(nil),          // 10: backup.service.v1.RestoreModuleBackupResponse
+	(*ListBackupsRequest)(nil),                   // 11: backup.service.v1.ListBackupsRequest
+	(*ListBackupsResponse)(nil),                  // 12: backup.service.v1.ListBackupsResponse
+	(*GetBackupRequest)(nil),                     // 13: backup.service.v1.GetBackupRequest
+	(*GetBackupResponse)(nil),                    // 14: backup.service.v1.GetBackupResponse
+	(*DeleteBackupRequest)(nil),                  // 15: backup.service.v1.DeleteBackupRequest
+	(*DeleteBackupResponse)(nil),                 // 16: backup.service.v1.DeleteBackupResponse
+	(*DeleteBackupsRequest)(nil),                 // 17: backup.service.v1.DeleteBackupsRequest
+	(*DeleteBackupResult)(nil),                   // 18: backup.service.v1.DeleteBackupResult
+	(*DeleteBackupsResponse)(nil),                // 19: backup.service.v1.DeleteBackupsResponse
+	(*DownloadBackupRequest)(nil),                // 20: backup.service.v1.DownloadBackupRequest
+	(*DownloadBackupResponse)(nil),               // 21: backup.service.v1.DownloadBackupResponse
+	(*CreateFullBackupRequest)(nil),              // 22: backup.service.v1.CreateFullBackupRequest
+	(*FullBackupInfo)(nil),                       // 23: backup.service.v1.FullBackupInfo
+	(*CreateFullBackupResponse)(nil),             // 24: backup.service.v1.CreateFullBackupResponse
+	(*RestoreFullBackupRequest)(nil),             // 25: backup.service.v1.RestoreFullBackupRequest
+	(*RestoreFullBackupResponse)(nil),            // 26: backup.service.v1.RestoreFullBackupResponse
+	(*ModuleRestoreResult)(nil),                  // 27: backup.service.v1.ModuleRestoreResult
+	(*ListFullBackupsRequest)(nil),               // 28: backup.service.v1.ListFullBackupsRequest
+	(*ListFullBackupsResponse)(nil),              // 29: backup.service.v1.ListFullBackupsResponse
+	(*GetFullBackupRequest)(nil),                 // 30: backup.service.v1.GetFullBackupRequest
+	(*GetFullBackupResponse)(nil),                // 31: backup.service.v1.GetFullBackupResponse
+	(*UpdateFullBackupRequest)(nil),              // 32: backup.service.v1.UpdateFullBackupRequest
+	(*UpdateFullBackupResponse)(nil),             // 33: backup.service.v1.UpdateFullBackupResponse
+	(*DownloadFullBackupRequest)(nil),            // 34: backup.service.v1.DownloadFullBackupRequest
+	(*DownloadFullBackupResponse)(nil),           // 35: backup.service.v1.DownloadFullBackupResponse
+	(*GeneratePresignedDownloadURLRequest)(nil),  // 36: backup.service.v1.GeneratePresignedDownloadURLRequest
+	(*GeneratePresignedDownloadURLResponse)(nil), // 37: backup.service.v1.GeneratePresignedDownloadURLResponse
+	(*DeleteFullBackupRequest)(nil),              // 38: backup.service.v1.DeleteFullBackupRequest
+	(*DeleteFullBackupResponse)(nil),             // 39: backup.service.v1.DeleteFullBackupResponse
+	(*PinBackupRequest)(nil),                     // 40: backup.service.v1.PinBackupRequest
+	(*PinBackupResponse)(nil),                    // 41: backup.service.v1.PinBackupResponse
+	(*UnpinBackupRequest)(nil),                   // 42: backup.service.v1.UnpinBackupRequest
+	(*UnpinBackupResponse)(nil),                  // 43: backup.service.v1.UnpinBackupResponse
+	(*VerifyBackupRequest)(nil),                  // 44: backup.service.v1.VerifyBackupRequest
+	(*VerificationCheck)(nil),                    // 45: backup.service.v1.VerificationCheck
+	(*ModuleVerification)(nil),                   // 46: backup.service.v1.ModuleVerification
+	(*BackupVerification)(nil),                   // 47: backup.service.v1.BackupVerification
+	(*VerifyBackupResponse)(nil),                 // 48: backup.service.v1.VerifyBackupResponse
+	(*TestRestoreRequest)(nil),                   // 49: backup.service.v1.TestRestoreRequest
+	(*TestRestoreResponse)(nil),                  // 50: backup.service.v1.TestRestoreResponse
+	(*RepairBackupRequest)(nil),                  // 51: backup.service.v1.RepairBackupRequest
+	(*RepairBackupResponse)(nil),                 // 52: backup.service.v1.RepairBackupResponse
+	(*ReplicateBackupRequest)(nil),               // 53: backup.service.v1.ReplicateBackupRequest
+	(*ReplicateBackupResponse)(nil),              // 54: backup.service.v1.ReplicateBackupResponse
+	(*ReplicaChunk)(nil),                         // 55: backup.service.v1.ReplicaChunk
+	(*ReplicaManifest)(nil),                      // 56: backup.service.v1.ReplicaManifest
+	(*ReplicaFile)(nil),                          // 57: backup.service.v1.ReplicaFile
+	(*ReplicaFileChunk)(nil),                     // 58: backup.service.v1.ReplicaFileChunk
+	(*ReceiveReplicaResponse)(nil),               // 59: backup.service.v1.ReceiveReplicaResponse
+	(*ListReplicasRequest)(nil),                  // 60: backup.service.v1.ListReplicasRequest
+	(*ReplicaEntry)(nil),                         // 61: backup.service.v1.ReplicaEntry
+	(*ListReplicasResponse)(nil),                 // 62: backup.service.v1.ListReplicasResponse
+	(*FetchReplicaRequest)(nil),                  // 63: backup.service.v1.FetchReplicaRequest
+	(*GetFederationStatusRequest)(nil),           // 64: backup.service.v1.GetFederationStatusRequest
+	(*GetFederationStatusResponse)(nil),          // 65: backup.service.v1.GetFederationStatusResponse
+	(*GetMirrorStatusRequest)(nil),               // 66: backup.service.v1.GetMirrorStatusRequest
+	(*GetMirrorStatusResponse)(nil),              // 67: backup.service.v1.GetMirrorStatusResponse
+	(*GetBackupUsageRequest)(nil),                // 68: backup.service.v1.GetBackupUsageRequest
+	(*RestoreRecord)(nil),                        // 69: backup.service.v1.RestoreRecord
+	(*GetBackupUsageResponse)(nil),               // 70: backup.service.v1.GetBackupUsageResponse
+	(*GetQuotaUsageRequest)(nil),                 // 71: backup.service.v1.GetQuotaUsageRequest
+	(*QuotaUsage)(nil),                           // 72: backup.service.v1.QuotaUsage
+	(*GetQuotaUsageResponse)(nil),                // 73: backup.service.v1.GetQuotaUsageResponse
+	(*ExportCatalogRequest)(nil),                 // 74: backup.service.v1.ExportCatalogRequest
+	(*CatalogEntry)(nil),                         // 75: backup.service.v1.CatalogEntry
+	(*ExportCatalogResponse)(nil),                // 76: backup.service.v1.ExportCatalogResponse
+	(*ImportCatalogRequest)(nil),                 // 77: backup.service.v1.ImportCatalogRequest
+	(*CatalogImportResult)(nil),                  // 78: backup.service.v1.CatalogImportResult
+	(*ImportCatalogResponse)(nil),                // 79: backup.service.v1.ImportCatalogResponse
+	(*ExportRepositoryRequest)(nil),              // 80: backup.service.v1.ExportRepositoryRequest
+	(*ExportRepositoryResponse)(nil),             // 81: backup.service.v1.ExportRepositoryResponse
+	(*ImportRepositoryRequest)(nil),              // 82: backup.service.v1.ImportRepositoryRequest
+	(*ImportRepositoryResponse)(nil),             // 83: backup.service.v1.ImportRepositoryResponse
+	(*PreflightCheckRequest)(nil),                // 84: backup.service.v1.PreflightCheckRequest
+	(*TargetPreflightResult)(nil),                // 85: backup.service.v1.TargetPreflightResult
+	(*PreflightCheckResponse)(nil),               // 86: backup.service.v1.PreflightCheckResponse
+	(*BootstrapRestoreRequest)(nil),              // 87: backup.service.v1.BootstrapRestoreRequest
+	(*BootstrapProfile)(nil),                     // 88: backup.service.v1.BootstrapProfile
+	(*ModuleDependency)(nil),                     // 89: backup.service.v1.ModuleDependency
+	(*BootstrapModule)(nil),                      // 90: backup.service.v1.BootstrapModule
+	(*BootstrapPlan)(nil),                        // 91: backup.service.v1.BootstrapPlan
+	(*BootstrapResult)(nil),                      // 92: backup.service.v1.BootstrapResult
+	(*BootstrapRestoreEvent)(nil),                // 93: backup.service.v1.BootstrapRestoreEvent
+	(*GetDescriptorSetRequest)(nil),              // 94: backup.service.v1.GetDescriptorSetRequest
+	(*GetDescriptorSetResponse)(nil),             // 95: backup.service.v1.GetDescriptorSetResponse
+	(*GetBackupStatisticsRequest)(nil),           // 96: backup.service.v1.GetBackupStatisticsRequest
+	(*BackupStatisticsBucket)(nil),               // 97: backup.service.v1.BackupStatisticsBucket
+	(*ModuleBackupTrend)(nil),                    // 98: backup.service.v1.ModuleBackupTrend
+	(*GetBackupStatisticsResponse)(nil),          // 99: backup.service.v1.GetBackupStatisticsResponse
+	(*GetBackupFreshnessRequest)(nil),            // 100: backup.service.v1.GetBackupFreshnessRequest
+	(*ModuleFreshness)(nil),                      // 101: backup.service.v1.ModuleFreshness
+	(*GetBackupFreshnessResponse)(nil),           // 102: backup.service.v1.GetBackupFreshnessResponse
+	(*GenerateBackupReportRequest)(nil),          // 103: backup.service.v1.GenerateBackupReportRequest
+	(*ReportFailure)(nil),                        // 104: backup.service.v1.ReportFailure
+	(*ReportModuleSummary)(nil),                  // 105: backup.service.v1.ReportModuleSummary
+	(*RetentionCandidate)(nil),                   // 106: backup.service.v1.RetentionCandidate
+	(*BackupReport)(nil),                         // 107: backup.service.v1.BackupReport
+	(*GenerateBackupReportResponse)(nil),         // 108: backup.service.v1.GenerateBackupReportResponse
+	nil,                                          // 109: backup.service.v1.BackupInfo.EntityCountsEntry
+	nil,                                          // 110: backup.service.v1.FullBackupInfo.LabelsEntry
+	nil,                                          // 111: backup.service.v1.UpdateFullBackupRequest.LabelsEntry
+	(*timestamppb.Timestamp)(nil),                // 112: google.protobuf.Timestamp
+	(RestoreMode)(0),                             // 113: backup.service.v1.RestoreMode
+	(*EntityImportResult)(nil),                   // 114: backup.service.v1.EntityImportResult
 }
 var file_backup_service_v1_backup_orchestrator_proto_depIdxs = []int32{
-	0,  // 0: backup.service.v1.CreateModuleBackupRequest.target:type_name -> backup.service.v1.ModuleTarget
-	28, // 1: backup.service.v1.BackupInfo.entity_counts:type_name -> backup.service.v1.BackupInfo.EntityCountsEntry
-	29, // 2: backup.service.v1.BackupInfo.created_at:type_name -> google.protobuf.Timestamp
-	2,  // 3: backup.service.v1.CreateModuleBackupResponse.backup:type_name -> backup.service.v1.BackupInfo
-	0,  // 4: backup.service.v1.RestoreModuleBackupRequest.target:type_name -> backup.service.v1.ModuleTarget
-	30, // 5: backup.service.v1.RestoreModuleBackupRequest.mode:type_name -> backup.service.v1.RestoreMode
-	31, // 6: backup.service.v1.RestoreModuleBackupResponse.results:type_name -> backup.service.v1.EntityImportResult
-	2,  // 7: backup.service.v1.ListBackupsResponse.backups:type_name -> backup.service.v1.BackupInfo
-	2,  // 8: backup.service.v1.GetBackupResponse.backup:type_name -> backup.service.v1.BackupInfo
-	0,  // 9: backup.service.v1.CreateFullBackupRequest.targets:type_name -> backup.service.v1.ModuleTarget
-	2,  // 10: backup.service.v1.FullBackupInfo.module_backups:type_name -> backup.service.v1.BackupInfo
-	29, // 11: backup.service.v1.FullBackupInfo.created_at:type_name -> google.protobuf.Timestamp
-	15, // 12: backup.service.v1.CreateFullBackupResponse.backup:type_name -> backup.service.v1.FullBackupInfo
-	0,  // 13: backup.service.v1.RestoreFullBackupRequest.targets:type_name -> backup.service.v1.ModuleTarget
-	30, // 14: backup.service.v1.RestoreFullBackupRequest.mode:type_name -> backup.service.v1.RestoreMode
-	19, // 15: backup.service.v1.RestoreFullBackupResponse.module_results:type_name -> backup.service.v1.ModuleRestoreResult
-	31, // 16: backup.service.v1.ModuleRestoreResult.results:type_name -> backup.service.v1.EntityImportResult
-	15, // 17: backup.service.v1.ListFullBackupsResponse.backups:type_name -> backup.service.v1.FullBackupInfo
-	15, // 18: backup.service.v1.GetFullBackupResponse.backup:type_name -> backup.service.v1.FullBackupInfo
-	1,  // 19: backup.service.v1.BackupOrchestratorService.CreateModuleBackup:input_type -> backup.service.v1.CreateModuleBackupRequest
-	4,  // 20: backup.service.v1.BackupOrchestratorService.RestoreModuleBackup:input_type -> backup.service.v1.RestoreModuleBackupRequest
-	6,  // 21: backup.service.v1.BackupOrchestratorService.ListBackups:input_type -> backup.service.v1.ListBackupsRequest
-	8,  // 22: backup.service.v1.BackupOrchestratorService.GetBackup:input_type -> backup.service.v1.GetBackupRequest
-	10, // 23: backup.service.v1.BackupOrchestratorService.DeleteBackup:input_type -> backup.service.v1.DeleteBackupRequest
-	12, // 24: backup.service.v1.BackupOrchestratorService.DownloadBackup:input_type -> backup.service.v1.DownloadBackupRequest
-	14, // 25: backup.service.v1.BackupOrchestratorService.CreateFullBackup:input_type -> backup.service.v1.CreateFullBackupRequest
-	17, // 26: backup.service.v1.BackupOrchestratorService.RestoreFullBackup:input_type -> backup.service.v1.RestoreFullBackupRequest
-	20, // 27: backup.service.v1.BackupOrchestratorService.ListFullBackups:input_type -> backup.service.v1.ListFullBackupsRequest
-	22, // 28: backup.service.v1.BackupOrchestratorService.GetFullBackup:input_type -> backup.service.v1.GetFullBackupRequest
-	24, // 29: backup.service.v1.BackupOrchestratorService.DownloadFullBackup:input_type -> backup.service.v1.DownloadFullBackupRequest
-	26, // 30: backup.service.v1.BackupOrchestratorService.DeleteFullBackup:input_type -> backup.service.v1.DeleteFullBackupRequest
-	3,  // 31: backup.service.v1.BackupOrchestratorService.CreateModuleBackup:output_type -> backup.service.v1.CreateModuleBackupResponse
-	5,  // 32: backup.service.v1.BackupOrchestratorService.RestoreModuleBackup:output_type -> backup.service.v1.RestoreModuleBackupResponse
-	7,  // 33: backup.service.v1.BackupOrchestratorService.ListBackups:output_type -> backup.service.v1.ListBackupsResponse
-	9,  // 34: backup.service.v1.BackupOrchestratorService.GetBackup:output_type -> backup.service.v1.GetBackupResponse
-	11, // 35: backup.service.v1.BackupOrchestratorService.DeleteBackup:output_type -> backup.service.v1.DeleteBackupResponse
-	13, // 36: backup.service.v1.BackupOrchestratorService.DownloadBackup:output_type -> backup.service.v1.DownloadBackupResponse
-	16, // 37: backup.service.v1.BackupOrchestratorService.CreateFullBackup:output_type -> backup.service.v1.CreateFullBackupResponse
-	18, // 38: backup.service.v1.BackupOrchestratorService.RestoreFullBackup:output_type -> backup.service.v1.RestoreFullBackupResponse
-	21, // 39: backup.service.v1.BackupOrchestratorService.ListFullBackups:output_type -> backup.service.v1.ListFullBackupsResponse
-	23, // 40: backup.service.v1.BackupOrchestratorService.GetFullBackup:output_type -> backup.service.v1.GetFullBackupResponse
-	25, // 41: backup.service.v1.BackupOrchestratorService.DownloadFullBackup:output_type -> backup.service.v1.DownloadFullBackupResponse
-	27, // 42: backup.service.v1.BackupOrchestratorService.DeleteFullBackup:output_type -> backup.service.v1.DeleteFullBackupResponse
-	31, // [31:43] is the sub-list for method output_type
-	19, // [19:31] is the sub-list for method input_type
-	19, // [19:19] is the sub-list for extension type_name
-	19, // [19:19] is the sub-list for extension extendee
-	0,  // [0:19] is the sub-list for field type_name
+	1,   // 0: backup.service.v1.ModuleTarget.tls:type_name -> backup.service.v1.TargetTLS
+	0,   // 1: backup.service.v1.CreateModuleBackupRequest.target:type_name -> backup.service.v1.ModuleTarget
+	109, // 2: backup.service.v1.BackupInfo.entity_counts:type_name -> backup.service.v1.BackupInfo.EntityCountsEntry
+	112, // 3: backup.service.v1.BackupInfo.created_at:type_name -> google.protobuf.Timestamp
+	7,   // 4: backup.service.v1.BackupInfo.timings:type_name -> backup.service.v1.PhaseTimings
+	4,   // 5: backup.service.v1.BackupInfo.pin:type_name -> backup.service.v1.BackupPin
+	47,  // 6: backup.service.v1.BackupInfo.last_verification:type_name -> backup.service.v1.BackupVerification
+	5,   // 7: backup.service.v1.BackupInfo.replication:type_name -> backup.service.v1.ReplicationStatus
+	5,   // 8: backup.service.v1.BackupInfo.mirror:type_name -> backup.service.v1.ReplicationStatus
+	5,   // 9: backup.service.v1.BackupInfo.origin:type_name -> backup.service.v1.ReplicationStatus
+	6,   // 10: backup.service.v1.BackupInfo.copies:type_name -> backup.service.v1.BackupCopy
+	112, // 11: backup.service.v1.BackupPin.pinned_at:type_name -> google.protobuf.Timestamp
+	112, // 12: backup.service.v1.ReplicationStatus.updated_at:type_name -> google.protobuf.Timestamp
+	112, // 13: backup.service.v1.ReplicationStatus.replicated_at:type_name -> google.protobuf.Timestamp
+	3,   // 14: backup.service.v1.CreateModuleBackupResponse.backup:type_name -> backup.service.v1.BackupInfo
+	0,   // 15: backup.service.v1.RestoreModuleBackupRequest.target:type_name -> backup.service.v1.ModuleTarget
+	113, // 16: backup.service.v1.RestoreModuleBackupRequest.mode:type_name -> backup.service.v1.RestoreMode
+	114, // 17: backup.service.v1.RestoreModuleBackupResponse.results:type_name -> backup.service.v1.EntityImportResult
+	6,   // 18: backup.service.v1.RestoreModuleBackupResponse.source:type_name -> backup.service.v1.BackupCopy
+	112, // 19: backup.service.v1.ListBackupsRequest.created_after:type_name -> google.protobuf.Timestamp
+	112, // 20: backup.service.v1.ListBackupsRequest.created_before:type_name -> google.protobuf.Timestamp
+	3,   // 21: backup.service.v1.ListBackupsResponse.backups:type_name -> backup.service.v1.BackupInfo
+	3,   // 22: backup.service.v1.GetBackupResponse.backup:type_name -> backup.service.v1.BackupInfo
+	112, // 23: backup.service.v1.DeleteBackupsRequest.created_before:type_name -> google.protobuf.Timestamp
+	18,  // 24: backup.service.v1.DeleteBackupsResponse.results:type_name -> backup.service.v1.DeleteBackupResult
+	0,   // 25: backup.service.v1.CreateFullBackupRequest.targets:type_name -> backup.service.v1.ModuleTarget
+	3,   // 26: backup.service.v1.FullBackupInfo.module_backups:type_name -> backup.service.v1.BackupInfo
+	112, // 27: backup.service.v1.FullBackupInfo.created_at:type_name -> google.protobuf.Timestamp
+	4,   // 28: backup.service.v1.FullBackupInfo.pin:type_name -> backup.service.v1.BackupPin
+	110, // 29: backup.service.v1.FullBackupInfo.labels:type_name -> backup.service.v1.FullBackupInfo.LabelsEntry
+	112, // 30: backup.service.v1.FullBackupInfo.updated_at:type_name -> google.protobuf.Timestamp
+	47,  // 31: backup.service.v1.FullBackupInfo.last_verification:type_name -> backup.service.v1.BackupVerification
+	5,   // 32: backup.service.v1.FullBackupInfo.replication:type_name -> backup.service.v1.ReplicationStatus
+	5,   // 33: backup.service.v1.FullBackupInfo.mirror:type_name -> backup.service.v1.ReplicationStatus
+	5,   // 34: backup.service.v1.FullBackupInfo.origin:type_name -> backup.service.v1.ReplicationStatus
+	6,   // 35: backup.service.v1.FullBackupInfo.copies:type_name -> backup.service.v1.BackupCopy
+	23,  // 36: backup.service.v1.CreateFullBackupResponse.backup:type_name -> backup.service.v1.FullBackupInfo
+	0,   // 37: backup.service.v1.RestoreFullBackupRequest.targets:type_name -> backup.service.v1.ModuleTarget
+	113, // 38: backup.service.v1.RestoreFullBackupRequest.mode:type_name -> backup.service.v1.RestoreMode
+	27,  // 39: backup.service.v1.RestoreFullBackupResponse.module_results:type_name -> backup.service.v1.ModuleRestoreResult
+	114, // 40: backup.service.v1.ModuleRestoreResult.results:type_name -> backup.service.v1.EntityImportResult
+	6,   // 41: backup.service.v1.ModuleRestoreResult.source:type_name -> backup.service.v1.BackupCopy
+	112, // 42: backup.service.v1.ListFullBackupsRequest.created_after:type_name -> google.protobuf.Timestamp
+	112, // 43: backup.service.v1.ListFullBackupsRequest.created_before:type_name -> google.protobuf.Timestamp
+	23,  // 44: backup.service.v1.ListFullBackupsResponse.backups:type_name -> backup.service.v1.FullBackupInfo
+	23,  // 45: backup.service.v1.GetFullBackupResponse.backup:type_name -> backup.service.v1.FullBackupInfo
+	111, // 46: backup.service.v1.UpdateFullBackupRequest.labels:type_name -> backup.service.v1.UpdateFullBackupRequest.LabelsEntry
+	23,  // 47: backup.service.v1.UpdateFullBackupResponse.backup:type_name -> backup.service.v1.FullBackupInfo
+	112, // 48: backup.service.v1.GeneratePresignedDownloadURLResponse.expires_at:type_name -> google.protobuf.Timestamp
+	4,   // 49: backup.service.v1.PinBackupResponse.pin:type_name -> backup.service.v1.BackupPin
+	45,  // 50: backup.service.v1.ModuleVerification.checks:type_name -> backup.service.v1.VerificationCheck
+	112, // 51: backup.service.v1.BackupVerification.verified_at:type_name -> google.protobuf.Timestamp
+	47,  // 52: backup.service.v1.VerifyBackupResponse.verification:type_name -> backup.service.v1.BackupVerification
+	46,  // 53: backup.service.v1.VerifyBackupResponse.modules:type_name -> backup.service.v1.ModuleVerification
+	0,   // 54: backup.service.v1.TestRestoreRequest.target:type_name -> backup.service.v1.ModuleTarget
+	114, // 55: backup.service.v1.TestRestoreResponse.results:type_name -> backup.service.v1.EntityImportResult
+	3,   // 56: backup.service.v1.RepairBackupResponse.backup:type_name -> backup.service.v1.BackupInfo
+	5,   // 57: backup.service.v1.ReplicateBackupResponse.replication:type_name -> backup.service.v1.ReplicationStatus
+	56,  // 58: backup.service.v1.ReplicaChunk.manifest:type_name -> backup.service.v1.ReplicaManifest
+	58,  // 59: backup.service.v1.ReplicaChunk.file:type_name -> backup.service.v1.ReplicaFileChunk
+	57,  // 60: backup.service.v1.ReplicaManifest.files:type_name -> backup.service.v1.ReplicaFile
+	112, // 61: backup.service.v1.ReplicaEntry.created_at:type_name -> google.protobuf.Timestamp
+	61,  // 62: backup.service.v1.ListReplicasResponse.backups:type_name -> backup.service.v1.ReplicaEntry
+	112, // 63: backup.service.v1.GetFederationStatusResponse.last_sync_at:type_name -> google.protobuf.Timestamp
+	112, // 64: backup.service.v1.GetFederationStatusResponse.last_success_at:type_name -> google.protobuf.Timestamp
+	112, // 65: backup.service.v1.GetMirrorStatusResponse.last_mirrored_at:type_name -> google.protobuf.Timestamp
+	113, // 66: backup.service.v1.RestoreRecord.mode:type_name -> backup.service.v1.RestoreMode
+	112, // 67: backup.service.v1.RestoreRecord.started_at:type_name -> google.protobuf.Timestamp
+	112, // 68: backup.service.v1.RestoreRecord.finished_at:type_name -> google.protobuf.Timestamp
+	6,   // 69: backup.service.v1.RestoreRecord.source:type_name -> backup.service.v1.BackupCopy
+	69,  // 70: backup.service.v1.GetBackupUsageResponse.restores:type_name -> backup.service.v1.RestoreRecord
+	72,  // 71: backup.service.v1.GetQuotaUsageResponse.usage:type_name -> backup.service.v1.QuotaUsage
+	112, // 72: backup.service.v1.CatalogEntry.created_at:type_name -> google.protobuf.Timestamp
+	75,  // 73: backup.service.v1.ExportCatalogResponse.entries:type_name -> backup.service.v1.CatalogEntry
+	78,  // 74: backup.service.v1.ImportCatalogResponse.results:type_name -> backup.service.v1.CatalogImportResult
+	112, // 75: backup.service.v1.ExportRepositoryRequest.created_after:type_name -> google.protobuf.Timestamp
+	112, // 76: backup.service.v1.ExportRepositoryRequest.created_before:type_name -> google.protobuf.Timestamp
+	112, // 77: backup.service.v1.ImportRepositoryResponse.exported_at:type_name -> google.protobuf.Timestamp
+	79,  // 78: backup.service.v1.ImportRepositoryResponse.catalog:type_name -> backup.service.v1.ImportCatalogResponse
+	0,   // 79: backup.service.v1.PreflightCheckRequest.targets:type_name -> backup.service.v1.ModuleTarget
+	85,  // 80: backup.service.v1.PreflightCheckResponse.results:type_name -> backup.service.v1.TargetPreflightResult
+	113, // 81: backup.service.v1.BootstrapRestoreRequest.mode:type_name -> backup.service.v1.RestoreMode
+	88,  // 82: backup.service.v1.BootstrapRestoreRequest.profile:type_name -> backup.service.v1.BootstrapProfile
+	0,   // 83: backup.service.v1.BootstrapProfile.targets:type_name -> backup.service.v1.ModuleTarget
+	89,  // 84: backup.service.v1.BootstrapProfile.dependencies:type_name -> backup.service.v1.ModuleDependency
+	85,  // 85: backup.service.v1.BootstrapModule.preflight:type_name -> backup.service.v1.TargetPreflightResult
+	27,  // 86: backup.service.v1.BootstrapModule.result:type_name -> backup.service.v1.ModuleRestoreResult
+	112, // 87: backup.service.v1.BootstrapPlan.backup_created_at:type_name -> google.protobuf.Timestamp
+	90,  // 88: backup.service.v1.BootstrapPlan.modules:type_name -> backup.service.v1.BootstrapModule
+	112, // 89: backup.service.v1.BootstrapRestoreEvent.time:type_name -> google.protobuf.Timestamp
+	91,  // 90: backup.service.v1.BootstrapRestoreEvent.plan:type_name -> backup.service.v1.BootstrapPlan
+	90,  // 91: backup.service.v1.BootstrapRestoreEvent.module:type_name -> backup.service.v1.BootstrapModule
+	92,  // 92: backup.service.v1.BootstrapRestoreEvent.result:type_name -> backup.service.v1.BootstrapResult
+	112, // 93: backup.service.v1.GetBackupStatisticsRequest.start_time:type_name -> google.protobuf.Timestamp
+	112, // 94: backup.service.v1.GetBackupStatisticsRequest.end_time:type_name -> google.protobuf.Timestamp
+	112, // 95: backup.service.v1.BackupStatisticsBucket.start:type_name -> google.protobuf.Timestamp
+	97,  // 96: backup.service.v1.GetBackupStatisticsResponse.buckets:type_name -> backup.service.v1.BackupStatisticsBucket
+	98,  // 97: backup.service.v1.GetBackupStatisticsResponse.module_trends:type_name -> backup.service.v1.ModuleBackupTrend
+	112, // 98: backup.service.v1.ModuleFreshness.last_success_at:type_name -> google.protobuf.Timestamp
+	101, // 99: backup.service.v1.GetBackupFreshnessResponse.modules:type_name -> backup.service.v1.ModuleFreshness
+	112, // 100: backup.service.v1.GenerateBackupReportRequest.end_time:type_name -> google.protobuf.Timestamp
+	112, // 101: backup.service.v1.ReportFailure.created_at:type_name -> google.protobuf.Timestamp
+	112, // 102: backup.service.v1.RetentionCandidate.created_at:type_name -> google.protobuf.Timestamp
+	112, // 103: backup.service.v1.RetentionCandidate.deletes_at:type_name -> google.protobuf.Timestamp
+	112, // 104: backup.service.v1.BackupReport.start_time:type_name -> google.protobuf.Timestamp
+	112, // 105: backup.service.v1.BackupReport.end_time:type_name -> google.protobuf.Timestamp
+	112, // 106: backup.service.v1.BackupReport.generated_at:type_name -> google.protobuf.Timestamp
+	104, // 107: backup.service.v1.BackupReport.failures:type_name -> backup.service.v1.ReportFailure
+	105, // 108: backup.service.v1.BackupReport.modules:type_name -> backup.service.v1.ReportModuleSummary
+	106, // 109: backup.service.v1.BackupReport.upcoming_deletions:type_name -> backup.service.v1.RetentionCandidate
+	107, // 110: backup.service.v1.GenerateBackupReportResponse.report:type_name -> backup.service.v1.BackupReport
+	2,   // 111: backup.service.v1.BackupOrchestratorService.CreateModuleBackup:input_type -> backup.service.v1.CreateModuleBackupRequest
+	9,   // 112: backup.service.v1.BackupOrchestratorService.RestoreModuleBackup:input_type -> backup.service.v1.RestoreModuleBackupRequest
+	11,  // 113: backup.service.v1.BackupOrchestratorService.ListBackups:input_type -> backup.service.v1.ListBackupsRequest
+	17,  // 114: backup.service.v1.BackupOrchestratorService.DeleteBackups:input_type -> backup.service.v1.DeleteBackupsRequest
+	22,  // 115: backup.service.v1.BackupOrchestratorService.CreateFullBackup:input_type -> backup.service.v1.CreateFullBackupRequest
+	25,  // 116: backup.service.v1.BackupOrchestratorService.RestoreFullBackup:input_type -> backup.service.v1.RestoreFullBackupRequest
+	28,  // 117: backup.service.v1.BackupOrchestratorService.ListFullBackups:input_type -> backup.service.v1.ListFullBackupsRequest
+	30,  // 118: backup.service.v1.BackupOrchestratorService.GetFullBackup:input_type -> backup.service.v1.GetFullBackupRequest
+	32,  // 119: backup.service.v1.BackupOrchestratorService.UpdateFullBackup:input_type -> backup.service.v1.UpdateFullBackupRequest
+	34,  // 120: backup.service.v1.BackupOrchestratorService.DownloadFullBackup:input_type -> backup.service.v1.DownloadFullBackupRequest
+	38,  // 121: backup.service.v1.BackupOrchestratorService.DeleteFullBackup:input_type -> backup.service.v1.DeleteFullBackupRequest
+	36,  // 122: backup.service.v1.BackupOrchestratorService.GeneratePresignedDownloadURL:input_type -> backup.service.v1.GeneratePresignedDownloadURLRequest
+	40,  // 123: backup.service.v1.BackupOrchestratorService.PinBackup:input_type -> backup.service.v1.PinBackupRequest
+	42,  // 124: backup.service.v1.BackupOrchestratorService.UnpinBackup:input_type -> backup.service.v1.UnpinBackupRequest
+	44,  // 125: backup.service.v1.BackupOrchestratorService.VerifyBackup:input_type -> backup.service.v1.VerifyBackupRequest
+	49,  // 126: backup.service.v1.BackupOrchestratorService.TestRestore:input_type -> backup.service.v1.TestRestoreRequest
+	51,  // 127: backup.service.v1.BackupOrchestratorService.RepairBackup:input_type -> backup.service.v1.RepairBackupRequest
+	53,  // 128: backup.service.v1.BackupOrchestratorService.ReplicateBackup:input_type -> backup.service.v1.ReplicateBackupRequest
+	55,  // 129: backup.service.v1.BackupOrchestratorService.ReceiveReplica:input_type -> backup.service.v1.ReplicaChunk
+	60,  // 130: backup.service.v1.BackupOrchestratorService.ListReplicas:input_type -> backup.service.v1.ListReplicasRequest
+	63,  // 131: backup.service.v1.BackupOrchestratorService.FetchReplica:input_type -> backup.service.v1.FetchReplicaRequest
+	64,  // 132: backup.service.v1.BackupOrchestratorService.GetFederationStatus:input_type -> backup.service.v1.GetFederationStatusRequest
+	66,  // 133: backup.service.v1.BackupOrchestratorService.GetMirrorStatus:input_type -> backup.service.v1.GetMirrorStatusRequest
+	68,  // 134: backup.service.v1.BackupOrchestratorService.GetBackupUsage:input_type -> backup.service.v1.GetBackupUsageRequest
+	96,  // 135: backup.service.v1.BackupOrchestratorService.GetBackupStatistics:input_type -> backup.service.v1.GetBackupStatisticsRequest
+	100, // 136: backup.service.v1.BackupOrchestratorService.GetBackupFreshness:input_type -> backup.service.v1.GetBackupFreshnessRequest
+	103, // 137: backup.service.v1.BackupOrchestratorService.GenerateBackupReport:input_type -> backup.service.v1.GenerateBackupReportRequest
+	71,  // 138: backup.service.v1.BackupOrchestratorService.GetQuotaUsage:input_type -> backup.service.v1.GetQuotaUsageRequest
+	74,  // 139: backup.service.v1.BackupOrchestratorService.ExportCatalog:input_type -> backup.service.v1.ExportCatalogRequest
+	77,  // 140: backup.service.v1.BackupOrchestratorService.ImportCatalog:input_type -> backup.service.v1.ImportCatalogRequest
+	80,  // 141: backup.service.v1.BackupOrchestratorService.ExportRepository:input_type -> backup.service.v1.ExportRepositoryRequest
+	82,  // 142: backup.service.v1.BackupOrchestratorService.ImportRepository:input_type -> backup.service.v1.ImportRepositoryRequest
+	84,  // 143: backup.service.v1.BackupOrchestratorService.PreflightCheck:input_type -> backup.service.v1.PreflightCheckRequest
+	87,  // 144: backup.service.v1.BackupOrchestratorService.BootstrapRestore:input_type -> backup.service.v1.BootstrapRestoreRequest
+	94,  // 145: backup.service.v1.BackupOrchestratorService.GetDescriptorSet:input_type -> backup.service.v1.GetDescriptorSetRequest
+	13,  // 146: backup.service.v1.BackupOrchestratorService.GetBackup:input_type -> backup.service.v1.GetBackupRequest
+	15,  // 147: backup.service.v1.BackupOrchestratorService.DeleteBackup:input_type -> backup.service.v1.DeleteBackupRequest
+	20,  // 148: backup.service.v1.BackupOrchestratorService.DownloadBackup:input_type -> backup.service.v1.DownloadBackupRequest
+	8,   // 149: backup.service.v1.BackupOrchestratorService.CreateModuleBackup:output_type -> backup.service.v1.CreateModuleBackupResponse
+	10,  // 150: backup.service.v1.BackupOrchestratorService.RestoreModuleBackup:output_type -> backup.service.v1.RestoreModuleBackupResponse
+	12,  // 151: backup.service.v1.BackupOrchestratorService.ListBackups:output_type -> backup.service.v1.ListBackupsResponse
+	19,  // 152: backup.service.v1.BackupOrchestratorService.DeleteBackups:output_type -> backup.service.v1.DeleteBackupsResponse
+	24,  // 153: backup.service.v1.BackupOrchestratorService.CreateFullBackup:output_type -> backup.service.v1.CreateFullBackupResponse
+	26,  // 154: backup.service.v1.BackupOrchestratorService.RestoreFullBackup:output_type -> backup.service.v1.RestoreFullBackupResponse
+	29,  // 155: backup.service.v1.BackupOrchestratorService.ListFullBackups:output_type -> backup.service.v1.ListFullBackupsResponse
+	31,  // 156: backup.service.v1.BackupOrchestratorService.GetFullBackup:output_type -> backup.service.v1.GetFullBackupResponse
+	33,  // 157: backup.service.v1.BackupOrchestratorService.UpdateFullBackup:output_type -> backup.service.v1.UpdateFullBackupResponse
+	35,  // 158: backup.service.v1.BackupOrchestratorService.DownloadFullBackup:output_type -> backup.service.v1.DownloadFullBackupResponse
+	39,  // 159: backup.service.v1.BackupOrchestratorService.DeleteFullBackup:output_type -> backup.service.v1.DeleteFullBackupResponse
+	37,  // 160: backup.service.v1.BackupOrchestratorService.GeneratePresignedDownloadURL:output_type -> backup.service.v1.GeneratePresignedDownloadURLResponse
+	41,  // 161: backup.service.v1.BackupOrchestratorService.PinBackup:output_type -> backup.service.v1.PinBackupResponse
+	43,  // 162: backup.service.v1.BackupOrchestratorService.UnpinBackup:output_type -> backup.service.v1.UnpinBackupResponse
+	48,  // 163: backup.service.v1.BackupOrchestratorService.VerifyBackup:output_type -> backup.service.v1.VerifyBackupResponse
+	50,  // 164: backup.service.v1.BackupOrchestratorService.TestRestore:output_type -> backup.service.v1.TestRestoreResponse
+	52,  // 165: backup.service.v1.BackupOrchestratorService.RepairBackup:output_type -> backup.service.v1.RepairBackupResponse
+	54,  // 166: backup.service.v1.BackupOrchestratorService.ReplicateBackup:output_type -> backup.service.v1.ReplicateBackupResponse
+	59,  // 167: backup.service.v1.BackupOrchestratorService.ReceiveReplica:output_type -> backup.service.v1.ReceiveReplicaResponse
+	62,  // 168: backup.service.v1.BackupOrchestratorService.ListReplicas:output_type -> backup.service.v1.ListReplicasResponse
+	55,  // 169: backup.service.v1.BackupOrchestratorService.FetchReplica:output_type -> backup.service.v1.ReplicaChunk
+	65,  // 170: backup.service.v1.BackupOrchestratorService.GetFederationStatus:output_type -> backup.service.v1.GetFederationStatusResponse
+	67,  // 171: backup.service.v1.BackupOrchestratorService.GetMirrorStatus:output_type -> backup.service.v1.GetMirrorStatusResponse
+	70,  // 172: backup.service.v1.BackupOrchestratorService.GetBackupUsage:output_type -> backup.service.v1.GetBackupUsageResponse
+	99,  // 173: backup.service.v1.BackupOrchestratorService.GetBackupStatistics:output_type -> backup.service.v1.GetBackupStatisticsResponse
+	102, // 174: backup.service.v1.BackupOrchestratorService.GetBackupFreshness:output_type -> backup.service.v1.GetBackupFreshnessResponse
+	108, // 175: backup.service.v1.BackupOrchestratorService.GenerateBackupReport:output_type -> backup.service.v1.GenerateBackupReportResponse
+	73,  // 176: backup.service.v1.BackupOrchestratorService.GetQuotaUsage:output_type -> backup.service.v1.GetQuotaUsageResponse
+	76,  // 177: backup.service.v1.BackupOrchestratorService.ExportCatalog:output_type -> backup.service.v1.ExportCatalogResponse
+	79,  // 178: backup.service.v1.BackupOrchestratorService.ImportCatalog:output_type -> backup.service.v1.ImportCatalogResponse
+	81,  // 179: backup.service.v1.BackupOrchestratorService.ExportRepository:output_type -> backup.service.v1.ExportRepositoryResponse
+	83,  // 180: backup.service.v1.BackupOrchestratorService.ImportRepository:output_type -> backup.service.v1.ImportRepositoryResponse
+	86,  // 181: backup.service.v1.BackupOrchestratorService.PreflightCheck:output_type -> backup.service.v1.PreflightCheckResponse
+	93,  // 182: backup.service.v1.BackupOrchestratorService.BootstrapRestore:output_type -> backup.service.v1.BootstrapRestoreEvent
+	95,  // 183: backup.service.v1.BackupOrchestratorService.GetDescriptorSet:output_type -> backup.service.v1.GetDescriptorSetResponse
+	14,  // 184: backup.service.v1.BackupOrchestratorService.GetBackup:output_type -> backup.service.v1.GetBackupResponse
+	16,  // 185: backup.service.v1.BackupOrchestratorService.DeleteBackup:output_type -> backup.service.v1.DeleteBackupResponse
+	21,  // 186: backup.service.v1.BackupOrchestratorService.DownloadBackup:output_type -> backup.service.v1.DownloadBackupResponse
+	149, // [149:187] is the sub-list for method output_type
+	111, // [111:149] is the sub-list for method input_type
+	111, // [111:111] is the sub-list for extension type_name
+	111, // [111:111] is the sub-list for extension extendee
+	0,   // [0:111] is the sub-list for field type_name
 }
 
 func init() { file_backup_service_v1_backup_orchestrator_proto_init() }
@@ -1999,17 +8975,34 @@ func file_backup_service_v1_backup_orchestrator_proto_init() {
 		return
 	}
 	file_backup_service_v1_backup_service_proto_init()
-	file_backup_service_v1_backup_orchestrator_proto_msgTypes[1].OneofWrappers = []any{}
-	file_backup_service_v1_backup_orchestrator_proto_msgTypes[6].OneofWrappers = []any{}
-	file_backup_service_v1_backup_orchestrator_proto_msgTypes[14].OneofWrappers = []any{}
-	file_backup_service_v1_backup_orchestrator_proto_msgTypes[20].OneofWrappers = []any{}
+	file_backup_service_v1_backup_orchestrator_proto_msgTypes[2].OneofWrappers = []any{}
+	file_backup_service_v1_backup_orchestrator_proto_msgTypes[11].OneofWrappers = []any{}
+	file_backup_service_v1_backup_orchestrator_proto_msgTypes[17].OneofWrappers = []any{}
+	file_backup_service_v1_backup_orchestrator_proto_msgTypes[22].OneofWrappers = []any{}
+	file_backup_service_v1_backup_orchestrator_proto_msgTypes[28].OneofWrappers = []any{}
+	file_backup_service_v1_backup_orchestrator_proto_msgTypes[32].OneofWrappers = []any{}
+	file_backup_service_v1_backup_orchestrator_proto_msgTypes[55].OneofWrappers = []any{
+		(*ReplicaChunk_Manifest)(nil),
+		(*ReplicaChunk_File)(nil),
+	}
+	file_backup_service_v1_backup_orchestrator_proto_msgTypes[71].OneofWrappers = []any{}
+	file_backup_service_v1_backup_orchestrator_proto_msgTypes[74].OneofWrappers = []any{}
+	file_backup_service_v1_backup_orchestrator_proto_msgTypes[80].OneofWrappers = []any{}
+	file_backup_service_v1_backup_orchestrator_proto_msgTypes[93].OneofWrappers = []any{
+		(*BootstrapRestoreEvent_Plan)(nil),
+		(*BootstrapRestoreEvent_Module)(nil),
+		(*BootstrapRestoreEvent_Result)(nil),
+	}
+	file_backup_service_v1_backup_orchestrator_proto_msgTypes[96].OneofWrappers = []any{}
+	file_backup_service_v1_backup_orchestrator_proto_msgTypes[100].OneofWrappers = []any{}
+	file_backup_service_v1_backup_orchestrator_proto_msgTypes[103].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_backup_service_v1_backup_orchestrator_proto_rawDesc), len(file_backup_service_v1_backup_orchestrator_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   29,
+			NumMessages:   112,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,18 +19,44 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	BackupOrchestratorService_CreateModuleBackup_FullMethodName  = "/backup.service.v1.BackupOrchestratorService/CreateModuleBackup"
-	BackupOrchestratorService_RestoreModuleBackup_FullMethodName = "/backup.service.v1.BackupOrchestratorService/RestoreModuleBackup"
-	BackupOrchestratorService_ListBackups_FullMethodName         = "/backup.service.v1.BackupOrchestratorService/ListBackups"
-	BackupOrchestratorService_GetBackup_FullMethodName           = "/backup.service.v1.BackupOrchestratorService/GetBackup"
-	BackupOrchestratorService_DeleteBackup_FullMethodName        = "/backup.service.v1.BackupOrchestratorService/DeleteBackup"
-	BackupOrchestratorService_DownloadBackup_FullMethodName      = "/backup.service.v1.BackupOrchestratorService/DownloadBackup"
-	BackupOrchestratorService_CreateFullBackup_FullMethodName    = "/backup.service.v1.BackupOrchestratorService/CreateFullBackup"
-	BackupOrchestratorService_RestoreFullBackup_FullMethodName   = "/backup.service.v1.BackupOrchestratorService/RestoreFullBackup"
-	BackupOrchestratorService_ListFullBackups_FullMethodName     = "/backup.service.v1.BackupOrchestratorService/ListFullBackups"
-	BackupOrchestratorService_GetFullBackup_FullMethodName       = "/backup.service.v1.BackupOrchestratorService/GetFullBackup"
-	BackupOrchestratorService_DownloadFullBackup_FullMethodName  = "/backup.service.v1.BackupOrchestratorService/DownloadFullBackup"
-	BackupOrchestratorService_DeleteFullBackup_FullMethodName    = "/backup.service.v1.BackupOrchestratorService/DeleteFullBackup"
+	BackupOrchestratorService_CreateModuleBackup_FullMethodName           = "/backup.service.v1.BackupOrchestratorService/CreateModuleBackup"
+	BackupOrchestratorService_RestoreModuleBackup_FullMethodName          = "/backup.service.v1.BackupOrchestratorService/RestoreModuleBackup"
+	BackupOrchestratorService_ListBackups_FullMethodName                  = "/backup.service.v1.BackupOrchestratorService/ListBackups"
+	BackupOrchestratorService_DeleteBackups_FullMethodName                = "/backup.service.v1.BackupOrchestratorService/DeleteBackups"
+	BackupOrchestratorService_CreateFullBackup_FullMethodName             = "/backup.service.v1.BackupOrchestratorService/CreateFullBackup"
+	BackupOrchestratorService_RestoreFullBackup_FullMethodName            = "/backup.service.v1.BackupOrchestratorService/RestoreFullBackup"
+	BackupOrchestratorService_ListFullBackups_FullMethodName              = "/backup.service.v1.BackupOrchestratorService/ListFullBackups"
+	BackupOrchestratorService_GetFullBackup_FullMethodName                = "/backup.service.v1.BackupOrchestratorService/GetFullBackup"
+	BackupOrchestratorService_UpdateFullBackup_FullMethodName             = "/backup.service.v1.BackupOrchestratorService/UpdateFullBackup"
+	BackupOrchestratorService_DownloadFullBackup_FullMethodName           = "/backup.service.v1.BackupOrchestratorService/DownloadFullBackup"
+	BackupOrchestratorService_DeleteFullBackup_FullMethodName             = "/backup.service.v1.BackupOrchestratorService/DeleteFullBackup"
+	BackupOrchestratorService_GeneratePresignedDownloadURL_FullMethodName = "/backup.service.v1.BackupOrchestratorService/GeneratePresignedDownloadURL"
+	BackupOrchestratorService_PinBackup_FullMethodName                    = "/backup.service.v1.BackupOrchestratorService/PinBackup"
+	BackupOrchestratorService_UnpinBackup_FullMethodName                  = "/backup.service.v1.BackupOrchestratorService/UnpinBackup"
+	BackupOrchestratorService_VerifyBackup_FullMethodName                 = "/backup.service.v1.BackupOrchestratorService/VerifyBackup"
+	BackupOrchestratorService_TestRestore_FullMethodName                  = "/backup.service.v1.BackupOrchestratorService/TestRestore"
+	BackupOrchestratorService_RepairBackup_FullMethodName                 = "/backup.service.v1.BackupOrchestratorService/RepairBackup"
+	BackupOrchestratorService_ReplicateBackup_FullMethodName              = "/backup.service.v1.BackupOrchestratorService/ReplicateBackup"
+	BackupOrchestratorService_ReceiveReplica_FullMethodName               = "/backup.service.v1.BackupOrchestratorService/ReceiveReplica"
+	BackupOrchestratorService_ListReplicas_FullMethodName                 = "/backup.service.v1.BackupOrchestratorService/ListReplicas"
+	BackupOrchestratorService_FetchReplica_FullMethodName                 = "/backup.service.v1.BackupOrchestratorService/FetchReplica"
+	BackupOrchestratorService_GetFederationStatus_FullMethodName          = "/backup.service.v1.BackupOrchestratorService/GetFederationStatus"
+	BackupOrchestratorService_GetMirrorStatus_FullMethodName              = "/backup.service.v1.BackupOrchestratorService/GetMirrorStatus"
+	BackupOrchestratorService_GetBackupUsage_FullMethodName               = "/backup.service.v1.BackupOrchestratorService/GetBackupUsage"
+	BackupOrchestratorService_GetBackupStatistics_FullMethodName          = "/backup.service.v1.BackupOrchestratorService/GetBackupStatistics"
+	BackupOrchestratorService_GetBackupFreshness_FullMethodName           = "/backup.service.v1.BackupOrchestratorService/GetBackupFreshness"
+	BackupOrchestratorService_GenerateBackupReport_FullMethodName         = "/backup.service.v1.BackupOrchestratorService/GenerateBackupReport"
+	BackupOrchestratorService_GetQuotaUsage_FullMethodName                = "/backup.service.v1.BackupOrchestratorService/GetQuotaUsage"
+	BackupOrchestratorService_ExportCatalog_FullMethodName                = "/backup.service.v1.BackupOrchestratorService/ExportCatalog"
+	BackupOrchestratorService_ImportCatalog_FullMethodName                = "/backup.service.v1.BackupOrchestratorService/ImportCatalog"
+	BackupOrchestratorService_ExportRepository_FullMethodName             = "/backup.service.v1.BackupOrchestratorService/ExportRepository"
+	BackupOrchestratorService_ImportRepository_FullMethodName             = "/backup.service.v1.BackupOrchestratorService/ImportRepository"
+	BackupOrchestratorService_PreflightCheck_FullMethodName               = "/backup.service.v1.BackupOrchestratorService/PreflightCheck"
+	BackupOrchestratorService_BootstrapRestore_FullMethodName             = "/backup.service.v1.BackupOrchestratorService/BootstrapRestore"
+	BackupOrchestratorService_GetDescriptorSet_FullMethodName             = "/backup.service.v1.BackupOrchestratorService/GetDescriptorSet"
+	BackupOrchestratorService_GetBackup_FullMethodName                    = "/backup.service.v1.BackupOrchestratorService/GetBackup"
+	BackupOrchestratorService_DeleteBackup_FullMethodName                 = "/backup.service.v1.BackupOrchestratorService/DeleteBackup"
+	BackupOrchestratorService_DownloadBackup_FullMethodName               = "/backup.service.v1.BackupOrchestratorService/DownloadBackup"
 )
 
 // BackupOrchestratorServiceClient is the client API for BackupOrchestratorService service.
@@ -41,16 +67,63 @@ type BackupOrchestratorServiceClient interface {
 	CreateModuleBackup(ctx context.Context, in *CreateModuleBackupRequest, opts ...grpc.CallOption) (*CreateModuleBackupResponse, error)
 	RestoreModuleBackup(ctx context.Context, in *RestoreModuleBackupRequest, opts ...grpc.CallOption) (*RestoreModuleBackupResponse, error)
 	ListBackups(ctx context.Context, in *ListBackupsRequest, opts ...grpc.CallOption) (*ListBackupsResponse, error)
-	GetBackup(ctx context.Context, in *GetBackupRequest, opts ...grpc.CallOption) (*GetBackupResponse, error)
-	DeleteBackup(ctx context.Context, in *DeleteBackupRequest, opts ...grpc.CallOption) (*DeleteBackupResponse, error)
-	DownloadBackup(ctx context.Context, in *DownloadBackupRequest, opts ...grpc.CallOption) (*DownloadBackupResponse, error)
+	DeleteBackups(ctx context.Context, in *DeleteBackupsRequest, opts ...grpc.CallOption) (*DeleteBackupsResponse, error)
 	// Full platform operations
 	CreateFullBackup(ctx context.Context, in *CreateFullBackupRequest, opts ...grpc.CallOption) (*CreateFullBackupResponse, error)
 	RestoreFullBackup(ctx context.Context, in *RestoreFullBackupRequest, opts ...grpc.CallOption) (*RestoreFullBackupResponse, error)
 	ListFullBackups(ctx context.Context, in *ListFullBackupsRequest, opts ...grpc.CallOption) (*ListFullBackupsResponse, error)
 	GetFullBackup(ctx context.Context, in *GetFullBackupRequest, opts ...grpc.CallOption) (*GetFullBackupResponse, error)
+	UpdateFullBackup(ctx context.Context, in *UpdateFullBackupRequest, opts ...grpc.CallOption) (*UpdateFullBackupResponse, error)
 	DownloadFullBackup(ctx context.Context, in *DownloadFullBackupRequest, opts ...grpc.CallOption) (*DownloadFullBackupResponse, error)
 	DeleteFullBackup(ctx context.Context, in *DeleteFullBackupRequest, opts ...grpc.CallOption) (*DeleteFullBackupResponse, error)
+	// Download links
+	GeneratePresignedDownloadURL(ctx context.Context, in *GeneratePresignedDownloadURLRequest, opts ...grpc.CallOption) (*GeneratePresignedDownloadURLResponse, error)
+	// Deletion protection; unpinning requires a platform admin
+	PinBackup(ctx context.Context, in *PinBackupRequest, opts ...grpc.CallOption) (*PinBackupResponse, error)
+	UnpinBackup(ctx context.Context, in *UnpinBackupRequest, opts ...grpc.CallOption) (*UnpinBackupResponse, error)
+	// Integrity checks
+	VerifyBackup(ctx context.Context, in *VerifyBackupRequest, opts ...grpc.CallOption) (*VerifyBackupResponse, error)
+	TestRestore(ctx context.Context, in *TestRestoreRequest, opts ...grpc.CallOption) (*TestRestoreResponse, error)
+	RepairBackup(ctx context.Context, in *RepairBackupRequest, opts ...grpc.CallOption) (*RepairBackupResponse, error)
+	// Off-site copies. ReplicateBackup queues a backup for replication now;
+	// ReceiveReplica is what a primary calls on the secondary, over mTLS only.
+	ReplicateBackup(ctx context.Context, in *ReplicateBackupRequest, opts ...grpc.CallOption) (*ReplicateBackupResponse, error)
+	ReceiveReplica(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ReplicaChunk, ReceiveReplicaResponse], error)
+	// What a DR orchestrator calls on its primary to pull backups, over mTLS
+	// only. FetchReplica streams the same messages ReceiveReplica takes.
+	ListReplicas(ctx context.Context, in *ListReplicasRequest, opts ...grpc.CallOption) (*ListReplicasResponse, error)
+	FetchReplica(ctx context.Context, in *FetchReplicaRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ReplicaChunk], error)
+	// How current this DR orchestrator is with its primary
+	GetFederationStatus(ctx context.Context, in *GetFederationStatusRequest, opts ...grpc.CallOption) (*GetFederationStatusResponse, error)
+	// How far the object storage mirror is behind
+	GetMirrorStatus(ctx context.Context, in *GetMirrorStatusRequest, opts ...grpc.CallOption) (*GetMirrorStatusResponse, error)
+	// Restore history of one backup, kept after the backup is deleted
+	GetBackupUsage(ctx context.Context, in *GetBackupUsageRequest, opts ...grpc.CallOption) (*GetBackupUsageResponse, error)
+	// Statistics
+	GetBackupStatistics(ctx context.Context, in *GetBackupStatisticsRequest, opts ...grpc.CallOption) (*GetBackupStatisticsResponse, error)
+	GetBackupFreshness(ctx context.Context, in *GetBackupFreshnessRequest, opts ...grpc.CallOption) (*GetBackupFreshnessResponse, error)
+	GenerateBackupReport(ctx context.Context, in *GenerateBackupReportRequest, opts ...grpc.CallOption) (*GenerateBackupReportResponse, error)
+	GetQuotaUsage(ctx context.Context, in *GetQuotaUsageRequest, opts ...grpc.CallOption) (*GetQuotaUsageResponse, error)
+	ExportCatalog(ctx context.Context, in *ExportCatalogRequest, opts ...grpc.CallOption) (*ExportCatalogResponse, error)
+	// Registers the backups of an attached volume; platform admin only
+	ImportCatalog(ctx context.Context, in *ImportCatalogRequest, opts ...grpc.CallOption) (*ImportCatalogResponse, error)
+	// Offline bundles of the whole repository; platform admin only
+	ExportRepository(ctx context.Context, in *ExportRepositoryRequest, opts ...grpc.CallOption) (*ExportRepositoryResponse, error)
+	ImportRepository(ctx context.Context, in *ImportRepositoryRequest, opts ...grpc.CallOption) (*ImportRepositoryResponse, error)
+	// Target checks
+	PreflightCheck(ctx context.Context, in *PreflightCheckRequest, opts ...grpc.CallOption) (*PreflightCheckResponse, error)
+	// Streams its progress, so gRPC only
+	BootstrapRestore(ctx context.Context, in *BootstrapRestoreRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BootstrapRestoreEvent], error)
+	// API metadata
+	GetDescriptorSet(ctx context.Context, in *GetDescriptorSetRequest, opts ...grpc.CallOption) (*GetDescriptorSetResponse, error)
+	// Single module backups by ID. Declared last because the HTTP gateway
+	// matches routes in declaration order, and /v1/backups/{id} would otherwise
+	// capture /v1/backups/full, /statistics, /freshness, /report, /quota,
+	// /catalog, /mirror, /federation, /repository and the other fixed paths
+	// above.
+	GetBackup(ctx context.Context, in *GetBackupRequest, opts ...grpc.CallOption) (*GetBackupResponse, error)
+	DeleteBackup(ctx context.Context, in *DeleteBackupRequest, opts ...grpc.CallOption) (*DeleteBackupResponse, error)
+	DownloadBackup(ctx context.Context, in *DownloadBackupRequest, opts ...grpc.CallOption) (*DownloadBackupResponse, error)
 }
 
 type backupOrchestratorServiceClient struct {
@@ -91,30 +164,10 @@ func (c *backupOrchestratorServiceClient) ListBackups(ctx context.Context, in *L
 	return out, nil
 }
 
-func (c *backupOrchestratorServiceClient) GetBackup(ctx context.Context, in *GetBackupRequest, opts ...grpc.CallOption) (*GetBackupResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(GetBackupResponse)
-	err := c.cc.Invoke(ctx, BackupOrchestratorService_GetBackup_FullMethodName, in, out, cOpts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-func (c *backupOrchestratorServiceClient) DeleteBackup(ctx context.Context, in *DeleteBackupRequest, opts ...grpc.CallOption) (*DeleteBackupResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(DeleteBackupResponse)
-	err := c.cc.Invoke(ctx, BackupOrchestratorService_DeleteBackup_FullMethodName, in, out, cOpts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-func (c *backupOrchestratorServiceClient) DownloadBackup(ctx context.Context, in *DownloadBackupRequest, opts ...grpc.CallOption) (*DownloadBackupResponse, error) {
+func (c *backupOrchestratorServiceClient) DeleteBackups(ctx context.Context, in *DeleteBackupsRequest, opts ...grpc.CallOption) (*DeleteBackupsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(DownloadBackupResponse)
-	err := c.cc.Invoke(ctx, BackupOrchestratorService_DownloadBackup_FullMethodName, in, out, cOpts...)
+	out := new(DeleteBackupsResponse)
+	err := c.cc.Invoke(ctx, BackupOrchestratorService_DeleteBackups_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -161,6 +214,16 @@ func (c *backupOrchestratorServiceClient) GetFullBackup(ctx context.Context, in
 	return out, nil
 }
 
+func (c *backupOrchestratorServiceClient) UpdateFullBackup(ctx context.Context, in *UpdateFullBackupRequest, opts ...grpc.CallOption) (*UpdateFullBackupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateFullBackupResponse)
+	err := c.cc.Invoke(ctx, BackupOrchestratorService_UpdateFullBackup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *backupOrchestratorServiceClient) DownloadFullBackup(ctx context.Context, in *DownloadFullBackupRequest, opts ...grpc.CallOption) (*DownloadFullBackupResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(DownloadFullBackupResponse)
@@ -181,6 +244,297 @@ func (c *backupOrchestratorServiceClient) DeleteFullBackup(ctx context.Context,
 	return out, nil
 }
 
+func (c *backupOrchestratorServiceClient) GeneratePresignedDownloadURL(ctx context.Context, in *GeneratePresignedDownloadURLRequest, opts ...grpc.CallOption) (*GeneratePresignedDownloadURLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GeneratePresignedDownloadURLResponse)
+	err := c.cc.Invoke(ctx, BackupOrchestratorService_GeneratePresignedDownloadURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backupOrchestratorServiceClient) PinBackup(ctx context.Context, in *PinBackupRequest, opts ...grpc.CallOption) (*PinBackupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PinBackupResponse)
+	err := c.cc.Invoke(ctx, BackupOrchestratorService_PinBackup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backupOrchestratorServiceClient) UnpinBackup(ctx context.Context, in *UnpinBackupRequest, opts ...grpc.CallOption) (*UnpinBackupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnpinBackupResponse)
+	err := c.cc.Invoke(ctx, BackupOrchestratorService_UnpinBackup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backupOrchestratorServiceClient) VerifyBackup(ctx context.Context, in *VerifyBackupRequest, opts ...grpc.CallOption) (*VerifyBackupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyBackupResponse)
+	err := c.cc.Invoke(ctx, BackupOrchestratorService_VerifyBackup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backupOrchestratorServiceClient) TestRestore(ctx context.Context, in *TestRestoreRequest, opts ...grpc.CallOption) (*TestRestoreResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TestRestoreResponse)
+	err := c.cc.Invoke(ctx, BackupOrchestratorService_TestRestore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backupOrchestratorServiceClient) RepairBackup(ctx context.Context, in *RepairBackupRequest, opts ...grpc.CallOption) (*RepairBackupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RepairBackupResponse)
+	err := c.cc.Invoke(ctx, BackupOrchestratorService_RepairBackup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backupOrchestratorServiceClient) ReplicateBackup(ctx context.Context, in *ReplicateBackupRequest, opts ...grpc.CallOption) (*ReplicateBackupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReplicateBackupResponse)
+	err := c.cc.Invoke(ctx, BackupOrchestratorService_ReplicateBackup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backupOrchestratorServiceClient) ReceiveReplica(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ReplicaChunk, ReceiveReplicaResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BackupOrchestratorService_ServiceDesc.Streams[0], BackupOrchestratorService_ReceiveReplica_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ReplicaChunk, ReceiveReplicaResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BackupOrchestratorService_ReceiveReplicaClient = grpc.ClientStreamingClient[ReplicaChunk, ReceiveReplicaResponse]
+
+func (c *backupOrchestratorServiceClient) ListReplicas(ctx context.Context, in *ListReplicasRequest, opts ...grpc.CallOption) (*ListReplicasResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListReplicasResponse)
+	err := c.cc.Invoke(ctx, BackupOrchestratorService_ListReplicas_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backupOrchestratorServiceClient) FetchReplica(ctx context.Context, in *FetchReplicaRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ReplicaChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BackupOrchestratorService_ServiceDesc.Streams[1], BackupOrchestratorService_FetchReplica_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FetchReplicaRequest, ReplicaChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BackupOrchestratorService_FetchReplicaClient = grpc.ServerStreamingClient[ReplicaChunk]
+
+func (c *backupOrchestratorServiceClient) GetFederationStatus(ctx context.Context, in *GetFederationStatusRequest, opts ...grpc.CallOption) (*GetFederationStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFederationStatusResponse)
+	err := c.cc.Invoke(ctx, BackupOrchestratorService_GetFederationStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backupOrchestratorServiceClient) GetMirrorStatus(ctx context.Context, in *GetMirrorStatusRequest, opts ...grpc.CallOption) (*GetMirrorStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMirrorStatusResponse)
+	err := c.cc.Invoke(ctx, BackupOrchestratorService_GetMirrorStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backupOrchestratorServiceClient) GetBackupUsage(ctx context.Context, in *GetBackupUsageRequest, opts ...grpc.CallOption) (*GetBackupUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBackupUsageResponse)
+	err := c.cc.Invoke(ctx, BackupOrchestratorService_GetBackupUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backupOrchestratorServiceClient) GetBackupStatistics(ctx context.Context, in *GetBackupStatisticsRequest, opts ...grpc.CallOption) (*GetBackupStatisticsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBackupStatisticsResponse)
+	err := c.cc.Invoke(ctx, BackupOrchestratorService_GetBackupStatistics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backupOrchestratorServiceClient) GetBackupFreshness(ctx context.Context, in *GetBackupFreshnessRequest, opts ...grpc.CallOption) (*GetBackupFreshnessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBackupFreshnessResponse)
+	err := c.cc.Invoke(ctx, BackupOrchestratorService_GetBackupFreshness_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backupOrchestratorServiceClient) GenerateBackupReport(ctx context.Context, in *GenerateBackupReportRequest, opts ...grpc.CallOption) (*GenerateBackupReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GenerateBackupReportResponse)
+	err := c.cc.Invoke(ctx, BackupOrchestratorService_GenerateBackupReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backupOrchestratorServiceClient) GetQuotaUsage(ctx context.Context, in *GetQuotaUsageRequest, opts ...grpc.CallOption) (*GetQuotaUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetQuotaUsageResponse)
+	err := c.cc.Invoke(ctx, BackupOrchestratorService_GetQuotaUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backupOrchestratorServiceClient) ExportCatalog(ctx context.Context, in *ExportCatalogRequest, opts ...grpc.CallOption) (*ExportCatalogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportCatalogResponse)
+	err := c.cc.Invoke(ctx, BackupOrchestratorService_ExportCatalog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backupOrchestratorServiceClient) ImportCatalog(ctx context.Context, in *ImportCatalogRequest, opts ...grpc.CallOption) (*ImportCatalogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportCatalogResponse)
+	err := c.cc.Invoke(ctx, BackupOrchestratorService_ImportCatalog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backupOrchestratorServiceClient) ExportRepository(ctx context.Context, in *ExportRepositoryRequest, opts ...grpc.CallOption) (*ExportRepositoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportRepositoryResponse)
+	err := c.cc.Invoke(ctx, BackupOrchestratorService_ExportRepository_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backupOrchestratorServiceClient) ImportRepository(ctx context.Context, in *ImportRepositoryRequest, opts ...grpc.CallOption) (*ImportRepositoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportRepositoryResponse)
+	err := c.cc.Invoke(ctx, BackupOrchestratorService_ImportRepository_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backupOrchestratorServiceClient) PreflightCheck(ctx context.Context, in *PreflightCheckRequest, opts ...grpc.CallOption) (*PreflightCheckResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreflightCheckResponse)
+	err := c.cc.Invoke(ctx, BackupOrchestratorService_PreflightCheck_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backupOrchestratorServiceClient) BootstrapRestore(ctx context.Context, in *BootstrapRestoreRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BootstrapRestoreEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BackupOrchestratorService_ServiceDesc.Streams[2], BackupOrchestratorService_BootstrapRestore_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[BootstrapRestoreRequest, BootstrapRestoreEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BackupOrchestratorService_BootstrapRestoreClient = grpc.ServerStreamingClient[BootstrapRestoreEvent]
+
+func (c *backupOrchestratorServiceClient) GetDescriptorSet(ctx context.Context, in *GetDescriptorSetRequest, opts ...grpc.CallOption) (*GetDescriptorSetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDescriptorSetResponse)
+	err := c.cc.Invoke(ctx, BackupOrchestratorService_GetDescriptorSet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backupOrchestratorServiceClient) GetBackup(ctx context.Context, in *GetBackupRequest, opts ...grpc.CallOption) (*GetBackupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBackupResponse)
+	err := c.cc.Invoke(ctx, BackupOrchestratorService_GetBackup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backupOrchestratorServiceClient) DeleteBackup(ctx context.Context, in *DeleteBackupRequest, opts ...grpc.CallOption) (*DeleteBackupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteBackupResponse)
+	err := c.cc.Invoke(ctx, BackupOrchestratorService_DeleteBackup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backupOrchestratorServiceClient) DownloadBackup(ctx context.Context, in *DownloadBackupRequest, opts ...grpc.CallOption) (*DownloadBackupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DownloadBackupResponse)
+	err := c.cc.Invoke(ctx, BackupOrchestratorService_DownloadBackup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BackupOrchestratorServiceServer is the server API for BackupOrchestratorService service.
 // All implementations must embed UnimplementedBackupOrchestratorServiceServer
 // for forward compatibility.
@@ -189,16 +543,63 @@ type BackupOrchestratorServiceServer interface {
 	CreateModuleBackup(context.Context, *CreateModuleBackupRequest) (*CreateModuleBackupResponse, error)
 	RestoreModuleBackup(context.Context, *RestoreModuleBackupRequest) (*RestoreModuleBackupResponse, error)
 	ListBackups(context.Context, *ListBackupsRequest) (*ListBackupsResponse, error)
-	GetBackup(context.Context, *GetBackupRequest) (*GetBackupResponse, error)
-	DeleteBackup(context.Context, *DeleteBackupRequest) (*DeleteBackupResponse, error)
-	DownloadBackup(context.Context, *DownloadBackupRequest) (*DownloadBackupResponse, error)
+	DeleteBackups(context.Context, *DeleteBackupsRequest) (*DeleteBackupsResponse, error)
 	// Full platform operations
 	CreateFullBackup(context.Context, *CreateFullBackupRequest) (*CreateFullBackupResponse, error)
 	RestoreFullBackup(context.Context, *RestoreFullBackupRequest) (*RestoreFullBackupResponse, error)
 	ListFullBackups(context.Context, *ListFullBackupsRequest) (*ListFullBackupsResponse, error)
 	GetFullBackup(context.Context, *GetFullBackupRequest) (*GetFullBackupResponse, error)
+	UpdateFullBackup(context.Context, *UpdateFullBackupRequest) (*UpdateFullBackupResponse, error)
 	DownloadFullBackup(context.Context, *DownloadFullBackupRequest) (*DownloadFullBackupResponse, error)
 	DeleteFullBackup(context.Context, *DeleteFullBackupRequest) (*DeleteFullBackupResponse, error)
+	// Download links
+	GeneratePresignedDownloadURL(context.Context, *GeneratePresignedDownloadURLRequest) (*GeneratePresignedDownloadURLResponse, error)
+	// Deletion protection; unpinning requires a platform admin
+	PinBackup(context.Context, *PinBackupRequest) (*PinBackupResponse, error)
+	UnpinBackup(context.Context, *UnpinBackupRequest) (*UnpinBackupResponse, error)
+	// Integrity checks
+	VerifyBackup(context.Context, *VerifyBackupRequest) (*VerifyBackupResponse, error)
+	TestRestore(context.Context, *TestRestoreRequest) (*TestRestoreResponse, error)
+	RepairBackup(context.Context, *RepairBackupRequest) (*RepairBackupResponse, error)
+	// Off-site copies. ReplicateBackup queues a backup for replication now;
+	// ReceiveReplica is what a primary calls on the secondary, over mTLS only.
+	ReplicateBackup(context.Context, *ReplicateBackupRequest) (*ReplicateBackupResponse, error)
+	ReceiveReplica(grpc.ClientStreamingServer[ReplicaChunk, ReceiveReplicaResponse]) error
+	// What a DR orchestrator calls on its primary to pull backups, over mTLS
+	// only. FetchReplica streams the same messages ReceiveReplica takes.
+	ListReplicas(context.Context, *ListReplicasRequest) (*ListReplicasResponse, error)
+	FetchReplica(*FetchReplicaRequest, grpc.ServerStreamingServer[ReplicaChunk]) error
+	// How current this DR orchestrator is with its primary
+	GetFederationStatus(context.Context, *GetFederationStatusRequest) (*GetFederationStatusResponse, error)
+	// How far the object storage mirror is behind
+	GetMirrorStatus(context.Context, *GetMirrorStatusRequest) (*GetMirrorStatusResponse, error)
+	// Restore history of one backup, kept after the backup is deleted
+	GetBackupUsage(context.Context, *GetBackupUsageRequest) (*GetBackupUsageResponse, error)
+	// Statistics
+	GetBackupStatistics(context.Context, *GetBackupStatisticsRequest) (*GetBackupStatisticsResponse, error)
+	GetBackupFreshness(context.Context, *GetBackupFreshnessRequest) (*GetBackupFreshnessResponse, error)
+	GenerateBackupReport(context.Context, *GenerateBackupReportRequest) (*GenerateBackupReportResponse, error)
+	GetQuotaUsage(context.Context, *GetQuotaUsageRequest) (*GetQuotaUsageResponse, error)
+	ExportCatalog(context.Context, *ExportCatalogRequest) (*ExportCatalogResponse, error)
+	// Registers the backups of an attached volume; platform admin only
+	ImportCatalog(context.Context, *ImportCatalogRequest) (*ImportCatalogResponse, error)
+	// Offline bundles of the whole repository; platform admin only
+	ExportRepository(context.Context, *ExportRepositoryRequest) (*ExportRepositoryResponse, error)
+	ImportRepository(context.Context, *ImportRepositoryRequest) (*ImportRepositoryResponse, error)
+	// Target checks
+	PreflightCheck(context.Context, *PreflightCheckRequest) (*PreflightCheckResponse, error)
+	// Streams its progress, so gRPC only
+	BootstrapRestore(*BootstrapRestoreRequest, grpc.ServerStreamingServer[BootstrapRestoreEvent]) error
+	// API metadata
+	GetDescriptorSet(context.Context, *GetDescriptorSetRequest) (*GetDescriptorSetResponse, error)
+	// Single module backups by ID. Declared last because the HTTP gateway
+	// matches routes in declaration order, and /v1/backups/{id} would otherwise
+	// capture /v1/backups/full, /statistics, /freshness, /report, /quota,
+	// /catalog, /mirror, /federation, /repository and the other fixed paths
+	// above.
+	GetBackup(context.Context, *GetBackupRequest) (*GetBackupResponse, error)
+	DeleteBackup(context.Context, *DeleteBackupRequest) (*DeleteBackupResponse, error)
+	DownloadBackup(context.Context, *DownloadBackupRequest) (*DownloadBackupResponse, error)
 	mustEmbedUnimplementedBackupOrchestratorServiceServer()
 }
 
@@ -218,267 +619,788 @@ func (UnimplementedBackupOrchestratorServiceServer) RestoreModuleBackup(context.
 func (UnimplementedBackupOrchestratorServiceServer) ListBackups(context.Context, *ListBackupsRequest) (*ListBackupsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListBackups not implemented")
 }
-func (UnimplementedBackupOrchestratorServiceServer) GetBackup(context.Context, *GetBackupRequest) (*GetBackupResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method GetBackup not implemented")
-}
-func (UnimplementedBackupOrchestratorServiceServer) DeleteBackup(context.Context, *DeleteBackupRequest) (*DeleteBackupResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method DeleteBackup not implemented")
-}
-func (UnimplementedBackupOrchestratorServiceServer) DownloadBackup(context.Context, *DownloadBackupRequest) (*DownloadBackupResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method DownloadBackup not implemented")
+func (UnimplementedBackupOrchestratorServiceServer) DeleteBackups(context.Context, *DeleteBackupsRequest) (*DeleteBackupsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteBackups not implemented")
 }
 func (UnimplementedBackupOrchestratorServiceServer) CreateFullBackup(context.Context, *CreateFullBackupRequest) (*CreateFullBackupResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method CreateFullBackup not implemented")
 }
-func (UnimplementedBackupOrchestratorServiceServer) RestoreFullBackup(context.Context, *RestoreFullBackupRequest) (*RestoreFullBackupResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method RestoreFullBackup not implemented")
+func (UnimplementedBackupOrchestratorServiceServer) RestoreFullBackup(context.Context, *RestoreFullBackupRequest) (*RestoreFullBackupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RestoreFullBackup not implemented")
+}
+func (UnimplementedBackupOrchestratorServiceServer) ListFullBackups(context.Context, *ListFullBackupsRequest) (*ListFullBackupsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListFullBackups not implemented")
+}
+func (UnimplementedBackupOrchestratorServiceServer) GetFullBackup(context.Context, *GetFullBackupRequest) (*GetFullBackupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetFullBackup not implemented")
+}
+func (UnimplementedBackupOrchestratorServiceServer) UpdateFullBackup(context.Context, *UpdateFullBackupRequest) (*UpdateFullBackupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateFullBackup not implemented")
+}
+func (UnimplementedBackupOrchestratorServiceServer) DownloadFullBackup(context.Context, *DownloadFullBackupRequest) (*DownloadFullBackupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DownloadFullBackup not implemented")
+}
+func (UnimplementedBackupOrchestratorServiceServer) DeleteFullBackup(context.Context, *DeleteFullBackupRequest) (*DeleteFullBackupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteFullBackup not implemented")
+}
+func (UnimplementedBackupOrchestratorServiceServer) GeneratePresignedDownloadURL(context.Context, *GeneratePresignedDownloadURLRequest) (*GeneratePresignedDownloadURLResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GeneratePresignedDownloadURL not implemented")
+}
+func (UnimplementedBackupOrchestratorServiceServer) PinBackup(context.Context, *PinBackupRequest) (*PinBackupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PinBackup not implemented")
+}
+func (UnimplementedBackupOrchestratorServiceServer) UnpinBackup(context.Context, *UnpinBackupRequest) (*UnpinBackupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UnpinBackup not implemented")
+}
+func (UnimplementedBackupOrchestratorServiceServer) VerifyBackup(context.Context, *VerifyBackupRequest) (*VerifyBackupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method VerifyBackup not implemented")
+}
+func (UnimplementedBackupOrchestratorServiceServer) TestRestore(context.Context, *TestRestoreRequest) (*TestRestoreResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method TestRestore not implemented")
+}
+func (UnimplementedBackupOrchestratorServiceServer) RepairBackup(context.Context, *RepairBackupRequest) (*RepairBackupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RepairBackup not implemented")
+}
+func (UnimplementedBackupOrchestratorServiceServer) ReplicateBackup(context.Context, *ReplicateBackupRequest) (*ReplicateBackupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReplicateBackup not implemented")
+}
+func (UnimplementedBackupOrchestratorServiceServer) ReceiveReplica(grpc.ClientStreamingServer[ReplicaChunk, ReceiveReplicaResponse]) error {
+	return status.Error(codes.Unimplemented, "method ReceiveReplica not implemented")
+}
+func (UnimplementedBackupOrchestratorServiceServer) ListReplicas(context.Context, *ListReplicasRequest) (*ListReplicasResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListReplicas not implemented")
+}
+func (UnimplementedBackupOrchestratorServiceServer) FetchReplica(*FetchReplicaRequest, grpc.ServerStreamingServer[ReplicaChunk]) error {
+	return status.Error(codes.Unimplemented, "method FetchReplica not implemented")
+}
+func (UnimplementedBackupOrchestratorServiceServer) GetFederationStatus(context.Context, *GetFederationStatusRequest) (*GetFederationStatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetFederationStatus not implemented")
+}
+func (UnimplementedBackupOrchestratorServiceServer) GetMirrorStatus(context.Context, *GetMirrorStatusRequest) (*GetMirrorStatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetMirrorStatus not implemented")
+}
+func (UnimplementedBackupOrchestratorServiceServer) GetBackupUsage(context.Context, *GetBackupUsageRequest) (*GetBackupUsageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBackupUsage not implemented")
+}
+func (UnimplementedBackupOrchestratorServiceServer) GetBackupStatistics(context.Context, *GetBackupStatisticsRequest) (*GetBackupStatisticsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBackupStatistics not implemented")
+}
+func (UnimplementedBackupOrchestratorServiceServer) GetBackupFreshness(context.Context, *GetBackupFreshnessRequest) (*GetBackupFreshnessResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBackupFreshness not implemented")
+}
+func (UnimplementedBackupOrchestratorServiceServer) GenerateBackupReport(context.Context, *GenerateBackupReportRequest) (*GenerateBackupReportResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GenerateBackupReport not implemented")
+}
+func (UnimplementedBackupOrchestratorServiceServer) GetQuotaUsage(context.Context, *GetQuotaUsageRequest) (*GetQuotaUsageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetQuotaUsage not implemented")
+}
+func (UnimplementedBackupOrchestratorServiceServer) ExportCatalog(context.Context, *ExportCatalogRequest) (*ExportCatalogResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExportCatalog not implemented")
+}
+func (UnimplementedBackupOrchestratorServiceServer) ImportCatalog(context.Context, *ImportCatalogRequest) (*ImportCatalogResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ImportCatalog not implemented")
+}
+func (UnimplementedBackupOrchestratorServiceServer) ExportRepository(context.Context, *ExportRepositoryRequest) (*ExportRepositoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExportRepository not implemented")
+}
+func (UnimplementedBackupOrchestratorServiceServer) ImportRepository(context.Context, *ImportRepositoryRequest) (*ImportRepositoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ImportRepository not implemented")
+}
+func (UnimplementedBackupOrchestratorServiceServer) PreflightCheck(context.Context, *PreflightCheckRequest) (*PreflightCheckResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PreflightCheck not implemented")
+}
+func (UnimplementedBackupOrchestratorServiceServer) BootstrapRestore(*BootstrapRestoreRequest, grpc.ServerStreamingServer[BootstrapRestoreEvent]) error {
+	return status.Error(codes.Unimplemented, "method BootstrapRestore not implemented")
+}
+func (UnimplementedBackupOrchestratorServiceServer) GetDescriptorSet(context.Context, *GetDescriptorSetRequest) (*GetDescriptorSetResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDescriptorSet not implemented")
+}
+func (UnimplementedBackupOrchestratorServiceServer) GetBackup(context.Context, *GetBackupRequest) (*GetBackupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBackup not implemented")
+}
+func (UnimplementedBackupOrchestratorServiceServer) DeleteBackup(context.Context, *DeleteBackupRequest) (*DeleteBackupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteBackup not implemented")
+}
+func (UnimplementedBackupOrchestratorServiceServer) DownloadBackup(context.Context, *DownloadBackupRequest) (*DownloadBackupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DownloadBackup not implemented")
+}
+func (UnimplementedBackupOrchestratorServiceServer) mustEmbedUnimplementedBackupOrchestratorServiceServer() {
+}
+func (UnimplementedBackupOrchestratorServiceServer) testEmbeddedByValue() {}
+
+// UnsafeBackupOrchestratorServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BackupOrchestratorServiceServer will
+// result in compilation errors.
+type UnsafeBackupOrchestratorServiceServer interface {
+	mustEmbedUnimplementedBackupOrchestratorServiceServer()
+}
+
+func RegisterBackupOrchestratorServiceServer(s grpc.ServiceRegistrar, srv BackupOrchestratorServiceServer) {
+	// If the following call panics, it indicates UnimplementedBackupOrchestratorServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BackupOrchestratorService_ServiceDesc, srv)
+}
+
+func _BackupOrchestratorService_CreateModuleBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateModuleBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackupOrchestratorServiceServer).CreateModuleBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BackupOrchestratorService_CreateModuleBackup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackupOrchestratorServiceServer).CreateModuleBackup(ctx, req.(*CreateModuleBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackupOrchestratorService_RestoreModuleBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreModuleBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackupOrchestratorServiceServer).RestoreModuleBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BackupOrchestratorService_RestoreModuleBackup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackupOrchestratorServiceServer).RestoreModuleBackup(ctx, req.(*RestoreModuleBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackupOrchestratorService_ListBackups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBackupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackupOrchestratorServiceServer).ListBackups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BackupOrchestratorService_ListBackups_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackupOrchestratorServiceServer).ListBackups(ctx, req.(*ListBackupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackupOrchestratorService_DeleteBackups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBackupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackupOrchestratorServiceServer).DeleteBackups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BackupOrchestratorService_DeleteBackups_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackupOrchestratorServiceServer).DeleteBackups(ctx, req.(*DeleteBackupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackupOrchestratorService_CreateFullBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFullBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackupOrchestratorServiceServer).CreateFullBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BackupOrchestratorService_CreateFullBackup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackupOrchestratorServiceServer).CreateFullBackup(ctx, req.(*CreateFullBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackupOrchestratorService_RestoreFullBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreFullBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackupOrchestratorServiceServer).RestoreFullBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BackupOrchestratorService_RestoreFullBackup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackupOrchestratorServiceServer).RestoreFullBackup(ctx, req.(*RestoreFullBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackupOrchestratorService_ListFullBackups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFullBackupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackupOrchestratorServiceServer).ListFullBackups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BackupOrchestratorService_ListFullBackups_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackupOrchestratorServiceServer).ListFullBackups(ctx, req.(*ListFullBackupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackupOrchestratorService_GetFullBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFullBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackupOrchestratorServiceServer).GetFullBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BackupOrchestratorService_GetFullBackup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackupOrchestratorServiceServer).GetFullBackup(ctx, req.(*GetFullBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackupOrchestratorService_UpdateFullBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFullBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackupOrchestratorServiceServer).UpdateFullBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BackupOrchestratorService_UpdateFullBackup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackupOrchestratorServiceServer).UpdateFullBackup(ctx, req.(*UpdateFullBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackupOrchestratorService_DownloadFullBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DownloadFullBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackupOrchestratorServiceServer).DownloadFullBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BackupOrchestratorService_DownloadFullBackup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackupOrchestratorServiceServer).DownloadFullBackup(ctx, req.(*DownloadFullBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackupOrchestratorService_DeleteFullBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFullBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackupOrchestratorServiceServer).DeleteFullBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BackupOrchestratorService_DeleteFullBackup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackupOrchestratorServiceServer).DeleteFullBackup(ctx, req.(*DeleteFullBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackupOrchestratorService_GeneratePresignedDownloadURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GeneratePresignedDownloadURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackupOrchestratorServiceServer).GeneratePresignedDownloadURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BackupOrchestratorService_GeneratePresignedDownloadURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackupOrchestratorServiceServer).GeneratePresignedDownloadURL(ctx, req.(*GeneratePresignedDownloadURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackupOrchestratorService_PinBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PinBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackupOrchestratorServiceServer).PinBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BackupOrchestratorService_PinBackup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackupOrchestratorServiceServer).PinBackup(ctx, req.(*PinBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackupOrchestratorService_UnpinBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnpinBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackupOrchestratorServiceServer).UnpinBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BackupOrchestratorService_UnpinBackup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackupOrchestratorServiceServer).UnpinBackup(ctx, req.(*UnpinBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackupOrchestratorService_VerifyBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackupOrchestratorServiceServer).VerifyBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BackupOrchestratorService_VerifyBackup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackupOrchestratorServiceServer).VerifyBackup(ctx, req.(*VerifyBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackupOrchestratorService_TestRestore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TestRestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackupOrchestratorServiceServer).TestRestore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BackupOrchestratorService_TestRestore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackupOrchestratorServiceServer).TestRestore(ctx, req.(*TestRestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackupOrchestratorService_RepairBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RepairBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackupOrchestratorServiceServer).RepairBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BackupOrchestratorService_RepairBackup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackupOrchestratorServiceServer).RepairBackup(ctx, req.(*RepairBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackupOrchestratorService_ReplicateBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplicateBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackupOrchestratorServiceServer).ReplicateBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BackupOrchestratorService_ReplicateBackup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackupOrchestratorServiceServer).ReplicateBackup(ctx, req.(*ReplicateBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedBackupOrchestratorServiceServer) ListFullBackups(context.Context, *ListFullBackupsRequest) (*ListFullBackupsResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ListFullBackups not implemented")
+
+func _BackupOrchestratorService_ReceiveReplica_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BackupOrchestratorServiceServer).ReceiveReplica(&grpc.GenericServerStream[ReplicaChunk, ReceiveReplicaResponse]{ServerStream: stream})
 }
-func (UnimplementedBackupOrchestratorServiceServer) GetFullBackup(context.Context, *GetFullBackupRequest) (*GetFullBackupResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method GetFullBackup not implemented")
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BackupOrchestratorService_ReceiveReplicaServer = grpc.ClientStreamingServer[ReplicaChunk, ReceiveReplicaResponse]
+
+func _BackupOrchestratorService_ListReplicas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReplicasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackupOrchestratorServiceServer).ListReplicas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BackupOrchestratorService_ListReplicas_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackupOrchestratorServiceServer).ListReplicas(ctx, req.(*ListReplicasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedBackupOrchestratorServiceServer) DownloadFullBackup(context.Context, *DownloadFullBackupRequest) (*DownloadFullBackupResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method DownloadFullBackup not implemented")
+
+func _BackupOrchestratorService_FetchReplica_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FetchReplicaRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BackupOrchestratorServiceServer).FetchReplica(m, &grpc.GenericServerStream[FetchReplicaRequest, ReplicaChunk]{ServerStream: stream})
 }
-func (UnimplementedBackupOrchestratorServiceServer) DeleteFullBackup(context.Context, *DeleteFullBackupRequest) (*DeleteFullBackupResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method DeleteFullBackup not implemented")
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BackupOrchestratorService_FetchReplicaServer = grpc.ServerStreamingServer[ReplicaChunk]
+
+func _BackupOrchestratorService_GetFederationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFederationStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackupOrchestratorServiceServer).GetFederationStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BackupOrchestratorService_GetFederationStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackupOrchestratorServiceServer).GetFederationStatus(ctx, req.(*GetFederationStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedBackupOrchestratorServiceServer) mustEmbedUnimplementedBackupOrchestratorServiceServer() {
+
+func _BackupOrchestratorService_GetMirrorStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMirrorStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackupOrchestratorServiceServer).GetMirrorStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BackupOrchestratorService_GetMirrorStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackupOrchestratorServiceServer).GetMirrorStatus(ctx, req.(*GetMirrorStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedBackupOrchestratorServiceServer) testEmbeddedByValue() {}
 
-// UnsafeBackupOrchestratorServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to BackupOrchestratorServiceServer will
-// result in compilation errors.
-type UnsafeBackupOrchestratorServiceServer interface {
-	mustEmbedUnimplementedBackupOrchestratorServiceServer()
+func _BackupOrchestratorService_GetBackupUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBackupUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackupOrchestratorServiceServer).GetBackupUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BackupOrchestratorService_GetBackupUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackupOrchestratorServiceServer).GetBackupUsage(ctx, req.(*GetBackupUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterBackupOrchestratorServiceServer(s grpc.ServiceRegistrar, srv BackupOrchestratorServiceServer) {
-	// If the following call panics, it indicates UnimplementedBackupOrchestratorServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _BackupOrchestratorService_GetBackupStatistics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBackupStatisticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&BackupOrchestratorService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(BackupOrchestratorServiceServer).GetBackupStatistics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BackupOrchestratorService_GetBackupStatistics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackupOrchestratorServiceServer).GetBackupStatistics(ctx, req.(*GetBackupStatisticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _BackupOrchestratorService_CreateModuleBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateModuleBackupRequest)
+func _BackupOrchestratorService_GetBackupFreshness_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBackupFreshnessRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BackupOrchestratorServiceServer).CreateModuleBackup(ctx, in)
+		return srv.(BackupOrchestratorServiceServer).GetBackupFreshness(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BackupOrchestratorService_CreateModuleBackup_FullMethodName,
+		FullMethod: BackupOrchestratorService_GetBackupFreshness_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BackupOrchestratorServiceServer).CreateModuleBackup(ctx, req.(*CreateModuleBackupRequest))
+		return srv.(BackupOrchestratorServiceServer).GetBackupFreshness(ctx, req.(*GetBackupFreshnessRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BackupOrchestratorService_RestoreModuleBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RestoreModuleBackupRequest)
+func _BackupOrchestratorService_GenerateBackupReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateBackupReportRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BackupOrchestratorServiceServer).RestoreModuleBackup(ctx, in)
+		return srv.(BackupOrchestratorServiceServer).GenerateBackupReport(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BackupOrchestratorService_RestoreModuleBackup_FullMethodName,
+		FullMethod: BackupOrchestratorService_GenerateBackupReport_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BackupOrchestratorServiceServer).RestoreModuleBackup(ctx, req.(*RestoreModuleBackupRequest))
+		return srv.(BackupOrchestratorServiceServer).GenerateBackupReport(ctx, req.(*GenerateBackupReportRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BackupOrchestratorService_ListBackups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListBackupsRequest)
+func _BackupOrchestratorService_GetQuotaUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuotaUsageRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BackupOrchestratorServiceServer).ListBackups(ctx, in)
+		return srv.(BackupOrchestratorServiceServer).GetQuotaUsage(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BackupOrchestratorService_ListBackups_FullMethodName,
+		FullMethod: BackupOrchestratorService_GetQuotaUsage_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BackupOrchestratorServiceServer).ListBackups(ctx, req.(*ListBackupsRequest))
+		return srv.(BackupOrchestratorServiceServer).GetQuotaUsage(ctx, req.(*GetQuotaUsageRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BackupOrchestratorService_GetBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetBackupRequest)
+func _BackupOrchestratorService_ExportCatalog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportCatalogRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BackupOrchestratorServiceServer).GetBackup(ctx, in)
+		return srv.(BackupOrchestratorServiceServer).ExportCatalog(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BackupOrchestratorService_GetBackup_FullMethodName,
+		FullMethod: BackupOrchestratorService_ExportCatalog_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BackupOrchestratorServiceServer).GetBackup(ctx, req.(*GetBackupRequest))
+		return srv.(BackupOrchestratorServiceServer).ExportCatalog(ctx, req.(*ExportCatalogRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BackupOrchestratorService_DeleteBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteBackupRequest)
+func _BackupOrchestratorService_ImportCatalog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportCatalogRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BackupOrchestratorServiceServer).DeleteBackup(ctx, in)
+		return srv.(BackupOrchestratorServiceServer).ImportCatalog(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BackupOrchestratorService_DeleteBackup_FullMethodName,
+		FullMethod: BackupOrchestratorService_ImportCatalog_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BackupOrchestratorServiceServer).DeleteBackup(ctx, req.(*DeleteBackupRequest))
+		return srv.(BackupOrchestratorServiceServer).ImportCatalog(ctx, req.(*ImportCatalogRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BackupOrchestratorService_DownloadBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DownloadBackupRequest)
+func _BackupOrchestratorService_ExportRepository_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportRepositoryRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BackupOrchestratorServiceServer).DownloadBackup(ctx, in)
+		return srv.(BackupOrchestratorServiceServer).ExportRepository(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BackupOrchestratorService_DownloadBackup_FullMethodName,
+		FullMethod: BackupOrchestratorService_ExportRepository_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BackupOrchestratorServiceServer).DownloadBackup(ctx, req.(*DownloadBackupRequest))
+		return srv.(BackupOrchestratorServiceServer).ExportRepository(ctx, req.(*ExportRepositoryRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BackupOrchestratorService_CreateFullBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateFullBackupRequest)
+func _BackupOrchestratorService_ImportRepository_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportRepositoryRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BackupOrchestratorServiceServer).CreateFullBackup(ctx, in)
+		return srv.(BackupOrchestratorServiceServer).ImportRepository(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BackupOrchestratorService_CreateFullBackup_FullMethodName,
+		FullMethod: BackupOrchestratorService_ImportRepository_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BackupOrchestratorServiceServer).CreateFullBackup(ctx, req.(*CreateFullBackupRequest))
+		return srv.(BackupOrchestratorServiceServer).ImportRepository(ctx, req.(*ImportRepositoryRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BackupOrchestratorService_RestoreFullBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RestoreFullBackupRequest)
+func _BackupOrchestratorService_PreflightCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreflightCheckRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BackupOrchestratorServiceServer).RestoreFullBackup(ctx, in)
+		return srv.(BackupOrchestratorServiceServer).PreflightCheck(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BackupOrchestratorService_RestoreFullBackup_FullMethodName,
+		FullMethod: BackupOrchestratorService_PreflightCheck_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BackupOrchestratorServiceServer).RestoreFullBackup(ctx, req.(*RestoreFullBackupRequest))
+		return srv.(BackupOrchestratorServiceServer).PreflightCheck(ctx, req.(*PreflightCheckRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BackupOrchestratorService_ListFullBackups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListFullBackupsRequest)
+func _BackupOrchestratorService_BootstrapRestore_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BootstrapRestoreRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BackupOrchestratorServiceServer).BootstrapRestore(m, &grpc.GenericServerStream[BootstrapRestoreRequest, BootstrapRestoreEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BackupOrchestratorService_BootstrapRestoreServer = grpc.ServerStreamingServer[BootstrapRestoreEvent]
+
+func _BackupOrchestratorService_GetDescriptorSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDescriptorSetRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BackupOrchestratorServiceServer).ListFullBackups(ctx, in)
+		return srv.(BackupOrchestratorServiceServer).GetDescriptorSet(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BackupOrchestratorService_ListFullBackups_FullMethodName,
+		FullMethod: BackupOrchestratorService_GetDescriptorSet_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BackupOrchestratorServiceServer).ListFullBackups(ctx, req.(*ListFullBackupsRequest))
+		return srv.(BackupOrchestratorServiceServer).GetDescriptorSet(ctx, req.(*GetDescriptorSetRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BackupOrchestratorService_GetFullBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetFullBackupRequest)
+func _BackupOrchestratorService_GetBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBackupRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BackupOrchestratorServiceServer).GetFullBackup(ctx, in)
+		return srv.(BackupOrchestratorServiceServer).GetBackup(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BackupOrchestratorService_GetFullBackup_FullMethodName,
+		FullMethod: BackupOrchestratorService_GetBackup_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BackupOrchestratorServiceServer).GetFullBackup(ctx, req.(*GetFullBackupRequest))
+		return srv.(BackupOrchestratorServiceServer).GetBackup(ctx, req.(*GetBackupRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BackupOrchestratorService_DownloadFullBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DownloadFullBackupRequest)
+func _BackupOrchestratorService_DeleteBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBackupRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BackupOrchestratorServiceServer).DownloadFullBackup(ctx, in)
+		return srv.(BackupOrchestratorServiceServer).DeleteBackup(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BackupOrchestratorService_DownloadFullBackup_FullMethodName,
+		FullMethod: BackupOrchestratorService_DeleteBackup_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BackupOrchestratorServiceServer).DownloadFullBackup(ctx, req.(*DownloadFullBackupRequest))
+		return srv.(BackupOrchestratorServiceServer).DeleteBackup(ctx, req.(*DeleteBackupRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BackupOrchestratorService_DeleteFullBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteFullBackupRequest)
+func _BackupOrchestratorService_DownloadBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DownloadBackupRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BackupOrchestratorServiceServer).DeleteFullBackup(ctx, in)
+		return srv.(BackupOrchestratorServiceServer).DownloadBackup(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BackupOrchestratorService_DeleteFullBackup_FullMethodName,
+		FullMethod: BackupOrchestratorService_DownloadBackup_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BackupOrchestratorServiceServer).DeleteFullBackup(ctx, req.(*DeleteFullBackupRequest))
+		return srv.(BackupOrchestratorServiceServer).DownloadBackup(ctx, req.(*DownloadBackupRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -503,16 +1425,8 @@ var BackupOrchestratorService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _BackupOrchestratorService_ListBackups_Handler,
 		},
 		{
-			MethodName: "GetBackup",
-			Handler:    _BackupOrchestratorService_GetBackup_Handler,
-		},
-		{
-			MethodName: "DeleteBackup",
-			Handler:    _BackupOrchestratorService_DeleteBackup_Handler,
-		},
-		{
-			MethodName: "DownloadBackup",
-			Handler:    _BackupOrchestratorService_DownloadBackup_Handler,
+			MethodName: "DeleteBackups",
+			Handler:    _BackupOrchestratorService_DeleteBackups_Handler,
 		},
 		{
 			MethodName: "CreateFullBackup",
@@ -530,6 +1444,10 @@ var BackupOrchestratorService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetFullBackup",
 			Handler:    _BackupOrchestratorService_GetFullBackup_Handler,
 		},
+		{
+			MethodName: "UpdateFullBackup",
+			Handler:    _BackupOrchestratorService_UpdateFullBackup_Handler,
+		},
 		{
 			MethodName: "DownloadFullBackup",
 			Handler:    _BackupOrchestratorService_DownloadFullBackup_Handler,
@@ -538,7 +1456,119 @@ var BackupOrchestratorService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteFullBackup",
 			Handler:    _BackupOrchestratorService_DeleteFullBackup_Handler,
 		},
+		{
+			MethodName: "GeneratePresignedDownloadURL",
+			Handler:    _BackupOrchestratorService_GeneratePresignedDownloadURL_Handler,
+		},
+		{
+			MethodName: "PinBackup",
+			Handler:    _BackupOrchestratorService_PinBackup_Handler,
+		},
+		{
+			MethodName: "UnpinBackup",
+			Handler:    _BackupOrchestratorService_UnpinBackup_Handler,
+		},
+		{
+			MethodName: "VerifyBackup",
+			Handler:    _BackupOrchestratorService_VerifyBackup_Handler,
+		},
+		{
+			MethodName: "TestRestore",
+			Handler:    _BackupOrchestratorService_TestRestore_Handler,
+		},
+		{
+			MethodName: "RepairBackup",
+			Handler:    _BackupOrchestratorService_RepairBackup_Handler,
+		},
+		{
+			MethodName: "ReplicateBackup",
+			Handler:    _BackupOrchestratorService_ReplicateBackup_Handler,
+		},
+		{
+			MethodName: "ListReplicas",
+			Handler:    _BackupOrchestratorService_ListReplicas_Handler,
+		},
+		{
+			MethodName: "GetFederationStatus",
+			Handler:    _BackupOrchestratorService_GetFederationStatus_Handler,
+		},
+		{
+			MethodName: "GetMirrorStatus",
+			Handler:    _BackupOrchestratorService_GetMirrorStatus_Handler,
+		},
+		{
+			MethodName: "GetBackupUsage",
+			Handler:    _BackupOrchestratorService_GetBackupUsage_Handler,
+		},
+		{
+			MethodName: "GetBackupStatistics",
+			Handler:    _BackupOrchestratorService_GetBackupStatistics_Handler,
+		},
+		{
+			MethodName: "GetBackupFreshness",
+			Handler:    _BackupOrchestratorService_GetBackupFreshness_Handler,
+		},
+		{
+			MethodName: "GenerateBackupReport",
+			Handler:    _BackupOrchestratorService_GenerateBackupReport_Handler,
+		},
+		{
+			MethodName: "GetQuotaUsage",
+			Handler:    _BackupOrchestratorService_GetQuotaUsage_Handler,
+		},
+		{
+			MethodName: "ExportCatalog",
+			Handler:    _BackupOrchestratorService_ExportCatalog_Handler,
+		},
+		{
+			MethodName: "ImportCatalog",
+			Handler:    _BackupOrchestratorService_ImportCatalog_Handler,
+		},
+		{
+			MethodName: "ExportRepository",
+			Handler:    _BackupOrchestratorService_ExportRepository_Handler,
+		},
+		{
+			MethodName: "ImportRepository",
+			Handler:    _BackupOrchestratorService_ImportRepository_Handler,
+		},
+		{
+			MethodName: "PreflightCheck",
+			Handler:    _BackupOrchestratorService_PreflightCheck_Handler,
+		},
+		{
+			MethodName: "GetDescriptorSet",
+			Handler:    _BackupOrchestratorService_GetDescriptorSet_Handler,
+		},
+		{
+			MethodName: "GetBackup",
+			Handler:    _BackupOrchestratorService_GetBackup_Handler,
+		},
+		{
+			MethodName: "DeleteBackup",
+			Handler:    _BackupOrchestratorService_DeleteBackup_Handler,
+		},
+		{
+			MethodName: "DownloadBackup",
+			Handler:    _BackupOrchestratorService_DownloadBackup_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ReceiveReplica",
+			Handler:       _BackupOrchestratorService_ReceiveReplica_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "FetchReplica",
+			Handler:       _BackupOrchestratorService_FetchReplica_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "BootstrapRestore",
+			Handler:       _BackupOrchestratorService_BootstrapRestore_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "backup/service/v1/backup_orchestrator.proto",
 }
@@ -22,15 +22,38 @@ const _ = http.SupportPackageIsVersion1
 const OperationBackupOrchestratorServiceCreateFullBackup = "/backup.service.v1.BackupOrchestratorService/CreateFullBackup"
 const OperationBackupOrchestratorServiceCreateModuleBackup = "/backup.service.v1.BackupOrchestratorService/CreateModuleBackup"
 const OperationBackupOrchestratorServiceDeleteBackup = "/backup.service.v1.BackupOrchestratorService/DeleteBackup"
+const OperationBackupOrchestratorServiceDeleteBackups = "/backup.service.v1.BackupOrchestratorService/DeleteBackups"
 const OperationBackupOrchestratorServiceDeleteFullBackup = "/backup.service.v1.BackupOrchestratorService/DeleteFullBackup"
 const OperationBackupOrchestratorServiceDownloadBackup = "/backup.service.v1.BackupOrchestratorService/DownloadBackup"
 const OperationBackupOrchestratorServiceDownloadFullBackup = "/backup.service.v1.BackupOrchestratorService/DownloadFullBackup"
+const OperationBackupOrchestratorServiceExportCatalog = "/backup.service.v1.BackupOrchestratorService/ExportCatalog"
+const OperationBackupOrchestratorServiceExportRepository = "/backup.service.v1.BackupOrchestratorService/ExportRepository"
+const OperationBackupOrchestratorServiceGenerateBackupReport = "/backup.service.v1.BackupOrchestratorService/GenerateBackupReport"
+const OperationBackupOrchestratorServiceGeneratePresignedDownloadURL = "/backup.service.v1.BackupOrchestratorService/GeneratePresignedDownloadURL"
 const OperationBackupOrchestratorServiceGetBackup = "/backup.service.v1.BackupOrchestratorService/GetBackup"
+const OperationBackupOrchestratorServiceGetBackupFreshness = "/backup.service.v1.BackupOrchestratorService/GetBackupFreshness"
+const OperationBackupOrchestratorServiceGetBackupStatistics = "/backup.service.v1.BackupOrchestratorService/GetBackupStatistics"
+const OperationBackupOrchestratorServiceGetBackupUsage = "/backup.service.v1.BackupOrchestratorService/GetBackupUsage"
+const OperationBackupOrchestratorServiceGetDescriptorSet = "/backup.service.v1.BackupOrchestratorService/GetDescriptorSet"
+const OperationBackupOrchestratorServiceGetFederationStatus = "/backup.service.v1.BackupOrchestratorService/GetFederationStatus"
 const OperationBackupOrchestratorServiceGetFullBackup = "/backup.service.v1.BackupOrchestratorService/GetFullBackup"
+const OperationBackupOrchestratorServiceGetMirrorStatus = "/backup.service.v1.BackupOrchestratorService/GetMirrorStatus"
+const OperationBackupOrchestratorServiceGetQuotaUsage = "/backup.service.v1.BackupOrchestratorService/GetQuotaUsage"
+const OperationBackupOrchestratorServiceImportCatalog = "/backup.service.v1.BackupOrchestratorService/ImportCatalog"
+const OperationBackupOrchestratorServiceImportRepository = "/backup.service.v1.BackupOrchestratorService/ImportRepository"
 const OperationBackupOrchestratorServiceListBackups = "/backup.service.v1.BackupOrchestratorService/ListBackups"
 const OperationBackupOrchestratorServiceListFullBackups = "/backup.service.v1.BackupOrchestratorService/ListFullBackups"
+const OperationBackupOrchestratorServiceListReplicas = "/backup.service.v1.BackupOrchestratorService/ListReplicas"
+const OperationBackupOrchestratorServicePinBackup = "/backup.service.v1.BackupOrchestratorService/PinBackup"
+const OperationBackupOrchestratorServicePreflightCheck = "/backup.service.v1.BackupOrchestratorService/PreflightCheck"
+const OperationBackupOrchestratorServiceRepairBackup = "/backup.service.v1.BackupOrchestratorService/RepairBackup"
+const OperationBackupOrchestratorServiceReplicateBackup = "/backup.service.v1.BackupOrchestratorService/ReplicateBackup"
 const OperationBackupOrchestratorServiceRestoreFullBackup = "/backup.service.v1.BackupOrchestratorService/RestoreFullBackup"
 const OperationBackupOrchestratorServiceRestoreModuleBackup = "/backup.service.v1.BackupOrchestratorService/RestoreModuleBackup"
+const OperationBackupOrchestratorServiceTestRestore = "/backup.service.v1.BackupOrchestratorService/TestRestore"
+const OperationBackupOrchestratorServiceUnpinBackup = "/backup.service.v1.BackupOrchestratorService/UnpinBackup"
+const OperationBackupOrchestratorServiceUpdateFullBackup = "/backup.service.v1.BackupOrchestratorService/UpdateFullBackup"
+const OperationBackupOrchestratorServiceVerifyBackup = "/backup.service.v1.BackupOrchestratorService/VerifyBackup"
 
 type BackupOrchestratorServiceHTTPServer interface {
 	// CreateFullBackup Full platform operations
@@ -38,15 +61,58 @@ type BackupOrchestratorServiceHTTPServer interface {
 	// CreateModuleBackup Single module operations
 	CreateModuleBackup(context.Context, *CreateModuleBackupRequest) (*CreateModuleBackupResponse, error)
 	DeleteBackup(context.Context, *DeleteBackupRequest) (*DeleteBackupResponse, error)
+	DeleteBackups(context.Context, *DeleteBackupsRequest) (*DeleteBackupsResponse, error)
 	DeleteFullBackup(context.Context, *DeleteFullBackupRequest) (*DeleteFullBackupResponse, error)
 	DownloadBackup(context.Context, *DownloadBackupRequest) (*DownloadBackupResponse, error)
 	DownloadFullBackup(context.Context, *DownloadFullBackupRequest) (*DownloadFullBackupResponse, error)
+	ExportCatalog(context.Context, *ExportCatalogRequest) (*ExportCatalogResponse, error)
+	// ExportRepository Offline bundles of the whole repository; platform admin only
+	ExportRepository(context.Context, *ExportRepositoryRequest) (*ExportRepositoryResponse, error)
+	GenerateBackupReport(context.Context, *GenerateBackupReportRequest) (*GenerateBackupReportResponse, error)
+	// GeneratePresignedDownloadURL Download links
+	GeneratePresignedDownloadURL(context.Context, *GeneratePresignedDownloadURLRequest) (*GeneratePresignedDownloadURLResponse, error)
+	// GetBackup Single module backups by ID. Declared last because the HTTP gateway
+	// matches routes in declaration order, and /v1/backups/{id} would otherwise
+	// capture /v1/backups/full, /statistics, /freshness, /report, /quota,
+	// /catalog, /mirror, /federation, /repository and the other fixed paths
+	// above.
 	GetBackup(context.Context, *GetBackupRequest) (*GetBackupResponse, error)
+	GetBackupFreshness(context.Context, *GetBackupFreshnessRequest) (*GetBackupFreshnessResponse, error)
+	// GetBackupStatistics Statistics
+	GetBackupStatistics(context.Context, *GetBackupStatisticsRequest) (*GetBackupStatisticsResponse, error)
+	// GetBackupUsage Restore history of one backup, kept after the backup is deleted
+	GetBackupUsage(context.Context, *GetBackupUsageRequest) (*GetBackupUsageResponse, error)
+	// GetDescriptorSet API metadata
+	GetDescriptorSet(context.Context, *GetDescriptorSetRequest) (*GetDescriptorSetResponse, error)
+	// GetFederationStatus How current this DR orchestrator is with its primary
+	GetFederationStatus(context.Context, *GetFederationStatusRequest) (*GetFederationStatusResponse, error)
 	GetFullBackup(context.Context, *GetFullBackupRequest) (*GetFullBackupResponse, error)
+	// GetMirrorStatus How far the object storage mirror is behind
+	GetMirrorStatus(context.Context, *GetMirrorStatusRequest) (*GetMirrorStatusResponse, error)
+	GetQuotaUsage(context.Context, *GetQuotaUsageRequest) (*GetQuotaUsageResponse, error)
+	// ImportCatalog Registers the backups of an attached volume; platform admin only
+	ImportCatalog(context.Context, *ImportCatalogRequest) (*ImportCatalogResponse, error)
+	ImportRepository(context.Context, *ImportRepositoryRequest) (*ImportRepositoryResponse, error)
 	ListBackups(context.Context, *ListBackupsRequest) (*ListBackupsResponse, error)
 	ListFullBackups(context.Context, *ListFullBackupsRequest) (*ListFullBackupsResponse, error)
+	// ListReplicas What a DR orchestrator calls on its primary to pull backups, over mTLS
+	// only. FetchReplica streams the same messages ReceiveReplica takes.
+	ListReplicas(context.Context, *ListReplicasRequest) (*ListReplicasResponse, error)
+	// PinBackup Deletion protection; unpinning requires a platform admin
+	PinBackup(context.Context, *PinBackupRequest) (*PinBackupResponse, error)
+	// PreflightCheck Target checks
+	PreflightCheck(context.Context, *PreflightCheckRequest) (*PreflightCheckResponse, error)
+	RepairBackup(context.Context, *RepairBackupRequest) (*RepairBackupResponse, error)
+	// ReplicateBackup Off-site copies. ReplicateBackup queues a backup for replication now;
+	// ReceiveReplica is what a primary calls on the secondary, over mTLS only.
+	ReplicateBackup(context.Context, *ReplicateBackupRequest) (*ReplicateBackupResponse, error)
 	RestoreFullBackup(context.Context, *RestoreFullBackupRequest) (*RestoreFullBackupResponse, error)
 	RestoreModuleBackup(context.Context, *RestoreModuleBackupRequest) (*RestoreModuleBackupResponse, error)
+	TestRestore(context.Context, *TestRestoreRequest) (*TestRestoreResponse, error)
+	UnpinBackup(context.Context, *UnpinBackupRequest) (*UnpinBackupResponse, error)
+	UpdateFullBackup(context.Context, *UpdateFullBackupRequest) (*UpdateFullBackupResponse, error)
+	// VerifyBackup Integrity checks
+	VerifyBackup(context.Context, *VerifyBackupRequest) (*VerifyBackupResponse, error)
 }
 
 func RegisterBackupOrchestratorServiceHTTPServer(s *http.Server, srv BackupOrchestratorServiceHTTPServer) {
@@ -54,15 +120,38 @@ func RegisterBackupOrchestratorServiceHTTPServer(s *http.Server, srv BackupOrche
 	r.POST("/v1/backups/modules", _BackupOrchestratorService_CreateModuleBackup0_HTTP_Handler(srv))
 	r.POST("/v1/backups/{backup_id}/restore", _BackupOrchestratorService_RestoreModuleBackup0_HTTP_Handler(srv))
 	r.GET("/v1/backups", _BackupOrchestratorService_ListBackups0_HTTP_Handler(srv))
-	r.GET("/v1/backups/{id}", _BackupOrchestratorService_GetBackup0_HTTP_Handler(srv))
-	r.DELETE("/v1/backups/{id}", _BackupOrchestratorService_DeleteBackup0_HTTP_Handler(srv))
-	r.POST("/v1/backups/{id}/download", _BackupOrchestratorService_DownloadBackup0_HTTP_Handler(srv))
+	r.POST("/v1/backups/delete", _BackupOrchestratorService_DeleteBackups0_HTTP_Handler(srv))
 	r.POST("/v1/backups/full", _BackupOrchestratorService_CreateFullBackup0_HTTP_Handler(srv))
 	r.POST("/v1/backups/full/{backup_id}/restore", _BackupOrchestratorService_RestoreFullBackup0_HTTP_Handler(srv))
 	r.GET("/v1/backups/full", _BackupOrchestratorService_ListFullBackups0_HTTP_Handler(srv))
 	r.GET("/v1/backups/full/{id}", _BackupOrchestratorService_GetFullBackup0_HTTP_Handler(srv))
+	r.PATCH("/v1/backups/full/{id}", _BackupOrchestratorService_UpdateFullBackup0_HTTP_Handler(srv))
 	r.POST("/v1/backups/full/{id}/download", _BackupOrchestratorService_DownloadFullBackup0_HTTP_Handler(srv))
 	r.DELETE("/v1/backups/full/{id}", _BackupOrchestratorService_DeleteFullBackup0_HTTP_Handler(srv))
+	r.POST("/v1/backups/{backup_id}/presign", _BackupOrchestratorService_GeneratePresignedDownloadURL0_HTTP_Handler(srv))
+	r.POST("/v1/backups/{backup_id}/pin", _BackupOrchestratorService_PinBackup0_HTTP_Handler(srv))
+	r.POST("/v1/backups/{backup_id}/unpin", _BackupOrchestratorService_UnpinBackup0_HTTP_Handler(srv))
+	r.POST("/v1/backups/{backup_id}/verify", _BackupOrchestratorService_VerifyBackup0_HTTP_Handler(srv))
+	r.POST("/v1/backups/{backup_id}/test-restore", _BackupOrchestratorService_TestRestore0_HTTP_Handler(srv))
+	r.POST("/v1/backups/{backup_id}/repair", _BackupOrchestratorService_RepairBackup0_HTTP_Handler(srv))
+	r.POST("/v1/backups/{backup_id}/replicate", _BackupOrchestratorService_ReplicateBackup0_HTTP_Handler(srv))
+	r.POST("/backup.service.v1.BackupOrchestratorService/ListReplicas", _BackupOrchestratorService_ListReplicas0_HTTP_Handler(srv))
+	r.GET("/v1/backups/federation", _BackupOrchestratorService_GetFederationStatus0_HTTP_Handler(srv))
+	r.GET("/v1/backups/mirror", _BackupOrchestratorService_GetMirrorStatus0_HTTP_Handler(srv))
+	r.GET("/v1/backups/{backup_id}/usage", _BackupOrchestratorService_GetBackupUsage0_HTTP_Handler(srv))
+	r.GET("/v1/backups/statistics", _BackupOrchestratorService_GetBackupStatistics0_HTTP_Handler(srv))
+	r.GET("/v1/backups/freshness", _BackupOrchestratorService_GetBackupFreshness0_HTTP_Handler(srv))
+	r.GET("/v1/backups/report", _BackupOrchestratorService_GenerateBackupReport0_HTTP_Handler(srv))
+	r.GET("/v1/backups/quota", _BackupOrchestratorService_GetQuotaUsage0_HTTP_Handler(srv))
+	r.GET("/v1/backups/catalog", _BackupOrchestratorService_ExportCatalog0_HTTP_Handler(srv))
+	r.POST("/v1/backups/catalog/import", _BackupOrchestratorService_ImportCatalog0_HTTP_Handler(srv))
+	r.POST("/v1/backups/repository/export", _BackupOrchestratorService_ExportRepository0_HTTP_Handler(srv))
+	r.POST("/v1/backups/repository/import", _BackupOrchestratorService_ImportRepository0_HTTP_Handler(srv))
+	r.POST("/v1/backups/preflight", _BackupOrchestratorService_PreflightCheck0_HTTP_Handler(srv))
+	r.GET("/v1/backups/descriptor", _BackupOrchestratorService_GetDescriptorSet0_HTTP_Handler(srv))
+	r.GET("/v1/backups/{id}", _BackupOrchestratorService_GetBackup0_HTTP_Handler(srv))
+	r.DELETE("/v1/backups/{id}", _BackupOrchestratorService_DeleteBackup0_HTTP_Handler(srv))
+	r.POST("/v1/backups/{id}/download", _BackupOrchestratorService_DownloadBackup0_HTTP_Handler(srv))
 }
 
 func _BackupOrchestratorService_CreateModuleBackup0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
@@ -131,53 +220,119 @@ func _BackupOrchestratorService_ListBackups0_HTTP_Handler(srv BackupOrchestrator
 	}
 }
 
-func _BackupOrchestratorService_GetBackup0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
+func _BackupOrchestratorService_DeleteBackups0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
 	return func(ctx http.Context) error {
-		var in GetBackupRequest
+		var in DeleteBackupsRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationBackupOrchestratorServiceDeleteBackups)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.DeleteBackups(ctx, req.(*DeleteBackupsRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*DeleteBackupsResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _BackupOrchestratorService_CreateFullBackup0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in CreateFullBackupRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationBackupOrchestratorServiceCreateFullBackup)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.CreateFullBackup(ctx, req.(*CreateFullBackupRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*CreateFullBackupResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _BackupOrchestratorService_RestoreFullBackup0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in RestoreFullBackupRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
 		if err := ctx.BindQuery(&in); err != nil {
 			return err
 		}
 		if err := ctx.BindVars(&in); err != nil {
 			return err
 		}
-		http.SetOperation(ctx, OperationBackupOrchestratorServiceGetBackup)
+		http.SetOperation(ctx, OperationBackupOrchestratorServiceRestoreFullBackup)
 		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
-			return srv.GetBackup(ctx, req.(*GetBackupRequest))
+			return srv.RestoreFullBackup(ctx, req.(*RestoreFullBackupRequest))
 		})
 		out, err := h(ctx, &in)
 		if err != nil {
 			return err
 		}
-		reply := out.(*GetBackupResponse)
+		reply := out.(*RestoreFullBackupResponse)
 		return ctx.Result(200, reply)
 	}
 }
 
-func _BackupOrchestratorService_DeleteBackup0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
+func _BackupOrchestratorService_ListFullBackups0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
 	return func(ctx http.Context) error {
-		var in DeleteBackupRequest
+		var in ListFullBackupsRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationBackupOrchestratorServiceListFullBackups)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListFullBackups(ctx, req.(*ListFullBackupsRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListFullBackupsResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _BackupOrchestratorService_GetFullBackup0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetFullBackupRequest
 		if err := ctx.BindQuery(&in); err != nil {
 			return err
 		}
 		if err := ctx.BindVars(&in); err != nil {
 			return err
 		}
-		http.SetOperation(ctx, OperationBackupOrchestratorServiceDeleteBackup)
+		http.SetOperation(ctx, OperationBackupOrchestratorServiceGetFullBackup)
 		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
-			return srv.DeleteBackup(ctx, req.(*DeleteBackupRequest))
+			return srv.GetFullBackup(ctx, req.(*GetFullBackupRequest))
 		})
 		out, err := h(ctx, &in)
 		if err != nil {
 			return err
 		}
-		reply := out.(*DeleteBackupResponse)
+		reply := out.(*GetFullBackupResponse)
 		return ctx.Result(200, reply)
 	}
 }
 
-func _BackupOrchestratorService_DownloadBackup0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
+func _BackupOrchestratorService_UpdateFullBackup0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
 	return func(ctx http.Context) error {
-		var in DownloadBackupRequest
+		var in UpdateFullBackupRequest
 		if err := ctx.Bind(&in); err != nil {
 			return err
 		}
@@ -187,150 +342,587 @@ func _BackupOrchestratorService_DownloadBackup0_HTTP_Handler(srv BackupOrchestra
 		if err := ctx.BindVars(&in); err != nil {
 			return err
 		}
-		http.SetOperation(ctx, OperationBackupOrchestratorServiceDownloadBackup)
+		http.SetOperation(ctx, OperationBackupOrchestratorServiceUpdateFullBackup)
 		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
-			return srv.DownloadBackup(ctx, req.(*DownloadBackupRequest))
+			return srv.UpdateFullBackup(ctx, req.(*UpdateFullBackupRequest))
 		})
 		out, err := h(ctx, &in)
 		if err != nil {
 			return err
 		}
-		reply := out.(*DownloadBackupResponse)
+		reply := out.(*UpdateFullBackupResponse)
 		return ctx.Result(200, reply)
 	}
 }
 
-func _BackupOrchestratorService_CreateFullBackup0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
+func _BackupOrchestratorService_DownloadFullBackup0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
 	return func(ctx http.Context) error {
-		var in CreateFullBackupRequest
+		var in DownloadFullBackupRequest
 		if err := ctx.Bind(&in); err != nil {
 			return err
 		}
 		if err := ctx.BindQuery(&in); err != nil {
 			return err
 		}
-		http.SetOperation(ctx, OperationBackupOrchestratorServiceCreateFullBackup)
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationBackupOrchestratorServiceDownloadFullBackup)
 		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
-			return srv.CreateFullBackup(ctx, req.(*CreateFullBackupRequest))
+			return srv.DownloadFullBackup(ctx, req.(*DownloadFullBackupRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*DownloadFullBackupResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _BackupOrchestratorService_DeleteFullBackup0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in DeleteFullBackupRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationBackupOrchestratorServiceDeleteFullBackup)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.DeleteFullBackup(ctx, req.(*DeleteFullBackupRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*DeleteFullBackupResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _BackupOrchestratorService_GeneratePresignedDownloadURL0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GeneratePresignedDownloadURLRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationBackupOrchestratorServiceGeneratePresignedDownloadURL)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GeneratePresignedDownloadURL(ctx, req.(*GeneratePresignedDownloadURLRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*GeneratePresignedDownloadURLResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _BackupOrchestratorService_PinBackup0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in PinBackupRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationBackupOrchestratorServicePinBackup)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.PinBackup(ctx, req.(*PinBackupRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*PinBackupResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _BackupOrchestratorService_UnpinBackup0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in UnpinBackupRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationBackupOrchestratorServiceUnpinBackup)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.UnpinBackup(ctx, req.(*UnpinBackupRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*UnpinBackupResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _BackupOrchestratorService_VerifyBackup0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in VerifyBackupRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationBackupOrchestratorServiceVerifyBackup)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.VerifyBackup(ctx, req.(*VerifyBackupRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*VerifyBackupResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _BackupOrchestratorService_TestRestore0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in TestRestoreRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationBackupOrchestratorServiceTestRestore)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.TestRestore(ctx, req.(*TestRestoreRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*TestRestoreResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _BackupOrchestratorService_RepairBackup0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in RepairBackupRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationBackupOrchestratorServiceRepairBackup)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.RepairBackup(ctx, req.(*RepairBackupRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*RepairBackupResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _BackupOrchestratorService_ReplicateBackup0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ReplicateBackupRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationBackupOrchestratorServiceReplicateBackup)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ReplicateBackup(ctx, req.(*ReplicateBackupRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ReplicateBackupResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _BackupOrchestratorService_ListReplicas0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListReplicasRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationBackupOrchestratorServiceListReplicas)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListReplicas(ctx, req.(*ListReplicasRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListReplicasResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _BackupOrchestratorService_GetFederationStatus0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetFederationStatusRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationBackupOrchestratorServiceGetFederationStatus)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetFederationStatus(ctx, req.(*GetFederationStatusRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*GetFederationStatusResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _BackupOrchestratorService_GetMirrorStatus0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetMirrorStatusRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationBackupOrchestratorServiceGetMirrorStatus)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetMirrorStatus(ctx, req.(*GetMirrorStatusRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*GetMirrorStatusResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _BackupOrchestratorService_GetBackupUsage0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetBackupUsageRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationBackupOrchestratorServiceGetBackupUsage)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetBackupUsage(ctx, req.(*GetBackupUsageRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*GetBackupUsageResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _BackupOrchestratorService_GetBackupStatistics0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetBackupStatisticsRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationBackupOrchestratorServiceGetBackupStatistics)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetBackupStatistics(ctx, req.(*GetBackupStatisticsRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*GetBackupStatisticsResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _BackupOrchestratorService_GetBackupFreshness0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetBackupFreshnessRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationBackupOrchestratorServiceGetBackupFreshness)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetBackupFreshness(ctx, req.(*GetBackupFreshnessRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*GetBackupFreshnessResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _BackupOrchestratorService_GenerateBackupReport0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GenerateBackupReportRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationBackupOrchestratorServiceGenerateBackupReport)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GenerateBackupReport(ctx, req.(*GenerateBackupReportRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*GenerateBackupReportResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _BackupOrchestratorService_GetQuotaUsage0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetQuotaUsageRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationBackupOrchestratorServiceGetQuotaUsage)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetQuotaUsage(ctx, req.(*GetQuotaUsageRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*GetQuotaUsageResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _BackupOrchestratorService_ExportCatalog0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ExportCatalogRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationBackupOrchestratorServiceExportCatalog)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ExportCatalog(ctx, req.(*ExportCatalogRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ExportCatalogResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _BackupOrchestratorService_ImportCatalog0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ImportCatalogRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationBackupOrchestratorServiceImportCatalog)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ImportCatalog(ctx, req.(*ImportCatalogRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ImportCatalogResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _BackupOrchestratorService_ExportRepository0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ExportRepositoryRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationBackupOrchestratorServiceExportRepository)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ExportRepository(ctx, req.(*ExportRepositoryRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ExportRepositoryResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _BackupOrchestratorService_ImportRepository0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ImportRepositoryRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationBackupOrchestratorServiceImportRepository)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ImportRepository(ctx, req.(*ImportRepositoryRequest))
 		})
 		out, err := h(ctx, &in)
 		if err != nil {
 			return err
 		}
-		reply := out.(*CreateFullBackupResponse)
+		reply := out.(*ImportRepositoryResponse)
 		return ctx.Result(200, reply)
 	}
 }
 
-func _BackupOrchestratorService_RestoreFullBackup0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
+func _BackupOrchestratorService_PreflightCheck0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
 	return func(ctx http.Context) error {
-		var in RestoreFullBackupRequest
+		var in PreflightCheckRequest
 		if err := ctx.Bind(&in); err != nil {
 			return err
 		}
 		if err := ctx.BindQuery(&in); err != nil {
 			return err
 		}
-		if err := ctx.BindVars(&in); err != nil {
-			return err
-		}
-		http.SetOperation(ctx, OperationBackupOrchestratorServiceRestoreFullBackup)
+		http.SetOperation(ctx, OperationBackupOrchestratorServicePreflightCheck)
 		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
-			return srv.RestoreFullBackup(ctx, req.(*RestoreFullBackupRequest))
+			return srv.PreflightCheck(ctx, req.(*PreflightCheckRequest))
 		})
 		out, err := h(ctx, &in)
 		if err != nil {
 			return err
 		}
-		reply := out.(*RestoreFullBackupResponse)
+		reply := out.(*PreflightCheckResponse)
 		return ctx.Result(200, reply)
 	}
 }
 
-func _BackupOrchestratorService_ListFullBackups0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
+func _BackupOrchestratorService_GetDescriptorSet0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
 	return func(ctx http.Context) error {
-		var in ListFullBackupsRequest
+		var in GetDescriptorSetRequest
 		if err := ctx.BindQuery(&in); err != nil {
 			return err
 		}
-		http.SetOperation(ctx, OperationBackupOrchestratorServiceListFullBackups)
+		http.SetOperation(ctx, OperationBackupOrchestratorServiceGetDescriptorSet)
 		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
-			return srv.ListFullBackups(ctx, req.(*ListFullBackupsRequest))
+			return srv.GetDescriptorSet(ctx, req.(*GetDescriptorSetRequest))
 		})
 		out, err := h(ctx, &in)
 		if err != nil {
 			return err
 		}
-		reply := out.(*ListFullBackupsResponse)
+		reply := out.(*GetDescriptorSetResponse)
 		return ctx.Result(200, reply)
 	}
 }
 
-func _BackupOrchestratorService_GetFullBackup0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
+func _BackupOrchestratorService_GetBackup0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
 	return func(ctx http.Context) error {
-		var in GetFullBackupRequest
+		var in GetBackupRequest
 		if err := ctx.BindQuery(&in); err != nil {
 			return err
 		}
 		if err := ctx.BindVars(&in); err != nil {
 			return err
 		}
-		http.SetOperation(ctx, OperationBackupOrchestratorServiceGetFullBackup)
+		http.SetOperation(ctx, OperationBackupOrchestratorServiceGetBackup)
 		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
-			return srv.GetFullBackup(ctx, req.(*GetFullBackupRequest))
+			return srv.GetBackup(ctx, req.(*GetBackupRequest))
 		})
 		out, err := h(ctx, &in)
 		if err != nil {
 			return err
 		}
-		reply := out.(*GetFullBackupResponse)
+		reply := out.(*GetBackupResponse)
 		return ctx.Result(200, reply)
 	}
 }
 
-func _BackupOrchestratorService_DownloadFullBackup0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
+func _BackupOrchestratorService_DeleteBackup0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
 	return func(ctx http.Context) error {
-		var in DownloadFullBackupRequest
-		if err := ctx.Bind(&in); err != nil {
-			return err
-		}
+		var in DeleteBackupRequest
 		if err := ctx.BindQuery(&in); err != nil {
 			return err
 		}
 		if err := ctx.BindVars(&in); err != nil {
 			return err
 		}
-		http.SetOperation(ctx, OperationBackupOrchestratorServiceDownloadFullBackup)
+		http.SetOperation(ctx, OperationBackupOrchestratorServiceDeleteBackup)
 		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
-			return srv.DownloadFullBackup(ctx, req.(*DownloadFullBackupRequest))
+			return srv.DeleteBackup(ctx, req.(*DeleteBackupRequest))
 		})
 		out, err := h(ctx, &in)
 		if err != nil {
 			return err
 		}
-		reply := out.(*DownloadFullBackupResponse)
+		reply := out.(*DeleteBackupResponse)
 		return ctx.Result(200, reply)
 	}
 }
 
-func _BackupOrchestratorService_DeleteFullBackup0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
+func _BackupOrchestratorService_DownloadBackup0_HTTP_Handler(srv BackupOrchestratorServiceHTTPServer) func(ctx http.Context) error {
 	return func(ctx http.Context) error {
-		var in DeleteFullBackupRequest
+		var in DownloadBackupRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
 		if err := ctx.BindQuery(&in); err != nil {
 			return err
 		}
 		if err := ctx.BindVars(&in); err != nil {
 			return err
 		}
-		http.SetOperation(ctx, OperationBackupOrchestratorServiceDeleteFullBackup)
+		http.SetOperation(ctx, OperationBackupOrchestratorServiceDownloadBackup)
 		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
-			return srv.DeleteFullBackup(ctx, req.(*DeleteFullBackupRequest))
+			return srv.DownloadBackup(ctx, req.(*DownloadBackupRequest))
 		})
 		out, err := h(ctx, &in)
 		if err != nil {
 			return err
 		}
-		reply := out.(*DeleteFullBackupResponse)
+		reply := out.(*DownloadBackupResponse)
 		return ctx.Result(200, reply)
 	}
 }
@@ -341,15 +933,58 @@ type BackupOrchestratorServiceHTTPClient interface {
 	// CreateModuleBackup Single module operations
 	CreateModuleBackup(ctx context.Context, req *CreateModuleBackupRequest, opts ...http.CallOption) (rsp *CreateModuleBackupResponse, err error)
 	DeleteBackup(ctx context.Context, req *DeleteBackupRequest, opts ...http.CallOption) (rsp *DeleteBackupResponse, err error)
+	DeleteBackups(ctx context.Context, req *DeleteBackupsRequest, opts ...http.CallOption) (rsp *DeleteBackupsResponse, err error)
 	DeleteFullBackup(ctx context.Context, req *DeleteFullBackupRequest, opts ...http.CallOption) (rsp *DeleteFullBackupResponse, err error)
 	DownloadBackup(ctx context.Context, req *DownloadBackupRequest, opts ...http.CallOption) (rsp *DownloadBackupResponse, err error)
 	DownloadFullBackup(ctx context.Context, req *DownloadFullBackupRequest, opts ...http.CallOption) (rsp *DownloadFullBackupResponse, err error)
+	ExportCatalog(ctx context.Context, req *ExportCatalogRequest, opts ...http.CallOption) (rsp *ExportCatalogResponse, err error)
+	// ExportRepository Offline bundles of the whole repository; platform admin only
+	ExportRepository(ctx context.Context, req *ExportRepositoryRequest, opts ...http.CallOption) (rsp *ExportRepositoryResponse, err error)
+	GenerateBackupReport(ctx context.Context, req *GenerateBackupReportRequest, opts ...http.CallOption) (rsp *GenerateBackupReportResponse, err error)
+	// GeneratePresignedDownloadURL Download links
+	GeneratePresignedDownloadURL(ctx context.Context, req *GeneratePresignedDownloadURLRequest, opts ...http.CallOption) (rsp *GeneratePresignedDownloadURLResponse, err error)
+	// GetBackup Single module backups by ID. Declared last because the HTTP gateway
+	// matches routes in declaration order, and /v1/backups/{id} would otherwise
+	// capture /v1/backups/full, /statistics, /freshness, /report, /quota,
+	// /catalog, /mirror, /federation, /repository and the other fixed paths
+	// above.
 	GetBackup(ctx context.Context, req *GetBackupRequest, opts ...http.CallOption) (rsp *GetBackupResponse, err error)
+	GetBackupFreshness(ctx context.Context, req *GetBackupFreshnessRequest, opts ...http.CallOption) (rsp *GetBackupFreshnessResponse, err error)
+	// GetBackupStatistics Statistics
+	GetBackupStatistics(ctx context.Context, req *GetBackupStatisticsRequest, opts ...http.CallOption) (rsp *GetBackupStatisticsResponse, err error)
+	// GetBackupUsage Restore history of one backup, kept after the backup is deleted
+	GetBackupUsage(ctx context.Context, req *GetBackupUsageRequest, opts ...http.CallOption) (rsp *GetBackupUsageResponse, err error)
+	// GetDescriptorSet API metadata
+	GetDescriptorSet(ctx context.Context, req *GetDescriptorSetRequest, opts ...http.CallOption) (rsp *GetDescriptorSetResponse, err error)
+	// GetFederationStatus How current this DR orchestrator is with its primary
+	GetFederationStatus(ctx context.Context, req *GetFederationStatusRequest, opts ...http.CallOption) (rsp *GetFederationStatusResponse, err error)
 	GetFullBackup(ctx context.Context, req *GetFullBackupRequest, opts ...http.CallOption) (rsp *GetFullBackupResponse, err error)
+	// GetMirrorStatus How far the object storage mirror is behind
+	GetMirrorStatus(ctx context.Context, req *GetMirrorStatusRequest, opts ...http.CallOption) (rsp *GetMirrorStatusResponse, err error)
+	GetQuotaUsage(ctx context.Context, req *GetQuotaUsageRequest, opts ...http.CallOption) (rsp *GetQuotaUsageResponse, err error)
+	// ImportCatalog Registers the backups of an attached volume; platform admin only
+	ImportCatalog(ctx context.Context, req *ImportCatalogRequest, opts ...http.CallOption) (rsp *ImportCatalogResponse, err error)
+	ImportRepository(ctx context.Context, req *ImportRepositoryRequest, opts ...http.CallOption) (rsp *ImportRepositoryResponse, err error)
 	ListBackups(ctx context.Context, req *ListBackupsRequest, opts ...http.CallOption) (rsp *ListBackupsResponse, err error)
 	ListFullBackups(ctx context.Context, req *ListFullBackupsRequest, opts ...http.CallOption) (rsp *ListFullBackupsResponse, err error)
+	// ListReplicas What a DR orchestrator calls on its primary to pull backups, over mTLS
+	// only. FetchReplica streams the same messages ReceiveReplica takes.
+	ListReplicas(ctx context.Context, req *ListReplicasRequest, opts ...http.CallOption) (rsp *ListReplicasResponse, err error)
+	// PinBackup Deletion protection; unpinning requires a platform admin
+	PinBackup(ctx context.Context, req *PinBackupRequest, opts ...http.CallOption) (rsp *PinBackupResponse, err error)
+	// PreflightCheck Target checks
+	PreflightCheck(ctx context.Context, req *PreflightCheckRequest, opts ...http.CallOption) (rsp *PreflightCheckResponse, err error)
+	RepairBackup(ctx context.Context, req *RepairBackupRequest, opts ...http.CallOption) (rsp *RepairBackupResponse, err error)
+	// ReplicateBackup Off-site copies. ReplicateBackup queues a backup for replication now;
+	// ReceiveReplica is what a primary calls on the secondary, over mTLS only.
+	ReplicateBackup(ctx context.Context, req *ReplicateBackupRequest, opts ...http.CallOption) (rsp *ReplicateBackupResponse, err error)
 	RestoreFullBackup(ctx context.Context, req *RestoreFullBackupRequest, opts ...http.CallOption) (rsp *RestoreFullBackupResponse, err error)
 	RestoreModuleBackup(ctx context.Context, req *RestoreModuleBackupRequest, opts ...http.CallOption) (rsp *RestoreModuleBackupResponse, err error)
+	TestRestore(ctx context.Context, req *TestRestoreRequest, opts ...http.CallOption) (rsp *TestRestoreResponse, err error)
+	UnpinBackup(ctx context.Context, req *UnpinBackupRequest, opts ...http.CallOption) (rsp *UnpinBackupResponse, err error)
+	UpdateFullBackup(ctx context.Context, req *UpdateFullBackupRequest, opts ...http.CallOption) (rsp *UpdateFullBackupResponse, err error)
+	// VerifyBackup Integrity checks
+	VerifyBackup(ctx context.Context, req *VerifyBackupRequest, opts ...http.CallOption) (rsp *VerifyBackupResponse, err error)
 }
 
 type BackupOrchestratorServiceHTTPClientImpl struct {
@@ -401,6 +1036,19 @@ func (c *BackupOrchestratorServiceHTTPClientImpl) DeleteBackup(ctx context.Conte
 	return &out, nil
 }
 
+func (c *BackupOrchestratorServiceHTTPClientImpl) DeleteBackups(ctx context.Context, in *DeleteBackupsRequest, opts ...http.CallOption) (*DeleteBackupsResponse, error) {
+	var out DeleteBackupsResponse
+	pattern := "/v1/backups/delete"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationBackupOrchestratorServiceDeleteBackups))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *BackupOrchestratorServiceHTTPClientImpl) DeleteFullBackup(ctx context.Context, in *DeleteFullBackupRequest, opts ...http.CallOption) (*DeleteFullBackupResponse, error) {
 	var out DeleteFullBackupResponse
 	pattern := "/v1/backups/full/{id}"
@@ -440,6 +1088,65 @@ func (c *BackupOrchestratorServiceHTTPClientImpl) DownloadFullBackup(ctx context
 	return &out, nil
 }
 
+func (c *BackupOrchestratorServiceHTTPClientImpl) ExportCatalog(ctx context.Context, in *ExportCatalogRequest, opts ...http.CallOption) (*ExportCatalogResponse, error) {
+	var out ExportCatalogResponse
+	pattern := "/v1/backups/catalog"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationBackupOrchestratorServiceExportCatalog))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ExportRepository Offline bundles of the whole repository; platform admin only
+func (c *BackupOrchestratorServiceHTTPClientImpl) ExportRepository(ctx context.Context, in *ExportRepositoryRequest, opts ...http.CallOption) (*ExportRepositoryResponse, error) {
+	var out ExportRepositoryResponse
+	pattern := "/v1/backups/repository/export"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationBackupOrchestratorServiceExportRepository))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *BackupOrchestratorServiceHTTPClientImpl) GenerateBackupReport(ctx context.Context, in *GenerateBackupReportRequest, opts ...http.CallOption) (*GenerateBackupReportResponse, error) {
+	var out GenerateBackupReportResponse
+	pattern := "/v1/backups/report"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationBackupOrchestratorServiceGenerateBackupReport))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GeneratePresignedDownloadURL Download links
+func (c *BackupOrchestratorServiceHTTPClientImpl) GeneratePresignedDownloadURL(ctx context.Context, in *GeneratePresignedDownloadURLRequest, opts ...http.CallOption) (*GeneratePresignedDownloadURLResponse, error) {
+	var out GeneratePresignedDownloadURLResponse
+	pattern := "/v1/backups/{backup_id}/presign"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationBackupOrchestratorServiceGeneratePresignedDownloadURL))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetBackup Single module backups by ID. Declared last because the HTTP gateway
+// matches routes in declaration order, and /v1/backups/{id} would otherwise
+// capture /v1/backups/full, /statistics, /freshness, /report, /quota,
+// /catalog, /mirror, /federation, /repository and the other fixed paths
+// above.
 func (c *BackupOrchestratorServiceHTTPClientImpl) GetBackup(ctx context.Context, in *GetBackupRequest, opts ...http.CallOption) (*GetBackupResponse, error) {
 	var out GetBackupResponse
 	pattern := "/v1/backups/{id}"
@@ -453,6 +1160,75 @@ func (c *BackupOrchestratorServiceHTTPClientImpl) GetBackup(ctx context.Context,
 	return &out, nil
 }
 
+func (c *BackupOrchestratorServiceHTTPClientImpl) GetBackupFreshness(ctx context.Context, in *GetBackupFreshnessRequest, opts ...http.CallOption) (*GetBackupFreshnessResponse, error) {
+	var out GetBackupFreshnessResponse
+	pattern := "/v1/backups/freshness"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationBackupOrchestratorServiceGetBackupFreshness))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetBackupStatistics Statistics
+func (c *BackupOrchestratorServiceHTTPClientImpl) GetBackupStatistics(ctx context.Context, in *GetBackupStatisticsRequest, opts ...http.CallOption) (*GetBackupStatisticsResponse, error) {
+	var out GetBackupStatisticsResponse
+	pattern := "/v1/backups/statistics"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationBackupOrchestratorServiceGetBackupStatistics))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetBackupUsage Restore history of one backup, kept after the backup is deleted
+func (c *BackupOrchestratorServiceHTTPClientImpl) GetBackupUsage(ctx context.Context, in *GetBackupUsageRequest, opts ...http.CallOption) (*GetBackupUsageResponse, error) {
+	var out GetBackupUsageResponse
+	pattern := "/v1/backups/{backup_id}/usage"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationBackupOrchestratorServiceGetBackupUsage))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetDescriptorSet API metadata
+func (c *BackupOrchestratorServiceHTTPClientImpl) GetDescriptorSet(ctx context.Context, in *GetDescriptorSetRequest, opts ...http.CallOption) (*GetDescriptorSetResponse, error) {
+	var out GetDescriptorSetResponse
+	pattern := "/v1/backups/descriptor"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationBackupOrchestratorServiceGetDescriptorSet))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetFederationStatus How current this DR orchestrator is with its primary
+func (c *BackupOrchestratorServiceHTTPClientImpl) GetFederationStatus(ctx context.Context, in *GetFederationStatusRequest, opts ...http.CallOption) (*GetFederationStatusResponse, error) {
+	var out GetFederationStatusResponse
+	pattern := "/v1/backups/federation"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationBackupOrchestratorServiceGetFederationStatus))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *BackupOrchestratorServiceHTTPClientImpl) GetFullBackup(ctx context.Context, in *GetFullBackupRequest, opts ...http.CallOption) (*GetFullBackupResponse, error) {
 	var out GetFullBackupResponse
 	pattern := "/v1/backups/full/{id}"
@@ -466,6 +1242,60 @@ func (c *BackupOrchestratorServiceHTTPClientImpl) GetFullBackup(ctx context.Cont
 	return &out, nil
 }
 
+// GetMirrorStatus How far the object storage mirror is behind
+func (c *BackupOrchestratorServiceHTTPClientImpl) GetMirrorStatus(ctx context.Context, in *GetMirrorStatusRequest, opts ...http.CallOption) (*GetMirrorStatusResponse, error) {
+	var out GetMirrorStatusResponse
+	pattern := "/v1/backups/mirror"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationBackupOrchestratorServiceGetMirrorStatus))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *BackupOrchestratorServiceHTTPClientImpl) GetQuotaUsage(ctx context.Context, in *GetQuotaUsageRequest, opts ...http.CallOption) (*GetQuotaUsageResponse, error) {
+	var out GetQuotaUsageResponse
+	pattern := "/v1/backups/quota"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationBackupOrchestratorServiceGetQuotaUsage))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ImportCatalog Registers the backups of an attached volume; platform admin only
+func (c *BackupOrchestratorServiceHTTPClientImpl) ImportCatalog(ctx context.Context, in *ImportCatalogRequest, opts ...http.CallOption) (*ImportCatalogResponse, error) {
+	var out ImportCatalogResponse
+	pattern := "/v1/backups/catalog/import"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationBackupOrchestratorServiceImportCatalog))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *BackupOrchestratorServiceHTTPClientImpl) ImportRepository(ctx context.Context, in *ImportRepositoryRequest, opts ...http.CallOption) (*ImportRepositoryResponse, error) {
+	var out ImportRepositoryResponse
+	pattern := "/v1/backups/repository/import"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationBackupOrchestratorServiceImportRepository))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *BackupOrchestratorServiceHTTPClientImpl) ListBackups(ctx context.Context, in *ListBackupsRequest, opts ...http.CallOption) (*ListBackupsResponse, error) {
 	var out ListBackupsResponse
 	pattern := "/v1/backups"
@@ -492,6 +1322,77 @@ func (c *BackupOrchestratorServiceHTTPClientImpl) ListFullBackups(ctx context.Co
 	return &out, nil
 }
 
+// ListReplicas What a DR orchestrator calls on its primary to pull backups, over mTLS
+// only. FetchReplica streams the same messages ReceiveReplica takes.
+func (c *BackupOrchestratorServiceHTTPClientImpl) ListReplicas(ctx context.Context, in *ListReplicasRequest, opts ...http.CallOption) (*ListReplicasResponse, error) {
+	var out ListReplicasResponse
+	pattern := "/backup.service.v1.BackupOrchestratorService/ListReplicas"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationBackupOrchestratorServiceListReplicas))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PinBackup Deletion protection; unpinning requires a platform admin
+func (c *BackupOrchestratorServiceHTTPClientImpl) PinBackup(ctx context.Context, in *PinBackupRequest, opts ...http.CallOption) (*PinBackupResponse, error) {
+	var out PinBackupResponse
+	pattern := "/v1/backups/{backup_id}/pin"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationBackupOrchestratorServicePinBackup))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PreflightCheck Target checks
+func (c *BackupOrchestratorServiceHTTPClientImpl) PreflightCheck(ctx context.Context, in *PreflightCheckRequest, opts ...http.CallOption) (*PreflightCheckResponse, error) {
+	var out PreflightCheckResponse
+	pattern := "/v1/backups/preflight"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationBackupOrchestratorServicePreflightCheck))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *BackupOrchestratorServiceHTTPClientImpl) RepairBackup(ctx context.Context, in *RepairBackupRequest, opts ...http.CallOption) (*RepairBackupResponse, error) {
+	var out RepairBackupResponse
+	pattern := "/v1/backups/{backup_id}/repair"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationBackupOrchestratorServiceRepairBackup))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ReplicateBackup Off-site copies. ReplicateBackup queues a backup for replication now;
+// ReceiveReplica is what a primary calls on the secondary, over mTLS only.
+func (c *BackupOrchestratorServiceHTTPClientImpl) ReplicateBackup(ctx context.Context, in *ReplicateBackupRequest, opts ...http.CallOption) (*ReplicateBackupResponse, error) {
+	var out ReplicateBackupResponse
+	pattern := "/v1/backups/{backup_id}/replicate"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationBackupOrchestratorServiceReplicateBackup))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *BackupOrchestratorServiceHTTPClientImpl) RestoreFullBackup(ctx context.Context, in *RestoreFullBackupRequest, opts ...http.CallOption) (*RestoreFullBackupResponse, error) {
 	var out RestoreFullBackupResponse
 	pattern := "/v1/backups/full/{backup_id}/restore"
@@ -517,3 +1418,56 @@ func (c *BackupOrchestratorServiceHTTPClientImpl) RestoreModuleBackup(ctx contex
 	}
 	return &out, nil
 }
+
+func (c *BackupOrchestratorServiceHTTPClientImpl) TestRestore(ctx context.Context, in *TestRestoreRequest, opts ...http.CallOption) (*TestRestoreResponse, error) {
+	var out TestRestoreResponse
+	pattern := "/v1/backups/{backup_id}/test-restore"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationBackupOrchestratorServiceTestRestore))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *BackupOrchestratorServiceHTTPClientImpl) UnpinBackup(ctx context.Context, in *UnpinBackupRequest, opts ...http.CallOption) (*UnpinBackupResponse, error) {
+	var out UnpinBackupResponse
+	pattern := "/v1/backups/{backup_id}/unpin"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationBackupOrchestratorServiceUnpinBackup))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *BackupOrchestratorServiceHTTPClientImpl) UpdateFullBackup(ctx context.Context, in *UpdateFullBackupRequest, opts ...http.CallOption) (*UpdateFullBackupResponse, error) {
+	var out UpdateFullBackupResponse
+	pattern := "/v1/backups/full/{id}"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationBackupOrchestratorServiceUpdateFullBackup))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "PATCH", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// VerifyBackup Integrity checks
+func (c *BackupOrchestratorServiceHTTPClientImpl) VerifyBackup(ctx context.Context, in *VerifyBackupRequest, opts ...http.CallOption) (*VerifyBackupResponse, error) {
+	var out VerifyBackupResponse
+	pattern := "/v1/backups/{backup_id}/verify"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationBackupOrchestratorServiceVerifyBackup))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -213,6 +213,182 @@ func (x *ExportBackupResponse) GetSchemaVersion() int32 {
 	return 0
 }
 
+// ExportBackupChunk is one message of a StreamExportBackup stream: any number
+// of data chunks, then exactly one summary as the last message.
+type ExportBackupChunk struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*ExportBackupChunk_Data
+	//	*ExportBackupChunk_Summary
+	Payload       isExportBackupChunk_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportBackupChunk) Reset() {
+	*x = ExportBackupChunk{}
+	mi := &file_backup_service_v1_backup_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportBackupChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportBackupChunk) ProtoMessage() {}
+
+func (x *ExportBackupChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportBackupChunk.ProtoReflect.Descriptor instead.
+func (*ExportBackupChunk) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ExportBackupChunk) GetPayload() isExportBackupChunk_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ExportBackupChunk) GetData() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*ExportBackupChunk_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *ExportBackupChunk) GetSummary() *ExportBackupSummary {
+	if x != nil {
+		if x, ok := x.Payload.(*ExportBackupChunk_Summary); ok {
+			return x.Summary
+		}
+	}
+	return nil
+}
+
+type isExportBackupChunk_Payload interface {
+	isExportBackupChunk_Payload()
+}
+
+type ExportBackupChunk_Data struct {
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3,oneof"`
+}
+
+type ExportBackupChunk_Summary struct {
+	Summary *ExportBackupSummary `protobuf:"bytes,2,opt,name=summary,proto3,oneof"`
+}
+
+func (*ExportBackupChunk_Data) isExportBackupChunk_Payload() {}
+
+func (*ExportBackupChunk_Summary) isExportBackupChunk_Payload() {}
+
+type ExportBackupSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Module        string                 `protobuf:"bytes,1,opt,name=module,proto3" json:"module,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	ExportedAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=exported_at,json=exportedAt,proto3" json:"exported_at,omitempty"`
+	TenantId      uint32                 `protobuf:"varint,4,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	EntityCounts  map[string]int64       `protobuf:"bytes,5,rep,name=entity_counts,json=entityCounts,proto3" json:"entity_counts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	SchemaVersion int32                  `protobuf:"varint,6,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	TotalBytes    int64                  `protobuf:"varint,7,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportBackupSummary) Reset() {
+	*x = ExportBackupSummary{}
+	mi := &file_backup_service_v1_backup_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportBackupSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportBackupSummary) ProtoMessage() {}
+
+func (x *ExportBackupSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportBackupSummary.ProtoReflect.Descriptor instead.
+func (*ExportBackupSummary) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ExportBackupSummary) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+func (x *ExportBackupSummary) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *ExportBackupSummary) GetExportedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExportedAt
+	}
+	return nil
+}
+
+func (x *ExportBackupSummary) GetTenantId() uint32 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+func (x *ExportBackupSummary) GetEntityCounts() map[string]int64 {
+	if x != nil {
+		return x.EntityCounts
+	}
+	return nil
+}
+
+func (x *ExportBackupSummary) GetSchemaVersion() int32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *ExportBackupSummary) GetTotalBytes() int64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
+}
+
 type ImportBackupRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
@@ -223,7 +399,7 @@ type ImportBackupRequest struct {
 
 func (x *ImportBackupRequest) Reset() {
 	*x = ImportBackupRequest{}
-	mi := &file_backup_service_v1_backup_service_proto_msgTypes[2]
+	mi := &file_backup_service_v1_backup_service_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -235,7 +411,7 @@ func (x *ImportBackupRequest) String() string {
 func (*ImportBackupRequest) ProtoMessage() {}
 
 func (x *ImportBackupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_backup_service_v1_backup_service_proto_msgTypes[2]
+	mi := &file_backup_service_v1_backup_service_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -248,7 +424,7 @@ func (x *ImportBackupRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ImportBackupRequest.ProtoReflect.Descriptor instead.
 func (*ImportBackupRequest) Descriptor() ([]byte, []int) {
-	return file_backup_service_v1_backup_service_proto_rawDescGZIP(), []int{2}
+	return file_backup_service_v1_backup_service_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ImportBackupRequest) GetData() []byte {
@@ -265,6 +441,141 @@ func (x *ImportBackupRequest) GetMode() RestoreMode {
 	return RestoreMode_RESTORE_MODE_SKIP
 }
 
+// ImportBackupChunk is one message of a StreamImportBackup stream.
+type ImportBackupChunk struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*ImportBackupChunk_Options
+	//	*ImportBackupChunk_Data
+	Payload       isImportBackupChunk_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportBackupChunk) Reset() {
+	*x = ImportBackupChunk{}
+	mi := &file_backup_service_v1_backup_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportBackupChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportBackupChunk) ProtoMessage() {}
+
+func (x *ImportBackupChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportBackupChunk.ProtoReflect.Descriptor instead.
+func (*ImportBackupChunk) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ImportBackupChunk) GetPayload() isImportBackupChunk_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ImportBackupChunk) GetOptions() *ImportBackupOptions {
+	if x != nil {
+		if x, ok := x.Payload.(*ImportBackupChunk_Options); ok {
+			return x.Options
+		}
+	}
+	return nil
+}
+
+func (x *ImportBackupChunk) GetData() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*ImportBackupChunk_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+type isImportBackupChunk_Payload interface {
+	isImportBackupChunk_Payload()
+}
+
+type ImportBackupChunk_Options struct {
+	Options *ImportBackupOptions `protobuf:"bytes,1,opt,name=options,proto3,oneof"`
+}
+
+type ImportBackupChunk_Data struct {
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3,oneof"`
+}
+
+func (*ImportBackupChunk_Options) isImportBackupChunk_Payload() {}
+
+func (*ImportBackupChunk_Data) isImportBackupChunk_Payload() {}
+
+type ImportBackupOptions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mode          RestoreMode            `protobuf:"varint,1,opt,name=mode,proto3,enum=backup.service.v1.RestoreMode" json:"mode,omitempty"`
+	TotalBytes    int64                  `protobuf:"varint,2,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportBackupOptions) Reset() {
+	*x = ImportBackupOptions{}
+	mi := &file_backup_service_v1_backup_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportBackupOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportBackupOptions) ProtoMessage() {}
+
+func (x *ImportBackupOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_backup_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportBackupOptions.ProtoReflect.Descriptor instead.
+func (*ImportBackupOptions) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_backup_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ImportBackupOptions) GetMode() RestoreMode {
+	if x != nil {
+		return x.Mode
+	}
+	return RestoreMode_RESTORE_MODE_SKIP
+}
+
+func (x *ImportBackupOptions) GetTotalBytes() int64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
+}
+
 type ImportBackupResponse struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
 	Success           bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -279,7 +590,7 @@ type ImportBackupResponse struct {
 
 func (x *ImportBackupResponse) Reset() {
 	*x = ImportBackupResponse{}
-	mi := &file_backup_service_v1_backup_service_proto_msgTypes[3]
+	mi := &file_backup_service_v1_backup_service_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -291,7 +602,7 @@ func (x *ImportBackupResponse) String() string {
 func (*ImportBackupResponse) ProtoMessage() {}
 
 func (x *ImportBackupResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_backup_service_v1_backup_service_proto_msgTypes[3]
+	mi := &file_backup_service_v1_backup_service_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -304,7 +615,7 @@ func (x *ImportBackupResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ImportBackupResponse.ProtoReflect.Descriptor instead.
 func (*ImportBackupResponse) Descriptor() ([]byte, []int) {
-	return file_backup_service_v1_backup_service_proto_rawDescGZIP(), []int{3}
+	return file_backup_service_v1_backup_service_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ImportBackupResponse) GetSuccess() bool {
@@ -363,7 +674,7 @@ type EntityImportResult struct {
 
 func (x *EntityImportResult) Reset() {
 	*x = EntityImportResult{}
-	mi := &file_backup_service_v1_backup_service_proto_msgTypes[4]
+	mi := &file_backup_service_v1_backup_service_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -375,7 +686,7 @@ func (x *EntityImportResult) String() string {
 func (*EntityImportResult) ProtoMessage() {}
 
 func (x *EntityImportResult) ProtoReflect() protoreflect.Message {
-	mi := &file_backup_service_v1_backup_service_proto_msgTypes[4]
+	mi := &file_backup_service_v1_backup_service_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -388,7 +699,7 @@ func (x *EntityImportResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EntityImportResult.ProtoReflect.Descriptor instead.
 func (*EntityImportResult) Descriptor() ([]byte, []int) {
-	return file_backup_service_v1_backup_service_proto_rawDescGZIP(), []int{4}
+	return file_backup_service_v1_backup_service_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *EntityImportResult) GetEntityType() string {
@@ -454,10 +765,35 @@ const file_backup_service_v1_backup_service_proto_rawDesc = "" +
 	"\x0eschema_version\x18\a \x01(\x05R\rschemaVersion\x1a?\n" +
 	"\x11EntityCountsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"x\n" +
+	"\x11ExportBackupChunk\x12\x14\n" +
+	"\x04data\x18\x01 \x01(\fH\x00R\x04data\x12B\n" +
+	"\asummary\x18\x02 \x01(\v2&.backup.service.v1.ExportBackupSummaryH\x00R\asummaryB\t\n" +
+	"\apayload\"\x89\x03\n" +
+	"\x13ExportBackupSummary\x12\x16\n" +
+	"\x06module\x18\x01 \x01(\tR\x06module\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12;\n" +
+	"\vexported_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"exportedAt\x12\x1b\n" +
+	"\ttenant_id\x18\x04 \x01(\rR\btenantId\x12]\n" +
+	"\rentity_counts\x18\x05 \x03(\v28.backup.service.v1.ExportBackupSummary.EntityCountsEntryR\fentityCounts\x12%\n" +
+	"\x0eschema_version\x18\x06 \x01(\x05R\rschemaVersion\x12\x1f\n" +
+	"\vtotal_bytes\x18\a \x01(\x03R\n" +
+	"totalBytes\x1a?\n" +
+	"\x11EntityCountsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"]\n" +
 	"\x13ImportBackupRequest\x12\x12\n" +
 	"\x04data\x18\x01 \x01(\fR\x04data\x122\n" +
-	"\x04mode\x18\x02 \x01(\x0e2\x1e.backup.service.v1.RestoreModeR\x04mode\"\x8a\x02\n" +
+	"\x04mode\x18\x02 \x01(\x0e2\x1e.backup.service.v1.RestoreModeR\x04mode\"x\n" +
+	"\x11ImportBackupChunk\x12B\n" +
+	"\aoptions\x18\x01 \x01(\v2&.backup.service.v1.ImportBackupOptionsH\x00R\aoptions\x12\x14\n" +
+	"\x04data\x18\x02 \x01(\fH\x00R\x04dataB\t\n" +
+	"\apayload\"j\n" +
+	"\x13ImportBackupOptions\x122\n" +
+	"\x04mode\x18\x01 \x01(\x0e2\x1e.backup.service.v1.RestoreModeR\x04mode\x12\x1f\n" +
+	"\vtotal_bytes\x18\x02 \x01(\x03R\n" +
+	"totalBytes\"\x8a\x02\n" +
 	"\x14ImportBackupResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12?\n" +
 	"\aresults\x18\x02 \x03(\v2%.backup.service.v1.EntityImportResultR\aresults\x12\x1a\n" +
@@ -475,10 +811,12 @@ const file_backup_service_v1_backup_service_proto_rawDesc = "" +
 	"\x06failed\x18\x06 \x01(\x03R\x06failed*@\n" +
 	"\vRestoreMode\x12\x15\n" +
 	"\x11RESTORE_MODE_SKIP\x10\x00\x12\x1a\n" +
-	"\x16RESTORE_MODE_OVERWRITE\x10\x012\x8a\x02\n" +
+	"\x16RESTORE_MODE_OVERWRITE\x10\x012\xd7\x03\n" +
 	"\rBackupService\x12z\n" +
-	"\fExportBackup\x12&.backup.service.v1.ExportBackupRequest\x1a'.backup.service.v1.ExportBackupResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/backup/export\x12}\n" +
-	"\fImportBackup\x12&.backup.service.v1.ImportBackupRequest\x1a'.backup.service.v1.ImportBackupResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v1/backup/importB\xda\x01\n" +
+	"\fExportBackup\x12&.backup.service.v1.ExportBackupRequest\x1a'.backup.service.v1.ExportBackupResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/backup/export\x12d\n" +
+	"\x12StreamExportBackup\x12&.backup.service.v1.ExportBackupRequest\x1a$.backup.service.v1.ExportBackupChunk0\x01\x12}\n" +
+	"\fImportBackup\x12&.backup.service.v1.ImportBackupRequest\x1a'.backup.service.v1.ImportBackupResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v1/backup/import\x12e\n" +
+	"\x12StreamImportBackup\x12$.backup.service.v1.ImportBackupChunk\x1a'.backup.service.v1.ImportBackupResponse(\x01B\xda\x01\n" +
 	"\x15com.backup.service.v1B\x12BackupServiceProtoP\x01ZGgithub.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1;backuppb\xa2\x02\x03BSX\xaa\x02\x11Backup.Service.V1\xca\x02\x11Backup\\Service\\V1\xe2\x02\x1dBackup\\Service\\V1\\GPBMetadata\xea\x02\x13Backup::Service::V1b\x06proto3"
 
 var (
@@ -494,31 +832,45 @@ func file_backup_service_v1_backup_service_proto_rawDescGZIP() []byte {
 }
 
 var file_backup_service_v1_backup_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_backup_service_v1_backup_service_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_backup_service_v1_backup_service_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_backup_service_v1_backup_service_proto_goTypes = []any{
 	(RestoreMode)(0),              // 0: backup.service.v1.RestoreMode
 	(*ExportBackupRequest)(nil),   // 1: backup.service.v1.ExportBackupRequest
 	(*ExportBackupResponse)(nil),  // 2: backup.service.v1.ExportBackupResponse
-	(*ImportBackupRequest)(nil),   // 3: backup.service.v1.ImportBackupRequest
-	(*ImportBackupResponse)(nil),  // 4: backup.service.v1.ImportBackupResponse
-	(*EntityImportResult)(nil),    // 5: backup.service.v1.EntityImportResult
-	nil,                           // 6: backup.service.v1.ExportBackupResponse.EntityCountsEntry
-	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+	(*ExportBackupChunk)(nil),     // 3: backup.service.v1.ExportBackupChunk
+	(*ExportBackupSummary)(nil),   // 4: backup.service.v1.ExportBackupSummary
+	(*ImportBackupRequest)(nil),   // 5: backup.service.v1.ImportBackupRequest
+	(*ImportBackupChunk)(nil),     // 6: backup.service.v1.ImportBackupChunk
+	(*ImportBackupOptions)(nil),   // 7: backup.service.v1.ImportBackupOptions
+	(*ImportBackupResponse)(nil),  // 8: backup.service.v1.ImportBackupResponse
+	(*EntityImportResult)(nil),    // 9: backup.service.v1.EntityImportResult
+	nil,                           // 10: backup.service.v1.ExportBackupResponse.EntityCountsEntry
+	nil,                           // 11: backup.service.v1.ExportBackupSummary.EntityCountsEntry
+	(*timestamppb.Timestamp)(nil), // 12: google.protobuf.Timestamp
 }
 var file_backup_service_v1_backup_service_proto_depIdxs = []int32{
-	7, // 0: backup.service.v1.ExportBackupResponse.exported_at:type_name -> google.protobuf.Timestamp
-	6, // 1: backup.service.v1.ExportBackupResponse.entity_counts:type_name -> backup.service.v1.ExportBackupResponse.EntityCountsEntry
-	0, // 2: backup.service.v1.ImportBackupRequest.mode:type_name -> backup.service.v1.RestoreMode
-	5, // 3: backup.service.v1.ImportBackupResponse.results:type_name -> backup.service.v1.EntityImportResult
-	1, // 4: backup.service.v1.BackupService.ExportBackup:input_type -> backup.service.v1.ExportBackupRequest
-	3, // 5: backup.service.v1.BackupService.ImportBackup:input_type -> backup.service.v1.ImportBackupRequest
-	2, // 6: backup.service.v1.BackupService.ExportBackup:output_type -> backup.service.v1.ExportBackupResponse
-	4, // 7: backup.service.v1.BackupService.ImportBackup:output_type -> backup.service.v1.ImportBackupResponse
-	6, // [6:8] is the sub-list for method output_type
-	4, // [4:6] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	12, // 0: backup.service.v1.ExportBackupResponse.exported_at:type_name -> google.protobuf.Timestamp
+	10, // 1: backup.service.v1.ExportBackupResponse.entity_counts:type_name -> backup.service.v1.ExportBackupResponse.EntityCountsEntry
+	4,  // 2: backup.service.v1.ExportBackupChunk.summary:type_name -> backup.service.v1.ExportBackupSummary
+	12, // 3: backup.service.v1.ExportBackupSummary.exported_at:type_name -> google.protobuf.Timestamp
+	11, // 4: backup.service.v1.ExportBackupSummary.entity_counts:type_name -> backup.service.v1.ExportBackupSummary.EntityCountsEntry
+	0,  // 5: backup.service.v1.ImportBackupRequest.mode:type_name -> backup.service.v1.RestoreMode
+	7,  // 6: backup.service.v1.ImportBackupChunk.options:type_name -> backup.service.v1.ImportBackupOptions
+	0,  // 7: backup.service.v1.ImportBackupOptions.mode:type_name -> backup.service.v1.RestoreMode
+	9,  // 8: backup.service.v1.ImportBackupResponse.results:type_name -> backup.service.v1.EntityImportResult
+	1,  // 9: backup.service.v1.BackupService.ExportBackup:input_type -> backup.service.v1.ExportBackupRequest
+	1,  // 10: backup.service.v1.BackupService.StreamExportBackup:input_type -> backup.service.v1.ExportBackupRequest
+	5,  // 11: backup.service.v1.BackupService.ImportBackup:input_type -> backup.service.v1.ImportBackupRequest
+	6,  // 12: backup.service.v1.BackupService.StreamImportBackup:input_type -> backup.service.v1.ImportBackupChunk
+	2,  // 13: backup.service.v1.BackupService.ExportBackup:output_type -> backup.service.v1.ExportBackupResponse
+	3,  // 14: backup.service.v1.BackupService.StreamExportBackup:output_type -> backup.service.v1.ExportBackupChunk
+	8,  // 15: backup.service.v1.BackupService.ImportBackup:output_type -> backup.service.v1.ImportBackupResponse
+	8,  // 16: backup.service.v1.BackupService.StreamImportBackup:output_type -> backup.service.v1.ImportBackupResponse
+	13, // [13:17] is the sub-list for method output_type
+	9,  // [9:13] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_backup_service_v1_backup_service_proto_init() }
@@ -527,13 +879,21 @@ func file_backup_service_v1_backup_service_proto_init() {
 		return
 	}
 	file_backup_service_v1_backup_service_proto_msgTypes[0].OneofWrappers = []any{}
+	file_backup_service_v1_backup_service_proto_msgTypes[2].OneofWrappers = []any{
+		(*ExportBackupChunk_Data)(nil),
+		(*ExportBackupChunk_Summary)(nil),
+	}
+	file_backup_service_v1_backup_service_proto_msgTypes[5].OneofWrappers = []any{
+		(*ImportBackupChunk_Options)(nil),
+		(*ImportBackupChunk_Data)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_backup_service_v1_backup_service_proto_rawDesc), len(file_backup_service_v1_backup_service_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   6,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
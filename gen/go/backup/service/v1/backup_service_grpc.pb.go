@@ -19,8 +19,10 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	BackupService_ExportBackup_FullMethodName = "/backup.service.v1.BackupService/ExportBackup"
-	BackupService_ImportBackup_FullMethodName = "/backup.service.v1.BackupService/ImportBackup"
+	BackupService_ExportBackup_FullMethodName       = "/backup.service.v1.BackupService/ExportBackup"
+	BackupService_StreamExportBackup_FullMethodName = "/backup.service.v1.BackupService/StreamExportBackup"
+	BackupService_ImportBackup_FullMethodName       = "/backup.service.v1.BackupService/ImportBackup"
+	BackupService_StreamImportBackup_FullMethodName = "/backup.service.v1.BackupService/StreamImportBackup"
 )
 
 // BackupServiceClient is the client API for BackupService service.
@@ -32,7 +34,14 @@ const (
 // this service on each module via gRPC.
 type BackupServiceClient interface {
 	ExportBackup(ctx context.Context, in *ExportBackupRequest, opts ...grpc.CallOption) (*ExportBackupResponse, error)
+	// StreamExportBackup returns the same export as ExportBackup, split into
+	// data chunks followed by a single summary message, so large modules are
+	// not bound by the gRPC message size limit.
+	StreamExportBackup(ctx context.Context, in *ExportBackupRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportBackupChunk], error)
 	ImportBackup(ctx context.Context, in *ImportBackupRequest, opts ...grpc.CallOption) (*ImportBackupResponse, error)
+	// StreamImportBackup accepts the same import as ImportBackup in chunks:
+	// one options message first, then any number of data chunks.
+	StreamImportBackup(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportBackupChunk, ImportBackupResponse], error)
 }
 
 type backupServiceClient struct {
@@ -53,6 +62,25 @@ func (c *backupServiceClient) ExportBackup(ctx context.Context, in *ExportBackup
 	return out, nil
 }
 
+func (c *backupServiceClient) StreamExportBackup(ctx context.Context, in *ExportBackupRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportBackupChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BackupService_ServiceDesc.Streams[0], BackupService_StreamExportBackup_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportBackupRequest, ExportBackupChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BackupService_StreamExportBackupClient = grpc.ServerStreamingClient[ExportBackupChunk]
+
 func (c *backupServiceClient) ImportBackup(ctx context.Context, in *ImportBackupRequest, opts ...grpc.CallOption) (*ImportBackupResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ImportBackupResponse)
@@ -63,6 +91,19 @@ func (c *backupServiceClient) ImportBackup(ctx context.Context, in *ImportBackup
 	return out, nil
 }
 
+func (c *backupServiceClient) StreamImportBackup(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportBackupChunk, ImportBackupResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BackupService_ServiceDesc.Streams[1], BackupService_StreamImportBackup_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportBackupChunk, ImportBackupResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BackupService_StreamImportBackupClient = grpc.ClientStreamingClient[ImportBackupChunk, ImportBackupResponse]
+
 // BackupServiceServer is the server API for BackupService service.
 // All implementations must embed UnimplementedBackupServiceServer
 // for forward compatibility.
@@ -72,7 +113,14 @@ func (c *backupServiceClient) ImportBackup(ctx context.Context, in *ImportBackup
 // this service on each module via gRPC.
 type BackupServiceServer interface {
 	ExportBackup(context.Context, *ExportBackupRequest) (*ExportBackupResponse, error)
+	// StreamExportBackup returns the same export as ExportBackup, split into
+	// data chunks followed by a single summary message, so large modules are
+	// not bound by the gRPC message size limit.
+	StreamExportBackup(*ExportBackupRequest, grpc.ServerStreamingServer[ExportBackupChunk]) error
 	ImportBackup(context.Context, *ImportBackupRequest) (*ImportBackupResponse, error)
+	// StreamImportBackup accepts the same import as ImportBackup in chunks:
+	// one options message first, then any number of data chunks.
+	StreamImportBackup(grpc.ClientStreamingServer[ImportBackupChunk, ImportBackupResponse]) error
 	mustEmbedUnimplementedBackupServiceServer()
 }
 
@@ -86,9 +134,15 @@ type UnimplementedBackupServiceServer struct{}
 func (UnimplementedBackupServiceServer) ExportBackup(context.Context, *ExportBackupRequest) (*ExportBackupResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ExportBackup not implemented")
 }
+func (UnimplementedBackupServiceServer) StreamExportBackup(*ExportBackupRequest, grpc.ServerStreamingServer[ExportBackupChunk]) error {
+	return status.Error(codes.Unimplemented, "method StreamExportBackup not implemented")
+}
 func (UnimplementedBackupServiceServer) ImportBackup(context.Context, *ImportBackupRequest) (*ImportBackupResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ImportBackup not implemented")
 }
+func (UnimplementedBackupServiceServer) StreamImportBackup(grpc.ClientStreamingServer[ImportBackupChunk, ImportBackupResponse]) error {
+	return status.Error(codes.Unimplemented, "method StreamImportBackup not implemented")
+}
 func (UnimplementedBackupServiceServer) mustEmbedUnimplementedBackupServiceServer() {}
 func (UnimplementedBackupServiceServer) testEmbeddedByValue()                       {}
 
@@ -128,6 +182,17 @@ func _BackupService_ExportBackup_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BackupService_StreamExportBackup_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportBackupRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BackupServiceServer).StreamExportBackup(m, &grpc.GenericServerStream[ExportBackupRequest, ExportBackupChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BackupService_StreamExportBackupServer = grpc.ServerStreamingServer[ExportBackupChunk]
+
 func _BackupService_ImportBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ImportBackupRequest)
 	if err := dec(in); err != nil {
@@ -146,6 +211,13 @@ func _BackupService_ImportBackup_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BackupService_StreamImportBackup_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BackupServiceServer).StreamImportBackup(&grpc.GenericServerStream[ImportBackupChunk, ImportBackupResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BackupService_StreamImportBackupServer = grpc.ClientStreamingServer[ImportBackupChunk, ImportBackupResponse]
+
 // BackupService_ServiceDesc is the grpc.ServiceDesc for BackupService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -162,6 +234,17 @@ var BackupService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _BackupService_ImportBackup_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamExportBackup",
+			Handler:       _BackupService_StreamExportBackup_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamImportBackup",
+			Handler:       _BackupService_StreamImportBackup_Handler,
+			ClientStreams: true,
+		},
+	},
 	Metadata: "backup/service/v1/backup_service.proto",
 }
@@ -166,6 +166,180 @@ func (x *ModuleExportResponse) GetSchemaVersion() int32 {
 	return 0
 }
 
+type ModuleExportChunk struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*ModuleExportChunk_Data
+	//	*ModuleExportChunk_Summary
+	Payload       isModuleExportChunk_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModuleExportChunk) Reset() {
+	*x = ModuleExportChunk{}
+	mi := &file_backup_service_v1_module_backup_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModuleExportChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModuleExportChunk) ProtoMessage() {}
+
+func (x *ModuleExportChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_module_backup_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModuleExportChunk.ProtoReflect.Descriptor instead.
+func (*ModuleExportChunk) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_module_backup_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ModuleExportChunk) GetPayload() isModuleExportChunk_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ModuleExportChunk) GetData() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*ModuleExportChunk_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *ModuleExportChunk) GetSummary() *ModuleExportSummary {
+	if x != nil {
+		if x, ok := x.Payload.(*ModuleExportChunk_Summary); ok {
+			return x.Summary
+		}
+	}
+	return nil
+}
+
+type isModuleExportChunk_Payload interface {
+	isModuleExportChunk_Payload()
+}
+
+type ModuleExportChunk_Data struct {
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3,oneof"`
+}
+
+type ModuleExportChunk_Summary struct {
+	Summary *ModuleExportSummary `protobuf:"bytes,2,opt,name=summary,proto3,oneof"`
+}
+
+func (*ModuleExportChunk_Data) isModuleExportChunk_Payload() {}
+
+func (*ModuleExportChunk_Summary) isModuleExportChunk_Payload() {}
+
+type ModuleExportSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Module        string                 `protobuf:"bytes,1,opt,name=module,proto3" json:"module,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	ExportedAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=exported_at,json=exportedAt,proto3" json:"exported_at,omitempty"`
+	TenantId      uint32                 `protobuf:"varint,4,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	EntityCounts  map[string]int64       `protobuf:"bytes,5,rep,name=entity_counts,json=entityCounts,proto3" json:"entity_counts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	SchemaVersion int32                  `protobuf:"varint,6,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	TotalBytes    int64                  `protobuf:"varint,7,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModuleExportSummary) Reset() {
+	*x = ModuleExportSummary{}
+	mi := &file_backup_service_v1_module_backup_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModuleExportSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModuleExportSummary) ProtoMessage() {}
+
+func (x *ModuleExportSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_module_backup_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModuleExportSummary.ProtoReflect.Descriptor instead.
+func (*ModuleExportSummary) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_module_backup_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ModuleExportSummary) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+func (x *ModuleExportSummary) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *ModuleExportSummary) GetExportedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExportedAt
+	}
+	return nil
+}
+
+func (x *ModuleExportSummary) GetTenantId() uint32 {
+	if x != nil {
+		return x.TenantId
+	}
+	return 0
+}
+
+func (x *ModuleExportSummary) GetEntityCounts() map[string]int64 {
+	if x != nil {
+		return x.EntityCounts
+	}
+	return nil
+}
+
+func (x *ModuleExportSummary) GetSchemaVersion() int32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *ModuleExportSummary) GetTotalBytes() int64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
+}
+
 type ModuleImportRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
@@ -176,7 +350,7 @@ type ModuleImportRequest struct {
 
 func (x *ModuleImportRequest) Reset() {
 	*x = ModuleImportRequest{}
-	mi := &file_backup_service_v1_module_backup_proto_msgTypes[2]
+	mi := &file_backup_service_v1_module_backup_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -188,7 +362,7 @@ func (x *ModuleImportRequest) String() string {
 func (*ModuleImportRequest) ProtoMessage() {}
 
 func (x *ModuleImportRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_backup_service_v1_module_backup_proto_msgTypes[2]
+	mi := &file_backup_service_v1_module_backup_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -201,7 +375,7 @@ func (x *ModuleImportRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ModuleImportRequest.ProtoReflect.Descriptor instead.
 func (*ModuleImportRequest) Descriptor() ([]byte, []int) {
-	return file_backup_service_v1_module_backup_proto_rawDescGZIP(), []int{2}
+	return file_backup_service_v1_module_backup_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ModuleImportRequest) GetData() []byte {
@@ -218,6 +392,140 @@ func (x *ModuleImportRequest) GetMode() RestoreMode {
 	return RestoreMode_RESTORE_MODE_SKIP
 }
 
+type ModuleImportChunk struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*ModuleImportChunk_Options
+	//	*ModuleImportChunk_Data
+	Payload       isModuleImportChunk_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModuleImportChunk) Reset() {
+	*x = ModuleImportChunk{}
+	mi := &file_backup_service_v1_module_backup_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModuleImportChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModuleImportChunk) ProtoMessage() {}
+
+func (x *ModuleImportChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_module_backup_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModuleImportChunk.ProtoReflect.Descriptor instead.
+func (*ModuleImportChunk) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_module_backup_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ModuleImportChunk) GetPayload() isModuleImportChunk_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ModuleImportChunk) GetOptions() *ModuleImportOptions {
+	if x != nil {
+		if x, ok := x.Payload.(*ModuleImportChunk_Options); ok {
+			return x.Options
+		}
+	}
+	return nil
+}
+
+func (x *ModuleImportChunk) GetData() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*ModuleImportChunk_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+type isModuleImportChunk_Payload interface {
+	isModuleImportChunk_Payload()
+}
+
+type ModuleImportChunk_Options struct {
+	Options *ModuleImportOptions `protobuf:"bytes,1,opt,name=options,proto3,oneof"`
+}
+
+type ModuleImportChunk_Data struct {
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3,oneof"`
+}
+
+func (*ModuleImportChunk_Options) isModuleImportChunk_Payload() {}
+
+func (*ModuleImportChunk_Data) isModuleImportChunk_Payload() {}
+
+type ModuleImportOptions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mode          RestoreMode            `protobuf:"varint,1,opt,name=mode,proto3,enum=backup.service.v1.RestoreMode" json:"mode,omitempty"`
+	TotalBytes    int64                  `protobuf:"varint,2,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModuleImportOptions) Reset() {
+	*x = ModuleImportOptions{}
+	mi := &file_backup_service_v1_module_backup_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModuleImportOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModuleImportOptions) ProtoMessage() {}
+
+func (x *ModuleImportOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_backup_service_v1_module_backup_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModuleImportOptions.ProtoReflect.Descriptor instead.
+func (*ModuleImportOptions) Descriptor() ([]byte, []int) {
+	return file_backup_service_v1_module_backup_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ModuleImportOptions) GetMode() RestoreMode {
+	if x != nil {
+		return x.Mode
+	}
+	return RestoreMode_RESTORE_MODE_SKIP
+}
+
+func (x *ModuleImportOptions) GetTotalBytes() int64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
+}
+
 type ModuleImportResponse struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
 	Success           bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -232,7 +540,7 @@ type ModuleImportResponse struct {
 
 func (x *ModuleImportResponse) Reset() {
 	*x = ModuleImportResponse{}
-	mi := &file_backup_service_v1_module_backup_proto_msgTypes[3]
+	mi := &file_backup_service_v1_module_backup_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -244,7 +552,7 @@ func (x *ModuleImportResponse) String() string {
 func (*ModuleImportResponse) ProtoMessage() {}
 
 func (x *ModuleImportResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_backup_service_v1_module_backup_proto_msgTypes[3]
+	mi := &file_backup_service_v1_module_backup_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -257,7 +565,7 @@ func (x *ModuleImportResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ModuleImportResponse.ProtoReflect.Descriptor instead.
 func (*ModuleImportResponse) Descriptor() ([]byte, []int) {
-	return file_backup_service_v1_module_backup_proto_rawDescGZIP(), []int{3}
+	return file_backup_service_v1_module_backup_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ModuleImportResponse) GetSuccess() bool {
@@ -323,10 +631,35 @@ const file_backup_service_v1_module_backup_proto_rawDesc = "" +
 	"\x0eschema_version\x18\a \x01(\x05R\rschemaVersion\x1a?\n" +
 	"\x11EntityCountsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"x\n" +
+	"\x11ModuleExportChunk\x12\x14\n" +
+	"\x04data\x18\x01 \x01(\fH\x00R\x04data\x12B\n" +
+	"\asummary\x18\x02 \x01(\v2&.backup.service.v1.ModuleExportSummaryH\x00R\asummaryB\t\n" +
+	"\apayload\"\x89\x03\n" +
+	"\x13ModuleExportSummary\x12\x16\n" +
+	"\x06module\x18\x01 \x01(\tR\x06module\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12;\n" +
+	"\vexported_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"exportedAt\x12\x1b\n" +
+	"\ttenant_id\x18\x04 \x01(\rR\btenantId\x12]\n" +
+	"\rentity_counts\x18\x05 \x03(\v28.backup.service.v1.ModuleExportSummary.EntityCountsEntryR\fentityCounts\x12%\n" +
+	"\x0eschema_version\x18\x06 \x01(\x05R\rschemaVersion\x12\x1f\n" +
+	"\vtotal_bytes\x18\a \x01(\x03R\n" +
+	"totalBytes\x1a?\n" +
+	"\x11EntityCountsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"]\n" +
 	"\x13ModuleImportRequest\x12\x12\n" +
 	"\x04data\x18\x01 \x01(\fR\x04data\x122\n" +
-	"\x04mode\x18\x02 \x01(\x0e2\x1e.backup.service.v1.RestoreModeR\x04mode\"\x8a\x02\n" +
+	"\x04mode\x18\x02 \x01(\x0e2\x1e.backup.service.v1.RestoreModeR\x04mode\"x\n" +
+	"\x11ModuleImportChunk\x12B\n" +
+	"\aoptions\x18\x01 \x01(\v2&.backup.service.v1.ModuleImportOptionsH\x00R\aoptions\x12\x14\n" +
+	"\x04data\x18\x02 \x01(\fH\x00R\x04dataB\t\n" +
+	"\apayload\"j\n" +
+	"\x13ModuleImportOptions\x122\n" +
+	"\x04mode\x18\x01 \x01(\x0e2\x1e.backup.service.v1.RestoreModeR\x04mode\x12\x1f\n" +
+	"\vtotal_bytes\x18\x02 \x01(\x03R\n" +
+	"totalBytes\"\x8a\x02\n" +
 	"\x14ModuleImportResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12?\n" +
 	"\aresults\x18\x02 \x03(\v2%.backup.service.v1.EntityImportResultR\aresults\x12\x1a\n" +
@@ -348,27 +681,37 @@ func file_backup_service_v1_module_backup_proto_rawDescGZIP() []byte {
 	return file_backup_service_v1_module_backup_proto_rawDescData
 }
 
-var file_backup_service_v1_module_backup_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_backup_service_v1_module_backup_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_backup_service_v1_module_backup_proto_goTypes = []any{
 	(*ModuleExportRequest)(nil),   // 0: backup.service.v1.ModuleExportRequest
 	(*ModuleExportResponse)(nil),  // 1: backup.service.v1.ModuleExportResponse
-	(*ModuleImportRequest)(nil),   // 2: backup.service.v1.ModuleImportRequest
-	(*ModuleImportResponse)(nil),  // 3: backup.service.v1.ModuleImportResponse
-	nil,                           // 4: backup.service.v1.ModuleExportResponse.EntityCountsEntry
-	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
-	(RestoreMode)(0),              // 6: backup.service.v1.RestoreMode
-	(*EntityImportResult)(nil),    // 7: backup.service.v1.EntityImportResult
+	(*ModuleExportChunk)(nil),     // 2: backup.service.v1.ModuleExportChunk
+	(*ModuleExportSummary)(nil),   // 3: backup.service.v1.ModuleExportSummary
+	(*ModuleImportRequest)(nil),   // 4: backup.service.v1.ModuleImportRequest
+	(*ModuleImportChunk)(nil),     // 5: backup.service.v1.ModuleImportChunk
+	(*ModuleImportOptions)(nil),   // 6: backup.service.v1.ModuleImportOptions
+	(*ModuleImportResponse)(nil),  // 7: backup.service.v1.ModuleImportResponse
+	nil,                           // 8: backup.service.v1.ModuleExportResponse.EntityCountsEntry
+	nil,                           // 9: backup.service.v1.ModuleExportSummary.EntityCountsEntry
+	(*timestamppb.Timestamp)(nil), // 10: google.protobuf.Timestamp
+	(RestoreMode)(0),              // 11: backup.service.v1.RestoreMode
+	(*EntityImportResult)(nil),    // 12: backup.service.v1.EntityImportResult
 }
 var file_backup_service_v1_module_backup_proto_depIdxs = []int32{
-	5, // 0: backup.service.v1.ModuleExportResponse.exported_at:type_name -> google.protobuf.Timestamp
-	4, // 1: backup.service.v1.ModuleExportResponse.entity_counts:type_name -> backup.service.v1.ModuleExportResponse.EntityCountsEntry
-	6, // 2: backup.service.v1.ModuleImportRequest.mode:type_name -> backup.service.v1.RestoreMode
-	7, // 3: backup.service.v1.ModuleImportResponse.results:type_name -> backup.service.v1.EntityImportResult
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	10, // 0: backup.service.v1.ModuleExportResponse.exported_at:type_name -> google.protobuf.Timestamp
+	8,  // 1: backup.service.v1.ModuleExportResponse.entity_counts:type_name -> backup.service.v1.ModuleExportResponse.EntityCountsEntry
+	3,  // 2: backup.service.v1.ModuleExportChunk.summary:type_name -> backup.service.v1.ModuleExportSummary
+	10, // 3: backup.service.v1.ModuleExportSummary.exported_at:type_name -> google.protobuf.Timestamp
+	9,  // 4: backup.service.v1.ModuleExportSummary.entity_counts:type_name -> backup.service.v1.ModuleExportSummary.EntityCountsEntry
+	11, // 5: backup.service.v1.ModuleImportRequest.mode:type_name -> backup.service.v1.RestoreMode
+	6,  // 6: backup.service.v1.ModuleImportChunk.options:type_name -> backup.service.v1.ModuleImportOptions
+	11, // 7: backup.service.v1.ModuleImportOptions.mode:type_name -> backup.service.v1.RestoreMode
+	12, // 8: backup.service.v1.ModuleImportResponse.results:type_name -> backup.service.v1.EntityImportResult
+	9,  // [9:9] is the sub-list for method output_type
+	9,  // [9:9] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_backup_service_v1_module_backup_proto_init() }
@@ -378,13 +721,21 @@ func file_backup_service_v1_module_backup_proto_init() {
 	}
 	file_backup_service_v1_backup_service_proto_init()
 	file_backup_service_v1_module_backup_proto_msgTypes[0].OneofWrappers = []any{}
+	file_backup_service_v1_module_backup_proto_msgTypes[2].OneofWrappers = []any{
+		(*ModuleExportChunk_Data)(nil),
+		(*ModuleExportChunk_Summary)(nil),
+	}
+	file_backup_service_v1_module_backup_proto_msgTypes[5].OneofWrappers = []any{
+		(*ModuleImportChunk_Options)(nil),
+		(*ModuleImportChunk_Data)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_backup_service_v1_module_backup_proto_rawDesc), len(file_backup_service_v1_module_backup_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
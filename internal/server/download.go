@@ -0,0 +1,80 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"mime"
+	"net/http"
+	"os"
+
+	kratosErrors "github.com/go-kratos/kratos/v2/errors"
+	kratosHttp "github.com/go-kratos/kratos/v2/transport/http"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+	"github.com/go-tangra/go-tangra-backup/internal/service"
+)
+
+// registerDownloadRoutes adds plain HTTP downloads of backup payloads:
+//
+//	GET /v1/backups/{id}/data
+//	GET /v1/backups/full/{id}/modules/{module_id}/data
+//
+// They answer with Content-Length, ETag and Range support, so browsers and
+// curl -C - can resume large downloads. ?raw=true serves the stored file
+// (gzipped, still encrypted if the backup is); otherwise the password comes
+// in the X-Backup-Password header and the JSON export is served. Requests
+// pass through the server middleware, including the bearer token check,
+// which links from GeneratePresignedDownloadURL bypass with their signature.
+func registerDownloadRoutes(srv *kratosHttp.Server, orchestratorSvc *service.OrchestratorService) {
+	route := srv.Route("/")
+	route.GET("/v1/backups/full/{id}/modules/{module_id}/data",
+		backupDownloadHandler(orchestratorSvc, backupV1.OperationBackupOrchestratorServiceDownloadFullBackup))
+	route.GET("/v1/backups/{id}/data",
+		backupDownloadHandler(orchestratorSvc, backupV1.OperationBackupOrchestratorServiceDownloadBackup))
+}
+
+func backupDownloadHandler(orchestratorSvc *service.OrchestratorService, operation string) kratosHttp.HandlerFunc {
+	return func(ctx kratosHttp.Context) error {
+		kratosHttp.SetOperation(ctx, operation)
+		req := ctx.Request()
+		vars := ctx.Vars()
+		query := req.URL.Query()
+		raw := query.Get("raw") == "true"
+
+		var callCtx context.Context = ctx
+		if signature := query.Get("signature"); signature != "" {
+			if !service.VerifyDownloadSignature(req.URL.EscapedPath(), query.Get("expires"), signature) {
+				return kratosErrors.Forbidden("DOWNLOAD_LINK_INVALID", "download link is invalid or has expired")
+			}
+			// Presigned links never carry the password: they serve the stored file.
+			raw = true
+			callCtx = withPresigned(ctx)
+		}
+
+		h := ctx.Middleware(func(c context.Context, _ interface{}) (interface{}, error) {
+			return orchestratorSvc.OpenBackupDownload(c, vars.Get("id"), vars.Get("module_id"), req.Header.Get("X-Backup-Password"), raw)
+		})
+		out, err := h(callCtx, nil)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			return kratosErrors.NotFound("BACKUP_NOT_FOUND", "backup not found")
+		case errors.Is(err, service.ErrBackupPassword):
+			return kratosErrors.BadRequest("BACKUP_PASSWORD", err.Error())
+		case err != nil:
+			return err
+		}
+		download := out.(*service.BackupDownload)
+		defer download.Close()
+
+		w := ctx.Response()
+		w.Header().Set("ETag", download.ETag)
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": download.Filename}))
+		if raw {
+			w.Header().Set("Content-Type", "application/octet-stream")
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+		}
+		http.ServeContent(w, req, download.Filename, download.ModTime, download.Content)
+		return nil
+	}
+}
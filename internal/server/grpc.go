@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"os"
 
 	"github.com/go-kratos/kratos/v2/middleware"
 	"github.com/go-kratos/kratos/v2/middleware/logging"
@@ -9,7 +10,6 @@ import (
 	"github.com/go-kratos/kratos/v2/middleware/recovery"
 	"github.com/go-kratos/kratos/v2/middleware/tracing"
 	"github.com/go-kratos/kratos/v2/middleware/validate"
-	rawGrpc "google.golang.org/grpc"
 
 	"github.com/go-kratos/kratos/v2/transport/grpc"
 	"github.com/tx7do/kratos-bootstrap/bootstrap"
@@ -104,12 +104,17 @@ func NewGRPCServer(
 
 	opts = append(opts, grpc.Middleware(ms...))
 
-	// Allow large backup payloads (100MB)
-	maxMsgSize := 100 * 1024 * 1024
-	opts = append(opts, grpc.Options(
-		rawGrpc.MaxRecvMsgSize(maxMsgSize),
-		rawGrpc.MaxSendMsgSize(maxMsgSize),
-	))
+	// Allow large backup payloads (100MB unless overridden)
+	limits := service.ServerMessageSizeLimits()
+	l.Infof("gRPC max message size: recv=%d send=%d bytes", limits.MaxRecv, limits.MaxSend)
+	opts = append(opts, grpc.Options(limits.ServerOptions()...))
+
+	// Kratos registers gRPC server reflection by default so grpcurl and evans
+	// can explore the API; it is still behind mTLS when that is enabled.
+	if os.Getenv("BACKUP_GRPC_REFLECTION") == "false" {
+		opts = append(opts, grpc.DisableReflection())
+		l.Info("gRPC server reflection disabled")
+	}
 
 	srv := grpc.NewServer(opts...)
 
@@ -1,18 +1,103 @@
 package server
 
 import (
+	"context"
+	"crypto/subtle"
+	"fmt"
 	"io/fs"
 	"net/http"
 	"os"
+	"strings"
 
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/middleware/logging"
+	"github.com/go-kratos/kratos/v2/middleware/recovery"
+	"github.com/go-kratos/kratos/v2/middleware/tracing"
+	"github.com/go-kratos/kratos/v2/middleware/validate"
+	"github.com/go-kratos/kratos/v2/transport"
 	kratosHttp "github.com/go-kratos/kratos/v2/transport/http"
 	"github.com/tx7do/kratos-bootstrap/bootstrap"
+	grpcMD "google.golang.org/grpc/metadata"
+
+	"github.com/go-tangra/go-tangra-common/middleware/audit"
 
 	"github.com/go-tangra/go-tangra-backup/cmd/server/assets"
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+	"github.com/go-tangra/go-tangra-backup/internal/service"
 )
 
-// NewHTTPServer creates a simple HTTP server for serving the frontend assets.
-func NewHTTPServer(ctx *bootstrap.Context) *kratosHttp.Server {
+type presignedKey struct{}
+
+// withPresigned marks a request as authorized by a presigned download link.
+func withPresigned(ctx context.Context) context.Context {
+	return context.WithValue(ctx, presignedKey{}, true)
+}
+
+// bearerTokenMiddleware rejects requests whose Authorization header does not
+// carry the given bearer token, unless a presigned link authorized them.
+func bearerTokenMiddleware(token string) middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			if presigned, _ := ctx.Value(presignedKey{}).(bool); presigned {
+				return handler(ctx, req)
+			}
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok {
+				return nil, errors.Unauthorized("UNAUTHORIZED", "missing transport")
+			}
+			got, found := strings.CutPrefix(tr.RequestHeader().Get("Authorization"), "Bearer ")
+			if !found || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+				return nil, errors.Unauthorized("UNAUTHORIZED", "invalid or missing bearer token")
+			}
+			return handler(ctx, req)
+		}
+	}
+}
+
+// callerIdentityHeaders carry the caller's identity on REST requests, as the
+// gateway forwards it to gRPC services as metadata.
+var callerIdentityHeaders = []string{"x-md-global-user-id", "x-md-global-username", "x-md-global-roles"}
+
+// callerIdentityMiddleware moves the caller identity headers of a REST
+// request into the incoming metadata the service authorizes against, and
+// rejects requests without one, unless a presigned link authorized them.
+func callerIdentityMiddleware() middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			if presigned, _ := ctx.Value(presignedKey{}).(bool); presigned {
+				return handler(ctx, req)
+			}
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok {
+				return nil, errors.Unauthorized("UNAUTHORIZED", "missing transport")
+			}
+			md := grpcMD.MD{}
+			for _, key := range callerIdentityHeaders {
+				if v := tr.RequestHeader().Get(key); v != "" {
+					md.Set(key, v)
+				}
+			}
+			if len(md) == 0 {
+				return nil, errors.Unauthorized("CALLER_IDENTITY_REQUIRED", "missing caller identity headers")
+			}
+			if v := tr.RequestHeader().Get("x-md-global-tenant-id"); v != "" {
+				md.Set("x-md-global-tenant-id", v)
+			}
+			return handler(grpcMD.NewIncomingContext(ctx, md), req)
+		}
+	}
+}
+
+// NewHTTPServer creates the HTTP server for the frontend assets and the backup
+// freshness metrics. With BACKUP_HTTP_API=true it also serves the
+// BackupOrchestratorService RPCs as REST endpoints (see openapi.yaml), plus
+// resumable payload downloads (see registerDownloadRoutes). That listener
+// has no mTLS, so the REST API requires BACKUP_HTTP_API_TOKEN: callers send
+// it as a bearer token, along with the identity of the user they act for in
+// the x-md-global-user-id, x-md-global-username, x-md-global-roles and
+// x-md-global-tenant-id headers, as the gateway does for gRPC.
+func NewHTTPServer(ctx *bootstrap.Context, orchestratorSvc *service.OrchestratorService) (*kratosHttp.Server, error) {
 	l := ctx.NewLoggerHelper("backup/http")
 
 	addr := os.Getenv("BACKUP_HTTP_ADDR")
@@ -20,13 +105,36 @@ func NewHTTPServer(ctx *bootstrap.Context) *kratosHttp.Server {
 		addr = "0.0.0.0:10101"
 	}
 
-	srv := kratosHttp.NewServer(kratosHttp.Address(addr))
+	restAPI := os.Getenv("BACKUP_HTTP_API") == "true"
+	token := os.Getenv("BACKUP_HTTP_API_TOKEN")
+	if restAPI && token == "" {
+		return nil, fmt.Errorf("BACKUP_HTTP_API=true requires BACKUP_HTTP_API_TOKEN")
+	}
+
+	var ms []middleware.Middleware
+	ms = append(ms, recovery.Recovery())
+	ms = append(ms, systemViewerMiddleware())
+	ms = append(ms, tracing.Server())
+	ms = append(ms, logging.Server(ctx.GetLogger()))
+	if token != "" {
+		ms = append(ms, bearerTokenMiddleware(token))
+	}
+	ms = append(ms, callerIdentityMiddleware())
+	ms = append(ms, audit.Server(ctx.GetLogger(), audit.WithServiceName("backup-service")))
+	ms = append(ms, validate.Validator())
+
+	srv := kratosHttp.NewServer(kratosHttp.Address(addr), kratosHttp.Middleware(ms...))
 
 	route := srv.Route("/")
 	route.GET("/health", func(ctx kratosHttp.Context) error {
 		return ctx.JSON(http.StatusOK, map[string]string{"status": "ok"})
 	})
 
+	route.GET("/metrics", func(ctx kratosHttp.Context) error {
+		ctx.Response().Header().Set("Content-Type", "text/plain; version=0.0.4")
+		return orchestratorSvc.WriteFreshnessMetrics(ctx.Response())
+	})
+
 	route.GET("/openapi.yaml", func(ctx kratosHttp.Context) error {
 		ctx.Response().Header().Set("Content-Type", "application/yaml")
 		_, err := ctx.Response().Write(assets.OpenApiData)
@@ -46,7 +154,11 @@ func NewHTTPServer(ctx *bootstrap.Context) *kratosHttp.Server {
 		return err
 	})
 
-
+	if restAPI {
+		backupV1.RegisterBackupOrchestratorServiceHTTPServer(srv, orchestratorSvc)
+		registerDownloadRoutes(srv, orchestratorSvc)
+		l.Info("REST API enabled, bearer token and caller identity required")
+	}
 
 	fsys, err := fs.Sub(assets.FrontendDist, "frontend-dist")
 	if err == nil {
@@ -58,5 +170,5 @@ func NewHTTPServer(ctx *bootstrap.Context) *kratosHttp.Server {
 	}
 
 	l.Infof("HTTP server listening on %s", addr)
-	return srv
+	return srv, nil
 }
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Throttle caps the bandwidth of bulk transfers so backups do not starve
+// production traffic. Each transfer goes to a destination:
+//
+//	module:<id>   exports from and imports into a module
+//	replication   backups sent to the secondary orchestrator
+//	federation    backups pulled from, or served to, a DR orchestrator
+//	download      backup data served to clients
+//
+// and waits on the global limit and on the limit of its destination, if any:
+//
+//	BACKUP_BANDWIDTH_LIMIT_KBPS  all transfers together, in KiB/s (default 0, unlimited)
+//	BACKUP_BANDWIDTH_OVERRIDES   per destination, e.g. "module:ipam=2048,replication=10240"
+//
+// Both follow runtime config reloads. A nil Throttle does not limit anything.
+type Throttle struct {
+	log *log.Helper
+
+	mu     sync.Mutex
+	global *rateLimiter
+	dest   map[string]*rateLimiter
+}
+
+// NewThrottle reads the limits and re-reads them after every runtime config
+// reload.
+func NewThrottle(ctx *bootstrap.Context, runtime *RuntimeConfig) *Throttle {
+	t := newThrottle(ctx.NewLoggerHelper("backup/throttle"))
+	runtime.OnReload(t.reload)
+	return t
+}
+
+func newThrottle(l *log.Helper) *Throttle {
+	t := &Throttle{log: l, global: &rateLimiter{}, dest: map[string]*rateLimiter{}}
+	t.reload()
+	return t
+}
+
+func (t *Throttle) reload() {
+	rates := map[string]int{}
+	for _, entry := range envList("BACKUP_BANDWIDTH_OVERRIDES") {
+		dest, kbps, ok := strings.Cut(entry, "=")
+		n, err := strconv.Atoi(strings.TrimSpace(kbps))
+		if !ok || err != nil || n < 0 || !validThrottleDest(strings.TrimSpace(dest)) {
+			t.log.Warnf("Ignoring malformed bandwidth override %q", entry)
+			continue
+		}
+		rates[strings.TrimSpace(dest)] = n
+	}
+	global := envInt("BACKUP_BANDWIDTH_LIMIT_KBPS", 0)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.global.setRate(global)
+	for dest, l := range t.dest {
+		if _, ok := rates[dest]; !ok {
+			l.setRate(0)
+			delete(t.dest, dest)
+		}
+	}
+	for dest, kbps := range rates {
+		l, ok := t.dest[dest]
+		if !ok {
+			l = &rateLimiter{}
+			t.dest[dest] = l
+		}
+		l.setRate(kbps)
+	}
+	if global > 0 || len(rates) > 0 {
+		t.log.Infof("Bandwidth limited to %d KiB/s overall (0 is unlimited), %d destination overrides", global, len(rates))
+	}
+}
+
+func validThrottleDest(dest string) bool {
+	switch dest {
+	case "replication", "federation", "download":
+		return true
+	}
+	id, ok := strings.CutPrefix(dest, "module:")
+	return ok && id != ""
+}
+
+// wait blocks until n bytes to dest may pass or ctx ends, and then returns
+// the context's error as a gRPC status.
+func (t *Throttle) wait(ctx context.Context, dest string, n int) error {
+	if t == nil || n <= 0 {
+		return nil
+	}
+	t.mu.Lock()
+	delay := t.global.reserve(n)
+	if l := t.dest[dest]; l != nil {
+		delay = max(delay, l.reserve(n))
+	}
+	t.mu.Unlock()
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return status.FromContextError(ctx.Err()).Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// dialOptions throttles every message sent or received on a connection to
+// dest.
+func (t *Throttle) dialOptions(dest string) []grpc.DialOption {
+	if t == nil {
+		return nil
+	}
+	unary := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if err := t.wait(ctx, dest, messageSize(req)); err != nil {
+			return err
+		}
+		if err := invoker(ctx, method, req, reply, cc, opts...); err != nil {
+			return err
+		}
+		return t.wait(ctx, dest, messageSize(reply))
+	}
+	stream := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		s, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return &throttledStream{ClientStream: s, t: t, dest: dest}, nil
+	}
+	return []grpc.DialOption{grpc.WithChainUnaryInterceptor(unary), grpc.WithChainStreamInterceptor(stream)}
+}
+
+type throttledStream struct {
+	grpc.ClientStream
+	t    *Throttle
+	dest string
+}
+
+func (s *throttledStream) SendMsg(m any) error {
+	if err := s.t.wait(s.Context(), s.dest, messageSize(m)); err != nil {
+		return err
+	}
+	return s.ClientStream.SendMsg(m)
+}
+
+func (s *throttledStream) RecvMsg(m any) error {
+	if err := s.ClientStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.t.wait(s.Context(), s.dest, messageSize(m))
+}
+
+func messageSize(m any) int {
+	if pm, ok := m.(proto.Message); ok {
+		return proto.Size(pm)
+	}
+	return 0
+}
+
+// readSeeker throttles what is read from r to dest, for content served over
+// HTTP.
+func (t *Throttle) readSeeker(ctx context.Context, dest string, r io.ReadSeeker) io.ReadSeeker {
+	if t == nil {
+		return r
+	}
+	return &throttledReader{ReadSeeker: r, ctx: ctx, t: t, dest: dest}
+}
+
+type throttledReader struct {
+	io.ReadSeeker
+	ctx  context.Context
+	t    *Throttle
+	dest string
+}
+
+func (r *throttledReader) Read(p []byte) (int, error) {
+	n, err := r.ReadSeeker.Read(p)
+	if werr := r.t.wait(r.ctx, r.dest, n); werr != nil {
+		return n, werr
+	}
+	return n, err
+}
+
+// rateLimiter is a token bucket holding up to a second's worth of bytes.
+// Callers reserve what they send and wait until the bucket has refilled, so
+// a message larger than the bucket still passes, at the limit's pace.
+type rateLimiter struct {
+	rate   float64 // bytes per second; 0 is unlimited
+	tokens float64
+	last   time.Time
+}
+
+// setRate changes the limit, in KiB/s, keeping what is reserved already.
+func (l *rateLimiter) setRate(kbps int) {
+	l.refill(time.Now())
+	unlimited := l.rate <= 0
+	l.rate = float64(max(kbps, 0)) * 1024
+	if unlimited {
+		l.tokens = l.rate
+	}
+	l.tokens = min(l.tokens, l.rate)
+}
+
+func (l *rateLimiter) refill(now time.Time) {
+	if l.rate > 0 {
+		l.tokens = min(l.rate, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+}
+
+// reserve takes n bytes from the bucket and returns how long to wait before
+// sending them.
+func (l *rateLimiter) reserve(n int) time.Duration {
+	if l.rate <= 0 {
+		return 0
+	}
+	l.refill(time.Now())
+	l.tokens -= float64(n)
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
@@ -0,0 +1,556 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+)
+
+// Bootstrap restores take their settings from the environment:
+//
+//	BACKUP_BOOTSTRAP_PROFILE_DIR  directory of environment profiles, <name>.json each (BootstrapProfile as JSON)
+//	BACKUP_MODULE_DEPENDENCIES    modules restored before others, e.g. "asset=ipam+warden,hr=notification"
+
+// Module states in a bootstrap plan.
+const (
+	bootstrapReady     = "ready"
+	bootstrapBlocked   = "blocked"
+	bootstrapSkipped   = "skipped"
+	bootstrapRestoring = "restoring"
+	bootstrapRestored  = "restored"
+	bootstrapFailed    = "failed"
+)
+
+// BootstrapRestore restores every module of a full backup into an
+// environment described by a profile, typically one rebuilt from scratch
+// after a disaster. It plans first: which module goes to which endpoint, in
+// what order their dependencies allow, and whether the running modules are
+// recent enough for the data. Then it checks every target answers and the
+// data can be read with the password, and only when nothing is blocked
+// restores the modules wave by wave. A module whose dependency failed is
+// skipped. Every step is streamed as it happens.
+func (s *OrchestratorService) BootstrapRestore(req *backupV1.BootstrapRestoreRequest, stream grpc.ServerStreamingServer[backupV1.BootstrapRestoreEvent]) error {
+	ctx := stream.Context()
+	if !isPlatformAdmin(ctx) {
+		return status.Error(codes.PermissionDenied, "only a platform admin can bootstrap a platform")
+	}
+	if !validPathElement(req.BackupId) {
+		return status.Error(codes.InvalidArgument, "invalid backup ID")
+	}
+	profile, err := bootstrapProfile(req.ProfileName, req.Profile)
+	if err != nil {
+		return err
+	}
+
+	ctx, done, err := s.jobs.Begin(ctx, "bootstrap restore")
+	if err != nil {
+		return err
+	}
+	defer done()
+	ctx, unlock, err := s.storage.LockOperations(ctx, "restore")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	reader := s.sources.reader(backupRef{id: req.BackupId, full: true}, req.Password)
+	defer reader.Close()
+	info, err := reader.fullInfo(ctx)
+	if err != nil {
+		return fmt.Errorf("get full backup: %w", err)
+	}
+	if info.Encrypted && req.Password == "" {
+		return status.Error(codes.InvalidArgument, "backup is encrypted: password required")
+	}
+
+	ctx, requestID := ensureRequestID(ctx)
+	started := time.Now()
+	send := func(phase string, ev *backupV1.BootstrapRestoreEvent, format string, args ...any) error {
+		ev.Time = timestamppb.Now()
+		ev.Phase = phase
+		ev.Message = fmt.Sprintf(format, args...)
+		return stream.Send(ev)
+	}
+	s.log.Infof("Bootstrapping from full backup %s (profile=%q dry_run=%v request=%s)", req.BackupId, req.ProfileName, req.DryRun, requestID)
+
+	plan, targets, err := s.bootstrapPlan(ctx, info, profile)
+	if err != nil {
+		return err
+	}
+	if err := send("validate", planEvent(plan), "Planned %d modules in %d waves", len(plan.Modules), planWaves(plan)); err != nil {
+		return err
+	}
+
+	s.bootstrapPreflight(ctx, reader, plan, targets)
+	blockers := planBlockers(plan)
+	if err := send("preflight", planEvent(plan), "Pre-flight checks: %s", readiness(blockers)); err != nil {
+		return err
+	}
+	if req.DryRun {
+		return send("done", &backupV1.BootstrapRestoreEvent{Payload: &backupV1.BootstrapRestoreEvent_Result{
+			Result: &backupV1.BootstrapResult{Success: plan.Ready, DurationMs: time.Since(started).Milliseconds(), RequestId: requestID},
+		}}, "Dry run: nothing restored")
+	}
+	if !plan.Ready {
+		return status.Errorf(codes.FailedPrecondition, "pre-flight checks failed: %s", strings.Join(blockers, "; "))
+	}
+
+	restoreEvent := &RestoreEvent{BackupID: req.BackupId, Kind: "full"}
+	for _, m := range plan.Modules {
+		if m.State == bootstrapReady {
+			restoreEvent.Modules = append(restoreEvent.Modules, m.ModuleId)
+		}
+	}
+	s.events.Publish(ctx, EventRestoreStarted, restoreEvent)
+
+	result := &backupV1.BootstrapResult{RequestId: requestID}
+	unrestored := map[string]bool{}
+	var restoreErrors []string
+	for _, m := range plan.Modules {
+		if m.State != bootstrapReady {
+			result.Skipped++
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("bootstrap restore aborted before %s: %w", m.ModuleId, err)
+		}
+		// Modules the plan leaves out do not hold up their dependents: the
+		// plan already warned about them.
+		if i := slices.IndexFunc(m.DependsOn, func(dep string) bool { return unrestored[dep] }); i >= 0 {
+			m.State = bootstrapSkipped
+			m.Problems = append(m.Problems, fmt.Sprintf("dependency %s was not restored", m.DependsOn[i]))
+			result.Skipped++
+			unrestored[m.ModuleId] = true
+			restoreErrors = append(restoreErrors, fmt.Sprintf("%s: dependency %s was not restored", m.ModuleId, m.DependsOn[i]))
+			if err := send("restore", moduleEvent(m), "Skipped %s: dependency %s was not restored", m.ModuleId, m.DependsOn[i]); err != nil {
+				return err
+			}
+			continue
+		}
+
+		m.State = bootstrapRestoring
+		if err := send("restore", moduleEvent(m), "Restoring %s to %s (wave %d)", m.ModuleId, m.GrpcEndpoint, m.Wave+1); err != nil {
+			return err
+		}
+		moduleStarted := time.Now()
+		m.Result = s.restoreFullModule(ctx, reader, req.BackupId, targets[m.ModuleId], req.Mode, requestID)
+		m.DurationMs = time.Since(moduleStarted).Milliseconds()
+		msg := fmt.Sprintf("Restored %s in %s", m.ModuleId, time.Since(moduleStarted).Round(time.Millisecond))
+		if m.Result.Success {
+			m.State = bootstrapRestored
+			result.Restored++
+		} else {
+			m.State = bootstrapFailed
+			problem := m.Result.Error
+			if problem == "" {
+				problem = "the module reported the import failed"
+			}
+			m.Problems = append(m.Problems, problem)
+			result.Failed++
+			unrestored[m.ModuleId] = true
+			restoreErrors = append(restoreErrors, fmt.Sprintf("%s: %s", m.ModuleId, problem))
+			msg = fmt.Sprintf("Restoring %s failed: %s", m.ModuleId, problem)
+		}
+		if err := send("restore", moduleEvent(m), "%s", msg); err != nil {
+			return err
+		}
+	}
+
+	result.Success = result.Failed == 0
+	result.DurationMs = time.Since(started).Milliseconds()
+	s.events.Publish(ctx, EventRestoreCompleted, restoreEvent.finished(result.Success, restoreErrors))
+	s.log.Infof("Bootstrap from full backup %s completed: restored=%d failed=%d skipped=%d", req.BackupId, result.Restored, result.Failed, result.Skipped)
+	return send("done", &backupV1.BootstrapRestoreEvent{Payload: &backupV1.BootstrapRestoreEvent_Result{Result: result}},
+		"Restored %d modules, %d failed, %d skipped", result.Restored, result.Failed, result.Skipped)
+}
+
+// bootstrapProfile loads the named environment profile and merges the
+// inline one over it: inline targets win on module ID, dependencies and
+// skipped modules add up.
+func bootstrapProfile(name string, inline *backupV1.BootstrapProfile) (*backupV1.BootstrapProfile, error) {
+	profile := &backupV1.BootstrapProfile{}
+	if name != "" {
+		dir := os.Getenv("BACKUP_BOOTSTRAP_PROFILE_DIR")
+		if dir == "" {
+			return nil, status.Errorf(codes.FailedPrecondition, "profile %q requested but BACKUP_BOOTSTRAP_PROFILE_DIR is not set", name)
+		}
+		if !validPathElement(name) {
+			return nil, status.Error(codes.InvalidArgument, "invalid profile name")
+		}
+		data, err := os.ReadFile(filepath.Join(dir, name+".json"))
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, status.Errorf(codes.NotFound, "no profile %q in %s", name, dir)
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "read profile %q: %v", name, err)
+		}
+		if err := protojson.Unmarshal(data, profile); err != nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "profile %q: %v", name, err)
+		}
+	}
+	if inline == nil {
+		return profile, nil
+	}
+
+	targets := slices.Clone(inline.Targets)
+	for _, t := range profile.Targets {
+		if !slices.ContainsFunc(inline.Targets, func(it *backupV1.ModuleTarget) bool { return it.ModuleId == t.ModuleId }) {
+			targets = append(targets, t)
+		}
+	}
+	profile.Targets = targets
+	if inline.TargetSelector != "" {
+		profile.TargetSelector = inline.TargetSelector
+	}
+	profile.Dependencies = append(profile.Dependencies, inline.Dependencies...)
+	profile.SkipModules = append(profile.SkipModules, inline.SkipModules...)
+	profile.AllowOlderModules = profile.AllowOlderModules || inline.AllowOlderModules
+	return profile, nil
+}
+
+// moduleDependencies reads BACKUP_MODULE_DEPENDENCIES and adds the
+// profile's dependencies to it.
+func moduleDependencies(profile []*backupV1.ModuleDependency) map[string][]string {
+	deps := map[string][]string{}
+	add := func(id, dep string) {
+		if id != "" && dep != "" && id != dep && !slices.Contains(deps[id], dep) {
+			deps[id] = append(deps[id], dep)
+		}
+	}
+	for _, entry := range envList("BACKUP_MODULE_DEPENDENCIES") {
+		id, list, _ := strings.Cut(entry, "=")
+		for _, dep := range strings.Split(list, "+") {
+			add(strings.TrimSpace(id), strings.TrimSpace(dep))
+		}
+	}
+	for _, d := range profile {
+		for _, dep := range d.DependsOn {
+			add(d.ModuleId, dep)
+		}
+	}
+	return deps
+}
+
+// bootstrapPlan works out what restoring the backup into the profile's
+// environment takes, and returns the plan with the target of every module.
+func (s *OrchestratorService) bootstrapPlan(ctx context.Context, info *backupV1.FullBackupInfo, profile *backupV1.BootstrapProfile) (*backupV1.BootstrapPlan, map[string]*backupV1.ModuleTarget, error) {
+	plan := &backupV1.BootstrapPlan{BackupId: info.Id, BackupCreatedAt: info.CreatedAt}
+
+	list := profile.Targets
+	if profile.TargetSelector != "" {
+		var err error
+		if list, err = s.selectTargets(ctx, profile.TargetSelector, list); err != nil {
+			return nil, nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+		}
+	} else if len(list) == 0 {
+		list = defaultModuleTargets()
+		plan.Warnings = append(plan.Warnings, "the profile lists no targets: using the default module endpoints")
+	}
+	targets := make(map[string]*backupV1.ModuleTarget, len(list))
+	for _, t := range list {
+		targets[t.ModuleId] = t
+	}
+
+	var versions map[string]string
+	if os.Getenv("ADMIN_GRPC_ENDPOINT") == "" {
+		plan.Warnings = append(plan.Warnings, "ADMIN_GRPC_ENDPOINT is not set: module versions are not checked")
+	} else if registered, err := s.moduleClient.RegisteredModules(ctx); err != nil {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("module versions are not checked: %v", err))
+	} else {
+		versions = make(map[string]string, len(registered))
+		for _, m := range registered {
+			versions[m.GetModuleId()] = m.GetVersion()
+		}
+	}
+
+	skip := map[string]bool{}
+	for _, id := range profile.SkipModules {
+		skip[id] = true
+	}
+	deps := moduleDependencies(profile.Dependencies)
+	inBackup := map[string]bool{}
+	var included []string
+	for _, mb := range info.ModuleBackups {
+		inBackup[mb.ModuleId] = true
+		m := &backupV1.BootstrapModule{
+			ModuleId:      mb.ModuleId,
+			DependsOn:     deps[mb.ModuleId],
+			BackupVersion: mb.Version,
+			SchemaVersion: mb.SchemaVersion,
+			SizeBytes:     mb.SizeBytes,
+			State:         bootstrapReady,
+		}
+		plan.Modules = append(plan.Modules, m)
+		switch {
+		case mb.Status != "completed":
+			m.State = bootstrapSkipped
+			m.Problems = append(m.Problems, fmt.Sprintf("the backup holds no data for it (export %s)", mb.Status))
+			continue
+		case skip[mb.ModuleId]:
+			m.State = bootstrapSkipped
+			m.Problems = append(m.Problems, "left out by the profile")
+			continue
+		}
+		included = append(included, mb.ModuleId)
+
+		target, ok := targets[mb.ModuleId]
+		if !ok {
+			m.State = bootstrapBlocked
+			m.Problems = append(m.Problems, "the profile has no target for it")
+		} else {
+			m.GrpcEndpoint = target.GrpcEndpoint
+		}
+		if versions != nil {
+			version, registered := versions[mb.ModuleId]
+			if !registered {
+				m.Warnings = append(m.Warnings, "not registered with the admin service: version not checked")
+			}
+			m.TargetVersion = version
+			checkModuleVersion(m, profile.AllowOlderModules)
+		}
+	}
+	for _, id := range profile.SkipModules {
+		if !inBackup[id] {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("skip_modules names %s, which is not in the backup", id))
+		}
+	}
+
+	for _, m := range plan.Modules {
+		if m.State == bootstrapSkipped {
+			continue
+		}
+		for _, dep := range m.DependsOn {
+			switch {
+			case !inBackup[dep]:
+				m.Warnings = append(m.Warnings, fmt.Sprintf("depends on %s, which the backup holds no data for", dep))
+			case !slices.Contains(included, dep):
+				m.Warnings = append(m.Warnings, fmt.Sprintf("depends on %s, which is not restored", dep))
+			}
+		}
+	}
+	waves, cycle := dependencyWaves(included, deps)
+	if len(cycle) > 0 {
+		plan.Problems = append(plan.Problems, fmt.Sprintf("dependency cycle: %s cannot be ordered", strings.Join(cycle, ", ")))
+		for _, m := range plan.Modules {
+			if slices.Contains(cycle, m.ModuleId) {
+				m.State = bootstrapBlocked
+				m.Problems = append(m.Problems, "on or behind a dependency cycle")
+			}
+		}
+	}
+	for _, m := range plan.Modules {
+		m.Wave = int32(waves[m.ModuleId])
+	}
+
+	// Modules restore by wave, then in the order the backup took them;
+	// the ones not restored at all go last.
+	sort.SliceStable(plan.Modules, func(i, j int) bool {
+		a, b := plan.Modules[i], plan.Modules[j]
+		if (a.State == bootstrapSkipped) != (b.State == bootstrapSkipped) {
+			return b.State == bootstrapSkipped
+		}
+		return a.Wave < b.Wave
+	})
+	plan.Ready = len(planBlockers(plan)) == 0
+	return plan, targets, nil
+}
+
+// checkModuleVersion compares the version a module's data was backed up
+// from with the version running in the environment. Data from a newer
+// module may use what the older one cannot read; data from an older one is
+// migrated by the import.
+func checkModuleVersion(m *backupV1.BootstrapModule, allowOlder bool) {
+	if m.TargetVersion == "" || m.BackupVersion == "" || m.TargetVersion == m.BackupVersion {
+		if m.BackupVersion == "" {
+			m.Warnings = append(m.Warnings, "the backup does not record the module version")
+		}
+		return
+	}
+	cmp, ok := compareVersions(m.TargetVersion, m.BackupVersion)
+	switch {
+	case !ok:
+		m.Warnings = append(m.Warnings, fmt.Sprintf("runs %s, backed up from %s: versions not comparable", m.TargetVersion, m.BackupVersion))
+	case cmp < 0 && allowOlder:
+		m.Warnings = append(m.Warnings, fmt.Sprintf("runs %s, older than %s it was backed up from", m.TargetVersion, m.BackupVersion))
+	case cmp < 0:
+		m.State = bootstrapBlocked
+		m.Problems = append(m.Problems, fmt.Sprintf("runs %s, older than %s it was backed up from (allow_older_modules to restore anyway)", m.TargetVersion, m.BackupVersion))
+	case cmp > 0:
+		m.Warnings = append(m.Warnings, fmt.Sprintf("runs %s, newer than %s it was backed up from: the import migrates the data", m.TargetVersion, m.BackupVersion))
+	}
+}
+
+// compareVersions compares dotted numeric versions such as "v1.4.2",
+// ignoring pre-release and build suffixes. It reports false when either is
+// not such a version.
+func compareVersions(a, b string) (int, bool) {
+	parse := func(v string) ([]int, bool) {
+		v = strings.TrimPrefix(v, "v")
+		if i := strings.IndexAny(v, "-+"); i >= 0 {
+			v = v[:i]
+		}
+		var parts []int
+		for _, p := range strings.Split(v, ".") {
+			n, err := strconv.Atoi(p)
+			if err != nil || n < 0 {
+				return nil, false
+			}
+			parts = append(parts, n)
+		}
+		return parts, true
+	}
+	pa, okA := parse(a)
+	pb, okB := parse(b)
+	if !okA || !okB {
+		return 0, false
+	}
+	for i := 0; i < max(len(pa), len(pb)); i++ {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		if x != y {
+			if x < y {
+				return -1, true
+			}
+			return 1, true
+		}
+	}
+	return 0, true
+}
+
+// dependencyWaves puts each module one wave after the last of its
+// dependencies among the modules, so that wave by wave every module comes
+// after what it depends on. Modules that cannot be placed, because they are
+// on a dependency cycle or depend on one, are returned instead.
+func dependencyWaves(modules []string, deps map[string][]string) (map[string]int, []string) {
+	waves := make(map[string]int, len(modules))
+	remaining := modules
+	for wave := 0; len(remaining) > 0; wave++ {
+		var placed, left []string
+		for _, id := range remaining {
+			waiting := slices.ContainsFunc(deps[id], func(dep string) bool {
+				_, done := waves[dep]
+				return slices.Contains(modules, dep) && !done
+			})
+			if waiting {
+				left = append(left, id)
+			} else {
+				placed = append(placed, id)
+			}
+		}
+		if len(placed) == 0 {
+			return waves, left
+		}
+		for _, id := range placed {
+			waves[id] = wave
+		}
+		remaining = left
+	}
+	return waves, nil
+}
+
+// bootstrapPreflight checks every target of the plan answers, and that the
+// backup's data can be read with the password by loading the smallest
+// module. A module whose target fails the check is blocked.
+func (s *OrchestratorService) bootstrapPreflight(ctx context.Context, reader *restoreReader, plan *backupV1.BootstrapPlan, targets map[string]*backupV1.ModuleTarget) {
+	var wg sync.WaitGroup
+	var smallest *backupV1.BootstrapModule
+	for _, m := range plan.Modules {
+		if m.State == bootstrapSkipped || m.GrpcEndpoint == "" {
+			continue
+		}
+		if smallest == nil || m.SizeBytes < smallest.SizeBytes {
+			smallest = m
+		}
+		wg.Add(1)
+		go func(m *backupV1.BootstrapModule) {
+			defer wg.Done()
+			m.Preflight = s.moduleClient.Preflight(ctx, targets[m.ModuleId])
+		}(m)
+	}
+	wg.Wait()
+
+	for _, m := range plan.Modules {
+		switch {
+		case m.Preflight == nil:
+		case !m.Preflight.Reachable:
+			m.State = bootstrapBlocked
+			m.Problems = append(m.Problems, fmt.Sprintf("target %s is unreachable: %s", m.GrpcEndpoint, m.Preflight.Error))
+		case m.Preflight.Health == "NOT_SERVING":
+			m.State = bootstrapBlocked
+			m.Problems = append(m.Problems, fmt.Sprintf("target %s reports NOT_SERVING", m.GrpcEndpoint))
+		}
+	}
+
+	if smallest != nil {
+		if _, _, warnings, err := reader.load(ctx, smallest.ModuleId); err != nil {
+			if errors.Is(err, ErrBackupPassword) {
+				plan.Problems = append(plan.Problems, "the password does not open the backup")
+			} else {
+				plan.Problems = append(plan.Problems, fmt.Sprintf("data of %s cannot be read: %v", smallest.ModuleId, err))
+			}
+		} else {
+			plan.Warnings = append(plan.Warnings, warnings...)
+		}
+	}
+	plan.Ready = len(planBlockers(plan)) == 0
+}
+
+// planBlockers lists what stops the plan from running.
+func planBlockers(plan *backupV1.BootstrapPlan) []string {
+	blockers := slices.Clone(plan.Problems)
+	for _, m := range plan.Modules {
+		if m.State == bootstrapBlocked {
+			blockers = append(blockers, fmt.Sprintf("%s: %s", m.ModuleId, strings.Join(m.Problems, ", ")))
+		}
+	}
+	return blockers
+}
+
+func readiness(blockers []string) string {
+	if len(blockers) == 0 {
+		return "ready"
+	}
+	if len(blockers) == 1 {
+		return "1 problem"
+	}
+	return fmt.Sprintf("%d problems", len(blockers))
+}
+
+func planWaves(plan *backupV1.BootstrapPlan) int {
+	waves := 0
+	for _, m := range plan.Modules {
+		if m.State != bootstrapSkipped {
+			waves = max(waves, int(m.Wave)+1)
+		}
+	}
+	return waves
+}
+
+func planEvent(plan *backupV1.BootstrapPlan) *backupV1.BootstrapRestoreEvent {
+	return &backupV1.BootstrapRestoreEvent{Payload: &backupV1.BootstrapRestoreEvent_Plan{Plan: plan}}
+}
+
+func moduleEvent(m *backupV1.BootstrapModule) *backupV1.BootstrapRestoreEvent {
+	return &backupV1.BootstrapRestoreEvent{Payload: &backupV1.BootstrapRestoreEvent_Module{Module: m}}
+}
@@ -0,0 +1,386 @@
+package service
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// A repository bundle holds backups for cold offline storage in one file:
+//
+//	"TBUNDLE1" | header length (4B) | header (JSON) | chunks | signature (64B)
+//
+// The header is in the clear, so a bundle's signer and date can be told
+// without its password. The chunks are a tar archive laid out as the storage
+// is, under modules/<id>/ and full/<id>/, plus catalog.json and format.json,
+// encrypted with AES-256-GCM under a key derived from the password. Each
+// chunk is its length (4B, the top bit marking the last chunk) and the
+// sealed data; the nonce counts chunks and the additional data binds each to
+// the header and says whether it is the last, so chunks cannot be reordered,
+// dropped or cut off unnoticed. The signature is Ed25519 over the SHA-256 of
+// everything before it.
+//
+//	BACKUP_BUNDLE_SIGNING_KEY   PEM file with the Ed25519 private key (PKCS #8) bundles are signed with; needed to export
+//	BACKUP_BUNDLE_TRUSTED_KEYS  comma-separated PEM files with public keys (PKIX); when set, only bundles they signed are imported
+
+// BundleVersion is the bundle format this build writes and the newest it
+// reads.
+const BundleVersion = 1
+
+const (
+	bundleChunkSize = 1 << 20
+	bundleFinal     = 1 << 31
+)
+
+var bundleMagic = []byte("TBUNDLE1")
+
+// errBundlePassword means the bundle could not be decrypted: the password is
+// wrong or the bundle was altered.
+var errBundlePassword = errors.New("wrong password, or the bundle is damaged")
+
+type bundleHeader struct {
+	Version    int       `json:"version"`
+	CreatedAt  time.Time `json:"createdAt"`
+	CreatedBy  string    `json:"createdBy"`
+	Site       string    `json:"site,omitempty"`
+	SignerKey  []byte    `json:"signerKey"` // Ed25519 public key
+	Salt       []byte    `json:"salt"`
+	Iterations int       `json:"iterations"`
+	Nonce      []byte    `json:"nonce"` // prefix; the chunk counter follows
+}
+
+// bundleFormat is format.json: what a reader needs to know about the
+// backups in the bundle.
+type bundleFormat struct {
+	BundleVersion int    `json:"bundleVersion"`
+	DataFormat    int    `json:"dataFormat"` // FormatVersion of the data files, at most
+	Metadata      string `json:"metadata"`   // encoding of metadata.json
+	ModuleBackups int    `json:"moduleBackups"`
+	FullBackups   int    `json:"fullBackups"`
+}
+
+// bundleWriter writes a bundle: the header on creation, the archive through
+// Write, and the last chunk and signature on Close.
+type bundleWriter struct {
+	w      io.Writer
+	sum    func() []byte // of everything written so far, for the signature
+	key    ed25519.PrivateKey
+	aead   cipher.AEAD
+	nonce  []byte
+	ad     []byte // header digest and last-chunk flag
+	buf    []byte
+	chunks uint64
+}
+
+func newBundleWriter(w io.Writer, h bundleHeader, key ed25519.PrivateKey, password string) (*bundleWriter, error) {
+	h.Version = BundleVersion
+	h.SignerKey = key.Public().(ed25519.PublicKey)
+	h.Salt = make([]byte, saltSize)
+	h.Nonce = make([]byte, nonceSize-8)
+	if _, err := rand.Read(h.Salt); err != nil {
+		return nil, fmt.Errorf("generate salt: %w", err)
+	}
+	if _, err := rand.Read(h.Nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+	h.Iterations = pbkdf2Iterations
+	header, err := json.Marshal(h)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := bundleCipher(h, password)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := sha256.New()
+	bw := &bundleWriter{
+		w:     io.MultiWriter(w, digest),
+		sum:   func() []byte { return digest.Sum(nil) },
+		key:   key,
+		aead:  aead,
+		nonce: make([]byte, nonceSize),
+		ad:    bundleAD(header),
+		buf:   make([]byte, 0, bundleChunkSize),
+	}
+	copy(bw.nonce, h.Nonce)
+	var size [4]byte
+	binary.BigEndian.PutUint32(size[:], uint32(len(header)))
+	for _, part := range [][]byte{bundleMagic, size[:], header} {
+		if _, err := bw.w.Write(part); err != nil {
+			return nil, err
+		}
+	}
+	return bw, nil
+}
+
+func (bw *bundleWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		take := min(len(p), bundleChunkSize-len(bw.buf))
+		bw.buf = append(bw.buf, p[:take]...)
+		p = p[take:]
+		if len(bw.buf) == bundleChunkSize {
+			if err := bw.seal(false); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// Close writes the last chunk, which may be empty, and the signature. It
+// does not close the underlying writer.
+func (bw *bundleWriter) Close() error {
+	if err := bw.seal(true); err != nil {
+		return err
+	}
+	_, err := bw.w.Write(ed25519.Sign(bw.key, bw.sum()))
+	return err
+}
+
+func (bw *bundleWriter) seal(final bool) error {
+	binary.BigEndian.PutUint64(bw.nonce[len(bw.nonce)-8:], bw.chunks)
+	bw.chunks++
+	bw.ad[len(bw.ad)-1] = 0
+	length := uint32(len(bw.buf) + bw.aead.Overhead())
+	if final {
+		bw.ad[len(bw.ad)-1] = 1
+		length |= bundleFinal
+	}
+	sealed := bw.aead.Seal(nil, bw.nonce, bw.buf, bw.ad)
+	bw.buf = bw.buf[:0]
+	var size [4]byte
+	binary.BigEndian.PutUint32(size[:], length)
+	if _, err := bw.w.Write(size[:]); err != nil {
+		return err
+	}
+	_, err := bw.w.Write(sealed)
+	return err
+}
+
+// signedBundle is a bundle file whose signature has been checked.
+type signedBundle struct {
+	bundleHeader
+	raw   []byte // the header as stored
+	start int64  // of the chunks
+	end   int64  // of the chunks, where the signature starts
+}
+
+// openBundle reads the header of a bundle and checks its signature, reading
+// the whole file to do so.
+func openBundle(f *os.File) (*signedBundle, error) {
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	r := bufio.NewReader(io.NewSectionReader(f, 0, fi.Size()))
+	prefix := make([]byte, len(bundleMagic)+4)
+	if _, err := io.ReadFull(r, prefix); err != nil || string(prefix[:len(bundleMagic)]) != string(bundleMagic) {
+		return nil, fmt.Errorf("not a repository bundle")
+	}
+	size := binary.BigEndian.Uint32(prefix[len(bundleMagic):])
+	if size > 1<<16 {
+		return nil, fmt.Errorf("bundle header too large")
+	}
+	b := &signedBundle{raw: make([]byte, size)}
+	if _, err := io.ReadFull(r, b.raw); err != nil {
+		return nil, fmt.Errorf("read bundle header: %w", err)
+	}
+	if err := json.Unmarshal(b.raw, &b.bundleHeader); err != nil {
+		return nil, fmt.Errorf("read bundle header: %w", err)
+	}
+	if len(b.SignerKey) != ed25519.PublicKeySize || len(b.Salt) != saltSize || len(b.Nonce) != nonceSize-8 || b.Iterations <= 0 || b.Iterations > 10*pbkdf2Iterations {
+		return nil, fmt.Errorf("invalid bundle header")
+	}
+
+	b.start = int64(len(prefix)) + int64(size)
+	b.end = fi.Size() - ed25519.SignatureSize
+	if b.end < b.start {
+		return nil, fmt.Errorf("bundle is truncated")
+	}
+	digest := sha256.New()
+	if _, err := io.Copy(digest, io.NewSectionReader(f, 0, b.end)); err != nil {
+		return nil, fmt.Errorf("read bundle: %w", err)
+	}
+	sig := make([]byte, ed25519.SignatureSize)
+	if _, err := f.ReadAt(sig, b.end); err != nil {
+		return nil, fmt.Errorf("read bundle signature: %w", err)
+	}
+	if !ed25519.Verify(b.SignerKey, digest.Sum(nil), sig) {
+		return nil, fmt.Errorf("bundle signature does not match: the bundle was altered or damaged")
+	}
+	if b.Version < 1 || b.Version > BundleVersion {
+		return nil, fmt.Errorf("%w: bundle version %d, this build reads up to %d", ErrUnsupportedFormat, b.Version, BundleVersion)
+	}
+	return b, nil
+}
+
+// bundleReader decrypts the archive of a bundle.
+type bundleReader struct {
+	r     *bufio.Reader
+	aead  cipher.AEAD
+	nonce []byte
+	ad    []byte
+	buf   []byte
+	next  uint64
+	done  bool
+}
+
+func (b *signedBundle) reader(f *os.File, password string) (*bundleReader, error) {
+	aead, err := bundleCipher(b.bundleHeader, password)
+	if err != nil {
+		return nil, err
+	}
+	br := &bundleReader{
+		r:     bufio.NewReader(io.NewSectionReader(f, b.start, b.end-b.start)),
+		aead:  aead,
+		nonce: make([]byte, nonceSize),
+		ad:    bundleAD(b.raw),
+	}
+	copy(br.nonce, b.Nonce)
+	return br, nil
+}
+
+func (br *bundleReader) Read(p []byte) (int, error) {
+	for len(br.buf) == 0 {
+		if br.done {
+			return 0, io.EOF
+		}
+		if err := br.open(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, br.buf)
+	br.buf = br.buf[n:]
+	return n, nil
+}
+
+func (br *bundleReader) open() error {
+	var size [4]byte
+	if _, err := io.ReadFull(br.r, size[:]); err != nil {
+		return fmt.Errorf("bundle is truncated")
+	}
+	length := binary.BigEndian.Uint32(size[:])
+	final := length&bundleFinal != 0
+	length &^= bundleFinal
+	if int(length) < br.aead.Overhead() || int(length) > bundleChunkSize+br.aead.Overhead() {
+		return fmt.Errorf("invalid bundle chunk")
+	}
+	sealed := make([]byte, length)
+	if _, err := io.ReadFull(br.r, sealed); err != nil {
+		return fmt.Errorf("bundle is truncated")
+	}
+	binary.BigEndian.PutUint64(br.nonce[len(br.nonce)-8:], br.next)
+	br.next++
+	br.ad[len(br.ad)-1] = 0
+	if final {
+		br.ad[len(br.ad)-1] = 1
+	}
+	plain, err := br.aead.Open(sealed[:0], br.nonce, sealed, br.ad)
+	if err != nil {
+		return errBundlePassword
+	}
+	br.buf = plain
+	if final {
+		br.done = true
+		if _, err := br.r.ReadByte(); err != io.EOF {
+			return fmt.Errorf("data after the last bundle chunk")
+		}
+	}
+	return nil
+}
+
+func bundleCipher(h bundleHeader, password string) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, password, h.Salt, h.Iterations, keySize)
+	if err != nil {
+		return nil, fmt.Errorf("derive key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// bundleAD is the additional data of a chunk: the header's digest and a
+// last-chunk flag, set per chunk.
+func bundleAD(header []byte) []byte {
+	sum := sha256.Sum256(header)
+	return append(sum[:], 0)
+}
+
+// bundleSigningKey reads BACKUP_BUNDLE_SIGNING_KEY.
+func bundleSigningKey() (ed25519.PrivateKey, error) {
+	path := os.Getenv("BACKUP_BUNDLE_SIGNING_KEY")
+	if path == "" {
+		return nil, fmt.Errorf("no signing key: set BACKUP_BUNDLE_SIGNING_KEY")
+	}
+	block, err := readPEM(path, "PRIVATE KEY")
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	priv, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%s: not an Ed25519 key", path)
+	}
+	return priv, nil
+}
+
+// bundleTrustedKeys reads BACKUP_BUNDLE_TRUSTED_KEYS; nil means any signer
+// is accepted.
+func bundleTrustedKeys() ([]ed25519.PublicKey, error) {
+	var keys []ed25519.PublicKey
+	for _, path := range envList("BACKUP_BUNDLE_TRUSTED_KEYS") {
+		block, err := readPEM(path, "PUBLIC KEY")
+		if err != nil {
+			return nil, err
+		}
+		key, err := x509.ParsePKIXPublicKey(block)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		pub, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("%s: not an Ed25519 key", path)
+		}
+		keys = append(keys, pub)
+	}
+	return keys, nil
+}
+
+func readPEM(path, kind string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != kind {
+		return nil, fmt.Errorf("%s: no %s PEM block", path, kind)
+	}
+	return block.Bytes, nil
+}
+
+// keyFingerprint names a public key the way ssh-keygen -l does.
+func keyFingerprint(key ed25519.PublicKey) string {
+	sum := sha256.Sum256(key)
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+)
+
+// ExportCatalog lists every stored backup as flat rows, oldest first, for
+// audit teams and CMDB ingestion.
+func (s *OrchestratorService) ExportCatalog(ctx context.Context, req *backupV1.ExportCatalogRequest) (*backupV1.ExportCatalogResponse, error) {
+	entries, err := s.catalog(req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+	resp := &backupV1.ExportCatalogResponse{Entries: entries}
+	stamp := time.Now().UTC().Format("20060102-150405")
+	switch req.Format {
+	case "", "csv":
+		doc, err := renderCatalogCSV(entries)
+		if err != nil {
+			return nil, err
+		}
+		resp.Document, resp.ContentType, resp.Filename = doc, "text/csv; charset=utf-8", "backup-catalog-"+stamp+".csv"
+	case "json":
+		doc, err := protojson.MarshalOptions{Indent: "  ", EmitUnpopulated: true}.Marshal(&backupV1.ExportCatalogResponse{Entries: entries})
+		if err != nil {
+			return nil, fmt.Errorf("marshal catalog: %w", err)
+		}
+		resp.Document, resp.ContentType, resp.Filename = string(doc), "application/json", "backup-catalog-"+stamp+".json"
+	default:
+		return nil, fmt.Errorf("unsupported catalog format %q, expected csv or json", req.Format)
+	}
+	return resp, nil
+}
+
+func (s *OrchestratorService) catalog(tenantID *uint32) ([]*backupV1.CatalogEntry, error) {
+	var entries []*backupV1.CatalogEntry
+
+	modules, err := s.storage.FindModuleBackups(BackupFilter{TenantID: tenantID})
+	if err != nil {
+		return nil, fmt.Errorf("list module backups: %w", err)
+	}
+	for _, b := range modules {
+		entries = append(entries, moduleCatalogEntry(b, "module"))
+	}
+
+	fulls, err := s.storage.FindFullBackups(BackupFilter{TenantID: tenantID})
+	if err != nil {
+		return nil, fmt.Errorf("list full backups: %w", err)
+	}
+	for _, b := range fulls {
+		entries = append(entries, fullCatalogEntries(b)...)
+	}
+
+	// Storage returns each kind newest first; a catalog reads better in order.
+	slices.SortStableFunc(entries, func(a, b *backupV1.CatalogEntry) int {
+		return a.CreatedAt.AsTime().Compare(b.CreatedAt.AsTime())
+	})
+	return entries, nil
+}
+
+// fullCatalogEntries returns the row of a full backup and those of its
+// modules.
+func fullCatalogEntries(b *backupV1.FullBackupInfo) []*backupV1.CatalogEntry {
+	entries := []*backupV1.CatalogEntry{{
+		BackupId:        b.Id,
+		Kind:            "full",
+		TenantId:        b.TenantId,
+		Status:          b.Status,
+		CreatedAt:       b.CreatedAt,
+		CreatedBy:       b.CreatedBy,
+		SizeBytes:       b.TotalSizeBytes,
+		StoredSizeBytes: b.TotalCompressedSizeBytes,
+		Encrypted:       b.Encrypted,
+		Pinned:          b.Pin != nil,
+		Description:     b.Description,
+	}}
+	for _, mb := range b.ModuleBackups {
+		// Module entries of a full backup carry no ID or ownership of
+		// their own.
+		e := moduleCatalogEntry(mb, "full_module")
+		e.BackupId, e.TenantId, e.CreatedAt, e.CreatedBy = b.Id, b.TenantId, b.CreatedAt, b.CreatedBy
+		e.Encrypted, e.Pinned = b.Encrypted, b.Pin != nil
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+func moduleCatalogEntry(b *backupV1.BackupInfo, kind string) *backupV1.CatalogEntry {
+	return &backupV1.CatalogEntry{
+		BackupId:        b.Id,
+		Kind:            kind,
+		ModuleId:        b.ModuleId,
+		TenantId:        b.TenantId,
+		Status:          b.Status,
+		CreatedAt:       b.CreatedAt,
+		CreatedBy:       b.CreatedBy,
+		SizeBytes:       b.SizeBytes,
+		StoredSizeBytes: b.CompressedSizeBytes,
+		Encrypted:       b.Encrypted,
+		Sha256:          b.Sha256,
+		Version:         b.Version,
+		SchemaVersion:   b.SchemaVersion,
+		Pinned:          b.Pin != nil,
+		Description:     b.Description,
+	}
+}
+
+var catalogColumns = []string{
+	"backup_id", "kind", "module_id", "tenant_id", "status", "created_at", "created_by",
+	"size_bytes", "stored_size_bytes", "encrypted", "sha256", "version", "schema_version", "pinned", "description",
+}
+
+func renderCatalogCSV(entries []*backupV1.CatalogEntry) (string, error) {
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	if err := w.Write(catalogColumns); err != nil {
+		return "", fmt.Errorf("render catalog: %w", err)
+	}
+	for _, e := range entries {
+		createdAt := ""
+		if e.CreatedAt != nil {
+			createdAt = e.CreatedAt.AsTime().UTC().Format(time.RFC3339)
+		}
+		row := []string{
+			e.BackupId, e.Kind, e.ModuleId, strconv.FormatUint(uint64(e.TenantId), 10), e.Status, createdAt, e.CreatedBy,
+			strconv.FormatInt(e.SizeBytes, 10), strconv.FormatInt(e.StoredSizeBytes, 10), strconv.FormatBool(e.Encrypted),
+			e.Sha256, e.Version, strconv.Itoa(int(e.SchemaVersion)), strconv.FormatBool(e.Pinned), e.Description,
+		}
+		if err := w.Write(row); err != nil {
+			return "", fmt.Errorf("render catalog: %w", err)
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", fmt.Errorf("render catalog: %w", err)
+	}
+	return b.String(), nil
+}
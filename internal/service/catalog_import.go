@@ -0,0 +1,409 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+)
+
+// ImportCatalog registers the backups of a storage tree attached to this
+// orchestrator, e.g. a replicated or restored volume, so they can be listed
+// and restored here. With a source path each backup there is checked, its
+// data files against their recorded checksums and a delta against its base,
+// and copied in the way replicas are stored; backups stored here already are
+// left alone. Without one, this orchestrator's own storage is checked in
+// place and its cached metadata dropped, for a volume mounted as the storage
+// itself. A catalog export limits the import to the backups it lists and
+// flags those that differ from it or are missing from the tree.
+func (s *OrchestratorService) ImportCatalog(ctx context.Context, req *backupV1.ImportCatalogRequest) (*backupV1.ImportCatalogResponse, error) {
+	if !isPlatformAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "only a platform admin can import backups")
+	}
+	var listed map[backupRef]*backupV1.CatalogEntry
+	if strings.TrimSpace(req.Catalog) != "" {
+		entries, err := parseCatalog(req.Catalog)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid catalog: %v", err)
+		}
+		listed = catalogRefs(entries)
+	}
+
+	src := s.storage
+	from := "in place"
+	if req.SourcePath != "" {
+		path := filepath.Clean(req.SourcePath)
+		if !filepath.IsAbs(path) {
+			return nil, status.Error(codes.InvalidArgument, "source_path must be absolute")
+		}
+		if rel, err := filepath.Rel(s.storage.basePath, path); err == nil && !strings.HasPrefix(rel, "..") {
+			return nil, status.Error(codes.InvalidArgument, "source_path is inside this orchestrator's storage; leave it empty to check that in place")
+		}
+		var err error
+		if src, err = OpenBackupStorage(path); err != nil {
+			return nil, status.Errorf(codes.NotFound, "%v", err)
+		}
+		from = "from " + path
+	}
+
+	resp, err := s.storage.ImportBackups(src, listed, req.DryRun)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "import catalog: %v", err)
+	}
+	s.log.Infof("Catalog import %s by %q (dry run %v): %d imported, %d registered, %d present, %d invalid, %d missing",
+		from, getUsernameFromContext(ctx), req.DryRun, resp.Imported, resp.Registered, resp.Present, resp.Invalid, resp.Missing)
+	return resp, nil
+}
+
+// catalogRefs maps the backups a catalog lists to their rows; rows of the
+// modules of a full backup are left out.
+func catalogRefs(entries []*backupV1.CatalogEntry) map[backupRef]*backupV1.CatalogEntry {
+	listed := map[backupRef]*backupV1.CatalogEntry{}
+	for _, e := range entries {
+		switch e.Kind {
+		case "module":
+			listed[backupRef{id: e.BackupId}] = e
+		case "full":
+			listed[backupRef{id: e.BackupId, full: true}] = e
+		}
+	}
+	return listed
+}
+
+type importCandidate struct {
+	ref     backupRef
+	created time.Time
+	err     error // reading its metadata
+}
+
+// ImportBackups registers the backups of src, oldest first so delta bases
+// come before their deltas. src may be s itself, which is then checked in
+// place. When listed is not nil only the backups it holds are considered.
+func (s *BackupStorage) ImportBackups(src *BackupStorage, listed map[backupRef]*backupV1.CatalogEntry, dryRun bool) (*backupV1.ImportCatalogResponse, error) {
+	candidates, err := src.scanTree()
+	if err != nil {
+		return nil, err
+	}
+	resp := &backupV1.ImportCatalogResponse{}
+	seen := map[backupRef]bool{}
+	planned := map[string]bool{} // module backups a dry run would import
+	for _, c := range candidates {
+		entry, ok := listed[c.ref]
+		if listed != nil && !ok {
+			continue
+		}
+		seen[c.ref] = true
+		r := &backupV1.CatalogImportResult{BackupId: c.ref.id, Kind: importKind(c.ref)}
+		resp.Results = append(resp.Results, r)
+
+		r.Outcome, err = s.importBackup(src, c, entry, dryRun, planned)
+		if err != nil {
+			r.Outcome, r.Reason = "invalid", status.Convert(err).Message()
+		}
+		switch r.Outcome {
+		case "imported", "would_import":
+			resp.Imported++
+		case "registered":
+			resp.Registered++
+		case "present":
+			resp.Present++
+		case "invalid":
+			resp.Invalid++
+		}
+	}
+
+	var missing []*backupV1.CatalogEntry
+	for ref, e := range listed {
+		if !seen[ref] {
+			missing = append(missing, e)
+		}
+	}
+	slices.SortFunc(missing, func(a, b *backupV1.CatalogEntry) int {
+		return a.CreatedAt.AsTime().Compare(b.CreatedAt.AsTime())
+	})
+	for _, e := range missing {
+		resp.Results = append(resp.Results, &backupV1.CatalogImportResult{
+			BackupId: e.BackupId, Kind: e.Kind, Outcome: "missing", Reason: "listed in the catalog but not in the storage tree",
+		})
+		resp.Missing++
+	}
+	return resp, nil
+}
+
+func (s *BackupStorage) importBackup(src *BackupStorage, c importCandidate, entry *backupV1.CatalogEntry, dryRun bool, planned map[string]bool) (string, error) {
+	if c.err != nil {
+		return "", c.err
+	}
+	dir, meta, err := src.copySource(c.ref)
+	if err != nil {
+		return "", err
+	}
+	if entry != nil {
+		if err := matchCatalogEntry(entry, meta); err != nil {
+			return "", err
+		}
+	}
+	base := ""
+	if info, ok := meta.(*backupV1.BackupInfo); ok {
+		if info.Id != c.ref.id {
+			return "", fmt.Errorf("metadata is of backup %s", info.Id)
+		}
+		base = info.DeltaBaseId
+	} else if info := meta.(*backupV1.FullBackupInfo); info.Id != c.ref.id {
+		return "", fmt.Errorf("metadata is of backup %s", info.Id)
+	}
+
+	switch {
+	case src == s:
+		if err := checkBackupData(dir, meta); err != nil {
+			return "", err
+		}
+		if base != "" && !s.HasBackup(base, false) {
+			return "", fmt.Errorf("delta base %s is not stored here", base)
+		}
+		return "registered", s.register(c.ref, meta)
+
+	case s.HasBackup(c.ref.id, c.ref.full):
+		return "present", nil
+
+	case dryRun:
+		if err := checkBackupData(dir, meta); err != nil {
+			return "", err
+		}
+		if base != "" && !s.HasBackup(base, false) && !planned[base] {
+			return "", fmt.Errorf("delta base %s is neither stored here nor imported", base)
+		}
+		if !c.ref.full {
+			planned[c.ref.id] = true
+		}
+		return "would_import", nil
+	}
+
+	err = s.StoreReplica(c.ref.id, c.ref.full, meta, func(staging string) error {
+		return copyBackupFiles(dir, staging)
+	})
+	if errors.Is(err, os.ErrExist) {
+		return "present", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return "imported", nil
+}
+
+// scanTree lists every backup directory of the storage, oldest first. A
+// backup whose metadata cannot be read is listed with the error, dated by
+// its directory.
+func (s *BackupStorage) scanTree() ([]importCandidate, error) {
+	var candidates []importCandidate
+	for _, full := range []bool{false, true} {
+		root := filepath.Join(s.basePath, "modules")
+		if full {
+			root = filepath.Join(s.basePath, "full")
+		}
+		entries, err := os.ReadDir(root)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", root, err)
+		}
+		for _, e := range entries {
+			if !e.IsDir() || strings.HasPrefix(e.Name(), ".") || !validPathElement(e.Name()) {
+				continue
+			}
+			c := importCandidate{ref: backupRef{id: e.Name(), full: full}}
+			// Read directly rather than through GetModuleBackup, which
+			// describes unreadable metadata as a corrupt backup.
+			var created *timestamppb.Timestamp
+			s.mu.RLock()
+			if full {
+				var info *backupV1.FullBackupInfo
+				if info, c.err = s.readFullMetadata(e.Name()); c.err == nil {
+					created = info.CreatedAt
+				}
+			} else {
+				var info *backupV1.BackupInfo
+				if info, c.err = s.readModuleMetadata(e.Name()); c.err == nil {
+					created = info.CreatedAt
+				}
+			}
+			s.mu.RUnlock()
+			if created != nil {
+				c.created = created.AsTime()
+			} else if fi, err := e.Info(); err == nil {
+				c.created = fi.ModTime()
+			}
+			candidates = append(candidates, c)
+		}
+	}
+	slices.SortStableFunc(candidates, func(a, b importCandidate) int {
+		return a.created.Compare(b.created)
+	})
+	return candidates, nil
+}
+
+// register makes a backup found valid in place visible as it is on disk
+// now: cached metadata is dropped and a full backup's summary written if
+// it is missing.
+func (s *BackupStorage) register(ref backupRef, meta proto.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !ref.full {
+		s.meta.invalidate(filepath.Join(s.moduleDir(ref.id), "metadata.json"))
+		return nil
+	}
+	s.forgetFull(ref.id)
+	summary := filepath.Join(s.fullDir(ref.id), "summary.json")
+	if _, err := os.Stat(summary); os.IsNotExist(err) {
+		if err := writeProtoFile(summary, fullBackupSummary(meta.(*backupV1.FullBackupInfo))); err != nil {
+			return fmt.Errorf("write summary: %w", err)
+		}
+	}
+	return nil
+}
+
+// matchCatalogEntry checks a backup against its catalog row.
+func matchCatalogEntry(e *backupV1.CatalogEntry, meta proto.Message) error {
+	var diffs []string
+	differs := func(field, catalog, stored string) {
+		if catalog != "" && catalog != stored {
+			diffs = append(diffs, fmt.Sprintf("%s %q, stored %q", field, catalog, stored))
+		}
+	}
+	switch info := meta.(type) {
+	case *backupV1.BackupInfo:
+		differs("status", e.Status, info.Status)
+		differs("sha256", e.Sha256, info.Sha256)
+		if e.StoredSizeBytes != 0 {
+			differs("stored size", strconv.FormatInt(e.StoredSizeBytes, 10), strconv.FormatInt(info.CompressedSizeBytes, 10))
+		}
+	case *backupV1.FullBackupInfo:
+		differs("status", e.Status, info.Status)
+		if e.StoredSizeBytes != 0 {
+			differs("stored size", strconv.FormatInt(e.StoredSizeBytes, 10), strconv.FormatInt(info.TotalCompressedSizeBytes, 10))
+		}
+	}
+	if len(diffs) > 0 {
+		return fmt.Errorf("does not match the catalog: %s", strings.Join(diffs, "; "))
+	}
+	return nil
+}
+
+// parseCatalog reads an ExportCatalog document in either format.
+func parseCatalog(doc string) ([]*backupV1.CatalogEntry, error) {
+	if strings.HasPrefix(strings.TrimSpace(doc), "{") {
+		var resp backupV1.ExportCatalogResponse
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal([]byte(doc), &resp); err != nil {
+			return nil, err
+		}
+		return resp.Entries, nil
+	}
+
+	r := csv.NewReader(strings.NewReader(doc))
+	header, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+	col := map[string]int{}
+	for i, name := range header {
+		col[strings.TrimSpace(name)] = i
+	}
+	for _, name := range []string{"backup_id", "kind"} {
+		if _, ok := col[name]; !ok {
+			return nil, fmt.Errorf("no %s column", name)
+		}
+	}
+	field := func(row []string, name string) string {
+		if i, ok := col[name]; ok && i < len(row) {
+			return row[i]
+		}
+		return ""
+	}
+	var entries []*backupV1.CatalogEntry
+	for {
+		row, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		e := &backupV1.CatalogEntry{
+			BackupId: field(row, "backup_id"),
+			Kind:     field(row, "kind"),
+			ModuleId: field(row, "module_id"),
+			Status:   field(row, "status"),
+			Sha256:   field(row, "sha256"),
+		}
+		if v := field(row, "stored_size_bytes"); v != "" {
+			if e.StoredSizeBytes, err = strconv.ParseInt(v, 10, 64); err != nil {
+				return nil, fmt.Errorf("backup %s: stored_size_bytes: %w", e.BackupId, err)
+			}
+		}
+		if v := field(row, "created_at"); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return nil, fmt.Errorf("backup %s: created_at: %w", e.BackupId, err)
+			}
+			e.CreatedAt = timestamppb.New(t)
+		}
+		entries = append(entries, e)
+	}
+}
+
+// copyBackupFiles copies the data files of a backup directory.
+func copyBackupFiles(from, to string) error {
+	entries, err := os.ReadDir(from)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if !isReplicaFile(e.Name()) || !e.Type().IsRegular() {
+			continue
+		}
+		if err := copyFile(filepath.Join(from, e.Name()), filepath.Join(to, e.Name())); err != nil {
+			return fmt.Errorf("copy %s: %w", e.Name(), err)
+		}
+	}
+	return nil
+}
+
+func copyFile(from, to string) error {
+	in, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(to, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func importKind(ref backupRef) string {
+	if ref.full {
+		return "full"
+	}
+	return "module"
+}
@@ -0,0 +1,292 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/klauspost/compress/zstd"
+	"github.com/klauspost/pgzip"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Storage compression algorithms. A backup records its algorithm in the
+// metadata and in the data file's extension, but reads detect it from the
+// data itself, so backups written before zstd support keep loading.
+const (
+	CompressionGzip = "gzip"
+	CompressionZstd = "zstd"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// storageCompression returns the algorithm for new backups from
+// BACKUP_COMPRESSION: "gzip" (default) or "zstd".
+func storageCompression(l *log.Helper) string {
+	switch name := strings.ToLower(strings.TrimSpace(os.Getenv("BACKUP_COMPRESSION"))); name {
+	case "", CompressionGzip:
+		return CompressionGzip
+	case CompressionZstd:
+		return CompressionZstd
+	default:
+		l.Warnf("Unknown BACKUP_COMPRESSION %q, using gzip", name)
+		return CompressionGzip
+	}
+}
+
+// parallelBlockSize is the chunk each compression worker takes at a time.
+// Data smaller than two chunks gains nothing from splitting and is
+// compressed on the calling goroutine.
+const parallelBlockSize = 1 << 20
+
+// compressionWorkers returns how many goroutines may compress one export,
+// from BACKUP_COMPRESSION_WORKERS (default GOMAXPROCS).
+func compressionWorkers() int {
+	if n := envInt("BACKUP_COMPRESSION_WORKERS", 0); n > 0 {
+		return n
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// CompressionExt is the file extension of data compressed with algo.
+func CompressionExt(algo string) string {
+	if algo == CompressionZstd {
+		return ".zst"
+	}
+	return ".gz"
+}
+
+// DataFileNames lists the names a data file may have, encrypted ones first.
+func DataFileNames(base string) []string {
+	return []string{
+		base + ".json.gz.enc", base + ".json.zst.enc",
+		base + ".json.gz", base + ".json.zst",
+	}
+}
+
+// compression is how a new backup's data is compressed. A zero level means
+// the algorithm's default.
+type compression struct {
+	algo  string
+	level int
+}
+
+// resolveCompression picks the algorithm from BACKUP_COMPRESSION and the
+// level from the request, falling back to BACKUP_COMPRESSION_LEVEL when the
+// request does not set one. An explicit 0 asks for the algorithm default.
+func resolveCompression(l *log.Helper, requested *int32) (compression, error) {
+	c := compression{algo: storageCompression(l)}
+	lo, hi := CompressionLevelRange(c.algo)
+	if requested != nil {
+		level := int(*requested)
+		if level != 0 && (level < lo || level > hi) {
+			return compression{}, status.Errorf(codes.InvalidArgument, "compression_level must be between %d and %d for %s", lo, hi, c.algo)
+		}
+		c.level = level
+		return c, nil
+	}
+	if level := envInt("BACKUP_COMPRESSION_LEVEL", 0); level != 0 {
+		if level < lo || level > hi {
+			l.Warnf("BACKUP_COMPRESSION_LEVEL %d is outside %d-%d for %s, using the default", level, lo, hi, c.algo)
+		} else {
+			c.level = level
+		}
+	}
+	return c, nil
+}
+
+// CompressionLevelRange returns the valid levels for algo, fastest first.
+func CompressionLevelRange(algo string) (lo, hi int) {
+	if algo == CompressionZstd {
+		return 1, 22
+	}
+	return gzip.BestSpeed, gzip.BestCompression
+}
+
+// effectiveLevel is the level recorded in the metadata.
+func (c compression) effectiveLevel() int {
+	if c.level != 0 {
+		return c.level
+	}
+	if c.algo == CompressionZstd {
+		return 3 // zstd.SpeedDefault
+	}
+	return 6 // gzip.DefaultCompression
+}
+
+// newCompressWriter returns a writer that compresses into w as c asks, on up
+// to compressionWorkers goroutines.
+func newCompressWriter(c compression, w io.Writer) (io.WriteCloser, error) {
+	workers := compressionWorkers()
+	if c.algo == CompressionZstd {
+		level := zstd.SpeedDefault
+		if c.level != 0 {
+			level = zstd.EncoderLevelFromZstd(c.level)
+		}
+		return zstd.NewWriter(w, zstd.WithEncoderLevel(level), zstd.WithEncoderConcurrency(workers))
+	}
+	level := gzip.DefaultCompression
+	if c.level != 0 {
+		level = c.level
+	}
+	if workers <= 1 {
+		return gzip.NewWriterLevel(w, level)
+	}
+	zw, err := pgzip.NewWriterLevel(w, level)
+	if err != nil {
+		return nil, err
+	}
+	if err := zw.SetConcurrency(parallelBlockSize, workers); err != nil {
+		return nil, err
+	}
+	return zw, nil
+}
+
+// countingWriter counts the bytes written through it.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// compressData compresses data as c asks.
+func compressData(c compression, data []byte) ([]byte, error) {
+	if c.level == 0 {
+		if c.algo == CompressionZstd {
+			return zstdCompressLevel(data, zstd.SpeedDefault)
+		}
+		return gzipCompress(data)
+	}
+	return CompressDataLevel(c.algo, data, c.level)
+}
+
+// CompressDataLevel compresses data with algo at level: 1-9 for gzip,
+// 1-22 for zstd (mapped onto the encoder's speed presets).
+func CompressDataLevel(algo string, data []byte, level int) ([]byte, error) {
+	if algo == CompressionZstd {
+		return zstdCompressLevel(data, zstd.EncoderLevelFromZstd(level))
+	}
+	return gzipCompressLevel(data, level)
+}
+
+// decompressData detects gzip or zstd from the magic bytes and decompresses.
+func decompressData(data []byte) ([]byte, error) {
+	switch DetectCompression(data) {
+	case CompressionZstd:
+		return zstdDecompress(data)
+	case CompressionGzip:
+		return gzipDecompress(data)
+	default:
+		return nil, fmt.Errorf("unknown compression format")
+	}
+}
+
+// NewDecompressReader streams the decompressed content of r, detecting gzip
+// or zstd from its first bytes.
+func NewDecompressReader(r io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	head, _ := br.Peek(len(zstdMagic))
+	switch DetectCompression(head) {
+	case CompressionZstd:
+		d, err := zstd.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		return d.IOReadCloser(), nil
+	case CompressionGzip:
+		return gzip.NewReader(br)
+	default:
+		return nil, fmt.Errorf("unknown compression format")
+	}
+}
+
+// DetectCompression names the algorithm data was compressed with, or
+// returns "" when it is neither gzip nor zstd.
+func DetectCompression(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, zstdMagic):
+		return CompressionZstd
+	case bytes.HasPrefix(data, gzipMagic):
+		return CompressionGzip
+	default:
+		return ""
+	}
+}
+
+func zstdCompressLevel(data []byte, level zstd.EncoderLevel) ([]byte, error) {
+	workers := compressionWorkers()
+	if workers <= 1 || len(data) < 2*parallelBlockSize {
+		enc, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(level))
+		if err != nil {
+			return nil, err
+		}
+		defer enc.Close()
+		return enc.EncodeAll(data, make([]byte, 0, len(data)/4)), nil
+	}
+
+	// EncodeAll is single-threaded; the streaming encoder compresses
+	// blocks on up to workers goroutines.
+	buf := bytes.NewBuffer(make([]byte, 0, len(data)/4))
+	enc, err := zstd.NewWriter(buf, zstd.WithEncoderLevel(level), zstd.WithEncoderConcurrency(workers))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := enc.Write(data); err != nil {
+		enc.Close()
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// pgzipCompressLevel splits data into blocks deflated on up to workers
+// goroutines. The result is a single ordinary gzip stream.
+func pgzipCompressLevel(data []byte, level, workers int) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := pgzip.NewWriterLevel(&buf, level)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.SetConcurrency(parallelBlockSize, workers); err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(data); err != nil {
+		w.Close()
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// zstdDecoder is shared: DecodeAll is safe for concurrent use.
+var zstdDecoder = sync.OnceValues(func() (*zstd.Decoder, error) {
+	return zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+})
+
+func zstdDecompress(data []byte) ([]byte, error) {
+	dec, err := zstdDecoder()
+	if err != nil {
+		return nil, err
+	}
+	return dec.DecodeAll(data, nil)
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/encoding/gzip"
+)
+
+func init() {
+	encoding.RegisterCompressor(&zstdCompressor{})
+}
+
+// moduleCompressor reads the gRPC compressor used on module calls from
+// BACKUP_MODULE_COMPRESSION ("gzip", "zstd" or "none", default "none"). It
+// stays opt-in because a module without the matching decompressor rejects
+// the call with Unimplemented, which would otherwise look like a missing RPC.
+func moduleCompressor(l *log.Helper) string {
+	name := strings.ToLower(strings.TrimSpace(os.Getenv("BACKUP_MODULE_COMPRESSION")))
+	switch name {
+	case "", "none":
+		return ""
+	case gzip.Name, zstdCompressorName:
+		return name
+	default:
+		l.Warnf("Unsupported BACKUP_MODULE_COMPRESSION %q, sending module calls uncompressed", name)
+		return ""
+	}
+}
+
+// compressionCallOptions returns the call option selecting the compressor.
+func compressionCallOptions(name string) []grpc.CallOption {
+	if name == "" {
+		return nil
+	}
+	return []grpc.CallOption{grpc.UseCompressor(name)}
+}
+
+const zstdCompressorName = "zstd"
+
+// zstdCompressor is the gRPC zstd compressor; grpc-go only ships gzip.
+// Encoders and decoders are pooled, as grpc-go does for gzip, since each
+// holds sizeable buffers.
+type zstdCompressor struct {
+	encoders sync.Pool
+	decoders sync.Pool
+}
+
+func (c *zstdCompressor) Name() string { return zstdCompressorName }
+
+func (c *zstdCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	if enc, ok := c.encoders.Get().(*zstd.Encoder); ok {
+		enc.Reset(w)
+		return &zstdWriter{Encoder: enc, pool: &c.encoders}, nil
+	}
+	enc, err := zstd.NewWriter(w, zstd.WithEncoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	return &zstdWriter{Encoder: enc, pool: &c.encoders}, nil
+}
+
+func (c *zstdCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	if dec, ok := c.decoders.Get().(*zstd.Decoder); ok {
+		if err := dec.Reset(r); err != nil {
+			c.decoders.Put(dec)
+			return nil, err
+		}
+		return &zstdReader{Decoder: dec, pool: &c.decoders}, nil
+	}
+	dec, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	return &zstdReader{Decoder: dec, pool: &c.decoders}, nil
+}
+
+// zstdWriter returns its encoder to the pool once the message is flushed.
+type zstdWriter struct {
+	*zstd.Encoder
+	pool *sync.Pool
+}
+
+func (w *zstdWriter) Close() error {
+	err := w.Encoder.Close()
+	w.pool.Put(w.Encoder)
+	return err
+}
+
+// zstdReader returns its decoder to the pool at the end of the message.
+type zstdReader struct {
+	*zstd.Decoder
+	pool *sync.Pool
+}
+
+func (r *zstdReader) Read(p []byte) (int, error) {
+	if r.Decoder == nil {
+		return 0, io.EOF
+	}
+	n, err := r.Decoder.Read(p)
+	if err == io.EOF {
+		r.pool.Put(r.Decoder)
+		r.Decoder = nil
+	}
+	return n, err
+}
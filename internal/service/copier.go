@@ -0,0 +1,366 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/go-tangra/go-tangra-common/eventbus"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+)
+
+// A backupCopier keeps a copy of every new backup somewhere else:
+// replication sends it to a secondary orchestrator, mirroring to object
+// storage. It queues each backup.created event, copies with retries on a
+// few workers and records the outcome in a status field of the backup's
+// metadata. Backups still pending when the service stops are picked up
+// again after it starts, and failed ones on every rescan. A delta is copied
+// after the backup it is based on.
+
+const (
+	copyPending = "pending"
+	copyDone    = "replicated"
+	copyFailed  = "failed"
+)
+
+type backupRef struct {
+	id   string
+	full bool
+}
+
+type backupCopier struct {
+	name    string // for logs: "Replication" or "Mirror"
+	log     *log.Helper
+	storage *BackupStorage
+	target  string
+	site    string // where target is, from _SITE
+	retry   RetryPolicy
+	rescan  time.Duration
+
+	// send copies one backup; field points at the status it keeps in a
+	// BackupInfo or FullBackupInfo.
+	send  func(ctx context.Context, ref backupRef) error
+	field func(proto.Message) **backupV1.ReplicationStatus
+
+	queue  chan backupRef
+	mu     sync.Mutex
+	queued map[backupRef]bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// copyRetryPolicy reads how copies of a backup to another place are
+// retried, under the given environment prefix: _RETRY_ATTEMPTS (default 5)
+// and _RETRY_BACKOFF_MS (default 5000, doubled per attempt up to 5 minutes).
+func copyRetryPolicy(prefix string) RetryPolicy {
+	retry := RetryPolicy{
+		MaxAttempts:    envInt(prefix+"_RETRY_ATTEMPTS", 5),
+		InitialBackoff: time.Duration(envInt(prefix+"_RETRY_BACKOFF_MS", 5000)) * time.Millisecond,
+		MaxBackoff:     5 * time.Minute,
+		RetryableCodes: map[codes.Code]bool{
+			codes.Unavailable: true, codes.DeadlineExceeded: true, codes.ResourceExhausted: true,
+			codes.Aborted: true, codes.Unknown: true, codes.Internal: true,
+		},
+	}
+	if retry.MaxAttempts < 1 {
+		retry.MaxAttempts = 1
+	}
+	return retry
+}
+
+// newBackupCopier reads the settings both copiers share, under the given
+// environment prefix: the retry policy, _RESCAN_MINUTES (default 30) and
+// _SITE, the site or region the copies live in.
+func newBackupCopier(name, prefix string, l *log.Helper, storage *BackupStorage, target string) *backupCopier {
+	rescan := envInt(prefix+"_RESCAN_MINUTES", 30)
+	if rescan <= 0 {
+		rescan = 30
+	}
+	c := &backupCopier{
+		name:    name,
+		log:     l,
+		storage: storage,
+		target:  target,
+		site:    strings.TrimSpace(os.Getenv(prefix + "_SITE")),
+		retry:   copyRetryPolicy(prefix),
+		rescan:  time.Duration(rescan) * time.Minute,
+		queue:   make(chan backupRef, 1024),
+		queued:  map[backupRef]bool{},
+	}
+	c.ctx, c.cancel = context.WithCancel(context.Background())
+	return c
+}
+
+// start runs the workers and the rescan, and subscribes to new backups.
+func (c *backupCopier) start(events *EventPublisher, workers int) {
+	if workers < 1 {
+		workers = 1
+	}
+	for range workers {
+		c.wg.Go(c.work)
+	}
+	c.wg.Go(c.rescanLoop)
+	events.subscribe(c, EventBackupCreated)
+	c.log.Infof("%s of backups to %s with %d workers", c.name, c.target, workers)
+}
+
+// stop ends the workers. Backups still in flight stay pending and are
+// copied after the next start.
+func (c *backupCopier) stop() {
+	c.cancel()
+	c.wg.Wait()
+}
+
+// Handle queues the backup of a backup.created event.
+func (c *backupCopier) Handle(_ context.Context, event *eventbus.Event) error {
+	if e, ok := event.Data.(*BackupEvent); ok {
+		c.Enqueue(e.BackupID, e.Kind == "full")
+	}
+	return nil
+}
+
+// Enqueue marks a backup pending and queues it. A backup already queued is
+// not queued twice; one that does not fit in the queue stays pending for the
+// next rescan.
+func (c *backupCopier) Enqueue(id string, full bool) *backupV1.ReplicationStatus {
+	st, err := c.setStatus(backupRef{id: id, full: full}, func(st *backupV1.ReplicationStatus) {
+		st.State, st.LastError = copyPending, ""
+	})
+	if err != nil {
+		c.log.Warnf("%s of backup %s not queued: %v", c.name, id, err)
+		return nil
+	}
+	c.push(backupRef{id: id, full: full})
+	return st
+}
+
+func (c *backupCopier) push(ref backupRef) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.queued[ref] {
+		return
+	}
+	select {
+	case c.queue <- ref:
+		c.queued[ref] = true
+	default:
+		c.log.Warnf("%s queue full; backup %s waits for the next rescan", c.name, ref.id)
+	}
+}
+
+func (c *backupCopier) work() {
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case ref := <-c.queue:
+			c.mu.Lock()
+			delete(c.queued, ref)
+			c.mu.Unlock()
+			c.copy(ref)
+		}
+	}
+}
+
+// rescanLoop queues the backups left pending, at startup and after, and
+// retries failed ones.
+func (c *backupCopier) rescanLoop() {
+	ticker := time.NewTicker(c.rescan)
+	defer ticker.Stop()
+	for {
+		c.requeue()
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *backupCopier) requeue() {
+	due := func(st *backupV1.ReplicationStatus) bool {
+		switch st.GetState() {
+		case copyPending:
+			return true
+		case copyFailed:
+			return time.Since(st.GetUpdatedAt().AsTime()) >= c.rescan
+		}
+		return false
+	}
+	modules, err := c.storage.FindModuleBackups(BackupFilter{})
+	if err != nil {
+		c.log.Warnf("%s rescan: %v", c.name, err)
+	}
+	// Oldest first, so delta bases go before their deltas.
+	for i := len(modules) - 1; i >= 0; i-- {
+		if due(*c.field(modules[i])) {
+			c.push(backupRef{id: modules[i].Id})
+		}
+	}
+	fulls, err := c.storage.FindFullBackupSummaries(BackupFilter{})
+	if err != nil {
+		c.log.Warnf("%s rescan: %v", c.name, err)
+	}
+	for i := len(fulls) - 1; i >= 0; i-- {
+		if due(*c.field(fulls[i])) {
+			c.push(backupRef{id: fulls[i].Id, full: true})
+		}
+	}
+}
+
+// copy sends one backup, retrying transient failures, and records the
+// outcome.
+func (c *backupCopier) copy(ref backupRef) {
+	if !ref.full {
+		if err := c.copyBase(ref.id); err != nil {
+			c.fail(ref, err, 0)
+			return
+		}
+	}
+
+	var attempts int32
+	_, err := c.retry.Do(c.ctx, func() error {
+		attempts++
+		return c.send(c.ctx, ref)
+	})
+	if c.ctx.Err() != nil {
+		// Shutting down: the backup stays pending.
+		return
+	}
+	if err != nil {
+		c.fail(ref, err, attempts)
+		return
+	}
+	if _, err := c.setStatus(ref, func(st *backupV1.ReplicationStatus) {
+		st.State, st.LastError = copyDone, ""
+		st.Attempts += attempts
+		st.ReplicatedAt = timestamppb.Now()
+	}); err != nil {
+		c.log.Warnf("Copied backup %s to %s but could not record it: %v", ref.id, c.target, err)
+		return
+	}
+	c.log.Infof("Copied backup %s (full=%v) to %s", ref.id, ref.full, c.target)
+}
+
+// copyBase copies the backup a delta is based on first, when it has not
+// been copied yet, since the delta cannot be restored without it.
+func (c *backupCopier) copyBase(id string) error {
+	info, err := c.storage.GetModuleBackup(id)
+	if err != nil || info.DeltaBaseId == "" {
+		return nil
+	}
+	base, err := c.storage.GetModuleBackup(info.DeltaBaseId)
+	if err != nil {
+		return fmt.Errorf("read delta base %s: %w", info.DeltaBaseId, err)
+	}
+	if (*c.field(base)).GetState() == copyDone {
+		return nil
+	}
+	ref := backupRef{id: base.Id}
+	c.setStatus(ref, func(st *backupV1.ReplicationStatus) { st.State = copyPending })
+	c.copy(ref)
+	if base, err = c.storage.GetModuleBackup(ref.id); err != nil || (*c.field(base)).GetState() != copyDone {
+		return fmt.Errorf("delta base %s is not copied", ref.id)
+	}
+	return nil
+}
+
+func (c *backupCopier) fail(ref backupRef, err error, attempts int32) {
+	c.log.Errorf("%s of backup %s (full=%v) to %s failed: %v", c.name, ref.id, ref.full, c.target, err)
+	c.setStatus(ref, func(st *backupV1.ReplicationStatus) {
+		st.State, st.LastError = copyFailed, err.Error()
+		st.Attempts += attempts
+	})
+}
+
+// setStatus updates a backup's copy status, creating it if needed.
+func (c *backupCopier) setStatus(ref backupRef, update func(*backupV1.ReplicationStatus)) (*backupV1.ReplicationStatus, error) {
+	var st *backupV1.ReplicationStatus
+	apply := func(info proto.Message) error {
+		field := c.field(info)
+		if *field == nil {
+			*field = &backupV1.ReplicationStatus{}
+		}
+		st = *field
+		st.Target, st.Site = c.target, c.site
+		update(st)
+		st.UpdatedAt = timestamppb.Now()
+		return nil
+	}
+	var err error
+	if ref.full {
+		_, err = c.storage.UpdateFullBackup(ref.id, func(info *backupV1.FullBackupInfo) error { return apply(info) })
+	} else {
+		_, err = c.storage.UpdateModuleBackup(ref.id, func(info *backupV1.BackupInfo) error { return apply(info) })
+	}
+	return st, err
+}
+
+// copySource returns a backup's directory and its metadata as copies carry
+// it, without this site's copy statuses.
+func (s *BackupStorage) copySource(ref backupRef) (string, proto.Message, error) {
+	if ref.full {
+		info, err := s.GetFullBackup(ref.id)
+		if err != nil {
+			return "", nil, status.Error(codes.NotFound, err.Error())
+		}
+		info.Replication, info.Mirror, info.Origin = nil, nil, nil
+		return s.fullDir(ref.id), info, nil
+	}
+	info, err := s.GetModuleBackup(ref.id)
+	if err != nil {
+		return "", nil, status.Error(codes.NotFound, err.Error())
+	}
+	info.Replication, info.Mirror, info.Origin = nil, nil, nil
+	return s.moduleDir(ref.id), info, nil
+}
+
+// replicaFiles lists the data files of a backup directory with their sizes
+// and checksums. Metadata travels separately.
+func replicaFiles(dir string) ([]*backupV1.ReplicaFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read backup dir: %w", err)
+	}
+	var files []*backupV1.ReplicaFile
+	for _, e := range entries {
+		if !isReplicaFile(e.Name()) || !e.Type().IsRegular() {
+			continue
+		}
+		f, err := os.Open(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		h := sha256.New()
+		n, err := io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", e.Name(), err)
+		}
+		files = append(files, &backupV1.ReplicaFile{Name: e.Name(), Size: n, Sha256: hex.EncodeToString(h.Sum(nil))})
+	}
+	return files, nil
+}
+
+// isReplicaFile reports whether a file of a backup directory is copied, and
+// accepted by a secondary.
+func isReplicaFile(name string) bool {
+	return validPathElement(name) && !strings.HasPrefix(name, ".") && !strings.HasSuffix(name, ".tmp") &&
+		name != "metadata.json" && name != "summary.json"
+}
@@ -16,9 +16,9 @@ const (
 	nonceSize        = 12 // AES-GCM standard nonce size
 )
 
-// encryptData encrypts data with AES-256-GCM using a password-derived key.
+// EncryptData encrypts data with AES-256-GCM using a password-derived key.
 // Output format: salt(32B) || nonce(12B) || ciphertext+GCM-tag
-func encryptData(data []byte, password string) ([]byte, error) {
+func EncryptData(data []byte, password string) ([]byte, error) {
 	salt := make([]byte, saltSize)
 	if _, err := rand.Read(salt); err != nil {
 		return nil, fmt.Errorf("generate salt: %w", err)
@@ -58,6 +58,16 @@ func encryptData(data []byte, password string) ([]byte, error) {
 // DecryptData decrypts AES-256-GCM encrypted data using a password-derived key.
 // Input format: salt(32B) || nonce(12B) || ciphertext+GCM-tag
 func DecryptData(encrypted []byte, password string) ([]byte, error) {
+	return decryptData(encrypted, password, false)
+}
+
+// decryptInPlace is DecryptData writing the plaintext over encrypted, so a
+// large backup is not held twice. encrypted must not be used afterwards.
+func decryptInPlace(encrypted []byte, password string) ([]byte, error) {
+	return decryptData(encrypted, password, true)
+}
+
+func decryptData(encrypted []byte, password string, inPlace bool) ([]byte, error) {
 	minLen := saltSize + nonceSize + 1
 	if len(encrypted) < minLen {
 		return nil, fmt.Errorf("encrypted data too short")
@@ -82,7 +92,11 @@ func DecryptData(encrypted []byte, password string) ([]byte, error) {
 		return nil, fmt.Errorf("create GCM: %w", err)
 	}
 
-	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	var dst []byte
+	if inPlace {
+		dst = ciphertext[:0]
+	}
+	plaintext, err := gcm.Open(dst, nonce, ciphertext, nil)
 	if err != nil {
 		return nil, fmt.Errorf("decryption failed (wrong password or corrupted data): %w", err)
 	}
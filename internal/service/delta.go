@@ -0,0 +1,301 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+)
+
+// A delta rebuilds a module export from the export of an earlier backup. It
+// is a header followed by copy ops (take a range of the base) and insert ops
+// (literal bytes), rsync style: the base is indexed in fixed blocks and the
+// target is scanned with a rolling hash, so content that merely moved is
+// still found.
+var deltaMagic = []byte("TBDELTA1")
+
+const (
+	deltaBlockSize = 1 << 11
+	deltaOpCopy    = 0
+	deltaOpInsert  = 1
+
+	// rollingBase is the multiplier of the rolling hash; rollingPow is
+	// rollingBase^deltaBlockSize, which removes the byte leaving the window.
+	rollingBase = 16777619
+)
+
+var rollingPow = func() uint32 {
+	p := uint32(1)
+	for range deltaBlockSize {
+		p *= rollingBase
+	}
+	return p
+}()
+
+func rollingHash(b []byte) uint32 {
+	var h uint32
+	for _, c := range b {
+		h = h*rollingBase + uint32(c)
+	}
+	return h
+}
+
+// encodeDelta returns the delta that turns base into target.
+func encodeDelta(base, target []byte) []byte {
+	blocks := make(map[uint32]int, len(base)/deltaBlockSize)
+	for off := 0; off+deltaBlockSize <= len(base); off += deltaBlockSize {
+		h := rollingHash(base[off : off+deltaBlockSize])
+		if _, ok := blocks[h]; !ok {
+			blocks[h] = off
+		}
+	}
+
+	out := append([]byte(nil), deltaMagic...)
+	out = binary.AppendUvarint(out, uint64(len(target)))
+	var literal []byte
+	flush := func() {
+		if len(literal) > 0 {
+			out = append(out, deltaOpInsert)
+			out = binary.AppendUvarint(out, uint64(len(literal)))
+			out = append(out, literal...)
+			literal = literal[:0]
+		}
+	}
+
+	i := 0
+	var h uint32
+	if len(target) >= deltaBlockSize {
+		h = rollingHash(target[:deltaBlockSize])
+	}
+	for i+deltaBlockSize <= len(target) {
+		if off, ok := blocks[h]; ok && bytes.Equal(base[off:off+deltaBlockSize], target[i:i+deltaBlockSize]) {
+			n := deltaBlockSize
+			for off+n < len(base) && i+n < len(target) && base[off+n] == target[i+n] {
+				n++
+			}
+			flush()
+			out = append(out, deltaOpCopy)
+			out = binary.AppendUvarint(out, uint64(off))
+			out = binary.AppendUvarint(out, uint64(n))
+			i += n
+			if i+deltaBlockSize <= len(target) {
+				h = rollingHash(target[i : i+deltaBlockSize])
+			}
+			continue
+		}
+		literal = append(literal, target[i])
+		if i+deltaBlockSize < len(target) {
+			h = h*rollingBase + uint32(target[i+deltaBlockSize]) - rollingPow*uint32(target[i])
+		}
+		i++
+	}
+	literal = append(literal, target[i:]...)
+	flush()
+	return out
+}
+
+var errBadDelta = errors.New("malformed delta")
+
+// applyDelta rebuilds the target encodeDelta was given.
+func applyDelta(base, delta []byte) ([]byte, error) {
+	if !bytes.HasPrefix(delta, deltaMagic) {
+		return nil, errBadDelta
+	}
+	r := bytes.NewReader(delta[len(deltaMagic):])
+	size, err := binary.ReadUvarint(r)
+	if err != nil || size > 1<<40 {
+		return nil, errBadDelta
+	}
+	out := make([]byte, 0, size)
+	for r.Len() > 0 {
+		op, _ := r.ReadByte()
+		switch op {
+		case deltaOpCopy:
+			off, err1 := binary.ReadUvarint(r)
+			n, err2 := binary.ReadUvarint(r)
+			if err1 != nil || err2 != nil || off > uint64(len(base)) || n > uint64(len(base))-off {
+				return nil, errBadDelta
+			}
+			out = append(out, base[off:off+n]...)
+		case deltaOpInsert:
+			n, err := binary.ReadUvarint(r)
+			if err != nil || n > uint64(r.Len()) {
+				return nil, errBadDelta
+			}
+			start := len(out)
+			out = append(out, make([]byte, n)...)
+			r.Read(out[start:])
+		default:
+			return nil, fmt.Errorf("%w: unknown op %d", errBadDelta, op)
+		}
+	}
+	if uint64(len(out)) != size {
+		return nil, fmt.Errorf("%w: rebuilt %d bytes, expected %d", errBadDelta, len(out), size)
+	}
+	return out, nil
+}
+
+// deltaAgainstPrevious returns the module's latest backup and the delta
+// from its export to data, or nil when the backup should be stored in full:
+// delta storage is off (BACKUP_DELTA_MAX_CHAIN unset or 0), there is no
+// unencrypted predecessor, the chain already holds BACKUP_DELTA_MAX_CHAIN
+// deltas, or the delta would not save at least half. The caller holds s.mu.
+func (s *BackupStorage) deltaAgainstPrevious(ctx context.Context, info *backupV1.BackupInfo, data []byte) (*backupV1.BackupInfo, []byte) {
+	maxChain := envInt("BACKUP_DELTA_MAX_CHAIN", 0)
+	if maxChain <= 0 {
+		return nil, nil
+	}
+	backups, err := s.findModuleBackups(BackupFilter{ModuleID: info.ModuleId, TenantID: &info.TenantId, Status: "completed"})
+	if err != nil {
+		s.log.Warnf("Delta for %s: %v", info.Id, err)
+		return nil, nil
+	}
+	var prev *backupV1.BackupInfo
+	for _, b := range backups {
+		if b.Id != info.Id && b.FullBackup == info.FullBackup {
+			prev = b
+			break
+		}
+	}
+	if prev == nil || prev.Encrypted || int(prev.DeltaDepth) >= maxChain {
+		return nil, nil
+	}
+
+	_, span := startSpan(ctx, "delta", attribute.String("delta.base", prev.Id))
+	defer endSpan(span, nil)
+	base, err := s.loadModuleData(ctx, prev.Id, "")
+	if err != nil {
+		s.log.Warnf("Delta for %s: load %s: %v", info.Id, prev.Id, err)
+		return nil, nil
+	}
+	delta := encodeDelta(base, data)
+	span.SetAttributes(attribute.Int("bytes.in", len(data)), attribute.Int("bytes.out", len(delta)))
+	if len(delta) > len(data)/2 {
+		return nil, nil
+	}
+	return prev, delta
+}
+
+// loadModuleData returns a module backup's export. A delta backup is rebuilt
+// from the nearest fully stored backup by replaying every delta after it,
+// each checked against its recorded checksum. The caller holds s.mu.
+func (s *BackupStorage) loadModuleData(ctx context.Context, backupID, password string) ([]byte, error) {
+	info, err := s.readModuleMetadata(backupID)
+	if err != nil || info.DeltaBaseId == "" {
+		// Backups with unreadable metadata can still be read.
+		return loadDataFile(ctx, s.moduleDir(backupID), "data", password, info)
+	}
+
+	chain := []*backupV1.BackupInfo{info}
+	for last := info; last.DeltaBaseId != ""; last = chain[len(chain)-1] {
+		if len(chain) > 1000 {
+			return nil, fmt.Errorf("delta chain of %s is too long", backupID)
+		}
+		base, err := s.readModuleMetadata(last.DeltaBaseId)
+		if err != nil {
+			return nil, fmt.Errorf("delta base %s of %s: %w", last.DeltaBaseId, last.Id, err)
+		}
+		chain = append(chain, base)
+	}
+
+	anchor := chain[len(chain)-1]
+	data, err := loadDataFile(ctx, s.moduleDir(anchor.Id), "data", "", anchor)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(chain) - 2; i >= 0; i-- {
+		b := chain[i]
+		delta, err := loadDeltaFile(ctx, s.moduleDir(b.Id), b.StoredSha256)
+		if err != nil {
+			return nil, err
+		}
+		if data, err = applyDelta(data, delta); err != nil {
+			return nil, fmt.Errorf("apply delta of %s: %w", b.Id, err)
+		}
+		if b.Sha256 != "" && checksum(data) != b.Sha256 {
+			return nil, fmt.Errorf("rebuilt data of %s does not match its checksum", b.Id)
+		}
+	}
+	return data, nil
+}
+
+// loadDeltaFile reads and decompresses the delta stored in dir, verifying
+// the stored file against storedSum when it is set.
+func loadDeltaFile(ctx context.Context, dir, storedSum string) ([]byte, error) {
+	var f *os.File
+	var err error
+	for _, algo := range []string{CompressionGzip, CompressionZstd} {
+		if f, err = os.Open(filepath.Join(dir, "data.delta"+CompressionExt(algo))); err == nil || !os.IsNotExist(err) {
+			break
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read delta: %w", err)
+	}
+	r, err := decodeDataFile(ctx, f, false, "", storedSum)
+	if err != nil {
+		return nil, fmt.Errorf("delta: %w", err)
+	}
+	defer r.Close()
+
+	delta, err := tracedReadAll(ctx, r, 0)
+	if verr := r.verify(); verr != nil {
+		return nil, fmt.Errorf("delta: %w", verr)
+	}
+	return delta, err
+}
+
+// rebaseDeltas stores the backups that are deltas against backupID in full,
+// so backupID can be deleted without breaking them. The caller holds s.mu.
+func (s *BackupStorage) rebaseDeltas(backupID string) error {
+	backups, err := s.findModuleBackups(BackupFilter{})
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	for _, b := range backups {
+		if b.DeltaBaseId != backupID {
+			continue
+		}
+		data, err := s.loadModuleData(ctx, b.Id, "")
+		if err != nil {
+			return fmt.Errorf("rebuild dependent backup %s: %w", b.Id, err)
+		}
+		c := compression{algo: b.Compression}
+		if c.algo == "" {
+			c.algo = CompressionGzip
+		}
+		compressed, err := compressData(c, data)
+		if err != nil {
+			return fmt.Errorf("compress dependent backup %s: %w", b.Id, err)
+		}
+		stored := FrameStoredData(c.algo, false, compressed)
+
+		dir := s.moduleDir(b.Id)
+		var swaps fileSwaps
+		if err := swaps.stage(filepath.Join(dir, "data.json"+CompressionExt(c.algo)), "", stored); err != nil {
+			return err
+		}
+		b.DeltaBaseId, b.DeltaDepth = "", 0
+		b.Compression, b.CompressionLevel = c.algo, int32(c.effectiveLevel())
+		b.CompressedSizeBytes = int64(len(compressed))
+		b.CompressionRatio = compressionRatio(b.SizeBytes, b.CompressedSizeBytes)
+		b.StoredSha256 = checksum(stored)
+		s.meta.invalidate(filepath.Join(dir, "metadata.json"))
+		if err := swaps.commit(dir, b); err != nil {
+			return err
+		}
+		for _, algo := range []string{CompressionGzip, CompressionZstd} {
+			os.Remove(filepath.Join(dir, "data.delta"+CompressionExt(algo)))
+		}
+		s.log.Infof("Stored backup %s in full before deleting its delta base %s", b.Id, backupID)
+	}
+	return nil
+}
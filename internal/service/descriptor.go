@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/go-tangra/go-tangra-backup/cmd/server/assets"
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+)
+
+// GetDescriptorSet returns the descriptor set embedded in the binary, the
+// same one admin receives at registration, so clients that cannot use server
+// reflection can still build requests dynamically.
+func (s *OrchestratorService) GetDescriptorSet(_ context.Context, _ *backupV1.GetDescriptorSetRequest) (*backupV1.GetDescriptorSetResponse, error) {
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(assets.DescriptorData, &set); err != nil {
+		return nil, fmt.Errorf("parse bundled descriptor set: %w", err)
+	}
+
+	resp := &backupV1.GetDescriptorSetResponse{DescriptorSet: assets.DescriptorData}
+	for _, f := range set.File {
+		for _, svc := range f.Service {
+			resp.Services = append(resp.Services, f.GetPackage()+"."+svc.GetName())
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/registry"
+	"github.com/go-kratos/kratos/v2/transport/grpc/resolver/discovery"
+	bRegistry "github.com/tx7do/kratos-bootstrap/registry"
+	"google.golang.org/grpc"
+	_ "google.golang.org/grpc/health" // enables client-side health checking
+
+	conf "github.com/tx7do/kratos-bootstrap/api/gen/go/conf/v1"
+)
+
+// discoveryScheme prefixes module endpoints that are resolved through the
+// service registry, e.g. "discovery:///ipam-service".
+const discoveryScheme = "discovery:///"
+
+// discoveryServiceConfig balances across the resolved instances and only
+// routes to those reporting SERVING on grpc.health.v1.
+const discoveryServiceConfig = `{
+	"loadBalancingConfig": [{"round_robin": {}}],
+	"healthCheckConfig": {"serviceName": ""}
+}`
+
+// isDiscoveryEndpoint reports whether the endpoint names a registry service
+// rather than a literal host:port.
+func isDiscoveryEndpoint(endpoint string) bool {
+	return strings.HasPrefix(endpoint, discoveryScheme)
+}
+
+// newModuleDiscovery builds a discovery client from the bootstrap registry
+// config (the same section used to register this service). It returns nil
+// when no registry is configured; registry targets then fail at dial time.
+// A configured registry whose implementation isn't linked into the binary is
+// a startup error rather than a silent fallback.
+func newModuleDiscovery(cfg *conf.Bootstrap, l *log.Helper) (registry.Discovery, error) {
+	if cfg == nil || cfg.GetRegistry() == nil || cfg.GetRegistry().GetType() == "" {
+		return nil, nil
+	}
+	typ := cfg.GetRegistry().GetType()
+	if _, ok := bRegistry.GetDiscoveryFactory(bRegistry.Type(typ)); !ok {
+		return nil, fmt.Errorf("registry type %q is configured but not linked into this binary (available: %s)",
+			typ, strings.Join(bRegistry.ListDiscoveryFactories(), ", "))
+	}
+	d, err := bRegistry.NewDiscovery(cfg.GetRegistry())
+	if err != nil {
+		return nil, fmt.Errorf("create %s registry discovery: %w", typ, err)
+	}
+	l.Infof("Resolving %s module targets via %s registry", discoveryScheme, typ)
+	return d, nil
+}
+
+// discoveryDialOptions returns the options that let grpc.NewClient resolve a
+// discovery:/// endpoint. secure selects instances registered with grpcs://.
+func discoveryDialOptions(d registry.Discovery, secure bool) []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithResolvers(discovery.NewBuilder(d, discovery.WithInsecure(!secure), discovery.PrintDebugLog(false))),
+		grpc.WithDefaultServiceConfig(discoveryServiceConfig),
+	}
+}
@@ -0,0 +1,332 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+)
+
+const (
+	defaultPresignTTL = 15 * time.Minute
+	maxPresignTTL     = 24 * time.Hour
+)
+
+// ErrBackupPassword means an encrypted backup was requested without its
+// password, or with the wrong one.
+var ErrBackupPassword = errors.New("backup password missing or wrong")
+
+// BackupDownload is a backup payload ready to be served over HTTP, seekable
+// so that Range requests can resume interrupted downloads.
+type BackupDownload struct {
+	Filename string
+	ModTime  time.Time
+	ETag     string
+	Content  io.ReadSeeker
+
+	file *os.File
+}
+
+// Close releases the stored file, if the download still holds it.
+func (d *BackupDownload) Close() error {
+	if d.file == nil {
+		return nil
+	}
+	return d.file.Close()
+}
+
+// OpenBackupDownload prepares a module backup, or one module of a full backup
+// when moduleID is set, for download. With raw the stored file is served as
+// is: compressed, and still encrypted if the backup is, so no password is needed
+// and nothing is held in memory. Otherwise the password decrypts it and the
+// module's JSON export is served, as DownloadBackup does, from a spool file.
+func (s *OrchestratorService) OpenBackupDownload(ctx context.Context, backupID, moduleID, password string, raw bool) (*BackupDownload, error) {
+	if !validPathElement(backupID) || (moduleID != "" && !validPathElement(moduleID)) {
+		return nil, fmt.Errorf("invalid backup or module ID: %w", os.ErrNotExist)
+	}
+
+	var info *backupV1.BackupInfo
+	var f *os.File
+	var encrypted bool
+	var err error
+	if moduleID == "" {
+		if info, err = s.storage.GetModuleBackup(backupID); err != nil {
+			return nil, fmt.Errorf("get backup metadata: %w", err)
+		}
+		if info.DeltaBaseId != "" {
+			return s.openDeltaDownload(ctx, info, raw)
+		}
+		f, encrypted, err = s.storage.OpenModuleBackupFile(backupID)
+	} else {
+		var full *backupV1.FullBackupInfo
+		if full, err = s.storage.GetFullBackup(backupID); err != nil {
+			return nil, fmt.Errorf("get full backup metadata: %w", err)
+		}
+		for _, mb := range full.ModuleBackups {
+			if mb.ModuleId == moduleID {
+				info = mb
+			}
+		}
+		if info == nil {
+			return nil, fmt.Errorf("module %s not in full backup %s: %w", moduleID, backupID, os.ErrNotExist)
+		}
+		f, encrypted, err = s.storage.OpenFullBackupModuleFile(backupID, moduleID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	st, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("stat backup data: %w", err)
+	}
+	name := downloadName(info, backupID)
+
+	if raw {
+		// Keep the stored extension, e.g. ".json.zst.enc". The format header
+		// is left out, so the file is plain gzip or zstd as the name says, or
+		// what the decrypt command takes.
+		stored := filepath.Base(f.Name())
+		ext := stored[strings.LastIndex(stored, ".json"):]
+		var content io.ReadSeeker = f
+		head := make([]byte, formatHeaderSize)
+		if n, _ := f.ReadAt(head, 0); n == formatHeaderSize {
+			if h, err := parseFormatHeader(head); err == nil && h.Version > 0 {
+				content = io.NewSectionReader(f, formatHeaderSize, st.Size()-formatHeaderSize)
+			}
+		}
+		return &BackupDownload{
+			Filename: name + ext,
+			ModTime:  st.ModTime(),
+			ETag:     fmt.Sprintf(`"%x-%x"`, st.Size(), st.ModTime().UnixNano()),
+			Content:  s.throttle.readSeeker(ctx, "download", content),
+			file:     f,
+		}, nil
+	}
+
+	// The export is decoded into a spool file rather than memory, which keeps
+	// it seekable for Range requests.
+	export, size, err := s.spoolExport(ctx, f, encrypted, password, info.StoredSha256)
+	if errors.Is(err, ErrBackupCorrupt) {
+		s.log.Errorf("Backup %s (module %s) is corrupt: %v", backupID, info.ModuleId, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("backup data: %w", err)
+	}
+
+	etag := fmt.Sprintf(`"%x-%x"`, size, st.ModTime().UnixNano())
+	if info.Sha256 != "" {
+		etag = `"` + info.Sha256 + `"`
+	}
+	return &BackupDownload{
+		Filename: name + ".json",
+		ModTime:  st.ModTime(),
+		ETag:     etag,
+		Content:  s.throttle.readSeeker(ctx, "download", export),
+		file:     export,
+	}, nil
+}
+
+// spoolExport decodes a stored data file into a spool file and returns it
+// rewound, with its size. The stored checksum is verified before it is
+// returned, so nothing corrupt is served.
+func (s *OrchestratorService) spoolExport(ctx context.Context, f *os.File, encrypted bool, password, storedSum string) (*os.File, int64, error) {
+	r, err := decodeDataFile(ctx, f, encrypted, password, storedSum)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer r.Close()
+
+	_, span := startSpan(ctx, "decompress")
+	export, size, err := s.storage.spoolReader(r)
+	endSpan(span, err)
+	if verr := r.verify(); verr != nil {
+		if export != nil {
+			export.Close()
+		}
+		return nil, 0, verr
+	}
+	return export, size, err
+}
+
+// openDeltaDownload serves a backup stored as a delta. Its stored file is of
+// no use without the backups before it, so the export is rebuilt; a raw
+// download gets it compressed as if the backup had been stored in full.
+func (s *OrchestratorService) openDeltaDownload(ctx context.Context, info *backupV1.BackupInfo, raw bool) (*BackupDownload, error) {
+	data, err := s.storage.LoadModuleBackupData(ctx, info.Id, "")
+	if err != nil {
+		return nil, fmt.Errorf("rebuild backup data: %w", err)
+	}
+	d := &BackupDownload{
+		Filename: downloadName(info, info.Id) + ".json",
+		ModTime:  info.GetCreatedAt().AsTime(),
+		ETag:     `"` + info.Sha256 + `"`,
+		Content:  bytes.NewReader(data),
+	}
+	if raw {
+		algo := info.Compression
+		if algo == "" {
+			algo = CompressionGzip
+		}
+		compressed, err := tracedCompress(ctx, compression{algo: algo}, data)
+		if err != nil {
+			return nil, fmt.Errorf("compress backup data: %w", err)
+		}
+		d.Filename += CompressionExt(algo)
+		d.ETag = `"` + info.Sha256 + "-" + algo + `"`
+		d.Content = bytes.NewReader(compressed)
+	}
+	d.Content = s.throttle.readSeeker(ctx, "download", d.Content)
+	return d, nil
+}
+
+// downloadName is the file name a backup is downloaded as, without its
+// extension.
+func downloadName(info *backupV1.BackupInfo, backupID string) string {
+	name := info.ModuleId + "-" + backupID
+	if info.CreatedAt != nil {
+		name += "-" + info.CreatedAt.AsTime().Format("20060102")
+	}
+	return name
+}
+
+// validPathElement rejects IDs that would escape the storage directory.
+func validPathElement(id string) bool {
+	return id != "" && id != "." && id != ".." && filepath.Base(id) == id
+}
+
+// GeneratePresignedDownloadURL returns a time-limited link to the stored
+// file of a backup, still encrypted if the backup is, that needs no other
+// credentials. Callers get links to their own tenant's backups only; backups
+// spanning all tenants need a platform admin.
+//
+// A backup already copied to an S3 mirror is linked there, presigned with the
+// mirror's credentials, so the download never passes through this service.
+// That file is exactly as stored, format header included, which the decrypt
+// and verify commands read. Other backups get a link to this service's raw
+// HTTP download instead, signed with BACKUP_DOWNLOAD_URL_SECRET, which needs
+// BACKUP_HTTP_API=true; BACKUP_DOWNLOAD_BASE_URL is the externally reachable
+// address of the HTTP server (the URL is relative without it).
+func (s *OrchestratorService) GeneratePresignedDownloadURL(ctx context.Context, req *backupV1.GeneratePresignedDownloadURLRequest) (*backupV1.GeneratePresignedDownloadURLResponse, error) {
+	if !validPathElement(req.BackupId) || (req.ModuleId != "" && !validPathElement(req.ModuleId)) {
+		return nil, status.Error(codes.InvalidArgument, "invalid backup or module ID")
+	}
+
+	ref := backupRef{id: req.BackupId, full: req.ModuleId != ""}
+	path := "/v1/backups/" + url.PathEscape(req.BackupId) + "/data"
+	var info *backupV1.BackupInfo
+	var tenantID uint32
+	var mirrored bool
+	if req.ModuleId == "" {
+		var err error
+		if info, err = s.storage.GetModuleBackup(req.BackupId); err != nil {
+			return nil, fmt.Errorf("get backup metadata: %w", err)
+		}
+		tenantID = info.TenantId
+		// A delta's stored file is of no use without the backups before
+		// it; the service rebuilds the export instead.
+		mirrored = info.Mirror.GetState() == copyDone && info.DeltaBaseId == ""
+	} else {
+		full, err := s.storage.GetFullBackup(req.BackupId)
+		if err != nil {
+			return nil, fmt.Errorf("get full backup metadata: %w", err)
+		}
+		for _, mb := range full.ModuleBackups {
+			if mb.ModuleId == req.ModuleId {
+				info = mb
+			}
+		}
+		if info == nil {
+			return nil, status.Errorf(codes.NotFound, "module %s not in full backup %s", req.ModuleId, req.BackupId)
+		}
+		tenantID = full.TenantId
+		mirrored = full.Mirror.GetState() == copyDone && info.Status == "completed"
+		path = "/v1/backups/full/" + url.PathEscape(req.BackupId) + "/modules/" + url.PathEscape(req.ModuleId) + "/data"
+	}
+	if !isPlatformAdmin(ctx) && (tenantID == 0 || getTenantIDFromContext(ctx) != tenantID) {
+		return nil, status.Error(codes.PermissionDenied, "no access to this backup")
+	}
+
+	ttl := defaultPresignTTL
+	if req.ExpiresInSeconds > 0 {
+		ttl = min(time.Duration(req.ExpiresInSeconds)*time.Second, maxPresignTTL)
+	}
+	expires := time.Now().Add(ttl).Truncate(time.Second)
+
+	if mirrored && s.mirror != nil {
+		link, err := s.presignMirrored(ctx, ref, info, ttl)
+		if err != nil {
+			return nil, err
+		}
+		if link != "" {
+			s.log.Infof("Presigned mirror download of %s valid until %s", path, expires.UTC().Format(time.RFC3339))
+			return &backupV1.GeneratePresignedDownloadURLResponse{Url: link, ExpiresAt: timestamppb.New(expires)}, nil
+		}
+	}
+
+	if os.Getenv("BACKUP_DOWNLOAD_URL_SECRET") == "" || os.Getenv("BACKUP_HTTP_API") != "true" {
+		return nil, status.Error(codes.FailedPrecondition, "backup is not in an S3 mirror, and links to this service are disabled: set BACKUP_HTTP_API=true and BACKUP_DOWNLOAD_URL_SECRET")
+	}
+	q := url.Values{}
+	q.Set("expires", strconv.FormatInt(expires.Unix(), 10))
+	q.Set("signature", signDownloadPath(path, expires.Unix()))
+	link := strings.TrimSuffix(os.Getenv("BACKUP_DOWNLOAD_BASE_URL"), "/") + path + "?" + q.Encode()
+
+	s.log.Infof("Presigned download of %s valid until %s", path, expires.UTC().Format(time.RFC3339))
+	return &backupV1.GeneratePresignedDownloadURLResponse{Url: link, ExpiresAt: timestamppb.New(expires)}, nil
+}
+
+// presignMirrored links to the mirrored copy of a backup's data file, or
+// returns "" if the mirror cannot hand out links. The mirror holds the file
+// under the name it has here.
+func (s *OrchestratorService) presignMirrored(ctx context.Context, ref backupRef, info *backupV1.BackupInfo, ttl time.Duration) (string, error) {
+	var f *os.File
+	var err error
+	if ref.full {
+		f, _, err = s.storage.OpenFullBackupModuleFile(ref.id, info.ModuleId)
+	} else {
+		f, _, err = s.storage.OpenModuleBackupFile(ref.id)
+	}
+	if err != nil {
+		return "", fmt.Errorf("find backup data: %w", err)
+	}
+	stored := filepath.Base(f.Name())
+	f.Close()
+	return s.mirror.presign(ctx, ref, stored, downloadName(info, ref.id)+stored[strings.LastIndex(stored, ".json"):], ttl)
+}
+
+// VerifyDownloadSignature reports whether signature was issued by
+// GeneratePresignedDownloadURL for path and expires has not passed.
+func VerifyDownloadSignature(path, expires, signature string) bool {
+	if os.Getenv("BACKUP_DOWNLOAD_URL_SECRET") == "" {
+		return false
+	}
+	exp, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > exp {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(signDownloadPath(path, exp)))
+}
+
+func signDownloadPath(path string, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(os.Getenv("BACKUP_DOWNLOAD_URL_SECRET")))
+	fmt.Fprintf(mac, "GET\n%s\n%d", path, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
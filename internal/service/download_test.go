@@ -0,0 +1,184 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/grpc/codes"
+	grpcMD "google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+)
+
+// callerContext is the context of a gRPC call from a user of tenantID with
+// the given roles.
+func callerContext(tenantID, roles string) context.Context {
+	return grpcMD.NewIncomingContext(context.Background(), grpcMD.Pairs("x-md-global-tenant-id", tenantID, "x-md-global-roles", roles))
+}
+
+func TestGeneratePresignedDownloadURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		tenantID uint32
+		mirrored bool
+		httpAPI  bool
+		toMirror bool // link presigned against the mirror, not this service
+		want     codes.Code
+	}{
+		{name: "own tenant", ctx: callerContext("7", "tenant:admin"), tenantID: 7, httpAPI: true},
+		{name: "other tenant", ctx: callerContext("8", "tenant:admin"), tenantID: 7, httpAPI: true, want: codes.PermissionDenied},
+		{name: "no tenant", ctx: context.Background(), tenantID: 7, httpAPI: true, want: codes.PermissionDenied},
+		{name: "cross-tenant backup", ctx: callerContext("7", "tenant:admin"), tenantID: 0, httpAPI: true, want: codes.PermissionDenied},
+		{name: "platform admin", ctx: callerContext("", "platform:admin"), tenantID: 0, httpAPI: true},
+		{name: "HTTP API off", ctx: callerContext("7", "tenant:admin"), tenantID: 7, want: codes.FailedPrecondition},
+		{name: "mirrored to S3", ctx: callerContext("7", "tenant:admin"), tenantID: 7, mirrored: true, toMirror: true},
+		{name: "mirrored, other tenant", ctx: callerContext("8", "tenant:admin"), tenantID: 7, mirrored: true, want: codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.httpAPI {
+				t.Setenv("BACKUP_HTTP_API", "true")
+				t.Setenv("BACKUP_DOWNLOAD_URL_SECRET", "secret")
+			}
+			storage, _ := newTestStorage(t)
+			f, srv := newFakeS3(t)
+			svc := &OrchestratorService{
+				log:     log.NewHelper(log.DefaultLogger),
+				storage: storage,
+				mirror:  &Mirror{dest: newTestS3Mirror(t, srv, f, "", f.secretKey)},
+			}
+
+			info := &backupV1.BackupInfo{Id: "b1", ModuleId: "m", TenantId: tt.tenantID, Status: "completed"}
+			if tt.mirrored {
+				info.Mirror = &backupV1.ReplicationStatus{State: copyDone}
+			}
+			dir := storage.moduleDir(info.Id)
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, "data.json.gz"), []byte("data"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if err := writeMetadata(dir, info); err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := svc.GeneratePresignedDownloadURL(tt.ctx, &backupV1.GeneratePresignedDownloadURLRequest{BackupId: info.Id})
+			if status.Code(err) != tt.want {
+				t.Fatalf("error = %v, want %v", err, tt.want)
+			}
+			if err != nil {
+				return
+			}
+			u, err := url.Parse(resp.Url)
+			if err != nil {
+				t.Fatalf("parse %q: %v", resp.Url, err)
+			}
+			if tt.toMirror {
+				if !strings.HasPrefix(resp.Url, srv.URL+"/bucket/modules/b1/data.json.gz?") || u.Query().Get("X-Amz-Signature") == "" {
+					t.Errorf("link = %s, want one presigned against the mirror", resp.Url)
+				}
+				return
+			}
+			if u.Path != "/v1/backups/b1/data" || !VerifyDownloadSignature(u.Path, u.Query().Get("expires"), u.Query().Get("signature")) {
+				t.Errorf("link = %s, want a signed link to /v1/backups/b1/data", resp.Url)
+			}
+		})
+	}
+}
+
+func TestOpenBackupDownloadRange(t *testing.T) {
+	export := bytes.Repeat([]byte(`{"id":1,"name":"subnet"},`), 400)
+	tests := []struct {
+		name     string
+		password string // the backup is encrypted with it
+		given    string // and downloaded with this one
+		raw      bool
+		rng      string
+		// want picks the bytes served out of the whole download.
+		wantStatus int
+		want       func(whole []byte) []byte
+		wantErr    error
+	}{
+		{name: "export, first bytes", rng: "bytes=0-99", wantStatus: http.StatusPartialContent, want: func(b []byte) []byte { return b[:100] }},
+		{name: "export, resumed", rng: "bytes=5000-", wantStatus: http.StatusPartialContent, want: func(b []byte) []byte { return b[5000:] }},
+		{name: "export, suffix", rng: "bytes=-10", wantStatus: http.StatusPartialContent, want: func(b []byte) []byte { return b[len(b)-10:] }},
+		{name: "export, past the end", rng: "bytes=1000000-", wantStatus: http.StatusRequestedRangeNotSatisfiable},
+		{name: "export, whole", wantStatus: http.StatusOK, want: func(b []byte) []byte { return b }},
+		{name: "encrypted export", password: "pw", given: "pw", rng: "bytes=10-19", wantStatus: http.StatusPartialContent, want: func(b []byte) []byte { return b[10:20] }},
+		{name: "encrypted export, wrong password", password: "pw", given: "other", wantErr: ErrBackupPassword},
+		{name: "raw, resumed", raw: true, rng: "bytes=20-", wantStatus: http.StatusPartialContent, want: func(b []byte) []byte { return b[20:] }},
+		{name: "raw encrypted, no password needed", password: "pw", raw: true, rng: "bytes=0-9", wantStatus: http.StatusPartialContent, want: func(b []byte) []byte { return b[:10] }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage, _ := newTestStorage(t)
+			svc := &OrchestratorService{log: log.NewHelper(log.DefaultLogger), storage: storage}
+			ctx := context.Background()
+			info := &backupV1.BackupInfo{Id: "b1", ModuleId: "ipam", Status: "completed", CreatedAt: timestamppb.Now(), Encrypted: tt.password != ""}
+			if err := storage.SaveModuleBackup(ctx, info, export, tt.password, compression{algo: CompressionGzip}); err != nil {
+				t.Fatalf("SaveModuleBackup: %v", err)
+			}
+
+			// The whole download, against which ranges are checked: the
+			// export, or the stored file without its format header.
+			whole := export
+			if tt.raw {
+				f, _, err := storage.OpenModuleBackupFile(info.Id)
+				if err != nil {
+					t.Fatal(err)
+				}
+				stored, err := io.ReadAll(f)
+				f.Close()
+				if err != nil {
+					t.Fatal(err)
+				}
+				whole = stored[formatHeaderSize:]
+			}
+
+			d, err := svc.OpenBackupDownload(ctx, info.Id, "", tt.given, tt.raw)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("OpenBackupDownload error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("OpenBackupDownload: %v", err)
+			}
+			defer d.Close()
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/backups/b1/data", nil)
+			if tt.rng != "" {
+				req.Header.Set("Range", tt.rng)
+			}
+			rec := httptest.NewRecorder()
+			http.ServeContent(rec, req, d.Filename, d.ModTime, d.Content)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.want == nil {
+				return
+			}
+			if got, want := rec.Body.Bytes(), tt.want(whole); !bytes.Equal(got, want) {
+				t.Errorf("served %d bytes, want %d bytes of the download", len(got), len(want))
+			}
+			if _, err := d.Content.Seek(0, io.SeekStart); err != nil {
+				t.Errorf("download not seekable after serving: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrShuttingDown is the cause the jobs still running when the shutdown
+// grace period ends are canceled with.
+var ErrShuttingDown = errors.New("aborted: the service shut down")
+
+// abortWait is how long jobs canceled at shutdown get to record how far
+// they got.
+const abortWait = 10 * time.Second
+
+// JobTracker lets the backups and restores in flight finish when the service
+// stops. It is registered with the app as a server, so its Stop runs while
+// the gRPC and HTTP servers stop taking requests: it refuses new jobs, waits
+// up to BACKUP_SHUTDOWN_GRACE_SECONDS (default 20) for the running ones, then
+// cancels the rest with ErrShuttingDown. Canceled jobs are recorded as for
+// any other cancellation: full backups as canceled, module backups and
+// restores as failed. The container's termination grace period should leave
+// room for both waits.
+type JobTracker struct {
+	log   *log.Helper
+	grace time.Duration
+
+	mu       sync.Mutex
+	draining bool
+	jobs     map[uint64]*trackedJob
+	nextID   uint64
+	idle     chan struct{} // closed once the last job ends while draining
+}
+
+type trackedJob struct {
+	kind    string
+	started time.Time
+	cancel  context.CancelCauseFunc
+}
+
+type jobCtxKey struct{}
+
+func NewJobTracker(ctx *bootstrap.Context) *JobTracker {
+	grace := envInt("BACKUP_SHUTDOWN_GRACE_SECONDS", 20)
+	if grace < 0 {
+		grace = 20
+	}
+	return &JobTracker{
+		log:   ctx.NewLoggerHelper("backup/jobs"),
+		grace: time.Duration(grace) * time.Second,
+		jobs:  map[uint64]*trackedJob{},
+		idle:  make(chan struct{}),
+	}
+}
+
+// Begin registers a job, which ends when the returned func is called. The
+// job should run under the returned context. A job started from within
+// another, such as a scheduled task's full backup, is part of it and is not
+// refused once draining has begun.
+func (t *JobTracker) Begin(ctx context.Context, kind string) (context.Context, func(), error) {
+	if ctx.Value(jobCtxKey{}) != nil {
+		return ctx, func() {}, nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.draining {
+		return nil, nil, status.Error(codes.Unavailable, "the service is shutting down and not starting new jobs")
+	}
+	ctx, cancel := context.WithCancelCause(ctx)
+	id := t.nextID
+	t.nextID++
+	t.jobs[id] = &trackedJob{kind: kind, started: time.Now(), cancel: cancel}
+	ctx = context.WithValue(ctx, jobCtxKey{}, id)
+	var once sync.Once
+	return ctx, func() { once.Do(func() { t.end(id) }) }, nil
+}
+
+func (t *JobTracker) end(id uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.jobs[id].cancel(nil)
+	delete(t.jobs, id)
+	if t.draining && len(t.jobs) == 0 {
+		close(t.idle)
+	}
+}
+
+// Start implements transport.Server; there is nothing to start.
+func (t *JobTracker) Start(context.Context) error {
+	return nil
+}
+
+// Stop drains the jobs in flight, giving up early if ctx ends.
+func (t *JobTracker) Stop(ctx context.Context) error {
+	t.mu.Lock()
+	t.draining = true
+	n := len(t.jobs)
+	t.mu.Unlock()
+	if n == 0 {
+		return nil
+	}
+
+	t.log.Infof("Shutting down: waiting up to %s for %d running jobs", t.grace, n)
+	grace := time.NewTimer(t.grace)
+	defer grace.Stop()
+	select {
+	case <-t.idle:
+		t.log.Info("All running jobs finished")
+		return nil
+	case <-grace.C:
+	case <-ctx.Done():
+	}
+
+	t.mu.Lock()
+	for _, j := range t.jobs {
+		t.log.Warnf("Aborting %s running since %s", j.kind, j.started.Format(time.RFC3339))
+		j.cancel(ErrShuttingDown)
+	}
+	t.mu.Unlock()
+
+	select {
+	case <-t.idle:
+	case <-time.After(abortWait):
+		t.mu.Lock()
+		t.log.Errorf("%d aborted jobs did not stop within %s", len(t.jobs), abortWait)
+		t.mu.Unlock()
+	}
+	return nil
+}
+
+// jobError marks err as caused by the shutdown when the job was aborted by
+// it, so the records it is kept in say why.
+func jobError(ctx context.Context, err error) error {
+	if err != nil && errors.Is(context.Cause(ctx), ErrShuttingDown) && !errors.Is(err, ErrShuttingDown) {
+		return fmt.Errorf("%w: %v", ErrShuttingDown, err)
+	}
+	return err
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// envInt reads an integer from the environment, returning def when the
+// variable is unset or malformed.
+func envInt(key string, def int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return def
+	}
+	return n
+}
+
+// envList reads a comma-separated list from the environment, dropping blanks.
+func envList(key string) []string {
+	var out []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+
+	"github.com/go-tangra/go-tangra-common/eventbus"
+)
+
+// Backup lifecycle event types.
+const (
+	EventBackupCreated    = "backup.created"
+	EventBackupFailed     = "backup.failed"
+	EventRestoreStarted   = "restore.started"
+	EventRestoreCompleted = "restore.completed"
+	EventRetentionPruned  = "retention.pruned"
+	EventReportGenerated  = "report.generated"
+)
+
+var allEventTypes = []string{
+	EventBackupCreated,
+	EventBackupFailed,
+	EventRestoreStarted,
+	EventRestoreCompleted,
+	EventRetentionPruned,
+	EventReportGenerated,
+}
+
+// BackupEvent is the payload of backup.created and backup.failed.
+type BackupEvent struct {
+	BackupID         string   `json:"backupId"`
+	Kind             string   `json:"kind"` // "module" or "full"
+	ModuleID         string   `json:"moduleId,omitempty"`
+	TenantID         uint32   `json:"tenantId"`
+	Status           string   `json:"status"`
+	SizeBytes        int64    `json:"sizeBytes"`
+	Modules          int      `json:"modules,omitempty"`
+	ValidationStatus string   `json:"validationStatus,omitempty"`
+	Errors           []string `json:"errors,omitempty"`
+	CreatedBy        string   `json:"createdBy,omitempty"`
+	Scheduled        bool     `json:"scheduled,omitempty"`
+
+	notify *NotifyConfig
+}
+
+func moduleBackupEvent(info *backupV1.BackupInfo) *BackupEvent {
+	e := &BackupEvent{
+		BackupID:  info.Id,
+		Kind:      "module",
+		ModuleID:  info.ModuleId,
+		TenantID:  info.TenantId,
+		Status:    info.Status,
+		SizeBytes: info.SizeBytes,
+		CreatedBy: info.CreatedBy,
+	}
+	if info.Status == "failed" {
+		e.Errors = info.Warnings
+	}
+	return e
+}
+
+func fullBackupEvent(info *backupV1.FullBackupInfo) *BackupEvent {
+	return &BackupEvent{
+		BackupID:         info.Id,
+		Kind:             "full",
+		TenantID:         info.TenantId,
+		Status:           info.Status,
+		SizeBytes:        info.TotalSizeBytes,
+		Modules:          len(info.ModuleBackups),
+		ValidationStatus: info.ValidationStatus,
+		Errors:           append(append([]string(nil), info.Errors...), info.ValidationReasons...),
+		CreatedBy:        info.CreatedBy,
+	}
+}
+
+// RestoreEvent is the payload of restore.started and restore.completed.
+type RestoreEvent struct {
+	BackupID string   `json:"backupId"`
+	Kind     string   `json:"kind"` // "module" or "full"
+	Modules  []string `json:"modules"`
+	Success  bool     `json:"success"`
+	Errors   []string `json:"errors,omitempty"`
+
+	notify *NotifyConfig
+}
+
+// finished returns a copy of the started event carrying the outcome.
+func (e *RestoreEvent) finished(success bool, errs []string) *RestoreEvent {
+	done := *e
+	done.Success = success
+	done.Errors = errs
+	return &done
+}
+
+// RetentionEvent is the payload of retention.pruned.
+type RetentionEvent struct {
+	ModuleID   string   `json:"moduleId,omitempty"`
+	MaxAgeDays int      `json:"maxAgeDays"`
+	DryRun     bool     `json:"dryRun"`
+	BackupIDs  []string `json:"backupIds"`
+}
+
+// ReportEvent is the payload of report.generated.
+type ReportEvent struct {
+	Report *backupV1.BackupReport `json:"report"`
+
+	// push asks the notifier to deliver the report to Slack and email.
+	push   bool
+	notify *NotifyConfig
+}
+
+// EventPublisher fans backup lifecycle events out to the configured sinks
+// through an in-process event bus. Publishing never blocks or fails the
+// backup operation that triggered it.
+type EventPublisher struct {
+	log *log.Helper
+	bus eventbus.EventBus
+}
+
+// NewEventPublisher creates the publisher and attaches every sink enabled in
+// the environment. Notification defaults follow runtime config reloads.
+func NewEventPublisher(ctx *bootstrap.Context, runtime *RuntimeConfig) (*EventPublisher, func(), error) {
+	p := &EventPublisher{
+		log: ctx.NewLoggerHelper("backup/events"),
+		bus: eventbus.NewEventBus(ctx.GetLogger()),
+	}
+
+	var closers []func()
+	if sink := newNATSSink(p.log); sink != nil {
+		p.subscribe(sink, allEventTypes...)
+		closers = append(closers, sink.Close)
+	}
+	for _, sink := range newWebhookSinks(p.log) {
+		p.subscribe(sink, sink.cfg.Events...)
+	}
+	notifier := newNotifier(p.log)
+	runtime.OnReload(notifier.reload)
+	p.subscribe(notifier, EventBackupFailed, EventRestoreCompleted, EventReportGenerated)
+
+	cleanup := func() {
+		for _, c := range closers {
+			c()
+		}
+		_ = p.bus.Close()
+	}
+	return p, cleanup, nil
+}
+
+// subscribe attaches a sink to the given lifecycle event types.
+func (p *EventPublisher) subscribe(h eventbus.Handler, types ...string) {
+	for _, t := range types {
+		if err := p.bus.Subscribe(t, h); err != nil {
+			p.log.Warnf("Subscribe to %s: %v", t, err)
+		}
+	}
+}
+
+// Publish emits an event asynchronously.
+func (p *EventPublisher) Publish(ctx context.Context, eventType string, data any) {
+	if p == nil {
+		return
+	}
+	if notify, ok := ctx.Value(scheduledCtxKey{}).(*NotifyConfig); ok {
+		switch d := data.(type) {
+		case *BackupEvent:
+			d.Scheduled, d.notify = true, notify
+		case *RestoreEvent:
+			d.notify = notify
+		case *ReportEvent:
+			d.notify = notify
+		}
+	}
+
+	event := eventbus.NewEvent(eventType, data).WithSource("backup")
+	if id, ok := ctx.Value(requestIDCtxKey{}).(string); ok && id != "" {
+		event.WithMetadata("requestId", id)
+	}
+	_ = p.bus.PublishAsync(ctx, event)
+}
+
+type scheduledCtxKey struct{}
+
+// withSchedule marks ctx as a scheduled run, carrying the schedule's own
+// notification settings (nil for server defaults).
+func withSchedule(ctx context.Context, notify *NotifyConfig) context.Context {
+	return context.WithValue(ctx, scheduledCtxKey{}, notify)
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// Exports are checked before they are stored, so a module that returns
+// nothing, a truncated stream or an export that disagrees with its own
+// entity counts is caught at backup time rather than at restore time.
+// BACKUP_EXPORT_VALIDATION picks what happens to them:
+//
+//	reject  (default) broken exports fail the backup; suspect ones are
+//	        stored with validation_status "suspect"
+//	strict  suspect exports fail the backup too
+//	warn    everything is stored, broken exports marked suspect as well
+//
+// An export is broken when it is empty, is not valid JSON or is not a JSON
+// object, the envelope modules export their entities in. It is suspect when
+// the entity counts the module reported do not match the top-level arrays of
+// the same name, give or take 1%, or the module reported entities but the
+// export holds none.
+
+const validationSuspect = "suspect"
+
+const (
+	exportValidationReject = "reject"
+	exportValidationStrict = "strict"
+	exportValidationWarn   = "warn"
+)
+
+// ErrExportRejected wraps the reasons an export was not stored.
+var ErrExportRejected = errors.New("export rejected")
+
+// exportValidationMode reads BACKUP_EXPORT_VALIDATION.
+func exportValidationMode(l *log.Helper) string {
+	switch mode := strings.ToLower(strings.TrimSpace(os.Getenv("BACKUP_EXPORT_VALIDATION"))); mode {
+	case "", exportValidationReject:
+		return exportValidationReject
+	case exportValidationStrict, exportValidationWarn:
+		return mode
+	default:
+		l.Warnf("Unknown BACKUP_EXPORT_VALIDATION %q, using reject", mode)
+		return exportValidationReject
+	}
+}
+
+// validateExport checks result as mode asks. It returns the reasons the
+// export is suspect when it is stored anyway, or an error wrapping
+// ErrExportRejected when it must not be stored.
+func validateExport(result *ExportResult, mode string) ([]string, error) {
+	f, err := os.Open(result.File)
+	if err != nil {
+		return nil, fmt.Errorf("open export: %w", err)
+	}
+	defer f.Close()
+	broken, suspect := checkExport(bufio.NewReader(f), result.Size, result.EntityCounts)
+	switch {
+	case len(broken) > 0 && mode != exportValidationWarn:
+		return nil, fmt.Errorf("%w: %s", ErrExportRejected, strings.Join(broken, "; "))
+	case len(suspect) > 0 && mode == exportValidationStrict:
+		return nil, fmt.Errorf("%w: %s", ErrExportRejected, strings.Join(suspect, "; "))
+	}
+	return append(broken, suspect...), nil
+}
+
+// exportValidationStatus is the validation_status recorded for an export
+// stored with the given suspect reasons.
+func exportValidationStatus(suspect []string) string {
+	if len(suspect) > 0 {
+		return validationSuspect
+	}
+	return validationUsable
+}
+
+// checkExport reads the export from r, size bytes long, token by token so
+// it is never held in memory whole.
+func checkExport(r io.Reader, size int64, counts map[string]int64) (broken, suspect []string) {
+	invalid := []string{fmt.Sprintf("the export is not valid JSON (%d bytes; truncated?)", size)}
+	dec := json.NewDecoder(r)
+	tok, err := dec.Token()
+	if err == io.EOF {
+		return []string{"the export is empty"}, nil
+	}
+	if err != nil {
+		return invalid, nil
+	}
+	if tok != json.Delim('{') {
+		if skipJSONValue(dec, tok) != nil || !atJSONEnd(dec) {
+			return invalid, nil
+		}
+		return []string{"the export is not a JSON object"}, nil
+	}
+
+	got := make(map[string]int64, len(counts))
+	var keys int
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return invalid, nil
+		}
+		name, _ := tok.(string)
+		keys++
+		if tok, err = dec.Token(); err != nil {
+			return invalid, nil
+		}
+		if _, counted := counts[name]; counted && tok == json.Delim('[') {
+			n, err := countJSONArray(dec)
+			if err != nil {
+				return invalid, nil
+			}
+			got[name] = n
+			continue
+		}
+		if skipJSONValue(dec, tok) != nil {
+			return invalid, nil
+		}
+	}
+	if _, err := dec.Token(); err != nil || !atJSONEnd(dec) {
+		return invalid, nil
+	}
+
+	names := make([]string, 0, len(counts))
+	var reported int64
+	for name, n := range counts {
+		names = append(names, name)
+		reported += n
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		n, ok := got[name]
+		if !ok {
+			continue
+		}
+		want := counts[name]
+		if diff := n - want; diff > want/100 || -diff > want/100 {
+			suspect = append(suspect, fmt.Sprintf("%s: %d in the export, the module reported %d", name, n, want))
+		}
+	}
+	if reported > 0 && keys == 0 {
+		suspect = append(suspect, fmt.Sprintf("the module reported %d entities but the export is an empty object", reported))
+	}
+	return nil, suspect
+}
+
+// countJSONArray counts the elements of the array whose opening bracket dec
+// just returned, and consumes its closing bracket.
+func countJSONArray(dec *json.Decoder) (int64, error) {
+	var n int64
+	for dec.More() {
+		var elem json.RawMessage
+		if err := dec.Decode(&elem); err != nil {
+			return 0, err
+		}
+		n++
+	}
+	_, err := dec.Token()
+	return n, err
+}
+
+// skipJSONValue consumes the rest of the value that starts with tok.
+func skipJSONValue(dec *json.Decoder, tok json.Token) error {
+	depth := 0
+	for {
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+		var err error
+		if tok, err = dec.Token(); err != nil {
+			return err
+		}
+	}
+}
+
+// atJSONEnd reports whether nothing but whitespace follows in dec.
+func atJSONEnd(dec *json.Decoder) bool {
+	_, err := dec.Token()
+	return err == io.EOF
+}
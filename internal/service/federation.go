@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+)
+
+// Federation lets a passive DR orchestrator keep itself in sync with a
+// primary: it periodically asks the primary for its catalog and pulls every
+// backup it does not have yet, over mTLS, storing each as an ordinary backup
+// of its own. The DR site can then list, verify and restore them even when
+// the primary is gone. Backups deleted on the primary are kept here; the DR
+// site's own retention applies to them.
+//
+// On the DR orchestrator:
+//
+//	BACKUP_FEDERATION_PEER                 host:port of the primary; unset turns pulling off
+//	BACKUP_FEDERATION_SERVER_NAME          name expected in the primary's certificate, when not the host
+//	BACKUP_FEDERATION_SITE                 site or region of the primary, recorded on pulled backups
+//	BACKUP_FEDERATION_INTERVAL_MINUTES     how often the catalog is synced (default 15)
+//	BACKUP_FEDERATION_RETRY_ATTEMPTS       attempts per backup and sync (default 5)
+//	BACKUP_FEDERATION_RETRY_BACKOFF_MS     default 5000, doubled per attempt up to 5 minutes
+//
+// On the primary:
+//
+//	BACKUP_FEDERATION_SERVE                "true" to let DR orchestrators pull backups
+//	BACKUP_FEDERATION_ALLOWED_PEERS        client certificate common names allowed to (default any the CA signed)
+//
+// The primary streams backups in chunks of BACKUP_REPLICATION_CHUNK_KB.
+
+// Federation pulls backups from the primary. A nil Federation means pulling
+// is off.
+type Federation struct {
+	log      *log.Helper
+	storage  *BackupStorage
+	peer     string
+	site     string
+	client   backupV1.BackupOrchestratorServiceClient
+	retry    RetryPolicy
+	interval time.Duration
+
+	mu   sync.Mutex
+	last *backupV1.GetFederationStatusResponse
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewFederation starts pulling from BACKUP_FEDERATION_PEER when it is set.
+func NewFederation(ctx *bootstrap.Context, storage *BackupStorage, throttle *Throttle) (*Federation, func(), error) {
+	l := ctx.NewLoggerHelper("backup/federation")
+	peer := strings.TrimSpace(os.Getenv("BACKUP_FEDERATION_PEER"))
+	if peer == "" {
+		return nil, func() {}, nil
+	}
+	conn, err := dialPeer(l, peer, os.Getenv("BACKUP_FEDERATION_SERVER_NAME"), throttle.dialOptions("federation")...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("federation with %s: %w", peer, err)
+	}
+	interval := envInt("BACKUP_FEDERATION_INTERVAL_MINUTES", 15)
+	if interval <= 0 {
+		interval = 15
+	}
+
+	f := &Federation{
+		log:      l,
+		storage:  storage,
+		peer:     peer,
+		site:     strings.TrimSpace(os.Getenv("BACKUP_FEDERATION_SITE")),
+		client:   backupV1.NewBackupOrchestratorServiceClient(conn),
+		retry:    copyRetryPolicy("BACKUP_FEDERATION"),
+		interval: time.Duration(interval) * time.Minute,
+		last:     &backupV1.GetFederationStatusResponse{Peer: peer},
+	}
+	f.ctx, f.cancel = context.WithCancel(context.Background())
+	f.wg.Go(f.loop)
+	l.Infof("Pulling backups from %s every %s", peer, f.interval)
+
+	cleanup := func() {
+		// A backup being pulled is dropped and pulled again next time.
+		f.cancel()
+		f.wg.Wait()
+		conn.Close()
+	}
+	return f, cleanup, nil
+}
+
+func (f *Federation) loop() {
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+	for {
+		f.sync()
+		select {
+		case <-f.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sync pulls the backups the primary has and this orchestrator does not,
+// oldest first so delta bases arrive before their deltas.
+func (f *Federation) sync() {
+	result := &backupV1.GetFederationStatusResponse{Peer: f.peer, LastSyncAt: timestamppb.Now()}
+	defer func() {
+		if f.ctx.Err() != nil {
+			return
+		}
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if result.LastError == "" && len(result.FailedBackupIds) == 0 {
+			result.LastSuccessAt = result.LastSyncAt
+		} else {
+			result.LastSuccessAt = f.last.LastSuccessAt
+		}
+		f.last = result
+	}()
+
+	resp, err := f.client.ListReplicas(f.ctx, &backupV1.ListReplicasRequest{})
+	if err != nil {
+		if f.ctx.Err() == nil {
+			f.log.Warnf("Failed to read the catalog of %s: %v", f.peer, err)
+			result.LastError = err.Error()
+		}
+		return
+	}
+	result.PeerBackups = int32(len(resp.Backups))
+
+	missing := map[string]bool{}
+	for _, e := range resp.Backups {
+		if f.ctx.Err() != nil {
+			return
+		}
+		if !validPathElement(e.BackupId) || f.storage.HasBackup(e.BackupId, e.Full) {
+			continue
+		}
+		if e.DeltaBaseId != "" && missing[e.DeltaBaseId] {
+			missing[e.BackupId] = true
+			result.FailedBackupIds = append(result.FailedBackupIds, e.BackupId)
+			continue
+		}
+		_, err := f.retry.Do(f.ctx, func() error { return f.pull(f.ctx, e) })
+		if f.ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			f.log.Errorf("Failed to pull backup %s (full=%v) from %s: %v", e.BackupId, e.Full, f.peer, err)
+			missing[e.BackupId] = true
+			result.FailedBackupIds = append(result.FailedBackupIds, e.BackupId)
+			continue
+		}
+		result.Pulled++
+	}
+	if result.Pulled > 0 || len(result.FailedBackupIds) > 0 {
+		f.log.Infof("Synced with %s: %d backups pulled, %d failed", f.peer, result.Pulled, len(result.FailedBackupIds))
+	}
+}
+
+// Status returns the outcome of the last sync.
+func (f *Federation) Status() *backupV1.GetFederationStatusResponse {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return proto.Clone(f.last).(*backupV1.GetFederationStatusResponse)
+}
+
+// pull fetches one backup from the primary and stores it.
+func (f *Federation) pull(ctx context.Context, e *backupV1.ReplicaEntry) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := f.client.FetchReplica(ctx, &backupV1.FetchReplicaRequest{BackupId: e.BackupId, Full: e.Full})
+	if err != nil {
+		return err
+	}
+	m, meta, err := recvReplicaManifest(stream)
+	if err != nil {
+		return err
+	}
+	if m.BackupId != e.BackupId || m.Full != e.Full {
+		return status.Errorf(codes.InvalidArgument, "the primary sent backup %s instead", m.BackupId)
+	}
+	// The primary's copy stays a restore source.
+	now := timestamppb.Now()
+	origin := &backupV1.ReplicationStatus{State: copyDone, Target: f.peer, Site: f.site, UpdatedAt: now, ReplicatedAt: now}
+	switch info := meta.(type) {
+	case *backupV1.BackupInfo:
+		info.Origin = origin
+	case *backupV1.FullBackupInfo:
+		info.Origin = origin
+	}
+	err = f.storage.StoreReplica(m.BackupId, m.Full, meta, func(dir string) error {
+		_, err := receiveReplicaFiles(stream, dir, m.Files)
+		return err
+	})
+	if errors.Is(err, os.ErrExist) {
+		return nil
+	}
+	return err
+}
+
+// ListReplicas lists the backups a DR orchestrator may pull, oldest first.
+// Failed and canceled backups, which hold no data, are left out.
+func (s *OrchestratorService) ListReplicas(ctx context.Context, _ *backupV1.ListReplicasRequest) (*backupV1.ListReplicasResponse, error) {
+	if err := checkPeer(ctx, "pull backups", "BACKUP_FEDERATION_SERVE", "BACKUP_FEDERATION_ALLOWED_PEERS"); err != nil {
+		return nil, err
+	}
+	modules, err := s.storage.FindModuleBackups(BackupFilter{})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list backups: %v", err)
+	}
+	fulls, err := s.storage.FindFullBackupSummaries(BackupFilter{})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list full backups: %v", err)
+	}
+
+	resp := &backupV1.ListReplicasResponse{}
+	for _, b := range modules {
+		if b.Status == "completed" {
+			resp.Backups = append(resp.Backups, &backupV1.ReplicaEntry{BackupId: b.Id, CreatedAt: b.CreatedAt, DeltaBaseId: b.DeltaBaseId})
+		}
+	}
+	for _, b := range fulls {
+		if b.Status == "completed" || b.Status == "partial" {
+			resp.Backups = append(resp.Backups, &backupV1.ReplicaEntry{BackupId: b.Id, Full: true, CreatedAt: b.CreatedAt})
+		}
+	}
+	sort.SliceStable(resp.Backups, func(i, j int) bool {
+		return resp.Backups[i].CreatedAt.AsTime().Before(resp.Backups[j].CreatedAt.AsTime())
+	})
+	return resp, nil
+}
+
+// FetchReplica streams one backup to a DR orchestrator pulling it: the
+// manifest, then every data file in chunks, as ReceiveReplica takes them.
+func (s *OrchestratorService) FetchReplica(req *backupV1.FetchReplicaRequest, stream grpc.ServerStreamingServer[backupV1.ReplicaChunk]) error {
+	if err := checkPeer(stream.Context(), "pull backups", "BACKUP_FEDERATION_SERVE", "BACKUP_FEDERATION_ALLOWED_PEERS"); err != nil {
+		return err
+	}
+	if !validPathElement(req.BackupId) {
+		return status.Error(codes.InvalidArgument, "invalid backup ID")
+	}
+	dir, manifest, err := s.storage.replicaManifest(backupRef{id: req.BackupId, full: req.Full})
+	if err != nil {
+		return err
+	}
+	err = sendReplica(dir, manifest, replicaChunkSize(), func(c *backupV1.ReplicaChunk) error {
+		if err := s.throttle.wait(stream.Context(), "federation", proto.Size(c)); err != nil {
+			return err
+		}
+		return stream.Send(c)
+	})
+	if err != nil {
+		return err
+	}
+	s.log.Infof("Backup %s (full=%v) pulled by a peer", req.BackupId, req.Full)
+	return nil
+}
+
+// GetFederationStatus reports how current this DR orchestrator is with the
+// primary it pulls from.
+func (s *OrchestratorService) GetFederationStatus(_ context.Context, _ *backupV1.GetFederationStatusRequest) (*backupV1.GetFederationStatusResponse, error) {
+	if s.federation == nil {
+		return nil, status.Error(codes.FailedPrecondition, "this orchestrator does not pull from a peer (BACKUP_FEDERATION_PEER)")
+	}
+	return s.federation.Status(), nil
+}
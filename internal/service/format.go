@@ -0,0 +1,108 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Every stored data file starts with an 8-byte format header ahead of the
+// compressed, possibly encrypted, payload:
+//
+//	"TBAK" | version (1B) | flags (1B) | compression (1B) | reserved (1B)
+//
+// The header sits outside the encryption, so a file's format can be told
+// without its password. Files written before the header existed start
+// directly with the gzip or zstd magic bytes, or with an encryption salt;
+// they read as version 0 and keep loading. A file from a newer version is
+// refused with ErrUnsupportedFormat instead of being misread. The
+// migrate-format command adds the header to old files in place.
+
+// FormatVersion is the format new data files are written in.
+const FormatVersion = 1
+
+const formatHeaderSize = 8
+
+var formatMagic = []byte("TBAK")
+
+const formatFlagEncrypted = 1 << 0
+
+// Compression IDs in the header. Zero means unknown, which only a migrated
+// encrypted file whose metadata did not record it has.
+var formatCompressionIDs = map[string]byte{CompressionGzip: 1, CompressionZstd: 2}
+
+// ErrUnsupportedFormat means a data file was written in a format this build
+// cannot read.
+var ErrUnsupportedFormat = errors.New("unsupported backup format")
+
+// FormatHeader describes how a data file is stored.
+type FormatHeader struct {
+	Version     int    // 0 for files written before the header existed
+	Encrypted   bool   // only known from the header for version 1 and up
+	Compression string // "" when unknown
+}
+
+// FrameStoredData prepends the current format header to a compressed,
+// optionally encrypted payload. algo may be "" when it is not known.
+func FrameStoredData(algo string, encrypted bool, payload []byte) []byte {
+	out := make([]byte, 0, formatHeaderSize+len(payload))
+	out = append(out, storedDataHeader(algo, encrypted)...)
+	return append(out, payload...)
+}
+
+// storedDataHeader is the format header FrameStoredData prepends, for
+// writers that stream the payload after it.
+func storedDataHeader(algo string, encrypted bool) []byte {
+	var flags byte
+	if encrypted {
+		flags |= formatFlagEncrypted
+	}
+	return append(append([]byte{}, formatMagic...), FormatVersion, flags, formatCompressionIDs[algo], 0)
+}
+
+// ParseFormatHeader splits a stored data file into its header and payload.
+// A file without a header is returned whole, as version 0.
+func ParseFormatHeader(stored []byte) (FormatHeader, []byte, error) {
+	h, err := parseFormatHeader(stored)
+	if err != nil || h.Version == 0 {
+		return h, stored, err
+	}
+	return h, stored[formatHeaderSize:], nil
+}
+
+// readFormatHeader consumes the header at the start of br, if there is one.
+func readFormatHeader(br *bufio.Reader) (FormatHeader, error) {
+	head, _ := br.Peek(formatHeaderSize)
+	h, err := parseFormatHeader(head)
+	if err != nil || h.Version == 0 {
+		return h, err
+	}
+	_, err = br.Discard(formatHeaderSize)
+	return h, err
+}
+
+// stripFormatHeader reads past the header at the start of r, if there is
+// one, and returns what follows.
+func stripFormatHeader(r io.Reader) (io.Reader, FormatHeader, error) {
+	br := bufio.NewReader(r)
+	h, err := readFormatHeader(br)
+	return br, h, err
+}
+
+func parseFormatHeader(head []byte) (FormatHeader, error) {
+	if len(head) < formatHeaderSize || !bytes.HasPrefix(head, formatMagic) {
+		return FormatHeader{}, nil
+	}
+	h := FormatHeader{Version: int(head[4]), Encrypted: head[5]&formatFlagEncrypted != 0}
+	if h.Version == 0 || h.Version > FormatVersion {
+		return h, fmt.Errorf("%w: version %d, this build reads up to %d; upgrade the backup service", ErrUnsupportedFormat, h.Version, FormatVersion)
+	}
+	for algo, id := range formatCompressionIDs {
+		if head[6] == id {
+			h.Compression = algo
+		}
+	}
+	return h, nil
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+)
+
+// FreshnessSLO is the maximum acceptable age of the last successful backup.
+type FreshnessSLO struct {
+	Default   time.Duration
+	PerModule map[string]time.Duration
+}
+
+// defaultFreshnessSLO reads the SLO from the environment:
+//
+//	BACKUP_FRESHNESS_SLO_HOURS      default 24
+//	BACKUP_FRESHNESS_SLO_OVERRIDES  per module, e.g. "ipam=6,warden=48"
+func defaultFreshnessSLO(l *log.Helper) FreshnessSLO {
+	slo := FreshnessSLO{
+		Default:   time.Duration(envInt("BACKUP_FRESHNESS_SLO_HOURS", 24)) * time.Hour,
+		PerModule: map[string]time.Duration{},
+	}
+	for _, entry := range envList("BACKUP_FRESHNESS_SLO_OVERRIDES") {
+		id, hours, ok := strings.Cut(entry, "=")
+		n, err := strconv.Atoi(strings.TrimSpace(hours))
+		if !ok || err != nil || n <= 0 {
+			l.Warnf("Ignoring malformed freshness SLO override %q", entry)
+			continue
+		}
+		slo.PerModule[strings.TrimSpace(id)] = time.Duration(n) * time.Hour
+	}
+	return slo
+}
+
+func (f FreshnessSLO) forModule(moduleID string) time.Duration {
+	if d, ok := f.PerModule[moduleID]; ok {
+		return d
+	}
+	return f.Default
+}
+
+// GetBackupFreshness reports, per module and tenant, when the last
+// successful backup completed and whether it breaches the freshness SLO.
+func (s *OrchestratorService) GetBackupFreshness(ctx context.Context, req *backupV1.GetBackupFreshnessRequest) (*backupV1.GetBackupFreshnessResponse, error) {
+	modules, err := s.freshness(req.TenantId, req.ModuleId, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	resp := &backupV1.GetBackupFreshnessResponse{Modules: modules}
+	for _, m := range modules {
+		if m.Violated {
+			resp.Violations++
+		}
+	}
+	return resp, nil
+}
+
+// freshness finds the newest successful backup of every module and tenant
+// that has any backup on record, standalone or as part of a full backup.
+func (s *OrchestratorService) freshness(tenantID *uint32, moduleID string, now time.Time) ([]*backupV1.ModuleFreshness, error) {
+	type key struct {
+		moduleID string
+		tenantID uint32
+	}
+	last := make(map[key]time.Time)
+	see := func(k key, created time.Time, ok bool) {
+		prev, seen := last[k]
+		if !seen {
+			last[k] = time.Time{}
+		}
+		if ok && created.After(prev) {
+			last[k] = created
+		}
+	}
+
+	backups, err := s.storage.ListModuleBackups(moduleID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("list module backups: %w", err)
+	}
+	for _, b := range backups {
+		see(key{b.ModuleId, b.TenantId}, b.GetCreatedAt().AsTime(), b.Status == "completed")
+	}
+
+	fulls, err := s.storage.ListFullBackups(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("list full backups: %w", err)
+	}
+	for _, fb := range fulls {
+		for _, mb := range fb.ModuleBackups {
+			if moduleID != "" && mb.ModuleId != moduleID {
+				continue
+			}
+			see(key{mb.ModuleId, fb.TenantId}, fb.GetCreatedAt().AsTime(), mb.Status == "completed")
+		}
+	}
+
+	slos := s.freshnessSLO.Load()
+	out := make([]*backupV1.ModuleFreshness, 0, len(last))
+	for k, t := range last {
+		slo := slos.forModule(k.moduleID)
+		m := &backupV1.ModuleFreshness{
+			ModuleId:   k.moduleID,
+			TenantId:   k.tenantID,
+			AgeSeconds: -1,
+			SloSeconds: int64(slo / time.Second),
+			Violated:   true,
+		}
+		if !t.IsZero() {
+			age := now.Sub(t)
+			m.LastSuccessAt = timestamppb.New(t)
+			m.AgeSeconds = int64(age / time.Second)
+			m.Violated = age > slo
+		}
+		out = append(out, m)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].ModuleId != out[j].ModuleId {
+			return out[i].ModuleId < out[j].ModuleId
+		}
+		return out[i].TenantId < out[j].TenantId
+	})
+	return out, nil
+}
+
+// WriteFreshnessMetrics writes the freshness gauges in the Prometheus text
+// exposition format.
+func (s *OrchestratorService) WriteFreshnessMetrics(w io.Writer) error {
+	now := time.Now()
+	modules, err := s.freshness(nil, "", now)
+	if err != nil {
+		return err
+	}
+
+	gauges := []struct {
+		name, help string
+		value      func(m *backupV1.ModuleFreshness) (float64, bool)
+	}{
+		{"backup_last_success_timestamp_seconds", "Unix time of the last successful backup.",
+			func(m *backupV1.ModuleFreshness) (float64, bool) {
+				return float64(m.GetLastSuccessAt().GetSeconds()), m.LastSuccessAt != nil
+			}},
+		{"backup_last_success_age_seconds", "Age of the last successful backup.",
+			func(m *backupV1.ModuleFreshness) (float64, bool) {
+				return float64(m.AgeSeconds), m.LastSuccessAt != nil
+			}},
+		{"backup_freshness_slo_seconds", "Configured maximum age of the last successful backup.",
+			func(m *backupV1.ModuleFreshness) (float64, bool) {
+				return float64(m.SloSeconds), true
+			}},
+		{"backup_freshness_slo_violated", "1 if the last successful backup is older than the SLO or missing.",
+			func(m *backupV1.ModuleFreshness) (float64, bool) {
+				if m.Violated {
+					return 1, true
+				}
+				return 0, true
+			}},
+	}
+
+	var b strings.Builder
+	for _, g := range gauges {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s gauge\n", g.name, g.help, g.name)
+		for _, m := range modules {
+			if v, ok := g.value(m); ok {
+				fmt.Fprintf(&b, "%s{module=%q,tenant=\"%d\"} %s\n",
+					g.name, m.ModuleId, m.TenantId, strconv.FormatFloat(v, 'f', -1, 64))
+			}
+		}
+	}
+	_, err = io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+)
+
+const (
+	validationUsable   = "usable"
+	validationUnusable = "unusable"
+)
+
+// FullBackupPolicy decides whether a full backup captured enough modules to
+// be worth restoring from.
+type FullBackupPolicy struct {
+	// MinSuccessPercent is the share of targets (0-100) that must export
+	// successfully. Zero disables the check.
+	MinSuccessPercent int
+	// RequiredModules must all export successfully.
+	RequiredModules []string
+}
+
+// defaultFullBackupPolicy reads the server-wide policy from the environment:
+//
+//	BACKUP_MIN_SUCCESS_PERCENT  e.g. "80"
+//	BACKUP_REQUIRED_MODULES     e.g. "ipam,warden"
+func defaultFullBackupPolicy(l *log.Helper) FullBackupPolicy {
+	p := FullBackupPolicy{
+		MinSuccessPercent: envInt("BACKUP_MIN_SUCCESS_PERCENT", 0),
+		RequiredModules:   envList("BACKUP_REQUIRED_MODULES"),
+	}
+	if p.MinSuccessPercent < 0 || p.MinSuccessPercent > 100 {
+		l.Warnf("Ignoring BACKUP_MIN_SUCCESS_PERCENT=%d: must be between 0 and 100", p.MinSuccessPercent)
+		p.MinSuccessPercent = 0
+	}
+	return p
+}
+
+// policyForRequest overlays request-level settings on the server default.
+func policyForRequest(def FullBackupPolicy, req *backupV1.CreateFullBackupRequest) (FullBackupPolicy, error) {
+	p := def
+	if req.MinSuccessPercent != nil {
+		pct := req.GetMinSuccessPercent()
+		if pct < 0 || pct > 100 {
+			return p, status.Errorf(codes.InvalidArgument, "min_success_percent must be between 0 and 100, got %d", pct)
+		}
+		p.MinSuccessPercent = int(pct)
+	}
+	if len(req.RequiredModules) > 0 {
+		p.RequiredModules = req.RequiredModules
+	}
+	return p, nil
+}
+
+// Validate checks the per-module results, one per target, against the
+// policy and returns the validation status and the reasons a backup was
+// flagged unusable.
+func (p FullBackupPolicy) Validate(moduleBackups []*backupV1.BackupInfo) (string, []string) {
+	var reasons []string
+
+	succeeded := make(map[string]bool, len(moduleBackups))
+	completed := 0
+	for _, mb := range moduleBackups {
+		if mb.Status == "completed" {
+			succeeded[mb.ModuleId] = true
+			completed++
+		}
+	}
+
+	if p.MinSuccessPercent > 0 && len(moduleBackups) > 0 {
+		pct := completed * 100 / len(moduleBackups)
+		if pct < p.MinSuccessPercent {
+			reasons = append(reasons, fmt.Sprintf("only %d of %d modules backed up (%d%%), minimum is %d%%",
+				completed, len(moduleBackups), pct, p.MinSuccessPercent))
+		}
+	}
+
+	for _, m := range p.RequiredModules {
+		if !succeeded[m] {
+			reasons = append(reasons, fmt.Sprintf("required module %s was not backed up", m))
+		}
+	}
+
+	if len(reasons) > 0 {
+		return validationUnusable, reasons
+	}
+	return validationUsable, nil
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+)
+
+// results returns one module backup per "module:status" pair.
+func results(pairs ...string) []*backupV1.BackupInfo {
+	var out []*backupV1.BackupInfo
+	for _, p := range pairs {
+		module, st, _ := strings.Cut(p, ":")
+		out = append(out, &backupV1.BackupInfo{ModuleId: module, Status: st})
+	}
+	return out
+}
+
+func TestFullBackupPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      FullBackupPolicy
+		results     []*backupV1.BackupInfo
+		want        string
+		wantReasons int
+	}{
+		{
+			name:    "no policy",
+			results: results("ipam:failed", "warden:failed"),
+			want:    validationUsable,
+		},
+		{
+			name:    "enough modules",
+			policy:  FullBackupPolicy{MinSuccessPercent: 50},
+			results: results("ipam:completed", "warden:failed"),
+			want:    validationUsable,
+		},
+		{
+			name:        "too few modules",
+			policy:      FullBackupPolicy{MinSuccessPercent: 67},
+			results:     results("ipam:completed", "warden:completed", "lcm:failed"),
+			want:        validationUnusable,
+			wantReasons: 1,
+		},
+		{
+			name:    "all required",
+			policy:  FullBackupPolicy{MinSuccessPercent: 100},
+			results: results("ipam:completed", "warden:completed"),
+			want:    validationUsable,
+		},
+		{
+			// Every target counts, so a module exported for several tenants
+			// is as many results; counted per module, this would be 100%.
+			name:        "counted per target",
+			policy:      FullBackupPolicy{MinSuccessPercent: 75},
+			results:     results("ipam:completed", "ipam:failed", "ipam:failed", "warden:completed"),
+			want:        validationUnusable,
+			wantReasons: 1,
+		},
+		{
+			name:        "required module failed",
+			policy:      FullBackupPolicy{RequiredModules: []string{"warden"}},
+			results:     results("ipam:completed", "warden:failed"),
+			want:        validationUnusable,
+			wantReasons: 1,
+		},
+		{
+			name:        "required module missing",
+			policy:      FullBackupPolicy{RequiredModules: []string{"warden"}},
+			results:     results("ipam:completed"),
+			want:        validationUnusable,
+			wantReasons: 1,
+		},
+		{
+			name:        "both checks fail",
+			policy:      FullBackupPolicy{MinSuccessPercent: 100, RequiredModules: []string{"ipam", "warden"}},
+			results:     results("ipam:completed", "warden:failed"),
+			want:        validationUnusable,
+			wantReasons: 2,
+		},
+		{
+			name:    "no targets",
+			policy:  FullBackupPolicy{MinSuccessPercent: 100},
+			results: nil,
+			want:    validationUsable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reasons := tt.policy.Validate(tt.results)
+			if got != tt.want || len(reasons) != tt.wantReasons {
+				t.Errorf("Validate = %s %q, want %s with %d reasons", got, reasons, tt.want, tt.wantReasons)
+			}
+		})
+	}
+}
+
+func TestPolicyForRequest(t *testing.T) {
+	def := FullBackupPolicy{MinSuccessPercent: 50, RequiredModules: []string{"ipam"}}
+	tests := []struct {
+		name    string
+		req     *backupV1.CreateFullBackupRequest
+		want    FullBackupPolicy
+		wantErr codes.Code
+	}{
+		{name: "server default", req: &backupV1.CreateFullBackupRequest{}, want: def},
+		{
+			name: "overridden",
+			req:  &backupV1.CreateFullBackupRequest{MinSuccessPercent: proto.Int32(90), RequiredModules: []string{"warden"}},
+			want: FullBackupPolicy{MinSuccessPercent: 90, RequiredModules: []string{"warden"}},
+		},
+		{
+			name: "check turned off",
+			req:  &backupV1.CreateFullBackupRequest{MinSuccessPercent: proto.Int32(0)},
+			want: FullBackupPolicy{RequiredModules: []string{"ipam"}},
+		},
+		{name: "100%", req: &backupV1.CreateFullBackupRequest{MinSuccessPercent: proto.Int32(100)}, want: FullBackupPolicy{MinSuccessPercent: 100, RequiredModules: []string{"ipam"}}},
+		{name: "negative", req: &backupV1.CreateFullBackupRequest{MinSuccessPercent: proto.Int32(-1)}, wantErr: codes.InvalidArgument},
+		{name: "over 100%", req: &backupV1.CreateFullBackupRequest{MinSuccessPercent: proto.Int32(101)}, wantErr: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := policyForRequest(def, tt.req)
+			if status.Code(err) != tt.wantErr {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.MinSuccessPercent != tt.want.MinSuccessPercent || !slices.Equal(got.RequiredModules, tt.want.RequiredModules) {
+				t.Errorf("policy = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+)
+
+const (
+	healthCheckTimeout  = 5 * time.Second
+	healthUnimplemented = "UNIMPLEMENTED"
+)
+
+// probeHealth queries grpc.health.v1 on an open connection. Modules without a
+// health service report UNIMPLEMENTED, which is not an error: they may still
+// serve BackupService. Any other failure means the module is unreachable.
+func probeHealth(ctx context.Context, conn *grpc.ClientConn) (string, error) {
+	callCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	resp, err := healthpb.NewHealthClient(conn).Check(callCtx, &healthpb.HealthCheckRequest{})
+	if status.Code(err) == codes.Unimplemented {
+		return healthUnimplemented, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return resp.GetStatus().String(), nil
+}
+
+// preflightHealth runs the optional pre-call health check (enabled with
+// BACKUP_MODULE_HEALTH_CHECK=true) and fails fast when the module is down or
+// reports NOT_SERVING. It returns the observed health for later diagnostics.
+func (c *ModuleClient) preflightHealth(ctx context.Context, conn *grpc.ClientConn, moduleID string) (string, error) {
+	if !c.healthCheck {
+		return "", nil
+	}
+	health, err := probeHealth(ctx, conn)
+	if err != nil {
+		return "", fmt.Errorf("module %s is down: %w", moduleID, err)
+	}
+	if health == healthpb.HealthCheckResponse_NOT_SERVING.String() {
+		return health, status.Errorf(codes.Unavailable, "module %s reports NOT_SERVING", moduleID)
+	}
+	return health, nil
+}
+
+// describeUnimplemented makes "module up but no BackupService" explicit when
+// the health check showed the module running.
+func describeUnimplemented(moduleID, health string, err error) error {
+	if health == "" || status.Code(err) != codes.Unimplemented {
+		return err
+	}
+	return fmt.Errorf("module %s is up (health %s) but does not implement BackupService: %w", moduleID, health, err)
+}
+
+// Preflight dials the target and reports whether it is reachable and what its
+// health service says, without invoking BackupService.
+func (c *ModuleClient) Preflight(ctx context.Context, target *backupV1.ModuleTarget) *backupV1.TargetPreflightResult {
+	result := &backupV1.TargetPreflightResult{ModuleId: target.ModuleId}
+
+	conn, cleanup, err := c.dialModule(target.GrpcEndpoint, target.ModuleId, target.Tls)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	defer cleanup()
+
+	start := time.Now()
+	health, err := probeHealth(forwardMetadata(ctx), conn)
+	result.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Reachable = true
+	result.Health = health
+	return result
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+)
+
+// The journal records the backups being written. An entry goes to
+// journal/<id>.json before the first file of a backup and is removed once
+// the backup's metadata is in place, so an entry found at startup means the
+// process stopped in between and the backup's directory holds a partial
+// write. The export itself was only ever held in memory, so such a backup
+// cannot be resumed: recoverJournal clears the partial files and keeps a
+// failed record of module backups, as for exports that fail.
+//
+// Replicas share the journal, so an entry names the replica writing the
+// backup and the operation lease it wrote under; entries whose writer still
+// runs (see OperationLock.ownerAlive) are left to it.
+type journalEntry struct {
+	Kind        string    `json:"kind"` // "module" or "full"
+	BackupID    string    `json:"backupId"`
+	ModuleID    string    `json:"moduleId,omitempty"`
+	Description string    `json:"description,omitempty"`
+	TenantID    uint32    `json:"tenantId"`
+	FullBackup  bool      `json:"fullBackup,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+	CreatedBy   string    `json:"createdBy,omitempty"`
+	StartedAt   time.Time `json:"startedAt"`
+	Owner       string    `json:"owner,omitempty"`      // hostname of the replica writing the backup
+	Instance    string    `json:"instance,omitempty"`   // its process
+	LeaseToken  uint64    `json:"leaseToken,omitempty"` // operation lease it held, if any
+}
+
+// interruptedWarning is recorded on module backups whose write was
+// interrupted.
+const interruptedWarning = "interrupted: the service stopped while the backup was being written"
+
+func (s *BackupStorage) journalPath(backupID string) string {
+	return filepath.Join(s.basePath, "journal", backupID+".json")
+}
+
+// journalBegin records that the backup in e is about to be written.
+func (s *BackupStorage) journalBegin(e journalEntry) error {
+	e.StartedAt = time.Now()
+	e.Owner, e.Instance, e.LeaseToken = s.lock.writer()
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("marshal journal entry: %w", err)
+	}
+	path := s.journalPath(e.BackupID)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create journal dir: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("write journal entry: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("write journal entry: %w", err)
+	}
+	return nil
+}
+
+// journalEnd removes a backup's entry once it is written, or once its
+// partial files are gone.
+func (s *BackupStorage) journalEnd(backupID string) {
+	if err := os.Remove(s.journalPath(backupID)); err != nil && !os.IsNotExist(err) {
+		s.log.Warnf("Failed to remove journal entry of backup %s: %v", backupID, err)
+	}
+}
+
+// readJournalEntry returns the journal entry of backupID, if it has one.
+func (s *BackupStorage) readJournalEntry(backupID string) (*journalEntry, error) {
+	data, err := os.ReadFile(s.journalPath(backupID))
+	if err != nil {
+		return nil, err
+	}
+	var e journalEntry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// recoverJournal settles the backups whose write was interrupted, before
+// the storage is used. A backup whose metadata made it to disk was written
+// in full and only lost its journal entry.
+func (s *BackupStorage) recoverJournal() {
+	dir := filepath.Join(s.basePath, "journal")
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			s.log.Warnf("Failed to read journal: %v", err)
+		}
+		return
+	}
+
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if !strings.HasSuffix(entry.Name(), ".json") {
+			os.Remove(path) // a .tmp from an entry that was never written
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			s.log.Warnf("Failed to read journal entry %s: %v", entry.Name(), err)
+			continue
+		}
+		var e journalEntry
+		if err := json.Unmarshal(data, &e); err != nil || !validPathElement(e.BackupID) {
+			s.log.Warnf("Dropping unreadable journal entry %s", entry.Name())
+			os.Remove(path)
+			continue
+		}
+		if s.lock.ownerAlive(e.Instance, e.LeaseToken) {
+			s.log.Infof("Backup %s is being written by replica %s; leaving its journal entry", e.BackupID, e.Owner)
+			continue
+		}
+
+		switch e.Kind {
+		case "module":
+			s.recoverModuleBackup(e)
+		case "full":
+			// The spool is cleared at startup, except for keyed backups that
+			// can still be resumed; only a finished backup is ever moved out
+			// of it.
+			if _, err := s.readFullMetadata(e.BackupID); err != nil {
+				if _, err := readSpoolJob(filepath.Join(s.basePath, "spool", e.BackupID)); err == nil {
+					s.log.Warnf("Full backup %s started at %s was interrupted while being written; kept it for a retry with the same idempotency key",
+						e.BackupID, e.StartedAt.Format(time.RFC3339))
+				} else {
+					s.log.Warnf("Full backup %s started at %s was interrupted while being written; discarded it",
+						e.BackupID, e.StartedAt.Format(time.RFC3339))
+				}
+			}
+		default:
+			s.log.Warnf("Dropping journal entry %s of unknown kind %q", entry.Name(), e.Kind)
+		}
+		os.Remove(path)
+	}
+}
+
+// recoverModuleBackup replaces the partial files of an interrupted module
+// backup with a failed record.
+func (s *BackupStorage) recoverModuleBackup(e journalEntry) {
+	if _, err := s.readModuleMetadata(e.BackupID); err == nil {
+		return
+	}
+	dir := s.moduleDir(e.BackupID)
+	if err := os.RemoveAll(dir); err != nil {
+		s.log.Warnf("Failed to remove partial backup %s: %v", e.BackupID, err)
+		return
+	}
+	info := &backupV1.BackupInfo{
+		Id:          e.BackupID,
+		ModuleId:    e.ModuleID,
+		Description: e.Description,
+		TenantId:    e.TenantID,
+		FullBackup:  e.FullBackup,
+		Status:      "failed",
+		CreatedAt:   timestamppb.New(e.CreatedAt),
+		CreatedBy:   e.CreatedBy,
+		Warnings:    []string{interruptedWarning},
+	}
+	err := os.MkdirAll(dir, 0o755)
+	if err == nil {
+		err = writeMetadata(dir, info)
+	}
+	if err != nil {
+		s.log.Warnf("Failed to record interrupted backup %s: %v", e.BackupID, err)
+		return
+	}
+	s.log.Warnf("Backup %s of module %s was interrupted while being written; recorded it as failed", e.BackupID, e.ModuleID)
+}
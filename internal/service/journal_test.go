@@ -0,0 +1,169 @@
+package service
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// newTestStorage returns the storage in a new directory as replica "a" sees
+// it, and the lock of a second replica "b" on it.
+func newTestStorage(t *testing.T) (*BackupStorage, *OperationLock) {
+	t.Helper()
+	dir := t.TempDir()
+	for _, sub := range []string{"modules", "full", "spool", "journal"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s := &BackupStorage{basePath: dir, log: log.NewHelper(log.DefaultLogger), meta: newMetadataCache(), resuming: map[string]bool{}}
+	s.lock = newTestLock(t, dir, "a")
+	return s, newTestLock(t, dir, "b")
+}
+
+// writeJournalEntry writes e as the replica it names would have.
+func writeJournalEntry(t *testing.T, s *BackupStorage, e journalEntry) {
+	t.Helper()
+	data, err := json.Marshal(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(s.journalPath(e.BackupID), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// writerStates are the replicas a journal entry or spooled export may have
+// been left by, and whether startup recovery must leave them alone.
+var writerStates = []struct {
+	name  string
+	setup func(t *testing.T, b *OperationLock) (instance string, token uint64)
+	keep  bool
+}{
+	{
+		name:  "stopped replica",
+		setup: func(_ *testing.T, b *OperationLock) (string, uint64) { return b.instance, 0 },
+	},
+	{
+		name: "running replica",
+		setup: func(_ *testing.T, b *OperationLock) (string, uint64) {
+			b.announce()
+			return b.instance, 0
+		},
+		keep: true,
+	},
+	{
+		name: "earlier process on this host",
+		setup: func(t *testing.T, b *OperationLock) (string, uint64) {
+			old := newTestLock(t, filepath.Dir(filepath.Dir(b.dir)), "a")
+			old.instance = "a-old"
+			old.announce()
+			return old.instance, 0
+		},
+	},
+	{
+		name:  "untagged entry",
+		setup: func(*testing.T, *OperationLock) (string, uint64) { return "", 0 },
+	},
+}
+
+func TestRecoverJournalModuleBackup(t *testing.T) {
+	for _, tt := range writerStates {
+		t.Run(tt.name, func(t *testing.T) {
+			s, b := newTestStorage(t)
+			instance, token := tt.setup(t, b)
+
+			const id = "b1"
+			partial := filepath.Join(s.moduleDir(id), "data.json.tmp")
+			if err := os.MkdirAll(filepath.Dir(partial), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(partial, []byte("{"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			writeJournalEntry(t, s, journalEntry{Kind: "module", BackupID: id, ModuleID: "m", CreatedAt: time.Now(), Owner: "b", Instance: instance, LeaseToken: token})
+
+			s.recoverJournal()
+
+			_, journalErr := os.Stat(s.journalPath(id))
+			_, partialErr := os.Stat(partial)
+			info, metaErr := s.readModuleMetadata(id)
+			if tt.keep {
+				if journalErr != nil || partialErr != nil || metaErr == nil {
+					t.Errorf("in-flight backup touched: journal %v, partial file %v, metadata %v", journalErr, partialErr, metaErr)
+				}
+				return
+			}
+			if !os.IsNotExist(journalErr) || !os.IsNotExist(partialErr) {
+				t.Errorf("interrupted backup not cleared: journal %v, partial file %v", journalErr, partialErr)
+			}
+			if metaErr != nil || info.Status != "failed" {
+				t.Errorf("interrupted backup recorded as %v, %v; want failed", info, metaErr)
+			}
+		})
+	}
+}
+
+func TestClearSpool(t *testing.T) {
+	for _, tt := range writerStates {
+		t.Run(tt.name, func(t *testing.T) {
+			s, b := newTestStorage(t)
+			instance, token := tt.setup(t, b)
+			spool := filepath.Join(s.basePath, "spool")
+
+			// A full backup being spooled, and a module export being
+			// received.
+			const id = "f1"
+			if err := os.MkdirAll(filepath.Join(spool, id), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			writeJournalEntry(t, s, journalEntry{Kind: "full", BackupID: id, Owner: "b", Instance: instance, LeaseToken: token})
+			export := filepath.Join(spool, exportFilePrefix+instance+"-123")
+			if err := os.WriteFile(export, []byte("{"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			s.clearSpool()
+
+			for _, path := range []string{filepath.Join(spool, id), export} {
+				_, err := os.Stat(path)
+				if tt.keep && err != nil {
+					t.Errorf("%s of a running replica removed: %v", filepath.Base(path), err)
+				}
+				if !tt.keep && !os.IsNotExist(err) {
+					t.Errorf("%s left behind: %v", filepath.Base(path), err)
+				}
+			}
+		})
+	}
+}
+
+func TestClearSpoolKeepsResumableSpools(t *testing.T) {
+	tests := []struct {
+		name    string
+		created time.Time
+		keep    bool
+	}{
+		{name: "within BACKUP_RESUME_HOURS", created: time.Now().Add(-time.Hour), keep: true},
+		{name: "expired", created: time.Now().Add(-48 * time.Hour)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newTestStorage(t)
+			dir := filepath.Join(s.basePath, "spool", "f1")
+			if err := writeSpoolJob(dir, &spoolJob{Key: "k", CreatedAt: tt.created}); err != nil {
+				t.Fatal(err)
+			}
+
+			s.clearSpool()
+
+			if _, err := os.Stat(dir); (err == nil) != tt.keep {
+				t.Errorf("spool kept = %v, want %v", err == nil, tt.keep)
+			}
+		})
+	}
+}
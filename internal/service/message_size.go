@@ -0,0 +1,58 @@
+package service
+
+import "google.golang.org/grpc"
+
+const megabyte = 1024 * 1024
+
+// MessageSizeLimits holds gRPC max message sizes in bytes. Zero leaves the
+// gRPC default in place.
+type MessageSizeLimits struct {
+	MaxRecv int
+	MaxSend int
+}
+
+// ServerMessageSizeLimits reads the orchestrator's own gRPC server limits:
+//
+//	BACKUP_GRPC_MAX_RECV_MSG_MB  (default 100)
+//	BACKUP_GRPC_MAX_SEND_MSG_MB  (default 100)
+func ServerMessageSizeLimits() MessageSizeLimits {
+	return MessageSizeLimits{
+		MaxRecv: envInt("BACKUP_GRPC_MAX_RECV_MSG_MB", 100) * megabyte,
+		MaxSend: envInt("BACKUP_GRPC_MAX_SEND_MSG_MB", 100) * megabyte,
+	}
+}
+
+// moduleMessageSizeLimits reads the limits applied to calls made to modules:
+//
+//	BACKUP_MODULE_MAX_RECV_MSG_MB  (default 100)
+//	BACKUP_MODULE_MAX_SEND_MSG_MB  (default unset, gRPC's client default)
+func moduleMessageSizeLimits() MessageSizeLimits {
+	return MessageSizeLimits{
+		MaxRecv: envInt("BACKUP_MODULE_MAX_RECV_MSG_MB", 100) * megabyte,
+		MaxSend: envInt("BACKUP_MODULE_MAX_SEND_MSG_MB", 0) * megabyte,
+	}
+}
+
+// callOptions returns the per-call options enforcing the limits on a client.
+func (l MessageSizeLimits) callOptions() []grpc.CallOption {
+	var opts []grpc.CallOption
+	if l.MaxRecv > 0 {
+		opts = append(opts, grpc.MaxCallRecvMsgSize(l.MaxRecv))
+	}
+	if l.MaxSend > 0 {
+		opts = append(opts, grpc.MaxCallSendMsgSize(l.MaxSend))
+	}
+	return opts
+}
+
+// ServerOptions returns the options enforcing the limits on a server.
+func (l MessageSizeLimits) ServerOptions() []grpc.ServerOption {
+	var opts []grpc.ServerOption
+	if l.MaxRecv > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(l.MaxRecv))
+	}
+	if l.MaxSend > 0 {
+		opts = append(opts, grpc.MaxSendMsgSize(l.MaxSend))
+	}
+	return opts
+}
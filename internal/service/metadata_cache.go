@@ -0,0 +1,121 @@
+package service
+
+import (
+	"container/list"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// metadataCache keeps parsed metadata.json files so listing and polling do
+// not re-read and re-parse every backup. An entry is only used while the
+// file's mtime and size match, which catches changes made outside the
+// service (offline convert, manual edits); writes through BackupStorage
+// also drop the entry explicitly. Least recently used entries are evicted
+// beyond BACKUP_METADATA_CACHE_SIZE (default 5000; 0 disables the cache).
+type metadataCache struct {
+	mu    sync.Mutex
+	max   int
+	order *list.List // front is most recently used
+	items map[string]*list.Element
+
+	// legacy keeps the encoding/json fallback on until the storage's
+	// metadata has been migrated.
+	legacy atomic.Bool
+}
+
+type metadataEntry struct {
+	path    string
+	modTime time.Time
+	size    int64
+	msg     proto.Message
+}
+
+func newMetadataCache() *metadataCache {
+	c := &metadataCache{
+		max:   envInt("BACKUP_METADATA_CACHE_SIZE", 5000),
+		order: list.New(),
+		items: map[string]*list.Element{},
+	}
+	c.legacy.Store(true)
+	return c
+}
+
+// loadMetadata returns the metadata at path, parsed into a fresh T. Callers
+// own the result and may modify it.
+func loadMetadata[T proto.Message](c *metadataCache, path string, newMsg func() T) (T, error) {
+	var zero T
+	fi, err := os.Stat(path)
+	if err != nil {
+		c.invalidate(path)
+		return zero, err
+	}
+	if msg, ok := c.get(path, fi); ok {
+		return proto.Clone(msg).(T), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return zero, err
+	}
+	msg := newMsg()
+	if err := c.unmarshalMetadata(data, msg); err != nil {
+		return zero, fmt.Errorf("unmarshal: %w", err)
+	}
+	c.put(path, fi, proto.Clone(msg))
+	return msg, nil
+}
+
+func (c *metadataCache) get(path string, fi os.FileInfo) (proto.Message, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[path]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*metadataEntry)
+	if !e.modTime.Equal(fi.ModTime()) || e.size != fi.Size() {
+		c.order.Remove(el)
+		delete(c.items, path)
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return e.msg, true
+}
+
+func (c *metadataCache) put(path string, fi os.FileInfo, msg proto.Message) {
+	if c.max <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e := &metadataEntry{path: path, modTime: fi.ModTime(), size: fi.Size(), msg: msg}
+	if el, ok := c.items[path]; ok {
+		el.Value = e
+		c.order.MoveToFront(el)
+		return
+	}
+	c.items[path] = c.order.PushFront(e)
+	for c.order.Len() > c.max {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*metadataEntry).path)
+	}
+}
+
+// invalidate drops the entry for path, if any.
+func (c *metadataCache) invalidate(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[path]; ok {
+		c.order.Remove(el)
+		delete(c.items, path)
+	}
+}
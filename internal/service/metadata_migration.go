@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+)
+
+// Metadata written before the protojson switch used encoding/json: snake_case
+// keys and {seconds, nanos} timestamps. At startup the service rewrites any
+// such metadata.json and summary.json into protojson once, checking that
+// each rewritten file reads back to the same message, and records that in
+// metadata-migration.json. From then on the service reads metadata as
+// protojson only; a legacy file that turns up later, e.g. copied in by hand,
+// reads as corrupt until the migrate-metadata command is run over it. The
+// offline tools keep reading both, since they also work on copies of
+// backups from elsewhere.
+
+// metadataMigrationWait bounds how long startup waits for the operation
+// lock before leaving the migration to the next start.
+const metadataMigrationWait = time.Minute
+
+// MetadataMigration is the outcome of MigrateMetadata.
+type MetadataMigration struct {
+	Scanned    int       `json:"scanned"`
+	Migrated   int       `json:"migrated"`             // or, on a dry run, would be
+	Unreadable []string  `json:"unreadable,omitempty"` // in neither format; left to quarantine
+	Failed     []string  `json:"failed,omitempty"`     // path: reason
+	FinishedAt time.Time `json:"finishedAt"`
+}
+
+func (s *BackupStorage) metadataMigrationPath() string {
+	return filepath.Join(s.basePath, "metadata-migration.json")
+}
+
+// migrateMetadataOnce runs MigrateMetadata at startup unless an earlier
+// start already did.
+func (s *BackupStorage) migrateMetadataOnce() {
+	if _, err := os.Stat(s.metadataMigrationPath()); err == nil {
+		s.meta.legacy.Store(false)
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), metadataMigrationWait)
+	defer cancel()
+	_, unlock, err := s.LockOperations(ctx, "metadata migration")
+	if err != nil {
+		s.log.Warnf("Metadata migration postponed to the next start: %v", err)
+		return
+	}
+	defer unlock()
+
+	m, err := s.MigrateMetadata(false)
+	switch {
+	case err != nil:
+		s.log.Errorf("Metadata migration failed: %v", err)
+	case len(m.Failed) > 0:
+		s.log.Errorf("Metadata migration left %d legacy files as they were; legacy metadata stays readable: %v", len(m.Failed), m.Failed)
+	case m.Migrated > 0:
+		s.log.Infof("Migrated %d of %d metadata files to protojson", m.Migrated, m.Scanned)
+	}
+}
+
+// MigrateMetadata rewrites every legacy metadata file under the storage root
+// into protojson. Once all legacy files are migrated it records that, so
+// the service stops falling back to the legacy format. The caller holds the
+// operation lock.
+func (s *BackupStorage) MigrateMetadata(dryRun bool) (*MetadataMigration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m := &MetadataMigration{}
+	modules, err := os.ReadDir(filepath.Join(s.basePath, "modules"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("read modules dir: %w", err)
+	}
+	for _, e := range modules {
+		if e.IsDir() {
+			s.migrateMetadataFile(m, filepath.Join(s.moduleDir(e.Name()), "metadata.json"), &backupV1.BackupInfo{}, dryRun)
+		}
+	}
+
+	fulls, err := os.ReadDir(filepath.Join(s.basePath, "full"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("read full dir: %w", err)
+	}
+	for _, e := range fulls {
+		if !e.IsDir() {
+			continue
+		}
+		dir := s.fullDir(e.Name())
+		full := s.migrateMetadataFile(m, filepath.Join(dir, "metadata.json"), &backupV1.FullBackupInfo{}, dryRun)
+		if summary := filepath.Join(dir, "summary.json"); full != nil && !dryRun {
+			// The summary is derived from the manifest; rewrite it from the
+			// migrated one rather than converting it on its own.
+			if data, err := os.ReadFile(summary); err == nil && protojson.Unmarshal(data, &backupV1.FullBackupInfo{}) != nil {
+				s.meta.invalidate(summary)
+				if err := writeProtoFile(summary, fullBackupSummary(full.(*backupV1.FullBackupInfo))); err != nil {
+					m.Failed = append(m.Failed, fmt.Sprintf("%s: %v", summary, err))
+				}
+			}
+		}
+	}
+
+	m.FinishedAt = time.Now().UTC()
+	if dryRun || len(m.Failed) > 0 {
+		return m, nil
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal migration record: %w", err)
+	}
+	if err := os.WriteFile(s.metadataMigrationPath(), data, 0o644); err != nil {
+		return nil, fmt.Errorf("write migration record: %w", err)
+	}
+	s.meta.legacy.Store(false)
+	return m, nil
+}
+
+// migrateMetadataFile rewrites path into protojson if it is in the legacy
+// layout, and returns the message it holds when it is readable.
+func (s *BackupStorage) migrateMetadataFile(m *MetadataMigration, path string, msg proto.Message, dryRun bool) proto.Message {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	m.Scanned++
+	if protojson.Unmarshal(data, msg) == nil {
+		return msg
+	}
+	proto.Reset(msg)
+	if json.Unmarshal(data, msg) != nil {
+		m.Unreadable = append(m.Unreadable, path)
+		return nil
+	}
+	if dryRun {
+		m.Migrated++
+		return msg
+	}
+
+	if err := s.rewriteMetadata(path, data, msg); err != nil {
+		m.Failed = append(m.Failed, fmt.Sprintf("%s: %v", path, err))
+		return nil
+	}
+	m.Migrated++
+	return msg
+}
+
+// rewriteMetadata replaces the legacy file at path, holding orig, with msg
+// in protojson, and puts orig back unless the new file reads back to msg.
+func (s *BackupStorage) rewriteMetadata(path string, orig []byte, msg proto.Message) error {
+	s.meta.invalidate(path)
+	if err := writeProtoFile(path, msg); err != nil {
+		return err
+	}
+	check := msg.ProtoReflect().New().Interface()
+	data, err := os.ReadFile(path)
+	if err == nil {
+		err = protojson.Unmarshal(data, check)
+	}
+	if err == nil && !proto.Equal(check, msg) {
+		err = fmt.Errorf("rewritten metadata does not read back the same")
+	}
+	if err != nil {
+		if rerr := os.WriteFile(path, orig, 0o644); rerr != nil {
+			return fmt.Errorf("%v; restoring the original failed: %w", err, rerr)
+		}
+		return err
+	}
+	return nil
+}
+
+// unmarshalMetadata parses a metadata file for the cache: protojson, with
+// the legacy fallback until the storage has been migrated.
+func (c *metadataCache) unmarshalMetadata(data []byte, msg proto.Message) error {
+	if c.legacy.Load() {
+		return unmarshalWithFallback(data, msg)
+	}
+	err := protojson.Unmarshal(data, msg)
+	if err != nil && json.Unmarshal(data, msg.ProtoReflect().New().Interface()) == nil {
+		return fmt.Errorf("legacy encoding/json metadata after the storage was migrated; run migrate-metadata: %w", err)
+	}
+	return err
+}
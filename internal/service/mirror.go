@@ -0,0 +1,509 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+)
+
+// The mirror copies every new backup to a second place independent of the
+// storage backend, typically object storage next to a local disk, and
+// records how that went in the backup's mirror status. It lays backups out
+// as they are stored here, under modules/<id>/ and full/<id>/, with
+// metadata.json written last, so a backup directory copied back from the
+// mirror is usable as is. Backups deleted here are kept in the mirror; expire
+// them there, e.g. with a bucket lifecycle rule.
+//
+//	BACKUP_MIRROR_URL                  s3://bucket/prefix, rclone:remote:path, or a directory (file:///path or a plain path); unset turns mirroring off
+//	BACKUP_MIRROR_S3_ENDPOINT          default https://s3.<region>.amazonaws.com; for MinIO and the like, addressed path-style
+//	BACKUP_MIRROR_S3_REGION            default us-east-1
+//	BACKUP_MIRROR_S3_ACCESS_KEY        default AWS_ACCESS_KEY_ID
+//	BACKUP_MIRROR_S3_SECRET_KEY        default AWS_SECRET_ACCESS_KEY
+//	BACKUP_MIRROR_S3_SESSION_TOKEN     default AWS_SESSION_TOKEN
+//	BACKUP_MIRROR_S3_PART_SIZE_MB      files larger than this are uploaded in parts of this size (default 64, at least 5)
+//	BACKUP_MIRROR_S3_CONCURRENCY       parts uploaded at a time (default 4)
+//	BACKUP_MIRROR_RCLONE_BINARY        default rclone, looked up in PATH
+//	BACKUP_MIRROR_RCLONE_CONFIG        rclone config file holding the remote; default rclone's own
+//	BACKUP_MIRROR_RCLONE_FLAGS         extra flags for every rclone call, e.g. "--b2-hard-delete --low-level-retries 3"
+//	BACKUP_MIRROR_WORKERS              backups mirrored at a time (default 1)
+//	BACKUP_MIRROR_RETRY_ATTEMPTS       attempts per backup before it is marked failed (default 5)
+//	BACKUP_MIRROR_RETRY_BACKOFF_MS     default 5000, doubled per attempt up to 5 minutes
+//	BACKUP_MIRROR_RESCAN_MINUTES       how often pending and failed backups are picked up again (default 30)
+
+// mirrorTarget stores objects under keys relative to the mirror's root. get
+// fails with codes.NotFound for a key it does not hold.
+type mirrorTarget interface {
+	put(ctx context.Context, key string, body io.ReadSeeker, size int64, sha256 string) error
+	get(ctx context.Context, key string) (io.ReadCloser, error)
+}
+
+// mirrorPresigner is a mirrorTarget that can hand out time-limited links to
+// the objects it holds.
+type mirrorPresigner interface {
+	presign(ctx context.Context, key, filename string, ttl time.Duration) (string, error)
+}
+
+// Mirror copies backups to the mirror. A nil Mirror means mirroring is off.
+type Mirror struct {
+	*backupCopier
+	dest mirrorTarget
+}
+
+// NewMirror starts mirroring when BACKUP_MIRROR_URL is set. Every
+// backup.created event queues the new backup.
+func NewMirror(ctx *bootstrap.Context, storage *BackupStorage, events *EventPublisher) (*Mirror, func(), error) {
+	l := ctx.NewLoggerHelper("backup/mirror")
+	raw := strings.TrimSpace(os.Getenv("BACKUP_MIRROR_URL"))
+	if raw == "" {
+		return nil, func() {}, nil
+	}
+	dest, target, err := newMirrorTarget(raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("BACKUP_MIRROR_URL: %w", err)
+	}
+
+	m := &Mirror{
+		backupCopier: newBackupCopier("Mirror", "BACKUP_MIRROR", l, storage, target),
+		dest:         dest,
+	}
+	m.send = m.mirror
+	m.field = func(info proto.Message) **backupV1.ReplicationStatus {
+		if full, ok := info.(*backupV1.FullBackupInfo); ok {
+			return &full.Mirror
+		}
+		return &info.(*backupV1.BackupInfo).Mirror
+	}
+	m.start(events, envInt("BACKUP_MIRROR_WORKERS", 1))
+	return m, m.stop, nil
+}
+
+func newMirrorTarget(raw string) (mirrorTarget, string, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, "", err
+	}
+	switch u.Scheme {
+	case "s3":
+		if u.Host == "" {
+			return nil, "", fmt.Errorf("%q names no bucket", raw)
+		}
+		t, err := newS3Mirror(u.Host, strings.Trim(u.Path, "/"))
+		return t, "s3://" + u.Host + strings.TrimSuffix(u.Path, "/"), err
+	case "rclone":
+		if u.Opaque == "" {
+			return nil, "", fmt.Errorf("%q is not rclone:remote:path", raw)
+		}
+		t, err := newRcloneMirror(u.Opaque)
+		return t, "rclone:" + u.Opaque, err
+	case "file", "":
+		dir := u.Path
+		if u.Scheme == "" {
+			dir = raw
+		}
+		if !filepath.IsAbs(dir) {
+			return nil, "", fmt.Errorf("%q is not an absolute path", raw)
+		}
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, "", err
+		}
+		return dirMirror(dir), dir, nil
+	}
+	return nil, "", fmt.Errorf("unsupported scheme %q", u.Scheme)
+}
+
+// mirror copies one backup: its data files, then its metadata.
+func (m *Mirror) mirror(ctx context.Context, ref backupRef) error {
+	dir, meta, err := m.storage.copySource(ref)
+	if err != nil {
+		return err
+	}
+	files, err := replicaFiles(dir)
+	if err != nil {
+		return err
+	}
+	prefix := mirrorPrefix(ref)
+	for _, f := range files {
+		if err := m.putFile(ctx, prefix+f.Name, filepath.Join(dir, f.Name), f); err != nil {
+			return err
+		}
+	}
+
+	// The metadata goes last: a backup whose metadata is in the mirror is
+	// complete there.
+	if full, ok := meta.(*backupV1.FullBackupInfo); ok {
+		if err := m.putProto(ctx, prefix+"summary.json", fullBackupSummary(full)); err != nil {
+			return err
+		}
+	}
+	return m.putProto(ctx, prefix+"metadata.json", meta)
+}
+
+// fetch copies one backup from the mirror into dir, for a restore that
+// cannot read the local copy, and returns its metadata.
+func (m *Mirror) fetch(ctx context.Context, ref backupRef, dir string) (proto.Message, error) {
+	prefix := mirrorPrefix(ref)
+	body, err := m.dest.get(ctx, prefix+"metadata.json")
+	if err != nil {
+		return nil, err
+	}
+	raw, err := io.ReadAll(body)
+	body.Close()
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "get %smetadata.json: %v", prefix, err)
+	}
+	var meta proto.Message = &backupV1.BackupInfo{}
+	if ref.full {
+		meta = &backupV1.FullBackupInfo{}
+	}
+	if err := unmarshalWithFallback(raw, meta); err != nil {
+		return nil, status.Errorf(codes.DataLoss, "metadata of backup %s in the mirror: %v", ref.id, err)
+	}
+
+	for _, names := range storedFileNames(meta) {
+		found := false
+		for _, name := range names {
+			err := m.getFile(ctx, prefix+name, filepath.Join(dir, name))
+			if status.Code(err) == codes.NotFound {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			found = true
+			break
+		}
+		if !found {
+			return nil, status.Errorf(codes.DataLoss, "%s of backup %s is not in the mirror", names[0], ref.id)
+		}
+	}
+	return meta, nil
+}
+
+// presign returns a link to one data file of a backup in the mirror, served
+// as filename, or "" if the mirror cannot hand out links.
+func (m *Mirror) presign(ctx context.Context, ref backupRef, name, filename string, ttl time.Duration) (string, error) {
+	p, ok := m.dest.(mirrorPresigner)
+	if !ok {
+		return "", nil
+	}
+	return p.presign(ctx, mirrorPrefix(ref)+name, filename, ttl)
+}
+
+func (m *Mirror) getFile(ctx context.Context, key, path string) error {
+	body, err := m.dest.get(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, body)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path)
+		return status.Errorf(codes.Unavailable, "get %s: %v", key, err)
+	}
+	return nil
+}
+
+// mirrorPrefix is where a backup is kept in the mirror.
+func mirrorPrefix(ref backupRef) string {
+	if ref.full {
+		return "full/" + ref.id + "/"
+	}
+	return "modules/" + ref.id + "/"
+}
+
+// storedFileNames lists, for every data file a backup holds, the names it
+// may be stored under.
+func storedFileNames(meta proto.Message) [][]string {
+	switch info := meta.(type) {
+	case *backupV1.BackupInfo:
+		if info.DeltaBaseId != "" {
+			return [][]string{{"data.delta" + CompressionExt(CompressionGzip), "data.delta" + CompressionExt(CompressionZstd)}}
+		}
+		return [][]string{DataFileNames("data")}
+	case *backupV1.FullBackupInfo:
+		var names [][]string
+		for _, mb := range info.ModuleBackups {
+			if mb.Status == "completed" {
+				names = append(names, DataFileNames(mb.ModuleId))
+			}
+		}
+		return names
+	}
+	return nil
+}
+
+func (m *Mirror) putFile(ctx context.Context, key, path string, f *backupV1.ReplicaFile) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", f.Name, err)
+	}
+	defer file.Close()
+	return m.dest.put(ctx, key, file, f.Size, f.Sha256)
+}
+
+func (m *Mirror) putProto(ctx context.Context, key string, msg proto.Message) error {
+	data, err := protojson.MarshalOptions{Indent: "  ", EmitUnpopulated: true}.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("marshal %s: %w", filepath.Base(key), err)
+	}
+	sum := sha256.Sum256(data)
+	return m.dest.put(ctx, key, bytes.NewReader(data), int64(len(data)), hex.EncodeToString(sum[:]))
+}
+
+// dirMirror mirrors to a directory, e.g. a mounted remote file system.
+type dirMirror string
+
+func (d dirMirror) put(_ context.Context, key string, body io.ReadSeeker, _ int64, _ string) error {
+	path := filepath.Join(string(d), filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return status.Errorf(codes.Unavailable, "mirror: %v", err)
+	}
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "mirror: %v", err)
+	}
+	_, err = io.Copy(f, body)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return status.Errorf(codes.Unavailable, "mirror %s: %v", key, err)
+	}
+	return nil
+}
+
+func (d dirMirror) get(_ context.Context, key string) (io.ReadCloser, error) {
+	f, err := os.Open(filepath.Join(string(d), filepath.FromSlash(key)))
+	if os.IsNotExist(err) {
+		return nil, status.Errorf(codes.NotFound, "mirror has no %s", key)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "mirror %s: %v", key, err)
+	}
+	return f, nil
+}
+
+// s3Mirror mirrors to an S3 bucket, or any store speaking the S3 API. Files
+// larger than a part are sent as multipart uploads, since a single PUT takes
+// at most 5 GiB, with several parts in flight at a time.
+type s3Mirror struct {
+	client      *minio.Client
+	bucket      string
+	prefix      string
+	partSize    int64
+	concurrency uint
+}
+
+// S3 multipart limits: parts are 5 MiB to 5 GiB, at most 10000 of them.
+const (
+	s3MinPartSize = 5 << 20
+	s3MaxPartSize = 5 << 30
+	s3MaxParts    = 10000
+)
+
+func newS3Mirror(bucket, prefix string) (*s3Mirror, error) {
+	envOr := func(key, fallback string) string {
+		if v := os.Getenv(key); v != "" {
+			return v
+		}
+		return os.Getenv(fallback)
+	}
+	region := os.Getenv("BACKUP_MIRROR_S3_REGION")
+	if region == "" {
+		region = "us-east-1"
+	}
+	lookup := minio.BucketLookupPath
+	raw := os.Getenv("BACKUP_MIRROR_S3_ENDPOINT")
+	if raw == "" {
+		raw = "https://s3." + region + ".amazonaws.com"
+		lookup = minio.BucketLookupAuto
+	}
+	endpoint, err := url.Parse(raw)
+	if err != nil || endpoint.Host == "" || strings.Trim(endpoint.Path, "/") != "" {
+		return nil, fmt.Errorf("invalid BACKUP_MIRROR_S3_ENDPOINT %q", raw)
+	}
+	accessKey := envOr("BACKUP_MIRROR_S3_ACCESS_KEY", "AWS_ACCESS_KEY_ID")
+	secretKey := envOr("BACKUP_MIRROR_S3_SECRET_KEY", "AWS_SECRET_ACCESS_KEY")
+	if accessKey == "" || secretKey == "" {
+		return nil, fmt.Errorf("s3 mirror needs BACKUP_MIRROR_S3_ACCESS_KEY and BACKUP_MIRROR_S3_SECRET_KEY")
+	}
+	client, err := minio.New(endpoint.Host, &minio.Options{
+		Creds:        credentials.NewStaticV4(accessKey, secretKey, envOr("BACKUP_MIRROR_S3_SESSION_TOKEN", "AWS_SESSION_TOKEN")),
+		Secure:       endpoint.Scheme == "https",
+		Region:       region,
+		BucketLookup: lookup,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("s3 mirror: %w", err)
+	}
+	return &s3Mirror{
+		client:      client,
+		bucket:      bucket,
+		prefix:      prefix,
+		partSize:    min(max(int64(envInt("BACKUP_MIRROR_S3_PART_SIZE_MB", 64))<<20, s3MinPartSize), s3MaxPartSize),
+		concurrency: uint(max(envInt("BACKUP_MIRROR_S3_CONCURRENCY", 4), 1)),
+	}, nil
+}
+
+func (s *s3Mirror) key(key string) string {
+	if s.prefix != "" {
+		return s.prefix + "/" + key
+	}
+	return key
+}
+
+func (s *s3Mirror) put(ctx context.Context, key string, body io.ReadSeeker, size int64, _ string) error {
+	key = s.key(key)
+	if _, err := body.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	// The part size grows as needed to stay within the part limit.
+	partSize := max(s.partSize, (size+s3MaxParts-1)/s3MaxParts)
+	_, err := s.client.PutObject(ctx, s.bucket, key, body, size, minio.PutObjectOptions{
+		ContentType: "application/octet-stream",
+		PartSize:    uint64(partSize),
+		NumThreads:  s.concurrency,
+	})
+	if err != nil {
+		// A failed multipart upload is aborted so its parts don't linger
+		// (and bill) in the bucket. The client does that itself, but with
+		// ctx, which may be what failed.
+		if size > partSize && ctx.Err() != nil {
+			abortCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Minute)
+			if aerr := s.client.RemoveIncompleteUpload(abortCtx, s.bucket, key); aerr != nil {
+				err = fmt.Errorf("%w (and aborting the upload: %v)", err, aerr)
+			}
+			cancel()
+		}
+		return s3Error("put", key, err)
+	}
+	return nil
+}
+
+func (s *s3Mirror) get(ctx context.Context, key string) (io.ReadCloser, error) {
+	key = s.key(key)
+	obj, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err == nil {
+		// GetObject only sends the request once the object is used.
+		_, err = obj.Stat()
+	}
+	if err != nil {
+		if obj != nil {
+			obj.Close()
+		}
+		return nil, s3Error("get", key, err)
+	}
+	return obj, nil
+}
+
+// presign signs a GET of key with the mirror's credentials. The link works
+// without them until ttl has passed, or the credentials expire if they are
+// temporary.
+func (s *s3Mirror) presign(ctx context.Context, key, filename string, ttl time.Duration) (string, error) {
+	params := url.Values{}
+	params.Set("response-content-disposition", `attachment; filename="`+filename+`"`)
+	u, err := s.client.PresignedGetObject(ctx, s.bucket, s.key(key), ttl, params)
+	if err != nil {
+		return "", s3Error("presign", s.key(key), err)
+	}
+	return u.String(), nil
+}
+
+// s3Error maps a failed S3 call to a status: transient failures are
+// Unavailable, so the copy is retried.
+func s3Error(op, key string, err error) error {
+	resp := minio.ToErrorResponse(err)
+	code := codes.FailedPrecondition
+	switch {
+	case resp.StatusCode == 0 || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		code = codes.Unavailable
+	case resp.StatusCode == http.StatusNotFound:
+		code = codes.NotFound
+	case resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusUnauthorized:
+		code = codes.PermissionDenied
+	}
+	return status.Errorf(code, "%s %s: %v", op, key, err)
+}
+
+// GetMirrorStatus reports how far the mirror is behind: how many backups
+// are mirrored, still pending or failed, and the age of the oldest backup
+// not mirrored yet.
+func (s *OrchestratorService) GetMirrorStatus(_ context.Context, _ *backupV1.GetMirrorStatusRequest) (*backupV1.GetMirrorStatusResponse, error) {
+	if s.mirror == nil {
+		return nil, status.Error(codes.FailedPrecondition, "mirroring is not configured (BACKUP_MIRROR_URL)")
+	}
+	modules, err := s.storage.FindModuleBackups(BackupFilter{})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list backups: %v", err)
+	}
+	fulls, err := s.storage.FindFullBackupSummaries(BackupFilter{})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list full backups: %v", err)
+	}
+
+	resp := &backupV1.GetMirrorStatusResponse{Target: s.mirror.target}
+	var oldest time.Time
+	see := func(id string, created *timestamppb.Timestamp, st *backupV1.ReplicationStatus) {
+		switch st.GetState() {
+		case copyDone:
+			resp.Mirrored++
+			if at := st.GetReplicatedAt(); at != nil && (resp.LastMirroredAt == nil || at.AsTime().After(resp.LastMirroredAt.AsTime())) {
+				resp.LastMirroredAt = at
+			}
+			return
+		case copyPending:
+			resp.Pending++
+		case copyFailed:
+			resp.Failed++
+			resp.FailedBackupIds = append(resp.FailedBackupIds, id)
+		default:
+			// Taken before mirroring was turned on.
+			return
+		}
+		if created != nil && (oldest.IsZero() || created.AsTime().Before(oldest)) {
+			oldest = created.AsTime()
+		}
+	}
+	for _, b := range modules {
+		see(b.Id, b.CreatedAt, b.Mirror)
+	}
+	for _, b := range fulls {
+		see(b.Id, b.CreatedAt, b.Mirror)
+	}
+	if !oldest.IsZero() {
+		resp.LagSeconds = int64(time.Since(oldest).Seconds())
+	}
+	return resp, nil
+}
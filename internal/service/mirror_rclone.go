@@ -0,0 +1,209 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// rcloneMirror mirrors to an rclone remote by running the rclone binary, for
+// the storage services without a native target here: Backblaze B2, Dropbox,
+// FTP, SFTP, WebDAV and whatever else rclone supports. The remote is a
+// section of rclone's config file, or an on-the-fly ":type,opt=value:"
+// remote.
+type rcloneMirror struct {
+	binary string
+	remote string   // remote:path
+	args   []string // global flags for every call
+}
+
+func newRcloneMirror(remote string) (*rcloneMirror, error) {
+	name, _, ok := strings.Cut(remote, ":")
+	if !ok {
+		return nil, fmt.Errorf("%q is not remote:path", remote)
+	}
+	binary := os.Getenv("BACKUP_MIRROR_RCLONE_BINARY")
+	if binary == "" {
+		binary = "rclone"
+	}
+	path, err := exec.LookPath(binary)
+	if err != nil {
+		return nil, fmt.Errorf("rclone binary: %w", err)
+	}
+	r := &rcloneMirror{binary: path, remote: remote, args: []string{"--ask-password=false"}}
+	if config := os.Getenv("BACKUP_MIRROR_RCLONE_CONFIG"); config != "" {
+		r.args = append(r.args, "--config", config)
+	}
+	r.args = append(r.args, strings.Fields(os.Getenv("BACKUP_MIRROR_RCLONE_FLAGS"))...)
+
+	// A remote missing from the config would otherwise only show when the
+	// first backup fails to mirror.
+	if name != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		out, err := r.run(ctx, nil, "listremotes")
+		if err != nil {
+			return nil, fmt.Errorf("list rclone remotes: %w", err)
+		}
+		if !strings.Contains("\n"+string(out), "\n"+name+":\n") {
+			return nil, fmt.Errorf("rclone config has no remote %q", name)
+		}
+	}
+	return r, nil
+}
+
+// path returns the rclone path of key.
+func (r *rcloneMirror) path(key string) string {
+	if strings.HasSuffix(r.remote, ":") || strings.HasSuffix(r.remote, "/") {
+		return r.remote + key
+	}
+	return r.remote + "/" + key
+}
+
+func (r *rcloneMirror) put(ctx context.Context, key string, body io.ReadSeeker, size int64, _ string) error {
+	if _, err := body.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	// rcat checks the upload against the remote's hash where it has one.
+	_, err := r.run(ctx, body, "rcat", "--size", strconv.FormatInt(size, 10), r.path(key))
+	return r.error("put", key, err)
+}
+
+// get checks the object exists first: rclone cat prints nothing, and
+// succeeds, for a path that matches no object.
+func (r *rcloneMirror) get(ctx context.Context, key string) (io.ReadCloser, error) {
+	if _, err := r.run(ctx, nil, "lsjson", "--stat", r.path(key)); err != nil {
+		return nil, r.error("get", key, err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	cmd := r.command(ctx, "cat", r.path(key))
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	out, err := cmd.StdoutPipe()
+	if err == nil {
+		err = cmd.Start()
+	}
+	if err != nil {
+		cancel()
+		return nil, r.error("get", key, err)
+	}
+	return &rcloneReader{r: r, key: key, cmd: cmd, out: out, stderr: stderr, cancel: cancel}, nil
+}
+
+func (r *rcloneMirror) command(ctx context.Context, args ...string) *exec.Cmd {
+	return exec.CommandContext(ctx, r.binary, append(append([]string{}, r.args...), args...)...)
+}
+
+// run runs rclone to completion and returns its output. Its error carries
+// the last line rclone logged.
+func (r *rcloneMirror) run(ctx context.Context, stdin io.Reader, args ...string) ([]byte, error) {
+	cmd := r.command(ctx, args...)
+	cmd.Stdin = stdin
+	out := &bytes.Buffer{}
+	cmd.Stdout = out
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		return nil, rcloneFailure(ctx, err, stderr)
+	}
+	return out.Bytes(), nil
+}
+
+// rcloneExitError is a failed rclone run.
+type rcloneExitError struct {
+	code int
+	msg  string
+}
+
+func (e *rcloneExitError) Error() string {
+	return fmt.Sprintf("exit status %d: %s", e.code, e.msg)
+}
+
+func rcloneFailure(ctx context.Context, err error, stderr *bytes.Buffer) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	var exit *exec.ExitError
+	if !errors.As(err, &exit) {
+		return err
+	}
+	msg := strings.TrimSpace(stderr.String())
+	if i := strings.LastIndexByte(msg, '\n'); i >= 0 {
+		msg = msg[i+1:]
+	}
+	return &rcloneExitError{code: exit.ExitCode(), msg: msg}
+}
+
+// error maps a failed rclone run onto a gRPC status by rclone's exit code,
+// so the copier retries what may pass later.
+func (r *rcloneMirror) error(op, key string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+	var exit *rcloneExitError
+	if !errors.As(err, &exit) {
+		// rclone did not start.
+		return status.Errorf(codes.FailedPrecondition, "%s %s: %v", op, key, err)
+	}
+	switch exit.code {
+	case 3, 4: // directory or file not found
+		return status.Errorf(codes.NotFound, "%s %s: %v", op, key, err)
+	case 1, 7: // usage error, fatal error such as a suspended account
+		return status.Errorf(codes.FailedPrecondition, "%s %s: %v", op, key, err)
+	}
+	return status.Errorf(codes.Unavailable, "%s %s: %v", op, key, err)
+}
+
+// rcloneReader streams the output of rclone cat. The read that ends it
+// reports how rclone exited.
+type rcloneReader struct {
+	r      *rcloneMirror
+	key    string
+	cmd    *exec.Cmd
+	out    io.ReadCloser
+	stderr *bytes.Buffer
+	cancel context.CancelFunc
+	done   bool
+	err    error
+}
+
+func (rr *rcloneReader) Read(p []byte) (int, error) {
+	n, err := rr.out.Read(p)
+	if err == io.EOF {
+		if werr := rr.wait(); werr != nil {
+			return n, werr
+		}
+	}
+	return n, err
+}
+
+func (rr *rcloneReader) Close() error {
+	rr.cancel()
+	rr.wait()
+	return nil
+}
+
+func (rr *rcloneReader) wait() error {
+	if !rr.done {
+		rr.done = true
+		if err := rr.cmd.Wait(); err != nil {
+			rr.err = rr.r.error("get", rr.key, rcloneFailure(context.Background(), err, rr.stderr))
+		}
+		rr.cancel()
+	}
+	return rr.err
+}
@@ -0,0 +1,495 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeS3 is an S3 endpoint holding objects in memory. It checks the
+// Signature Version 4 of every request against its own credentials.
+type fakeS3 struct {
+	t         *testing.T
+	accessKey string
+	secretKey string
+	region    string
+	deny      bool // answer every request with AccessDenied
+	failPart  int  // reject this part number of multipart uploads
+
+	mu      sync.Mutex
+	objects map[string][]byte
+	uploads map[string]map[int][]byte // in-progress multipart uploads by ID
+	parts   []int                     // part numbers received, in order
+	aborted []string                  // IDs of aborted uploads
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *httptest.Server) {
+	f := &fakeS3{t: t, accessKey: "AKTEST", secretKey: "secret", region: "eu-test-1", objects: map[string][]byte{}, uploads: map[string]map[int][]byte{}}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	return f, srv
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if f.deny {
+		s3ErrorResponse(w, http.StatusForbidden, "AccessDenied")
+		return
+	}
+	if body, err = f.verify(r, body); err != nil {
+		f.t.Logf("%s %s: %v", r.Method, r.URL, err)
+		s3ErrorResponse(w, http.StatusForbidden, "SignatureDoesNotMatch")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	query := r.URL.Query()
+	switch uploadID := query.Get("uploadId"); {
+	case r.Method == http.MethodPost && query.Has("uploads"):
+		uploadID = fmt.Sprintf("upload-%d", len(f.uploads)+1)
+		f.uploads[uploadID] = map[int][]byte{}
+		fmt.Fprintf(w, "<InitiateMultipartUploadResult><UploadId>%s</UploadId></InitiateMultipartUploadResult>", uploadID)
+	case r.Method == http.MethodPut && uploadID != "":
+		var n int
+		fmt.Sscan(query.Get("partNumber"), &n)
+		if n == f.failPart {
+			s3ErrorResponse(w, http.StatusForbidden, "AccessDenied")
+			return
+		}
+		f.uploads[uploadID][n] = body
+		f.parts = append(f.parts, n)
+		w.Header().Set("ETag", `"`+checksum(body)+`"`)
+	case r.Method == http.MethodPost && uploadID != "":
+		var complete struct {
+			Parts []struct{ PartNumber int } `xml:"Part"`
+		}
+		if err := xml.Unmarshal(body, &complete); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var data []byte
+		for _, p := range complete.Parts {
+			data = append(data, f.uploads[uploadID][p.PartNumber]...)
+		}
+		f.objects[r.URL.Path] = data
+		delete(f.uploads, uploadID)
+		bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+		fmt.Fprintf(w, `<CompleteMultipartUploadResult><Bucket>%s</Bucket><Key>%s</Key><ETag>"%s"</ETag></CompleteMultipartUploadResult>`, bucket, key, checksum(data))
+	case r.Method == http.MethodDelete && uploadID != "":
+		delete(f.uploads, uploadID)
+		f.aborted = append(f.aborted, uploadID)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPut:
+		f.objects[r.URL.Path] = body
+		w.Header().Set("ETag", `"`+checksum(body)+`"`)
+	case r.Method == http.MethodGet, r.Method == http.MethodHead:
+		data, ok := f.objects[r.URL.Path]
+		if !ok {
+			s3ErrorResponse(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		w.Header().Set("ETag", `"`+checksum(data)+`"`)
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.Header().Set("Content-Length", fmt.Sprint(len(data)))
+		if r.Method == http.MethodGet {
+			w.Write(data)
+		}
+	default:
+		http.Error(w, "unexpected method", http.StatusMethodNotAllowed)
+	}
+}
+
+func s3ErrorResponse(w http.ResponseWriter, code int, s3Code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(code)
+	fmt.Fprintf(w, "<Error><Code>%s</Code><Message>%s</Message></Error>", s3Code, s3Code)
+}
+
+// streamingPayload marks a body sent as signed aws-chunked chunks.
+const streamingPayload = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD"
+
+// verify recomputes the request's signature, and those of the chunks of a
+// streamed body, and returns the decoded body. Presigned requests carry the
+// signature in the query instead of the Authorization header.
+func (f *fakeS3) verify(r *http.Request, body []byte) ([]byte, error) {
+	query := r.URL.Query()
+	fields := map[string]string{}
+	amzDate, payload := r.Header.Get("X-Amz-Date"), r.Header.Get("X-Amz-Content-Sha256")
+	if query.Get("X-Amz-Algorithm") == "AWS4-HMAC-SHA256" {
+		for _, k := range []string{"Credential", "SignedHeaders", "Signature"} {
+			fields[k] = query.Get("X-Amz-" + k)
+		}
+		amzDate, payload = query.Get("X-Amz-Date"), "UNSIGNED-PAYLOAD"
+		signed, err := time.Parse("20060102T150405Z", amzDate)
+		var ttl int
+		fmt.Sscan(query.Get("X-Amz-Expires"), &ttl)
+		if err != nil || time.Now().After(signed.Add(time.Duration(ttl)*time.Second)) {
+			return nil, fmt.Errorf("presigned link expired")
+		}
+		query.Del("X-Amz-Signature")
+	} else {
+		auth, ok := strings.CutPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ")
+		if !ok {
+			return nil, fmt.Errorf("not signed with SigV4: %q", r.Header.Get("Authorization"))
+		}
+		for _, kv := range strings.Split(auth, ",") {
+			k, v, _ := strings.Cut(strings.TrimSpace(kv), "=")
+			fields[k] = v
+		}
+	}
+	day, _, _ := strings.Cut(amzDate, "T")
+	scope := day + "/" + f.region + "/s3/aws4_request"
+	if want := f.accessKey + "/" + scope; fields["Credential"] != want {
+		return nil, fmt.Errorf("credential %q, want %q", fields["Credential"], want)
+	}
+
+	switch payload {
+	case "UNSIGNED-PAYLOAD", streamingPayload:
+	default:
+		if payload != sha256Hex(body) {
+			return nil, fmt.Errorf("payload hash %q does not match the body", payload)
+		}
+	}
+	var headers strings.Builder
+	for _, name := range strings.Split(fields["SignedHeaders"], ";") {
+		value := r.Header.Get(name)
+		switch name {
+		case "host":
+			value = r.Host
+		case "content-length":
+			value = fmt.Sprint(r.ContentLength)
+		}
+		headers.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var params []string
+	for _, k := range keys {
+		params = append(params, s3QueryEscape(k)+"="+s3QueryEscape(query.Get(k)))
+	}
+	canonical := strings.Join([]string{r.Method, r.URL.EscapedPath(), strings.Join(params, "&"), headers.String(), fields["SignedHeaders"], payload}, "\n")
+	toSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonical))
+
+	key := []byte("AWS4" + f.secretKey)
+	for _, part := range []string{day, f.region, "s3", "aws4_request"} {
+		key = hmacSum(key, part)
+	}
+	signature := hex.EncodeToString(hmacSum(key, toSign))
+	if fields["Signature"] != signature {
+		return nil, fmt.Errorf("signature %s, want %s", fields["Signature"], signature)
+	}
+	if payload != streamingPayload {
+		return body, nil
+	}
+
+	// Each chunk is "<hex size>;chunk-signature=<sig>\r\n<data>\r\n",
+	// signed over the previous signature, ending with an empty chunk.
+	var decoded []byte
+	for {
+		header, rest, ok := bytes.Cut(body, []byte("\r\n"))
+		if !ok {
+			return nil, fmt.Errorf("truncated chunk header")
+		}
+		sizeHex, chunkSig, _ := strings.Cut(string(header), ";chunk-signature=")
+		var size int
+		if _, err := fmt.Sscanf(sizeHex, "%x", &size); err != nil || len(rest) < size+2 {
+			return nil, fmt.Errorf("bad chunk header %q", header)
+		}
+		chunk := rest[:size]
+		want := hex.EncodeToString(hmacSum(key, "AWS4-HMAC-SHA256-PAYLOAD\n"+amzDate+"\n"+scope+"\n"+signature+"\n"+sha256Hex(nil)+"\n"+sha256Hex(chunk)))
+		if chunkSig != want {
+			return nil, fmt.Errorf("chunk signature %s, want %s", chunkSig, want)
+		}
+		signature = want
+		decoded = append(decoded, chunk...)
+		body = rest[size+2:]
+		if size == 0 {
+			break
+		}
+	}
+	if n := r.Header.Get("X-Amz-Decoded-Content-Length"); n != fmt.Sprint(len(decoded)) {
+		return nil, fmt.Errorf("decoded %d bytes, header says %s", len(decoded), n)
+	}
+	return decoded, nil
+}
+
+// s3QueryEscape encodes a query parameter as SigV4 canonicalizes it.
+func s3QueryEscape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSum(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// newTestS3Mirror configures an s3Mirror for f as BACKUP_MIRROR_URL
+// s3://bucket/prefix would.
+func newTestS3Mirror(t *testing.T, srv *httptest.Server, f *fakeS3, prefix, secretKey string) *s3Mirror {
+	t.Helper()
+	t.Setenv("BACKUP_MIRROR_S3_ENDPOINT", srv.URL)
+	t.Setenv("BACKUP_MIRROR_S3_REGION", f.region)
+	t.Setenv("BACKUP_MIRROR_S3_ACCESS_KEY", f.accessKey)
+	t.Setenv("BACKUP_MIRROR_S3_SECRET_KEY", secretKey)
+	m, err := newS3Mirror("bucket", prefix)
+	if err != nil {
+		t.Fatalf("newS3Mirror: %v", err)
+	}
+	return m
+}
+
+func TestS3MirrorPutGet(t *testing.T) {
+	tests := []struct {
+		name    string
+		prefix  string
+		data    []byte
+		wantKey string
+	}{
+		{name: "object", data: []byte(`{"id":"b1"}`), wantKey: "/bucket/modules/b1/metadata.json"},
+		{name: "under a prefix", prefix: "backups/site-a", data: []byte("data"), wantKey: "/bucket/backups/site-a/modules/b1/metadata.json"},
+		{name: "empty object", data: []byte{}, wantKey: "/bucket/modules/b1/metadata.json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, srv := newFakeS3(t)
+			m := newTestS3Mirror(t, srv, f, tt.prefix, f.secretKey)
+			ctx := context.Background()
+
+			if err := m.put(ctx, "modules/b1/metadata.json", bytes.NewReader(tt.data), int64(len(tt.data)), checksum(tt.data)); err != nil {
+				t.Fatalf("put: %v", err)
+			}
+			if got, ok := f.objects[tt.wantKey]; !ok || !bytes.Equal(got, tt.data) {
+				t.Fatalf("stored objects %v, want %s = %q", keysOf(f.objects), tt.wantKey, tt.data)
+			}
+
+			body, err := m.get(ctx, "modules/b1/metadata.json")
+			if err != nil {
+				t.Fatalf("get: %v", err)
+			}
+			defer body.Close()
+			if got, err := io.ReadAll(body); err != nil || !bytes.Equal(got, tt.data) {
+				t.Errorf("get = %q, %v; want %q", got, err, tt.data)
+			}
+		})
+	}
+}
+
+func TestS3MirrorPresign(t *testing.T) {
+	tests := []struct {
+		name      string
+		secretKey string
+		edit      func(u *url.URL)
+		want      int
+	}{
+		{name: "valid", want: http.StatusOK},
+		{name: "signed with other credentials", secretKey: "wrong", want: http.StatusForbidden},
+		{
+			name: "for another object",
+			edit: func(u *url.URL) { u.Path = strings.Replace(u.Path, "b1", "b2", 1) },
+			want: http.StatusForbidden,
+		},
+		{
+			name: "expiry extended",
+			edit: func(u *url.URL) {
+				q := u.Query()
+				q.Set("X-Amz-Expires", "86400")
+				u.RawQuery = q.Encode()
+			},
+			want: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, srv := newFakeS3(t)
+			data := []byte("stored data")
+			f.objects["/bucket/site-a/modules/b1/data.json.gz"] = data
+			f.objects["/bucket/site-a/modules/b2/data.json.gz"] = data
+			secretKey := f.secretKey
+			if tt.secretKey != "" {
+				secretKey = tt.secretKey
+			}
+			m := newTestS3Mirror(t, srv, f, "site-a", secretKey)
+
+			link, err := m.presign(context.Background(), "modules/b1/data.json.gz", "m-b1.json.gz", time.Minute)
+			if err != nil {
+				t.Fatalf("presign: %v", err)
+			}
+			u, err := url.Parse(link)
+			if err != nil {
+				t.Fatalf("parse %q: %v", link, err)
+			}
+			if tt.edit != nil {
+				tt.edit(u)
+			}
+
+			resp, err := http.Get(u.String())
+			if err != nil {
+				t.Fatalf("GET: %v", err)
+			}
+			defer resp.Body.Close()
+			got, _ := io.ReadAll(resp.Body)
+			if resp.StatusCode != tt.want {
+				t.Fatalf("GET status %d, want %d: %s", resp.StatusCode, tt.want, got)
+			}
+			if tt.want == http.StatusOK && !bytes.Equal(got, data) {
+				t.Errorf("GET = %q, want %q", got, data)
+			}
+		})
+	}
+}
+
+func TestS3MirrorErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		secretKey string
+		deny      bool
+		op        func(context.Context, *s3Mirror) error
+		want      codes.Code
+	}{
+		{
+			name: "missing object",
+			op: func(ctx context.Context, m *s3Mirror) error {
+				_, err := m.get(ctx, "modules/none/metadata.json")
+				return err
+			},
+			want: codes.NotFound,
+		},
+		{
+			name:      "wrong secret key",
+			secretKey: "not-the-secret",
+			op: func(ctx context.Context, m *s3Mirror) error {
+				return m.put(ctx, "k", strings.NewReader("x"), 1, checksum([]byte("x")))
+			},
+			want: codes.PermissionDenied,
+		},
+		{
+			name: "access denied",
+			deny: true,
+			op: func(ctx context.Context, m *s3Mirror) error {
+				return m.put(ctx, "k", strings.NewReader("x"), 1, checksum([]byte("x")))
+			},
+			want: codes.PermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, srv := newFakeS3(t)
+			f.deny = tt.deny
+			secretKey := f.secretKey
+			if tt.secretKey != "" {
+				secretKey = tt.secretKey
+			}
+			m := newTestS3Mirror(t, srv, f, "", secretKey)
+			if err := tt.op(context.Background(), m); status.Code(err) != tt.want {
+				t.Errorf("error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestS3MirrorMultipart(t *testing.T) {
+	data := make([]byte, 2*s3MinPartSize+1234)
+	for i := range data {
+		data[i] = byte(i * 7)
+	}
+	tests := []struct {
+		name        string
+		concurrency uint
+		failPart    int
+		wantParts   int
+		wantErr     codes.Code
+	}{
+		{name: "one part at a time", concurrency: 1, wantParts: 3},
+		{name: "parts in parallel", concurrency: 4, wantParts: 3},
+		{name: "failed part aborts the upload", concurrency: 2, failPart: 2, wantErr: codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, srv := newFakeS3(t)
+			f.failPart = tt.failPart
+			m := newTestS3Mirror(t, srv, f, "", f.secretKey)
+			m.partSize, m.concurrency = s3MinPartSize, tt.concurrency
+
+			err := m.put(context.Background(), "full/f1/m.json.zst", bytes.NewReader(data), int64(len(data)), checksum(data))
+			if status.Code(err) != tt.wantErr {
+				t.Fatalf("put error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != codes.OK {
+				if len(f.aborted) != 1 || len(f.uploads) != 0 {
+					t.Errorf("aborted uploads %v, left %d in progress; want the upload aborted", f.aborted, len(f.uploads))
+				}
+				if _, ok := f.objects["/bucket/full/f1/m.json.zst"]; ok {
+					t.Error("object stored despite the failed part")
+				}
+				return
+			}
+			if len(f.parts) != tt.wantParts {
+				t.Errorf("uploaded parts %v, want %d", f.parts, tt.wantParts)
+			}
+			if got := f.objects["/bucket/full/f1/m.json.zst"]; !bytes.Equal(got, data) {
+				t.Errorf("reassembled object is %d bytes, want the %d put", len(got), len(data))
+			}
+		})
+	}
+}
+
+func TestS3MirrorPartSize(t *testing.T) {
+	tests := []struct {
+		name string
+		env  string
+		want int64
+	}{
+		{name: "default", want: 64 << 20},
+		{name: "configured", env: "16", want: 16 << 20},
+		{name: "below the S3 minimum", env: "1", want: s3MinPartSize},
+		{name: "above the S3 maximum", env: "10000", want: s3MaxPartSize},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, srv := newFakeS3(t)
+			t.Setenv("BACKUP_MIRROR_S3_PART_SIZE_MB", tt.env)
+			if m := newTestS3Mirror(t, srv, f, "", f.secretKey); m.partSize != tt.want {
+				t.Errorf("part size = %d, want %d", m.partSize, tt.want)
+			}
+		})
+	}
+}
+
+func keysOf(m map[string][]byte) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -1,17 +1,23 @@
 package service
 
 import (
-	"bytes"
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/hex"
 	"fmt"
+	"hash"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/registry"
+	"github.com/go-kratos/kratos/v2/transport"
 	"github.com/tx7do/kratos-bootstrap/bootstrap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/backoff"
@@ -27,101 +33,386 @@ import (
 	"github.com/go-tangra/go-tangra-common/grpcx"
 )
 
-// ExportResult holds the result of a dynamic ExportBackup call.
+// ExportResult holds the result of a dynamic ExportBackup call. The export
+// itself is spooled to File as it streams in, so it never has to be held in
+// memory whole; Close removes the file.
 type ExportResult struct {
-	Data          []byte
+	File          string
+	Size          int64
+	Sha256        string
 	Module        string
 	Version       string
 	TenantID      uint32
 	EntityCounts  map[string]int64
 	SchemaVersion int32
+	// Warnings records transient failures that were retried.
+	Warnings []string
+	// Shared is set when the module exported through the shared
+	// common.service.v1.BackupService rather than its legacy service.
+	Shared bool
+}
+
+// ReadData reads the spooled export, for consumers that need it in memory.
+func (r *ExportResult) ReadData() ([]byte, error) {
+	return os.ReadFile(r.File)
+}
+
+// Close removes the spooled export.
+func (r *ExportResult) Close() {
+	if r != nil && r.File != "" {
+		os.Remove(r.File)
+	}
+}
+
+// exportFilePrefix starts the names of exports in the spool, which go on
+// with the instance receiving them so other replicas leave them alone.
+const exportFilePrefix = "export-"
+
+// exportFileInstance returns the instance receiving the spooled export
+// name, if it is one.
+func exportFileInstance(name string) (string, bool) {
+	rest, ok := strings.CutPrefix(name, exportFilePrefix)
+	if !ok {
+		return "", false
+	}
+	// os.CreateTemp puts its random part after the last dash.
+	i := strings.LastIndex(rest, "-")
+	if i < 0 {
+		return "", false
+	}
+	return rest[:i], true
+}
+
+// exportFile is an export being received into the spool. It hashes and
+// counts what is written to it.
+type exportFile struct {
+	f *os.File
+	h hash.Hash
+	n int64
+}
+
+func newExportFile(dir string) (*exportFile, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create spool dir: %w", err)
+	}
+	f, err := os.CreateTemp(dir, exportFilePrefix+processInstance+"-*")
+	if err != nil {
+		return nil, fmt.Errorf("create export spool file: %w", err)
+	}
+	return &exportFile{f: f, h: sha256.New()}, nil
+}
+
+func (e *exportFile) Write(p []byte) (int, error) {
+	n, err := e.f.Write(p)
+	e.h.Write(p[:n])
+	e.n += int64(n)
+	return n, err
+}
+
+// reset empties the file for another attempt.
+func (e *exportFile) reset() error {
+	if err := e.f.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := e.f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	e.h.Reset()
+	e.n = 0
+	return nil
+}
+
+// finish closes the file and records it in r.
+func (e *exportFile) finish(r *ExportResult) (*ExportResult, error) {
+	if err := e.f.Close(); err != nil {
+		os.Remove(e.f.Name())
+		return nil, fmt.Errorf("write export spool file: %w", err)
+	}
+	r.File, r.Size, r.Sha256 = e.f.Name(), e.n, hex.EncodeToString(e.h.Sum(nil))
+	return r, nil
+}
+
+// discard closes and removes the file.
+func (e *exportFile) discard() {
+	e.f.Close()
+	os.Remove(e.f.Name())
+}
+
+// Default timeouts for module calls, used only when the caller's context has
+// no deadline of its own.
+const (
+	unaryCallTimeout  = 60 * time.Second
+	streamCallTimeout = 10 * time.Minute
+)
+
+// callContext derives the context for a single module call. A deadline set by
+// the caller always wins so it propagates to the module unchanged; otherwise
+// the default timeout applies. Cancellation of ctx aborts the call either way.
+func callContext(ctx context.Context, def time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, def)
 }
 
 // ModuleClient connects to any module's BackupService dynamically using raw
 // gRPC invocation. It does not import any module-specific proto code.
 type ModuleClient struct {
-	log *log.Helper
+	log        *log.Helper
+	limits     MessageSizeLimits
+	compressor string
+	retry      atomic.Pointer[RetryPolicy]
+	discovery  registry.Discovery
+	throttle   *Throttle
+	// spoolDir receives exports as they stream in.
+	spoolDir string
+	// healthCheck enables a grpc.health.v1 probe before each module call.
+	healthCheck bool
 }
 
-// NewModuleClient creates a new dynamic module client.
-func NewModuleClient(ctx *bootstrap.Context) *ModuleClient {
-	return &ModuleClient{
-		log: ctx.NewLoggerHelper("backup/module-client"),
+// NewModuleClient creates a new dynamic module client. Its retry policy
+// follows runtime config reloads.
+func NewModuleClient(ctx *bootstrap.Context, runtime *RuntimeConfig, throttle *Throttle) (*ModuleClient, error) {
+	l := ctx.NewLoggerHelper("backup/module-client")
+	d, err := newModuleDiscovery(ctx.GetConfig(), l)
+	if err != nil {
+		return nil, err
 	}
+	c := &ModuleClient{
+		log:        l,
+		limits:     moduleMessageSizeLimits(),
+		compressor: moduleCompressor(l),
+		discovery:  d,
+		throttle:   throttle,
+		spoolDir:   filepath.Join(StoragePath(), "spool"),
+
+		healthCheck: os.Getenv("BACKUP_MODULE_HEALTH_CHECK") == "true",
+	}
+	c.reloadRetryPolicy()
+	runtime.OnReload(c.reloadRetryPolicy)
+	return c, nil
+}
+
+func (c *ModuleClient) reloadRetryPolicy() {
+	p := moduleRetryPolicy(c.log)
+	c.retry.Store(&p)
 }
 
 // ExportBackup obtains a module's backup. It prefers the shared streaming
 // common.service.v1.BackupService (schema-agnostic SQL dump); if the module
-// hasn't migrated to it yet (Unimplemented), it falls back to the legacy unary
-// per-module BackupService. Either way it returns the archive bytes.
-func (c *ModuleClient) ExportBackup(ctx context.Context, target *backupV1.ModuleTarget, tenantID *uint32, includeSecrets bool) (*ExportResult, error) {
-	conn, cleanup, err := c.dialModule(target.GrpcEndpoint, target.ModuleId == "lcm")
+// hasn't migrated to it yet (Unimplemented), it falls back to the legacy
+// per-module BackupService, streaming where supported and unary otherwise.
+// Either way the archive is spooled to the result's File, which the caller
+// must Close. Transient failures are retried
+// according to the client's RetryPolicy and recorded in the result's warnings.
+func (c *ModuleClient) ExportBackup(ctx context.Context, target *backupV1.ModuleTarget, tenantID *uint32, includeSecrets bool) (_ *ExportResult, err error) {
+	ctx, span := startModuleSpan(ctx, "ExportBackup", target.ModuleId)
+	defer func() { endSpan(span, err) }()
+
+	var result *ExportResult
+	warnings, err := c.retry.Load().Do(ctx, func() error {
+		var err error
+		result, err = c.exportOnce(ctx, target, tenantID, includeSecrets)
+		if err != nil {
+			c.log.Warnf("ExportBackup attempt on %s failed: %v", target.ModuleId, err)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Warnings = append(warnings, result.Warnings...)
+	return result, nil
+}
+
+func (c *ModuleClient) exportOnce(ctx context.Context, target *backupV1.ModuleTarget, tenantID *uint32, includeSecrets bool) (_ *ExportResult, err error) {
+	conn, cleanup, err := c.dialModule(target.GrpcEndpoint, target.ModuleId, target.Tls)
 	if err != nil {
 		return nil, fmt.Errorf("dial %s at %s: %w", target.ModuleId, target.GrpcEndpoint, err)
 	}
 	defer cleanup()
 
+	out, err := newExportFile(c.spoolDir)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			out.discard()
+		}
+	}()
+
 	outCtx := forwardMetadata(ctx)
 
+	health, err := c.preflightHealth(outCtx, conn, target.ModuleId)
+	if err != nil {
+		return nil, err
+	}
+
 	// Preferred: streaming SQL-dump backup.
-	data, serr := c.exportStreaming(outCtx, conn, includeSecrets)
+	serr := c.exportStreaming(outCtx, conn, includeSecrets, out)
 	if serr == nil {
-		c.log.Infof("Streamed SQL backup from %s (%d bytes)", target.ModuleId, len(data))
-		return &ExportResult{Data: data, Module: target.ModuleId, TenantID: tenantIDValue(tenantID)}, nil
+		c.log.Infof("Streamed SQL backup from %s (%d bytes)", target.ModuleId, out.n)
+		return out.finish(&ExportResult{Module: target.ModuleId, TenantID: tenantIDValue(tenantID), Shared: true})
 	}
 	if status.Code(serr) != codes.Unimplemented {
 		return nil, fmt.Errorf("stream export %s: %w", target.ModuleId, serr)
 	}
+	if err := out.reset(); err != nil {
+		return nil, err
+	}
 
-	// Fallback: legacy unary per-module BackupService.
+	// Fallback: legacy per-module BackupService, chunked if the module
+	// implements StreamExportBackup, otherwise a single unary response.
 	c.log.Infof("%s has no streaming BackupService; using legacy export", target.ModuleId)
-	method := fmt.Sprintf("/%s.service.v1.BackupService/ExportBackup", backupServicePackage(target.ModuleId))
 	req := &backupV1.ModuleExportRequest{TenantId: tenantID, IncludeSecrets: includeSecrets}
+
+	result, lerr := c.exportLegacyStreaming(outCtx, conn, legacyBackupService(target), req, out)
+	if lerr == nil {
+		c.log.Infof("Streamed legacy backup from %s (%d bytes)", target.ModuleId, out.n)
+		return out.finish(result)
+	}
+	if status.Code(lerr) != codes.Unimplemented {
+		return nil, fmt.Errorf("stream legacy export %s: %w", target.ModuleId, lerr)
+	}
+	if err := out.reset(); err != nil {
+		return nil, err
+	}
+
+	method := fmt.Sprintf("/%s/ExportBackup", legacyBackupService(target))
 	resp := &backupV1.ModuleExportResponse{}
-	callCtx, cancel := context.WithTimeout(outCtx, 60*time.Second)
+	callCtx, cancel := callContext(outCtx, unaryCallTimeout)
 	defer cancel()
 	if err := conn.Invoke(callCtx, method, req, resp); err != nil {
-		return nil, fmt.Errorf("invoke ExportBackup on %s: %w", target.ModuleId, err)
+		return nil, fmt.Errorf("invoke ExportBackup on %s: %w", target.ModuleId, describeUnimplemented(target.ModuleId, health, err))
 	}
-	return &ExportResult{
-		Data:          resp.Data,
+	if _, err := out.Write(resp.Data); err != nil {
+		return nil, fmt.Errorf("write export spool file: %w", err)
+	}
+	return out.finish(&ExportResult{
 		Module:        resp.Module,
 		Version:       resp.Version,
 		TenantID:      resp.TenantId,
 		EntityCounts:  resp.EntityCounts,
 		SchemaVersion: resp.SchemaVersion,
-	}, nil
+	})
 }
 
 // exportStreaming pulls the archive via the streaming common.BackupService and
-// accumulates the chunks. The Unimplemented sentinel (when present) surfaces on
-// the first Recv.
-func (c *ModuleClient) exportStreaming(ctx context.Context, conn *grpc.ClientConn, includeSecrets bool) ([]byte, error) {
-	callCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+// writes the chunks to w as they arrive. The Unimplemented sentinel (when
+// present) surfaces on the first Recv.
+func (c *ModuleClient) exportStreaming(ctx context.Context, conn *grpc.ClientConn, includeSecrets bool, w io.Writer) error {
+	callCtx, cancel := callContext(ctx, streamCallTimeout)
 	defer cancel()
 
 	stream, err := commonV1.NewBackupServiceClient(conn).ExportBackup(callCtx, &commonV1.ExportBackupRequest{IncludeSecrets: includeSecrets})
 	if err != nil {
-		return nil, err
+		return err
 	}
-	var buf bytes.Buffer
 	for {
 		msg, err := stream.Recv()
 		if err == io.EOF {
-			return buf.Bytes(), nil
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(msg.GetContent()); err != nil {
+			return fmt.Errorf("write export spool file: %w", err)
+		}
+	}
+}
+
+// legacyExportStreamDesc describes the per-module StreamExportBackup RPC for
+// raw invocation, since the module's generated client isn't imported.
+var legacyExportStreamDesc = &grpc.StreamDesc{
+	StreamName:    "StreamExportBackup",
+	ServerStreams: true,
+}
+
+// exportLegacyStreaming calls the per-module StreamExportBackup RPC and
+// writes the data chunks to out as they arrive, taking metadata from the
+// trailing summary.
+func (c *ModuleClient) exportLegacyStreaming(ctx context.Context, conn *grpc.ClientConn, service string, req *backupV1.ModuleExportRequest, out *exportFile) (*ExportResult, error) {
+	callCtx, cancel := callContext(ctx, streamCallTimeout)
+	defer cancel()
+
+	method := fmt.Sprintf("/%s/StreamExportBackup", service)
+	stream, err := conn.NewStream(callCtx, legacyExportStreamDesc, method)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.SendMsg(req); err != nil {
+		return nil, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+
+	var summary *backupV1.ModuleExportSummary
+	for {
+		msg := &backupV1.ModuleExportChunk{}
+		err := stream.RecvMsg(msg)
+		if err == io.EOF {
+			break
 		}
 		if err != nil {
 			return nil, err
 		}
-		buf.Write(msg.GetContent())
+		switch p := msg.Payload.(type) {
+		case *backupV1.ModuleExportChunk_Data:
+			if _, err := out.Write(p.Data); err != nil {
+				return nil, fmt.Errorf("write export spool file: %w", err)
+			}
+		case *backupV1.ModuleExportChunk_Summary:
+			summary = p.Summary
+		}
+	}
+	if summary == nil {
+		return nil, fmt.Errorf("export stream ended without a summary")
+	}
+	if summary.TotalBytes > 0 && summary.TotalBytes != out.n {
+		return nil, fmt.Errorf("export stream truncated: got %d of %d bytes", out.n, summary.TotalBytes)
 	}
+
+	return &ExportResult{
+		Module:        summary.Module,
+		Version:       summary.Version,
+		TenantID:      summary.TenantId,
+		EntityCounts:  summary.EntityCounts,
+		SchemaVersion: summary.SchemaVersion,
+	}, nil
 }
 
 // ImportBackup restores a module's backup. It prefers the streaming
 // common.service.v1.BackupService; on Unimplemented it falls back to the legacy
-// unary per-module BackupService.
-func (c *ModuleClient) ImportBackup(ctx context.Context, target *backupV1.ModuleTarget, data []byte, mode backupV1.RestoreMode) (*backupV1.ModuleImportResponse, error) {
-	conn, cleanup, err := c.dialModule(target.GrpcEndpoint, target.ModuleId == "lcm")
+// unary per-module BackupService. Transient failures are retried according to
+// the client's RetryPolicy and recorded in the response's warnings. Imports
+// through the shared service upsert, so resending one is safe; a legacy
+// import is only retried when it failed before any data was sent, since the
+// module may have applied part of it.
+func (c *ModuleClient) ImportBackup(ctx context.Context, target *backupV1.ModuleTarget, data []byte, mode backupV1.RestoreMode) (_ *backupV1.ModuleImportResponse, err error) {
+	ctx, span := startModuleSpan(ctx, "ImportBackup", target.ModuleId)
+	defer func() { endSpan(span, err) }()
+
+	var resp *backupV1.ModuleImportResponse
+	warnings, err := c.retry.Load().Do(ctx, func() error {
+		var err error
+		resp, err = c.importOnce(ctx, target, data, mode)
+		if err != nil {
+			c.log.Warnf("ImportBackup attempt on %s failed: %v", target.ModuleId, err)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp.Warnings = append(warnings, resp.Warnings...)
+	return resp, nil
+}
+
+func (c *ModuleClient) importOnce(ctx context.Context, target *backupV1.ModuleTarget, data []byte, mode backupV1.RestoreMode) (*backupV1.ModuleImportResponse, error) {
+	conn, cleanup, err := c.dialModule(target.GrpcEndpoint, target.ModuleId, target.Tls)
 	if err != nil {
 		return nil, fmt.Errorf("dial %s at %s: %w", target.ModuleId, target.GrpcEndpoint, err)
 	}
@@ -129,7 +420,12 @@ func (c *ModuleClient) ImportBackup(ctx context.Context, target *backupV1.Module
 
 	outCtx := forwardMetadata(ctx)
 
-	resp, serr := c.importStreaming(outCtx, conn, data, mode)
+	health, err := c.preflightHealth(outCtx, conn, target.ModuleId)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, serr := c.importStreaming(outCtx, conn, data, commonV1.RestoreMode_RESTORE_MODE_MERGE)
 	if serr == nil {
 		return resp, nil
 	}
@@ -137,25 +433,68 @@ func (c *ModuleClient) ImportBackup(ctx context.Context, target *backupV1.Module
 		return nil, fmt.Errorf("stream import %s: %w", target.ModuleId, serr)
 	}
 
-	// Fallback: legacy unary.
+	// Fallback: legacy per-module BackupService, chunked if the module
+	// implements StreamImportBackup, otherwise a single unary request.
 	c.log.Infof("%s has no streaming BackupService; using legacy import", target.ModuleId)
-	method := fmt.Sprintf("/%s.service.v1.BackupService/ImportBackup", backupServicePackage(target.ModuleId))
+	resp, lerr := c.importLegacyStreaming(outCtx, conn, legacyBackupService(target), data, mode)
+	if lerr == nil {
+		return resp, nil
+	}
+	if status.Code(lerr) != codes.Unimplemented {
+		return nil, fmt.Errorf("stream legacy import %s: %w", target.ModuleId, lerr)
+	}
+
+	method := fmt.Sprintf("/%s/ImportBackup", legacyBackupService(target))
 	req := &backupV1.ModuleImportRequest{Data: data, Mode: mode}
 	out := &backupV1.ModuleImportResponse{}
-	callCtx, cancel := context.WithTimeout(outCtx, 60*time.Second)
+	callCtx, cancel := callContext(outCtx, unaryCallTimeout)
 	defer cancel()
 	if err := conn.Invoke(callCtx, method, req, out); err != nil {
-		return nil, fmt.Errorf("invoke ImportBackup on %s: %w", target.ModuleId, err)
+		err = fmt.Errorf("invoke ImportBackup on %s: %w", target.ModuleId, describeUnimplemented(target.ModuleId, health, err))
+		if status.Code(err) == codes.Unimplemented {
+			return nil, err
+		}
+		// The request carried all the data: the module may have applied it.
+		return nil, noRetry{err}
 	}
 	return out, nil
 }
 
+// ResetBackup imports data through the shared common.BackupService in
+// FULL_SYNC mode, so rows that are not in data are deleted. It is how test
+// restores put the validation tenant back; legacy per-module services have
+// no such mode, and the call fails for them. Transient failures are retried
+// like ImportBackup's.
+func (c *ModuleClient) ResetBackup(ctx context.Context, target *backupV1.ModuleTarget, data []byte) (_ *backupV1.ModuleImportResponse, err error) {
+	ctx, span := startModuleSpan(ctx, "ResetBackup", target.ModuleId)
+	defer func() { endSpan(span, err) }()
+
+	var resp *backupV1.ModuleImportResponse
+	warnings, err := c.retry.Load().Do(ctx, func() error {
+		conn, cleanup, err := c.dialModule(target.GrpcEndpoint, target.ModuleId, target.Tls)
+		if err != nil {
+			return fmt.Errorf("dial %s at %s: %w", target.ModuleId, target.GrpcEndpoint, err)
+		}
+		defer cleanup()
+		resp, err = c.importStreaming(forwardMetadata(ctx), conn, data, commonV1.RestoreMode_RESTORE_MODE_FULL_SYNC)
+		if err != nil {
+			c.log.Warnf("ResetBackup attempt on %s failed: %v", target.ModuleId, err)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp.Warnings = append(warnings, resp.Warnings...)
+	return resp, nil
+}
+
 // importStreaming restores via the streaming common.BackupService: send options,
-// then the archive in chunks, then receive the result. The legacy OVERWRITE/SKIP
-// modes both map to MERGE (live-safe upsert); FULL_SYNC is not yet exposed by the
-// orchestrator API.
-func (c *ModuleClient) importStreaming(ctx context.Context, conn *grpc.ClientConn, data []byte, _ backupV1.RestoreMode) (*backupV1.ModuleImportResponse, error) {
-	callCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+// then the archive in chunks, then receive the result. Restores always use
+// MERGE (live-safe upsert): the legacy OVERWRITE/SKIP modes both map to it, and
+// FULL_SYNC is only used by ResetBackup.
+func (c *ModuleClient) importStreaming(ctx context.Context, conn *grpc.ClientConn, data []byte, mode commonV1.RestoreMode) (*backupV1.ModuleImportResponse, error) {
+	callCtx, cancel := callContext(ctx, streamCallTimeout)
 	defer cancel()
 
 	stream, err := commonV1.NewBackupServiceClient(conn).ImportBackup(callCtx)
@@ -164,7 +503,7 @@ func (c *ModuleClient) importStreaming(ctx context.Context, conn *grpc.ClientCon
 	}
 	if err := stream.Send(&commonV1.ImportBackupRequest{
 		Payload: &commonV1.ImportBackupRequest_Options{
-			Options: &commonV1.ImportOptions{Mode: commonV1.RestoreMode_RESTORE_MODE_MERGE},
+			Options: &commonV1.ImportOptions{Mode: mode},
 		},
 	}); err != nil {
 		return nil, err
@@ -196,6 +535,78 @@ func (c *ModuleClient) importStreaming(ctx context.Context, conn *grpc.ClientCon
 	return out, nil
 }
 
+// legacyImportStreamDesc describes the per-module StreamImportBackup RPC for
+// raw invocation.
+var legacyImportStreamDesc = &grpc.StreamDesc{
+	StreamName:    "StreamImportBackup",
+	ClientStreams: true,
+}
+
+// importLegacyStreaming sends the archive to the per-module StreamImportBackup
+// RPC: options first, then the data in chunks. Unlike the shared service, the
+// legacy RestoreMode is passed through unchanged.
+func (c *ModuleClient) importLegacyStreaming(ctx context.Context, conn *grpc.ClientConn, service string, data []byte, mode backupV1.RestoreMode) (*backupV1.ModuleImportResponse, error) {
+	callCtx, cancel := callContext(ctx, streamCallTimeout)
+	defer cancel()
+
+	method := fmt.Sprintf("/%s/StreamImportBackup", service)
+	stream, err := conn.NewStream(callCtx, legacyImportStreamDesc, method)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.SendMsg(&backupV1.ModuleImportChunk{
+		Payload: &backupV1.ModuleImportChunk_Options{
+			Options: &backupV1.ModuleImportOptions{Mode: mode, TotalBytes: int64(len(data))},
+		},
+	}); err != nil {
+		return nil, recvStreamError(stream, err)
+	}
+	const chunk = 256 * 1024
+	for off := 0; off < len(data); {
+		end := off + chunk
+		if end > len(data) {
+			end = len(data)
+		}
+		if err := stream.SendMsg(&backupV1.ModuleImportChunk{
+			Payload: &backupV1.ModuleImportChunk_Data{Data: data[off:end]},
+		}); err != nil {
+			return nil, importSent(off > 0, recvStreamError(stream, err))
+		}
+		off = end
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, importSent(len(data) > 0, err)
+	}
+
+	out := &backupV1.ModuleImportResponse{}
+	if err := stream.RecvMsg(out); err != nil {
+		return nil, importSent(len(data) > 0, err)
+	}
+	return out, nil
+}
+
+// importSent keeps a legacy import that failed after data went out from
+// being retried.
+func importSent(sent bool, err error) error {
+	if sent && status.Code(err) != codes.Unimplemented {
+		return noRetry{err}
+	}
+	return err
+}
+
+// recvStreamError returns the server's status when SendMsg fails with io.EOF,
+// which is how gRPC reports that the server already ended the stream (e.g.
+// with Unimplemented).
+func recvStreamError(stream grpc.ClientStream, err error) error {
+	if err != io.EOF {
+		return err
+	}
+	if rerr := stream.RecvMsg(&backupV1.ModuleImportResponse{}); rerr != nil {
+		return rerr
+	}
+	return err
+}
+
 func boolToInt(b bool) int64 {
 	if b {
 		return 1
@@ -203,14 +614,28 @@ func boolToInt(b bool) int64 {
 	return 0
 }
 
-// backupServicePackage returns the proto package name for a module's BackupService.
-// Most modules register under their own namespace (e.g., "ipam.service.v1.BackupService"),
-// but the scheduler uses the shared "backup.service.v1.BackupService" proto.
-func backupServicePackage(moduleID string) string {
-	if moduleID == "scheduler" {
-		return "backup"
+// legacyBackupService returns the fully-qualified name of a module's legacy
+// BackupService. In order of precedence:
+//
+//   - the target's backup_service field
+//   - BACKUP_SERVICE_OVERRIDES, e.g. "hr=people.api.v1.BackupService,asset=assets.v2.BackupService"
+//   - the "{moduleId}.service.v1.BackupService" convention
+//
+// The scheduler uses the shared "backup.service.v1.BackupService" proto.
+func legacyBackupService(target *backupV1.ModuleTarget) string {
+	if target.BackupService != "" {
+		return target.BackupService
+	}
+	for _, entry := range envList("BACKUP_SERVICE_OVERRIDES") {
+		id, service, ok := strings.Cut(entry, "=")
+		if ok && strings.TrimSpace(id) == target.ModuleId && strings.TrimSpace(service) != "" {
+			return strings.TrimSpace(service)
+		}
+	}
+	if target.ModuleId == "scheduler" {
+		return "backup.service.v1.BackupService"
 	}
-	return moduleID
+	return target.ModuleId + ".service.v1.BackupService"
 }
 
 // resolveEndpoint replaces the hostname in a module endpoint if
@@ -222,7 +647,7 @@ func backupServicePackage(moduleID string) string {
 //	"lcm-service:9100" → "portal.infra.verax.net:9100"
 func resolveEndpoint(endpoint string) string {
 	override := os.Getenv("MODULE_HOST_OVERRIDE")
-	if override == "" {
+	if override == "" || isDiscoveryEndpoint(endpoint) {
 		return endpoint
 	}
 	parts := strings.SplitN(endpoint, ":", 2)
@@ -235,18 +660,35 @@ func resolveEndpoint(endpoint string) string {
 // dialModule establishes a gRPC connection to a module endpoint.
 // When useTLS is true and no mTLS certs are available, it falls back to
 // TLS with InsecureSkipVerify (needed for modules like LCM that always use TLS).
-func (c *ModuleClient) dialModule(endpoint string, useTLS bool) (*grpc.ClientConn, func(), error) {
+// A per-target TLS override replaces this logic entirely for that target.
+// Endpoints of the form "discovery:///service-name" are resolved through the
+// configured service registry, balancing across healthy instances.
+func (c *ModuleClient) dialModule(endpoint, moduleID string, tlsOverride *backupV1.TargetTLS) (*grpc.ClientConn, func(), error) {
+	useTLS := moduleID == "lcm"
+
 	endpoint = resolveEndpoint(endpoint)
 	c.log.Infof("dialModule: endpoint=%q", endpoint)
 
+	fromRegistry := isDiscoveryEndpoint(endpoint)
+	if fromRegistry && c.discovery == nil {
+		return nil, func() {}, fmt.Errorf("%s requires a service registry, none is configured", endpoint)
+	}
+
 	// grpc.NewClient requires a URI scheme; passthrough lets the OS handle DNS
 	if !strings.Contains(endpoint, "://") {
 		endpoint = "passthrough:///" + endpoint
 	}
 
 	var dialOpt grpc.DialOption
-	creds, err := loadClientTLSCredentials(c.log)
-	if err != nil {
+	var secure bool
+	if tlsOverride != nil {
+		creds, isTLS, err := c.targetCredentials(moduleID, tlsOverride)
+		if err != nil {
+			return nil, func() {}, fmt.Errorf("TLS override for %s: %w", moduleID, err)
+		}
+		dialOpt, secure = grpc.WithTransportCredentials(creds), isTLS
+	} else if creds, err := loadClientTLSCredentials(c.log); err != nil {
+		secure = useTLS
 		if useTLS {
 			// Some modules (like LCM) always run with TLS even when mTLS certs
 			// aren't available. Use TLS with InsecureSkipVerify as fallback.
@@ -261,6 +703,7 @@ func (c *ModuleClient) dialModule(endpoint string, useTLS bool) (*grpc.ClientCon
 	} else {
 		c.log.Infof("dialModule: using mTLS client credentials")
 		dialOpt = grpc.WithTransportCredentials(creds)
+		secure = true
 	}
 
 	connectParams := grpc.ConnectParams{
@@ -279,13 +722,18 @@ func (c *ModuleClient) dialModule(endpoint string, useTLS bool) (*grpc.ClientCon
 		PermitWithoutStream: false,
 	}
 
-	conn, err := grpc.NewClient(
-		endpoint,
+	dialOpts := []grpc.DialOption{
 		dialOpt,
 		grpc.WithConnectParams(connectParams),
 		grpc.WithKeepaliveParams(keepaliveParams),
-		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(100*1024*1024)), // 100MB for large backups
-	)
+		grpc.WithDefaultCallOptions(append(c.limits.callOptions(), compressionCallOptions(c.compressor)...)...),
+	}
+	if fromRegistry {
+		dialOpts = append(dialOpts, discoveryDialOptions(c.discovery, secure)...)
+	}
+	dialOpts = append(dialOpts, c.throttle.dialOptions("module:"+moduleID)...)
+
+	conn, err := grpc.NewClient(endpoint, dialOpts...)
 	if err != nil {
 		return nil, func() {}, fmt.Errorf("connect to %s: %w", endpoint, err)
 	}
@@ -299,10 +747,23 @@ func (c *ModuleClient) dialModule(endpoint string, useTLS bool) (*grpc.ClientCon
 	return conn, cleanup, nil
 }
 
+// targetTenantKey carries a tenant that module calls act on instead of the
+// caller's; see withTargetTenant.
+type targetTenantKey struct{}
+
+// withTargetTenant makes module calls made with ctx send tenantID as the
+// tenant, whoever the caller is. Test restores use it to confine the import
+// to the validation tenant.
+func withTargetTenant(ctx context.Context, tenantID uint32) context.Context {
+	return context.WithValue(ctx, targetTenantKey{}, tenantID)
+}
+
 // forwardMetadata builds outgoing gRPC metadata by forwarding relevant headers
-// from the incoming context so the target module sees the caller's auth context.
+// from the incoming context so the target module sees the caller's auth context,
+// along with the current trace context and request ID.
 // When no incoming metadata exists (e.g., background scheduler tasks), it injects
-// platform admin credentials so backup operations are authorized.
+// platform admin credentials so backup operations are authorized. REST calls
+// never get them: a caller without an identity is not the scheduler.
 func forwardMetadata(ctx context.Context) context.Context {
 	outMD := grpcMD.New(map[string]string{
 		"x-md-global-tenant-id": fmt.Sprintf("%d", grpcx.GetTenantIDFromContext(ctx)),
@@ -320,11 +781,16 @@ func forwardMetadata(ctx context.Context) context.Context {
 
 	// When running as a background task (no incoming metadata), inject platform
 	// admin identity so modules authorize the backup operation.
-	if !hasMetadata {
+	if tr, ok := transport.FromServerContext(ctx); !hasMetadata && (!ok || tr.Kind() != transport.KindHTTP) {
 		outMD.Set("x-md-global-tenant-id", "0")
 		outMD.Set("x-md-global-roles", "platform:admin")
 		outMD.Set("x-md-global-username", "backup-service")
 	}
+	if tenantID, ok := ctx.Value(targetTenantKey{}).(uint32); ok {
+		outMD.Set("x-md-global-tenant-id", fmt.Sprintf("%d", tenantID))
+	}
+
+	injectTraceContext(ctx, outMD)
 
 	return grpcMD.NewOutgoingContext(ctx, outMD)
 }
@@ -335,6 +801,16 @@ func forwardMetadata(ctx context.Context) context.Context {
 //	Client: {certsDir}/backup/backup.crt
 //	Key:    {certsDir}/backup/backup.key
 func loadClientTLSCredentials(l *log.Helper) (credentials.TransportCredentials, error) {
+	tlsConfig, err := loadClientTLSConfig(l)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// loadClientTLSConfig builds the TLS config behind loadClientTLSCredentials,
+// for callers that adjust it before creating credentials.
+func loadClientTLSConfig(l *log.Helper) (*tls.Config, error) {
 	// Prefer explicit env vars, fall back to convention-based paths
 	caCertPath := os.Getenv("BACKUP_CA_CERT_PATH")
 	clientCertPath := os.Getenv("BACKUP_CLIENT_CERT_PATH")
@@ -396,5 +872,5 @@ func loadClientTLSCredentials(l *log.Helper) (credentials.TransportCredentials,
 		}
 	}
 
-	return credentials.NewTLS(tlsConfig), nil
+	return tlsConfig, nil
 }
@@ -0,0 +1,335 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"text/template"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/go-tangra/go-tangra-common/eventbus"
+)
+
+// NotifyConfig selects the Slack and email recipients for a schedule's
+// notifications. Unset fields fall back to the server defaults.
+type NotifyConfig struct {
+	Slack *SlackConfig `json:"slack,omitempty"`
+	Email *EmailConfig `json:"email,omitempty"`
+}
+
+// SlackConfig configures a Slack incoming webhook.
+type SlackConfig struct {
+	WebhookURL string `json:"webhookUrl,omitempty"`
+	Channel    string `json:"channel,omitempty"`
+	// Template is a text/template rendered with notificationData.
+	Template string `json:"template,omitempty"`
+}
+
+// EmailConfig configures email recipients and message templates.
+type EmailConfig struct {
+	To       []string `json:"to,omitempty"`
+	Subject  string   `json:"subject,omitempty"`
+	Template string   `json:"template,omitempty"`
+}
+
+// notificationData is what Slack and email templates are rendered with.
+type notificationData struct {
+	Type    string
+	Time    time.Time
+	Backup  *BackupEvent
+	Restore *RestoreEvent
+}
+
+const (
+	defaultMessageTemplate = `{{if .Backup}}Backup {{.Backup.BackupID}} ({{.Backup.Kind}}{{if .Backup.ModuleID}} {{.Backup.ModuleID}}{{end}}) failed: status={{.Backup.Status}}, size={{.Backup.SizeBytes}} bytes{{if .Backup.ValidationStatus}}, validation={{.Backup.ValidationStatus}}{{end}}
+{{- range .Backup.Errors}}
+- {{.}}{{end}}
+{{- else}}Restore of backup {{.Restore.BackupID}} {{if .Restore.Success}}completed{{else}}finished with errors{{end}} (modules: {{join .Restore.Modules ", "}})
+{{- range .Restore.Errors}}
+- {{.}}{{end}}
+{{- end}}`
+	defaultSubjectTemplate = `[tangra-backup] {{if .Backup}}Backup {{.Backup.BackupID}} failed{{else if .Restore.Success}}Restore of {{.Restore.BackupID}} completed{{else}}Restore of {{.Restore.BackupID}} failed{{end}}`
+)
+
+var templateFuncs = template.FuncMap{"join": strings.Join}
+
+// smtpSettings is the outgoing mail server, configured only server-wide.
+type smtpSettings struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+// notifier sends Slack and email notifications for failed scheduled backups,
+// completed restores and scheduled reports that ask to be pushed.
+//
+//	BACKUP_SLACK_WEBHOOK_URL   default Slack incoming webhook
+//	BACKUP_SLACK_CHANNEL       optional channel override
+//	BACKUP_SLACK_TEMPLATE      optional message template
+//	BACKUP_SMTP_HOST           mail server; email is disabled when unset
+//	BACKUP_SMTP_PORT           default 587 (STARTTLS); 465 uses implicit TLS
+//	BACKUP_SMTP_USERNAME       optional, with BACKUP_SMTP_PASSWORD
+//	BACKUP_SMTP_FROM           sender address
+//	BACKUP_EMAIL_TO            default recipients, comma-separated
+//	BACKUP_EMAIL_SUBJECT       optional subject template
+//	BACKUP_EMAIL_TEMPLATE      optional body template
+type notifier struct {
+	log      *log.Helper
+	settings atomic.Pointer[notifySettings]
+	client   *http.Client
+}
+
+// notifySettings are the server defaults, swapped as a whole on reload.
+type notifySettings struct {
+	slack SlackConfig
+	email EmailConfig
+	smtp  smtpSettings
+}
+
+func newNotifier(l *log.Helper) *notifier {
+	n := &notifier{
+		log:    l,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+	n.reload()
+	return n
+}
+
+// reload re-reads the server defaults from the environment.
+func (n *notifier) reload() {
+	n.settings.Store(&notifySettings{
+		slack: SlackConfig{
+			WebhookURL: os.Getenv("BACKUP_SLACK_WEBHOOK_URL"),
+			Channel:    os.Getenv("BACKUP_SLACK_CHANNEL"),
+			Template:   os.Getenv("BACKUP_SLACK_TEMPLATE"),
+		},
+		email: EmailConfig{
+			To:       envList("BACKUP_EMAIL_TO"),
+			Subject:  os.Getenv("BACKUP_EMAIL_SUBJECT"),
+			Template: os.Getenv("BACKUP_EMAIL_TEMPLATE"),
+		},
+		smtp: smtpSettings{
+			Host:     os.Getenv("BACKUP_SMTP_HOST"),
+			Port:     envInt("BACKUP_SMTP_PORT", 587),
+			Username: os.Getenv("BACKUP_SMTP_USERNAME"),
+			Password: os.Getenv("BACKUP_SMTP_PASSWORD"),
+			From:     os.Getenv("BACKUP_SMTP_FROM"),
+		},
+	})
+}
+
+// Handle implements eventbus.Handler.
+func (n *notifier) Handle(_ context.Context, event *eventbus.Event) error {
+	data := notificationData{Type: event.Type, Time: event.Timestamp}
+	var override *NotifyConfig
+	switch d := event.Data.(type) {
+	case *BackupEvent:
+		// Interactive callers see failures in the response; only scheduled
+		// runs need someone told.
+		if !d.Scheduled {
+			return nil
+		}
+		data.Backup, override = d, d.notify
+	case *RestoreEvent:
+		data.Restore, override = d, d.notify
+	case *ReportEvent:
+		if d.push {
+			n.pushReport(d)
+		}
+		return nil
+	default:
+		return nil
+	}
+
+	cfg := n.settings.Load()
+	slackCfg, emailCfg := cfg.resolve(override)
+	go func() {
+		if slackCfg.WebhookURL != "" {
+			if err := n.sendSlack(slackCfg, data); err != nil {
+				n.log.Errorf("Slack notification for %s failed: %v", event.Type, err)
+			}
+		}
+		if len(emailCfg.To) > 0 && cfg.smtp.Host != "" {
+			if err := n.sendEmail(cfg.smtp, emailCfg, data); err != nil {
+				n.log.Errorf("Email notification for %s failed: %v", event.Type, err)
+			}
+		}
+	}()
+	return nil
+}
+
+// resolve overlays a schedule's settings on the server defaults.
+func (c *notifySettings) resolve(o *NotifyConfig) (SlackConfig, EmailConfig) {
+	slackCfg, emailCfg := c.slack, c.email
+	if o != nil && o.Slack != nil {
+		if o.Slack.WebhookURL != "" {
+			slackCfg.WebhookURL = o.Slack.WebhookURL
+		}
+		if o.Slack.Channel != "" {
+			slackCfg.Channel = o.Slack.Channel
+		}
+		if o.Slack.Template != "" {
+			slackCfg.Template = o.Slack.Template
+		}
+	}
+	if o != nil && o.Email != nil {
+		if len(o.Email.To) > 0 {
+			emailCfg.To = o.Email.To
+		}
+		if o.Email.Subject != "" {
+			emailCfg.Subject = o.Email.Subject
+		}
+		if o.Email.Template != "" {
+			emailCfg.Template = o.Email.Template
+		}
+	}
+	return slackCfg, emailCfg
+}
+
+func renderTemplate(text, def string, data notificationData) (string, error) {
+	if text == "" {
+		text = def
+	}
+	t, err := template.New("notification").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("parse template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("render template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+func (n *notifier) sendSlack(cfg SlackConfig, data notificationData) error {
+	text, err := renderTemplate(cfg.Template, defaultMessageTemplate, data)
+	if err != nil {
+		return err
+	}
+	return n.postSlack(cfg, text)
+}
+
+func (n *notifier) postSlack(cfg SlackConfig, text string) error {
+	msg := map[string]string{"text": text}
+	if cfg.Channel != "" {
+		msg["channel"] = cfg.Channel
+	}
+	body, _ := json.Marshal(msg)
+
+	resp, err := n.client.Post(cfg.WebhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("slack returned %s", resp.Status)
+	}
+	return nil
+}
+
+func (n *notifier) sendEmail(server smtpSettings, cfg EmailConfig, data notificationData) error {
+	subject, err := renderTemplate(cfg.Subject, defaultSubjectTemplate, data)
+	if err != nil {
+		return err
+	}
+	text, err := renderTemplate(cfg.Template, defaultMessageTemplate, data)
+	if err != nil {
+		return err
+	}
+	return n.sendMail(server, cfg.To, subject, "text/plain; charset=utf-8", text)
+}
+
+func (n *notifier) sendMail(server smtpSettings, to []string, subject, contentType, body string) error {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", server.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", strings.ReplaceAll(subject, "\n", " "))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "MIME-Version: 1.0\r\nContent-Type: %s\r\n\r\n", contentType)
+	msg.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	msg.WriteString("\r\n")
+
+	addr := net.JoinHostPort(server.Host, strconv.Itoa(server.Port))
+	var auth smtp.Auth
+	if server.Username != "" {
+		auth = smtp.PlainAuth("", server.Username, server.Password, server.Host)
+	}
+	if server.Port != 465 {
+		// SendMail upgrades with STARTTLS when the server offers it.
+		return smtp.SendMail(addr, auth, server.From, to, msg.Bytes())
+	}
+
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: 10 * time.Second}, "tcp", addr,
+		&tls.Config{ServerName: server.Host, MinVersion: tls.VersionTLS12})
+	if err != nil {
+		return err
+	}
+	c, err := smtp.NewClient(conn, server.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+	if auth != nil {
+		if err := c.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(server.From); err != nil {
+		return err
+	}
+	for _, rcpt := range to {
+		if err := c.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg.Bytes()); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// pushReport sends a generated report: a summary to Slack and the HTML
+// document by email.
+func (n *notifier) pushReport(e *ReportEvent) {
+	cfg := n.settings.Load()
+	slackCfg, emailCfg := cfg.resolve(e.notify)
+	go func() {
+		if slackCfg.WebhookURL != "" {
+			if err := n.postSlack(slackCfg, reportSummary(e.Report)); err != nil {
+				n.log.Errorf("Slack report delivery failed: %v", err)
+			}
+		}
+		if len(emailCfg.To) > 0 && cfg.smtp.Host != "" {
+			html, _, err := renderReport(e.Report, "html")
+			if err == nil {
+				subject := fmt.Sprintf("[tangra-backup] Backup %s report: %d backups, %d failed",
+					e.Report.Period, e.Report.TotalBackups, e.Report.FailedBackups)
+				err = n.sendMail(cfg.smtp, emailCfg.To, subject, "text/html; charset=utf-8", html)
+			}
+			if err != nil {
+				n.log.Errorf("Email report delivery failed: %v", err)
+			}
+		}
+	}()
+}
@@ -0,0 +1,511 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+)
+
+// OpenBackupStorage opens an existing storage directory for the CLI, without
+// a bootstrap context and without creating anything. Warnings about
+// unreadable backups go to stderr.
+func OpenBackupStorage(basePath string) (*BackupStorage, error) {
+	if _, err := os.Stat(basePath); err != nil {
+		return nil, fmt.Errorf("open storage: %w", err)
+	}
+	l := cliLogger()
+	return &BackupStorage{basePath: basePath, log: l, meta: newMetadataCache(), lock: newOperationLock(basePath, l), resuming: map[string]bool{}}, nil
+}
+
+// NewOfflineModuleClient creates a ModuleClient for the CLI. It honours the
+// same environment settings as the service but has no service registry, so
+// targets must be given as host:port.
+func NewOfflineModuleClient() *ModuleClient {
+	l := cliLogger()
+	c := &ModuleClient{
+		log:        l,
+		limits:     moduleMessageSizeLimits(),
+		compressor: moduleCompressor(l),
+		throttle:   newThrottle(l),
+		spoolDir:   filepath.Join(StoragePath(), "spool"),
+
+		healthCheck: os.Getenv("BACKUP_MODULE_HEALTH_CHECK") == "true",
+	}
+	c.reloadRetryPolicy()
+	return c
+}
+
+// cliLogger logs warnings and errors to stderr, keeping stdout for output.
+func cliLogger() *log.Helper {
+	return log.NewHelper(log.NewFilter(log.NewStdLogger(os.Stderr), log.FilterLevel(log.LevelWarn)))
+}
+
+// ReadBackupMetadata reads the metadata.json of a backup directory without a
+// running BackupStorage, for the CLI. Exactly one of the results is non-nil:
+// full backups are told apart by their module list.
+func ReadBackupMetadata(dir string) (*backupV1.BackupInfo, *backupV1.FullBackupInfo, error) {
+	metaBytes, err := os.ReadFile(filepath.Join(dir, "metadata.json"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("read metadata: %w", err)
+	}
+
+	if bytes.Contains(metaBytes, []byte(`"moduleBackups"`)) || bytes.Contains(metaBytes, []byte(`"module_backups"`)) {
+		var full backupV1.FullBackupInfo
+		if err := unmarshalWithFallback(metaBytes, &full); err != nil {
+			return nil, nil, fmt.Errorf("unmarshal manifest: %w", err)
+		}
+		return nil, &full, nil
+	}
+
+	var info backupV1.BackupInfo
+	if err := unmarshalWithFallback(metaBytes, &info); err != nil {
+		return nil, nil, fmt.Errorf("unmarshal metadata: %w", err)
+	}
+	return &info, nil, nil
+}
+
+// SelfContained reports why the offline tools cannot work on a module
+// backup on their own: one stored as a delta needs the backups before it,
+// which only the orchestrator resolves.
+func SelfContained(module *backupV1.BackupInfo) error {
+	if module.GetDeltaBaseId() != "" {
+		return fmt.Errorf("backup %s is stored as a delta against %s: restore or download it through the orchestrator", module.Id, module.DeltaBaseId)
+	}
+	return nil
+}
+
+// deltaDependent returns the ID of a sibling module backup of dir that is
+// stored as a delta against backupID, or "".
+func deltaDependent(dir, backupID string) string {
+	entries, _ := os.ReadDir(filepath.Dir(dir))
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		if m, _, err := ReadBackupMetadata(filepath.Join(filepath.Dir(dir), e.Name())); err == nil && m != nil && m.DeltaBaseId == backupID {
+			return m.Id
+		}
+	}
+	return ""
+}
+
+// BackupDataFile is one data file of a backup and the metadata entry
+// describing it. Path is empty when the file is missing.
+type BackupDataFile struct {
+	Path string
+	Info *backupV1.BackupInfo
+}
+
+// BackupDataFiles lists the data files a backup directory should contain:
+// data.json.{gz,zst}[.enc] for a module backup, {module}.json.{gz,zst}[.enc]
+// for every successfully exported module of a full backup.
+func BackupDataFiles(dir string, module *backupV1.BackupInfo, full *backupV1.FullBackupInfo) []BackupDataFile {
+	find := func(base string) string {
+		for _, name := range DataFileNames(base) {
+			p := filepath.Join(dir, name)
+			if _, err := os.Stat(p); err == nil {
+				return p
+			}
+		}
+		return ""
+	}
+
+	if module != nil {
+		return []BackupDataFile{{Path: find("data"), Info: module}}
+	}
+	var files []BackupDataFile
+	for _, mb := range full.GetModuleBackups() {
+		if mb.Status != "completed" {
+			continue
+		}
+		files = append(files, BackupDataFile{Path: find(mb.ModuleId), Info: mb})
+	}
+	return files
+}
+
+// DecodeBackupData turns a stored data file back into the module's JSON
+// export: skip the format header, decrypt when encrypted, then decompress
+// gzip or zstd.
+func DecodeBackupData(raw []byte, encrypted bool, password string) ([]byte, error) {
+	_, raw, err := ParseFormatHeader(raw)
+	if err != nil {
+		return nil, err
+	}
+	if encrypted {
+		if password == "" {
+			return nil, fmt.Errorf("backup is encrypted: password required")
+		}
+		if raw, err = DecryptData(raw, password); err != nil {
+			return nil, fmt.Errorf("decrypt: %w", err)
+		}
+	}
+	data, err := decompressData(raw)
+	if err != nil {
+		return nil, fmt.Errorf("decompress: %w", err)
+	}
+	return data, nil
+}
+
+// RekeyBackup re-encrypts every data file of the backup in dir with
+// newPassword and returns how many files it rewrote. Unencrypted files are
+// left alone unless encryptPlain is set, in which case they are encrypted and
+// the metadata is updated to match.
+func RekeyBackup(dir, oldPassword, newPassword string, encryptPlain bool) (int, error) {
+	module, full, err := ReadBackupMetadata(dir)
+	if err != nil {
+		return 0, err
+	}
+	if err := SelfContained(module); err != nil {
+		return 0, err
+	}
+	if encryptPlain && module != nil && !module.Encrypted {
+		// The orchestrator rebuilds deltas without a password.
+		if id := deltaDependent(dir, module.Id); id != "" {
+			return 0, fmt.Errorf("backup %s is the delta base of %s and must stay unencrypted", module.Id, id)
+		}
+	}
+
+	var swaps fileSwaps
+	for _, f := range BackupDataFiles(dir, module, full) {
+		if f.Path == "" {
+			swaps.abort()
+			return 0, fmt.Errorf("%s data file missing", f.Info.GetModuleId())
+		}
+		encrypted := strings.HasSuffix(f.Path, ".enc")
+		if !encrypted && !encryptPlain {
+			continue
+		}
+
+		raw, err := os.ReadFile(f.Path)
+		if err != nil {
+			swaps.abort()
+			return 0, fmt.Errorf("read %s: %w", f.Path, err)
+		}
+		h, raw, err := ParseFormatHeader(raw)
+		if err != nil {
+			swaps.abort()
+			return 0, fmt.Errorf("%s: %w", f.Path, err)
+		}
+		target, replaced := f.Path, ""
+		if encrypted {
+			if oldPassword == "" {
+				swaps.abort()
+				return 0, fmt.Errorf("backup is encrypted: old password required")
+			}
+			if raw, err = DecryptData(raw, oldPassword); err != nil {
+				swaps.abort()
+				return 0, fmt.Errorf("decrypt %s: %w", f.Path, err)
+			}
+		} else {
+			target, replaced = f.Path+".enc", f.Path
+		}
+
+		out, err := EncryptData(raw, newPassword)
+		if err != nil {
+			swaps.abort()
+			return 0, fmt.Errorf("encrypt %s: %w", f.Path, err)
+		}
+		algo := h.Compression
+		if algo == "" {
+			algo = f.Info.GetCompression()
+		}
+		out = FrameStoredData(algo, true, out)
+		f.Info.StoredSha256 = checksum(out)
+		if err := swaps.stage(target, replaced, out); err != nil {
+			swaps.abort()
+			return 0, err
+		}
+	}
+
+	// Every rewritten file has a new stored checksum.
+	var msg proto.Message
+	if len(swaps) > 0 {
+		msg = module
+		if full != nil {
+			msg = full
+		}
+	}
+	if swaps.renames() {
+		if full != nil {
+			full.Encrypted = true
+			for _, mb := range full.ModuleBackups {
+				mb.Encrypted = mb.Status == "completed" || mb.Encrypted
+			}
+		} else {
+			module.Encrypted = true
+		}
+	}
+	if err := swaps.commit(dir, msg); err != nil {
+		return 0, err
+	}
+	return len(swaps), nil
+}
+
+// RecompressBackup recompresses every data file of the backup in dir with
+// algo at the given level, re-encrypting encrypted files with the same
+// password, and records the new algorithm and compressed sizes in the
+// metadata. Files whose extension changes are renamed. It returns the total
+// stored size of the data files before and after.
+func RecompressBackup(dir, password, algo string, level int) (before, after int64, err error) {
+	module, full, err := ReadBackupMetadata(dir)
+	if err != nil {
+		return 0, 0, err
+	}
+	if err := SelfContained(module); err != nil {
+		return 0, 0, err
+	}
+
+	var swaps fileSwaps
+	for _, f := range BackupDataFiles(dir, module, full) {
+		if f.Path == "" {
+			swaps.abort()
+			return 0, 0, fmt.Errorf("%s data file missing", f.Info.GetModuleId())
+		}
+		raw, err := os.ReadFile(f.Path)
+		if err != nil {
+			swaps.abort()
+			return 0, 0, fmt.Errorf("read %s: %w", f.Path, err)
+		}
+		encrypted := strings.HasSuffix(f.Path, ".enc")
+		data, err := DecodeBackupData(raw, encrypted, password)
+		if err != nil {
+			swaps.abort()
+			return 0, 0, fmt.Errorf("%s: %w", f.Path, err)
+		}
+
+		out, err := CompressDataLevel(algo, data, level)
+		if err != nil {
+			swaps.abort()
+			return 0, 0, fmt.Errorf("compress %s: %w", f.Path, err)
+		}
+		f.Info.Compression = algo
+		f.Info.CompressionLevel = int32(level)
+		f.Info.CompressedSizeBytes = int64(len(out))
+		f.Info.CompressionRatio = compressionRatio(int64(len(data)), f.Info.CompressedSizeBytes)
+		if encrypted {
+			if out, err = EncryptData(out, password); err != nil {
+				swaps.abort()
+				return 0, 0, fmt.Errorf("encrypt %s: %w", f.Path, err)
+			}
+		}
+		out = FrameStoredData(algo, encrypted, out)
+		f.Info.StoredSha256 = checksum(out)
+		target, replaced := recompressedPath(f.Path, algo), ""
+		if target != f.Path {
+			replaced = f.Path
+		}
+		if err := swaps.stage(target, replaced, out); err != nil {
+			swaps.abort()
+			return 0, 0, err
+		}
+		before += int64(len(raw))
+		after += int64(len(out))
+	}
+
+	var meta proto.Message = module
+	if full != nil {
+		full.TotalCompressedSizeBytes = 0
+		for _, mb := range full.ModuleBackups {
+			full.TotalCompressedSizeBytes += mb.CompressedSizeBytes
+		}
+		full.CompressionRatio = compressionRatio(full.TotalSizeBytes, full.TotalCompressedSizeBytes)
+		meta = full
+	}
+	if err := swaps.commit(dir, meta); err != nil {
+		return 0, 0, err
+	}
+	return before, after, nil
+}
+
+// MigrateBackupFormat adds the current format header to the data files of
+// the backup in dir that were written without one, and records their new
+// stored checksums in the metadata. The payloads are kept as they are, so
+// encrypted backups need no password. A file that no longer matches its
+// stored checksum is refused, since migrating it would record the damage as
+// good. It returns how many files it rewrote, or with dryRun how many it
+// would rewrite.
+func MigrateBackupFormat(dir string, dryRun bool) (int, error) {
+	module, full, err := ReadBackupMetadata(dir)
+	if err != nil {
+		return 0, err
+	}
+	if module != nil && module.Status != "completed" {
+		return 0, nil
+	}
+	files := BackupDataFiles(dir, module, full)
+	if module.GetDeltaBaseId() != "" {
+		files[0].Path = ""
+		for _, algo := range []string{CompressionGzip, CompressionZstd} {
+			p := filepath.Join(dir, "data.delta"+CompressionExt(algo))
+			if _, err := os.Stat(p); err == nil {
+				files[0].Path = p
+			}
+		}
+	}
+
+	var swaps fileSwaps
+	pending := 0
+	for _, f := range files {
+		if f.Path == "" {
+			swaps.abort()
+			return 0, fmt.Errorf("%s data file missing", f.Info.GetModuleId())
+		}
+		raw, err := os.ReadFile(f.Path)
+		if err != nil {
+			swaps.abort()
+			return 0, fmt.Errorf("read %s: %w", f.Path, err)
+		}
+		h, _, err := ParseFormatHeader(raw)
+		if err != nil {
+			swaps.abort()
+			return 0, fmt.Errorf("%s: %w", f.Path, err)
+		}
+		if h.Version == FormatVersion {
+			continue
+		}
+		if f.Info.StoredSha256 != "" {
+			if err := verifyChecksum(checksum(raw), f.Info.StoredSha256); err != nil {
+				swaps.abort()
+				return 0, fmt.Errorf("%s: %w", f.Path, err)
+			}
+		}
+		pending++
+		if dryRun {
+			continue
+		}
+
+		encrypted := strings.HasSuffix(f.Path, ".enc")
+		algo := DetectCompression(raw)
+		if encrypted {
+			algo = f.Info.GetCompression()
+		}
+		out := FrameStoredData(algo, encrypted, raw)
+		f.Info.StoredSha256 = checksum(out)
+		if err := swaps.stage(f.Path, "", out); err != nil {
+			swaps.abort()
+			return 0, err
+		}
+	}
+	if dryRun || len(swaps) == 0 {
+		return pending, nil
+	}
+
+	var meta proto.Message = module
+	if full != nil {
+		meta = full
+	}
+	if err := swaps.commit(dir, meta); err != nil {
+		return 0, err
+	}
+	return len(swaps), nil
+}
+
+// recompressedPath swaps the compression extension of a data file path for
+// algo's, keeping any ".enc" suffix.
+func recompressedPath(path, algo string) string {
+	base, enc := strings.CutSuffix(path, ".enc")
+	base = strings.TrimSuffix(strings.TrimSuffix(base, ".gz"), ".zst")
+	path = base + CompressionExt(algo)
+	if enc {
+		path += ".enc"
+	}
+	return path
+}
+
+// fileSwaps stages replacement data files next to the originals and swaps
+// them in together. Everything is written before anything is replaced, so a
+// wrong password or a full disk leaves the backup untouched.
+type fileSwaps []fileSwap
+
+type fileSwap struct {
+	tmp, target string
+	// replaced is the old file when the new one has a different name.
+	replaced string
+}
+
+func (s *fileSwaps) stage(target, replaced string, data []byte) error {
+	tmp := target + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("write %s: %w", tmp, err)
+	}
+	*s = append(*s, fileSwap{tmp: tmp, target: target, replaced: replaced})
+	return nil
+}
+
+func (s fileSwaps) abort() {
+	for _, sw := range s {
+		os.Remove(sw.tmp)
+	}
+}
+
+// renames reports whether any staged file changes the name of its original.
+func (s fileSwaps) renames() bool {
+	for _, sw := range s {
+		if sw.replaced != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// commit swaps the staged files in, then writes meta (if non-nil) and only
+// then removes replaced originals, so the metadata never names a missing file.
+func (s fileSwaps) commit(dir string, meta proto.Message) error {
+	for _, sw := range s {
+		if err := os.Rename(sw.tmp, sw.target); err != nil {
+			s.abort()
+			return fmt.Errorf("replace %s: %w", sw.target, err)
+		}
+	}
+	if meta != nil {
+		if err := writeMetadata(dir, meta); err != nil {
+			return err
+		}
+	}
+	for _, sw := range s {
+		if sw.replaced != "" {
+			os.Remove(sw.replaced)
+		}
+	}
+	return nil
+}
+
+// writeMetadata replaces dir/metadata.json via a rename, so readers never
+// see a partial file. A full backup's summary.json is rewritten after it.
+func writeMetadata(dir string, msg proto.Message) error {
+	if err := writeProtoFile(filepath.Join(dir, "metadata.json"), msg); err != nil {
+		return fmt.Errorf("write metadata: %w", err)
+	}
+	if full, ok := msg.(*backupV1.FullBackupInfo); ok {
+		if err := writeProtoFile(filepath.Join(dir, "summary.json"), fullBackupSummary(full)); err != nil {
+			return fmt.Errorf("write summary: %w", err)
+		}
+	}
+	return nil
+}
+
+func writeProtoFile(path string, msg proto.Message) error {
+	data, err := protojson.MarshalOptions{Indent: "  ", EmitUnpopulated: true}.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
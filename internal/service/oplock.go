@@ -0,0 +1,474 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The operation lock keeps orchestrator replicas that share one storage
+// directory from running mutating operations at the same time: restores,
+// deletes, pins and label changes, repairs, retention and recompaction.
+// Creating backups is not locked, since each writes a directory of its own,
+// except for module backups that may be stored as a delta: their base must
+// not be deleted while the delta is written.
+//
+// The lock is a lease in locks/operations/<token>.json. A replica takes it by
+// linking a file into place under the next token, which only one replica can
+// do per token, and keeps it by renewing the expiry every third of
+// BACKUP_LOCK_TTL_SECONDS (default 30). The highest token is the current
+// lease and doubles as a fencing token: a replica that finds a higher token
+// than its own has lost the lock, and the operations running under it are
+// canceled with ErrOperationLockLost. Within a replica, operations share the
+// lease and run concurrently as before. BACKUP_OPERATION_LOCK=false turns the
+// lock off.
+//
+// Each replica also keeps a presence lease in locks/replicas/<instance>.json
+// for as long as it runs, renewed on the same schedule. Backups being written
+// are tagged with the instance writing them, so a replica that starts up
+// leaves alone the partial writes of replicas that still hold theirs.
+
+// ErrOperationLockLost is the cause an operation's context is canceled with
+// when another replica took over the lease it ran under.
+var ErrOperationLockLost = errors.New("operation lock lost to another replica")
+
+// lockPollInterval is how often a replica waiting for the lock looks again.
+const lockPollInterval = time.Second
+
+// processInstance identifies this process among the replicas and the
+// processes that ran on the same host before it.
+var processInstance = func() string {
+	id := make([]byte, 8)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}()
+
+type lease struct {
+	Holder     string    `json:"holder"`   // hostname of the replica
+	Instance   string    `json:"instance"` // process, so a restarted replica recognizes its old lease
+	Token      uint64    `json:"token"`
+	Operation  string    `json:"operation"`
+	AcquiredAt time.Time `json:"acquiredAt"`
+	ExpiresAt  time.Time `json:"expiresAt"` // set to the release time on release
+}
+
+// OperationLock is the replica's side of the lock. A nil lock is disabled.
+type OperationLock struct {
+	dir      string
+	holder   string
+	instance string
+	ttl      time.Duration
+	log      *log.Helper
+
+	sem chan struct{} // one goroutine at a time takes the lease
+
+	mu     sync.Mutex
+	held   *lease // nil unless this replica holds the lease
+	stop   chan struct{}
+	holds  map[uint64]context.CancelCauseFunc
+	nextID uint64
+}
+
+func newOperationLock(basePath string, l *log.Helper) *OperationLock {
+	if os.Getenv("BACKUP_OPERATION_LOCK") == "false" {
+		return nil
+	}
+	holder, err := os.Hostname()
+	if err != nil {
+		holder = "unknown"
+	}
+	ttl := envInt("BACKUP_LOCK_TTL_SECONDS", 30)
+	if ttl < 3 {
+		ttl = 30
+	}
+	return &OperationLock{
+		dir:      filepath.Join(basePath, "locks", "operations"),
+		holder:   holder,
+		instance: processInstance,
+		ttl:      time.Duration(ttl) * time.Second,
+		log:      l,
+		sem:      make(chan struct{}, 1),
+		holds:    map[uint64]context.CancelCauseFunc{},
+	}
+}
+
+// Acquire holds the lock for op until the returned release func is called,
+// waiting for other replicas as long as ctx allows. The operation should
+// run under the returned context, which is canceled if the lease is lost.
+func (l *OperationLock) Acquire(ctx context.Context, op string) (context.Context, func(), error) {
+	if l == nil {
+		return ctx, func() {}, nil
+	}
+	select {
+	case l.sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, nil, status.Errorf(codes.Aborted, "waiting for the operation lock: %v", ctx.Err())
+	}
+	defer func() { <-l.sem }()
+
+	l.mu.Lock()
+	held := l.held != nil
+	l.mu.Unlock()
+	if !held {
+		if err := l.take(ctx, op); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.held == nil {
+		return nil, nil, status.Error(codes.Aborted, ErrOperationLockLost.Error())
+	}
+	ctx, cancel := context.WithCancelCause(ctx)
+	id := l.nextID
+	l.nextID++
+	l.holds[id] = cancel
+	var once sync.Once
+	return ctx, func() { once.Do(func() { l.release(id) }) }, nil
+}
+
+// take waits until the lease is free or expired and claims the next token.
+func (l *OperationLock) take(ctx context.Context, op string) error {
+	waiting := false
+	for {
+		cur, err := l.current()
+		if err != nil {
+			return fmt.Errorf("operation lock: %w", err)
+		}
+		now := time.Now()
+		restarted := cur != nil && cur.Holder == l.holder && cur.Instance != l.instance
+		if cur != nil && now.Before(cur.ExpiresAt) && !restarted {
+			if !waiting {
+				l.log.Infof("Waiting to run %s: replica %s is running %s (lease %d until %s)",
+					op, cur.Holder, cur.Operation, cur.Token, cur.ExpiresAt.Format(time.RFC3339))
+				waiting = true
+			}
+			select {
+			case <-ctx.Done():
+				return status.Errorf(codes.Aborted, "replica %s is running %s: %v", cur.Holder, cur.Operation, ctx.Err())
+			case <-time.After(lockPollInterval):
+			}
+			continue
+		}
+		if restarted && now.Before(cur.ExpiresAt) {
+			l.log.Warnf("Taking over lease %d left by an earlier process on this host", cur.Token)
+		}
+
+		next := &lease{Holder: l.holder, Instance: l.instance, Token: 1, Operation: op, AcquiredAt: now, ExpiresAt: now.Add(l.ttl)}
+		if cur != nil {
+			next.Token = cur.Token + 1
+		}
+		ok, err := l.claim(next)
+		if err != nil {
+			return fmt.Errorf("operation lock: %w", err)
+		}
+		if !ok {
+			continue // another replica claimed the token first
+		}
+		// A replica that read the lease long ago may claim a token below the
+		// current one; only the highest counts.
+		if cur, err := l.current(); err != nil || cur == nil || cur.Token != next.Token {
+			os.Remove(l.path(next.Token))
+			continue
+		}
+
+		l.mu.Lock()
+		l.held = next
+		l.stop = make(chan struct{})
+		go l.heartbeat(l.stop, next.Token)
+		l.mu.Unlock()
+		l.prune(next.Token)
+		if waiting {
+			l.log.Infof("Took operation lock %d for %s", next.Token, op)
+		}
+		return nil
+	}
+}
+
+// release drops one hold and gives the lease up once none are left.
+func (l *OperationLock) release(id uint64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	cancel, ok := l.holds[id]
+	if !ok {
+		return // canceled with the lost lease
+	}
+	delete(l.holds, id)
+	cancel(nil)
+	if len(l.holds) > 0 || l.held == nil {
+		return
+	}
+	close(l.stop)
+	released := *l.held
+	released.ExpiresAt = time.Now()
+	if err := l.renew(&released); err != nil && !errors.Is(err, ErrOperationLockLost) {
+		l.log.Warnf("Failed to release operation lock %d: %v", released.Token, err)
+	}
+	l.held = nil
+}
+
+// heartbeat renews the lease until it is released, and cancels the
+// operations holding it when another replica has taken it over.
+func (l *OperationLock) heartbeat(stop chan struct{}, token uint64) {
+	t := time.NewTicker(l.ttl / 3)
+	defer t.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-t.C:
+		}
+
+		l.mu.Lock()
+		if l.held == nil || l.held.Token != token {
+			l.mu.Unlock()
+			return
+		}
+		renewed := *l.held
+		renewed.ExpiresAt = time.Now().Add(l.ttl)
+		switch err := l.renew(&renewed); {
+		case errors.Is(err, ErrOperationLockLost):
+			l.log.Errorf("Lost operation lock %d; canceling %d operations", token, len(l.holds))
+			for id, cancel := range l.holds {
+				cancel(ErrOperationLockLost)
+				delete(l.holds, id)
+			}
+			l.held = nil
+			l.mu.Unlock()
+			return
+		case err != nil:
+			l.log.Warnf("Failed to renew operation lock %d: %v", token, err)
+		default:
+			l.held = &renewed
+		}
+		l.mu.Unlock()
+	}
+}
+
+func (l *OperationLock) path(token uint64) string {
+	return filepath.Join(l.dir, strconv.FormatUint(token, 10)+".json")
+}
+
+// current returns the lease with the highest token, or nil if there is none.
+func (l *OperationLock) current() (*lease, error) {
+	entries, err := os.ReadDir(l.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read lock dir: %w", err)
+	}
+	var top uint64
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), ".json")
+		if !ok {
+			continue
+		}
+		if n, err := strconv.ParseUint(name, 10, 64); err == nil && n > top {
+			top = n
+		}
+	}
+	if top == 0 {
+		return nil, nil
+	}
+	data, err := os.ReadFile(l.path(top))
+	if err != nil {
+		return nil, fmt.Errorf("read lease: %w", err)
+	}
+	var cur lease
+	if err := json.Unmarshal(data, &cur); err != nil {
+		return nil, fmt.Errorf("read lease %d: %w", top, err)
+	}
+	return &cur, nil
+}
+
+// claim writes the lease under its token unless some replica already has.
+func (l *OperationLock) claim(next *lease) (bool, error) {
+	if err := os.MkdirAll(l.dir, 0o755); err != nil {
+		return false, fmt.Errorf("create lock dir: %w", err)
+	}
+	tmp, err := l.writeTemp(next)
+	if err != nil {
+		return false, err
+	}
+	defer os.Remove(tmp)
+	// Unlike rename, link never replaces an existing file.
+	if err := os.Link(tmp, l.path(next.Token)); err != nil {
+		if os.IsExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("claim lease: %w", err)
+	}
+	return true, nil
+}
+
+// renew rewrites this replica's lease, unless another replica has claimed
+// a higher token since.
+func (l *OperationLock) renew(next *lease) error {
+	cur, err := l.current()
+	if err != nil {
+		return err
+	}
+	if cur == nil || cur.Token != next.Token || cur.Instance != l.instance {
+		return ErrOperationLockLost
+	}
+	tmp, err := l.writeTemp(next)
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, l.path(next.Token)); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("write lease: %w", err)
+	}
+	return nil
+}
+
+func (l *OperationLock) writeTemp(ls *lease) (string, error) {
+	data, err := json.Marshal(ls)
+	if err != nil {
+		return "", fmt.Errorf("marshal lease: %w", err)
+	}
+	tmp := filepath.Join(l.dir, fmt.Sprintf("%d.%s.tmp", ls.Token, l.instance))
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		os.Remove(tmp)
+		return "", fmt.Errorf("write lease: %w", err)
+	}
+	return tmp, nil
+}
+
+// prune removes leases older than the previous one. The previous one is
+// kept so a replica that read it late cannot claim the current token again.
+func (l *OperationLock) prune(token uint64) {
+	entries, err := os.ReadDir(l.dir)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), ".json")
+		if !ok {
+			continue
+		}
+		if n, err := strconv.ParseUint(name, 10, 64); err == nil && n+1 < token {
+			os.Remove(filepath.Join(l.dir, e.Name()))
+		}
+	}
+}
+
+type presence struct {
+	Holder    string    `json:"holder"`
+	Instance  string    `json:"instance"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+func (l *OperationLock) presencePath(instance string) string {
+	return filepath.Join(filepath.Dir(l.dir), "replicas", instance+".json")
+}
+
+// keepPresence takes this replica's presence lease and renews it for as
+// long as the process runs.
+func (l *OperationLock) keepPresence() {
+	if l == nil {
+		return
+	}
+	l.announce()
+	go func() {
+		for range time.Tick(l.ttl / 3) {
+			l.announce()
+		}
+	}()
+}
+
+func (l *OperationLock) announce() {
+	data, err := json.Marshal(presence{Holder: l.holder, Instance: l.instance, ExpiresAt: time.Now().Add(l.ttl)})
+	if err == nil {
+		path := l.presencePath(l.instance)
+		err = os.MkdirAll(filepath.Dir(path), 0o755)
+		if err == nil {
+			err = os.WriteFile(path+".tmp", data, 0o644)
+		}
+		if err == nil {
+			err = os.Rename(path+".tmp", path)
+		}
+	}
+	if err != nil {
+		l.log.Warnf("Failed to renew presence lease: %v", err)
+	}
+}
+
+// ownerAlive reports whether the process instance that tagged a write is
+// still running: it holds its presence lease, or the operation lease token
+// it wrote under. A lease left by an earlier process on this host does not
+// count.
+func (l *OperationLock) ownerAlive(instance string, token uint64) bool {
+	if l == nil || instance == "" {
+		return false
+	}
+	if instance == l.instance {
+		return true
+	}
+	now := time.Now()
+	if data, err := os.ReadFile(l.presencePath(instance)); err == nil {
+		var p presence
+		if json.Unmarshal(data, &p) == nil && p.Holder != l.holder && now.Before(p.ExpiresAt) {
+			return true
+		}
+	}
+	if token == 0 {
+		return false
+	}
+	cur, err := l.current()
+	return err == nil && cur != nil && cur.Token == token && cur.Instance == instance &&
+		cur.Holder != l.holder && now.Before(cur.ExpiresAt)
+}
+
+// prunePresence removes the presence leases of replicas that are gone.
+func (l *OperationLock) prunePresence() {
+	if l == nil {
+		return
+	}
+	dir := filepath.Dir(l.presencePath(l.instance))
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		instance, ok := strings.CutSuffix(e.Name(), ".json")
+		if ok && !l.ownerAlive(instance, 0) {
+			os.Remove(filepath.Join(dir, e.Name()))
+		}
+	}
+}
+
+// writer identifies this process and the operation lease it holds, if
+// any, for tagging the writes it makes.
+func (l *OperationLock) writer() (holder, instance string, token uint64) {
+	if l == nil {
+		return "", "", 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.held != nil {
+		token = l.held.Token
+	}
+	return l.holder, l.instance, token
+}
+
+// LockOperations holds the operation lock for op; see OperationLock.Acquire.
+func (s *BackupStorage) LockOperations(ctx context.Context, op string) (context.Context, func(), error) {
+	return s.lock.Acquire(ctx, op)
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newTestLock returns the lock of a replica named holder on the storage in
+// dir, with a short TTL.
+func newTestLock(t *testing.T, dir, holder string) *OperationLock {
+	t.Helper()
+	l := newOperationLock(dir, log.NewHelper(log.DefaultLogger))
+	l.holder = holder
+	l.instance = holder + "-instance"
+	l.ttl = 300 * time.Millisecond
+	return l
+}
+
+func TestOperationLockAcquire(t *testing.T) {
+	tests := []struct {
+		name string
+		// existing is the lease found on disk, if any.
+		existing  *lease
+		wantToken uint64
+		wantErr   codes.Code
+	}{
+		{
+			name:      "free",
+			wantToken: 1,
+		},
+		{
+			name:     "held by another replica",
+			existing: &lease{Holder: "b", Instance: "b-instance", Token: 4, ExpiresAt: time.Now().Add(time.Hour)},
+			wantErr:  codes.Aborted,
+		},
+		{
+			name:      "expired",
+			existing:  &lease{Holder: "b", Instance: "b-instance", Token: 4, ExpiresAt: time.Now().Add(-time.Second)},
+			wantToken: 5,
+		},
+		{
+			name:      "released",
+			existing:  &lease{Holder: "b", Instance: "b-instance", Token: 4, ExpiresAt: time.Now()},
+			wantToken: 5,
+		},
+		{
+			name:      "left by an earlier process on this host",
+			existing:  &lease{Holder: "a", Instance: "a-old", Token: 4, ExpiresAt: time.Now().Add(time.Hour)},
+			wantToken: 5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			a := newTestLock(t, dir, "a")
+			if tt.existing != nil {
+				if ok, err := a.claim(tt.existing); err != nil || !ok {
+					t.Fatalf("claim existing lease: %v, %v", ok, err)
+				}
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+			_, release, err := a.Acquire(ctx, "test")
+			if tt.wantErr != codes.OK {
+				if status.Code(err) != tt.wantErr {
+					t.Fatalf("Acquire error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Acquire: %v", err)
+			}
+			defer release()
+			cur, err := a.current()
+			if err != nil || cur == nil {
+				t.Fatalf("current lease: %v, %v", cur, err)
+			}
+			if cur.Token != tt.wantToken || cur.Instance != a.instance {
+				t.Errorf("lease = token %d of %s, want token %d of %s", cur.Token, cur.Instance, tt.wantToken, a.instance)
+			}
+		})
+	}
+}
+
+func TestOperationLockHandover(t *testing.T) {
+	dir := t.TempDir()
+	a, b := newTestLock(t, dir, "a"), newTestLock(t, dir, "b")
+
+	_, releaseA, err := a.Acquire(context.Background(), "first")
+	if err != nil {
+		t.Fatalf("a: Acquire: %v", err)
+	}
+	// Holds within a replica share the lease.
+	_, releaseA2, err := a.Acquire(context.Background(), "second")
+	if err != nil {
+		t.Fatalf("a: second Acquire: %v", err)
+	}
+
+	// The lease is renewed, so b keeps waiting past its TTL.
+	ctx, cancel := context.WithTimeout(context.Background(), 2*a.ttl)
+	_, _, err = b.Acquire(ctx, "third")
+	cancel()
+	if status.Code(err) != codes.Aborted {
+		t.Fatalf("b: Acquire while a holds the lease: %v, want Aborted", err)
+	}
+
+	releaseA()
+	releaseA2()
+	_, releaseB, err := b.Acquire(context.Background(), "third")
+	if err != nil {
+		t.Fatalf("b: Acquire after release: %v", err)
+	}
+	defer releaseB()
+	if cur, _ := b.current(); cur == nil || cur.Token != 2 || cur.Holder != "b" {
+		t.Errorf("lease after handover = %+v, want token 2 of b", cur)
+	}
+}
+
+func TestOperationLockFencing(t *testing.T) {
+	dir := t.TempDir()
+	a, b := newTestLock(t, dir, "a"), newTestLock(t, dir, "b")
+
+	ctx, release, err := a.Acquire(context.Background(), "test")
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	defer release()
+
+	// b takes over with a higher token, as after a pause that outlasted
+	// a's lease.
+	now := time.Now()
+	if ok, err := b.claim(&lease{Holder: "b", Instance: b.instance, Token: 2, AcquiredAt: now, ExpiresAt: now.Add(time.Hour)}); err != nil || !ok {
+		t.Fatalf("claim: %v, %v", ok, err)
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * a.ttl):
+		t.Fatal("operation not canceled after the lease was lost")
+	}
+	if cause := context.Cause(ctx); !errors.Is(cause, ErrOperationLockLost) {
+		t.Errorf("cancel cause = %v, want ErrOperationLockLost", cause)
+	}
+	if err := a.renew(&lease{Holder: "a", Instance: a.instance, Token: 1}); !errors.Is(err, ErrOperationLockLost) {
+		t.Errorf("renew with a stale token = %v, want ErrOperationLockLost", err)
+	}
+}
+
+func TestOperationLockOwnerAlive(t *testing.T) {
+	tests := []struct {
+		name     string
+		instance func(a, b *OperationLock) string
+		token    uint64
+		setup    func(t *testing.T, a, b *OperationLock)
+		want     bool
+	}{
+		{
+			name:     "this process",
+			instance: func(a, _ *OperationLock) string { return a.instance },
+			want:     true,
+		},
+		{
+			name:     "replica holding its presence lease",
+			instance: func(_, b *OperationLock) string { return b.instance },
+			setup:    func(_ *testing.T, _, b *OperationLock) { b.announce() },
+			want:     true,
+		},
+		{
+			name:     "replica whose presence lease expired",
+			instance: func(_, b *OperationLock) string { return b.instance },
+			setup: func(_ *testing.T, _, b *OperationLock) {
+				b.announce()
+				time.Sleep(b.ttl + 50*time.Millisecond)
+			},
+		},
+		{
+			name:     "replica without a presence lease",
+			instance: func(_, b *OperationLock) string { return b.instance },
+		},
+		{
+			name:     "earlier process on this host",
+			instance: func(a, _ *OperationLock) string { return "a-old" },
+			setup: func(t *testing.T, a, _ *OperationLock) {
+				old := newTestLock(t, filepath.Dir(filepath.Dir(a.dir)), "a")
+				old.instance = "a-old"
+				old.announce()
+			},
+		},
+		{
+			name:     "replica holding the operation lease it wrote under",
+			instance: func(_, b *OperationLock) string { return b.instance },
+			token:    1,
+			setup: func(t *testing.T, _, b *OperationLock) {
+				_, release, err := b.Acquire(context.Background(), "test")
+				if err != nil {
+					t.Fatalf("Acquire: %v", err)
+				}
+				t.Cleanup(release)
+			},
+			want: true,
+		},
+		{
+			name:     "unknown writer",
+			instance: func(_, _ *OperationLock) string { return "" },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			a, b := newTestLock(t, dir, "a"), newTestLock(t, dir, "b")
+			if tt.setup != nil {
+				tt.setup(t, a, b)
+			}
+			if got := a.ownerAlive(tt.instance(a, b), tt.token); got != tt.want {
+				t.Errorf("ownerAlive = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-kratos/kratos/v2/log"
@@ -23,19 +25,57 @@ type OrchestratorService struct {
 	log          *log.Helper
 	moduleClient *ModuleClient
 	storage      *BackupStorage
+	policy       atomic.Pointer[FullBackupPolicy]
+	freshnessSLO atomic.Pointer[FreshnessSLO]
+	quotas       atomic.Pointer[Quotas]
+	events       *EventPublisher
+	jobs         *JobTracker
+	replicator   *Replicator
+	mirror       *Mirror
+	federation   *Federation
+	throttle     *Throttle
+	sources      *restoreSources
 }
 
-// NewOrchestratorService creates a new orchestrator service.
+// NewOrchestratorService creates a new orchestrator service. The full
+// backup policy, freshness SLOs and quotas follow runtime config reloads.
 func NewOrchestratorService(
 	ctx *bootstrap.Context,
 	moduleClient *ModuleClient,
 	storage *BackupStorage,
+	events *EventPublisher,
+	runtime *RuntimeConfig,
+	jobs *JobTracker,
+	replicator *Replicator,
+	mirror *Mirror,
+	federation *Federation,
+	throttle *Throttle,
 ) *OrchestratorService {
-	return &OrchestratorService{
-		log:          ctx.NewLoggerHelper("backup/orchestrator"),
+	l := ctx.NewLoggerHelper("backup/orchestrator")
+	s := &OrchestratorService{
+		log:          l,
 		moduleClient: moduleClient,
 		storage:      storage,
-	}
+		events:       events,
+		jobs:         jobs,
+		replicator:   replicator,
+		mirror:       mirror,
+		federation:   federation,
+		throttle:     throttle,
+		sources:      newRestoreSources(l, storage, mirror, replicator, federation),
+	}
+	s.reloadSettings()
+	runtime.OnReload(s.reloadSettings)
+	return s
+}
+
+// reloadSettings re-reads the settings the service caches from the
+// environment.
+func (s *OrchestratorService) reloadSettings() {
+	policy, slo, quotas := defaultFullBackupPolicy(s.log), defaultFreshnessSLO(s.log), defaultQuotas(s.log)
+	s.policy.Store(&policy)
+	s.freshnessSLO.Store(&slo)
+	s.quotas.Store(&quotas)
 }
 
 // --- Single Module Operations ---
@@ -44,13 +84,36 @@ func (s *OrchestratorService) CreateModuleBackup(ctx context.Context, req *backu
 	if req.Target == nil {
 		return nil, fmt.Errorf("target is required")
 	}
+	if err := s.checkQuota(tenantIDValue(req.TenantId), req.Target.ModuleId); err != nil {
+		return nil, err
+	}
+	ctx, done, err := s.jobs.Begin(ctx, "module backup")
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	comp, err := resolveCompression(s.log, req.CompressionLevel)
+	if err != nil {
+		return nil, err
+	}
 
 	username := getUsernameFromContext(ctx)
 	now := time.Now()
 
-	s.log.Infof("Creating backup for module %s at %s", req.Target.ModuleId, req.Target.GrpcEndpoint)
+	ctx, requestID := ensureRequestID(ctx)
+	s.log.Infof("Creating backup for module %s at %s (request=%s)", req.Target.ModuleId, req.Target.GrpcEndpoint, requestID)
 
 	result, err := s.moduleClient.ExportBackup(ctx, req.Target, req.TenantId, req.IncludeSecrets)
+	defer result.Close()
+	timings := &backupV1.PhaseTimings{ExportMs: time.Since(now).Milliseconds()}
+	var suspect []string
+	if err == nil {
+		suspect, err = validateExport(result, exportValidationMode(s.log))
+	}
+	var data []byte
+	if err == nil {
+		data, err = result.ReadData()
+	}
 	if err != nil {
 		// Save a failed backup record
 		backupID := uuid.New().String()
@@ -63,32 +126,51 @@ func (s *OrchestratorService) CreateModuleBackup(ctx context.Context, req *backu
 			Status:      "failed",
 			CreatedAt:   timestamppb.New(now),
 			CreatedBy:   username,
-			Warnings:    []string{err.Error()},
+			Warnings:    []string{jobError(ctx, err).Error()},
+			DurationMs:  time.Since(now).Milliseconds(),
+			Timings:     timings,
+		}
+		if err := s.storage.SaveModuleBackupRecord(info); err != nil {
+			s.log.Warnf("Failed to record failed backup %s: %v", backupID, err)
 		}
+		s.events.Publish(ctx, EventBackupFailed, moduleBackupEvent(info))
 		return &backupV1.CreateModuleBackupResponse{Backup: info}, nil
 	}
 
 	backupID := uuid.New().String()
 	info := &backupV1.BackupInfo{
-		Id:           backupID,
-		ModuleId:     req.Target.ModuleId,
-		Description:  req.Description,
-		TenantId:     result.TenantID,
-		FullBackup:   req.TenantId != nil && *req.TenantId == 0,
-		Status:       "completed",
-		SizeBytes:    int64(len(result.Data)),
-		EntityCounts: result.EntityCounts,
-		CreatedAt:    timestamppb.New(now),
+		Id:            backupID,
+		ModuleId:      req.Target.ModuleId,
+		Description:   req.Description,
+		TenantId:      result.TenantID,
+		FullBackup:    req.TenantId != nil && *req.TenantId == 0,
+		Status:        "completed",
+		SizeBytes:     result.Size,
+		EntityCounts:  result.EntityCounts,
+		CreatedAt:     timestamppb.New(now),
 		CreatedBy:     username,
 		Version:       result.Version,
 		SchemaVersion: result.SchemaVersion,
+		Warnings:      result.Warnings,
+		Timings:       timings,
+
+		ValidationStatus:  exportValidationStatus(suspect),
+		ValidationReasons: suspect,
+	}
+	if len(suspect) > 0 {
+		s.log.Warnf("Backup %s of module %s is suspect: %s", backupID, req.Target.ModuleId, strings.Join(suspect, "; "))
 	}
 
-	if err := s.storage.SaveModuleBackup(info, result.Data, req.Password); err != nil {
+	info.DurationMs = time.Since(now).Milliseconds()
+	if err := s.storage.SaveModuleBackup(ctx, info, data, req.Password, comp); err != nil {
+		info.Status = "failed"
+		info.Warnings = append(info.Warnings, err.Error())
+		s.events.Publish(ctx, EventBackupFailed, moduleBackupEvent(info))
 		return nil, fmt.Errorf("save backup: %w", err)
 	}
 
-	s.log.Infof("Module backup completed: id=%s module=%s size=%d", backupID, req.Target.ModuleId, len(result.Data))
+	s.log.Infof("Module backup completed: id=%s module=%s size=%d", backupID, req.Target.ModuleId, result.Size)
+	s.events.Publish(ctx, EventBackupCreated, moduleBackupEvent(info))
 	return &backupV1.CreateModuleBackupResponse{Backup: info}, nil
 }
 
@@ -96,16 +178,47 @@ func (s *OrchestratorService) RestoreModuleBackup(ctx context.Context, req *back
 	if req.Target == nil {
 		return nil, fmt.Errorf("target is required")
 	}
+	ctx, done, err := s.jobs.Begin(ctx, "restore")
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	ctx, unlock, err := s.storage.LockOperations(ctx, "restore")
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
 
-	s.log.Infof("Restoring backup %s to module %s at %s", req.BackupId, req.Target.ModuleId, req.Target.GrpcEndpoint)
+	ctx, requestID := ensureRequestID(ctx)
+	s.log.Infof("Restoring backup %s to module %s at %s (request=%s)", req.BackupId, req.Target.ModuleId, req.Target.GrpcEndpoint, requestID)
 
-	data, err := s.storage.LoadModuleBackupData(req.BackupId, req.Password)
+	reader := s.sources.reader(backupRef{id: req.BackupId}, req.Password)
+	defer reader.Close()
+	data, source, sourceWarnings, err := reader.load(ctx, "")
 	if err != nil {
 		return nil, fmt.Errorf("load backup data: %w", err)
 	}
 
+	restoreEvent := &RestoreEvent{BackupID: req.BackupId, Kind: "module", Modules: []string{req.Target.ModuleId}}
+	s.events.Publish(ctx, EventRestoreStarted, restoreEvent)
+
+	record := &backupV1.RestoreRecord{
+		BackupId:     req.BackupId,
+		Kind:         "module",
+		ModuleId:     req.Target.ModuleId,
+		GrpcEndpoint: req.Target.GrpcEndpoint,
+		Mode:         req.Mode,
+		RequestId:    requestID,
+		Source:       source,
+	}
+	started := time.Now()
 	resp, err := s.moduleClient.ImportBackup(ctx, req.Target, data, req.Mode)
+	if err == nil {
+		record.Success = resp.Success
+	}
+	s.recordRestore(ctx, record, started, err)
 	if err != nil {
+		s.events.Publish(ctx, EventRestoreCompleted, restoreEvent.finished(false, []string{err.Error()}))
 		return nil, fmt.Errorf("import backup to %s: %w", req.Target.ModuleId, err)
 	}
 
@@ -122,37 +235,45 @@ func (s *OrchestratorService) RestoreModuleBackup(ctx context.Context, req *back
 	}
 
 	s.log.Infof("Module restore completed: backup=%s module=%s migrations=%d", req.BackupId, req.Target.ModuleId, resp.MigrationsApplied)
+	s.events.Publish(ctx, EventRestoreCompleted, restoreEvent.finished(resp.Success, nil))
 	return &backupV1.RestoreModuleBackupResponse{
 		Success:           resp.Success,
 		Results:           results,
-		Warnings:          resp.Warnings,
+		Warnings:          append(sourceWarnings, resp.Warnings...),
 		SourceVersion:     resp.SourceVersion,
 		TargetVersion:     resp.TargetVersion,
 		MigrationsApplied: resp.MigrationsApplied,
+		Source:            source,
 	}, nil
 }
 
 func (s *OrchestratorService) ListBackups(ctx context.Context, req *backupV1.ListBackupsRequest) (*backupV1.ListBackupsResponse, error) {
-	backups, err := s.storage.ListModuleBackups(req.ModuleId, req.TenantId)
+	backups, err := s.storage.FindModuleBackups(BackupFilter{
+		ModuleID:      req.ModuleId,
+		TenantID:      req.TenantId,
+		CreatedAfter:  timeOrZero(req.CreatedAfter),
+		CreatedBefore: timeOrZero(req.CreatedBefore),
+		Status:        req.Status,
+		CreatedBy:     req.CreatedBy,
+		Encrypted:     req.Encrypted,
+		Search:        req.Search,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("list backups: %w", err)
 	}
-
-	// Pagination
-	total := int32(len(backups))
-	page, pageSize := normalizePagination(req.Page, req.PageSize)
-	start := (page - 1) * pageSize
-	if start >= total {
-		return &backupV1.ListBackupsResponse{Total: total}, nil
-	}
-	end := start + pageSize
-	if end > total {
-		end = total
+	order, err := parseBackupOrder(req.SortBy, req.SortOrder, moduleSortFields...)
+	if err != nil {
+		return nil, err
 	}
 
+	page, next, err := paginate(backups, order, moduleSortKey, req.Page, req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, err
+	}
 	return &backupV1.ListBackupsResponse{
-		Backups: backups[start:end],
-		Total:   total,
+		Backups:       page,
+		Total:         int32(len(backups)),
+		NextPageToken: next,
 	}, nil
 }
 
@@ -161,17 +282,97 @@ func (s *OrchestratorService) GetBackup(ctx context.Context, req *backupV1.GetBa
 	if err != nil {
 		return nil, fmt.Errorf("get backup: %w", err)
 	}
+	info.Copies = s.sources.backupCopies(info.Status, info.Replication, info.Mirror, info.Origin)
 	return &backupV1.GetBackupResponse{Backup: info}, nil
 }
 
 func (s *OrchestratorService) DeleteBackup(ctx context.Context, req *backupV1.DeleteBackupRequest) (*backupV1.DeleteBackupResponse, error) {
+	_, unlock, err := s.storage.LockOperations(ctx, "delete")
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
 	if err := s.storage.DeleteModuleBackup(req.Id); err != nil {
-		return nil, fmt.Errorf("delete backup: %w", err)
+		return nil, fmt.Errorf("delete backup: %w", deleteError(err))
 	}
 	s.log.Infof("Deleted module backup: %s", req.Id)
 	return &backupV1.DeleteBackupResponse{Success: true}, nil
 }
 
+// DeleteBackups deletes the module backups named by ID or matching a filter,
+// carrying on past failures so one bad backup does not block the rest.
+func (s *OrchestratorService) DeleteBackups(ctx context.Context, req *backupV1.DeleteBackupsRequest) (*backupV1.DeleteBackupsResponse, error) {
+	hasFilter := req.ModuleId != "" || req.TenantId != nil || req.CreatedBefore != nil || req.Status != ""
+	ids := req.Ids
+	switch {
+	case len(ids) > 0 && hasFilter:
+		return nil, fmt.Errorf("ids and filters cannot be combined")
+	case len(ids) == 0 && !hasFilter:
+		return nil, fmt.Errorf("ids or at least one filter is required")
+	}
+	if !req.DryRun {
+		var unlock func()
+		var err error
+		if ctx, unlock, err = s.storage.LockOperations(ctx, "delete"); err != nil {
+			return nil, err
+		}
+		defer unlock()
+	}
+	if hasFilter {
+		backups, err := s.storage.FindModuleBackups(BackupFilter{
+			ModuleID:      req.ModuleId,
+			TenantID:      req.TenantId,
+			CreatedBefore: timeOrZero(req.CreatedBefore),
+			Status:        req.Status,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list backups: %w", err)
+		}
+		for _, b := range backups {
+			ids = append(ids, b.Id)
+		}
+	}
+
+	resp := &backupV1.DeleteBackupsResponse{}
+	for _, id := range ids {
+		r := &backupV1.DeleteBackupResult{Id: id}
+		resp.Results = append(resp.Results, r)
+		if !validPathElement(id) {
+			r.Error = "invalid backup ID"
+			resp.Failed++
+			continue
+		}
+		if req.DryRun {
+			switch info, err := s.storage.GetModuleBackup(id); {
+			case err != nil:
+				r.Error = "backup not found: " + id
+				resp.Failed++
+			case info.Pin != nil:
+				r.Error = ErrBackupPinned.Error()
+				resp.Failed++
+			}
+			continue
+		}
+		if ctx.Err() != nil {
+			r.Error = context.Cause(ctx).Error()
+			resp.Failed++
+			continue
+		}
+		if err := s.storage.DeleteModuleBackup(id); err != nil {
+			r.Error = err.Error()
+			resp.Failed++
+			continue
+		}
+		r.Deleted = true
+		resp.Deleted++
+	}
+	if !req.DryRun {
+		s.log.Infof("Deleted %d module backups (%d failed)", resp.Deleted, resp.Failed)
+	}
+	return resp, nil
+}
+
 func (s *OrchestratorService) DownloadBackup(ctx context.Context, req *backupV1.DownloadBackupRequest) (*backupV1.DownloadBackupResponse, error) {
 	info, err := s.storage.GetModuleBackup(req.Id)
 	if err != nil {
@@ -182,11 +383,15 @@ func (s *OrchestratorService) DownloadBackup(ctx context.Context, req *backupV1.
 		return nil, fmt.Errorf("backup is encrypted: password required")
 	}
 
-	data, err := s.storage.LoadModuleBackupData(req.Id, req.Password)
+	data, err := s.storage.LoadModuleBackupData(ctx, req.Id, req.Password)
 	if err != nil {
 		return nil, fmt.Errorf("load backup data: %w", err)
 	}
 
+	if err := s.throttle.wait(ctx, "download", len(data)); err != nil {
+		return nil, err
+	}
+
 	filename := fmt.Sprintf("%s-%s-%s.json", info.ModuleId, info.Id[:8], info.CreatedAt.AsTime().Format("20060102"))
 	return &backupV1.DownloadBackupResponse{
 		Data:     data,
@@ -197,65 +402,187 @@ func (s *OrchestratorService) DownloadBackup(ctx context.Context, req *backupV1.
 // --- Full Platform Operations ---
 
 func (s *OrchestratorService) CreateFullBackup(ctx context.Context, req *backupV1.CreateFullBackupRequest) (*backupV1.CreateFullBackupResponse, error) {
+	policy, err := policyForRequest(*s.policy.Load(), req)
+	if err != nil {
+		return nil, err
+	}
+	if req.IdempotencyKey != "" {
+		existing, err := s.storage.FindFullBackupByKey(req.IdempotencyKey, tenantIDValue(req.TenantId))
+		if err != nil {
+			return nil, err
+		}
+		// A failed or canceled backup is retried; anything else was saved.
+		if existing != nil && existing.Status != "failed" && existing.Status != "canceled" {
+			s.log.Infof("Full backup with idempotency key %q already saved as %s", req.IdempotencyKey, existing.Id)
+			return &backupV1.CreateFullBackupResponse{Backup: existing}, nil
+		}
+	}
+	if req.TargetSelector != "" {
+		targets, err := s.selectTargets(ctx, req.TargetSelector, req.Targets)
+		if err != nil {
+			return nil, err
+		}
+		req.Targets = targets
+	}
 	if len(req.Targets) == 0 {
 		return nil, fmt.Errorf("at least one target is required")
 	}
+	moduleIDs := make([]string, len(req.Targets))
+	for i, t := range req.Targets {
+		moduleIDs[i] = t.ModuleId
+	}
+	if err := s.checkQuota(tenantIDValue(req.TenantId), moduleIDs...); err != nil {
+		return nil, err
+	}
+	ctx, done, err := s.jobs.Begin(ctx, "full backup")
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	comp, err := resolveCompression(s.log, req.CompressionLevel)
+	if err != nil {
+		return nil, err
+	}
 
 	username := getUsernameFromContext(ctx)
 	now := time.Now()
-	backupID := uuid.New().String()
 
-	s.log.Infof("Creating full backup %s for %d modules", backupID, len(req.Targets))
+	// Each module's data goes to disk as soon as its export returns, so
+	// only the exports still in flight are held in memory. With an
+	// idempotency key, the modules an earlier attempt wrote are kept.
+	spool, resumed, err := s.storage.OpenFullBackupSpool(uuid.New().String(), req.IdempotencyKey, tenantIDValue(req.TenantId), req.Password, comp)
+	if err != nil {
+		return nil, fmt.Errorf("save full backup: %w", err)
+	}
+	backupID := spool.ID()
+	createdAt := now
+	if !spool.CreatedAt().IsZero() {
+		createdAt = spool.CreatedAt()
+	}
+
+	ctx, requestID := ensureRequestID(ctx)
+	s.log.Infof("Creating full backup %s for %d modules (request=%s)", backupID, len(req.Targets), requestID)
+	saved := false
+	defer func() {
+		if !saved {
+			spool.Discard()
+		}
+	}()
 
 	type moduleResult struct {
-		target *backupV1.ModuleTarget
-		result *ExportResult
-		err    error
+		info     *backupV1.BackupInfo
+		err      error
+		spoolErr error
 	}
 
 	results := make([]moduleResult, len(req.Targets))
 	var wg sync.WaitGroup
 
 	for i, target := range req.Targets {
+		if mb, ok := resumed[target.ModuleId]; ok {
+			results[i] = moduleResult{info: mb}
+			continue
+		}
 		wg.Add(1)
 		go func(idx int, t *backupV1.ModuleTarget) {
 			defer wg.Done()
+			start := time.Now()
 			result, err := s.moduleClient.ExportBackup(ctx, t, req.TenantId, req.IncludeSecrets)
-			results[idx] = moduleResult{target: t, result: result, err: err}
+			defer result.Close()
+			timings := &backupV1.PhaseTimings{ExportMs: time.Since(start).Milliseconds()}
+			var suspect []string
+			if err == nil {
+				suspect, err = validateExport(result, exportValidationMode(s.log))
+			}
+			if err == nil && ctx.Err() != nil {
+				// The backup is canceled; don't spend time storing an
+				// export that will be discarded.
+				err = ctx.Err()
+			}
+			if err != nil {
+				status := "failed"
+				if ctx.Err() != nil {
+					status = "canceled"
+				}
+				results[idx] = moduleResult{err: err, info: &backupV1.BackupInfo{
+					ModuleId: t.ModuleId,
+					Status:   status,
+					Warnings: []string{err.Error()},
+					Timings:  timings,
+				}}
+				return
+			}
+			mb := &backupV1.BackupInfo{
+				ModuleId:      t.ModuleId,
+				TenantId:      result.TenantID,
+				FullBackup:    req.TenantId != nil && *req.TenantId == 0,
+				Status:        "completed",
+				SizeBytes:     result.Size,
+				EntityCounts:  result.EntityCounts,
+				Version:       result.Version,
+				SchemaVersion: result.SchemaVersion,
+				Warnings:      result.Warnings,
+				Timings:       timings,
+
+				ValidationStatus:  exportValidationStatus(suspect),
+				ValidationReasons: suspect,
+			}
+			if len(suspect) > 0 {
+				s.log.Warnf("Full backup %s: export of module %s is suspect: %s", backupID, t.ModuleId, strings.Join(suspect, "; "))
+			}
+			results[idx] = moduleResult{info: mb, spoolErr: spool.WriteModule(ctx, mb, result)}
 		}(i, target)
 	}
 	wg.Wait()
 
+	// The caller went away or its deadline passed: in-flight exports were
+	// aborted with it, so don't persist a backup nobody asked to keep. A
+	// record of the canceled backup is kept, as for failed ones.
+	if err := ctx.Err(); err != nil {
+		s.log.Warnf("Full backup %s canceled: %v", backupID, context.Cause(ctx))
+		info := &backupV1.FullBackupInfo{
+			Id:          backupID,
+			Description: req.Description,
+			TenantId:    tenantIDValue(req.TenantId),
+			FullBackup:  req.TenantId != nil && *req.TenantId == 0,
+			Status:      "canceled",
+			CreatedAt:   timestamppb.New(createdAt),
+			CreatedBy:   username,
+			Errors:      []string{fmt.Sprintf("canceled: %v", context.Cause(ctx))},
+			DurationMs:  time.Since(now).Milliseconds(),
+
+			IdempotencyKey: req.IdempotencyKey,
+		}
+		for _, mr := range results {
+			if mr.info.Status == "completed" {
+				mr.info.Status = "canceled"
+				mr.info.Warnings = append(mr.info.Warnings, "exported, but discarded with the canceled backup")
+			}
+			info.ModuleBackups = append(info.ModuleBackups, mr.info)
+		}
+		if err := s.storage.SaveFullBackupRecord(info); err != nil {
+			s.log.Warnf("Failed to record canceled full backup %s: %v", backupID, err)
+		}
+		s.events.Publish(context.WithoutCancel(ctx), EventBackupFailed, fullBackupEvent(info))
+		return nil, fmt.Errorf("full backup canceled: %w", err)
+	}
+
 	var moduleBackups []*backupV1.BackupInfo
-	moduleData := make(map[string][]byte)
 	var totalSize int64
 	var errors []string
+	var saveErr error
 
 	for _, mr := range results {
+		if mr.spoolErr != nil && saveErr == nil {
+			saveErr = mr.spoolErr
+		}
+		moduleBackups = append(moduleBackups, mr.info)
 		if mr.err != nil {
-			s.log.Warnf("ExportBackup failed for %s: %v", mr.target.ModuleId, mr.err)
-			errors = append(errors, fmt.Sprintf("%s: %v", mr.target.ModuleId, mr.err))
-			moduleBackups = append(moduleBackups, &backupV1.BackupInfo{
-				ModuleId: mr.target.ModuleId,
-				Status:   "failed",
-				Warnings: []string{mr.err.Error()},
-			})
+			s.log.Warnf("ExportBackup failed for %s: %v", mr.info.ModuleId, mr.err)
+			errors = append(errors, fmt.Sprintf("%s: %v", mr.info.ModuleId, mr.err))
 			continue
 		}
-
-		moduleBackups = append(moduleBackups, &backupV1.BackupInfo{
-			ModuleId:     mr.target.ModuleId,
-			TenantId:     mr.result.TenantID,
-			FullBackup:   req.TenantId != nil && *req.TenantId == 0,
-			Status:       "completed",
-			SizeBytes:    int64(len(mr.result.Data)),
-			EntityCounts: mr.result.EntityCounts,
-			Version:       mr.result.Version,
-			SchemaVersion: mr.result.SchemaVersion,
-		})
-
-		moduleData[mr.target.ModuleId] = mr.result.Data
-		totalSize += int64(len(mr.result.Data))
+		totalSize += mr.info.SizeBytes
 	}
 
 	status := "completed"
@@ -265,6 +592,11 @@ func (s *OrchestratorService) CreateFullBackup(ctx context.Context, req *backupV
 		status = "partial"
 	}
 
+	validationStatus, validationReasons := policy.Validate(moduleBackups)
+	if validationStatus == validationUnusable {
+		s.log.Warnf("Full backup %s flagged unusable: %v", backupID, validationReasons)
+	}
+
 	info := &backupV1.FullBackupInfo{
 		Id:             backupID,
 		Description:    req.Description,
@@ -273,30 +605,86 @@ func (s *OrchestratorService) CreateFullBackup(ctx context.Context, req *backupV
 		Status:         status,
 		TotalSizeBytes: totalSize,
 		ModuleBackups:  moduleBackups,
-		CreatedAt:      timestamppb.New(now),
+		CreatedAt:      timestamppb.New(createdAt),
 		CreatedBy:      username,
 		Errors:         errors,
+
+		ValidationStatus:  validationStatus,
+		ValidationReasons: validationReasons,
+		DurationMs:        time.Since(now).Milliseconds(),
 	}
 
-	if err := s.storage.SaveFullBackup(info, moduleData, req.Password); err != nil {
-		return nil, fmt.Errorf("save full backup: %w", err)
+	if saveErr == nil {
+		saveErr = s.storage.SaveFullBackup(ctx, info, spool)
 	}
+	if saveErr != nil {
+		info.Status = "failed"
+		info.Errors = append(info.Errors, saveErr.Error())
+		s.events.Publish(ctx, EventBackupFailed, fullBackupEvent(info))
+		return nil, fmt.Errorf("save full backup: %w", saveErr)
+	}
+	saved = true
 
-	s.log.Infof("Full backup completed: id=%s modules=%d status=%s", backupID, len(req.Targets), status)
+	s.log.Infof("Full backup completed: id=%s modules=%d status=%s validation=%s", backupID, len(req.Targets), status, validationStatus)
+	if status == "failed" || validationStatus == validationUnusable {
+		s.events.Publish(ctx, EventBackupFailed, fullBackupEvent(info))
+	} else {
+		s.events.Publish(ctx, EventBackupCreated, fullBackupEvent(info))
+	}
 	return &backupV1.CreateFullBackupResponse{Backup: info}, nil
 }
 
+// selectTargets adds the registered modules matching the selector to the
+// explicitly listed targets. Explicit targets win on duplicate module IDs.
+func (s *OrchestratorService) selectTargets(ctx context.Context, selector string, explicit []*backupV1.ModuleTarget) ([]*backupV1.ModuleTarget, error) {
+	sel, err := ParseLabelSelector(selector)
+	if err != nil {
+		return nil, fmt.Errorf("target selector: %w", err)
+	}
+	selected, err := s.moduleClient.SelectTargets(ctx, sel)
+	if err != nil {
+		return nil, fmt.Errorf("resolve target selector %q: %w", selector, err)
+	}
+
+	seen := make(map[string]bool, len(explicit))
+	targets := append([]*backupV1.ModuleTarget(nil), explicit...)
+	for _, t := range explicit {
+		seen[t.ModuleId] = true
+	}
+	for _, t := range selected {
+		if !seen[t.ModuleId] {
+			seen[t.ModuleId] = true
+			targets = append(targets, t)
+		}
+	}
+	s.log.Infof("Target selector %q matched %d modules", selector, len(selected))
+	return targets, nil
+}
+
 func (s *OrchestratorService) RestoreFullBackup(ctx context.Context, req *backupV1.RestoreFullBackupRequest) (*backupV1.RestoreFullBackupResponse, error) {
 	if len(req.Targets) == 0 {
 		return nil, fmt.Errorf("at least one target is required")
 	}
+	ctx, done, err := s.jobs.Begin(ctx, "full restore")
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	ctx, unlock, err := s.storage.LockOperations(ctx, "restore")
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
 
-	info, err := s.storage.GetFullBackup(req.BackupId)
+	reader := s.sources.reader(backupRef{id: req.BackupId, full: true}, req.Password)
+	defer reader.Close()
+	info, err := reader.fullInfo(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("get full backup: %w", err)
 	}
 
-	s.log.Infof("Restoring full backup %s to %d modules", req.BackupId, len(req.Targets))
+	ctx, requestID := ensureRequestID(ctx)
+	s.log.Infof("Restoring full backup %s to %d modules (request=%s)", req.BackupId, len(req.Targets), requestID)
 
 	// Build a map of module_id -> target for quick lookup
 	targetMap := make(map[string]*backupV1.ModuleTarget, len(req.Targets))
@@ -304,6 +692,14 @@ func (s *OrchestratorService) RestoreFullBackup(ctx context.Context, req *backup
 		targetMap[t.ModuleId] = t
 	}
 
+	restoreEvent := &RestoreEvent{BackupID: req.BackupId, Kind: "full"}
+	for _, mb := range info.ModuleBackups {
+		if mb.Status == "completed" {
+			restoreEvent.Modules = append(restoreEvent.Modules, mb.ModuleId)
+		}
+	}
+	s.events.Publish(ctx, EventRestoreStarted, restoreEvent)
+
 	var moduleResults []*backupV1.ModuleRestoreResult
 	allSuccess := true
 
@@ -311,6 +707,9 @@ func (s *OrchestratorService) RestoreFullBackup(ctx context.Context, req *backup
 		if mb.Status != "completed" {
 			continue
 		}
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("full restore aborted before %s: %w", mb.ModuleId, err)
+		}
 
 		target, ok := targetMap[mb.ModuleId]
 		if !ok {
@@ -323,55 +722,83 @@ func (s *OrchestratorService) RestoreFullBackup(ctx context.Context, req *backup
 			continue
 		}
 
-		data, err := s.storage.LoadFullBackupModuleData(req.BackupId, mb.ModuleId, req.Password)
-		if err != nil {
-			moduleResults = append(moduleResults, &backupV1.ModuleRestoreResult{
-				ModuleId: mb.ModuleId,
-				Success:  false,
-				Error:    fmt.Sprintf("load data: %v", err),
-			})
-			allSuccess = false
-			continue
-		}
-
-		resp, err := s.moduleClient.ImportBackup(ctx, target, data, req.Mode)
-		if err != nil {
-			moduleResults = append(moduleResults, &backupV1.ModuleRestoreResult{
-				ModuleId: mb.ModuleId,
-				Success:  false,
-				Error:    err.Error(),
-			})
+		result := s.restoreFullModule(ctx, reader, req.BackupId, target, req.Mode, requestID)
+		if result.Error != "" {
 			allSuccess = false
-			continue
 		}
-
-		results := make([]*backupV1.EntityImportResult, len(resp.Results))
-		for i, r := range resp.Results {
-			results[i] = &backupV1.EntityImportResult{
-				EntityType: r.EntityType,
-				Total:      r.Total,
-				Created:    r.Created,
-				Updated:    r.Updated,
-				Skipped:    r.Skipped,
-				Failed:     r.Failed,
-			}
-		}
-
-		moduleResults = append(moduleResults, &backupV1.ModuleRestoreResult{
-			ModuleId: mb.ModuleId,
-			Success:  resp.Success,
-			Results:  results,
-			Warnings: resp.Warnings,
-		})
+		moduleResults = append(moduleResults, result)
 	}
 
 	s.log.Infof("Full restore completed: backup=%s success=%v", req.BackupId, allSuccess)
+	var restoreErrors []string
+	for _, r := range moduleResults {
+		if r.Error != "" {
+			restoreErrors = append(restoreErrors, fmt.Sprintf("%s: %s", r.ModuleId, r.Error))
+		}
+	}
+	s.events.Publish(ctx, EventRestoreCompleted, restoreEvent.finished(allSuccess, restoreErrors))
 	return &backupV1.RestoreFullBackupResponse{
 		Success:       allSuccess,
 		ModuleResults: moduleResults,
 	}, nil
 }
 
+// restoreFullModule imports the data one module has in a full backup into
+// its target and records the restore.
+func (s *OrchestratorService) restoreFullModule(ctx context.Context, reader *restoreReader, backupID string, target *backupV1.ModuleTarget, mode backupV1.RestoreMode, requestID string) *backupV1.ModuleRestoreResult {
+	data, source, sourceWarnings, err := reader.load(ctx, target.ModuleId)
+	if err != nil {
+		return &backupV1.ModuleRestoreResult{
+			ModuleId: target.ModuleId,
+			Success:  false,
+			Error:    fmt.Sprintf("load data: %v", err),
+		}
+	}
+
+	record := &backupV1.RestoreRecord{
+		BackupId:     backupID,
+		Kind:         "full",
+		ModuleId:     target.ModuleId,
+		GrpcEndpoint: target.GrpcEndpoint,
+		Mode:         mode,
+		RequestId:    requestID,
+		Source:       source,
+	}
+	started := time.Now()
+	resp, err := s.moduleClient.ImportBackup(ctx, target, data, mode)
+	if err == nil {
+		record.Success = resp.Success
+	}
+	s.recordRestore(ctx, record, started, err)
+	if err != nil {
+		return &backupV1.ModuleRestoreResult{
+			ModuleId: target.ModuleId,
+			Success:  false,
+			Error:    err.Error(),
+			Source:   source,
+		}
+	}
+
+	results := make([]*backupV1.EntityImportResult, len(resp.Results))
+	for i, r := range resp.Results {
+		results[i] = &backupV1.EntityImportResult{
+			EntityType: r.EntityType,
+			Total:      r.Total,
+			Created:    r.Created,
+			Updated:    r.Updated,
+			Skipped:    r.Skipped,
+			Failed:     r.Failed,
+		}
+	}
+	return &backupV1.ModuleRestoreResult{
+		ModuleId: target.ModuleId,
+		Success:  resp.Success,
+		Results:  results,
+		Warnings: append(sourceWarnings, resp.Warnings...),
+		Source:   source,
+	}
+}
+
 func (s *OrchestratorService) DownloadFullBackup(ctx context.Context, req *backupV1.DownloadFullBackupRequest) (*backupV1.DownloadFullBackupResponse, error) {
 	info, err := s.storage.GetFullBackup(req.Id)
 	if err != nil {
@@ -389,7 +816,7 @@ func (s *OrchestratorService) DownloadFullBackup(ctx context.Context, req *backu
 		if mb.Status != "completed" {
 			continue
 		}
-		data, err := s.storage.LoadFullBackupModuleData(req.Id, mb.ModuleId, req.Password)
+		data, err := s.storage.LoadFullBackupModuleData(ctx, req.Id, mb.ModuleId, req.Password)
 		if err != nil {
 			return nil, fmt.Errorf("load module %s data: %w", mb.ModuleId, err)
 		}
@@ -401,6 +828,9 @@ func (s *OrchestratorService) DownloadFullBackup(ctx context.Context, req *backu
 	if err != nil {
 		return nil, fmt.Errorf("marshal combined data: %w", err)
 	}
+	if err := s.throttle.wait(ctx, "download", len(out)); err != nil {
+		return nil, err
+	}
 
 	filename := fmt.Sprintf("full-%s-%s.json", info.Id[:8], info.CreatedAt.AsTime().Format("20060102"))
 	return &backupV1.DownloadFullBackupResponse{
@@ -409,26 +839,34 @@ func (s *OrchestratorService) DownloadFullBackup(ctx context.Context, req *backu
 	}, nil
 }
 
+// ListFullBackups returns manifest summaries: module entries carry only
+// their ID, status and sizes. GetFullBackup returns the full manifest.
 func (s *OrchestratorService) ListFullBackups(ctx context.Context, req *backupV1.ListFullBackupsRequest) (*backupV1.ListFullBackupsResponse, error) {
-	backups, err := s.storage.ListFullBackups(req.TenantId)
+	backups, err := s.storage.FindFullBackupSummaries(BackupFilter{
+		TenantID:      req.TenantId,
+		CreatedAfter:  timeOrZero(req.CreatedAfter),
+		CreatedBefore: timeOrZero(req.CreatedBefore),
+		Status:        req.Status,
+		CreatedBy:     req.CreatedBy,
+		Encrypted:     req.Encrypted,
+		Search:        req.Search,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("list full backups: %w", err)
 	}
-
-	total := int32(len(backups))
-	page, pageSize := normalizePagination(req.Page, req.PageSize)
-	start := (page - 1) * pageSize
-	if start >= total {
-		return &backupV1.ListFullBackupsResponse{Total: total}, nil
-	}
-	end := start + pageSize
-	if end > total {
-		end = total
+	order, err := parseBackupOrder(req.SortBy, req.SortOrder, fullSortFields...)
+	if err != nil {
+		return nil, err
 	}
 
+	page, next, err := paginate(backups, order, fullSortKey, req.Page, req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, err
+	}
 	return &backupV1.ListFullBackupsResponse{
-		Backups: backups[start:end],
-		Total:   total,
+		Backups:       page,
+		Total:         int32(len(backups)),
+		NextPageToken: next,
 	}, nil
 }
 
@@ -437,17 +875,96 @@ func (s *OrchestratorService) GetFullBackup(ctx context.Context, req *backupV1.G
 	if err != nil {
 		return nil, fmt.Errorf("get full backup: %w", err)
 	}
+	info.Copies = s.sources.backupCopies(info.Status, info.Replication, info.Mirror, info.Origin)
 	return &backupV1.GetFullBackupResponse{Backup: info}, nil
 }
 
+// UpdateFullBackup annotates a full backup after creation. The backup data
+// is never touched, only its description and labels.
+func (s *OrchestratorService) UpdateFullBackup(ctx context.Context, req *backupV1.UpdateFullBackupRequest) (*backupV1.UpdateFullBackupResponse, error) {
+	if !validPathElement(req.Id) {
+		return nil, fmt.Errorf("invalid backup ID")
+	}
+	for k := range req.Labels {
+		if k == "" || strings.ContainsAny(k, "=,") {
+			return nil, fmt.Errorf("invalid label key %q", k)
+		}
+	}
+
+	_, unlock, err := s.storage.LockOperations(ctx, "update")
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	info, err := s.storage.UpdateFullBackup(req.Id, func(info *backupV1.FullBackupInfo) error {
+		if req.Description != nil {
+			info.Description = *req.Description
+		}
+		for k, v := range req.Labels {
+			if v == "" {
+				delete(info.Labels, k)
+				continue
+			}
+			if info.Labels == nil {
+				info.Labels = map[string]string{}
+			}
+			info.Labels[k] = v
+		}
+		info.UpdatedAt = timestamppb.Now()
+		info.UpdatedBy = getUsernameFromContext(ctx)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("update full backup: %w", err)
+	}
+	s.log.Infof("Updated full backup %s by %q", req.Id, info.UpdatedBy)
+	return &backupV1.UpdateFullBackupResponse{Backup: info}, nil
+}
+
 func (s *OrchestratorService) DeleteFullBackup(ctx context.Context, req *backupV1.DeleteFullBackupRequest) (*backupV1.DeleteFullBackupResponse, error) {
+	_, unlock, err := s.storage.LockOperations(ctx, "delete")
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
 	if err := s.storage.DeleteFullBackup(req.Id); err != nil {
-		return nil, fmt.Errorf("delete full backup: %w", err)
+		return nil, fmt.Errorf("delete full backup: %w", deleteError(err))
 	}
 	s.log.Infof("Deleted full backup: %s", req.Id)
 	return &backupV1.DeleteFullBackupResponse{Success: true}, nil
 }
 
+// --- Target Checks ---
+
+func (s *OrchestratorService) PreflightCheck(ctx context.Context, req *backupV1.PreflightCheckRequest) (*backupV1.PreflightCheckResponse, error) {
+	if len(req.Targets) == 0 {
+		return nil, fmt.Errorf("at least one target is required")
+	}
+
+	results := make([]*backupV1.TargetPreflightResult, len(req.Targets))
+	var wg sync.WaitGroup
+	for i, target := range req.Targets {
+		wg.Add(1)
+		go func(idx int, t *backupV1.ModuleTarget) {
+			defer wg.Done()
+			results[idx] = s.moduleClient.Preflight(ctx, t)
+		}(i, target)
+	}
+	wg.Wait()
+
+	ready := true
+	for _, r := range results {
+		if !r.Reachable || r.Health == "NOT_SERVING" {
+			ready = false
+		}
+	}
+
+	s.log.Infof("Pre-flight check of %d targets: ready=%v", len(req.Targets), ready)
+	return &backupV1.PreflightCheckResponse{Results: results, Ready: ready}, nil
+}
+
 // --- Helpers ---
 
 func tenantIDValue(tid *uint32) uint32 {
@@ -457,6 +974,14 @@ func tenantIDValue(tid *uint32) uint32 {
 	return 0
 }
 
+// timeOrZero converts an optional timestamp, leaving unset ones zero.
+func timeOrZero(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
 func normalizePagination(page, pageSize int32) (int32, int32) {
 	if page <= 0 {
 		page = 1
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// pageCursor is the content of a page token: the sort order it was issued
+// for and the key of the last backup returned. The next page starts after
+// that key, so backups created or deleted in between do not shift it.
+type pageCursor struct {
+	SortBy    string    `json:"s"`
+	Desc      bool      `json:"d,omitempty"`
+	CreatedAt time.Time `json:"t"`
+	Size      int64     `json:"z,omitempty"`
+	ModuleID  string    `json:"m,omitempty"`
+	Status    string    `json:"st,omitempty"`
+	ID        string    `json:"id"`
+}
+
+func encodePageToken(o backupOrder, k backupSortKey) string {
+	b, _ := json.Marshal(pageCursor{
+		SortBy:    o.by,
+		Desc:      o.desc,
+		CreatedAt: k.createdAt,
+		Size:      k.size,
+		ModuleID:  k.moduleID,
+		Status:    k.status,
+		ID:        k.id,
+	})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodePageToken(token string, o backupOrder) (backupSortKey, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return backupSortKey{}, status.Error(codes.InvalidArgument, "invalid page_token")
+	}
+	var c pageCursor
+	if err := json.Unmarshal(b, &c); err != nil || c.ID == "" {
+		return backupSortKey{}, status.Error(codes.InvalidArgument, "invalid page_token")
+	}
+	if c.SortBy != o.by || c.Desc != o.desc {
+		return backupSortKey{}, status.Error(codes.InvalidArgument, "page_token was issued for a different sort order")
+	}
+	return backupSortKey{createdAt: c.CreatedAt, size: c.Size, moduleID: c.ModuleID, status: c.Status, id: c.ID}, nil
+}
+
+// paginate sorts backups and cuts out one page. A page token takes
+// precedence over page; either way the next page token is returned while
+// more backups follow.
+func paginate[T any](backups []T, o backupOrder, key func(T) backupSortKey, page, pageSize int32, pageToken string) ([]T, string, error) {
+	sortBackups(backups, o, key)
+
+	page, pageSize = normalizePagination(page, pageSize)
+	start := int((page - 1) * pageSize)
+	if pageToken != "" {
+		after, err := decodePageToken(pageToken, o)
+		if err != nil {
+			return nil, "", err
+		}
+		start = len(backups)
+		for i, b := range backups {
+			if o.compare(key(b), after) > 0 {
+				start = i
+				break
+			}
+		}
+	}
+	if start >= len(backups) {
+		return nil, "", nil
+	}
+
+	end := min(start+int(pageSize), len(backups))
+	var next string
+	if end < len(backups) {
+		next = encodePageToken(o, key(backups[end-1]))
+	}
+	return backups[start:end], next, nil
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+)
+
+// PinBackup protects a backup from DeleteBackup, DeleteBackups, the retention
+// task and the prune command. Pinning a pinned backup replaces the reason.
+func (s *OrchestratorService) PinBackup(ctx context.Context, req *backupV1.PinBackupRequest) (*backupV1.PinBackupResponse, error) {
+	if !validPathElement(req.BackupId) {
+		return nil, status.Error(codes.InvalidArgument, "invalid backup ID")
+	}
+	_, unlock, err := s.storage.LockOperations(ctx, "pin")
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	pin := &backupV1.BackupPin{
+		PinnedBy: getUsernameFromContext(ctx),
+		PinnedAt: timestamppb.Now(),
+		Reason:   req.Reason,
+	}
+	if err := s.setPin(req.BackupId, req.Full, pin); err != nil {
+		return nil, fmt.Errorf("pin backup: %w", err)
+	}
+	s.log.Infof("Pinned backup %s (full=%v) by %q: %s", req.BackupId, req.Full, pin.PinnedBy, pin.Reason)
+	return &backupV1.PinBackupResponse{Pin: pin}, nil
+}
+
+// UnpinBackup lifts the protection again. Only platform admins may do this,
+// so whoever could delete a backup cannot simply unpin it first.
+func (s *OrchestratorService) UnpinBackup(ctx context.Context, req *backupV1.UnpinBackupRequest) (*backupV1.UnpinBackupResponse, error) {
+	if !isPlatformAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "only a platform admin can unpin backups")
+	}
+	if !validPathElement(req.BackupId) {
+		return nil, status.Error(codes.InvalidArgument, "invalid backup ID")
+	}
+	_, unlock, err := s.storage.LockOperations(ctx, "unpin")
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if err := s.setPin(req.BackupId, req.Full, nil); err != nil {
+		return nil, fmt.Errorf("unpin backup: %w", err)
+	}
+	s.log.Infof("Unpinned backup %s (full=%v) by %q", req.BackupId, req.Full, getUsernameFromContext(ctx))
+	return &backupV1.UnpinBackupResponse{}, nil
+}
+
+func (s *OrchestratorService) setPin(backupID string, full bool, pin *backupV1.BackupPin) error {
+	if full {
+		_, err := s.storage.SetFullBackupPin(backupID, pin)
+		return err
+	}
+	_, err := s.storage.SetModuleBackupPin(backupID, pin)
+	return err
+}
+
+// deleteError maps a storage delete error to the status callers should see.
+func deleteError(err error) error {
+	if errors.Is(err, ErrBackupPinned) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return err
+}
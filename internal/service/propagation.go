@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	grpcMD "google.golang.org/grpc/metadata"
+)
+
+const requestIDKey = "x-md-global-request-id"
+
+// tracePropagator writes W3C trace context and baggage. B3 is added by
+// injectB3 for modules whose tracing still expects Zipkin headers.
+var tracePropagator = propagation.NewCompositeTextMapPropagator(
+	propagation.TraceContext{},
+	propagation.Baggage{},
+)
+
+type requestIDCtxKey struct{}
+
+// ensureRequestID returns a context carrying a request ID, reusing the
+// caller's x-md-global-request-id when present and generating one otherwise.
+// Every module call made with the returned context forwards the same ID.
+func ensureRequestID(ctx context.Context) (context.Context, string) {
+	if id, ok := ctx.Value(requestIDCtxKey{}).(string); ok && id != "" {
+		return ctx, id
+	}
+	id := ""
+	if inMD, ok := grpcMD.FromIncomingContext(ctx); ok {
+		if vals := inMD.Get(requestIDKey); len(vals) > 0 {
+			id = vals[0]
+		}
+	}
+	if id == "" {
+		id = uuid.New().String()
+	}
+	return context.WithValue(ctx, requestIDCtxKey{}, id), id
+}
+
+// startModuleSpan starts a client span for one module call so each call shows
+// up as a child of the orchestrator's request span.
+func startModuleSpan(ctx context.Context, op, moduleID string) (context.Context, trace.Span) {
+	return otel.Tracer("backup/module-client").Start(ctx, op+" "+moduleID,
+		trace.WithSpanKind(trace.SpanKindClient))
+}
+
+// endSpan records err on the span, if any, and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// injectTraceContext adds tracing and request-ID headers to outgoing metadata.
+func injectTraceContext(ctx context.Context, md grpcMD.MD) {
+	tracePropagator.Inject(ctx, propagation.HeaderCarrier(md))
+	injectB3(ctx, md)
+	if id, ok := ctx.Value(requestIDCtxKey{}).(string); ok && id != "" {
+		md.Set(requestIDKey, id)
+	}
+}
+
+// injectB3 writes the single-header B3 format: {trace}-{span}-{sampled}.
+func injectB3(ctx context.Context, md grpcMD.MD) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return
+	}
+	sampled := "0"
+	if sc.IsSampled() {
+		sampled = "1"
+	}
+	md.Set("b3", fmt.Sprintf("%s-%s-%s", sc.TraceID(), sc.SpanID(), sampled))
+}
@@ -13,8 +13,15 @@ import (
 
 // ProviderSet is the Wire provider set for service layer
 var ProviderSet = wire.NewSet(
+	service.NewRuntimeConfig,
+	service.NewThrottle,
 	service.NewModuleClient,
 	service.NewBackupStorage,
+	service.NewEventPublisher,
+	service.NewJobTracker,
+	service.NewReplicator,
+	service.NewMirror,
+	service.NewFederation,
 	service.NewOrchestratorService,
 	service.NewTaskExecutor,
 )
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+)
+
+// A module backup whose metadata cannot be read is quarantined rather than
+// hidden: listings and GetBackup show it with status "corrupt", with what
+// its directory still tells and the read error as a warning. It stays until
+// RepairBackup rebuilds its metadata or it is deleted; retention leaves it
+// alone. Without metadata its module and tenant are unknown, so it only
+// appears in listings not filtered by module, under tenant 0.
+
+// ErrBackupNotRepairable is returned by RepairModuleBackup when there is
+// nothing to rebuild the metadata from, or no need to.
+var ErrBackupNotRepairable = errors.New("backup cannot be repaired")
+
+// corruptModuleBackup describes the backup directory backupID, whose
+// metadata failed to read with readErr. It reports false when there is no
+// such directory.
+func (s *BackupStorage) corruptModuleBackup(backupID string, readErr error) (*backupV1.BackupInfo, bool) {
+	if !validPathElement(backupID) {
+		return nil, false
+	}
+	dir := s.moduleDir(backupID)
+	fi, err := os.Stat(dir)
+	if err != nil || !fi.IsDir() {
+		return nil, false
+	}
+	info := &backupV1.BackupInfo{
+		Id:        backupID,
+		Status:    "corrupt",
+		CreatedAt: timestamppb.New(fi.ModTime()),
+		Warnings:  []string{readErr.Error()},
+	}
+	if name, size, ok := findDataFile(dir); ok {
+		info.Encrypted = strings.HasSuffix(name, ".enc")
+		info.Compression = CompressionGzip
+		if strings.Contains(name, CompressionExt(CompressionZstd)) {
+			info.Compression = CompressionZstd
+		}
+		info.CompressedSizeBytes = size
+		if strings.HasPrefix(name, "data.delta") {
+			info.Warnings = append(info.Warnings, "stored as a delta against a backup that was recorded only in the metadata")
+		}
+	} else {
+		info.Warnings = append(info.Warnings, "no data file")
+	}
+	return info, true
+}
+
+// findDataFile returns the name and size of the data file in a module
+// backup directory.
+func findDataFile(dir string) (string, int64, bool) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", 0, false
+	}
+	for _, e := range entries {
+		if !strings.HasPrefix(e.Name(), "data.") || strings.HasSuffix(e.Name(), ".tmp") {
+			continue
+		}
+		fi, err := e.Info()
+		if err != nil {
+			continue
+		}
+		return e.Name(), fi.Size(), true
+	}
+	return "", 0, false
+}
+
+// RepairModuleBackup rebuilds the metadata of a corrupt module backup from
+// its data file, which must decode to JSON. The module and tenant were only
+// recorded in the lost metadata, so the caller supplies them; the version,
+// entity counts and timings are gone for good. A delta cannot be repaired,
+// since its base is unknown.
+func (s *BackupStorage) RepairModuleBackup(ctx context.Context, backupID, moduleID string, tenantID uint32, password, repairedBy string) (*backupV1.BackupInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.readModuleMetadata(backupID); err == nil {
+		return nil, fmt.Errorf("%w: its metadata is readable", ErrBackupNotRepairable)
+	}
+	dir := s.moduleDir(backupID)
+	name, _, ok := findDataFile(dir)
+	switch {
+	case !ok:
+		return nil, fmt.Errorf("%w: it has no data file; delete it", ErrBackupNotRepairable)
+	case strings.HasPrefix(name, "data.delta"):
+		return nil, fmt.Errorf("%w: it is stored as a delta against an unknown base; delete it", ErrBackupNotRepairable)
+	}
+
+	f, encrypted, err := openDataFile(dir, "data")
+	if err != nil {
+		return nil, fmt.Errorf("open data: %w", err)
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("open data: %w", err)
+	}
+	raw, err := io.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("read data: %w", err)
+	}
+	_, compressed, err := ParseFormatHeader(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBackupNotRepairable, err)
+	}
+	if encrypted {
+		if password == "" {
+			return nil, fmt.Errorf("%w: the data is encrypted, a password is required", ErrBackupNotRepairable)
+		}
+		if compressed, err = DecryptData(compressed, password); err != nil {
+			return nil, fmt.Errorf("%w: decrypt data: %v", ErrBackupNotRepairable, err)
+		}
+	}
+	data, err := decompressData(compressed)
+	if err != nil {
+		return nil, fmt.Errorf("%w: the data file is damaged too: %v; delete it", ErrBackupNotRepairable, err)
+	}
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("%w: the data file does not hold a JSON export; delete it", ErrBackupNotRepairable)
+	}
+
+	info := &backupV1.BackupInfo{
+		Id:                  backupID,
+		ModuleId:            moduleID,
+		TenantId:            tenantID,
+		Status:              "completed",
+		SizeBytes:           int64(len(data)),
+		CreatedAt:           timestamppb.New(fi.ModTime()),
+		Encrypted:           encrypted,
+		CompressedSizeBytes: int64(len(compressed)),
+		CompressionRatio:    compressionRatio(int64(len(data)), int64(len(compressed))),
+		Sha256:              checksum(data),
+		Compression:         DetectCompression(compressed),
+		StoredSha256:        checksum(raw),
+		Warnings: []string{fmt.Sprintf("metadata rebuilt from the data file by %s on %s; version, entity counts and timings were lost",
+			repairedBy, time.Now().UTC().Format(time.RFC3339))},
+	}
+	s.meta.invalidate(filepath.Join(dir, "metadata.json"))
+	if err := writeMetadata(dir, info); err != nil {
+		return nil, err
+	}
+	s.log.Infof("Repaired metadata of backup %s as module %s, tenant %d", backupID, moduleID, tenantID)
+	return info, nil
+}
+
+// RepairBackup rebuilds the metadata of a quarantined module backup from its
+// data file. Only platform admins may do this, since the caller decides
+// which module and tenant the data belongs to.
+func (s *OrchestratorService) RepairBackup(ctx context.Context, req *backupV1.RepairBackupRequest) (*backupV1.RepairBackupResponse, error) {
+	if !isPlatformAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "only a platform admin can repair backups")
+	}
+	if !validPathElement(req.BackupId) {
+		return nil, status.Error(codes.InvalidArgument, "invalid backup ID")
+	}
+	if req.ModuleId == "" {
+		return nil, status.Error(codes.InvalidArgument, "module_id is required")
+	}
+	_, unlock, err := s.storage.LockOperations(ctx, "repair")
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	info, err := s.storage.RepairModuleBackup(ctx, req.BackupId, req.ModuleId, req.TenantId, req.Password, getUsernameFromContext(ctx))
+	if errors.Is(err, ErrBackupNotRepairable) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("repair backup: %w", err)
+	}
+	return &backupV1.RepairBackupResponse{Backup: info}, nil
+}
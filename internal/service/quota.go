@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+)
+
+// Quotas caps the storage each tenant and each module may use. A zero limit
+// is unlimited. Module limits span tenants.
+type Quotas struct {
+	Tenant QuotaLimit
+	Module QuotaLimit
+	// Overrides are keyed "tenant:<id>" or "module:<id>".
+	Overrides map[string]QuotaLimit
+}
+
+// QuotaLimit is the stored bytes and backup count one tenant or module may
+// reach before new backups are refused.
+type QuotaLimit struct {
+	MaxBytes   int64
+	MaxBackups int
+}
+
+// defaultQuotas reads quotas from the environment:
+//
+//	BACKUP_QUOTA_TENANT_MAX_MB       default 0 (unlimited)
+//	BACKUP_QUOTA_TENANT_MAX_BACKUPS  default 0 (unlimited)
+//	BACKUP_QUOTA_MODULE_MAX_MB       default 0 (unlimited)
+//	BACKUP_QUOTA_MODULE_MAX_BACKUPS  default 0 (unlimited)
+//	BACKUP_QUOTA_OVERRIDES           e.g. "tenant:5:mb=20480,module:ipam:backups=50"
+func defaultQuotas(l *log.Helper) Quotas {
+	q := Quotas{
+		Tenant:    QuotaLimit{MaxBytes: int64(envInt("BACKUP_QUOTA_TENANT_MAX_MB", 0)) << 20, MaxBackups: envInt("BACKUP_QUOTA_TENANT_MAX_BACKUPS", 0)},
+		Module:    QuotaLimit{MaxBytes: int64(envInt("BACKUP_QUOTA_MODULE_MAX_MB", 0)) << 20, MaxBackups: envInt("BACKUP_QUOTA_MODULE_MAX_BACKUPS", 0)},
+		Overrides: map[string]QuotaLimit{},
+	}
+	for _, entry := range envList("BACKUP_QUOTA_OVERRIDES") {
+		key, value, ok := strings.Cut(entry, "=")
+		parts := strings.Split(strings.TrimSpace(key), ":")
+		n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if !ok || err != nil || n < 0 || len(parts) != 3 || !validQuotaScope(parts[0], parts[1]) {
+			l.Warnf("Ignoring malformed quota override %q", entry)
+			continue
+		}
+		scope := parts[0] + ":" + parts[1]
+		limit := q.limit(parts[0], parts[1])
+		switch parts[2] {
+		case "mb":
+			limit.MaxBytes = n << 20
+		case "backups":
+			limit.MaxBackups = int(n)
+		default:
+			l.Warnf("Ignoring malformed quota override %q", entry)
+			continue
+		}
+		q.Overrides[scope] = limit
+	}
+	return q
+}
+
+func validQuotaScope(scope, id string) bool {
+	switch scope {
+	case "tenant":
+		_, err := strconv.ParseUint(id, 10, 32)
+		return err == nil
+	case "module":
+		return id != ""
+	}
+	return false
+}
+
+func (q Quotas) limit(scope, id string) QuotaLimit {
+	if l, ok := q.Overrides[scope+":"+id]; ok {
+		return l
+	}
+	if scope == "tenant" {
+		return q.Tenant
+	}
+	return q.Module
+}
+
+func (q Quotas) enabled() bool {
+	if q.Tenant != (QuotaLimit{}) || q.Module != (QuotaLimit{}) {
+		return true
+	}
+	for _, l := range q.Overrides {
+		if l != (QuotaLimit{}) {
+			return true
+		}
+	}
+	return false
+}
+
+// quotaUsage adds up the stored size and count of every backup that holds
+// data, per tenant and per module, and applies the limits.
+func (s *OrchestratorService) quotaUsage() (map[string]*backupV1.QuotaUsage, error) {
+	quotas := s.quotas.Load()
+	usage := map[string]*backupV1.QuotaUsage{}
+	add := func(scope, id string, size int64) {
+		u, ok := usage[scope+":"+id]
+		if !ok {
+			u = &backupV1.QuotaUsage{Scope: scope, Id: id}
+			usage[scope+":"+id] = u
+		}
+		u.UsedBytes += size
+		u.Backups++
+	}
+
+	modules, err := s.storage.FindModuleBackups(BackupFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("list backups: %w", err)
+	}
+	for _, b := range modules {
+		if b.Status == "failed" {
+			continue
+		}
+		size := storedSize(b.CompressedSizeBytes, b.SizeBytes)
+		add("tenant", strconv.FormatUint(uint64(b.TenantId), 10), size)
+		add("module", b.ModuleId, size)
+	}
+
+	fulls, err := s.storage.FindFullBackups(BackupFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("list full backups: %w", err)
+	}
+	for _, b := range fulls {
+		if b.Status == "failed" || b.Status == "canceled" {
+			continue
+		}
+		add("tenant", strconv.FormatUint(uint64(b.TenantId), 10), storedSize(b.TotalCompressedSizeBytes, b.TotalSizeBytes))
+		for _, mb := range b.ModuleBackups {
+			if mb.Status != "failed" {
+				add("module", mb.ModuleId, storedSize(mb.CompressedSizeBytes, mb.SizeBytes))
+			}
+		}
+	}
+
+	// Overridden tenants and modules show up even before their first backup.
+	for key := range quotas.Overrides {
+		if _, ok := usage[key]; !ok {
+			scope, id, _ := strings.Cut(key, ":")
+			usage[key] = &backupV1.QuotaUsage{Scope: scope, Id: id}
+		}
+	}
+	for _, u := range usage {
+		limit := quotas.limit(u.Scope, u.Id)
+		u.MaxBytes = limit.MaxBytes
+		u.MaxBackups = int32(limit.MaxBackups)
+		u.Exceeded = (u.MaxBytes > 0 && u.UsedBytes >= u.MaxBytes) || (u.MaxBackups > 0 && u.Backups >= u.MaxBackups)
+	}
+	return usage, nil
+}
+
+// storedSize prefers the compressed size; backups written before it was
+// recorded only have the export size.
+func storedSize(compressed, size int64) int64 {
+	if compressed > 0 {
+		return compressed
+	}
+	return size
+}
+
+// checkQuota refuses a new backup for the tenant and modules once any of
+// them has reached its quota. The size of the new backup is not known until
+// it is exported, so the backup that crosses a limit is still stored.
+func (s *OrchestratorService) checkQuota(tenantID uint32, moduleIDs ...string) error {
+	if !s.quotas.Load().enabled() {
+		return nil
+	}
+	usage, err := s.quotaUsage()
+	if err != nil {
+		return fmt.Errorf("check quota: %w", err)
+	}
+
+	keys := []string{"tenant:" + strconv.FormatUint(uint64(tenantID), 10)}
+	for _, id := range moduleIDs {
+		keys = append(keys, "module:"+id)
+	}
+	var exceeded []string
+	for _, key := range keys {
+		u := usage[key]
+		if u == nil {
+			continue
+		}
+		if u.MaxBytes > 0 && u.UsedBytes >= u.MaxBytes {
+			exceeded = append(exceeded, fmt.Sprintf("%s %s uses %s of its %s", u.Scope, u.Id, FormatBytes(u.UsedBytes), FormatBytes(u.MaxBytes)))
+		}
+		if u.MaxBackups > 0 && u.Backups >= u.MaxBackups {
+			exceeded = append(exceeded, fmt.Sprintf("%s %s has %d of its %d backups", u.Scope, u.Id, u.Backups, u.MaxBackups))
+		}
+	}
+	if len(exceeded) > 0 {
+		return status.Errorf(codes.ResourceExhausted, "backup quota exceeded: %s; delete old backups or raise the quota", strings.Join(exceeded, "; "))
+	}
+	return nil
+}
+
+// GetQuotaUsage reports storage used against the quotas, per tenant and per
+// module.
+func (s *OrchestratorService) GetQuotaUsage(ctx context.Context, req *backupV1.GetQuotaUsageRequest) (*backupV1.GetQuotaUsageResponse, error) {
+	usage, err := s.quotaUsage()
+	if err != nil {
+		return nil, err
+	}
+	resp := &backupV1.GetQuotaUsageResponse{}
+	for _, u := range usage {
+		if req.TenantId != nil || req.ModuleId != "" {
+			tenantMatch := req.TenantId != nil && u.Scope == "tenant" && u.Id == strconv.FormatUint(uint64(*req.TenantId), 10)
+			moduleMatch := req.ModuleId != "" && u.Scope == "module" && u.Id == req.ModuleId
+			if !tenantMatch && !moduleMatch {
+				continue
+			}
+		}
+		resp.Usage = append(resp.Usage, u)
+	}
+	sort.Slice(resp.Usage, func(i, j int) bool {
+		if resp.Usage[i].Scope != resp.Usage[j].Scope {
+			return resp.Usage[i].Scope > resp.Usage[j].Scope
+		}
+		return resp.Usage[i].Id < resp.Usage[j].Id
+	})
+	return resp, nil
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+	commonV1 "github.com/go-tangra/go-tangra-common/gen/go/common/service/v1"
+)
+
+// RecompactConfig is the payload for backup:recompact tasks.
+type RecompactConfig struct {
+	MinAgeDays  int    `json:"minAgeDays"`            // default 30
+	Compression string `json:"compression,omitempty"` // default zstd
+	Level       int    `json:"level,omitempty"`       // default: the algorithm's highest
+	// ModuleID limits the task to that module's standalone backups; full
+	// backups are only recompacted when it is empty.
+	ModuleID string `json:"moduleId,omitempty"`
+	// MaxMinutes stops the task from starting on further backups once it
+	// has run this long (default 60), so it stays inside the idle window.
+	MaxMinutes int  `json:"maxMinutes,omitempty"`
+	DryRun     bool `json:"dryRun"`
+}
+
+// recompactable reports whether a module backup or full backup module entry
+// is worth recompressing to c: stored in full, readable without a password,
+// and not already at c's algorithm and at least its level.
+func recompactable(b *backupV1.BackupInfo, c compression) bool {
+	if b.Status != "completed" || b.Encrypted || b.DeltaBaseId != "" {
+		return false
+	}
+	return b.Compression != c.algo || int(b.CompressionLevel) < c.level
+}
+
+func (e *TaskExecutor) handleRecompact(
+	ctx context.Context,
+	req *commonV1.ExecuteTaskRequest,
+) (*commonV1.ExecuteTaskResponse, error) {
+	cfg := RecompactConfig{MinAgeDays: 30, Compression: CompressionZstd, MaxMinutes: 60}
+	if len(req.GetPayload()) > 0 {
+		if err := json.Unmarshal(req.GetPayload(), &cfg); err != nil {
+			return &commonV1.ExecuteTaskResponse{
+				Success:          false,
+				PermanentFailure: true,
+				Message:          fmt.Sprintf("invalid payload: %v", err),
+			}, nil
+		}
+	}
+
+	if cfg.Compression != CompressionGzip && cfg.Compression != CompressionZstd {
+		return &commonV1.ExecuteTaskResponse{
+			Success:          false,
+			PermanentFailure: true,
+			Message:          fmt.Sprintf("unknown compression %q", cfg.Compression),
+		}, nil
+	}
+	lo, hi := CompressionLevelRange(cfg.Compression)
+	if cfg.Level == 0 {
+		cfg.Level = hi
+	}
+	if cfg.Level < lo || cfg.Level > hi {
+		return &commonV1.ExecuteTaskResponse{
+			Success:          false,
+			PermanentFailure: true,
+			Message:          fmt.Sprintf("level must be between %d and %d for %s", lo, hi, cfg.Compression),
+		}, nil
+	}
+	if cfg.MinAgeDays <= 0 {
+		cfg.MinAgeDays = 30
+	}
+	if cfg.MaxMinutes <= 0 {
+		cfg.MaxMinutes = 60
+	}
+
+	if !cfg.DryRun {
+		lockCtx, unlock, err := e.backupStorage.LockOperations(ctx, "recompaction")
+		if err != nil {
+			return &commonV1.ExecuteTaskResponse{
+				Success: false,
+				Message: fmt.Sprintf("failed to take the operation lock: %v", err),
+			}, nil
+		}
+		defer unlock()
+		ctx = lockCtx
+	}
+
+	c := compression{algo: cfg.Compression, level: cfg.Level}
+	cutoff := time.Now().AddDate(0, 0, -cfg.MinAgeDays)
+	deadline := time.Now().Add(time.Duration(cfg.MaxMinutes) * time.Minute)
+	e.log.Infof("Recompacting backups older than %d days to %s level %d (module=%s, dryRun=%v)",
+		cfg.MinAgeDays, c.algo, c.level, cfg.ModuleID, cfg.DryRun)
+
+	type candidate struct {
+		id   string
+		full bool
+	}
+	var candidates []candidate
+
+	backups, err := e.backupStorage.ListModuleBackups(cfg.ModuleID, tenantPtr(req.GetTenantId()))
+	if err != nil {
+		return &commonV1.ExecuteTaskResponse{
+			Success: false,
+			Message: fmt.Sprintf("failed to list backups: %v", err),
+		}, nil
+	}
+	for _, b := range backups {
+		if b.GetCreatedAt().AsTime().Before(cutoff) && recompactable(b, c) {
+			candidates = append(candidates, candidate{id: b.Id})
+		}
+	}
+
+	if cfg.ModuleID == "" {
+		fulls, err := e.backupStorage.ListFullBackups(tenantPtr(req.GetTenantId()))
+		if err != nil {
+			return &commonV1.ExecuteTaskResponse{
+				Success: false,
+				Message: fmt.Sprintf("failed to list full backups: %v", err),
+			}, nil
+		}
+		for _, fb := range fulls {
+			if fb.Encrypted || !fb.GetCreatedAt().AsTime().Before(cutoff) {
+				continue
+			}
+			for _, mb := range fb.ModuleBackups {
+				if recompactable(mb, c) {
+					candidates = append(candidates, candidate{id: fb.Id, full: true})
+					break
+				}
+			}
+		}
+	}
+
+	// Lists are newest first; the oldest backups are the coldest, so they go
+	// first in case the time budget runs out.
+	var done int
+	var before, after int64
+	for i := len(candidates) - 1; i >= 0; i-- {
+		cand := candidates[i]
+		if ctx.Err() != nil || time.Now().After(deadline) {
+			e.log.Infof("Recompaction stopped with %d backups left", i+1)
+			break
+		}
+		if cfg.DryRun {
+			e.log.Infof("[dry-run] Would recompact backup %s (full=%v)", cand.id, cand.full)
+			done++
+			continue
+		}
+		var b, a int64
+		if cand.full {
+			b, a, err = e.backupStorage.RecompactFullBackup(cand.id, c)
+		} else {
+			b, a, err = e.backupStorage.RecompactModuleBackup(cand.id, c)
+		}
+		if err != nil {
+			e.log.Warnf("Failed to recompact backup %s: %v", cand.id, err)
+			continue
+		}
+		e.log.Infof("Recompacted backup %s (full=%v): %s -> %s", cand.id, cand.full, FormatBytes(b), FormatBytes(a))
+		done++
+		before += b
+		after += a
+	}
+
+	msg := fmt.Sprintf("Recompacted %d backups to %s level %d, reclaiming %s", done, c.algo, c.level, FormatBytes(before-after))
+	if cfg.DryRun {
+		msg = fmt.Sprintf("[DRY RUN] Would recompact %d backups to %s level %d", done, c.algo, c.level)
+	}
+
+	return &commonV1.ExecuteTaskResponse{
+		Success: true,
+		Message: msg,
+	}, nil
+}
+
+// RecompactModuleBackup recompresses an unencrypted module backup as c asks
+// and returns its stored size before and after. See RecompressBackup.
+func (s *BackupStorage) RecompactModuleBackup(backupID string, c compression) (before, after int64, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir := s.moduleDir(backupID)
+	defer s.meta.invalidate(filepath.Join(dir, "metadata.json"))
+	return RecompressBackup(dir, "", c.algo, c.level)
+}
+
+// RecompactFullBackup recompresses every module of an unencrypted full
+// backup as c asks and returns its stored size before and after.
+func (s *BackupStorage) RecompactFullBackup(backupID string, c compression) (before, after int64, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	defer s.forgetFull(backupID)
+	return RecompressBackup(s.fullDir(backupID), "", c.algo, c.level)
+}
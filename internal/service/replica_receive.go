@@ -0,0 +1,335 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+)
+
+// ReceiveReplica stores a backup a primary orchestrator replicates to this
+// one. It is refused unless BACKUP_REPLICATION_ACCEPT is "true", and only
+// accepted from a peer that presented a verified client certificate, listed
+// in BACKUP_REPLICATION_ALLOWED_PEERS when that is set. A backup this
+// orchestrator already has is acknowledged without being stored again.
+func (s *OrchestratorService) ReceiveReplica(stream grpc.ClientStreamingServer[backupV1.ReplicaChunk, backupV1.ReceiveReplicaResponse]) error {
+	if err := checkPeer(stream.Context(), "send replicas", "BACKUP_REPLICATION_ACCEPT", "BACKUP_REPLICATION_ALLOWED_PEERS"); err != nil {
+		return err
+	}
+	m, meta, err := recvReplicaManifest(stream)
+	if err != nil {
+		return err
+	}
+
+	if s.storage.HasBackup(m.BackupId, m.Full) {
+		s.log.Infof("Replica of backup %s (full=%v) is already here", m.BackupId, m.Full)
+		return stream.SendAndClose(&backupV1.ReceiveReplicaResponse{BackupId: m.BackupId, AlreadyPresent: true})
+	}
+
+	var received int64
+	err = s.storage.StoreReplica(m.BackupId, m.Full, meta, func(dir string) error {
+		n, err := receiveReplicaFiles(stream, dir, m.Files)
+		received = n
+		return err
+	})
+	if errors.Is(err, os.ErrExist) {
+		return stream.SendAndClose(&backupV1.ReceiveReplicaResponse{BackupId: m.BackupId, AlreadyPresent: true})
+	}
+	if err != nil {
+		s.log.Errorf("Failed to store replica of backup %s (full=%v): %v", m.BackupId, m.Full, err)
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return status.Errorf(codes.Internal, "store replica: %v", err)
+	}
+	s.log.Infof("Stored replica of backup %s (full=%v): %d files, %d bytes", m.BackupId, m.Full, len(m.Files), received)
+	return stream.SendAndClose(&backupV1.ReceiveReplicaResponse{BackupId: m.BackupId, BytesReceived: received})
+}
+
+// checkPeer refuses a call from another orchestrator, which wants to do
+// what, unless the enable variable is "true" here and the caller presented a
+// verified client certificate, one of those listed in the allowed variable
+// when that is set.
+func checkPeer(ctx context.Context, what, enable, allowed string) error {
+	if os.Getenv(enable) != "true" {
+		return status.Errorf(codes.PermissionDenied, "this orchestrator does not let peers %s (%s)", what, enable)
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return status.Errorf(codes.Unauthenticated, "peers may only %s over mTLS", what)
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return status.Errorf(codes.Unauthenticated, "peers may only %s over mTLS", what)
+	}
+	leaf := info.State.VerifiedChains[0][0]
+	if names := envList(allowed); len(names) > 0 && !slices.Contains(names, leaf.Subject.CommonName) {
+		return status.Errorf(codes.PermissionDenied, "%q may not %s (%s)", leaf.Subject.CommonName, what, allowed)
+	}
+	return nil
+}
+
+// replicaStream is either end of a stream of replica chunks.
+type replicaStream interface {
+	Recv() (*backupV1.ReplicaChunk, error)
+}
+
+// recvReplicaManifest reads and checks the manifest a replica stream starts
+// with, and returns it with the metadata it carries.
+func recvReplicaManifest(stream replicaStream) (*backupV1.ReplicaManifest, proto.Message, error) {
+	first, err := stream.Recv()
+	if err != nil {
+		return nil, nil, err
+	}
+	m := first.GetManifest()
+	if m == nil {
+		return nil, nil, status.Error(codes.InvalidArgument, "the first message must carry the manifest")
+	}
+	if !validPathElement(m.BackupId) {
+		return nil, nil, status.Error(codes.InvalidArgument, "invalid backup ID")
+	}
+	for _, f := range m.Files {
+		if !isReplicaFile(f.Name) {
+			return nil, nil, status.Errorf(codes.InvalidArgument, "invalid file name %q", f.Name)
+		}
+	}
+
+	var meta proto.Message = &backupV1.BackupInfo{}
+	if m.Full {
+		meta = &backupV1.FullBackupInfo{}
+	}
+	if err := protojson.Unmarshal(m.Metadata, meta); err != nil {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "invalid metadata: %v", err)
+	}
+	return m, meta, nil
+}
+
+// receiveReplicaFiles writes the files the manifest lists into dir as their
+// chunks arrive, checking each against its size and checksum.
+func receiveReplicaFiles(stream replicaStream, dir string, files []*backupV1.ReplicaFile) (int64, error) {
+	var total int64
+	var f *os.File
+	var h hash.Hash
+	var written int64
+	i := -1
+	finish := func() error {
+		if f == nil {
+			return nil
+		}
+		want := files[i]
+		err := f.Close()
+		f = nil
+		if err != nil {
+			return err
+		}
+		if written != want.Size || hex.EncodeToString(h.Sum(nil)) != want.Sha256 {
+			return status.Errorf(codes.DataLoss, "%s arrived damaged: %d of %d bytes, checksum mismatch or short", want.Name, written, want.Size)
+		}
+		return nil
+	}
+	defer func() {
+		if f != nil {
+			f.Close()
+		}
+	}()
+
+	for {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return total, err
+		}
+		chunk := msg.GetFile()
+		if chunk == nil {
+			return total, status.Error(codes.InvalidArgument, "only file chunks may follow the manifest")
+		}
+		if f == nil || chunk.Name != files[i].Name {
+			if err := finish(); err != nil {
+				return total, err
+			}
+			if i+1 >= len(files) || chunk.Name != files[i+1].Name {
+				return total, status.Errorf(codes.InvalidArgument, "unexpected chunk of %q", chunk.Name)
+			}
+			i++
+			if f, err = os.OpenFile(filepath.Join(dir, chunk.Name), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644); err != nil {
+				return total, err
+			}
+			h, written = sha256.New(), 0
+		}
+		if _, err := f.Write(chunk.Data); err != nil {
+			return total, fmt.Errorf("write %s: %w", chunk.Name, err)
+		}
+		h.Write(chunk.Data)
+		written += int64(len(chunk.Data))
+		total += int64(len(chunk.Data))
+	}
+	if err := finish(); err != nil {
+		return total, err
+	}
+	// Files with no chunks at all are empty.
+	for i++; i < len(files); i++ {
+		if files[i].Size != 0 {
+			return total, status.Errorf(codes.DataLoss, "%s never arrived", files[i].Name)
+		}
+		if err := os.WriteFile(filepath.Join(dir, files[i].Name), nil, 0o644); err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// HasBackup reports whether a backup with readable metadata exists.
+func (s *BackupStorage) HasBackup(backupID string, full bool) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.hasBackup(backupID, full)
+}
+
+func (s *BackupStorage) hasBackup(backupID string, full bool) bool {
+	var err error
+	if full {
+		_, err = s.readFullMetadata(backupID)
+	} else {
+		_, err = s.readModuleMetadata(backupID)
+	}
+	return err == nil
+}
+
+// StoreReplica stores a backup received from another orchestrator under the
+// same ID. write fills a staging directory with the data files; they must
+// match the checksums in meta, and a delta's base must be here already. The
+// metadata is written last and the directory then moved into place, so a
+// replica that fails halfway leaves nothing behind. It returns an error
+// wrapping os.ErrExist if the backup arrived in the meantime.
+func (s *BackupStorage) StoreReplica(backupID string, full bool, meta proto.Message, write func(dir string) error) error {
+	spool := filepath.Join(s.basePath, "spool")
+	if err := os.MkdirAll(spool, 0o755); err != nil {
+		return fmt.Errorf("create spool dir: %w", err)
+	}
+	// Each call stages on its own, so concurrent sends of the same backup
+	// don't write into each other's files; the first to finish wins.
+	staging, err := os.MkdirTemp(spool, "replica-"+backupID+"-")
+	if err != nil {
+		return fmt.Errorf("create staging dir: %w", err)
+	}
+	defer os.RemoveAll(staging)
+	if err := write(staging); err != nil {
+		return err
+	}
+
+	var dest string
+	switch info := meta.(type) {
+	case *backupV1.BackupInfo:
+		if info.Id != backupID {
+			return status.Errorf(codes.InvalidArgument, "metadata is of backup %s", info.Id)
+		}
+		if info.DeltaBaseId != "" && !s.HasBackup(info.DeltaBaseId, false) {
+			return status.Errorf(codes.FailedPrecondition, "delta base %s is not stored here", info.DeltaBaseId)
+		}
+		dest = s.moduleDir(backupID)
+	case *backupV1.FullBackupInfo:
+		if info.Id != backupID {
+			return status.Errorf(codes.InvalidArgument, "metadata is of backup %s", info.Id)
+		}
+		dest = s.fullDir(backupID)
+	}
+	if err := checkBackupData(staging, meta); err != nil {
+		return err
+	}
+	if err := writeMetadata(staging, meta); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.hasBackup(backupID, full) {
+		return fmt.Errorf("backup %s: %w", backupID, os.ErrExist)
+	}
+	if _, err := os.Stat(dest); err == nil {
+		return status.Errorf(codes.FailedPrecondition, "a damaged backup %s is stored here; repair or delete it first", backupID)
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return err
+	}
+	if full {
+		s.forgetFull(backupID)
+	} else {
+		s.meta.invalidate(filepath.Join(dest, "metadata.json"))
+	}
+	if err := os.Rename(staging, dest); err != nil {
+		return fmt.Errorf("move replica into place: %w", err)
+	}
+	return nil
+}
+
+// checkBackupData checks that the data files meta lists are all in dir
+// and match their recorded checksums.
+func checkBackupData(dir string, meta proto.Message) error {
+	var files []BackupDataFile
+	switch info := meta.(type) {
+	case *backupV1.BackupInfo:
+		if info.Status != "completed" {
+			return nil
+		}
+		files = BackupDataFiles(dir, info, nil)
+		if info.DeltaBaseId != "" {
+			files[0].Path = ""
+			for _, algo := range []string{CompressionGzip, CompressionZstd} {
+				p := filepath.Join(dir, "data.delta"+CompressionExt(algo))
+				if _, err := os.Stat(p); err == nil {
+					files[0].Path = p
+				}
+			}
+		}
+	case *backupV1.FullBackupInfo:
+		files = BackupDataFiles(dir, nil, info)
+	}
+	for _, f := range files {
+		if f.Path == "" {
+			return status.Errorf(codes.DataLoss, "data of %s is missing", f.Info.ModuleId)
+		}
+		if err := verifyReplicaFile(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyReplicaFile checks a received data file against the checksum its
+// metadata records, for backups written since checksums were.
+func verifyReplicaFile(f BackupDataFile) error {
+	if f.Info.StoredSha256 == "" {
+		return nil
+	}
+	file, err := os.Open(f.Path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return fmt.Errorf("read data of %s: %w", f.Info.ModuleId, err)
+	}
+	if hex.EncodeToString(h.Sum(nil)) != f.Info.StoredSha256 {
+		return status.Errorf(codes.DataLoss, "data of %s does not match its recorded checksum", f.Info.ModuleId)
+	}
+	return nil
+}
@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+)
+
+// Replication keeps an off-site copy of every backup on a secondary
+// orchestrator. The primary pushes each backup that completes, data files
+// and metadata, to the secondary's ReceiveReplica over mTLS, and records how
+// that went in the backup's replication status. The secondary stores the
+// copy as an ordinary backup of its own, so it can be listed, verified and
+// restored from there.
+//
+// On the primary:
+//
+//	BACKUP_REPLICATION_TARGET              host:port of the secondary; unset turns replication off
+//	BACKUP_REPLICATION_SERVER_NAME         name expected in the secondary's certificate, when not the host
+//	BACKUP_REPLICATION_WORKERS             backups replicated at a time (default 1)
+//	BACKUP_REPLICATION_RETRY_ATTEMPTS      attempts per backup before it is marked failed (default 5)
+//	BACKUP_REPLICATION_RETRY_BACKOFF_MS    default 5000, doubled per attempt up to 5 minutes
+//	BACKUP_REPLICATION_RESCAN_MINUTES      how often pending and failed backups are picked up again (default 30)
+//	BACKUP_REPLICATION_CHUNK_KB            size of the stream messages (default 1024)
+//
+// On the secondary:
+//
+//	BACKUP_REPLICATION_ACCEPT              "true" to accept replicas
+//	BACKUP_REPLICATION_ALLOWED_PEERS       client certificate common names allowed to send them (default any the CA signed)
+//
+// A delta is sent after the backup it is based on. The status is kept in the
+// metadata, so backups still pending when the primary stops are sent after
+// it starts again, and failed ones are retried on every rescan.
+
+// Replicator sends backups to the secondary. A nil Replicator means
+// replication is off.
+type Replicator struct {
+	*backupCopier
+	client backupV1.BackupOrchestratorServiceClient
+	chunk  int
+}
+
+// NewReplicator starts replication when BACKUP_REPLICATION_TARGET is set.
+// Every backup.created event queues the new backup.
+func NewReplicator(ctx *bootstrap.Context, storage *BackupStorage, events *EventPublisher, throttle *Throttle) (*Replicator, func(), error) {
+	l := ctx.NewLoggerHelper("backup/replication")
+	target := strings.TrimSpace(os.Getenv("BACKUP_REPLICATION_TARGET"))
+	if target == "" {
+		return nil, func() {}, nil
+	}
+
+	conn, err := dialPeer(l, target, os.Getenv("BACKUP_REPLICATION_SERVER_NAME"), throttle.dialOptions("replication")...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("replication to %s: %w", target, err)
+	}
+
+	r := &Replicator{
+		backupCopier: newBackupCopier("Replication", "BACKUP_REPLICATION", l, storage, target),
+		client:       backupV1.NewBackupOrchestratorServiceClient(conn),
+		chunk:        replicaChunkSize(),
+	}
+	r.send = r.sendBackup
+	r.field = func(info proto.Message) **backupV1.ReplicationStatus {
+		if full, ok := info.(*backupV1.FullBackupInfo); ok {
+			return &full.Replication
+		}
+		return &info.(*backupV1.BackupInfo).Replication
+	}
+	r.start(events, envInt("BACKUP_REPLICATION_WORKERS", 1))
+
+	cleanup := func() {
+		r.stop()
+		conn.Close()
+	}
+	return r, cleanup, nil
+}
+
+// dialPeer connects to another orchestrator. Backups leave the site over
+// this connection: mTLS or nothing.
+func dialPeer(l *log.Helper, target, serverName string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	tlsConfig, err := loadClientTLSConfig(l)
+	if err != nil {
+		return nil, fmt.Errorf("mTLS client credentials required: %w", err)
+	}
+	if serverName != "" {
+		tlsConfig.ServerName = serverName
+	}
+	creds := credentials.NewTLS(tlsConfig)
+	endpoint := target
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "passthrough:///" + endpoint
+	}
+	opts = append(opts,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{Time: 5 * time.Minute, Timeout: 20 * time.Second}),
+	)
+	conn, err := grpc.NewClient(endpoint, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("connect to %s: %w", target, err)
+	}
+	return conn, nil
+}
+
+// fetchReplica pulls one backup from another orchestrator's FetchReplica
+// into dir, checking every file against the manifest, and returns its
+// metadata.
+func fetchReplica(ctx context.Context, client backupV1.BackupOrchestratorServiceClient, ref backupRef, dir string) (proto.Message, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := client.FetchReplica(ctx, &backupV1.FetchReplicaRequest{BackupId: ref.id, Full: ref.full})
+	if err != nil {
+		return nil, err
+	}
+	m, meta, err := recvReplicaManifest(stream)
+	if err != nil {
+		return nil, err
+	}
+	if m.BackupId != ref.id || m.Full != ref.full {
+		return nil, status.Errorf(codes.InvalidArgument, "the peer sent backup %s instead", m.BackupId)
+	}
+	if _, err := receiveReplicaFiles(stream, dir, m.Files); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// sendBackup streams one backup to the secondary.
+func (r *Replicator) sendBackup(ctx context.Context, ref backupRef) error {
+	dir, manifest, err := r.storage.replicaManifest(ref)
+	if err != nil {
+		return err
+	}
+	stream, err := r.client.ReceiveReplica(ctx)
+	if err != nil {
+		return err
+	}
+	err = sendReplica(dir, manifest, r.chunk, func(c *backupV1.ReplicaChunk) error {
+		if err := stream.Send(c); err != nil {
+			return replicaStreamError(stream, err)
+		}
+		return nil
+	})
+	if errors.Is(err, errReplicaAnswered) {
+		r.log.Infof("Backup %s was already on %s", ref.id, r.target)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+	if resp.AlreadyPresent {
+		r.log.Infof("Backup %s was already on %s", ref.id, r.target)
+	}
+	return nil
+}
+
+// replicaManifest describes a backup as it is sent to another
+// orchestrator, along with the directory its files are in.
+func (s *BackupStorage) replicaManifest(ref backupRef) (string, *backupV1.ReplicaManifest, error) {
+	dir, meta, err := s.copySource(ref)
+	if err != nil {
+		return "", nil, err
+	}
+	metadata, err := protojson.Marshal(meta)
+	if err != nil {
+		return "", nil, fmt.Errorf("marshal metadata: %w", err)
+	}
+	files, err := replicaFiles(dir)
+	if err != nil {
+		return "", nil, err
+	}
+	return dir, &backupV1.ReplicaManifest{BackupId: ref.id, Full: ref.full, Metadata: metadata, Files: files}, nil
+}
+
+// replicaChunkSize is the size of the messages backups are streamed in
+// between orchestrators.
+func replicaChunkSize() int {
+	chunk := envInt("BACKUP_REPLICATION_CHUNK_KB", 1024) * 1024
+	if chunk <= 0 {
+		chunk = 1024 * 1024
+	}
+	return chunk
+}
+
+// sendReplica sends the manifest, then every file it lists in chunks of
+// the given size.
+func sendReplica(dir string, manifest *backupV1.ReplicaManifest, chunk int, send func(*backupV1.ReplicaChunk) error) error {
+	if err := send(&backupV1.ReplicaChunk{Payload: &backupV1.ReplicaChunk_Manifest{Manifest: manifest}}); err != nil {
+		return err
+	}
+	buf := make([]byte, chunk)
+	for _, f := range manifest.Files {
+		if err := sendReplicaFile(filepath.Join(dir, f.Name), f.Name, buf, send); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sendReplicaFile(path, name string, buf []byte, send func(*backupV1.ReplicaChunk) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", name, err)
+	}
+	defer f.Close()
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			chunk := &backupV1.ReplicaFileChunk{Name: name, Data: buf[:n]}
+			if err := send(&backupV1.ReplicaChunk{Payload: &backupV1.ReplicaChunk_File{File: chunk}}); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read %s: %w", name, err)
+		}
+	}
+}
+
+// errReplicaAnswered means the secondary answered before all data was sent,
+// which it does when it has the backup already.
+var errReplicaAnswered = errors.New("the secondary answered early")
+
+// replicaStreamError returns why the secondary ended the stream early; Send
+// only reports io.EOF then.
+func replicaStreamError(stream grpc.ClientStreamingClient[backupV1.ReplicaChunk, backupV1.ReceiveReplicaResponse], err error) error {
+	if !errors.Is(err, io.EOF) {
+		return err
+	}
+	if _, err := stream.CloseAndRecv(); err != nil {
+		return err
+	}
+	return errReplicaAnswered
+}
+
+// ReplicateBackup queues a backup for replication now, e.g. after the
+// secondary was down for longer than the retries lasted.
+func (s *OrchestratorService) ReplicateBackup(ctx context.Context, req *backupV1.ReplicateBackupRequest) (*backupV1.ReplicateBackupResponse, error) {
+	if s.replicator == nil {
+		return nil, status.Error(codes.FailedPrecondition, "replication is not configured (BACKUP_REPLICATION_TARGET)")
+	}
+	if !validPathElement(req.BackupId) {
+		return nil, status.Error(codes.InvalidArgument, "invalid backup ID")
+	}
+	var err error
+	if req.Full {
+		_, err = s.storage.GetFullBackup(req.BackupId)
+	} else {
+		_, err = s.storage.GetModuleBackup(req.BackupId)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "backup %s not found", req.BackupId)
+	}
+	st := s.replicator.Enqueue(req.BackupId, req.Full)
+	if st == nil {
+		return nil, status.Error(codes.Internal, "could not queue the backup for replication")
+	}
+	s.log.Infof("Backup %s (full=%v) queued for replication by %q", req.BackupId, req.Full, getUsernameFromContext(ctx))
+	return &backupV1.ReplicateBackupResponse{Replication: st}, nil
+}
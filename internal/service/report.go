@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+)
+
+// retentionDays is the default age after which backup:cleanup-old deletes
+// module backups (BACKUP_RETENTION_DAYS, default 30).
+func retentionDays() int {
+	if d := envInt("BACKUP_RETENTION_DAYS", 30); d > 0 {
+		return d
+	}
+	return 30
+}
+
+func reportPeriod(period string) (string, time.Duration, error) {
+	switch period {
+	case "", "daily":
+		return "daily", 24 * time.Hour, nil
+	case "weekly":
+		return "weekly", 7 * 24 * time.Hour, nil
+	default:
+		return "", 0, fmt.Errorf("unsupported report period %q, expected daily or weekly", period)
+	}
+}
+
+// GenerateBackupReport summarizes backup activity over the last day or week.
+func (s *OrchestratorService) GenerateBackupReport(ctx context.Context, req *backupV1.GenerateBackupReportRequest) (*backupV1.GenerateBackupReportResponse, error) {
+	end := time.Now()
+	if req.EndTime != nil {
+		end = req.EndTime.AsTime()
+	}
+	report, err := s.buildReport(req.Period, end, req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+	doc, contentType, err := renderReport(report, req.Format)
+	if err != nil {
+		return nil, err
+	}
+	return &backupV1.GenerateBackupReportResponse{Report: report, Document: doc, ContentType: contentType}, nil
+}
+
+func (s *OrchestratorService) buildReport(period string, end time.Time, tenantID *uint32) (*backupV1.BackupReport, error) {
+	period, length, err := reportPeriod(period)
+	if err != nil {
+		return nil, err
+	}
+	start := end.Add(-length)
+	inPeriod := func(t time.Time) bool { return !t.Before(start) && t.Before(end) }
+
+	report := &backupV1.BackupReport{
+		Period:      period,
+		StartTime:   timestamppb.New(start),
+		EndTime:     timestamppb.New(end),
+		GeneratedAt: timestamppb.Now(),
+	}
+	modules := make(map[string]*backupV1.ReportModuleSummary)
+	moduleSummary := func(id string) *backupV1.ReportModuleSummary {
+		if m, ok := modules[id]; ok {
+			return m
+		}
+		m := &backupV1.ReportModuleSummary{ModuleId: id}
+		modules[id] = m
+		return m
+	}
+
+	backups, err := s.storage.ListModuleBackups("", tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("list module backups: %w", err)
+	}
+	retention := time.Duration(retentionDays()) * 24 * time.Hour
+	for _, b := range backups {
+		created := b.GetCreatedAt().AsTime()
+		if b.Status == "corrupt" {
+			// Listed until dealt with, whenever it was written; retention
+			// leaves it alone.
+			report.CorruptBackups = append(report.CorruptBackups, b.Id)
+			continue
+		}
+		if deletesAt := created.Add(retention); !deletesAt.Before(end) && deletesAt.Before(end.Add(length)) {
+			report.UpcomingDeletions = append(report.UpcomingDeletions, &backupV1.RetentionCandidate{
+				BackupId:  b.Id,
+				ModuleId:  b.ModuleId,
+				CreatedAt: b.CreatedAt,
+				DeletesAt: timestamppb.New(deletesAt),
+			})
+		}
+		if !inPeriod(created) {
+			continue
+		}
+		m := moduleSummary(b.ModuleId)
+		m.Backups++
+		m.TotalBytes += b.SizeBytes
+		report.TotalBackups++
+		report.TotalBytes += b.SizeBytes
+		if b.Status == "failed" {
+			m.Failed++
+			report.FailedBackups++
+			report.Failures = append(report.Failures, &backupV1.ReportFailure{
+				BackupId:  b.Id,
+				ModuleId:  b.ModuleId,
+				CreatedAt: b.CreatedAt,
+				Errors:    b.Warnings,
+			})
+		}
+	}
+
+	fulls, err := s.storage.ListFullBackups(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("list full backups: %w", err)
+	}
+	for _, fb := range fulls {
+		if !inPeriod(fb.GetCreatedAt().AsTime()) {
+			continue
+		}
+		report.TotalBackups++
+		report.TotalBytes += fb.TotalSizeBytes
+		if fb.Status == "failed" || fb.Status == "canceled" || fb.ValidationStatus == validationUnusable {
+			report.FailedBackups++
+			report.Failures = append(report.Failures, &backupV1.ReportFailure{
+				BackupId:  fb.Id,
+				CreatedAt: fb.CreatedAt,
+				Errors:    append(append([]string(nil), fb.Errors...), fb.ValidationReasons...),
+			})
+		}
+		for _, mb := range fb.ModuleBackups {
+			m := moduleSummary(mb.ModuleId)
+			m.Backups++
+			m.TotalBytes += mb.SizeBytes
+			if mb.Status != "completed" {
+				m.Failed++
+			}
+		}
+	}
+
+	if report.StorageUsedBytes, err = s.storage.DiskUsage(); err != nil {
+		s.log.Warnf("Report: %v", err)
+	}
+
+	for _, m := range modules {
+		report.Modules = append(report.Modules, m)
+	}
+	sort.Slice(report.Modules, func(i, j int) bool { return report.Modules[i].ModuleId < report.Modules[j].ModuleId })
+	sort.Slice(report.UpcomingDeletions, func(i, j int) bool {
+		return report.UpcomingDeletions[i].DeletesAt.AsTime().Before(report.UpcomingDeletions[j].DeletesAt.AsTime())
+	})
+	return report, nil
+}
+
+// renderReport renders the report as JSON or HTML.
+func renderReport(report *backupV1.BackupReport, format string) (string, string, error) {
+	switch format {
+	case "", "json":
+		doc, err := protojson.MarshalOptions{Indent: "  ", EmitUnpopulated: true}.Marshal(report)
+		if err != nil {
+			return "", "", fmt.Errorf("marshal report: %w", err)
+		}
+		return string(doc), "application/json", nil
+	case "html":
+		var b strings.Builder
+		if err := reportHTML.Execute(&b, report); err != nil {
+			return "", "", fmt.Errorf("render report: %w", err)
+		}
+		return b.String(), "text/html; charset=utf-8", nil
+	default:
+		return "", "", fmt.Errorf("unsupported report format %q, expected json or html", format)
+	}
+}
+
+// reportSummary is the one-paragraph version sent to Slack.
+func reportSummary(r *backupV1.BackupReport) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Backup %s report %s to %s: %d backups, %d failed, %s created, %s stored",
+		r.Period, r.StartTime.AsTime().Format(time.DateOnly), r.EndTime.AsTime().Format(time.DateOnly),
+		r.TotalBackups, r.FailedBackups, FormatBytes(r.TotalBytes), FormatBytes(r.StorageUsedBytes))
+	if n := len(r.UpcomingDeletions); n > 0 {
+		fmt.Fprintf(&b, ", %d due for retention deletion", n)
+	}
+	if n := len(r.CorruptBackups); n > 0 {
+		fmt.Fprintf(&b, ", %d corrupt awaiting repair or deletion", n)
+	}
+	for _, f := range r.Failures {
+		name := f.ModuleId
+		if name == "" {
+			name = "full backup"
+		}
+		fmt.Fprintf(&b, "\n- %s %s: %s", name, f.BackupId, strings.Join(f.Errors, "; "))
+	}
+	return b.String()
+}
+
+// FormatBytes renders a byte count with binary units, e.g. "1.5 MiB".
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+var reportHTML = template.Must(template.New("report").Funcs(template.FuncMap{
+	"bytes": FormatBytes,
+	"time": func(ts *timestamppb.Timestamp) string {
+		return ts.AsTime().UTC().Format("2006-01-02 15:04 MST")
+	},
+	"join": strings.Join,
+}).Parse(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>Backup {{.Period}} report</title>
+<style>body{font-family:sans-serif}table{border-collapse:collapse}td,th{border:1px solid #ccc;padding:4px 8px;text-align:left}.failed{color:#b00}</style>
+</head><body>
+<h1>Backup {{.Period}} report</h1>
+<p>{{time .StartTime}} &ndash; {{time .EndTime}}</p>
+<ul>
+<li>Backups: {{.TotalBackups}}</li>
+<li class="{{if .FailedBackups}}failed{{end}}">Failed: {{.FailedBackups}}</li>
+<li>Created: {{bytes .TotalBytes}}</li>
+<li>Storage used: {{bytes .StorageUsedBytes}}</li>
+</ul>
+{{if .Failures}}<h2>Failures</h2>
+<table><tr><th>Backup</th><th>Module</th><th>Created</th><th>Errors</th></tr>
+{{range .Failures}}<tr><td>{{.BackupId}}</td><td>{{if .ModuleId}}{{.ModuleId}}{{else}}full{{end}}</td><td>{{time .CreatedAt}}</td><td>{{join .Errors "; "}}</td></tr>
+{{end}}</table>{{end}}
+{{if .CorruptBackups}}<h2>Corrupt backups</h2>
+<p class="failed">Unreadable metadata; repair or delete: {{join .CorruptBackups ", "}}</p>{{end}}
+{{if .Modules}}<h2>Modules</h2>
+<table><tr><th>Module</th><th>Backups</th><th>Failed</th><th>Size</th></tr>
+{{range .Modules}}<tr><td>{{.ModuleId}}</td><td>{{.Backups}}</td><td>{{.Failed}}</td><td>{{bytes .TotalBytes}}</td></tr>
+{{end}}</table>{{end}}
+{{if .UpcomingDeletions}}<h2>Upcoming retention deletions</h2>
+<table><tr><th>Backup</th><th>Module</th><th>Created</th><th>Deletes at</th></tr>
+{{range .UpcomingDeletions}}<tr><td>{{.BackupId}}</td><td>{{.ModuleId}}</td><td>{{time .CreatedAt}}</td><td>{{time .DeletesAt}}</td></tr>
+{{end}}</table>{{end}}
+</body></html>
+`))
@@ -0,0 +1,441 @@
+package service
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+)
+
+// ExportRepository writes the backups matching the filters, all of them by
+// default, to a bundle file on the orchestrator's host for cold offline
+// storage: encrypted with the password, signed with the key in
+// BACKUP_BUNDLE_SIGNING_KEY, and carrying the catalog of its backups and the
+// format they are stored in. A delta goes with the backups it is based on,
+// so the bundle restores on its own. Backups keep their own encryption
+// inside the bundle.
+func (s *OrchestratorService) ExportRepository(ctx context.Context, req *backupV1.ExportRepositoryRequest) (*backupV1.ExportRepositoryResponse, error) {
+	if !isPlatformAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "only a platform admin can export the repository")
+	}
+	if req.Password == "" {
+		return nil, status.Error(codes.InvalidArgument, "password is required: it encrypts the bundle")
+	}
+	path, err := s.bundlePath(req.DestinationPath, "destination_path")
+	if err != nil {
+		return nil, err
+	}
+	key, err := bundleSigningKey()
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+	}
+
+	ctx, done, err := s.jobs.Begin(ctx, "repository export")
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	ctx, unlock, err := s.storage.LockOperations(ctx, "repository export")
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	refs, entries, warnings, err := s.bundleContents(BackupFilter{
+		ModuleID:      req.ModuleId,
+		TenantID:      req.TenantId,
+		CreatedAfter:  timeOrZero(req.CreatedAfter),
+		CreatedBefore: timeOrZero(req.CreatedBefore),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if errors.Is(err, os.ErrExist) {
+		return nil, status.Errorf(codes.AlreadyExists, "%s exists already", path)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "create bundle: %v", err)
+	}
+	sum := sha256.New()
+	header := bundleHeader{CreatedAt: time.Now().UTC(), CreatedBy: getUsernameFromContext(ctx), Site: localSite()}
+	err = s.writeBundle(ctx, io.MultiWriter(f, sum), header, key, req.Password, refs, entries)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path)
+		if ctx.Err() != nil {
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+		return nil, status.Errorf(codes.Internal, "write bundle: %v", err)
+	}
+
+	resp := &backupV1.ExportRepositoryResponse{
+		Path:     path,
+		Sha256:   hex.EncodeToString(sum.Sum(nil)),
+		Signer:   keyFingerprint(key.Public().(ed25519.PublicKey)),
+		Warnings: warnings,
+	}
+	if fi, err := os.Stat(path); err == nil {
+		resp.SizeBytes = fi.Size()
+	}
+	for _, ref := range refs {
+		if ref.full {
+			resp.FullBackups++
+		} else {
+			resp.ModuleBackups++
+		}
+	}
+	s.log.Infof("Repository exported to %s by %q: %d module and %d full backups, %d bytes, signed by %s",
+		path, getUsernameFromContext(ctx), resp.ModuleBackups, resp.FullBackups, resp.SizeBytes, resp.Signer)
+	return resp, nil
+}
+
+// ImportRepository imports the backups of a bundle written by
+// ExportRepository. The signature is checked before anything is decrypted;
+// the backups are then unpacked into the spool, which needs room for them,
+// and imported as ImportCatalog imports a storage tree, each checked
+// against its checksums and the bundle's catalog.
+func (s *OrchestratorService) ImportRepository(ctx context.Context, req *backupV1.ImportRepositoryRequest) (*backupV1.ImportRepositoryResponse, error) {
+	if !isPlatformAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "only a platform admin can import a repository bundle")
+	}
+	path, err := s.bundlePath(req.SourcePath, "source_path")
+	if err != nil {
+		return nil, err
+	}
+	trustedKeys, err := bundleTrustedKeys()
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "BACKUP_BUNDLE_TRUSTED_KEYS: %v", err)
+	}
+
+	ctx, done, err := s.jobs.Begin(ctx, "repository import")
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "open bundle: %v", err)
+	}
+	defer f.Close()
+	b, err := openBundle(f)
+	if errors.Is(err, ErrUnsupportedFormat) {
+		return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	signer := ed25519.PublicKey(b.SignerKey)
+	trusted := slices.ContainsFunc(trustedKeys, func(k ed25519.PublicKey) bool { return k.Equal(signer) })
+	if len(trustedKeys) > 0 && !trusted {
+		return nil, status.Errorf(codes.PermissionDenied, "bundle is signed by %s, which is not one of BACKUP_BUNDLE_TRUSTED_KEYS", keyFingerprint(signer))
+	}
+
+	r, err := b.reader(f, req.Password)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	spool := filepath.Join(s.storage.basePath, "spool")
+	if err := os.MkdirAll(spool, 0o755); err != nil {
+		return nil, status.Errorf(codes.Internal, "create spool: %v", err)
+	}
+	root, err := os.MkdirTemp(spool, "bundle-")
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "create spool: %v", err)
+	}
+	defer os.RemoveAll(root)
+	format, entries, err := extractBundle(ctx, r, root)
+	switch {
+	case ctx.Err() != nil:
+		return nil, status.FromContextError(ctx.Err()).Err()
+	case errors.Is(err, errBundlePassword):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case err != nil:
+		return nil, status.Errorf(codes.InvalidArgument, "invalid bundle: %v", err)
+	case format.DataFormat > FormatVersion:
+		return nil, status.Errorf(codes.FailedPrecondition, "%v: bundle holds data files of format %d, this build reads up to %d",
+			ErrUnsupportedFormat, format.DataFormat, FormatVersion)
+	}
+
+	src, err := OpenBackupStorage(root)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	src.log = s.storage.log
+	result, err := s.storage.ImportBackups(src, catalogRefs(entries), req.DryRun)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "import bundle: %v", err)
+	}
+	s.log.Infof("Repository bundle %s imported by %q (dry run %v), signed by %s (trusted %v): %d imported, %d present, %d invalid, %d missing",
+		path, getUsernameFromContext(ctx), req.DryRun, keyFingerprint(signer), trusted, result.Imported, result.Present, result.Invalid, result.Missing)
+	return &backupV1.ImportRepositoryResponse{
+		Signer:        keyFingerprint(signer),
+		SignerTrusted: trusted,
+		ExportedAt:    timestamppb.New(b.CreatedAt),
+		ExportedBy:    b.CreatedBy,
+		Site:          b.Site,
+		Catalog:       result,
+	}, nil
+}
+
+// bundlePath checks a bundle path given in a request: the bundle must not be
+// inside the storage, where it would be taken for part of it.
+func (s *OrchestratorService) bundlePath(path, field string) (string, error) {
+	if path == "" {
+		return "", status.Errorf(codes.InvalidArgument, "%s is required", field)
+	}
+	path = filepath.Clean(path)
+	if !filepath.IsAbs(path) {
+		return "", status.Errorf(codes.InvalidArgument, "%s must be absolute", field)
+	}
+	if rel, err := filepath.Rel(s.storage.basePath, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return "", status.Errorf(codes.InvalidArgument, "%s is inside this orchestrator's storage", field)
+	}
+	return path, nil
+}
+
+// bundleContents selects the backups to export, oldest first, with their
+// catalog rows. Backups that cannot go are left out with a warning.
+func (s *OrchestratorService) bundleContents(f BackupFilter) ([]backupRef, []*backupV1.CatalogEntry, []string, error) {
+	modules, err := s.storage.FindModuleBackups(f)
+	if err != nil {
+		return nil, nil, nil, status.Errorf(codes.Internal, "list module backups: %v", err)
+	}
+	fulls, err := s.storage.FindFullBackups(f)
+	if err != nil {
+		return nil, nil, nil, status.Errorf(codes.Internal, "list full backups: %v", err)
+	}
+
+	type item struct {
+		ref     backupRef
+		created time.Time
+		entries []*backupV1.CatalogEntry
+	}
+	var items []item
+	var warnings []string
+	added := map[string]bool{}
+	// add takes a module backup and, for a delta, the backups it is based
+	// on, which the filters may leave out.
+	var add func(b *backupV1.BackupInfo) error
+	add = func(b *backupV1.BackupInfo) error {
+		if added[b.Id] {
+			return nil
+		}
+		if b.Status == "corrupt" {
+			return fmt.Errorf("its metadata is unreadable")
+		}
+		if b.DeltaBaseId != "" {
+			base, err := s.storage.GetModuleBackup(b.DeltaBaseId)
+			if err == nil {
+				err = add(base)
+			}
+			if err != nil {
+				return fmt.Errorf("delta base %s: %w", b.DeltaBaseId, err)
+			}
+		}
+		added[b.Id] = true
+		items = append(items, item{backupRef{id: b.Id}, b.CreatedAt.AsTime(), []*backupV1.CatalogEntry{moduleCatalogEntry(b, "module")}})
+		return nil
+	}
+	for _, b := range modules {
+		if err := add(b); err != nil {
+			warnings = append(warnings, fmt.Sprintf("module backup %s left out: %v", b.Id, err))
+		}
+	}
+	for _, b := range fulls {
+		items = append(items, item{backupRef{id: b.Id, full: true}, b.CreatedAt.AsTime(), fullCatalogEntries(b)})
+	}
+
+	slices.SortStableFunc(items, func(a, b item) int { return a.created.Compare(b.created) })
+	refs := make([]backupRef, 0, len(items))
+	var entries []*backupV1.CatalogEntry
+	for _, it := range items {
+		refs = append(refs, it.ref)
+		entries = append(entries, it.entries...)
+	}
+	return refs, entries, warnings, nil
+}
+
+// writeBundle writes a bundle of the given backups to w.
+func (s *OrchestratorService) writeBundle(ctx context.Context, w io.Writer, h bundleHeader, key ed25519.PrivateKey, password string, refs []backupRef, entries []*backupV1.CatalogEntry) error {
+	bw, err := newBundleWriter(w, h, key, password)
+	if err != nil {
+		return err
+	}
+	tw := tar.NewWriter(bw)
+	put := func(name string, data []byte) error {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(data)), ModTime: h.CreatedAt}); err != nil {
+			return err
+		}
+		_, err := tw.Write(data)
+		return err
+	}
+
+	format := bundleFormat{BundleVersion: BundleVersion, DataFormat: FormatVersion, Metadata: "protojson"}
+	for _, ref := range refs {
+		if ref.full {
+			format.FullBackups++
+		} else {
+			format.ModuleBackups++
+		}
+	}
+	doc, err := json.MarshalIndent(format, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := put("format.json", doc); err != nil {
+		return err
+	}
+	marshal := protojson.MarshalOptions{Indent: "  ", EmitUnpopulated: true}
+	if doc, err = marshal.Marshal(&backupV1.ExportCatalogResponse{Entries: entries}); err != nil {
+		return err
+	}
+	if err := put("catalog.json", doc); err != nil {
+		return err
+	}
+
+	for _, ref := range refs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		dir, meta, err := s.storage.copySource(ref)
+		if err != nil {
+			return fmt.Errorf("backup %s: %w", ref.id, err)
+		}
+		prefix := importKindDir(ref) + "/" + ref.id + "/"
+		if err := writeBundleFiles(tw, dir, prefix, h.CreatedAt); err != nil {
+			return fmt.Errorf("backup %s: %w", ref.id, err)
+		}
+		if doc, err = marshal.Marshal(meta); err != nil {
+			return err
+		}
+		if err := put(prefix+"metadata.json", doc); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return bw.Close()
+}
+
+func writeBundleFiles(tw *tar.Writer, dir, prefix string, modTime time.Time) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if !isReplicaFile(e.Name()) || !e.Type().IsRegular() {
+			continue
+		}
+		f, err := os.Open(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return err
+		}
+		fi, err := f.Stat()
+		if err == nil {
+			err = tw.WriteHeader(&tar.Header{Name: prefix + e.Name(), Mode: 0o644, Size: fi.Size(), ModTime: modTime})
+		}
+		if err == nil {
+			_, err = io.Copy(tw, f)
+		}
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", e.Name(), err)
+		}
+	}
+	return nil
+}
+
+// extractBundle unpacks the archive of a bundle into a storage tree at root
+// and returns its format and catalog.
+func extractBundle(ctx context.Context, r io.Reader, root string) (bundleFormat, []*backupV1.CatalogEntry, error) {
+	var format bundleFormat
+	var entries []*backupV1.CatalogEntry
+	seenFormat := false
+	tr := tar.NewReader(r)
+	for {
+		if err := ctx.Err(); err != nil {
+			return format, nil, err
+		}
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return format, nil, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			return format, nil, fmt.Errorf("unexpected entry %q", hdr.Name)
+		}
+
+		switch parts := strings.Split(hdr.Name, "/"); {
+		case hdr.Name == "format.json":
+			if err := json.NewDecoder(tr).Decode(&format); err != nil {
+				return format, nil, fmt.Errorf("format.json: %w", err)
+			}
+			seenFormat = true
+		case hdr.Name == "catalog.json":
+			doc, err := io.ReadAll(tr)
+			if err != nil {
+				return format, nil, err
+			}
+			var catalog backupV1.ExportCatalogResponse
+			if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(doc, &catalog); err != nil {
+				return format, nil, fmt.Errorf("catalog.json: %w", err)
+			}
+			entries = catalog.Entries
+		case len(parts) == 3 && (parts[0] == "modules" || parts[0] == "full") && validPathElement(parts[1]) && validPathElement(parts[2]):
+			dir := filepath.Join(root, parts[0], parts[1])
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				return format, nil, err
+			}
+			out, err := os.OpenFile(filepath.Join(dir, parts[2]), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+			if err != nil {
+				return format, nil, err
+			}
+			_, err = io.Copy(out, tr)
+			if cerr := out.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				return format, nil, fmt.Errorf("%s: %w", hdr.Name, err)
+			}
+		default:
+			return format, nil, fmt.Errorf("unexpected entry %q", hdr.Name)
+		}
+	}
+	if !seenFormat {
+		return format, nil, fmt.Errorf("no format.json")
+	}
+	return format, entries, nil
+}
+
+// importKindDir is where a backup of the kind is stored in a storage tree.
+func importKindDir(ref backupRef) string {
+	if ref.full {
+		return "full"
+	}
+	return "modules"
+}
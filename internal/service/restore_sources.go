@@ -0,0 +1,369 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+)
+
+// Restores read a backup from its nearest healthy copy. The local copy comes
+// first; when it is missing or cannot be read, the copies in the mirror and
+// on peer orchestrators are tried, those at this orchestrator's site before
+// those elsewhere and those that failed lately last. A remote copy is
+// fetched into the spool, checked against its recorded checksums and read
+// from there; the local storage is left as it is. Test restores read the
+// local copy only, since checking it is their point.
+//
+//	BACKUP_SITE                             site or region of this orchestrator, recorded on new backups
+//	BACKUP_MIRROR_SITE                      site of the mirror
+//	BACKUP_REPLICATION_SITE                 site of the secondary; it must serve FetchReplica (BACKUP_FEDERATION_SERVE)
+//	BACKUP_FEDERATION_SITE                  site of the primary backups are pulled from
+//	BACKUP_RESTORE_SOURCES                  copies restores may read, preferred in this order when as near (default "local,mirror,peer")
+//	BACKUP_RESTORE_SOURCE_COOLDOWN_SECONDS  how long a copy that failed is tried last (default 300)
+//
+// Which copies a backup has comes from its local metadata; when that is
+// gone, every configured copy is tried.
+
+func localSite() string {
+	return strings.TrimSpace(os.Getenv("BACKUP_SITE"))
+}
+
+// restoreSources picks the copies a restore reads.
+type restoreSources struct {
+	log        *log.Helper
+	storage    *BackupStorage
+	mirror     *Mirror
+	replicator *Replicator
+	federation *Federation
+	order      []string
+	cooldown   time.Duration
+
+	mu     sync.Mutex
+	failed map[string]time.Time // by copy target
+}
+
+func newRestoreSources(l *log.Helper, storage *BackupStorage, mirror *Mirror, replicator *Replicator, federation *Federation) *restoreSources {
+	order := envList("BACKUP_RESTORE_SOURCES")
+	if len(order) == 0 {
+		order = []string{"local", "mirror", "peer"}
+	}
+	cooldown := envInt("BACKUP_RESTORE_SOURCE_COOLDOWN_SECONDS", 300)
+	if cooldown < 0 {
+		cooldown = 300
+	}
+	return &restoreSources{
+		log:        l,
+		storage:    storage,
+		mirror:     mirror,
+		replicator: replicator,
+		federation: federation,
+		order:      order,
+		cooldown:   time.Duration(cooldown) * time.Second,
+		failed:     map[string]time.Time{},
+	}
+}
+
+// backupCopies lists the copies of a backup this orchestrator knows of,
+// given its status and copy statuses.
+func (rs *restoreSources) backupCopies(status string, replication, mirror, origin *backupV1.ReplicationStatus) []*backupV1.BackupCopy {
+	copies := []*backupV1.BackupCopy{{
+		Source:    "local",
+		Site:      localSite(),
+		Target:    rs.storage.basePath,
+		State:     status,
+		Available: status == "completed" || status == "partial",
+	}}
+	add := func(source string, st *backupV1.ReplicationStatus) {
+		if st != nil {
+			copies = append(copies, &backupV1.BackupCopy{
+				Source: source, Site: st.Site, Target: st.Target, State: st.State, Available: st.State == copyDone,
+			})
+		}
+	}
+	add("mirror", mirror)
+	add("peer", replication)
+	add("peer", origin)
+	return copies
+}
+
+// A restoreSource is one copy a restore may read. fetch copies the backup
+// into a directory and returns its metadata; the local copy has none.
+type restoreSource struct {
+	copy  *backupV1.BackupCopy
+	fetch func(ctx context.Context, ref backupRef, dir string) (proto.Message, error)
+}
+
+// candidates ranks the copies of a backup a restore may read. meta is its
+// local metadata, or nil when that cannot be read.
+func (rs *restoreSources) candidates(meta proto.Message) []restoreSource {
+	var copies []*backupV1.BackupCopy
+	switch info := meta.(type) {
+	case *backupV1.BackupInfo:
+		copies = rs.backupCopies(info.Status, info.Replication, info.Mirror, info.Origin)
+	case *backupV1.FullBackupInfo:
+		copies = rs.backupCopies(info.Status, info.Replication, info.Mirror, info.Origin)
+	default:
+		copies = []*backupV1.BackupCopy{{Source: "local", Site: localSite(), Target: rs.storage.basePath}}
+		if rs.mirror != nil {
+			copies = append(copies, &backupV1.BackupCopy{Source: "mirror", Site: rs.mirror.site, Target: rs.mirror.target})
+		}
+		if rs.replicator != nil {
+			copies = append(copies, &backupV1.BackupCopy{Source: "peer", Site: rs.replicator.site, Target: rs.replicator.target})
+		}
+		if rs.federation != nil {
+			copies = append(copies, &backupV1.BackupCopy{Source: "peer", Site: rs.federation.site, Target: rs.federation.peer})
+		}
+	}
+
+	var sources []restoreSource
+	for _, c := range copies {
+		if !slices.Contains(rs.order, c.Source) {
+			continue
+		}
+		// The local copy is always tried: its metadata may be wrong.
+		if meta != nil && !c.Available && c.Source != "local" {
+			continue
+		}
+		src := restoreSource{copy: c}
+		switch {
+		case c.Source == "local":
+		case c.Source == "mirror" && rs.mirror != nil && c.Target == rs.mirror.target:
+			src.fetch = rs.mirror.fetch
+		case c.Source == "peer" && rs.replicator != nil && c.Target == rs.replicator.target:
+			client := rs.replicator.client
+			src.fetch = func(ctx context.Context, ref backupRef, dir string) (proto.Message, error) {
+				return fetchReplica(ctx, client, ref, dir)
+			}
+		case c.Source == "peer" && rs.federation != nil && c.Target == rs.federation.peer:
+			client := rs.federation.client
+			src.fetch = func(ctx context.Context, ref backupRef, dir string) (proto.Message, error) {
+				return fetchReplica(ctx, client, ref, dir)
+			}
+		default:
+			// A copy at a target this orchestrator no longer reaches.
+			continue
+		}
+		sources = append(sources, src)
+	}
+
+	here := localSite()
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rank := func(src restoreSource) [3]int {
+		if src.copy.Source == "local" {
+			return [3]int{}
+		}
+		var r [3]int
+		if t, ok := rs.failed[src.copy.Target]; ok && time.Since(t) < rs.cooldown {
+			r[0] = 1
+		}
+		if src.copy.Site == "" || src.copy.Site != here {
+			r[1] = 1
+		}
+		r[2] = slices.Index(rs.order, src.copy.Source)
+		return r
+	}
+	sort.SliceStable(sources, func(i, j int) bool {
+		a, b := rank(sources[i]), rank(sources[j])
+		return slices.Compare(a[:], b[:]) < 0
+	})
+	return sources
+}
+
+func (rs *restoreSources) markFailed(target string) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rs.failed[target] = time.Now()
+}
+
+// restoreReader reads the data of one backup for a restore. Each module is
+// read from the local copy when it can be; a remote copy is fetched at most
+// once and serves every module the local copy cannot.
+type restoreReader struct {
+	rs       *restoreSources
+	ref      backupRef
+	password string
+	sources  []restoreSource
+
+	remote   *BackupStorage // the remote copy in the spool
+	from     *backupV1.BackupCopy
+	warnings []string // how the remote copy was found
+}
+
+func (rs *restoreSources) reader(ref backupRef, password string) *restoreReader {
+	var meta proto.Message
+	rs.storage.mu.RLock()
+	if ref.full {
+		if info, err := rs.storage.readFullMetadata(ref.id); err == nil {
+			meta = info
+		}
+	} else if info, err := rs.storage.readModuleMetadata(ref.id); err == nil {
+		meta = info
+	}
+	rs.storage.mu.RUnlock()
+	return &restoreReader{rs: rs, ref: ref, password: password, sources: rs.candidates(meta)}
+}
+
+// Close removes the fetched remote copy.
+func (r *restoreReader) Close() {
+	if r.remote != nil {
+		os.RemoveAll(r.remote.basePath)
+	}
+}
+
+// load reads the export of a module backup, or of one module of a full
+// backup, and returns the copy it came from, with warnings when that is not
+// the local one.
+func (r *restoreReader) load(ctx context.Context, moduleID string) ([]byte, *backupV1.BackupCopy, []string, error) {
+	var localErr error
+	if len(r.sources) > 0 && r.sources[0].fetch == nil {
+		data, err := r.read(ctx, r.rs.storage, moduleID)
+		if err == nil {
+			return data, r.sources[0].copy, nil, nil
+		}
+		if errors.Is(err, ErrBackupPassword) || ctx.Err() != nil || len(r.sources) == 1 {
+			return nil, nil, nil, err
+		}
+		localErr = err
+	}
+	if err := r.fetchRemote(ctx, localErr); err != nil {
+		return nil, nil, nil, err
+	}
+	data, err := r.read(ctx, r.remote, moduleID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("%s copy at %s: %w", r.from.Source, r.from.Target, err)
+	}
+	return data, r.from, slices.Clone(r.warnings), nil
+}
+
+// fullInfo returns the metadata of a full backup, from the local copy or,
+// when it is gone there, from a remote one.
+func (r *restoreReader) fullInfo(ctx context.Context) (*backupV1.FullBackupInfo, error) {
+	info, err := r.rs.storage.GetFullBackup(r.ref.id)
+	if err == nil {
+		return info, nil
+	}
+	if len(r.sources) <= 1 {
+		return nil, err
+	}
+	if err := r.fetchRemote(ctx, err); err != nil {
+		return nil, err
+	}
+	return r.remote.GetFullBackup(r.ref.id)
+}
+
+func (r *restoreReader) read(ctx context.Context, st *BackupStorage, moduleID string) ([]byte, error) {
+	if r.ref.full {
+		return st.LoadFullBackupModuleData(ctx, r.ref.id, moduleID, r.password)
+	}
+	return st.LoadModuleBackupData(ctx, r.ref.id, r.password)
+}
+
+// fetchRemote fetches the backup from the first remote copy that has it,
+// unless that is done already. localErr is why the local copy would not do.
+func (r *restoreReader) fetchRemote(ctx context.Context, localErr error) error {
+	if r.remote != nil {
+		return nil
+	}
+	var errs []error
+	if localErr != nil {
+		errs = append(errs, fmt.Errorf("local copy: %w", localErr))
+		r.warnings = append(r.warnings, fmt.Sprintf("local copy unreadable: %v", localErr))
+	}
+	for _, src := range r.sources {
+		if src.fetch == nil {
+			continue
+		}
+		remote, err := r.fetch(ctx, src)
+		if err == nil {
+			r.remote, r.from = remote, src.copy
+			r.warnings = append(r.warnings, fmt.Sprintf("read from the %s copy at %s%s", src.copy.Source, src.copy.Target, siteSuffix(src.copy.Site)))
+			r.rs.log.Warnf("Restoring backup %s from the %s copy at %s: %s", r.ref.id, src.copy.Source, src.copy.Target, strings.Join(r.warnings, "; "))
+			return nil
+		}
+		if ctx.Err() != nil {
+			return err
+		}
+		// A copy that lacks this backup may still serve others.
+		if status.Code(err) != codes.NotFound {
+			r.rs.markFailed(src.copy.Target)
+		}
+		errs = append(errs, fmt.Errorf("%s copy at %s: %w", src.copy.Source, src.copy.Target, err))
+		r.warnings = append(r.warnings, fmt.Sprintf("%s copy at %s failed: %v", src.copy.Source, src.copy.Target, err))
+	}
+	if len(errs) == 0 {
+		return fmt.Errorf("backup %s has no copy this orchestrator can read", r.ref.id)
+	}
+	return errors.Join(errs...)
+}
+
+// fetch copies the backup, and for a delta every backup it is based on,
+// from src into a new storage tree in the spool.
+func (r *restoreReader) fetch(ctx context.Context, src restoreSource) (*BackupStorage, error) {
+	spool := filepath.Join(r.rs.storage.basePath, "spool")
+	if err := os.MkdirAll(spool, 0o755); err != nil {
+		return nil, err
+	}
+	root, err := os.MkdirTemp(spool, "restore-")
+	if err != nil {
+		return nil, err
+	}
+	st, err := OpenBackupStorage(root)
+	if err != nil {
+		os.RemoveAll(root)
+		return nil, err
+	}
+	st.log = r.rs.storage.log
+
+	ref := r.ref
+	for range 1000 {
+		dir := st.moduleDir(ref.id)
+		if ref.full {
+			dir = st.fullDir(ref.id)
+		}
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			break
+		}
+		var meta proto.Message
+		if meta, err = src.fetch(ctx, ref, dir); err != nil {
+			break
+		}
+		if err = checkBackupData(dir, meta); err != nil {
+			break
+		}
+		if err = writeMetadata(dir, meta); err != nil {
+			break
+		}
+		info, ok := meta.(*backupV1.BackupInfo)
+		if !ok || info.DeltaBaseId == "" {
+			return st, nil
+		}
+		ref = backupRef{id: info.DeltaBaseId}
+	}
+	if err == nil {
+		err = fmt.Errorf("delta chain of %s is too long", r.ref.id)
+	}
+	os.RemoveAll(root)
+	return nil, err
+}
+
+func siteSuffix(site string) string {
+	if site == "" {
+		return ""
+	}
+	return " (" + site + ")"
+}
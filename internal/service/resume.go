@@ -0,0 +1,266 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+)
+
+// A full backup created with an idempotency key can be retried without
+// starting over. Its spool keeps a job record under .resume/ and, next to
+// each module's data file, a record of that module, written once the data
+// file is complete. A failed or canceled attempt leaves the spool in place
+// instead of discarding it, and so does a restart, for
+// BACKUP_RESUME_HOURS (default 24). A retry with the same key and tenant
+// picks the spool up, keeps the modules whose data file still matches its
+// record and exports only the rest. The backup only becomes visible once
+// its manifest is written and the spool is renamed into place, as for any
+// full backup; a retry after that gets the saved backup back.
+
+const resumeDir = ".resume"
+
+// resumeCheck is encrypted with the backup's password in the job record,
+// so a retry with a different password is refused rather than mixing keys
+// in one backup.
+var resumeCheck = []byte("go-tangra-backup resume")
+
+type spoolJob struct {
+	Key           string    `json:"key"`
+	TenantID      uint32    `json:"tenantId"`
+	CreatedAt     time.Time `json:"createdAt"`
+	PasswordCheck []byte    `json:"passwordCheck,omitempty"`
+}
+
+// resumeRetention is how long an unfinished keyed spool is kept.
+func resumeRetention() time.Duration {
+	h := envInt("BACKUP_RESUME_HOURS", 24)
+	if h <= 0 {
+		h = 24
+	}
+	return time.Duration(h) * time.Hour
+}
+
+// OpenFullBackupSpool returns the spool for a full backup. Without a key it
+// is a new spool under backupID. With one, it is the spool an earlier
+// attempt with the same key and tenant left, along with the modules that
+// attempt already wrote, or a new spool under backupID if there is none.
+func (s *BackupStorage) OpenFullBackupSpool(backupID, key string, tenantID uint32, password string, c compression) (*FullBackupSpool, map[string]*backupV1.BackupInfo, error) {
+	if key == "" {
+		sp, err := s.NewFullBackupSpool(backupID, password, c)
+		return sp, nil, err
+	}
+
+	s.resumeMu.Lock()
+	defer s.resumeMu.Unlock()
+	if s.resuming[key] {
+		return nil, nil, status.Error(codes.Aborted, "a full backup with this idempotency key is already running")
+	}
+
+	var job *spoolJob
+	var done map[string]*backupV1.BackupInfo
+	if id, found := s.findKeyedSpool(key, tenantID); found != nil {
+		if found.PasswordCheck != nil {
+			if password == "" {
+				return nil, nil, status.Error(codes.InvalidArgument, "the backup being resumed is encrypted: password required")
+			}
+			if _, err := DecryptData(found.PasswordCheck, password); err != nil {
+				return nil, nil, status.Error(codes.InvalidArgument, "the password differs from the one the backup being resumed was started with")
+			}
+		} else if password != "" {
+			return nil, nil, status.Error(codes.InvalidArgument, "the backup being resumed is not encrypted: retry without a password or with a new idempotency key")
+		}
+		backupID, job = id, found
+		done = s.resumableModules(filepath.Join(s.basePath, "spool", id))
+		s.log.Infof("Resuming full backup %s (key %q) with %d modules already written", id, key, len(done))
+	} else {
+		job = &spoolJob{Key: key, TenantID: tenantID, CreatedAt: time.Now()}
+		if password != "" {
+			check, err := EncryptData(resumeCheck, password)
+			if err != nil {
+				return nil, nil, fmt.Errorf("encrypt resume check: %w", err)
+			}
+			job.PasswordCheck = check
+		}
+	}
+
+	sp, err := s.NewFullBackupSpool(backupID, password, c)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := writeSpoolJob(sp.dir, job); err != nil {
+		sp.Discard()
+		return nil, nil, err
+	}
+	sp.key, sp.createdAt = key, job.CreatedAt
+	s.resuming[key] = true
+	return sp, done, nil
+}
+
+// findKeyedSpool returns the ID and job of the spool left for key and
+// tenantID, removing expired keyed spools on the way.
+func (s *BackupStorage) findKeyedSpool(key string, tenantID uint32) (string, *spoolJob) {
+	spool := filepath.Join(s.basePath, "spool")
+	entries, err := os.ReadDir(spool)
+	if err != nil {
+		return "", nil
+	}
+	for _, e := range entries {
+		job, err := readSpoolJob(filepath.Join(spool, e.Name()))
+		if err != nil {
+			continue
+		}
+		if time.Since(job.CreatedAt) > resumeRetention() && !s.resuming[job.Key] {
+			os.RemoveAll(filepath.Join(spool, e.Name()))
+			continue
+		}
+		if job.Key == key && job.TenantID == tenantID {
+			return e.Name(), job
+		}
+	}
+	return "", nil
+}
+
+// resumableModules returns the modules of dir whose data file is complete
+// and still matches its record. Anything else is written again.
+func (s *BackupStorage) resumableModules(dir string) map[string]*backupV1.BackupInfo {
+	entries, err := os.ReadDir(filepath.Join(dir, resumeDir))
+	if err != nil {
+		return nil
+	}
+	done := map[string]*backupV1.BackupInfo{}
+	for _, e := range entries {
+		moduleID, ok := strings.CutSuffix(e.Name(), ".module.json")
+		if !ok {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, resumeDir, e.Name()))
+		if err != nil {
+			continue
+		}
+		mb := &backupV1.BackupInfo{}
+		if protojson.Unmarshal(data, mb) != nil || mb.ModuleId != moduleID {
+			continue
+		}
+		f, _, err := openDataFile(dir, moduleID)
+		if err != nil {
+			continue
+		}
+		stored, err := os.ReadFile(f.Name())
+		f.Close()
+		if err != nil || checksum(stored) != mb.StoredSha256 {
+			s.log.Warnf("Module %s of resumed full backup %s no longer matches its record; exporting it again", moduleID, filepath.Base(dir))
+			continue
+		}
+		done[moduleID] = mb
+	}
+	return done
+}
+
+// recordModule notes that mb's data file in the spool is complete.
+func (sp *FullBackupSpool) recordModule(mb *backupV1.BackupInfo) error {
+	if sp.key == "" {
+		return nil
+	}
+	if err := writeProtoFile(filepath.Join(sp.dir, resumeDir, mb.ModuleId+".module.json"), mb); err != nil {
+		return fmt.Errorf("record %s for resume: %w", mb.ModuleId, err)
+	}
+	return nil
+}
+
+// release lets the key be used again once the attempt is over.
+func (sp *FullBackupSpool) release() {
+	if sp.key == "" {
+		return
+	}
+	sp.s.resumeMu.Lock()
+	delete(sp.s.resuming, sp.key)
+	sp.s.resumeMu.Unlock()
+}
+
+func writeSpoolJob(dir string, job *spoolJob) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("marshal spool job: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, resumeDir), 0o755); err != nil {
+		return fmt.Errorf("create resume dir: %w", err)
+	}
+	path := filepath.Join(dir, resumeDir, "job.json")
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("write spool job: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("write spool job: %w", err)
+	}
+	return nil
+}
+
+func readSpoolJob(dir string) (*spoolJob, error) {
+	data, err := os.ReadFile(filepath.Join(dir, resumeDir, "job.json"))
+	if err != nil {
+		return nil, err
+	}
+	var job spoolJob
+	if err := json.Unmarshal(data, &job); err != nil {
+		return nil, err
+	}
+	if job.Key == "" {
+		return nil, errors.New("spool job without a key")
+	}
+	return &job, nil
+}
+
+// clearSpool removes the spools of full backups that never finished and
+// the exports left behind at startup, except keyed spools that may still be
+// resumed and whatever replicas that are still running are writing.
+func (s *BackupStorage) clearSpool() {
+	spool := filepath.Join(s.basePath, "spool")
+	entries, err := os.ReadDir(spool)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		dir := filepath.Join(spool, e.Name())
+		if instance, ok := exportFileInstance(e.Name()); ok {
+			if s.lock.ownerAlive(instance, 0) {
+				continue
+			}
+		} else if j, err := s.readJournalEntry(e.Name()); err == nil && s.lock.ownerAlive(j.Instance, j.LeaseToken) {
+			continue
+		}
+		if job, err := readSpoolJob(dir); err == nil && time.Since(job.CreatedAt) <= resumeRetention() {
+			continue
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			s.log.Warnf("Failed to clear stale spool %s: %v", e.Name(), err)
+		}
+	}
+}
+
+// FindFullBackupByKey returns the saved full backup created with key for
+// tenantID, or nil if there is none.
+func (s *BackupStorage) FindFullBackupByKey(key string, tenantID uint32) (*backupV1.FullBackupInfo, error) {
+	summaries, err := s.FindFullBackupSummaries(BackupFilter{TenantID: &tenantID})
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range summaries {
+		if b.IdempotencyKey == key {
+			return s.GetFullBackup(b.Id)
+		}
+	}
+	return nil, nil
+}
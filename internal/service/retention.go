@@ -0,0 +1,90 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// RetentionPolicy keeps the newest KeepLast backups plus the newest backup of
+// each of the last KeepDaily days, KeepWeekly ISO weeks and KeepMonthly
+// months that have one. Periods are taken in UTC.
+type RetentionPolicy struct {
+	KeepLast    int
+	KeepDaily   int
+	KeepWeekly  int
+	KeepMonthly int
+}
+
+// Empty reports whether the policy keeps nothing, which would delete every
+// backup it is applied to.
+func (p RetentionPolicy) Empty() bool {
+	return p.KeepLast <= 0 && p.KeepDaily <= 0 && p.KeepWeekly <= 0 && p.KeepMonthly <= 0
+}
+
+// RetentionItem is one backup a policy is applied to. Backups that did not
+// complete are never kept by the rules: they cannot be restored from. Pinned
+// backups are always kept.
+type RetentionItem struct {
+	ID        string
+	CreatedAt time.Time
+	Completed bool
+	Pinned    bool
+}
+
+// Apply splits the items of one series (one module, or the full backups of
+// one tenant) into those to keep, with the rules that keep them, and those
+// to delete.
+func (p RetentionPolicy) Apply(items []RetentionItem) (keep map[string][]string, remove []RetentionItem) {
+	sorted := make([]RetentionItem, len(items))
+	copy(sorted, items)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].CreatedAt.After(sorted[j].CreatedAt) })
+
+	type bucketRule struct {
+		name  string
+		count int
+		key   func(time.Time) string
+		seen  map[string]bool
+	}
+	rules := []*bucketRule{
+		{name: "daily", count: p.KeepDaily, key: func(t time.Time) string { return t.Format("2006-01-02") }},
+		{name: "weekly", count: p.KeepWeekly, key: func(t time.Time) string {
+			y, w := t.ISOWeek()
+			return fmt.Sprintf("%d-W%02d", y, w)
+		}},
+		{name: "monthly", count: p.KeepMonthly, key: func(t time.Time) string { return t.Format("2006-01") }},
+	}
+	for _, r := range rules {
+		r.seen = make(map[string]bool)
+	}
+
+	keep = make(map[string][]string)
+	last := 0
+	for _, it := range sorted {
+		if it.Pinned {
+			keep[it.ID] = append(keep[it.ID], "pinned")
+		}
+		if !it.Completed {
+			if !it.Pinned {
+				remove = append(remove, it)
+			}
+			continue
+		}
+		if last < p.KeepLast {
+			keep[it.ID] = append(keep[it.ID], "last")
+			last++
+		}
+		t := it.CreatedAt.UTC()
+		for _, r := range rules {
+			k := r.key(t)
+			if len(r.seen) < r.count && !r.seen[k] {
+				r.seen[k] = true
+				keep[it.ID] = append(keep[it.ID], r.name)
+			}
+		}
+		if len(keep[it.ID]) == 0 {
+			remove = append(remove, it)
+		}
+	}
+	return keep, remove
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryPolicy controls how module calls are retried on transient failures.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	RetryableCodes map[codes.Code]bool
+}
+
+// moduleRetryPolicy reads the retry policy for module calls:
+//
+//	BACKUP_MODULE_RETRY_ATTEMPTS           total attempts (default 3, 1 disables retries)
+//	BACKUP_MODULE_RETRY_INITIAL_BACKOFF_MS default 500, doubled per attempt
+//	BACKUP_MODULE_RETRY_MAX_BACKOFF_MS     default 10000
+//	BACKUP_MODULE_RETRY_CODES              default "UNAVAILABLE"
+//
+// RESOURCE_EXHAUSTED is not retried by default: grpc-go also reports a
+// message over the size limit with it, which fails the same way every time.
+func moduleRetryPolicy(l *log.Helper) RetryPolicy {
+	p := RetryPolicy{
+		MaxAttempts:    envInt("BACKUP_MODULE_RETRY_ATTEMPTS", 3),
+		InitialBackoff: time.Duration(envInt("BACKUP_MODULE_RETRY_INITIAL_BACKOFF_MS", 500)) * time.Millisecond,
+		MaxBackoff:     time.Duration(envInt("BACKUP_MODULE_RETRY_MAX_BACKOFF_MS", 10000)) * time.Millisecond,
+		RetryableCodes: map[codes.Code]bool{},
+	}
+	if p.MaxAttempts < 1 {
+		p.MaxAttempts = 1
+	}
+
+	names := envList("BACKUP_MODULE_RETRY_CODES")
+	if os.Getenv("BACKUP_MODULE_RETRY_CODES") == "" {
+		names = []string{"UNAVAILABLE"}
+	}
+	for _, name := range names {
+		var c codes.Code
+		if err := c.UnmarshalJSON([]byte(`"` + strings.ToUpper(name) + `"`)); err != nil {
+			l.Warnf("Ignoring unknown retry code %q", name)
+			continue
+		}
+		p.RetryableCodes[c] = true
+	}
+	return p
+}
+
+// backoff returns the delay before the given retry (1-based), with jitter.
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	d := p.InitialBackoff << (retry - 1)
+	if d <= 0 || d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	// +/-20% so parallel full-backup exports don't retry in lockstep.
+	return time.Duration(float64(d) * (0.8 + 0.4*rand.Float64()))
+}
+
+// Do runs fn until it succeeds, fails with a non-retryable code, or runs out
+// of attempts. It returns one warning per retried failure.
+func (p RetryPolicy) Do(ctx context.Context, fn func() error) ([]string, error) {
+	var warnings []string
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return warnings, nil
+		}
+		var final noRetry
+		if attempt >= p.MaxAttempts || errors.As(err, &final) || !p.RetryableCodes[status.Code(err)] {
+			if attempt > 1 {
+				err = fmt.Errorf("after %d attempts: %w", attempt, err)
+			}
+			return warnings, err
+		}
+
+		delay := p.backoff(attempt)
+		warnings = append(warnings, fmt.Sprintf("attempt %d failed, retrying in %s: %v", attempt, delay.Round(time.Millisecond), err))
+		select {
+		case <-ctx.Done():
+			return warnings, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// noRetry marks a failure Do must not retry whatever its code, such as an
+// import the module may already have applied in part.
+type noRetry struct{ error }
+
+func (e noRetry) Unwrap() error { return e.error }
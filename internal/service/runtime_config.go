@@ -0,0 +1,232 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/tx7do/kratos-bootstrap/bootstrap"
+)
+
+// reloadableKeys are the settings a runtime config file may change while the
+// orchestrator runs. Anything that shapes listeners, TLS, storage or module
+// connections needs a restart and is ignored here.
+var reloadableKeys = []string{
+	"BACKUP_MIN_SUCCESS_PERCENT",
+	"BACKUP_REQUIRED_MODULES",
+	"BACKUP_FRESHNESS_SLO_HOURS",
+	"BACKUP_FRESHNESS_SLO_OVERRIDES",
+	"BACKUP_QUOTA_TENANT_MAX_MB",
+	"BACKUP_QUOTA_TENANT_MAX_BACKUPS",
+	"BACKUP_QUOTA_MODULE_MAX_MB",
+	"BACKUP_QUOTA_MODULE_MAX_BACKUPS",
+	"BACKUP_QUOTA_OVERRIDES",
+	"BACKUP_MODULE_RETRY_ATTEMPTS",
+	"BACKUP_MODULE_RETRY_INITIAL_BACKOFF_MS",
+	"BACKUP_MODULE_RETRY_MAX_BACKOFF_MS",
+	"BACKUP_MODULE_RETRY_CODES",
+	"BACKUP_COMPRESSION",
+	"BACKUP_COMPRESSION_LEVEL",
+	"BACKUP_COMPRESSION_WORKERS",
+	"BACKUP_DELTA_MAX_CHAIN",
+	"BACKUP_BANDWIDTH_LIMIT_KBPS",
+	"BACKUP_BANDWIDTH_OVERRIDES",
+	"BACKUP_RETENTION_DAYS",
+	"BACKUP_BALLOON_THRESHOLD_PERCENT",
+	"BACKUP_SLACK_WEBHOOK_URL",
+	"BACKUP_SLACK_CHANNEL",
+	"BACKUP_SLACK_TEMPLATE",
+	"BACKUP_EMAIL_TO",
+	"BACKUP_EMAIL_SUBJECT",
+	"BACKUP_EMAIL_TEMPLATE",
+	"BACKUP_SMTP_HOST",
+	"BACKUP_SMTP_PORT",
+	"BACKUP_SMTP_USERNAME",
+	"BACKUP_SMTP_PASSWORD",
+	"BACKUP_SMTP_FROM",
+}
+
+// RuntimeConfig overlays the reloadable settings from a KEY=value file on
+// top of the process environment and re-applies them when the file changes:
+//
+//	BACKUP_RUNTIME_CONFIG               path of the file; unset disables reloading
+//	BACKUP_RUNTIME_CONFIG_POLL_SECONDS  how often to check it (default 10)
+//
+// SIGHUP forces a reload. A key removed from the file falls back to its
+// value from the environment the process started with.
+type RuntimeConfig struct {
+	log      *log.Helper
+	path     string
+	interval time.Duration
+
+	mu       sync.Mutex
+	original map[string]*string // startup environment of every key the file has set
+	applied  map[string]string
+	modTime  time.Time
+	size     int64
+	hooks    []func()
+}
+
+// NewRuntimeConfig applies the runtime config file once and starts watching
+// it. The returned cleanup stops the watcher.
+func NewRuntimeConfig(ctx *bootstrap.Context) (*RuntimeConfig, func(), error) {
+	c := &RuntimeConfig{
+		log:      ctx.NewLoggerHelper("backup/runtime-config"),
+		path:     os.Getenv("BACKUP_RUNTIME_CONFIG"),
+		interval: time.Duration(envInt("BACKUP_RUNTIME_CONFIG_POLL_SECONDS", 10)) * time.Second,
+		original: map[string]*string{},
+		applied:  map[string]string{},
+	}
+	if c.path == "" {
+		return c, func() {}, nil
+	}
+	if c.interval <= 0 {
+		c.interval = 10 * time.Second
+	}
+	if _, err := c.reload(false); err != nil {
+		return nil, nil, fmt.Errorf("runtime config: %w", err)
+	}
+
+	stop := make(chan struct{})
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go c.watch(stop, hup)
+	c.log.Infof("Watching runtime config %s every %s", c.path, c.interval)
+
+	return c, func() {
+		signal.Stop(hup)
+		close(stop)
+	}, nil
+}
+
+// OnReload registers fn to rebuild cached settings after every reload that
+// changed something.
+func (c *RuntimeConfig) OnReload(fn func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.hooks = append(c.hooks, fn)
+}
+
+func (c *RuntimeConfig) watch(stop <-chan struct{}, hup <-chan os.Signal) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-hup:
+			c.logReload(c.reload(true))
+		case <-ticker.C:
+			c.logReload(c.reload(false))
+		}
+	}
+}
+
+func (c *RuntimeConfig) logReload(changed []string, err error) {
+	if err != nil {
+		// Keep running with the last good settings.
+		c.log.Warnf("Runtime config reload failed: %v", err)
+		return
+	}
+	if len(changed) > 0 {
+		c.log.Infof("Runtime config reloaded, changed: %s", strings.Join(changed, ", "))
+	}
+}
+
+// reload re-reads the file when it changed since the last read, or always
+// when forced, and returns the keys whose effective value changed.
+func (c *RuntimeConfig) reload(force bool) ([]string, error) {
+	fi, err := os.Stat(c.path)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !force && fi.ModTime().Equal(c.modTime) && fi.Size() == c.size {
+		return nil, nil
+	}
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return nil, err
+	}
+	values, err := parseRuntimeConfig(data)
+	if err != nil {
+		return nil, err
+	}
+	c.modTime, c.size = fi.ModTime(), fi.Size()
+
+	var changed []string
+	for key, value := range values {
+		if !slices.Contains(reloadableKeys, key) {
+			c.log.Warnf("Ignoring %s in runtime config: it cannot change without a restart", key)
+			continue
+		}
+		if _, seen := c.original[key]; !seen {
+			if v, ok := os.LookupEnv(key); ok {
+				c.original[key] = &v
+			} else {
+				c.original[key] = nil
+			}
+		}
+		if old, ok := c.applied[key]; ok && old == value {
+			continue
+		}
+		os.Setenv(key, value)
+		c.applied[key] = value
+		changed = append(changed, key)
+	}
+	for key := range c.applied {
+		if _, ok := values[key]; ok {
+			continue
+		}
+		if v := c.original[key]; v != nil {
+			os.Setenv(key, *v)
+		} else {
+			os.Unsetenv(key)
+		}
+		delete(c.applied, key)
+		changed = append(changed, key)
+	}
+
+	if len(changed) > 0 {
+		for _, fn := range c.hooks {
+			fn()
+		}
+	}
+	slices.Sort(changed)
+	return changed, nil
+}
+
+// parseRuntimeConfig reads KEY=value lines. Blank lines and lines starting
+// with # are skipped; values may be wrapped in single or double quotes.
+func parseRuntimeConfig(data []byte) (map[string]string, error) {
+	values := map[string]string{}
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(strings.TrimPrefix(key, "export "))
+		if !ok || key == "" {
+			return nil, fmt.Errorf("line %d: expected KEY=value", n)
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		values[key] = value
+	}
+	return values, sc.Err()
+}
@@ -79,10 +79,26 @@ func doRegister(endpoint string, l *log.Helper) error {
 				TaskType:        "backup:full-platform",
 				DisplayName:     "Full Platform Backup",
 				Description:     "Create a full backup of all platform modules (all services with BackupService)",
-				PayloadSchema:   `{"type":"object","properties":{"modules":{"type":"array","items":{"type":"string"},"description":"List of module_id:grpc_endpoint pairs. Empty = all defaults."},"password":{"type":"string","description":"Optional encryption password"}}}`,
+				PayloadSchema:   `{"type":"object","properties":{"modules":{"type":"array","items":{"type":"string"},"description":"List of module_id:grpc_endpoint pairs. Empty = all defaults."},"password":{"type":"string","description":"Optional encryption password"},"minSuccessPercent":{"type":"integer","minimum":0,"maximum":100,"description":"Share of modules that must back up successfully. Unset = server default."},"requiredModules":{"type":"array","items":{"type":"string"},"description":"Module IDs that must back up successfully. Empty = server default."},"selector":{"type":"string","description":"Label selector over registered modules, e.g. tier=core,backup=true"},"notify":{"type":"object","description":"Failure notifications for this schedule. Unset fields use the server defaults.","properties":{"slack":{"type":"object","properties":{"webhookUrl":{"type":"string"},"channel":{"type":"string"},"template":{"type":"string"}}},"email":{"type":"object","properties":{"to":{"type":"array","items":{"type":"string"}},"subject":{"type":"string"},"template":{"type":"string"}}}}}}}`,
 				DefaultCron:     "0 2 * * *",
 				DefaultMaxRetry: 1,
 			},
+			{
+				TaskType:        "backup:report",
+				DisplayName:     "Backup Report",
+				Description:     "Summarize backup activity, failures, storage use and upcoming retention deletions",
+				PayloadSchema:   `{"type":"object","properties":{"period":{"type":"string","enum":["daily","weekly"],"default":"daily"},"push":{"type":"boolean","default":false,"description":"Send the report via Slack and email"},"notify":{"type":"object","description":"Recipients for this schedule. Unset fields use the server defaults.","properties":{"slack":{"type":"object","properties":{"webhookUrl":{"type":"string"},"channel":{"type":"string"}}},"email":{"type":"object","properties":{"to":{"type":"array","items":{"type":"string"}}}}}}}}`,
+				DefaultCron:     "0 7 * * *",
+				DefaultMaxRetry: 1,
+			},
+			{
+				TaskType:        "backup:recompact",
+				DisplayName:     "Recompact Old Backups",
+				Description:     "Recompress aging unencrypted backups with a higher-ratio algorithm and level to reclaim space",
+				PayloadSchema:   `{"type":"object","properties":{"minAgeDays":{"type":"integer","default":30},"compression":{"type":"string","enum":["gzip","zstd"],"default":"zstd"},"level":{"type":"integer","description":"Compression level. Unset = the algorithm's highest."},"moduleId":{"type":"string","description":"Only this module's standalone backups. Empty = all backups, full backups included."},"maxMinutes":{"type":"integer","default":60,"description":"Stop starting on further backups after this long"},"dryRun":{"type":"boolean","default":false}}}`,
+				DefaultCron:     "0 5 * * 6",
+				DefaultMaxRetry: 1,
+			},
 		},
 	})
 	if err != nil {
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	commonV1 "github.com/go-tangra/go-tangra-common/gen/go/common/service/v1"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+)
+
+// selectorTerm is one "key=value" or "key!=value" requirement.
+type selectorTerm struct {
+	key    string
+	value  string
+	negate bool
+}
+
+// LabelSelector matches module attributes. Terms are comma-separated and all
+// must hold, e.g. "tier=core,backup!=false". A key alone ("backup") only
+// requires the label to be present.
+type LabelSelector []selectorTerm
+
+// ParseLabelSelector parses a comma-separated selector expression.
+func ParseLabelSelector(expr string) (LabelSelector, error) {
+	var sel LabelSelector
+	for _, raw := range strings.Split(expr, ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		var t selectorTerm
+		switch {
+		case strings.Contains(raw, "!="):
+			parts := strings.SplitN(raw, "!=", 2)
+			t = selectorTerm{key: parts[0], value: parts[1], negate: true}
+		case strings.Contains(raw, "="):
+			parts := strings.SplitN(raw, "=", 2)
+			t = selectorTerm{key: parts[0], value: parts[1]}
+		default:
+			t = selectorTerm{key: raw}
+		}
+		t.key = strings.TrimSpace(t.key)
+		t.value = strings.TrimSpace(t.value)
+		if t.key == "" {
+			return nil, fmt.Errorf("invalid selector term %q", raw)
+		}
+		sel = append(sel, t)
+	}
+	if len(sel) == 0 {
+		return nil, fmt.Errorf("empty selector")
+	}
+	return sel, nil
+}
+
+// Matches reports whether the labels satisfy every term.
+func (s LabelSelector) Matches(labels map[string]string) bool {
+	for _, t := range s {
+		v, ok := labels[t.key]
+		switch {
+		case t.value == "" && !t.negate:
+			if !ok {
+				return false
+			}
+		case t.negate:
+			if ok && v == t.value {
+				return false
+			}
+		default:
+			if !ok || v != t.value {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// builtinLabels are the keys every registered module carries.
+var builtinLabels = []string{"module_id", "module_name", "version", "status", "health"}
+
+// staticModuleLabels reads BACKUP_MODULE_LABELS, e.g.
+// "ipam=tier:core+region:eu,asset=tier:edge", into labels per module ID.
+func staticModuleLabels(l *log.Helper) map[string]map[string]string {
+	out := map[string]map[string]string{}
+	for _, entry := range envList("BACKUP_MODULE_LABELS") {
+		id, list, ok := strings.Cut(entry, "=")
+		id = strings.TrimSpace(id)
+		if !ok || id == "" {
+			l.Warnf("Ignoring malformed BACKUP_MODULE_LABELS entry %q", entry)
+			continue
+		}
+		for _, pair := range strings.Split(list, "+") {
+			k, v, ok := strings.Cut(pair, ":")
+			k = strings.TrimSpace(k)
+			if !ok || k == "" {
+				l.Warnf("Ignoring malformed BACKUP_MODULE_LABELS label %q for %s", pair, id)
+				continue
+			}
+			if out[id] == nil {
+				out[id] = map[string]string{}
+			}
+			out[id][k] = strings.TrimSpace(v)
+		}
+	}
+	return out
+}
+
+// checkLabelKeys rejects selector keys that no label source can supply, so a
+// typo or an unconfigured label fails loudly instead of matching nothing.
+// Registry metadata may carry any key, so nothing is rejected when service
+// discovery is configured.
+func (c *ModuleClient) checkLabelKeys(sel LabelSelector, static map[string]map[string]string) error {
+	if c.discovery != nil {
+		return nil
+	}
+	for _, t := range sel {
+		if slices.Contains(builtinLabels, t.key) {
+			continue
+		}
+		known := false
+		for _, labels := range static {
+			if _, known = labels[t.key]; known {
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("no module has label %q; set it in BACKUP_MODULE_LABELS or use one of %s",
+				t.key, strings.Join(builtinLabels, ", "))
+		}
+	}
+	return nil
+}
+
+// moduleLabels returns the attributes a selector can match for a registered
+// module: its registration fields, the labels configured for it in
+// BACKUP_MODULE_LABELS, and any metadata it published to the service registry.
+// Earlier sources win on conflicting keys.
+func (c *ModuleClient) moduleLabels(ctx context.Context, m *commonV1.Module, static map[string]string) map[string]string {
+	labels := map[string]string{
+		"module_id":   m.GetModuleId(),
+		"module_name": m.GetModuleName(),
+		"version":     m.GetVersion(),
+		"status":      strings.ToLower(strings.TrimPrefix(m.GetStatus().String(), "MODULE_STATUS_")),
+		"health":      strings.ToLower(strings.TrimPrefix(m.GetHealth().String(), "MODULE_HEALTH_")),
+	}
+	for k, v := range static {
+		if _, taken := labels[k]; !taken {
+			labels[k] = v
+		}
+	}
+	if c.discovery == nil {
+		return labels
+	}
+	name := m.GetServerName()
+	if name == "" {
+		name = m.GetModuleId() + "-service"
+	}
+	instances, err := c.discovery.GetService(ctx, name)
+	if err != nil {
+		c.log.Debugf("No registry metadata for %s: %v", name, err)
+		return labels
+	}
+	for _, ins := range instances {
+		for k, v := range ins.Metadata {
+			if _, taken := labels[k]; !taken {
+				labels[k] = v
+			}
+		}
+	}
+	return labels
+}
+
+// SelectTargets lists the modules registered with the admin service and
+// returns those whose labels match the selector. The backup service itself is
+// never selected.
+func (c *ModuleClient) SelectTargets(ctx context.Context, sel LabelSelector) ([]*backupV1.ModuleTarget, error) {
+	static := staticModuleLabels(c.log)
+	if err := c.checkLabelKeys(sel, static); err != nil {
+		return nil, err
+	}
+	modules, err := c.RegisteredModules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var targets []*backupV1.ModuleTarget
+	for _, m := range modules {
+		if m.GetModuleId() == "backup" || m.GetGrpcEndpoint() == "" {
+			continue
+		}
+		if !sel.Matches(c.moduleLabels(ctx, m, static[m.GetModuleId()])) {
+			continue
+		}
+		targets = append(targets, &backupV1.ModuleTarget{
+			ModuleId:     m.GetModuleId(),
+			GrpcEndpoint: m.GetGrpcEndpoint(),
+		})
+	}
+	return targets, nil
+}
+
+// RegisteredModules lists the modules registered with the admin service at
+// ADMIN_GRPC_ENDPOINT.
+func (c *ModuleClient) RegisteredModules(ctx context.Context) ([]*commonV1.Module, error) {
+	adminEndpoint := os.Getenv("ADMIN_GRPC_ENDPOINT")
+	if adminEndpoint == "" {
+		return nil, fmt.Errorf("ADMIN_GRPC_ENDPOINT is not set, cannot list registered modules")
+	}
+	conn, cleanup, err := c.dialModule(adminEndpoint, "admin", nil)
+	if err != nil {
+		return nil, fmt.Errorf("dial admin at %s: %w", adminEndpoint, err)
+	}
+	defer cleanup()
+
+	callCtx, cancel := callContext(forwardMetadata(ctx), unaryCallTimeout)
+	defer cancel()
+	resp, err := commonV1.NewModuleRegistrationServiceClient(conn).ListModules(callCtx, &commonV1.ListModulesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("list registered modules: %w", err)
+	}
+	return resp.GetModules(), nil
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+)
+
+// backupSortKey is what a list can be sorted by.
+type backupSortKey struct {
+	createdAt time.Time
+	size      int64
+	moduleID  string
+	status    string
+	id        string
+}
+
+// backupOrder is the validated sort_by and sort_order of a list request.
+type backupOrder struct {
+	by   string
+	desc bool
+}
+
+// parseBackupOrder validates sort_by against fields. Empty sort_by means
+// created_at. Without sort_order, created_at and size sort descending and
+// module_id and status ascending.
+func parseBackupOrder(sortBy, sortOrder string, fields ...string) (backupOrder, error) {
+	if sortBy == "" {
+		sortBy = "created_at"
+	}
+	if !slices.Contains(fields, sortBy) {
+		return backupOrder{}, fmt.Errorf("sort_by must be one of %s", strings.Join(fields, ", "))
+	}
+
+	o := backupOrder{by: sortBy}
+	switch sortOrder {
+	case "":
+		o.desc = sortBy == "created_at" || sortBy == "size"
+	case "asc":
+	case "desc":
+		o.desc = true
+	default:
+		return backupOrder{}, fmt.Errorf("sort_order must be asc or desc")
+	}
+	return o, nil
+}
+
+// compare orders two keys. Ties fall back to newest first and then to the
+// backup ID, so the order is total and a page token can resume after any key.
+func (o backupOrder) compare(a, b backupSortKey) int {
+	var c int
+	switch o.by {
+	case "created_at":
+		c = a.createdAt.Compare(b.createdAt)
+	case "size":
+		c = cmp.Compare(a.size, b.size)
+	case "module_id":
+		c = cmp.Compare(a.moduleID, b.moduleID)
+	case "status":
+		c = cmp.Compare(a.status, b.status)
+	}
+	if o.desc {
+		c = -c
+	}
+	if c != 0 {
+		return c
+	}
+	if c = b.createdAt.Compare(a.createdAt); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.id, b.id)
+}
+
+func sortBackups[T any](backups []T, o backupOrder, key func(T) backupSortKey) {
+	slices.SortFunc(backups, func(a, b T) int {
+		return o.compare(key(a), key(b))
+	})
+}
+
+var moduleSortFields = []string{"created_at", "size", "module_id", "status"}
+
+// fullSortFields has no module_id: a full backup spans several modules.
+var fullSortFields = []string{"created_at", "size", "status"}
+
+func moduleSortKey(b *backupV1.BackupInfo) backupSortKey {
+	return backupSortKey{createdAt: b.CreatedAt.AsTime(), size: b.SizeBytes, moduleID: b.ModuleId, status: b.Status, id: b.Id}
+}
+
+func fullSortKey(b *backupV1.FullBackupInfo) backupSortKey {
+	return backupSortKey{createdAt: b.CreatedAt.AsTime(), size: b.TotalSizeBytes, status: b.Status, id: b.Id}
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+)
+
+// maxStatisticsBuckets bounds the response size, e.g. one year of days.
+const maxStatisticsBuckets = 1000
+
+// statSample is one stored backup reduced to what statistics need.
+type statSample struct {
+	moduleID   string // empty for full backups
+	tenantID   uint32
+	createdAt  time.Time
+	failed     bool
+	sizeBytes  int64
+	compressed int64
+	durationMs int64
+}
+
+// moduleSizeSample is one module's successful export, standalone or as part
+// of a full backup, used for growth trends.
+type moduleSizeSample struct {
+	moduleID  string
+	tenantID  uint32
+	createdAt time.Time
+	sizeBytes int64
+	ratio     float64 // 0 when not recorded
+}
+
+// GetBackupStatistics aggregates stored backup metadata into time buckets
+// and per-module growth trends. A module is flagged as ballooned when its
+// newest export grew by more than BACKUP_BALLOON_THRESHOLD_PERCENT (default
+// 50, 0 disables) over the previous one.
+func (s *OrchestratorService) GetBackupStatistics(ctx context.Context, req *backupV1.GetBackupStatisticsRequest) (*backupV1.GetBackupStatisticsResponse, error) {
+	end := time.Now()
+	if req.EndTime != nil {
+		end = req.EndTime.AsTime()
+	}
+	start := end.AddDate(0, 0, -30)
+	if req.StartTime != nil {
+		start = req.StartTime.AsTime()
+	}
+	if !start.Before(end) {
+		return nil, fmt.Errorf("start_time must be before end_time")
+	}
+
+	var width time.Duration
+	switch req.Bucket {
+	case "hour":
+		width = time.Hour
+	case "", "day":
+		width = 24 * time.Hour
+	case "week":
+		width = 7 * 24 * time.Hour
+	default:
+		return nil, fmt.Errorf("unsupported bucket %q, expected hour, day or week", req.Bucket)
+	}
+	start = start.UTC().Truncate(width)
+	if end.Sub(start)/width > maxStatisticsBuckets {
+		return nil, fmt.Errorf("range too large for %s buckets (max %d)", req.Bucket, maxStatisticsBuckets)
+	}
+
+	samples, sizes, err := s.statisticsSamples(req.TenantId, req.ModuleId, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := make([]*backupV1.BackupStatisticsBucket, 0, int(end.Sub(start)/width)+1)
+	durations := make([]struct{ sum, n int64 }, cap(buckets))
+	for t := start; t.Before(end); t = t.Add(width) {
+		buckets = append(buckets, &backupV1.BackupStatisticsBucket{Start: timestamppb.New(t)})
+	}
+
+	resp := &backupV1.GetBackupStatisticsResponse{Buckets: buckets}
+	var durationSum, durationN int64
+	for _, smp := range samples {
+		b := buckets[int(smp.createdAt.Sub(start)/width)]
+		b.BackupCount++
+		resp.TotalBackups++
+		if smp.failed {
+			b.FailedCount++
+			resp.FailedBackups++
+		}
+		b.TotalBytes += smp.sizeBytes
+		b.TotalCompressedBytes += smp.compressed
+		resp.TotalBytes += smp.sizeBytes
+		if smp.durationMs > 0 {
+			d := &durations[int(smp.createdAt.Sub(start)/width)]
+			d.sum += smp.durationMs
+			d.n++
+			durationSum += smp.durationMs
+			durationN++
+		}
+	}
+	for i, b := range buckets {
+		if b.BackupCount > 0 {
+			b.FailureRate = float64(b.FailedCount) / float64(b.BackupCount)
+		}
+		if durations[i].n > 0 {
+			b.AvgDurationMs = durations[i].sum / durations[i].n
+		}
+	}
+	if resp.TotalBackups > 0 {
+		resp.FailureRate = float64(resp.FailedBackups) / float64(resp.TotalBackups)
+	}
+	if durationN > 0 {
+		resp.AvgDurationMs = durationSum / durationN
+	}
+
+	resp.ModuleTrends = moduleTrends(sizes, float64(envInt("BACKUP_BALLOON_THRESHOLD_PERCENT", 50)))
+	return resp, nil
+}
+
+// statisticsSamples collects module and full backups created in [start, end).
+func (s *OrchestratorService) statisticsSamples(tenantID *uint32, moduleID string, start, end time.Time) ([]statSample, []moduleSizeSample, error) {
+	inRange := func(t time.Time) bool { return !t.Before(start) && t.Before(end) }
+
+	var samples []statSample
+	var sizes []moduleSizeSample
+
+	modules, err := s.storage.ListModuleBackups(moduleID, tenantID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list module backups: %w", err)
+	}
+	for _, b := range modules {
+		created := b.GetCreatedAt().AsTime()
+		if !inRange(created) || b.Status == "corrupt" {
+			continue
+		}
+		failed := b.Status == "failed"
+		samples = append(samples, statSample{
+			moduleID:   b.ModuleId,
+			tenantID:   b.TenantId,
+			createdAt:  created,
+			failed:     failed,
+			sizeBytes:  b.SizeBytes,
+			compressed: b.CompressedSizeBytes,
+			durationMs: b.DurationMs,
+		})
+		if !failed {
+			sizes = append(sizes, moduleSizeSample{b.ModuleId, b.TenantId, created, b.SizeBytes, b.CompressionRatio})
+		}
+	}
+
+	fulls, err := s.storage.ListFullBackups(tenantID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list full backups: %w", err)
+	}
+	for _, fb := range fulls {
+		created := fb.GetCreatedAt().AsTime()
+		if !inRange(created) {
+			continue
+		}
+		if moduleID == "" {
+			samples = append(samples, statSample{
+				tenantID:   fb.TenantId,
+				createdAt:  created,
+				failed:     fb.Status == "failed" || fb.Status == "canceled",
+				sizeBytes:  fb.TotalSizeBytes,
+				compressed: fb.TotalCompressedSizeBytes,
+				durationMs: fb.DurationMs,
+			})
+		}
+		for _, mb := range fb.ModuleBackups {
+			if moduleID != "" && mb.ModuleId != moduleID {
+				continue
+			}
+			if moduleID != "" {
+				// Filtering by module counts its slice of each full backup.
+				samples = append(samples, statSample{
+					moduleID:   mb.ModuleId,
+					tenantID:   fb.TenantId,
+					createdAt:  created,
+					failed:     mb.Status != "completed",
+					sizeBytes:  mb.SizeBytes,
+					compressed: mb.CompressedSizeBytes,
+				})
+			}
+			if mb.Status == "completed" {
+				sizes = append(sizes, moduleSizeSample{mb.ModuleId, fb.TenantId, created, mb.SizeBytes, mb.CompressionRatio})
+			}
+		}
+	}
+	return samples, sizes, nil
+}
+
+// moduleTrends compares each module's oldest and newest successful backup
+// size per tenant, and flags modules whose newest export grew by more than
+// balloonPercent over the one before it.
+func moduleTrends(sizes []moduleSizeSample, balloonPercent float64) []*backupV1.ModuleBackupTrend {
+	type key struct {
+		moduleID string
+		tenantID uint32
+	}
+	series := make(map[key][]moduleSizeSample)
+	for _, smp := range sizes {
+		k := key{smp.moduleID, smp.tenantID}
+		series[k] = append(series[k], smp)
+	}
+
+	trends := make([]*backupV1.ModuleBackupTrend, 0, len(series))
+	for k, ss := range series {
+		sort.Slice(ss, func(i, j int) bool { return ss[i].createdAt.Before(ss[j].createdAt) })
+		first, last := ss[0], ss[len(ss)-1]
+
+		t := &backupV1.ModuleBackupTrend{
+			ModuleId:             k.moduleID,
+			TenantId:             k.tenantID,
+			BackupCount:          int32(len(ss)),
+			FirstSizeBytes:       first.sizeBytes,
+			LastSizeBytes:        last.sizeBytes,
+			GrowthBytes:          last.sizeBytes - first.sizeBytes,
+			GrowthPercent:        growthPercent(first.sizeBytes, last.sizeBytes),
+			LastCompressionRatio: last.ratio,
+		}
+		if len(ss) > 1 {
+			t.LastGrowthPercent = growthPercent(ss[len(ss)-2].sizeBytes, last.sizeBytes)
+			t.Ballooned = balloonPercent > 0 && t.LastGrowthPercent > balloonPercent
+		}
+
+		var ratioSum float64
+		var ratioN int
+		for _, smp := range ss {
+			if smp.ratio > 0 {
+				ratioSum += smp.ratio
+				ratioN++
+			}
+		}
+		if ratioN > 0 {
+			t.AvgCompressionRatio = ratioSum / float64(ratioN)
+		}
+		trends = append(trends, t)
+	}
+	sort.Slice(trends, func(i, j int) bool {
+		if trends[i].ModuleId != trends[j].ModuleId {
+			return trends[i].ModuleId < trends[j].ModuleId
+		}
+		return trends[i].TenantId < trends[j].TenantId
+	})
+	return trends
+}
+
+func growthPercent(from, to int64) float64 {
+	if from <= 0 {
+		return 0
+	}
+	return float64(to-from) * 100 / float64(from)
+}
@@ -3,49 +3,84 @@ package service
 import (
 	"bytes"
 	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/tx7do/kratos-bootstrap/bootstrap"
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 
 	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
 )
 
+// ErrBackupPinned is returned when deleting a pinned backup.
+var ErrBackupPinned = errors.New("backup is pinned")
+
 // BackupStorage manages backup metadata and data on the filesystem.
 // No database — all state is stored as files.
 type BackupStorage struct {
 	basePath string
 	log      *log.Helper
 	mu       sync.RWMutex
+	meta     *metadataCache
+	lock     *OperationLock
+
+	resumeMu sync.Mutex
+	resuming map[string]bool // idempotency keys of full backups in progress
 }
 
 // NewBackupStorage creates a new filesystem-backed backup storage.
 func NewBackupStorage(ctx *bootstrap.Context) *BackupStorage {
-	basePath := os.Getenv("BACKUP_STORAGE_PATH")
-	if basePath == "" {
-		basePath = "/data/backups"
-	}
+	basePath := StoragePath()
 
 	l := ctx.NewLoggerHelper("backup/storage")
+	s := &BackupStorage{basePath: basePath, log: l, meta: newMetadataCache(), lock: newOperationLock(basePath, l), resuming: map[string]bool{}}
 
 	// Ensure base directories exist
-	for _, sub := range []string{"modules", "full"} {
+	for _, sub := range []string{"modules", "full", "restores", "spool", "journal"} {
 		dir := filepath.Join(basePath, sub)
 		if err := os.MkdirAll(dir, 0o755); err != nil {
 			l.Warnf("Failed to create storage directory %s: %v", dir, err)
 		}
 	}
 
+	// Other replicas may be writing backups while this one starts; recovery
+	// leaves alone whatever the replicas holding a presence lease write.
+	s.lock.keepPresence()
+	s.lock.prunePresence()
+
+	// A spool left behind belongs to a full backup that never finished.
+	s.clearSpool()
+
+	s.recoverJournal()
+	s.migrateMetadataOnce()
+
 	l.Infof("BackupStorage initialized at %s", basePath)
-	return &BackupStorage{basePath: basePath, log: l}
+	return s
+}
+
+// StoragePath returns the configured storage root, BACKUP_STORAGE_PATH or
+// /data/backups.
+func StoragePath() string {
+	if p := os.Getenv("BACKUP_STORAGE_PATH"); p != "" {
+		return p
+	}
+	return "/data/backups"
 }
 
 // --- Module Backups ---
@@ -54,35 +89,104 @@ func (s *BackupStorage) moduleDir(backupID string) string {
 	return filepath.Join(s.basePath, "modules", backupID)
 }
 
-// SaveModuleBackup persists backup metadata and gzipped data to disk.
-// If password is non-empty, the gzipped data is encrypted with AES-256-GCM.
-func (s *BackupStorage) SaveModuleBackup(info *backupV1.BackupInfo, data []byte, password string) error {
+// SaveModuleBackup persists backup metadata and data compressed as c asks.
+// If password is non-empty, the compressed data is encrypted with
+// AES-256-GCM.
+func (s *BackupStorage) SaveModuleBackup(ctx context.Context, info *backupV1.BackupInfo, data []byte, password string, c compression) (err error) {
+	ctx, span := startSpan(ctx, "storage.SaveModuleBackup", attribute.String("backup.id", info.Id), attribute.String("module.id", info.ModuleId))
+	defer func() { endSpan(span, err) }()
+
+	// A delta depends on its base, which another replica could delete (and
+	// rebase its dependents) before this backup's metadata exists. Saves
+	// that may pick a base therefore take the operation lock.
+	if password == "" && envInt("BACKUP_DELTA_MAX_CHAIN", 0) > 0 {
+		var unlock func()
+		if ctx, unlock, err = s.LockOperations(ctx, "delta backup"); err != nil {
+			return err
+		}
+		defer unlock()
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	timings := phaseTimings(info)
+	info.Sha256 = checksum(data)
+	if info.Site == "" {
+		info.Site = localSite()
+	}
+
+	// Unencrypted backups may be stored as a delta against the module's
+	// previous backup.
+	source, base := data, "data.json"
+	if password == "" {
+		if prev, delta := s.deltaAgainstPrevious(ctx, info, data); prev != nil {
+			source, base = delta, "data.delta"
+			info.DeltaBaseId, info.DeltaDepth = prev.Id, prev.DeltaDepth+1
+		}
+	}
+
 	dir := s.moduleDir(info.Id)
+	if err := s.journalBegin(journalEntry{
+		Kind:        "module",
+		BackupID:    info.Id,
+		ModuleID:    info.ModuleId,
+		Description: info.Description,
+		TenantID:    info.TenantId,
+		FullBackup:  info.FullBackup,
+		CreatedAt:   info.CreatedAt.AsTime(),
+		CreatedBy:   info.CreatedBy,
+	}); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			os.RemoveAll(dir)
+		}
+		s.journalEnd(info.Id)
+	}()
 	if err := os.MkdirAll(dir, 0o755); err != nil {
 		return fmt.Errorf("create backup dir: %w", err)
 	}
 
 	// Compress data
-	compressed, err := gzipCompress(data)
+	phaseStart := time.Now()
+	compressed, err := tracedCompress(ctx, c, source)
 	if err != nil {
 		return fmt.Errorf("compress data: %w", err)
 	}
+	timings.CompressMs = time.Since(phaseStart).Milliseconds()
+	info.Compression = c.algo
+	info.CompressionLevel = int32(c.effectiveLevel())
+	info.CompressedSizeBytes = int64(len(compressed))
+	info.CompressionRatio = compressionRatio(int64(len(data)), info.CompressedSizeBytes)
 
 	// Optionally encrypt
-	filename := "data.json.gz"
+	filename := base + CompressionExt(c.algo)
 	payload := compressed
 	if password != "" {
-		encrypted, err := encryptData(compressed, password)
+		phaseStart = time.Now()
+		encrypted, err := tracedEncrypt(ctx, compressed, password)
 		if err != nil {
 			return fmt.Errorf("encrypt data: %w", err)
 		}
+		timings.EncryptMs = time.Since(phaseStart).Milliseconds()
 		payload = encrypted
-		filename = "data.json.gz.enc"
+		filename += ".enc"
 		info.Encrypted = true
 	}
+	payload = FrameStoredData(c.algo, info.Encrypted, payload)
+	info.StoredSha256 = checksum(payload)
+
+	// Write data first so the recorded write time lands in the metadata
+	phaseStart = time.Now()
+	if err := os.WriteFile(filepath.Join(dir, filename), payload, 0o644); err != nil {
+		return fmt.Errorf("write data: %w", err)
+	}
+	timings.WriteMs = time.Since(phaseStart).Milliseconds()
+	// The caller's duration covers the export; add the phases spent here.
+	info.DurationMs += timings.CompressMs + timings.EncryptMs + timings.WriteMs
+	info.ThroughputBytesPerSec = throughput(info.SizeBytes, timings)
 
 	// Write metadata (use protojson for correct timestamp/zero-value handling)
 	marshaler := protojson.MarshalOptions{Indent: "  ", EmitUnpopulated: true}
@@ -90,80 +194,159 @@ func (s *BackupStorage) SaveModuleBackup(info *backupV1.BackupInfo, data []byte,
 	if err != nil {
 		return fmt.Errorf("marshal metadata: %w", err)
 	}
-	if err := os.WriteFile(filepath.Join(dir, "metadata.json"), metaBytes, 0o644); err != nil {
+	metaPath := filepath.Join(dir, "metadata.json")
+	s.meta.invalidate(metaPath)
+	if err := os.WriteFile(metaPath, metaBytes, 0o644); err != nil {
 		return fmt.Errorf("write metadata: %w", err)
 	}
 
-	if err := os.WriteFile(filepath.Join(dir, filename), payload, 0o644); err != nil {
-		return fmt.Errorf("write data: %w", err)
+	s.log.Infof("Saved module backup %s (%d bytes, encrypted=%v)", info.Id, len(payload), info.Encrypted)
+	return nil
+}
+
+// SaveModuleBackupRecord persists metadata only, for backups that failed
+// before producing any data, so they show up in history and statistics.
+func (s *BackupStorage) SaveModuleBackupRecord(info *backupV1.BackupInfo) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if info.Site == "" {
+		info.Site = localSite()
 	}
 
-	s.log.Infof("Saved module backup %s (%d bytes, encrypted=%v)", info.Id, len(payload), info.Encrypted)
+	dir := s.moduleDir(info.Id)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create backup dir: %w", err)
+	}
+
+	marshaler := protojson.MarshalOptions{Indent: "  ", EmitUnpopulated: true}
+	metaBytes, err := marshaler.Marshal(info)
+	if err != nil {
+		return fmt.Errorf("marshal metadata: %w", err)
+	}
+	metaPath := filepath.Join(dir, "metadata.json")
+	s.meta.invalidate(metaPath)
+	if err := os.WriteFile(metaPath, metaBytes, 0o644); err != nil {
+		return fmt.Errorf("write metadata: %w", err)
+	}
 	return nil
 }
 
 // LoadModuleBackupData reads, optionally decrypts, and decompresses the backup payload.
-func (s *BackupStorage) LoadModuleBackupData(backupID string, password string) ([]byte, error) {
+func (s *BackupStorage) LoadModuleBackupData(ctx context.Context, backupID string, password string) (_ []byte, err error) {
+	ctx, span := startSpan(ctx, "storage.LoadModuleBackupData", attribute.String("backup.id", backupID))
+	defer func() { endSpan(span, err) }()
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	dir := s.moduleDir(backupID)
-
-	// Check for encrypted file first
-	encPath := filepath.Join(dir, "data.json.gz.enc")
-	plainPath := filepath.Join(dir, "data.json.gz")
-
-	if _, err := os.Stat(encPath); err == nil {
-		// Encrypted backup
-		if password == "" {
-			return nil, fmt.Errorf("backup is encrypted: password required")
-		}
-		encrypted, err := os.ReadFile(encPath)
-		if err != nil {
-			return nil, fmt.Errorf("read encrypted backup data: %w", err)
-		}
-		compressed, err := DecryptData(encrypted, password)
-		if err != nil {
-			return nil, fmt.Errorf("decrypt backup data: %w", err)
-		}
-		return gzipDecompress(compressed)
-	}
-
-	// Unencrypted backup
-	compressed, err := os.ReadFile(plainPath)
-	if err != nil {
-		return nil, fmt.Errorf("read backup data: %w", err)
+	data, err := s.loadModuleData(ctx, backupID, password)
+	if errors.Is(err, ErrBackupCorrupt) {
+		s.log.Errorf("Backup %s is corrupt: %v", backupID, err)
 	}
-	return gzipDecompress(compressed)
+	return data, err
 }
 
-// GetModuleBackup reads backup metadata from disk.
+// GetModuleBackup reads backup metadata from disk. A backup whose metadata
+// is unreadable is described with status "corrupt".
 func (s *BackupStorage) GetModuleBackup(backupID string) (*backupV1.BackupInfo, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	return s.readModuleMetadata(backupID)
+	info, err := s.readModuleMetadata(backupID)
+	if err != nil {
+		if corrupt, ok := s.corruptModuleBackup(backupID, err); ok {
+			return corrupt, nil
+		}
+	}
+	return info, err
 }
 
 func (s *BackupStorage) readModuleMetadata(backupID string) (*backupV1.BackupInfo, error) {
 	metaPath := filepath.Join(s.moduleDir(backupID), "metadata.json")
-	metaBytes, err := os.ReadFile(metaPath)
+	info, err := loadMetadata(s.meta, metaPath, func() *backupV1.BackupInfo { return &backupV1.BackupInfo{} })
 	if err != nil {
 		return nil, fmt.Errorf("read metadata: %w", err)
 	}
+	return info, nil
+}
+
+// BackupFilter selects backups while the metadata is scanned, so callers do
+// not have to load everything and filter afterwards. Zero fields match all.
+type BackupFilter struct {
+	ModuleID      string
+	TenantID      *uint32
+	CreatedAfter  time.Time // inclusive
+	CreatedBefore time.Time // exclusive
+	Status        string
+	CreatedBy     string
+	Encrypted     *bool
+	Search        string // case-insensitive substring of the ID, module IDs, description or a key=value label
+}
+
+func (f BackupFilter) match(id string, moduleIDs []string, description string, tenantID uint32, createdAt time.Time, status, createdBy string, encrypted bool, labels map[string]string) bool {
+	if f.ModuleID != "" && !slices.Contains(moduleIDs, f.ModuleID) {
+		return false
+	}
+	if f.TenantID != nil && tenantID != *f.TenantID {
+		return false
+	}
+	if !f.CreatedAfter.IsZero() && createdAt.Before(f.CreatedAfter) {
+		return false
+	}
+	if !f.CreatedBefore.IsZero() && !createdAt.Before(f.CreatedBefore) {
+		return false
+	}
+	if f.Status != "" && status != f.Status {
+		return false
+	}
+	if f.CreatedBy != "" && createdBy != f.CreatedBy {
+		return false
+	}
+	if f.Encrypted != nil && encrypted != *f.Encrypted {
+		return false
+	}
+	if f.Search == "" {
+		return true
+	}
+	q := strings.ToLower(f.Search)
+	fields := append([]string{id, description}, moduleIDs...)
+	for k, v := range labels {
+		fields = append(fields, k+"="+v)
+	}
+	for _, field := range fields {
+		if strings.Contains(strings.ToLower(field), q) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f BackupFilter) matchModule(b *backupV1.BackupInfo) bool {
+	return f.match(b.Id, []string{b.ModuleId}, b.Description, b.TenantId, b.CreatedAt.AsTime(), b.Status, b.CreatedBy, b.Encrypted, nil)
+}
 
-	var info backupV1.BackupInfo
-	if err := unmarshalWithFallback(metaBytes, &info); err != nil {
-		return nil, fmt.Errorf("unmarshal metadata: %w", err)
+func (f BackupFilter) matchFull(b *backupV1.FullBackupInfo) bool {
+	moduleIDs := make([]string, len(b.ModuleBackups))
+	for i, mb := range b.ModuleBackups {
+		moduleIDs[i] = mb.ModuleId
 	}
-	return &info, nil
+	return f.match(b.Id, moduleIDs, b.Description, b.TenantId, b.CreatedAt.AsTime(), b.Status, b.CreatedBy, b.Encrypted, b.Labels)
 }
 
 // ListModuleBackups returns all module backups, optionally filtered by module and tenant.
 func (s *BackupStorage) ListModuleBackups(moduleID string, tenantID *uint32) ([]*backupV1.BackupInfo, error) {
+	return s.FindModuleBackups(BackupFilter{ModuleID: moduleID, TenantID: tenantID})
+}
+
+// FindModuleBackups returns the module backups matching f, newest first.
+func (s *BackupStorage) FindModuleBackups(f BackupFilter) ([]*backupV1.BackupInfo, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
+	return s.findModuleBackups(f)
+}
+
+func (s *BackupStorage) findModuleBackups(f BackupFilter) ([]*backupV1.BackupInfo, error) {
 	modulesDir := filepath.Join(s.basePath, "modules")
 	entries, err := os.ReadDir(modulesDir)
 	if err != nil {
@@ -180,13 +363,14 @@ func (s *BackupStorage) ListModuleBackups(moduleID string, tenantID *uint32) ([]
 		}
 		info, err := s.readModuleMetadata(entry.Name())
 		if err != nil {
-			s.log.Warnf("Skip backup %s: %v", entry.Name(), err)
-			continue
-		}
-		if moduleID != "" && info.ModuleId != moduleID {
-			continue
+			corrupt, ok := s.corruptModuleBackup(entry.Name(), err)
+			if !ok {
+				continue
+			}
+			s.log.Warnf("Backup %s is corrupt: %v", entry.Name(), err)
+			info = corrupt
 		}
-		if tenantID != nil && info.TenantId != *tenantID {
+		if !f.matchModule(info) {
 			continue
 		}
 		backups = append(backups, info)
@@ -211,99 +395,446 @@ func (s *BackupStorage) DeleteModuleBackup(backupID string) error {
 	if _, err := os.Stat(dir); os.IsNotExist(err) {
 		return fmt.Errorf("backup not found: %s", backupID)
 	}
+	// Backups with unreadable metadata stay deletable.
+	if info, err := s.readModuleMetadata(backupID); err == nil && info.Pin != nil {
+		return fmt.Errorf("delete %s: %w", backupID, ErrBackupPinned)
+	}
+	if err := s.rebaseDeltas(backupID); err != nil {
+		return fmt.Errorf("delete %s: %w", backupID, err)
+	}
+	s.meta.invalidate(filepath.Join(dir, "metadata.json"))
 	return os.RemoveAll(dir)
 }
 
+// SetModuleBackupPin pins a module backup, or unpins it when pin is nil.
+func (s *BackupStorage) SetModuleBackupPin(backupID string, pin *backupV1.BackupPin) (*backupV1.BackupInfo, error) {
+	return s.UpdateModuleBackup(backupID, func(info *backupV1.BackupInfo) error {
+		info.Pin = pin
+		return nil
+	})
+}
+
+// UpdateModuleBackup applies update to a module backup's metadata and stores
+// the result. Nothing is written if update fails.
+func (s *BackupStorage) UpdateModuleBackup(backupID string, update func(*backupV1.BackupInfo) error) (*backupV1.BackupInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := s.readModuleMetadata(backupID)
+	if err != nil {
+		return nil, err
+	}
+	if err := update(info); err != nil {
+		return nil, err
+	}
+	s.meta.invalidate(filepath.Join(s.moduleDir(backupID), "metadata.json"))
+	if err := writeMetadata(s.moduleDir(backupID), info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
 // --- Full Backups ---
 
 func (s *BackupStorage) fullDir(backupID string) string {
 	return filepath.Join(s.basePath, "full", backupID)
 }
 
-// SaveFullBackup persists a full platform backup manifest and per-module data.
-// If password is non-empty, each module's gzipped data is encrypted with AES-256-GCM.
-func (s *BackupStorage) SaveFullBackup(info *backupV1.FullBackupInfo, moduleData map[string][]byte, password string) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+// FullBackupSpool collects the module data of a full backup under
+// spool/<id> while the backup is being created, so each module's export can
+// be written out and released as soon as it arrives. SaveFullBackup moves
+// the finished directory into place; until then the backup is invisible.
+type FullBackupSpool struct {
+	s        *BackupStorage
+	id       string
+	dir      string
+	password string
+	c        compression
+
+	// key is the idempotency key of a resumable backup, and createdAt
+	// when its first attempt started.
+	key       string
+	createdAt time.Time
+}
 
-	dir := s.fullDir(info.Id)
+// NewFullBackupSpool creates the spool directory for a new full backup.
+// Module data is compressed as c asks and, if password is non-empty,
+// encrypted with AES-256-GCM.
+func (s *BackupStorage) NewFullBackupSpool(backupID, password string, c compression) (*FullBackupSpool, error) {
+	if err := s.journalBegin(journalEntry{Kind: "full", BackupID: backupID, CreatedAt: time.Now()}); err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(s.basePath, "spool", backupID)
 	if err := os.MkdirAll(dir, 0o755); err != nil {
-		return fmt.Errorf("create full backup dir: %w", err)
+		s.journalEnd(backupID)
+		return nil, fmt.Errorf("create spool dir: %w", err)
 	}
+	return &FullBackupSpool{s: s, id: backupID, dir: dir, password: password, c: c}, nil
+}
 
-	if password != "" {
-		info.Encrypted = true
+// WriteModule compresses, optionally encrypts and writes one module's export,
+// and records sizes, checksums and timings in mb. It is safe to call for
+// different modules concurrently.
+func (sp *FullBackupSpool) WriteModule(ctx context.Context, mb *backupV1.BackupInfo, export *ExportResult) (err error) {
+	ctx, span := startSpan(ctx, "storage.SpoolModule", attribute.String("module.id", mb.ModuleId))
+	defer func() { endSpan(span, err) }()
+
+	timings := phaseTimings(mb)
+	mb.Compression = sp.c.algo
+	mb.CompressionLevel = int32(sp.c.effectiveLevel())
+	mb.Sha256 = export.Sha256
+
+	filename := mb.ModuleId + ".json" + CompressionExt(sp.c.algo)
+	if sp.password != "" {
+		filename += ".enc"
 	}
-
-	// Write per-module data
-	for moduleID, data := range moduleData {
-		compressed, err := gzipCompress(data)
+	// An earlier attempt at a resumed backup may have left the module in
+	// another format.
+	for _, name := range DataFileNames(mb.ModuleId) {
+		os.Remove(filepath.Join(sp.dir, name))
+	}
+	path := filepath.Join(sp.dir, filename)
+	f, err := os.OpenFile(path+".tmp", os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("write %s data: %w", mb.ModuleId, err)
+	}
+	defer func() {
 		if err != nil {
-			return fmt.Errorf("compress %s data: %w", moduleID, err)
+			f.Close()
+			os.Remove(path + ".tmp")
 		}
+	}()
+	stored := sha256.New()
+	out := io.MultiWriter(f, stored)
+	if _, err := out.Write(storedDataHeader(sp.c.algo, sp.password != "")); err != nil {
+		return fmt.Errorf("write %s data: %w", mb.ModuleId, err)
+	}
 
-		filename := fmt.Sprintf("%s.json.gz", moduleID)
-		payload := compressed
-		if password != "" {
-			encrypted, err := encryptData(compressed, password)
-			if err != nil {
-				return fmt.Errorf("encrypt %s data: %w", moduleID, err)
-			}
-			payload = encrypted
-			filename = fmt.Sprintf("%s.json.gz.enc", moduleID)
+	// Unencrypted modules are compressed straight into the spool file.
+	// Encryption seals the whole payload, so encrypted ones are compressed
+	// in memory first.
+	phaseStart := time.Now()
+	if sp.password == "" {
+		if mb.CompressedSizeBytes, err = tracedCompressFile(ctx, sp.c, export.File, out); err != nil {
+			return fmt.Errorf("compress %s data: %w", mb.ModuleId, err)
 		}
-
-		if err := os.WriteFile(filepath.Join(dir, filename), payload, 0o644); err != nil {
-			return fmt.Errorf("write %s data: %w", moduleID, err)
+		timings.CompressMs = time.Since(phaseStart).Milliseconds()
+	} else {
+		var compressed bytes.Buffer
+		if mb.CompressedSizeBytes, err = tracedCompressFile(ctx, sp.c, export.File, &compressed); err != nil {
+			return fmt.Errorf("compress %s data: %w", mb.ModuleId, err)
+		}
+		timings.CompressMs = time.Since(phaseStart).Milliseconds()
+		phaseStart = time.Now()
+		encrypted, err := tracedEncrypt(ctx, compressed.Bytes(), sp.password)
+		if err != nil {
+			return fmt.Errorf("encrypt %s data: %w", mb.ModuleId, err)
+		}
+		timings.EncryptMs = time.Since(phaseStart).Milliseconds()
+		phaseStart = time.Now()
+		if _, err := out.Write(encrypted); err != nil {
+			return fmt.Errorf("write %s data: %w", mb.ModuleId, err)
 		}
 	}
+	mb.CompressionRatio = compressionRatio(export.Size, mb.CompressedSizeBytes)
 
-	// Write manifest (use protojson for correct timestamp/zero-value handling)
-	marshaler := protojson.MarshalOptions{Indent: "  ", EmitUnpopulated: true}
-	metaBytes, err := marshaler.Marshal(info)
-	if err != nil {
-		return fmt.Errorf("marshal manifest: %w", err)
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("write %s data: %w", mb.ModuleId, err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("write %s data: %w", mb.ModuleId, err)
+	}
+	mb.StoredSha256 = hex.EncodeToString(stored.Sum(nil))
+	timings.WriteMs = time.Since(phaseStart).Milliseconds()
+	mb.ThroughputBytesPerSec = throughput(export.Size, timings)
+	return sp.recordModule(mb)
+}
+
+// ID is the ID the backup is saved under; a resumed backup keeps the one
+// its first attempt was given.
+func (sp *FullBackupSpool) ID() string {
+	return sp.id
+}
+
+// CreatedAt is when the first attempt at a resumed backup started, or zero.
+func (sp *FullBackupSpool) CreatedAt() time.Time {
+	return sp.createdAt
+}
+
+// Discard removes the spool of a backup that will not be saved. The spool
+// of a resumable backup is kept for the next attempt.
+func (sp *FullBackupSpool) Discard() {
+	defer sp.release()
+	if sp.key != "" {
+		sp.s.log.Infof("Keeping the spool of full backup %s for a retry with the same idempotency key", sp.id)
+	} else {
+		os.RemoveAll(sp.dir)
+	}
+	sp.s.journalEnd(sp.id)
+}
+
+// SaveFullBackup writes the manifest of a full backup into its spool and
+// moves the spool into place.
+func (s *BackupStorage) SaveFullBackup(ctx context.Context, info *backupV1.FullBackupInfo, sp *FullBackupSpool) (err error) {
+	_, span := startSpan(ctx, "storage.SaveFullBackup", attribute.String("backup.id", info.Id), attribute.Int("modules", len(info.ModuleBackups)))
+	defer func() { endSpan(span, err) }()
+
+	info.Encrypted = sp.password != ""
+	if info.Site == "" {
+		info.Site = localSite()
+	}
+	info.TotalCompressedSizeBytes = 0
+	for _, mb := range info.ModuleBackups {
+		info.TotalCompressedSizeBytes += mb.CompressedSizeBytes
+	}
+	info.CompressionRatio = compressionRatio(info.TotalSizeBytes, info.TotalCompressedSizeBytes)
+
+	info.IdempotencyKey = sp.key
+	if err := writeMetadata(sp.dir, info); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.forgetFull(info.Id)
+	dest := s.fullDir(info.Id)
+	if sp.key != "" {
+		// A canceled attempt left a record under the same ID.
+		if prev, err := s.readFullMetadata(info.Id); err == nil && prev.Status == "canceled" {
+			os.RemoveAll(dest)
+		}
 	}
-	if err := os.WriteFile(filepath.Join(dir, "metadata.json"), metaBytes, 0o644); err != nil {
-		return fmt.Errorf("write manifest: %w", err)
+	// The rename is the commit: until then the backup is not listed.
+	if err := os.Rename(sp.dir, dest); err != nil {
+		return fmt.Errorf("move full backup into place: %w", err)
 	}
+	sp.release()
+	os.RemoveAll(filepath.Join(dest, resumeDir))
+	s.journalEnd(info.Id)
 
-	s.log.Infof("Saved full backup %s with %d modules (encrypted=%v)", info.Id, len(moduleData), info.Encrypted)
+	s.log.Infof("Saved full backup %s with %d modules (encrypted=%v)", info.Id, len(info.ModuleBackups), info.Encrypted)
 	return nil
 }
 
+// SaveFullBackupRecord persists the manifest only, for full backups that
+// were canceled before any data was kept, so they show up in history and
+// statistics.
+func (s *BackupStorage) SaveFullBackupRecord(info *backupV1.FullBackupInfo) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if info.Site == "" {
+		info.Site = localSite()
+	}
+
+	dir := s.fullDir(info.Id)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create backup dir: %w", err)
+	}
+	s.forgetFull(info.Id)
+	return writeMetadata(dir, info)
+}
+
 // LoadFullBackupModuleData reads, optionally decrypts, and decompresses a single module's data from a full backup.
-func (s *BackupStorage) LoadFullBackupModuleData(backupID, moduleID string, password string) ([]byte, error) {
+func (s *BackupStorage) LoadFullBackupModuleData(ctx context.Context, backupID, moduleID string, password string) (_ []byte, err error) {
+	ctx, span := startSpan(ctx, "storage.LoadFullBackupModuleData", attribute.String("backup.id", backupID), attribute.String("module.id", moduleID))
+	defer func() { endSpan(span, err) }()
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	dir := s.fullDir(backupID)
+	// An unreadable manifest only stops the data from being verified.
+	var info *backupV1.BackupInfo
+	if full, err := s.readFullMetadata(backupID); err == nil {
+		for _, mb := range full.ModuleBackups {
+			if mb.ModuleId == moduleID {
+				info = mb
+			}
+		}
+	}
+	data, err := loadDataFile(ctx, s.fullDir(backupID), moduleID, password, info)
+	if errors.Is(err, ErrBackupCorrupt) {
+		s.log.Errorf("Module %s of full backup %s is corrupt: %v", moduleID, backupID, err)
+	}
+	return data, err
+}
 
-	// Check for encrypted file first
-	encPath := filepath.Join(dir, fmt.Sprintf("%s.json.gz.enc", moduleID))
-	plainPath := filepath.Join(dir, fmt.Sprintf("%s.json.gz", moduleID))
+// OpenModuleBackupFile opens a module backup's data file as stored:
+// compressed, and encrypted when the backup is. The caller closes it.
+func (s *BackupStorage) OpenModuleBackupFile(backupID string) (*os.File, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return openDataFile(s.moduleDir(backupID), "data")
+}
+
+// OpenFullBackupModuleFile opens one module's data file of a full backup as
+// stored. The caller closes it.
+func (s *BackupStorage) OpenFullBackupModuleFile(backupID, moduleID string) (*os.File, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
-	if _, err := os.Stat(encPath); err == nil {
+	return openDataFile(s.fullDir(backupID), moduleID)
+}
+
+// openDataFile opens {base}.json.{gz,zst}[.enc] in dir and reports whether
+// it is encrypted.
+func openDataFile(dir, base string) (*os.File, bool, error) {
+	var err error
+	for _, name := range DataFileNames(base) {
+		var f *os.File
+		if f, err = os.Open(filepath.Join(dir, name)); err == nil {
+			return f, strings.HasSuffix(name, ".enc"), nil
+		}
+		if !os.IsNotExist(err) {
+			break
+		}
+	}
+	return nil, false, fmt.Errorf("open %s data: %w", base, err)
+}
+
+// loadDataFile reads, optionally decrypts, and decompresses {base}'s data
+// file in dir, whichever compression it was written with. info, when known,
+// supplies the stored checksum to verify and the export's expected size.
+func loadDataFile(ctx context.Context, dir, base, password string, info *backupV1.BackupInfo) ([]byte, error) {
+	f, encrypted, err := openDataFile(dir, base)
+	if err != nil {
+		return nil, err
+	}
+	name := filepath.Base(f.Name())
+	r, err := decodeDataFile(ctx, f, encrypted, password, info.GetStoredSha256())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	defer r.Close()
+
+	data, err := tracedReadAll(ctx, r, info.GetSizeBytes())
+	// Bit rot usually breaks decompression first; the checksum names it.
+	if verr := r.verify(); verr != nil {
+		return nil, fmt.Errorf("%s: %w", name, verr)
+	}
+	return data, err
+}
+
+// decodeDataFile streams the export held in a data file from openDataFile,
+// with or without a format header. Decompression reads straight from the
+// file. An encrypted file is sealed as a single AES-GCM message, which
+// cannot be authenticated before all of it is read, so it is read whole,
+// checked against storedSum and decrypted in place first. An unencrypted
+// file is hashed as it streams; call verify once the export has been read.
+// The returned reader takes over f.
+func decodeDataFile(ctx context.Context, f *os.File, encrypted bool, password, storedSum string) (*dataReader, error) {
+	var src io.Reader = f
+	r := &dataReader{file: f, want: storedSum}
+	if encrypted {
+		defer f.Close()
 		if password == "" {
-			return nil, fmt.Errorf("backup is encrypted: password required")
+			return nil, fmt.Errorf("backup is encrypted: %w", ErrBackupPassword)
+		}
+		stored, err := io.ReadAll(f)
+		if err != nil {
+			return nil, fmt.Errorf("read: %w", err)
+		}
+		if storedSum != "" {
+			if err := verifyChecksum(checksum(stored), storedSum); err != nil {
+				return nil, err
+			}
 		}
-		encrypted, err := os.ReadFile(encPath)
+		_, sealed, err := ParseFormatHeader(stored)
 		if err != nil {
-			return nil, fmt.Errorf("read encrypted module data %s: %w", moduleID, err)
+			return nil, err
 		}
-		compressed, err := DecryptData(encrypted, password)
+		plain, err := tracedDecrypt(ctx, sealed, password)
 		if err != nil {
-			return nil, fmt.Errorf("decrypt module data %s: %w", moduleID, err)
+			return nil, fmt.Errorf("decrypt: %w: %v", ErrBackupPassword, err)
+		}
+		src, r.file = bytes.NewReader(plain), nil
+	} else {
+		if storedSum != "" {
+			r.hash = sha256.New()
+			src = io.TeeReader(f, r.hash)
+		}
+		var err error
+		if src, _, err = stripFormatHeader(src); err != nil {
+			r.Close()
+			return nil, err
 		}
-		return gzipDecompress(compressed)
 	}
 
-	// Unencrypted backup
-	compressed, err := os.ReadFile(plainPath)
+	dr, err := NewDecompressReader(src)
 	if err != nil {
-		return nil, fmt.Errorf("read module data %s: %w", moduleID, err)
+		if verr := r.verify(); verr != nil {
+			err = verr
+		} else {
+			err = fmt.Errorf("decompress: %w", err)
+		}
+		r.Close()
+		return nil, err
 	}
-	return gzipDecompress(compressed)
+	r.ReadCloser = dr
+	return r, nil
+}
+
+// dataReader is a decompressing reader that also closes the file under it.
+type dataReader struct {
+	io.ReadCloser
+	file *os.File
+
+	// hash covers the file as it is read, when want is to be checked.
+	hash hash.Hash
+	want string
+}
+
+// verify reads what decompression left of the file and checks all of it
+// against the stored checksum. It does nothing when there is no checksum
+// or it was already checked before decryption.
+func (r *dataReader) verify() error {
+	if r.hash == nil {
+		return nil
+	}
+	if _, err := io.Copy(r.hash, r.file); err != nil {
+		return fmt.Errorf("read: %w", err)
+	}
+	return verifyChecksum(hex.EncodeToString(r.hash.Sum(nil)), r.want)
+}
+
+func (r *dataReader) Close() error {
+	var err error
+	if r.ReadCloser != nil {
+		err = r.ReadCloser.Close()
+	}
+	if r.file != nil {
+		if ferr := r.file.Close(); err == nil {
+			err = ferr
+		}
+	}
+	return err
+}
+
+// spoolReader copies r into an anonymous file in the spool directory and
+// returns it rewound, with its size. The file is unlinked already, so
+// closing it is all the cleanup it needs.
+func (s *BackupStorage) spoolReader(r io.Reader) (*os.File, int64, error) {
+	f, err := os.CreateTemp(filepath.Join(s.basePath, "spool"), "export-*")
+	if err != nil {
+		return nil, 0, fmt.Errorf("create spool file: %w", err)
+	}
+	os.Remove(f.Name())
+	n, err := io.Copy(f, r)
+	if err == nil {
+		_, err = f.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		f.Close()
+		return nil, 0, err
+	}
+	return f, n, nil
+}
+
+// forgetFull drops a full backup's manifest and summary from the cache.
+func (s *BackupStorage) forgetFull(backupID string) {
+	s.meta.invalidate(filepath.Join(s.fullDir(backupID), "metadata.json"))
+	s.meta.invalidate(filepath.Join(s.fullDir(backupID), "summary.json"))
 }
 
 // GetFullBackup reads full backup metadata from disk.
@@ -316,20 +847,68 @@ func (s *BackupStorage) GetFullBackup(backupID string) (*backupV1.FullBackupInfo
 
 func (s *BackupStorage) readFullMetadata(backupID string) (*backupV1.FullBackupInfo, error) {
 	metaPath := filepath.Join(s.fullDir(backupID), "metadata.json")
-	metaBytes, err := os.ReadFile(metaPath)
+	info, err := loadMetadata(s.meta, metaPath, func() *backupV1.FullBackupInfo { return &backupV1.FullBackupInfo{} })
 	if err != nil {
 		return nil, fmt.Errorf("read manifest: %w", err)
 	}
-
-	var info backupV1.FullBackupInfo
-	if err := unmarshalWithFallback(metaBytes, &info); err != nil {
-		return nil, fmt.Errorf("unmarshal manifest: %w", err)
-	}
-	return &info, nil
+	return info, nil
 }
 
 // ListFullBackups returns all full backups, optionally filtered by tenant.
 func (s *BackupStorage) ListFullBackups(tenantID *uint32) ([]*backupV1.FullBackupInfo, error) {
+	return s.FindFullBackups(BackupFilter{TenantID: tenantID})
+}
+
+// FindFullBackups returns the full backups matching f, newest first. A
+// module filter matches full backups that include that module.
+func (s *BackupStorage) FindFullBackups(f BackupFilter) ([]*backupV1.FullBackupInfo, error) {
+	return s.findFullBackups(f, s.readFullMetadata)
+}
+
+// FindFullBackupSummaries is FindFullBackups for listings: each backup
+// carries only the module ID, status and sizes of its modules. Use
+// GetFullBackup for the rest.
+func (s *BackupStorage) FindFullBackupSummaries(f BackupFilter) ([]*backupV1.FullBackupInfo, error) {
+	return s.findFullBackups(f, s.readFullSummary)
+}
+
+// readFullSummary reads summary.json, or derives the summary from the
+// manifest when the summary is missing or older than the manifest (backups
+// written before summaries existed, or manifests edited by offline tools).
+func (s *BackupStorage) readFullSummary(backupID string) (*backupV1.FullBackupInfo, error) {
+	dir := s.fullDir(backupID)
+	summaryPath := filepath.Join(dir, "summary.json")
+	if sfi, err := os.Stat(summaryPath); err == nil {
+		if mfi, err := os.Stat(filepath.Join(dir, "metadata.json")); err == nil && !sfi.ModTime().Before(mfi.ModTime()) {
+			if info, err := loadMetadata(s.meta, summaryPath, func() *backupV1.FullBackupInfo { return &backupV1.FullBackupInfo{} }); err == nil {
+				return info, nil
+			}
+		}
+	}
+	info, err := s.readFullMetadata(backupID)
+	if err != nil {
+		return nil, err
+	}
+	return fullBackupSummary(info), nil
+}
+
+// fullBackupSummary copies info with its module entries cut down to what a
+// listing shows and filters on.
+func fullBackupSummary(info *backupV1.FullBackupInfo) *backupV1.FullBackupInfo {
+	summary := proto.Clone(info).(*backupV1.FullBackupInfo)
+	summary.ModuleBackups = make([]*backupV1.BackupInfo, len(info.ModuleBackups))
+	for i, mb := range info.ModuleBackups {
+		summary.ModuleBackups[i] = &backupV1.BackupInfo{
+			ModuleId:            mb.ModuleId,
+			Status:              mb.Status,
+			SizeBytes:           mb.SizeBytes,
+			CompressedSizeBytes: mb.CompressedSizeBytes,
+		}
+	}
+	return summary
+}
+
+func (s *BackupStorage) findFullBackups(f BackupFilter, read func(string) (*backupV1.FullBackupInfo, error)) ([]*backupV1.FullBackupInfo, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -347,12 +926,12 @@ func (s *BackupStorage) ListFullBackups(tenantID *uint32) ([]*backupV1.FullBacku
 		if !entry.IsDir() {
 			continue
 		}
-		info, err := s.readFullMetadata(entry.Name())
+		info, err := read(entry.Name())
 		if err != nil {
 			s.log.Warnf("Skip full backup %s: %v", entry.Name(), err)
 			continue
 		}
-		if tenantID != nil && info.TenantId != *tenantID {
+		if !f.matchFull(info) {
 			continue
 		}
 		backups = append(backups, info)
@@ -377,15 +956,178 @@ func (s *BackupStorage) DeleteFullBackup(backupID string) error {
 	if _, err := os.Stat(dir); os.IsNotExist(err) {
 		return fmt.Errorf("full backup not found: %s", backupID)
 	}
+	if info, err := s.readFullMetadata(backupID); err == nil && info.Pin != nil {
+		return fmt.Errorf("delete %s: %w", backupID, ErrBackupPinned)
+	}
+	s.forgetFull(backupID)
 	return os.RemoveAll(dir)
 }
 
+// SetFullBackupPin pins a full backup, or unpins it when pin is nil.
+func (s *BackupStorage) SetFullBackupPin(backupID string, pin *backupV1.BackupPin) (*backupV1.FullBackupInfo, error) {
+	return s.UpdateFullBackup(backupID, func(info *backupV1.FullBackupInfo) error {
+		info.Pin = pin
+		return nil
+	})
+}
+
+// UpdateFullBackup applies update to a full backup's manifest and stores the
+// result. Nothing is written if update fails.
+func (s *BackupStorage) UpdateFullBackup(backupID string, update func(*backupV1.FullBackupInfo) error) (*backupV1.FullBackupInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := s.readFullMetadata(backupID)
+	if err != nil {
+		return nil, err
+	}
+	if err := update(info); err != nil {
+		return nil, err
+	}
+	s.forgetFull(backupID)
+	if err := writeMetadata(s.fullDir(backupID), info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// --- Restore history ---
+
+// restoreLog holds one JSON line per restore of a backup. It lives outside the
+// backup's directory so the history survives the backup's deletion.
+func (s *BackupStorage) restoreLog(backupID string) string {
+	return filepath.Join(s.basePath, "restores", backupID+".jsonl")
+}
+
+// AppendRestoreRecord adds rec to the restore history of rec.BackupId.
+func (s *BackupStorage) AppendRestoreRecord(rec *backupV1.RestoreRecord) error {
+	line, err := protojson.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("marshal restore record: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := s.restoreLog(rec.BackupId)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create restores dir: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("open restore log: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("write restore log: %w", err)
+	}
+	return f.Close()
+}
+
+// ListRestoreRecords returns the restore history of a backup, oldest first.
+// A backup that was never restored has no history and no error.
+func (s *BackupStorage) ListRestoreRecords(backupID string) ([]*backupV1.RestoreRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data, err := os.ReadFile(s.restoreLog(backupID))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read restore log: %w", err)
+	}
+
+	var records []*backupV1.RestoreRecord
+	for i, line := range bytes.Split(data, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		rec := &backupV1.RestoreRecord{}
+		if err := protojson.Unmarshal(line, rec); err != nil {
+			s.log.Warnf("Skipping unreadable restore record %d of %s: %v", i+1, backupID, err)
+			continue
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
 // --- Unmarshal helpers ---
 
+// DiskUsage returns the total size of everything under the storage path.
+func (s *BackupStorage) DiskUsage() (int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var total int64
+	err := filepath.WalkDir(s.basePath, func(_ string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			fi, err := d.Info()
+			if err != nil {
+				return err
+			}
+			total += fi.Size()
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("walk storage: %w", err)
+	}
+	return total, nil
+}
+
+// phaseTimings returns info's timings, creating them if the caller recorded
+// none.
+func phaseTimings(info *backupV1.BackupInfo) *backupV1.PhaseTimings {
+	if info.Timings == nil {
+		info.Timings = &backupV1.PhaseTimings{}
+	}
+	return info.Timings
+}
+
+// checksum returns the hex SHA-256 recorded in metadata for later verification.
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// ErrBackupCorrupt means a data file no longer matches the checksum recorded
+// when it was written.
+var ErrBackupCorrupt = errors.New("stored data does not match its checksum")
+
+func verifyChecksum(got, want string) error {
+	if got != want {
+		return fmt.Errorf("%w: sha256 %s, metadata says %s", ErrBackupCorrupt, got, want)
+	}
+	return nil
+}
+
+func compressionRatio(original, compressed int64) float64 {
+	if compressed <= 0 {
+		return 0
+	}
+	return float64(original) / float64(compressed)
+}
+
+// throughput is bytes per second across all recorded phases.
+func throughput(size int64, t *backupV1.PhaseTimings) int64 {
+	ms := t.ExportMs + t.CompressMs + t.EncryptMs + t.WriteMs
+	if ms <= 0 {
+		return 0
+	}
+	return size * 1000 / ms
+}
+
 // unmarshalWithFallback tries protojson first, then falls back to encoding/json
 // for backward compatibility with metadata written before the protojson migration.
 // Old metadata used encoding/json which produces snake_case keys and object-style
 // timestamps ({seconds, nanos}), while protojson expects camelCase and RFC3339 strings.
+// The service only falls back until MigrateMetadata has run; the offline tools
+// always do.
 func unmarshalWithFallback(data []byte, msg proto.Message) error {
 	// Try protojson first (new format)
 	if err := protojson.Unmarshal(data, msg); err == nil {
@@ -402,8 +1144,18 @@ func unmarshalWithFallback(data []byte, msg proto.Message) error {
 // --- Compression helpers ---
 
 func gzipCompress(data []byte) ([]byte, error) {
+	return gzipCompressLevel(data, gzip.DefaultCompression)
+}
+
+func gzipCompressLevel(data []byte, level int) ([]byte, error) {
+	if workers := compressionWorkers(); workers > 1 && len(data) >= 2*parallelBlockSize {
+		return pgzipCompressLevel(data, level, workers)
+	}
 	var buf bytes.Buffer
-	w := gzip.NewWriter(&buf)
+	w, err := gzip.NewWriterLevel(&buf, level)
+	if err != nil {
+		return nil, err
+	}
 	if _, err := w.Write(data); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+)
+
+// downgradeAllowed reports whether a target may use plaintext or disable
+// certificate verification. Modules must be listed in
+// BACKUP_TLS_SKIP_VERIFY_ALLOWLIST (comma-separated module IDs) so a request
+// can't silently downgrade transport security for production modules.
+func downgradeAllowed(moduleID string) bool {
+	return slices.Contains(envList("BACKUP_TLS_SKIP_VERIFY_ALLOWLIST"), moduleID)
+}
+
+// readTargetCA reads a per-target CA certificate. Paths are resolved inside
+// BACKUP_TLS_CA_DIR and may not leave it, so a request can't make the service
+// read arbitrary files.
+func readTargetCA(path string) ([]byte, error) {
+	dir := os.Getenv("BACKUP_TLS_CA_DIR")
+	if dir == "" {
+		return nil, fmt.Errorf("ca_cert_path requires BACKUP_TLS_CA_DIR to be set")
+	}
+	if filepath.IsAbs(path) {
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return nil, fmt.Errorf("ca_cert_path %s is not under BACKUP_TLS_CA_DIR", path)
+		}
+		path = rel
+	}
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return nil, fmt.Errorf("open BACKUP_TLS_CA_DIR: %w", err)
+	}
+	defer root.Close()
+	return root.ReadFile(path)
+}
+
+// targetCredentials builds transport credentials from a per-target TLS
+// override. The returned bool reports whether the connection uses TLS.
+func (c *ModuleClient) targetCredentials(moduleID string, o *backupV1.TargetTLS) (credentials.TransportCredentials, bool, error) {
+	if o.GetInsecure() {
+		if !downgradeAllowed(moduleID) {
+			return nil, false, fmt.Errorf("insecure is not allowed for module %s (see BACKUP_TLS_SKIP_VERIFY_ALLOWLIST)", moduleID)
+		}
+		c.log.Warnf("dialModule: %s uses plaintext per target override", moduleID)
+		return insecure.NewCredentials(), false, nil
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName: o.GetServerName(),
+		MinVersion: tls.VersionTLS12,
+	}
+
+	// Present the backup client certificate when we have one; lab modules
+	// with their own CA often don't require it.
+	if cert, err := loadClientCertificate(); err == nil {
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if o.GetSkipVerify() {
+		if !downgradeAllowed(moduleID) {
+			return nil, false, fmt.Errorf("skip_verify is not allowed for module %s (see BACKUP_TLS_SKIP_VERIFY_ALLOWLIST)", moduleID)
+		}
+		c.log.Warnf("dialModule: %s uses TLS without certificate verification per target override", moduleID)
+		tlsConfig.InsecureSkipVerify = true
+		return credentials.NewTLS(tlsConfig), true, nil
+	}
+
+	if path := o.GetCaCertPath(); path != "" {
+		caCert, err := readTargetCA(path)
+		if err != nil {
+			return nil, false, fmt.Errorf("read CA cert from %s: %w", path, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, false, fmt.Errorf("parse CA certificate %s", path)
+		}
+		tlsConfig.RootCAs = pool
+		c.log.Infof("dialModule: %s trusts CA %s per target override", moduleID, path)
+		return credentials.NewTLS(tlsConfig), true, nil
+	}
+
+	// Only server_name was overridden: keep the global CA.
+	global, err := loadClientTLSConfig(c.log)
+	if err != nil {
+		return nil, false, err
+	}
+	if o.GetServerName() != "" {
+		global.ServerName = o.GetServerName()
+	}
+	return credentials.NewTLS(global), true, nil
+}
+
+// loadClientCertificate loads the backup service's client certificate from
+// the same locations loadClientTLSCredentials uses.
+func loadClientCertificate() (tls.Certificate, error) {
+	certPath := os.Getenv("BACKUP_CLIENT_CERT_PATH")
+	keyPath := os.Getenv("BACKUP_CLIENT_KEY_PATH")
+	if certPath == "" || keyPath == "" {
+		certsDir := os.Getenv("CERTS_DIR")
+		if certsDir == "" {
+			certsDir = "/app/certs"
+		}
+		if certPath == "" {
+			certPath = certsDir + "/backup/backup.crt"
+		}
+		if keyPath == "" {
+			keyPath = certsDir + "/backup/backup.key"
+		}
+	}
+	return tls.LoadX509KeyPair(certPath, keyPath)
+}
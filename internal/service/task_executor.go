@@ -22,17 +22,20 @@ type TaskExecutor struct {
 	log           *log.Helper
 	orchestrator  *OrchestratorService
 	backupStorage *BackupStorage
+	events        *EventPublisher
 }
 
 func NewTaskExecutor(
 	ctx *bootstrap.Context,
 	orchestrator *OrchestratorService,
 	storage *BackupStorage,
+	events *EventPublisher,
 ) *TaskExecutor {
 	return &TaskExecutor{
 		log:           ctx.NewLoggerHelper("task-executor/backup-service"),
 		orchestrator:  orchestrator,
 		backupStorage: storage,
+		events:        events,
 	}
 }
 
@@ -43,6 +46,16 @@ func (e *TaskExecutor) ExecuteTask(
 	e.log.Infof("Executing task %s (execution=%s, attempt=%d/%d, tenant=%d)",
 		req.GetTaskType(), req.GetExecutionId(), req.GetAttempt(), req.GetMaxAttempts(), req.GetTenantId())
 
+	ctx, done, err := e.orchestrator.jobs.Begin(ctx, "task "+req.GetTaskType())
+	if err != nil {
+		// Not permanent: the scheduler retries, on another replica if there is one.
+		return &commonV1.ExecuteTaskResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+	defer done()
+
 	switch req.GetTaskType() {
 	case "backup:full-platform":
 		return e.handleFullPlatformBackup(ctx, req)
@@ -50,6 +63,10 @@ func (e *TaskExecutor) ExecuteTask(
 		return e.handleCleanupOld(ctx, req)
 	case "backup:validate-all":
 		return e.handleValidateAll(ctx, req)
+	case "backup:report":
+		return e.handleReport(ctx, req)
+	case "backup:recompact":
+		return e.handleRecompact(ctx, req)
 	default:
 		return &commonV1.ExecuteTaskResponse{
 			Success:          false,
@@ -65,6 +82,19 @@ type FullBackupConfig struct {
 	// If empty, backs up all modules from the default list.
 	Modules  []string `json:"modules,omitempty"`
 	Password string   `json:"password,omitempty"`
+	// MinSuccessPercent and RequiredModules override the server-wide
+	// validation policy (BACKUP_MIN_SUCCESS_PERCENT, BACKUP_REQUIRED_MODULES).
+	MinSuccessPercent *int32   `json:"minSuccessPercent,omitempty"`
+	RequiredModules   []string `json:"requiredModules,omitempty"`
+	// Selector picks registered modules by label (e.g. "tier=core") instead
+	// of, or in addition to, Modules.
+	Selector string `json:"selector,omitempty"`
+	// CompressionLevel overrides BACKUP_COMPRESSION_LEVEL, e.g. to favour
+	// ratio over speed for nightly backups.
+	CompressionLevel *int32 `json:"compressionLevel,omitempty"`
+	// Notify overrides the server's Slack and email settings for this
+	// schedule's failure notifications.
+	Notify *NotifyConfig `json:"notify,omitempty"`
 }
 
 // defaultModuleTargets returns the default list of modules to back up.
@@ -132,15 +162,26 @@ func (e *TaskExecutor) handleFullPlatformBackup(
 				GrpcEndpoint: parts[1],
 			})
 		}
-	} else {
+	} else if cfg.Selector == "" {
 		targets = defaultModuleTargets()
 	}
 
 	e.log.Infof("Starting full platform backup for %d modules", len(targets))
 
-	resp, err := e.orchestrator.CreateFullBackup(ctx, &backupV1.CreateFullBackupRequest{
-		Targets:  targets,
-		Password: cfg.Password,
+	// A retried attempt of the same execution picks up where the last one
+	// stopped instead of exporting every module again.
+	var idempotencyKey string
+	if req.GetExecutionId() != "" {
+		idempotencyKey = "task:" + req.GetExecutionId()
+	}
+	resp, err := e.orchestrator.CreateFullBackup(withSchedule(ctx, cfg.Notify), &backupV1.CreateFullBackupRequest{
+		Targets:           targets,
+		Password:          cfg.Password,
+		MinSuccessPercent: cfg.MinSuccessPercent,
+		RequiredModules:   cfg.RequiredModules,
+		TargetSelector:    cfg.Selector,
+		CompressionLevel:  cfg.CompressionLevel,
+		IdempotencyKey:    idempotencyKey,
 	})
 	if err != nil {
 		return &commonV1.ExecuteTaskResponse{
@@ -154,6 +195,15 @@ func (e *TaskExecutor) handleFullPlatformBackup(
 		moduleCount = len(resp.GetBackup().GetModuleBackups())
 	}
 
+	if resp.GetBackup().GetValidationStatus() == validationUnusable {
+		return &commonV1.ExecuteTaskResponse{
+			Success:          false,
+			PermanentFailure: true,
+			Message: fmt.Sprintf("Full platform backup %s is unusable: %s",
+				resp.GetBackup().GetId(), strings.Join(resp.GetBackup().GetValidationReasons(), "; ")),
+		}, nil
+	}
+
 	return &commonV1.ExecuteTaskResponse{
 		Success: true,
 		Message: fmt.Sprintf("Full platform backup completed: %d modules backed up, ID=%s",
@@ -172,7 +222,7 @@ func (e *TaskExecutor) handleCleanupOld(
 	ctx context.Context,
 	req *commonV1.ExecuteTaskRequest,
 ) (*commonV1.ExecuteTaskResponse, error) {
-	cfg := CleanupConfig{MaxAgeDays: 30}
+	cfg := CleanupConfig{MaxAgeDays: retentionDays()}
 	if len(req.GetPayload()) > 0 {
 		if err := json.Unmarshal(req.GetPayload(), &cfg); err != nil {
 			return &commonV1.ExecuteTaskResponse{
@@ -184,13 +234,25 @@ func (e *TaskExecutor) handleCleanupOld(
 	}
 
 	if cfg.MaxAgeDays <= 0 {
-		cfg.MaxAgeDays = 30
+		cfg.MaxAgeDays = retentionDays()
 	}
 
 	cutoff := time.Now().AddDate(0, 0, -cfg.MaxAgeDays)
 	e.log.Infof("Cleaning up backups older than %d days (cutoff=%s, module=%s, dryRun=%v)",
 		cfg.MaxAgeDays, cutoff.Format(time.RFC3339), cfg.ModuleID, cfg.DryRun)
 
+	if !cfg.DryRun {
+		lockCtx, unlock, err := e.backupStorage.LockOperations(ctx, "retention")
+		if err != nil {
+			return &commonV1.ExecuteTaskResponse{
+				Success: false,
+				Message: fmt.Sprintf("failed to take the operation lock: %v", err),
+			}, nil
+		}
+		defer unlock()
+		ctx = lockCtx
+	}
+
 	backups, err := e.backupStorage.ListModuleBackups(cfg.ModuleID, tenantPtr(req.GetTenantId()))
 	if err != nil {
 		return &commonV1.ExecuteTaskResponse{
@@ -199,22 +261,45 @@ func (e *TaskExecutor) handleCleanupOld(
 		}, nil
 	}
 
-	deleted := 0
+	var pruned []string
 	for _, b := range backups {
 		if b.GetCreatedAt() != nil && b.GetCreatedAt().AsTime().Before(cutoff) {
+			if b.GetStatus() == "corrupt" {
+				// Quarantined until repaired or deleted by hand.
+				e.log.Warnf("Keep corrupt backup %s for inspection", b.GetId())
+				continue
+			}
+			if b.GetPin() != nil {
+				e.log.Infof("Keep pinned backup %s (created %s)", b.GetId(), b.GetCreatedAt().AsTime())
+				continue
+			}
 			if cfg.DryRun {
 				e.log.Infof("[dry-run] Would delete backup %s (created %s)", b.GetId(), b.GetCreatedAt().AsTime())
-				deleted++
+				pruned = append(pruned, b.GetId())
 				continue
 			}
+			if ctx.Err() != nil {
+				e.log.Warnf("Cleanup stopped: %v", context.Cause(ctx))
+				break
+			}
 			if err := e.backupStorage.DeleteModuleBackup(b.GetId()); err != nil {
 				e.log.Warnf("Failed to delete backup %s: %v", b.GetId(), err)
 			} else {
 				e.log.Infof("Deleted old backup %s (created %s)", b.GetId(), b.GetCreatedAt().AsTime())
-				deleted++
+				pruned = append(pruned, b.GetId())
 			}
 		}
 	}
+	deleted := len(pruned)
+
+	if deleted > 0 {
+		e.events.Publish(ctx, EventRetentionPruned, &RetentionEvent{
+			ModuleID:   cfg.ModuleID,
+			MaxAgeDays: cfg.MaxAgeDays,
+			DryRun:     cfg.DryRun,
+			BackupIDs:  pruned,
+		})
+	}
 
 	msg := fmt.Sprintf("Cleaned up %d backups older than %d days", deleted, cfg.MaxAgeDays)
 	if cfg.DryRun {
@@ -261,3 +346,45 @@ func tenantPtr(id uint32) *uint32 {
 	}
 	return &id
 }
+
+// ReportConfig is the payload for backup:report tasks.
+type ReportConfig struct {
+	Period string `json:"period,omitempty"` // "daily" (default) or "weekly"
+	// Push sends the report through the Slack and email channels, using
+	// Notify or the server defaults.
+	Push   bool          `json:"push"`
+	Notify *NotifyConfig `json:"notify,omitempty"`
+}
+
+func (e *TaskExecutor) handleReport(
+	ctx context.Context,
+	req *commonV1.ExecuteTaskRequest,
+) (*commonV1.ExecuteTaskResponse, error) {
+	cfg := ReportConfig{}
+	if len(req.GetPayload()) > 0 {
+		if err := json.Unmarshal(req.GetPayload(), &cfg); err != nil {
+			return &commonV1.ExecuteTaskResponse{
+				Success:          false,
+				PermanentFailure: true,
+				Message:          fmt.Sprintf("invalid payload: %v", err),
+			}, nil
+		}
+	}
+
+	report, err := e.orchestrator.buildReport(cfg.Period, time.Now(), tenantPtr(req.GetTenantId()))
+	if err != nil {
+		return &commonV1.ExecuteTaskResponse{
+			Success:          false,
+			PermanentFailure: true,
+			Message:          fmt.Sprintf("generate report: %v", err),
+		}, nil
+	}
+
+	e.events.Publish(withSchedule(ctx, cfg.Notify), EventReportGenerated, &ReportEvent{Report: report, push: cfg.Push})
+
+	return &commonV1.ExecuteTaskResponse{
+		Success: true,
+		Message: fmt.Sprintf("Generated %s report: %d backups, %d failed",
+			report.Period, report.TotalBackups, report.FailedBackups),
+	}, nil
+}
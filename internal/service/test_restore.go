@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+)
+
+// TestRestore proves a backup can be restored without touching real data:
+// the backup is imported into the validation tenant set with
+// BACKUP_TEST_RESTORE_TENANT_ID, and the tenant is then put back to a
+// snapshot taken just before, in FULL_SYNC mode so the imported rows are
+// removed again. Legacy per-module backup services have no FULL_SYNC mode,
+// so their modules are refused before anything is imported. The import is
+// recorded in the backup's restore history as kind "test".
+func (s *OrchestratorService) TestRestore(ctx context.Context, req *backupV1.TestRestoreRequest) (*backupV1.TestRestoreResponse, error) {
+	if !isPlatformAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "only a platform admin can run test restores")
+	}
+	tenantID := uint32(envInt("BACKUP_TEST_RESTORE_TENANT_ID", 0))
+	if tenantID == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "test restores are disabled: BACKUP_TEST_RESTORE_TENANT_ID is not set")
+	}
+	if !validPathElement(req.BackupId) {
+		return nil, status.Error(codes.InvalidArgument, "invalid backup ID")
+	}
+	if req.Target == nil || !validPathElement(req.Target.ModuleId) {
+		return nil, status.Error(codes.InvalidArgument, "a target with a module ID is required")
+	}
+	target := req.Target
+	ctx, done, err := s.jobs.Begin(ctx, "test restore")
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	ctx, unlock, err := s.storage.LockOperations(ctx, "test restore")
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	ctx, requestID := ensureRequestID(ctx)
+	s.log.Infof("Test restore of backup %s (full=%v) to module %s at %s in tenant %d (request=%s)",
+		req.BackupId, req.Full, target.ModuleId, target.GrpcEndpoint, tenantID, requestID)
+
+	var data []byte
+	if req.Full {
+		data, err = s.storage.LoadFullBackupModuleData(ctx, req.BackupId, target.ModuleId, req.Password)
+	} else {
+		data, err = s.storage.LoadModuleBackupData(ctx, req.BackupId, req.Password)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load backup data: %w", err)
+	}
+
+	ctx = withTargetTenant(ctx, tenantID)
+	snapshot, err := s.moduleClient.ExportBackup(ctx, target, &tenantID, false)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot validation tenant %d of %s: %w", tenantID, target.ModuleId, err)
+	}
+	defer snapshot.Close()
+	if !snapshot.Shared {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"%s only has a legacy backup service, which cannot clean up the validation tenant after the test", target.ModuleId)
+	}
+	snapshotData, err := snapshot.ReadData()
+	if err != nil {
+		return nil, fmt.Errorf("read snapshot of validation tenant %d of %s: %w", tenantID, target.ModuleId, err)
+	}
+
+	record := &backupV1.RestoreRecord{
+		BackupId:     req.BackupId,
+		Kind:         "test",
+		ModuleId:     target.ModuleId,
+		GrpcEndpoint: target.GrpcEndpoint,
+		RequestId:    requestID,
+	}
+	started := time.Now()
+	resp, importErr := s.moduleClient.ImportBackup(ctx, target, data, backupV1.RestoreMode_RESTORE_MODE_OVERWRITE)
+	if importErr == nil {
+		record.Success = resp.Success
+	}
+	s.recordRestore(ctx, record, started, importErr)
+
+	// Clean up after a failed import too, which may have written part of
+	// the backup.
+	out := &backupV1.TestRestoreResponse{TenantId: tenantID}
+	reset, resetErr := s.moduleClient.ResetBackup(ctx, target, snapshotData)
+	switch {
+	case resetErr != nil:
+		s.log.Errorf("Failed to clean up validation tenant %d of %s after test restore of %s: %v", tenantID, target.ModuleId, req.BackupId, resetErr)
+	case !reset.Success:
+		resetErr = fmt.Errorf("module reported failure: %v", reset.Warnings)
+		s.log.Errorf("Failed to clean up validation tenant %d of %s after test restore of %s: %v", tenantID, target.ModuleId, req.BackupId, resetErr)
+	default:
+		out.CleanedUp = true
+	}
+
+	if importErr != nil {
+		out.Error = fmt.Sprintf("import: %v", importErr)
+	} else {
+		out.Success = resp.Success
+		out.Warnings = resp.Warnings
+		out.Results = make([]*backupV1.EntityImportResult, len(resp.Results))
+		for i, r := range resp.Results {
+			out.Results[i] = &backupV1.EntityImportResult{
+				EntityType: r.EntityType,
+				Total:      r.Total,
+				Created:    r.Created,
+				Updated:    r.Updated,
+				Skipped:    r.Skipped,
+				Failed:     r.Failed,
+			}
+		}
+		if !resp.Success {
+			out.Error = "import: module reported failure"
+		}
+	}
+	if resetErr != nil {
+		if out.Error != "" {
+			out.Error += "; "
+		}
+		out.Error += fmt.Sprintf("cleanup: %v", resetErr)
+	}
+
+	s.log.Infof("Test restore completed: backup=%s module=%s success=%v cleanedUp=%v", req.BackupId, target.ModuleId, out.Success, out.CleanedUp)
+	return out, nil
+}
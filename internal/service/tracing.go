@@ -0,0 +1,92 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer records the orchestrator's internal phases. RPC handler spans come
+// from the kratos tracing middleware and the exporter from the bootstrap
+// "trace" config, so these spans nest under the incoming request.
+var tracer = otel.Tracer("backup")
+
+// startSpan starts an internal span for one phase of a backup or restore.
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// tracedCompress compresses data as c asks inside a "compress" span.
+func tracedCompress(ctx context.Context, c compression, data []byte) (_ []byte, err error) {
+	_, span := startSpan(ctx, "compress", attribute.String("compression", c.algo), attribute.Int("compression.level", c.effectiveLevel()), attribute.Int("bytes.in", len(data)))
+	defer func() { endSpan(span, err) }()
+
+	out, err := compressData(c, data)
+	span.SetAttributes(attribute.Int("bytes.out", len(out)))
+	return out, err
+}
+
+// tracedCompressFile streams the file at path through c's compressor into w,
+// inside a "compress" span, and returns the compressed size.
+func tracedCompressFile(ctx context.Context, c compression, path string, w io.Writer) (_ int64, err error) {
+	_, span := startSpan(ctx, "compress", attribute.String("compression", c.algo), attribute.Int("compression.level", c.effectiveLevel()))
+	defer func() { endSpan(span, err) }()
+
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	out := &countingWriter{w: w}
+	zw, err := newCompressWriter(c, out)
+	if err != nil {
+		return 0, err
+	}
+	in, err := io.Copy(zw, f)
+	if err != nil {
+		zw.Close()
+		return 0, err
+	}
+	if err := zw.Close(); err != nil {
+		return 0, err
+	}
+	span.SetAttributes(attribute.Int64("bytes.in", in), attribute.Int64("bytes.out", out.n))
+	return out.n, nil
+}
+
+// tracedReadAll drains a decompressing reader inside a "decompress" span.
+// A known size (0 if not) lets the result be allocated once instead of
+// growing through repeated copies.
+func tracedReadAll(ctx context.Context, r io.Reader, size int64) (_ []byte, err error) {
+	_, span := startSpan(ctx, "decompress")
+	defer func() { endSpan(span, err) }()
+
+	var buf bytes.Buffer
+	if size > 0 {
+		buf.Grow(int(size) + bytes.MinRead)
+	}
+	_, err = buf.ReadFrom(r)
+	span.SetAttributes(attribute.Int("bytes.out", buf.Len()))
+	return buf.Bytes(), err
+}
+
+// tracedEncrypt encrypts data inside an "encrypt" span.
+func tracedEncrypt(ctx context.Context, data []byte, password string) (_ []byte, err error) {
+	_, span := startSpan(ctx, "encrypt", attribute.Int("bytes.in", len(data)))
+	defer func() { endSpan(span, err) }()
+
+	return EncryptData(data, password)
+}
+
+// tracedDecrypt decrypts data in place inside a "decrypt" span.
+func tracedDecrypt(ctx context.Context, data []byte, password string) (_ []byte, err error) {
+	_, span := startSpan(ctx, "decrypt", attribute.Int("bytes.in", len(data)))
+	defer func() { endSpan(span, err) }()
+
+	return decryptInPlace(data, password)
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+)
+
+// GetBackupUsage returns every restore of a backup that reached a module:
+// which target, by whom and with what result. Modules restored as part of a
+// full restore are recorded under the full backup's ID. The history is kept
+// after the backup itself is deleted.
+func (s *OrchestratorService) GetBackupUsage(_ context.Context, req *backupV1.GetBackupUsageRequest) (*backupV1.GetBackupUsageResponse, error) {
+	if !validPathElement(req.BackupId) {
+		return nil, status.Error(codes.InvalidArgument, "invalid backup ID")
+	}
+	records, err := s.storage.ListRestoreRecords(req.BackupId)
+	if err != nil {
+		return nil, fmt.Errorf("get backup usage: %w", err)
+	}
+	return &backupV1.GetBackupUsageResponse{Restores: records}, nil
+}
+
+// recordRestore appends one module import to the backup's restore history.
+// A failure to record is logged but does not fail the restore, which has
+// already happened by then.
+func (s *OrchestratorService) recordRestore(ctx context.Context, rec *backupV1.RestoreRecord, started time.Time, importErr error) {
+	rec.RestoredBy = getUsernameFromContext(ctx)
+	rec.StartedAt = timestamppb.New(started)
+	rec.FinishedAt = timestamppb.Now()
+	if importErr != nil {
+		rec.Success = false
+		rec.Error = jobError(ctx, importErr).Error()
+	}
+	if err := s.storage.AppendRestoreRecord(rec); err != nil {
+		s.log.Warnf("Failed to record restore of %s to %s: %v", rec.BackupId, rec.ModuleId, err)
+	}
+}
@@ -0,0 +1,285 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	backupV1 "github.com/go-tangra/go-tangra-backup/gen/go/backup/service/v1"
+)
+
+// errWrongPassword means a data file whose stored checksum matched did not
+// decrypt: the data is intact, the password is not.
+var errWrongPassword = errors.New("wrong password")
+
+// VerifyBackup reads a module backup, or every completed module of a full
+// backup, end to end and records the outcome in its metadata as
+// last_verification. A wrong password fails the call instead of being
+// recorded against a backup that may be fine.
+func (s *OrchestratorService) VerifyBackup(ctx context.Context, req *backupV1.VerifyBackupRequest) (*backupV1.VerifyBackupResponse, error) {
+	if !validPathElement(req.BackupId) {
+		return nil, status.Error(codes.InvalidArgument, "invalid backup ID")
+	}
+
+	var modules []*backupV1.ModuleVerification
+	if req.Full {
+		full, err := s.storage.GetFullBackup(req.BackupId)
+		if err != nil {
+			return nil, fmt.Errorf("get full backup: %w", err)
+		}
+		if full.Status == "canceled" {
+			return nil, status.Errorf(codes.FailedPrecondition, "backup has status %q: there is no data to verify", full.Status)
+		}
+		if full.Encrypted && req.Password == "" {
+			return nil, status.Error(codes.InvalidArgument, "backup is encrypted: password required")
+		}
+		for _, mb := range full.ModuleBackups {
+			if mb.Status != "completed" {
+				continue
+			}
+			v, err := s.verifyModuleData(ctx, mb, req.Password, func() (*os.File, bool, error) {
+				return s.storage.OpenFullBackupModuleFile(req.BackupId, mb.ModuleId)
+			})
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "%s: %v", mb.ModuleId, err)
+			}
+			modules = append(modules, v)
+		}
+	} else {
+		info, err := s.storage.GetModuleBackup(req.BackupId)
+		if err != nil {
+			return nil, fmt.Errorf("get backup: %w", err)
+		}
+		if info.Status != "completed" {
+			return nil, status.Errorf(codes.FailedPrecondition, "backup has status %q: there is no data to verify", info.Status)
+		}
+		if info.Encrypted && req.Password == "" {
+			return nil, status.Error(codes.InvalidArgument, "backup is encrypted: password required")
+		}
+		v, err := s.verifyModuleData(ctx, info, req.Password, func() (*os.File, bool, error) {
+			return s.storage.OpenModuleBackupFile(req.BackupId)
+		})
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		modules = append(modules, v)
+	}
+
+	result := &backupV1.BackupVerification{
+		VerifiedAt: timestamppb.Now(),
+		VerifiedBy: getUsernameFromContext(ctx),
+		Ok:         true,
+	}
+	byModule := make(map[string]*backupV1.BackupVerification, len(modules))
+	for _, m := range modules {
+		mv := &backupV1.BackupVerification{VerifiedAt: result.VerifiedAt, VerifiedBy: result.VerifiedBy, Ok: m.Ok}
+		if !m.Ok {
+			last := m.Checks[len(m.Checks)-1]
+			mv.Error = last.Name + ": " + last.Detail
+			if result.Ok {
+				result.Ok = false
+				result.Error = m.ModuleId + ": " + mv.Error
+			}
+		}
+		byModule[m.ModuleId] = mv
+	}
+
+	var err error
+	if req.Full {
+		_, err = s.storage.UpdateFullBackup(req.BackupId, func(info *backupV1.FullBackupInfo) error {
+			info.LastVerification = result
+			for _, mb := range info.ModuleBackups {
+				if mv, ok := byModule[mb.ModuleId]; ok {
+					mb.LastVerification = mv
+				}
+			}
+			return nil
+		})
+	} else {
+		_, err = s.storage.UpdateModuleBackup(req.BackupId, func(info *backupV1.BackupInfo) error {
+			info.LastVerification = result
+			return nil
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("record verification: %w", err)
+	}
+
+	if result.Ok {
+		s.log.Infof("Verified backup %s (full=%v): %d modules OK", req.BackupId, req.Full, len(modules))
+	} else {
+		s.log.Errorf("Verification of backup %s (full=%v) failed: %s", req.BackupId, req.Full, result.Error)
+	}
+	return &backupV1.VerifyBackupResponse{Verification: result, Modules: modules}, nil
+}
+
+// verifyModuleData runs the checks on one stored module backup in order and
+// stops at the first failure, since later checks need the output of earlier
+// ones. open returns the backup's data file and whether it is encrypted.
+func (s *OrchestratorService) verifyModuleData(ctx context.Context, info *backupV1.BackupInfo, password string, open func() (*os.File, bool, error)) (*backupV1.ModuleVerification, error) {
+	v := &backupV1.ModuleVerification{ModuleId: info.ModuleId}
+	check := func(name, status, detail string) {
+		v.Checks = append(v.Checks, &backupV1.VerificationCheck{Name: name, Status: status, Detail: detail})
+	}
+	pass := func(name, detail string) { check(name, "PASS", detail) }
+	skip := func(name, detail string) { check(name, "SKIP", detail) }
+	fail := func(name string, err error) (*backupV1.ModuleVerification, error) {
+		check(name, "FAIL", err.Error())
+		return v, nil
+	}
+
+	var data []byte
+	if info.DeltaBaseId != "" {
+		// Rebuilding checks the stored checksum of every file in the chain
+		// and the export checksum after every delta.
+		rebuilt, err := s.storage.LoadModuleBackupData(ctx, info.Id, "")
+		if err != nil {
+			return fail("delta", err)
+		}
+		pass("delta", fmt.Sprintf("rebuilt over %s, %d deltas deep", info.DeltaBaseId, info.DeltaDepth))
+		data = rebuilt
+	} else {
+		f, encrypted, err := open()
+		if err != nil {
+			return fail("read", err)
+		}
+		raw, err := io.ReadAll(f)
+		f.Close()
+		if err != nil {
+			return fail("read", err)
+		}
+
+		storedOK := false
+		if info.StoredSha256 == "" {
+			skip("stored", "not recorded in metadata")
+		} else if err := verifyChecksum(checksum(raw), info.StoredSha256); err != nil {
+			return fail("stored", err)
+		} else {
+			storedOK = true
+			pass("stored", "sha256 of the stored file matches")
+		}
+
+		h, compressed, err := ParseFormatHeader(raw)
+		if err != nil {
+			return fail("format", err)
+		}
+		pass("format", fmt.Sprintf("version %d", h.Version))
+		if encrypted {
+			if compressed, err = DecryptData(compressed, password); err != nil {
+				if storedOK {
+					return nil, errWrongPassword
+				}
+				return fail("decrypt", err)
+			}
+			pass("decrypt", "")
+		} else {
+			skip("decrypt", "not encrypted")
+		}
+
+		// Reading to EOF verifies the gzip trailer CRC and length, or the
+		// zstd frame checksums.
+		algo := DetectCompression(compressed)
+		if data, err = decompressData(compressed); err != nil {
+			return fail("decompress", err)
+		}
+		pass("decompress", fmt.Sprintf("%s, %d bytes uncompressed", algo, len(data)))
+	}
+
+	if !json.Valid(data) {
+		return fail("json", fmt.Errorf("export is not valid JSON"))
+	}
+	pass("json", "")
+
+	if info.Sha256 == "" {
+		skip("checksum", "not recorded in metadata")
+	} else if got := checksum(data); got != info.Sha256 {
+		return fail("checksum", fmt.Errorf("sha256 %s, metadata says %s", got, info.Sha256))
+	} else {
+		pass("checksum", "sha256 matches")
+	}
+
+	switch {
+	case info.SizeBytes == 0:
+		skip("size", "not recorded in metadata")
+	case int64(len(data)) != info.SizeBytes:
+		return fail("size", fmt.Errorf("%d bytes, metadata says %d", len(data), info.SizeBytes))
+	default:
+		pass("size", "")
+	}
+
+	switch n, err := checkEntityCounts(data, info.EntityCounts); {
+	case err != nil:
+		return fail("entity_counts", err)
+	case len(info.EntityCounts) == 0:
+		skip("entity_counts", "not recorded in metadata")
+	case n == 0:
+		skip("entity_counts", "the export lists no entity type by name")
+	default:
+		pass("entity_counts", fmt.Sprintf("%d of %d entity types counted", n, len(info.EntityCounts)))
+	}
+
+	v.Ok = true
+	return v, nil
+}
+
+// checkEntityCounts compares the entity counts recorded in the metadata with
+// the export and returns how many it could check. Exports are defined by
+// each module, so an entity type is only counted when the export is a JSON
+// object holding it as a top-level array under the same name.
+func checkEntityCounts(data []byte, counts map[string]int64) (int, error) {
+	if len(counts) == 0 {
+		return 0, nil
+	}
+	var top map[string]json.RawMessage
+	if json.Unmarshal(data, &top) != nil {
+		return 0, nil
+	}
+
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	checked := 0
+	for _, name := range names {
+		got, ok := jsonArrayLen(top[name])
+		if !ok {
+			continue
+		}
+		if got != counts[name] {
+			return checked, fmt.Errorf("%s: %d in the export, metadata says %d", name, got, counts[name])
+		}
+		checked++
+	}
+	return checked, nil
+}
+
+// jsonArrayLen counts the elements of raw if it is a JSON array.
+func jsonArrayLen(raw json.RawMessage) (int64, bool) {
+	if len(raw) == 0 {
+		return 0, false
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+		return 0, false
+	}
+	var n int64
+	for dec.More() {
+		var elem json.RawMessage
+		if err := dec.Decode(&elem); err != nil {
+			return 0, false
+		}
+		n++
+	}
+	return n, true
+}
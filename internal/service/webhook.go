@@ -0,0 +1,143 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/go-tangra/go-tangra-common/eventbus"
+)
+
+// WebhookConfig describes one webhook endpoint.
+type WebhookConfig struct {
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	// Events limits the webhook to these event types; empty means all.
+	Events []string `json:"events,omitempty"`
+}
+
+// webhookSink POSTs lifecycle events as JSON to a webhook, retrying
+// transient failures with exponential backoff. Delivery happens in the
+// background so a slow receiver never holds up other sinks.
+type webhookSink struct {
+	log    *log.Helper
+	cfg    WebhookConfig
+	client *http.Client
+	retry  RetryPolicy
+}
+
+// newWebhookSinks reads the webhook list from the environment:
+//
+//	BACKUP_WEBHOOKS                          JSON array, e.g. [{"url":"https://...","headers":{"Authorization":"..."},"events":["backup.failed"]}]
+//	BACKUP_WEBHOOK_RETRY_ATTEMPTS            total attempts (default 5)
+//	BACKUP_WEBHOOK_RETRY_INITIAL_BACKOFF_MS  default 1000, doubled per attempt
+//	BACKUP_WEBHOOK_RETRY_MAX_BACKOFF_MS      default 60000
+//	BACKUP_WEBHOOK_TIMEOUT_SECONDS           per-request timeout (default 10)
+func newWebhookSinks(l *log.Helper) []*webhookSink {
+	raw := os.Getenv("BACKUP_WEBHOOKS")
+	if raw == "" {
+		return nil
+	}
+	var cfgs []WebhookConfig
+	if err := json.Unmarshal([]byte(raw), &cfgs); err != nil {
+		l.Warnf("Ignoring invalid BACKUP_WEBHOOKS: %v", err)
+		return nil
+	}
+
+	retry := RetryPolicy{
+		MaxAttempts:    envInt("BACKUP_WEBHOOK_RETRY_ATTEMPTS", 5),
+		InitialBackoff: time.Duration(envInt("BACKUP_WEBHOOK_RETRY_INITIAL_BACKOFF_MS", 1000)) * time.Millisecond,
+		MaxBackoff:     time.Duration(envInt("BACKUP_WEBHOOK_RETRY_MAX_BACKOFF_MS", 60000)) * time.Millisecond,
+	}
+	if retry.MaxAttempts < 1 {
+		retry.MaxAttempts = 1
+	}
+	client := &http.Client{Timeout: time.Duration(envInt("BACKUP_WEBHOOK_TIMEOUT_SECONDS", 10)) * time.Second}
+
+	var sinks []*webhookSink
+	for _, cfg := range cfgs {
+		if cfg.URL == "" {
+			l.Warn("Ignoring webhook without url")
+			continue
+		}
+		if len(cfg.Events) == 0 {
+			cfg.Events = allEventTypes
+		}
+		l.Infof("Sending %v events to webhook %s", cfg.Events, redactURL(cfg.URL))
+		sinks = append(sinks, &webhookSink{log: l, cfg: cfg, client: client, retry: retry})
+	}
+	return sinks
+}
+
+// Handle implements eventbus.Handler.
+func (w *webhookSink) Handle(_ context.Context, event *eventbus.Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal event: %w", err)
+	}
+	go w.deliver(event, body)
+	return nil
+}
+
+func (w *webhookSink) deliver(event *eventbus.Event, body []byte) {
+	for attempt := 1; ; attempt++ {
+		retryable, err := w.post(event, body)
+		if err == nil {
+			return
+		}
+		if !retryable || attempt >= w.retry.MaxAttempts {
+			w.log.Errorf("Webhook %s: giving up on %s event %s after %d attempts: %v",
+				redactURL(w.cfg.URL), event.Type, event.ID, attempt, err)
+			return
+		}
+		delay := w.retry.backoff(attempt)
+		w.log.Warnf("Webhook %s: attempt %d failed, retrying in %s: %v",
+			redactURL(w.cfg.URL), attempt, delay.Round(time.Millisecond), err)
+		time.Sleep(delay)
+	}
+}
+
+// post sends one attempt and reports whether a failure is worth retrying:
+// network errors, 429 and 5xx are; other 4xx responses are not.
+func (w *webhookSink) post(event *eventbus.Event, body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, w.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Backup-Event", event.Type)
+	req.Header.Set("X-Backup-Event-Id", event.ID)
+	for k, v := range w.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	err = fmt.Errorf("unexpected status %s", resp.Status)
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500, err
+}
+
+// redactURL strips the path and query, which often embed tokens, for logs.
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return "<invalid url>"
+	}
+	return u.Scheme + "://" + u.Host
+}